@@ -0,0 +1,88 @@
+package addon
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/match"
+)
+
+// BlockList reply the requests matching the patterns with StatusCode and Body instead of sending them to server,
+// such as to block ads and trackers.
+//
+// A pattern without "/" matches the hostname, such as "ads.example.com" or "*.doubleclick.net".
+// A pattern with "/" matches the hostname and path, such as "example.com/track/*".
+//
+// The CONNECT of a matched host which is not intercepted is refused, the requests of intercepted hosts are matched
+// one by one. The reply goes through the Response hooks of the other addons, so the blocked flows are still logged.
+type BlockList struct {
+	proxy.BaseAddon
+	StatusCode int
+	Body       []byte // http.StatusText of StatusCode if empty
+	hosts      []string
+	paths      []string
+}
+
+func NewBlockList(patterns []string, statusCode int) *BlockList {
+	bl := &BlockList{StatusCode: statusCode}
+	for _, pattern := range patterns {
+		if strings.Contains(pattern, "/") {
+			bl.paths = append(bl.paths, pattern)
+		} else {
+			bl.hosts = append(bl.hosts, pattern)
+		}
+	}
+	return bl
+}
+
+func (bl *BlockList) Requestheaders(f *proxy.Flow) {
+	req := f.Request
+	hostname := req.URL.Hostname()
+	if req.Method == "CONNECT" {
+		// the path is unknown until the requests are intercepted
+		if f.ConnContext != nil && f.ConnContext.Intercept {
+			return
+		}
+		if bl.matchHost(hostname) {
+			log.Infof("block list: refuse connect %v", req.URL.Host)
+			f.Response = bl.response()
+		}
+		return
+	}
+	if bl.matchHost(hostname) || bl.matchPath(hostname+req.URL.Path) {
+		log.Infof("block list: block %v %v", req.Method, req.URL)
+		f.Response = bl.response()
+	}
+}
+
+func (bl *BlockList) matchHost(hostname string) bool {
+	for _, pattern := range bl.hosts {
+		if match.Match(hostname, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func (bl *BlockList) matchPath(s string) bool {
+	for _, pattern := range bl.paths {
+		if match.Match(s, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func (bl *BlockList) response() *proxy.Response {
+	body := bl.Body
+	if len(body) == 0 {
+		body = []byte(http.StatusText(bl.StatusCode))
+	}
+	return &proxy.Response{
+		StatusCode: bl.StatusCode,
+		Header:     http.Header{"Content-Type": {"text/plain; charset=utf-8"}},
+		Body:       body,
+	}
+}
@@ -0,0 +1,51 @@
+package addon
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+func TestBlockList(t *testing.T) {
+	bl := NewBlockList([]string{"*.doubleclick.net", "example.com/track/*"}, http.StatusForbidden)
+
+	cases := []struct {
+		method  string
+		rawurl  string
+		blocked bool
+	}{
+		{"GET", "http://ad.doubleclick.net/pixel", true},
+		{"GET", "https://example.com/track/1", true},
+		{"GET", "https://example.com/page", false},
+		{"GET", "http://doubleclick.net.example.com/", false},
+		{"CONNECT", "//ad.doubleclick.net:443", true},
+		{"CONNECT", "//example.com:443", false},
+	}
+	for _, c := range cases {
+		u, _ := url.Parse(c.rawurl)
+		f := &proxy.Flow{
+			ConnContext: &proxy.ConnContext{},
+			Request:     &proxy.Request{Method: c.method, URL: u, Header: http.Header{}},
+		}
+		bl.Requestheaders(f)
+		if blocked := f.Response != nil; blocked != c.blocked {
+			t.Fatalf("%v %v: expected blocked %v, but got %v", c.method, c.rawurl, c.blocked, blocked)
+		}
+		if c.blocked && (f.Response.StatusCode != http.StatusForbidden || string(f.Response.Body) != "Forbidden") {
+			t.Fatalf("unexpected response %v %q", f.Response.StatusCode, f.Response.Body)
+		}
+	}
+
+	// the requests of intercepted hosts are matched instead
+	u, _ := url.Parse("//ad.doubleclick.net:443")
+	f := &proxy.Flow{
+		ConnContext: &proxy.ConnContext{Intercept: true},
+		Request:     &proxy.Request{Method: "CONNECT", URL: u, Header: http.Header{}},
+	}
+	bl.Requestheaders(f)
+	if f.Response != nil {
+		t.Fatal("expected intercepted connect not refused")
+	}
+}
@@ -0,0 +1,112 @@
+package addon
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/match"
+	"golang.org/x/net/html/charset"
+)
+
+// policies for response bodies not valid utf-8, BodyRewriteRule.InvalidUTF8
+const (
+	InvalidUTF8Skip      = "skip"      // not rewrite the body, the default
+	InvalidUTF8Bytes     = "bytes"     // apply the regexp to the raw bytes, for binary safe patterns
+	InvalidUTF8Transcode = "transcode" // decode from the charset of Content-Type or html meta, rewrite, then encode back
+)
+
+// BodyRewriteRule replace the matches of Pattern in the decoded response body with Replacement, as regexp.ReplaceAll.
+type BodyRewriteRule struct {
+	URLFilter   string // pattern of the request url, such as "*.example.com/page*", empty matches all
+	Pattern     string // regexp
+	Replacement string // $1 for the submatch
+	InvalidUTF8 string // InvalidUTF8Skip, InvalidUTF8Bytes or InvalidUTF8Transcode
+}
+
+type bodyRewriteRule struct {
+	BodyRewriteRule
+	re *regexp.Regexp
+}
+
+// BodyRewrite rewrite response bodies with regexp rules, applied in order.
+// A Shift-JIS page, for example, is rewritten safely with InvalidUTF8Transcode.
+type BodyRewrite struct {
+	proxy.BaseAddon
+	rules []*bodyRewriteRule
+}
+
+func NewBodyRewrite(rules []BodyRewriteRule) (*BodyRewrite, error) {
+	r := &BodyRewrite{}
+	for _, rule := range rules {
+		switch rule.InvalidUTF8 {
+		case "":
+			rule.InvalidUTF8 = InvalidUTF8Skip
+		case InvalidUTF8Skip, InvalidUTF8Bytes, InvalidUTF8Transcode:
+		default:
+			return nil, fmt.Errorf("body rewrite: invalid InvalidUTF8 %q", rule.InvalidUTF8)
+		}
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("body rewrite: %w", err)
+		}
+		r.rules = append(r.rules, &bodyRewriteRule{BodyRewriteRule: rule, re: re})
+	}
+	return r, nil
+}
+
+func (r *BodyRewrite) Response(f *proxy.Flow) {
+	var body []byte
+	rewritten := false
+	for _, rule := range r.rules {
+		if rule.URLFilter != "" && !match.Match(f.Request.URL.String(), rule.URLFilter) {
+			continue
+		}
+		if body == nil {
+			var err error
+			if body, err = f.Response.DecodedBody(); err != nil || len(body) == 0 {
+				return
+			}
+		}
+		if out, ok := rule.rewrite(body, f.Response.Header); ok {
+			body = out
+			rewritten = true
+		}
+	}
+	if !rewritten {
+		return
+	}
+	f.Response.Body = body
+	f.Response.Header.Del("Content-Encoding")
+	f.Response.Header.Set("Content-Length", strconv.Itoa(len(body)))
+}
+
+func (rule *bodyRewriteRule) rewrite(body []byte, header http.Header) ([]byte, bool) {
+	if utf8.Valid(body) || rule.InvalidUTF8 == InvalidUTF8Bytes {
+		return rule.re.ReplaceAll(body, []byte(rule.Replacement)), true
+	}
+	if rule.InvalidUTF8 == InvalidUTF8Skip {
+		return nil, false
+	}
+
+	enc, name, _ := charset.DetermineEncoding(body, header.Get("Content-Type"))
+	if name == "utf-8" {
+		log.Debugf("body rewrite: skip invalid utf-8 body")
+		return nil, false
+	}
+	text, err := enc.NewDecoder().Bytes(body)
+	if err != nil {
+		log.Warnf("body rewrite: decode %v: %v", name, err)
+		return nil, false
+	}
+	out, err := enc.NewEncoder().Bytes(rule.re.ReplaceAll(text, []byte(rule.Replacement)))
+	if err != nil {
+		log.Warnf("body rewrite: encode %v: %v", name, err)
+		return nil, false
+	}
+	return out, true
+}
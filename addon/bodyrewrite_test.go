@@ -0,0 +1,67 @@
+package addon
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+	"golang.org/x/net/html/charset"
+)
+
+func TestBodyRewriteInvalidUTF8(t *testing.T) {
+	sjis, _ := charset.Lookup("shift_jis")
+	page, err := sjis.NewEncoder().Bytes([]byte("<p>こんにちは 世界</p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rewrite := func(policy, pattern, replacement string, body []byte, contentType string) []byte {
+		t.Helper()
+		r, err := NewBodyRewrite([]BodyRewriteRule{{Pattern: pattern, Replacement: replacement, InvalidUTF8: policy}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, _ := url.Parse("https://example.com/")
+		f := &proxy.Flow{
+			Request:  &proxy.Request{Method: "GET", URL: u, Header: http.Header{}},
+			Response: &proxy.Response{StatusCode: 200, Header: http.Header{"Content-Type": {contentType}}, Body: body},
+		}
+		r.Response(f)
+		return f.Response.Body
+	}
+
+	t.Run("utf-8", func(t *testing.T) {
+		if body := rewrite("", "world", "go", []byte("hello world"), "text/plain"); string(body) != "hello go" {
+			t.Fatalf("unexpected body %q", body)
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		if body := rewrite(InvalidUTF8Skip, "世界", "日本", page, "text/html; charset=shift_jis"); string(body) != string(page) {
+			t.Fatalf("expected body untouched, got %q", body)
+		}
+	})
+
+	t.Run("bytes", func(t *testing.T) {
+		body := rewrite(InvalidUTF8Bytes, "<p>", "<div>", page, "text/html; charset=shift_jis")
+		if string(body[:5]) != "<div>" || string(body[5:]) != string(page[3:]) {
+			t.Fatalf("unexpected body %q", body)
+		}
+	})
+
+	t.Run("transcode", func(t *testing.T) {
+		body := rewrite(InvalidUTF8Transcode, "世界", "日本", page, "text/html; charset=shift_jis")
+		text, err := sjis.NewDecoder().Bytes(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != "<p>こんにちは 日本</p>" {
+			t.Fatalf("unexpected body %q", text)
+		}
+	})
+
+	if _, err := NewBodyRewrite([]BodyRewriteRule{{Pattern: "a", InvalidUTF8: "latin1"}}); err == nil {
+		t.Fatal("expected error of unknown policy")
+	}
+}
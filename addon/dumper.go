@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"unicode"
 
 	"github.com/lqqyt2423/go-mitmproxy/proxy"
@@ -15,8 +16,10 @@ import (
 
 type Dumper struct {
 	proxy.BaseAddon
-	out   io.Writer
-	level int // 0: header 1: header + body
+	out    io.Writer
+	closer io.Closer // the file opened by NewDumperWithFilename
+	level  int       // 0: header 1: header + body
+	wg     sync.WaitGroup
 }
 
 func NewDumper(out io.Writer, level int) *Dumper {
@@ -31,16 +34,29 @@ func NewDumperWithFilename(filename string, level int) *Dumper {
 	if err != nil {
 		panic(err)
 	}
-	return NewDumper(out, level)
+	d := NewDumper(out, level)
+	d.closer = out
+	return d
 }
 
 func (d *Dumper) Requestheaders(f *proxy.Flow) {
+	d.wg.Add(1)
 	go func() {
+		defer d.wg.Done()
 		<-f.Done()
 		d.dump(f)
 	}()
 }
 
+// Stop wait the flows to be dumped, and close the file opened by NewDumperWithFilename
+func (d *Dumper) Stop() error {
+	d.wg.Wait()
+	if d.closer != nil {
+		return d.closer.Close()
+	}
+	return nil
+}
+
 // call when <-f.Done()
 func (d *Dumper) dump(f *proxy.Flow) {
 	// 参考 httputil.DumpRequest
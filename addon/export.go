@@ -0,0 +1,30 @@
+package addon
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+// copy of flows ordered by the start time of request with ties broken by flow id,
+// flows captured concurrently are exported in the same order every time
+func sortFlows(flows []*proxy.Flow) []*proxy.Flow {
+	sorted := slices.Clone(flows)
+	slices.SortStableFunc(sorted, func(a, b *proxy.Flow) int {
+		// flows without request are skipped by the exporters, keep them last
+		switch {
+		case a.Request == nil && b.Request == nil:
+			return 0
+		case a.Request == nil:
+			return 1
+		case b.Request == nil:
+			return -1
+		}
+		if c := a.Request.StartAt.Compare(b.Request.StartAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Id, b.Id)
+	})
+	return sorted
+}
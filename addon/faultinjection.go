@@ -0,0 +1,122 @@
+package addon
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/match"
+)
+
+// Fault the requests matching Pattern are replied with StatusCode instead of sending them to server, when its
+// triggers are met. A fault without triggers fails all the matched requests.
+//
+// The triggers model a server which degrades over time and recovers, such as After: 5 fails the requests after the
+// first 5 succeeded, adding Duration: 30 * time.Second makes it a 30s outage, after which the requests succeed again.
+type Fault struct {
+	Pattern    string        // same as the patterns of BlockList, such as "api.example.com" or "example.com/orders/*"
+	StatusCode int           // 503 if 0
+	Body       []byte        // http.StatusText of StatusCode if empty
+	Delay      time.Duration // delay of the reply, such as to simulate a timeout of server
+
+	After         int           // the first After matched requests succeed
+	AfterDuration time.Duration // the matched requests succeed within AfterDuration since the first of them
+	Count         int           // at most Count requests fail then the others succeed, 0 for no limit
+	Duration      time.Duration // the requests fail within Duration since the first failed, then succeed, 0 for no limit
+
+	mu      sync.Mutex
+	matched int
+	failed  int
+	first   time.Time // of the matched requests
+	start   time.Time // of the failed requests
+}
+
+// Reset the state of the triggers, the fault starts over as no request was matched
+func (fault *Fault) Reset() {
+	fault.mu.Lock()
+	defer fault.mu.Unlock()
+	fault.matched = 0
+	fault.failed = 0
+	fault.first = time.Time{}
+	fault.start = time.Time{}
+}
+
+func (fault *Fault) match(hostname, path string) bool {
+	if strings.Contains(fault.Pattern, "/") {
+		return match.Match(hostname+path, fault.Pattern)
+	}
+	return match.Match(hostname, fault.Pattern)
+}
+
+// count the matched request, return whether it should fail
+func (fault *Fault) trigger(now time.Time) bool {
+	fault.mu.Lock()
+	defer fault.mu.Unlock()
+	fault.matched++
+	if fault.first.IsZero() {
+		fault.first = now
+	}
+	if fault.matched <= fault.After || now.Sub(fault.first) < fault.AfterDuration {
+		return false
+	}
+	if fault.Count > 0 && fault.failed >= fault.Count {
+		return false
+	}
+	if fault.start.IsZero() {
+		fault.start = now
+	}
+	if fault.Duration > 0 && now.Sub(fault.start) >= fault.Duration {
+		return false
+	}
+	fault.failed++
+	return true
+}
+
+func (fault *Fault) response() *proxy.Response {
+	statusCode := fault.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusServiceUnavailable
+	}
+	body := fault.Body
+	if len(body) == 0 {
+		body = []byte(http.StatusText(statusCode))
+	}
+	return &proxy.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": {"text/plain; charset=utf-8"}},
+		Body:       body,
+		Delay:      fault.Delay,
+	}
+}
+
+// FaultInjection reply the requests with the faults to test the retry and recovery of clients.
+// The first fault matching the request is used, the requests of CONNECT are not matched.
+type FaultInjection struct {
+	proxy.BaseAddon
+	Faults []*Fault
+}
+
+func NewFaultInjection(faults ...*Fault) *FaultInjection {
+	return &FaultInjection{Faults: faults}
+}
+
+func (fi *FaultInjection) Requestheaders(f *proxy.Flow) {
+	req := f.Request
+	if req.Method == "CONNECT" {
+		return
+	}
+	hostname := req.URL.Hostname()
+	for _, fault := range fi.Faults {
+		if !fault.match(hostname, req.URL.Path) {
+			continue
+		}
+		if fault.trigger(time.Now()) {
+			log.Infof("fault injection: fail %v %v", req.Method, req.URL)
+			f.Response = fault.response()
+		}
+		return
+	}
+}
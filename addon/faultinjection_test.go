@@ -0,0 +1,81 @@
+package addon
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+func TestFaultInjection(t *testing.T) {
+	send := func(fi *FaultInjection, rawurl string) *proxy.Response {
+		u, _ := url.Parse(rawurl)
+		f := &proxy.Flow{Request: &proxy.Request{Method: "GET", URL: u, Header: http.Header{}}}
+		fi.Requestheaders(f)
+		return f.Response
+	}
+
+	t.Run("after n requests", func(t *testing.T) {
+		fi := NewFaultInjection(&Fault{Pattern: "example.com/orders/*", After: 5})
+		for i := 0; i < 8; i++ {
+			res := send(fi, "http://example.com/orders/1")
+			if failed := res != nil; failed != (i >= 5) {
+				t.Fatalf("request %v: expected failed %v, but got %v", i, i >= 5, failed)
+			}
+		}
+		if res := send(fi, "http://example.com/orders/1"); res.StatusCode != 503 || string(res.Body) != "Service Unavailable" {
+			t.Fatalf("unexpected response %v %q", res.StatusCode, res.Body)
+		}
+		if res := send(fi, "http://example.com/users/1"); res != nil {
+			t.Fatal("expected the other path not matched")
+		}
+
+		fi.Faults[0].Reset()
+		if res := send(fi, "http://example.com/orders/1"); res != nil {
+			t.Fatal("expected succeeded after reset")
+		}
+	})
+
+	t.Run("count", func(t *testing.T) {
+		fi := NewFaultInjection(&Fault{Pattern: "example.com", After: 1, Count: 2, StatusCode: 500})
+		var failed []bool
+		for i := 0; i < 5; i++ {
+			res := send(fi, "http://example.com/")
+			failed = append(failed, res != nil && res.StatusCode == 500)
+		}
+		if want := []bool{false, true, true, false, false}; !slices.Equal(failed, want) {
+			t.Fatalf("expected %v, but got %v", want, failed)
+		}
+	})
+
+	t.Run("outage window", func(t *testing.T) {
+		fault := &Fault{Pattern: "example.com", AfterDuration: 10 * time.Second, Duration: 30 * time.Second}
+		start := time.Now()
+		cases := []struct {
+			at     time.Duration
+			failed bool
+		}{
+			{0, false},
+			{5 * time.Second, false},
+			{10 * time.Second, true},
+			{39 * time.Second, true},
+			{40 * time.Second, false},
+			{60 * time.Second, false},
+		}
+		for _, c := range cases {
+			if failed := fault.trigger(start.Add(c.at)); failed != c.failed {
+				t.Fatalf("at %v: expected failed %v, but got %v", c.at, c.failed, failed)
+			}
+		}
+	})
+
+	t.Run("delay", func(t *testing.T) {
+		fi := NewFaultInjection(&Fault{Pattern: "*", Delay: time.Second})
+		if res := send(fi, "http://example.com/"); res.Delay != time.Second {
+			t.Fatalf("expected delay, but got %v", res.Delay)
+		}
+	})
+}
@@ -0,0 +1,208 @@
+package addon
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+// HAR 1.2, http://www.softwareishard.com/blog/har-12-spec/
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harCookie    `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harCookie    `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harCookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	Expires  string `json:"expires,omitempty"`
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// ExportHAR write the flows as a HAR 1.2 file.
+// Headers with multiple values, such as Set-Cookie, are kept as separate entries, and so are the cookies.
+// Response bodies are decoded, binary ones are in base64. CONNECT flows are skipped.
+// Entries are ordered by the start time of request, then by flow id.
+func ExportHAR(w io.Writer, flows []*proxy.Flow) error {
+	har := &harFile{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: "go-mitmproxy", Version: proxy.Version},
+		Entries: make([]harEntry, 0),
+	}}
+
+	for _, f := range sortFlows(flows) {
+		if f.Request == nil || f.Request.Method == http.MethodConnect {
+			continue
+		}
+		duration := float64(f.Duration()) / float64(time.Millisecond)
+		entry := harEntry{
+			StartedDateTime: f.Request.StartAt.Format(time.RFC3339Nano),
+			Time:            duration,
+			Request:         newHarRequest(f.Request),
+			Response:        harResponse{Cookies: make([]harCookie, 0), Headers: make([]harNameValue, 0), HeadersSize: -1, BodySize: -1},
+			// only the total duration is known
+			Timings: harTimings{Wait: duration},
+		}
+		if f.Response != nil {
+			entry.Response = newHarResponse(f.Request, f.Response)
+		}
+		har.Log.Entries = append(har.Log.Entries, entry)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(har)
+}
+
+func newHarRequest(r *proxy.Request) harRequest {
+	req := harRequest{
+		Method:      r.Method,
+		URL:         r.URL.String(),
+		HTTPVersion: r.Proto,
+		Cookies:     make([]harCookie, 0),
+		Headers:     harHeaders(r.Header),
+		QueryString: make([]harNameValue, 0),
+		HeadersSize: -1,
+		BodySize:    len(r.Body),
+	}
+	for _, c := range (&http.Request{Header: r.Header}).Cookies() {
+		req.Cookies = append(req.Cookies, harCookie{Name: c.Name, Value: c.Value})
+	}
+	query := r.URL.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range query[k] {
+			req.QueryString = append(req.QueryString, harNameValue{Name: k, Value: v})
+		}
+	}
+	if len(r.Body) > 0 {
+		req.PostData = &harPostData{MimeType: r.Header.Get("Content-Type"), Text: string(r.Body)}
+	}
+	return req
+}
+
+func newHarResponse(req *proxy.Request, r *proxy.Response) harResponse {
+	res := harResponse{
+		Status:      r.StatusCode,
+		StatusText:  http.StatusText(r.StatusCode),
+		HTTPVersion: req.Proto,
+		Cookies:     make([]harCookie, 0),
+		Headers:     harHeaders(r.Header),
+		Content:     harContent{MimeType: r.Header.Get("Content-Type")},
+		RedirectURL: r.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    len(r.Body),
+	}
+	// each Set-Cookie header is a cookie
+	for _, c := range (&http.Response{Header: r.Header}).Cookies() {
+		cookie := harCookie{Name: c.Name, Value: c.Value, Path: c.Path, Domain: c.Domain, HTTPOnly: c.HttpOnly, Secure: c.Secure}
+		if !c.Expires.IsZero() {
+			cookie.Expires = c.Expires.UTC().Format(time.RFC3339)
+		}
+		res.Cookies = append(res.Cookies, cookie)
+	}
+	if body, err := r.DecodedBody(); err == nil && len(body) > 0 {
+		res.Content.Size = len(body)
+		if utf8.Valid(body) {
+			res.Content.Text = string(body)
+		} else {
+			res.Content.Text = base64.StdEncoding.EncodeToString(body)
+			res.Content.Encoding = "base64"
+		}
+	}
+	return res
+}
+
+func harHeaders(header http.Header) []harNameValue {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	headers := make([]harNameValue, 0, len(keys))
+	for _, k := range keys {
+		for _, v := range header[k] {
+			headers = append(headers, harNameValue{Name: k, Value: v})
+		}
+	}
+	return headers
+}
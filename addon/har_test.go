@@ -0,0 +1,102 @@
+package addon
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+func TestExportHAR(t *testing.T) {
+	u, _ := url.Parse("https://example.com/login?next=%2F")
+	header := http.Header{"Content-Type": {"text/plain"}}
+	for _, c := range []string{"a=1; Path=/", "b=2; HttpOnly", "c=3; Secure", "d=4; Domain=example.com", "e=5"} {
+		header.Add("Set-Cookie", c)
+	}
+	flows := []*proxy.Flow{
+		{Request: &proxy.Request{Method: "CONNECT", URL: &url.URL{Host: "example.com:443"}, Header: http.Header{}}},
+		{
+			Request:  &proxy.Request{Method: "POST", URL: u, Proto: "HTTP/1.1", Header: http.Header{"Cookie": {"s=x; t=y"}}, Body: []byte("user=a")},
+			Response: &proxy.Response{StatusCode: 200, Header: header, Body: []byte("ok")},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := ExportHAR(buf, flows); err != nil {
+		t.Fatal(err)
+	}
+	har := new(harFile)
+	if err := json.Unmarshal(buf.Bytes(), har); err != nil {
+		t.Fatal(err)
+	}
+	if har.Log.Version != "1.2" || len(har.Log.Entries) != 1 {
+		t.Fatalf("unexpected log %+v", har.Log)
+	}
+
+	entry := har.Log.Entries[0]
+	setCookies := 0
+	for _, h := range entry.Response.Headers {
+		if h.Name == "Set-Cookie" {
+			setCookies++
+		}
+	}
+	if setCookies != 5 || len(entry.Response.Cookies) != 5 {
+		t.Fatalf("expected 5 Set-Cookie headers and cookies, got %v and %+v", setCookies, entry.Response.Cookies)
+	}
+	if c := entry.Response.Cookies[1]; c.Name != "b" || c.Value != "2" || !c.HTTPOnly {
+		t.Fatalf("unexpected cookie %+v", c)
+	}
+	if entry.Response.Content.Text != "ok" || entry.Response.Content.Size != 2 {
+		t.Fatalf("unexpected content %+v", entry.Response.Content)
+	}
+	if len(entry.Request.Cookies) != 2 || len(entry.Request.QueryString) != 1 || entry.Request.QueryString[0].Value != "/" {
+		t.Fatalf("unexpected request %+v", entry.Request)
+	}
+	if entry.Request.PostData == nil || entry.Request.PostData.Text != "user=a" {
+		t.Fatalf("unexpected post data %+v", entry.Request.PostData)
+	}
+}
+
+func TestExportHAROrder(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newFlow := func(id string, at time.Duration) *proxy.Flow {
+		u, _ := url.Parse("https://example.com/" + id)
+		f := &proxy.Flow{Request: &proxy.Request{Method: "GET", URL: u, Header: http.Header{}, StartAt: start.Add(at)}}
+		f.Id = id
+		return f
+	}
+	flows := []*proxy.Flow{newFlow("c", time.Second), newFlow("b", 0), {}, newFlow("a", time.Second)}
+
+	var exports []string
+	for _, order := range [][]int{{0, 1, 2, 3}, {3, 2, 1, 0}} {
+		shuffled := make([]*proxy.Flow, 0, len(flows))
+		for _, i := range order {
+			shuffled = append(shuffled, flows[i])
+		}
+		buf := new(bytes.Buffer)
+		if err := ExportHAR(buf, shuffled); err != nil {
+			t.Fatal(err)
+		}
+		exports = append(exports, buf.String())
+	}
+	if exports[0] != exports[1] {
+		t.Fatal("expected the same export for flows in different orders")
+	}
+
+	har := new(harFile)
+	if err := json.Unmarshal([]byte(exports[0]), har); err != nil {
+		t.Fatal(err)
+	}
+	var urls []string
+	for _, entry := range har.Log.Entries {
+		urls = append(urls, entry.Request.URL)
+	}
+	if want := []string{"https://example.com/b", "https://example.com/a", "https://example.com/c"}; !slices.Equal(urls, want) {
+		t.Fatalf("expected %v, got %v", want, urls)
+	}
+}
@@ -0,0 +1,189 @@
+package addon
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+	log "github.com/sirupsen/logrus"
+)
+
+// JSONLinesOptions of NewJSONLinesDumper
+type JSONLinesOptions struct {
+	IncludeBody bool // include the request and response bodies, the response body is decoded
+	MaxBodySize int  // the bodies larger are omitted with only the size, 0 for no limit
+}
+
+// JSONLinesFlow the schema of each line written by JSONLinesDumper, new fields may be added but not changed
+type JSONLinesFlow struct {
+	Id         string            `json:"id"`
+	StartAt    time.Time         `json:"startAt"`
+	DurationMs float64           `json:"durationMs"`
+	ClientAddr string            `json:"clientAddr,omitempty"`
+	ServerAddr string            `json:"serverAddr,omitempty"`
+	Request    JSONLinesMessage  `json:"request"`
+	Response   *JSONLinesMessage `json:"response,omitempty"` // nil if no response, such as the flow failed
+	Error      string            `json:"error,omitempty"`
+	Timings    *JSONLinesTimings `json:"timings,omitempty"`
+}
+
+// JSONLinesMessage the request or response of JSONLinesFlow
+type JSONLinesMessage struct {
+	Method     string         `json:"method,omitempty"`
+	URL        string         `json:"url,omitempty"`
+	Proto      string         `json:"proto,omitempty"`
+	StatusCode int            `json:"statusCode,omitempty"`
+	Header     http.Header    `json:"header"`
+	Body       *JSONLinesBody `json:"body,omitempty"` // nil if JSONLinesOptions.IncludeBody is not set or there is no body
+}
+
+// JSONLinesBody the body is Text if it is valid utf-8, otherwise Base64
+type JSONLinesBody struct {
+	Size      int    `json:"size"`
+	Text      string `json:"text,omitempty"`
+	Base64    string `json:"base64,omitempty"`
+	Omitted   bool   `json:"omitted,omitempty"`   // larger than JSONLinesOptions.MaxBodySize
+	Truncated bool   `json:"truncated,omitempty"` // Flow.BodyTruncated, only a part of the body was buffered
+}
+
+// JSONLinesTimings Flow.Timings in milliseconds
+type JSONLinesTimings struct {
+	DNS             float64 `json:"dns"`
+	Connect         float64 `json:"connect"`
+	ClientHandshake float64 `json:"clientHandshake"`
+	ServerHandshake float64 `json:"serverHandshake"`
+	FirstByte       float64 `json:"firstByte"`
+}
+
+// JSONLinesDumper write each completed flow as a line of json to the writer, such as a file, a socket or stdout,
+// to be ingested by log pipelines or filtered with jq. See JSONLinesFlow for the schema.
+type JSONLinesDumper struct {
+	proxy.BaseAddon
+	opts   JSONLinesOptions
+	out    io.Writer
+	closer io.Closer  // the file opened by NewJSONLinesDumperWithFilename
+	mu     sync.Mutex // a line is written by a single Write
+	wg     sync.WaitGroup
+}
+
+func NewJSONLinesDumper(w io.Writer, opts JSONLinesOptions) *JSONLinesDumper {
+	return &JSONLinesDumper{out: w, opts: opts}
+}
+
+// NewJSONLinesDumperWithFilename append the lines to the file, it is closed by Stop
+func NewJSONLinesDumperWithFilename(filename string, opts JSONLinesOptions) (*JSONLinesDumper, error) {
+	out, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		return nil, err
+	}
+	d := NewJSONLinesDumper(out, opts)
+	d.closer = out
+	return d, nil
+}
+
+func (d *JSONLinesDumper) Requestheaders(f *proxy.Flow) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		<-f.Done()
+		d.dump(f)
+	}()
+}
+
+// Stop wait the flows to be written, and close the file opened by NewJSONLinesDumperWithFilename
+func (d *JSONLinesDumper) Stop() error {
+	d.wg.Wait()
+	if d.closer != nil {
+		return d.closer.Close()
+	}
+	return nil
+}
+
+func (d *JSONLinesDumper) dump(f *proxy.Flow) {
+	line, err := json.Marshal(d.newFlow(f))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	line = append(line, '\n')
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, err := d.out.Write(line); err != nil {
+		log.Error(err)
+	}
+}
+
+func (d *JSONLinesDumper) newFlow(f *proxy.Flow) *JSONLinesFlow {
+	jf := &JSONLinesFlow{
+		Id:         f.Id,
+		StartAt:    f.Request.StartAt,
+		DurationMs: milliseconds(f.Duration()),
+		Request: JSONLinesMessage{
+			Method: f.Request.Method,
+			URL:    f.Request.URL.String(),
+			Proto:  f.Request.Proto,
+			Header: f.Request.Header,
+			Body:   d.newBody(f.Request.Body, f.BodyTruncated),
+		},
+	}
+	if connCtx := f.ConnContext; connCtx != nil {
+		if connCtx.ClientConn != nil && connCtx.ClientConn.Conn != nil {
+			jf.ClientAddr = connCtx.ClientConn.Conn.RemoteAddr().String()
+		}
+		if connCtx.ServerConn != nil {
+			jf.ServerAddr = connCtx.ServerConn.Address
+		}
+		timings := f.Timings
+		jf.Timings = &JSONLinesTimings{
+			DNS:             milliseconds(timings.DNS),
+			Connect:         milliseconds(timings.Connect),
+			ClientHandshake: milliseconds(timings.ClientHandshake),
+			ServerHandshake: milliseconds(timings.ServerHandshake),
+			FirstByte:       milliseconds(timings.FirstByte),
+		}
+	}
+	if f.Response != nil {
+		body := f.Response.Body
+		if d.opts.IncludeBody && len(body) > 0 {
+			if decoded, err := f.Response.DecodedBody(); err == nil {
+				body = decoded
+			}
+		}
+		jf.Response = &JSONLinesMessage{
+			StatusCode: f.Response.StatusCode,
+			Header:     f.Response.Header,
+			Body:       d.newBody(body, f.BodyTruncated),
+		}
+	}
+	if f.Error != nil {
+		jf.Error = f.Error.Error()
+	}
+	return jf
+}
+
+func (d *JSONLinesDumper) newBody(body []byte, truncated bool) *JSONLinesBody {
+	if !d.opts.IncludeBody || len(body) == 0 {
+		return nil
+	}
+	b := &JSONLinesBody{Size: len(body), Truncated: truncated}
+	switch {
+	case d.opts.MaxBodySize > 0 && len(body) > d.opts.MaxBodySize:
+		b.Omitted = true
+	case utf8.Valid(body):
+		b.Text = string(body)
+	default:
+		b.Base64 = base64.StdEncoding.EncodeToString(body)
+	}
+	return b
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,82 @@
+package addon
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+func TestJSONLinesDumper(t *testing.T) {
+	u, _ := url.Parse("https://example.com/api?id=1")
+	flows := []*proxy.Flow{
+		{
+			Id:       "1",
+			Request:  &proxy.Request{Method: "POST", URL: u, Proto: "HTTP/1.1", Header: http.Header{"Content-Type": {"application/json"}}, Body: []byte(`{"a":1}`)},
+			Response: &proxy.Response{StatusCode: 200, Header: http.Header{"Content-Type": {"image/png"}}, Body: []byte{0x89, 'P', 'N', 'G', 0xff}},
+		},
+		{
+			Id:       "2",
+			Request:  &proxy.Request{Method: "GET", URL: u, Proto: "HTTP/1.1", Header: http.Header{}},
+			Response: &proxy.Response{StatusCode: 200, Header: http.Header{}, Body: bytes.Repeat([]byte("a"), 100)},
+		},
+		{
+			Id:      "3",
+			Request: &proxy.Request{Method: "GET", URL: u, Proto: "HTTP/1.1", Header: http.Header{}},
+			Error:   errors.New("connection refused"),
+		},
+	}
+
+	read := func(opts JSONLinesOptions) []*JSONLinesFlow {
+		buf := new(bytes.Buffer)
+		d := NewJSONLinesDumper(buf, opts)
+		for _, f := range flows {
+			d.dump(f)
+		}
+		var lines []*JSONLinesFlow
+		scanner := bufio.NewScanner(buf)
+		for scanner.Scan() {
+			jf := new(JSONLinesFlow)
+			if err := json.Unmarshal(scanner.Bytes(), jf); err != nil {
+				t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+			}
+			lines = append(lines, jf)
+		}
+		if len(lines) != len(flows) {
+			t.Fatalf("expected %v lines, but got %v", len(flows), len(lines))
+		}
+		return lines
+	}
+
+	t.Run("without body", func(t *testing.T) {
+		lines := read(JSONLinesOptions{})
+		jf := lines[0]
+		if jf.Id != "1" || jf.Request.Method != "POST" || jf.Request.URL != u.String() || jf.Response.StatusCode != 200 {
+			t.Fatalf("unexpected flow %+v", jf)
+		}
+		if jf.Request.Header.Get("Content-Type") != "application/json" || jf.Request.Body != nil || jf.Response.Body != nil {
+			t.Fatalf("expected headers without body, but got %+v %+v", jf.Request, jf.Response)
+		}
+		if jf := lines[2]; jf.Response != nil || jf.Error != "connection refused" {
+			t.Fatalf("expected error without response, but got %+v", jf)
+		}
+	})
+
+	t.Run("with body", func(t *testing.T) {
+		lines := read(JSONLinesOptions{IncludeBody: true, MaxBodySize: 50})
+		if body := lines[0].Request.Body; body == nil || body.Text != `{"a":1}` || body.Size != 7 {
+			t.Fatalf("expected text body, but got %+v", body)
+		}
+		if body := lines[0].Response.Body; body == nil || body.Text != "" || body.Base64 != "iVBOR/8=" {
+			t.Fatalf("expected base64 body, but got %+v", body)
+		}
+		if body := lines[1].Response.Body; body == nil || !body.Omitted || body.Size != 100 || body.Text != "" {
+			t.Fatalf("expected omitted body, but got %+v", body)
+		}
+	})
+}
@@ -98,7 +98,8 @@ type MapLocal struct {
 }
 
 func (ml *MapLocal) Requestheaders(f *proxy.Flow) {
-	if !ml.Enable {
+	// the response would refuse the CONNECT
+	if !ml.Enable || f.Request.Method == "CONNECT" {
 		return
 	}
 	for _, item := range ml.Items {
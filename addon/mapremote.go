@@ -2,6 +2,7 @@ package addon
 
 import (
 	"fmt"
+	"net/url"
 	"path"
 	"strings"
 
@@ -18,12 +19,18 @@ import (
 //   2. mapFrom.Path /hello/* and mapTo.Path /world
 //     /hello => /world
 //     /hello/abc => /world/abc
+//
+// Query rule:
+//   mapFrom.Query {"env": "prod"} matches /hello?env=prod, the values are patterns such as "prod*"
+//   mapRemoteTo.Query {"env": "staging"} and mapRemoteTo.DelQuery ["debug"]
+//     /hello?env=prod&debug=1 => /hello?env=staging
 
 type mapFrom struct {
 	Protocol string
 	Host     string
 	Method   []string
 	Path     string
+	Query    map[string]string // query parameters the request must have, one of the values matches the pattern
 }
 
 func (mf *mapFrom) match(req *proxy.Request) bool {
@@ -39,6 +46,14 @@ func (mf *mapFrom) match(req *proxy.Request) bool {
 	if mf.Path != "" && !match.Match(req.URL.Path, mf.Path) {
 		return false
 	}
+	if len(mf.Query) > 0 {
+		query := req.URL.Query()
+		for key, pattern := range mf.Query {
+			if !lo.ContainsBy(query[key], func(value string) bool { return match.Match(value, pattern) }) {
+				return false
+			}
+		}
+	}
 	return true
 }
 
@@ -46,6 +61,8 @@ type mapRemoteTo struct {
 	Protocol string
 	Host     string
 	Path     string
+	Query    map[string]string // query parameters to add or replace
+	DelQuery []string          // query parameters to remove
 }
 
 type mapRemoteItem struct {
@@ -76,6 +93,12 @@ func (item *mapRemoteItem) replace(req *proxy.Request) *proxy.Request {
 			req.URL.Path = path.Join("/", item.To.Path)
 		}
 	}
+	for _, key := range item.To.DelQuery {
+		req.DelQueryParam(key)
+	}
+	for key, value := range item.To.Query {
+		req.SetQueryParam(key, value)
+	}
 	return req
 }
 
@@ -83,6 +106,9 @@ type MapRemote struct {
 	proxy.BaseAddon
 	Items  []*mapRemoteItem
 	Enable bool
+	// rewrite Set-Cookie of mapped responses so the client accepts them:
+	// Domain to the original host, drop Secure if the original request is http, SameSite=None to Lax then
+	RewriteCookie bool
 }
 
 func (mr *MapRemote) Requestheaders(f *proxy.Flow) {
@@ -101,6 +127,110 @@ func (mr *MapRemote) Requestheaders(f *proxy.Flow) {
 	}
 }
 
+// Responseheaders rewrite the redirect headers pointing at the mapped remote back to the original url,
+// so the client stays on the host it requested.
+func (mr *MapRemote) Responseheaders(f *proxy.Flow) {
+	if !mr.Enable || f.OriginalRequest == nil || f.Response == nil || f.Response.Header == nil {
+		return
+	}
+	var matched *mapRemoteItem
+	for _, item := range mr.Items {
+		if item.match(f.OriginalRequest) {
+			matched = item
+			break
+		}
+	}
+	if matched == nil {
+		return
+	}
+
+	header := f.Response.Header
+	for _, key := range []string{"Location", "Content-Location"} {
+		if v := header.Get(key); v != "" {
+			if nv, ok := matched.restore(v, f.OriginalRequest.URL, f.Request.URL); ok {
+				header.Set(key, nv)
+			}
+		}
+	}
+	// Refresh: 5; url=https://example.com/
+	if v := header.Get("Refresh"); v != "" {
+		if i := strings.Index(strings.ToLower(v), "url="); i >= 0 {
+			if nv, ok := matched.restore(v[i+4:], f.OriginalRequest.URL, f.Request.URL); ok {
+				header.Set("Refresh", v[:i+4]+nv)
+			}
+		}
+	}
+
+	if mr.RewriteCookie {
+		cookies := header.Values("Set-Cookie")
+		for i, cookie := range cookies {
+			cookies[i] = rewriteSetCookie(cookie, f.OriginalRequest.URL)
+		}
+	}
+}
+
+// rewrite the attributes of Set-Cookie header for the client facing url
+func rewriteSetCookie(cookie string, origin *url.URL) string {
+	parts := strings.Split(cookie, ";")
+	insecure := origin.Scheme == "http"
+	attrs := parts[:1]
+	for _, part := range parts[1:] {
+		attr := strings.TrimSpace(part)
+		name, value, _ := strings.Cut(attr, "=")
+		switch strings.ToLower(name) {
+		case "domain":
+			attr = "Domain=" + origin.Hostname()
+		case "secure":
+			if insecure {
+				continue
+			}
+		case "samesite":
+			// SameSite=None requires Secure
+			if insecure && strings.EqualFold(value, "none") {
+				attr = "SameSite=Lax"
+			}
+		}
+		attrs = append(attrs, " "+attr)
+	}
+	return strings.Join(attrs, ";")
+}
+
+// restore the url in response of the mapped request to the url before mapping
+func (item *mapRemoteItem) restore(rawURL string, origin, mapped *url.URL) (string, bool) {
+	ref, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+	u := mapped.ResolveReference(ref)
+	if u.Scheme != mapped.Scheme || u.Host != mapped.Host {
+		return "", false
+	}
+
+	if item.To.Path != "" {
+		toPath := path.Join("/", item.To.Path)
+		if item.From.Path != "" && strings.HasSuffix(item.From.Path, "/*") {
+			fromPath := strings.TrimSuffix(item.From.Path, "/*")
+			if u.Path == toPath || strings.HasPrefix(u.Path, strings.TrimSuffix(toPath, "/")+"/") {
+				u.Path = path.Join("/", fromPath, u.Path[len(toPath):])
+				u.RawPath = ""
+			}
+		} else if u.Path == toPath && item.From.Path != "" {
+			u.Path = item.From.Path
+			u.RawPath = ""
+		}
+	}
+
+	// keep relative url relative
+	if !ref.IsAbs() && ref.Host == "" {
+		u.Scheme = ""
+		u.Host = ""
+		return u.String(), true
+	}
+	u.Scheme = origin.Scheme
+	u.Host = origin.Host
+	return u.String(), true
+}
+
 func (mr *MapRemote) validate() error {
 	for i, item := range mr.Items {
 		if item.From == nil {
@@ -112,7 +242,7 @@ func (mr *MapRemote) validate() error {
 		if item.To == nil {
 			return fmt.Errorf("%v no item.To", i)
 		}
-		if item.To.Protocol == "" && item.To.Host == "" && item.To.Path == "" {
+		if item.To.Protocol == "" && item.To.Host == "" && item.To.Path == "" && len(item.To.Query) == 0 && len(item.To.DelQuery) == 0 {
 			return fmt.Errorf("%v empty item.To", i)
 		}
 		if item.To.Protocol != "" && item.To.Protocol != "http" && item.To.Protocol != "https" {
@@ -1,6 +1,8 @@
 package addon
 
 import (
+	"net/http"
+	"net/http/cookiejar"
 	"net/url"
 	"testing"
 
@@ -233,3 +235,123 @@ func TestMapItemReplace(t *testing.T) {
 		t.Errorf("Expected %v, but got %v", should, req.URL.String())
 	}
 }
+
+func TestMapItemQuery(t *testing.T) {
+	req := &proxy.Request{
+		Method: "GET",
+		URL:    &url.URL{Scheme: "https", Host: "example.com", Path: "/api", RawQuery: "env=prod&debug=1&id=7"},
+	}
+	item := &mapRemoteItem{
+		From: &mapFrom{Query: map[string]string{"env": "prod"}},
+		To: &mapRemoteTo{
+			Query:    map[string]string{"env": "staging"},
+			DelQuery: []string{"debug"},
+		},
+		Enable: true,
+	}
+	if !item.match(req) {
+		t.Fatal("expected env=prod to match")
+	}
+	for _, query := range []map[string]string{{"env": "staging"}, {"missing": "*"}} {
+		if (&mapFrom{Query: query}).match(req) {
+			t.Fatalf("expected %v not to match", query)
+		}
+	}
+	if !(&mapFrom{Query: map[string]string{"env": "pr*", "id": "*"}}).match(req) {
+		t.Fatal("expected query patterns to match")
+	}
+
+	req = item.replace(req)
+	if req.URL.String() != "https://example.com/api?env=staging&id=7" {
+		t.Fatalf("unexpected url %v", req.URL.String())
+	}
+	if item.match(req) {
+		t.Fatal("expected env=staging not to match")
+	}
+	if err := (&MapRemote{Items: []*mapRemoteItem{item}}).validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMapRemoteRestoreLocation(t *testing.T) {
+	mr := &MapRemote{
+		Enable: true,
+		Items: []*mapRemoteItem{
+			{
+				From:   &mapFrom{Host: "example.com", Path: "/api/*"},
+				To:     &mapRemoteTo{Protocol: "http", Host: "localhost:8000", Path: "/v1"},
+				Enable: true,
+			},
+		},
+	}
+
+	newFlow := func(header http.Header) *proxy.Flow {
+		origin := &url.URL{Scheme: "https", Host: "example.com", Path: "/api/users"}
+		f := &proxy.Flow{
+			OriginalRequest: &proxy.Request{Method: "GET", URL: origin},
+			Request:         &proxy.Request{Method: "GET", URL: &url.URL{Scheme: "https", Host: "example.com", Path: "/api/users"}},
+			Response:        &proxy.Response{StatusCode: 302, Header: header},
+		}
+		mr.Requestheaders(f)
+		mr.Responseheaders(f)
+		return f
+	}
+
+	cases := []struct {
+		key, value, expected string
+	}{
+		{"Location", "http://localhost:8000/v1/login?next=1", "https://example.com/api/login?next=1"},
+		{"Location", "/v1/login", "/api/login"},
+		{"Location", "https://other.com/v1/login", "https://other.com/v1/login"},
+		{"Content-Location", "http://localhost:8000/v1/users/1", "https://example.com/api/users/1"},
+		{"Refresh", "5; url=http://localhost:8000/v1", "5; url=https://example.com/api"},
+	}
+	for _, c := range cases {
+		f := newFlow(http.Header{c.key: []string{c.value}})
+		if got := f.Response.Header.Get(c.key); got != c.expected {
+			t.Errorf("%v: %v expected %v, but got %v", c.key, c.value, c.expected, got)
+		}
+	}
+}
+
+func TestMapRemoteRewriteCookie(t *testing.T) {
+	mr := &MapRemote{
+		Enable:        true,
+		RewriteCookie: true,
+		Items: []*mapRemoteItem{
+			{
+				From:   &mapFrom{Host: "example.com"},
+				To:     &mapRemoteTo{Protocol: "https", Host: "upstream.com"},
+				Enable: true,
+			},
+		},
+	}
+
+	origin := &url.URL{Scheme: "http", Host: "example.com", Path: "/"}
+	f := &proxy.Flow{
+		OriginalRequest: &proxy.Request{Method: "GET", URL: origin},
+		Request:         &proxy.Request{Method: "GET", URL: &url.URL{Scheme: "http", Host: "example.com", Path: "/"}},
+		Response: &proxy.Response{StatusCode: 200, Header: http.Header{"Set-Cookie": []string{
+			"a=1; Domain=upstream.com; Path=/; Secure; HttpOnly",
+			"b=2; Domain=.upstream.com; SameSite=None; Secure",
+		}}},
+	}
+	mr.Requestheaders(f)
+	mr.Responseheaders(f)
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := &http.Response{Header: f.Response.Header}
+	jar.SetCookies(origin, resp.Cookies())
+	cookies := jar.Cookies(origin)
+	if len(cookies) != 2 {
+		t.Fatalf("expected 2 cookies accepted, but got %v, Set-Cookie: %v", cookies, f.Response.Header.Values("Set-Cookie"))
+	}
+
+	should := "b=2; Domain=example.com; SameSite=Lax"
+	if got := f.Response.Header.Values("Set-Cookie")[1]; got != should {
+		t.Errorf("Expected %v, but got %v", should, got)
+	}
+}
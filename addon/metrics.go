@@ -0,0 +1,223 @@
+package addon
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+// upper bounds in seconds of the buckets of the upstream latency histogram, same as the default of prometheus client
+var latencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Metrics count flows, connections and bytes of the proxy, exported in prometheus text format by the handler
+// returned from NewMetricsAddon.
+// Flows and upstream latency are also counted by Flow.RouteLabel if set by proxy.Options.MetricsRouteLabel,
+// raw urls are never used as labels to keep the cardinality low.
+type Metrics struct {
+	proxy.BaseAddon
+
+	flows         int64
+	flowErrors    int64
+	clientConns   int64
+	serverConns   int64
+	tlsFailures   int64
+	bytesReceived int64 // from clients, counted when the client connection is closed
+	bytesSent     int64 // to clients, counted when the client connection is closed
+	latency       *histogram
+
+	routesMu     sync.Mutex
+	routeFlows   map[string]int64
+	routeLatency map[string]*histogram
+}
+
+// NewMetricsAddon return the addon to add to the proxy, and the handler of its metrics to mount on an admin server,
+// such as http.Handle("/metrics", handler)
+func NewMetricsAddon() (*Metrics, http.Handler) {
+	m := &Metrics{
+		latency:      newHistogram(latencyBuckets),
+		routeFlows:   make(map[string]int64),
+		routeLatency: make(map[string]*histogram),
+	}
+	return m, http.HandlerFunc(m.serveHTTP)
+}
+
+func (m *Metrics) Hooks() proxy.Hook {
+	return proxy.HookClientConnected | proxy.HookClientDisconnected | proxy.HookServerConnected | proxy.HookServerDisconnected |
+		proxy.HookRequestheaders | proxy.HookResponse | proxy.HookFlowError | proxy.HookConnTimings
+}
+
+func (m *Metrics) ClientConnected(*proxy.ClientConn) {
+	atomic.AddInt64(&m.clientConns, 1)
+}
+
+// called once for each client connection, however it is closed
+func (m *Metrics) ClientDisconnected(client *proxy.ClientConn) {
+	atomic.AddInt64(&m.clientConns, -1)
+	if client.CloseReason == proxy.CloseReasonClientTlsError {
+		atomic.AddInt64(&m.tlsFailures, 1)
+	}
+}
+
+func (m *Metrics) ServerConnected(*proxy.ConnContext) {
+	atomic.AddInt64(&m.serverConns, 1)
+}
+
+func (m *Metrics) ServerDisconnected(*proxy.ConnContext) {
+	atomic.AddInt64(&m.serverConns, -1)
+}
+
+func (m *Metrics) ConnTimings(connCtx *proxy.ConnContext) {
+	read, written := connCtx.ClientBytes()
+	atomic.AddInt64(&m.bytesReceived, read)
+	atomic.AddInt64(&m.bytesSent, written)
+}
+
+func (m *Metrics) Requestheaders(f *proxy.Flow) {
+	atomic.AddInt64(&m.flows, 1)
+	if f == nil || f.RouteLabel == "" {
+		return
+	}
+	m.routesMu.Lock()
+	defer m.routesMu.Unlock()
+	m.routeFlows[f.RouteLabel]++
+}
+
+// only the responses of upstream are observed, not the ones set by addons
+func (m *Metrics) Response(f *proxy.Flow) {
+	if f.EffectiveUpstreamURL() == nil {
+		return
+	}
+	seconds := f.Duration().Seconds()
+	m.latency.observe(seconds)
+	if f.RouteLabel == "" {
+		return
+	}
+	m.routesMu.Lock()
+	h, ok := m.routeLatency[f.RouteLabel]
+	if !ok {
+		h = newHistogram(latencyBuckets)
+		m.routeLatency[f.RouteLabel] = h
+	}
+	m.routesMu.Unlock()
+	h.observe(seconds)
+}
+
+func (m *Metrics) FlowError(*proxy.Flow) {
+	atomic.AddInt64(&m.flowErrors, 1)
+}
+
+func (m *Metrics) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.writeTo(w)
+}
+
+// write the metrics in prometheus text format
+func (m *Metrics) writeTo(w io.Writer) {
+	metric := func(name, typ, help string, value int64) {
+		fmt.Fprintf(w, "# HELP %v %v\n# TYPE %v %v\n%v %v\n", name, help, name, typ, name, value)
+	}
+	metric("go_mitmproxy_flows_total", "counter", "Total flows received.", atomic.LoadInt64(&m.flows))
+	metric("go_mitmproxy_flow_errors_total", "counter", "Total flows not completed normally.", atomic.LoadInt64(&m.flowErrors))
+	metric("go_mitmproxy_client_connections", "gauge", "Active client connections.", atomic.LoadInt64(&m.clientConns))
+	metric("go_mitmproxy_server_connections", "gauge", "Active server connections.", atomic.LoadInt64(&m.serverConns))
+	metric("go_mitmproxy_tls_handshake_failures_total", "counter", "Total failed tls handshakes with clients.", atomic.LoadInt64(&m.tlsFailures))
+
+	fmt.Fprint(w, "# HELP go_mitmproxy_client_bytes_total Bytes transferred with clients, counted when the connections are closed.\n")
+	fmt.Fprint(w, "# TYPE go_mitmproxy_client_bytes_total counter\n")
+	fmt.Fprintf(w, "go_mitmproxy_client_bytes_total{direction=\"received\"} %v\n", atomic.LoadInt64(&m.bytesReceived))
+	fmt.Fprintf(w, "go_mitmproxy_client_bytes_total{direction=\"sent\"} %v\n", atomic.LoadInt64(&m.bytesSent))
+
+	const latencyName = "go_mitmproxy_upstream_response_seconds"
+	fmt.Fprintf(w, "# HELP %v Latency from the request received to the upstream response read.\n# TYPE %v histogram\n", latencyName, latencyName)
+	m.latency.writeTo(w, latencyName, "")
+
+	m.routesMu.Lock()
+	defer m.routesMu.Unlock()
+	if len(m.routeFlows) == 0 {
+		return
+	}
+	fmt.Fprint(w, "# HELP go_mitmproxy_route_flows_total Total flows received by route.\n")
+	fmt.Fprint(w, "# TYPE go_mitmproxy_route_flows_total counter\n")
+	for _, route := range sortedKeys(m.routeFlows) {
+		fmt.Fprintf(w, "go_mitmproxy_route_flows_total{route=\"%v\"} %v\n", escapeLabel(route), m.routeFlows[route])
+	}
+	if len(m.routeLatency) == 0 {
+		return
+	}
+	const routeLatencyName = "go_mitmproxy_route_upstream_response_seconds"
+	fmt.Fprintf(w, "# HELP %v Latency from the request received to the upstream response read by route.\n# TYPE %v histogram\n", routeLatencyName, routeLatencyName)
+	for _, route := range sortedKeys(m.routeLatency) {
+		m.routeLatency[route].writeTo(w, routeLatencyName, "route=\""+escapeLabel(route)+"\"")
+	}
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// prometheus histogram with fixed buckets
+type histogram struct {
+	bounds []float64
+
+	mu     sync.Mutex
+	counts []uint64 // not cumulative, counts[len(bounds)] is +Inf
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{
+		bounds: bounds,
+		counts: make([]uint64, len(bounds)+1),
+	}
+}
+
+func (h *histogram) observe(v float64) {
+	i := 0
+	for i < len(h.bounds) && v > h.bounds[i] {
+		i++
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[i]++
+	h.sum += v
+	h.count++
+}
+
+func (h *histogram) writeTo(w io.Writer, name, labels string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	sep := ""
+	if labels != "" {
+		sep = ","
+	}
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += h.counts[i]
+		fmt.Fprintf(w, "%v_bucket{%v%vle=\"%v\"} %v\n", name, labels, sep, strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+	}
+	fmt.Fprintf(w, "%v_bucket{%v%vle=\"+Inf\"} %v\n", name, labels, sep, h.count)
+	if labels != "" {
+		labels = "{" + labels + "}"
+	}
+	fmt.Fprintf(w, "%v_sum%v %v\n", name, labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+	fmt.Fprintf(w, "%v_count%v %v\n", name, labels, h.count)
+}
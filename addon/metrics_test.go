@@ -0,0 +1,58 @@
+package addon
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+func TestMetrics(t *testing.T) {
+	m, handler := NewMetricsAddon()
+
+	ok := &proxy.ClientConn{CloseReason: proxy.CloseReasonNormal}
+	failed := &proxy.ClientConn{CloseReason: proxy.CloseReasonClientTlsError}
+	m.ClientConnected(ok)
+	m.ClientConnected(failed)
+	m.ClientConnected(&proxy.ClientConn{})
+	m.ClientDisconnected(ok)
+	m.ClientDisconnected(failed)
+	m.ServerConnected(nil)
+	m.Requestheaders(nil)
+	m.Requestheaders(nil)
+	m.Requestheaders(&proxy.Flow{RouteLabel: "/users/{id}"})
+	m.Requestheaders(&proxy.Flow{RouteLabel: "/users/{id}"})
+	m.Requestheaders(&proxy.Flow{RouteLabel: `/say/"hi"`})
+	m.FlowError(nil)
+	m.latency.observe(0.02)
+	m.latency.observe(3)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Fatalf("unexpected content type %v", ct)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	for _, line := range []string{
+		"go_mitmproxy_flows_total 5",
+		`go_mitmproxy_route_flows_total{route="/users/{id}"} 2`,
+		`go_mitmproxy_route_flows_total{route="/say/\"hi\""} 1`,
+		"go_mitmproxy_flow_errors_total 1",
+		"go_mitmproxy_client_connections 1",
+		"go_mitmproxy_server_connections 1",
+		"go_mitmproxy_tls_handshake_failures_total 1",
+		`go_mitmproxy_client_bytes_total{direction="received"} 0`,
+		`go_mitmproxy_upstream_response_seconds_bucket{le="0.01"} 0`,
+		`go_mitmproxy_upstream_response_seconds_bucket{le="0.025"} 1`,
+		`go_mitmproxy_upstream_response_seconds_bucket{le="5"} 2`,
+		`go_mitmproxy_upstream_response_seconds_bucket{le="+Inf"} 2`,
+		"go_mitmproxy_upstream_response_seconds_sum 3.02",
+		"go_mitmproxy_upstream_response_seconds_count 2",
+	} {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Fatalf("expected %q in metrics:\n%s", line, body)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package addon
+
+import (
+	"encoding/json"
+	"math"
+	"mime"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+// OpenAPI 3.0, only the fields can be inferred from traffic
+type openapiDoc struct {
+	OpenAPI string                           `json:"openapi"`
+	Info    openapiInfo                      `json:"info"`
+	Servers []openapiServer                  `json:"servers"`
+	Paths   map[string]map[string]*openapiOp `json:"paths"`
+}
+
+type openapiInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openapiServer struct {
+	URL string `json:"url"`
+}
+
+type openapiOp struct {
+	Parameters  []*openapiParam             `json:"parameters,omitempty"`
+	RequestBody *openapiBody                `json:"requestBody,omitempty"`
+	Responses   map[string]*openapiResponse `json:"responses"`
+}
+
+type openapiParam struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *openapiSchema `json:"schema"`
+}
+
+type openapiBody struct {
+	Content map[string]*openapiMedia `json:"content"`
+}
+
+type openapiResponse struct {
+	Description string                   `json:"description"`
+	Content     map[string]*openapiMedia `json:"content,omitempty"`
+}
+
+type openapiMedia struct {
+	Schema *openapiSchema `json:"schema"`
+}
+
+type openapiSchema struct {
+	Type       string                    `json:"type,omitempty"`
+	Properties map[string]*openapiSchema `json:"properties,omitempty"`
+	Items      *openapiSchema            `json:"items,omitempty"`
+	Nullable   bool                      `json:"nullable,omitempty"`
+}
+
+var openapiIdSegment = regexp.MustCompile(`^(\d+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9a-fA-F]{24,})$`)
+
+// ExportOpenAPI infer a draft OpenAPI 3.0 spec in json from the flows.
+// Path segments like numbers and uuids become path parameters, so /users/1 and /users/2 are merged into /users/{id}.
+// Schemas of json request and response bodies are inferred from the samples and merged. CONNECT flows are skipped.
+// Flows are merged in the order of the start time of request, then of flow id.
+func ExportOpenAPI(flows []*proxy.Flow) ([]byte, error) {
+	doc := &openapiDoc{
+		OpenAPI: "3.0.3",
+		Info:    openapiInfo{Title: "go-mitmproxy", Version: "0.0.0"},
+		Servers: make([]openapiServer, 0),
+		Paths:   make(map[string]map[string]*openapiOp),
+	}
+	servers := make(map[string]bool)
+
+	for _, f := range sortFlows(flows) {
+		if f.Request == nil || f.Request.Method == http.MethodConnect {
+			continue
+		}
+		server := f.Request.URL.Scheme + "://" + f.Request.URL.Host
+		if !servers[server] {
+			servers[server] = true
+			doc.Servers = append(doc.Servers, openapiServer{URL: server})
+		}
+
+		path, pathParams := openapiPath(f.Request.URL.Path)
+		ops, ok := doc.Paths[path]
+		if !ok {
+			ops = make(map[string]*openapiOp)
+			doc.Paths[path] = ops
+		}
+		method := strings.ToLower(f.Request.Method)
+		op, ok := ops[method]
+		if !ok {
+			op = &openapiOp{Responses: make(map[string]*openapiResponse)}
+			for _, name := range pathParams {
+				op.Parameters = append(op.Parameters, &openapiParam{Name: name, In: "path", Required: true, Schema: &openapiSchema{Type: "string"}})
+			}
+			ops[method] = op
+		}
+
+		for name := range f.Request.URL.Query() {
+			op.addQueryParam(name)
+		}
+		if contentType, schema := openapiBodySchema(f.Request.Header, f.Request.Body); contentType != "" {
+			if op.RequestBody == nil {
+				op.RequestBody = &openapiBody{Content: make(map[string]*openapiMedia)}
+			}
+			op.RequestBody.Content[contentType] = mergeOpenapiMedia(op.RequestBody.Content[contentType], schema)
+		}
+
+		if f.Response == nil {
+			continue
+		}
+		code := strconv.Itoa(f.Response.StatusCode)
+		res, ok := op.Responses[code]
+		if !ok {
+			res = &openapiResponse{Description: http.StatusText(f.Response.StatusCode)}
+			op.Responses[code] = res
+		}
+		body, err := f.Response.DecodedBody()
+		if err != nil {
+			continue
+		}
+		if contentType, schema := openapiBodySchema(f.Response.Header, body); contentType != "" {
+			if res.Content == nil {
+				res.Content = make(map[string]*openapiMedia)
+			}
+			res.Content[contentType] = mergeOpenapiMedia(res.Content[contentType], schema)
+		}
+	}
+
+	for _, ops := range doc.Paths {
+		for _, op := range ops {
+			// path parameters in order first, then query parameters by name
+			sort.SliceStable(op.Parameters, func(i, j int) bool {
+				pi, pj := op.Parameters[i], op.Parameters[j]
+				if pi.In != pj.In {
+					return pi.In == "path"
+				}
+				return pi.In == "query" && pi.Name < pj.Name
+			})
+		}
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// template path and names of the path parameters
+func openapiPath(urlPath string) (string, []string) {
+	var names []string
+	segments := strings.Split(urlPath, "/")
+	for i, segment := range segments {
+		if !openapiIdSegment.MatchString(segment) {
+			continue
+		}
+		name := "id"
+		if len(names) > 0 {
+			name += strconv.Itoa(len(names) + 1)
+		}
+		names = append(names, name)
+		segments[i] = "{" + name + "}"
+	}
+	path := strings.Join(segments, "/")
+	if path == "" {
+		path = "/"
+	}
+	return path, names
+}
+
+func (op *openapiOp) addQueryParam(name string) {
+	for _, p := range op.Parameters {
+		if p.In == "query" && p.Name == name {
+			return
+		}
+	}
+	op.Parameters = append(op.Parameters, &openapiParam{Name: name, In: "query", Schema: &openapiSchema{Type: "string"}})
+}
+
+// media type and schema of the body, schema is nil if the body is not json
+func openapiBodySchema(header http.Header, body []byte) (string, *openapiSchema) {
+	if len(body) == 0 {
+		return "", nil
+	}
+	contentType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		contentType = "application/octet-stream"
+	}
+	if contentType != "application/json" && !strings.HasSuffix(contentType, "+json") {
+		return contentType, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return contentType, nil
+	}
+	return contentType, inferOpenapiSchema(v)
+}
+
+func inferOpenapiSchema(v interface{}) *openapiSchema {
+	switch v := v.(type) {
+	case nil:
+		return &openapiSchema{Nullable: true}
+	case bool:
+		return &openapiSchema{Type: "boolean"}
+	case float64:
+		if v == math.Trunc(v) {
+			return &openapiSchema{Type: "integer"}
+		}
+		return &openapiSchema{Type: "number"}
+	case string:
+		return &openapiSchema{Type: "string"}
+	case []interface{}:
+		s := &openapiSchema{Type: "array"}
+		for _, item := range v {
+			s.Items = mergeOpenapiSchema(s.Items, inferOpenapiSchema(item))
+		}
+		if s.Items == nil {
+			s.Items = &openapiSchema{}
+		}
+		return s
+	case map[string]interface{}:
+		s := &openapiSchema{Type: "object", Properties: make(map[string]*openapiSchema)}
+		for key, value := range v {
+			s.Properties[key] = inferOpenapiSchema(value)
+		}
+		return s
+	}
+	return &openapiSchema{}
+}
+
+// merge schemas of two samples, the type is dropped if they differ
+func mergeOpenapiSchema(a, b *openapiSchema) *openapiSchema {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	nullable := a.Nullable || b.Nullable
+	switch {
+	case a.Type == "":
+		if a.Nullable && !b.Nullable {
+			a = b
+		}
+	case b.Type == "":
+		if !b.Nullable {
+			a = &openapiSchema{}
+		}
+	case a.Type == b.Type:
+		if a.Type == "object" {
+			for key, value := range b.Properties {
+				a.Properties[key] = mergeOpenapiSchema(a.Properties[key], value)
+			}
+		} else if a.Type == "array" {
+			a.Items = mergeOpenapiSchema(a.Items, b.Items)
+		}
+	case (a.Type == "integer" && b.Type == "number") || (a.Type == "number" && b.Type == "integer"):
+		a.Type = "number"
+	default:
+		a = &openapiSchema{}
+	}
+	a.Nullable = nullable
+	return a
+}
+
+func mergeOpenapiMedia(media *openapiMedia, schema *openapiSchema) *openapiMedia {
+	if media == nil {
+		return &openapiMedia{Schema: schema}
+	}
+	media.Schema = mergeOpenapiSchema(media.Schema, schema)
+	return media
+}
@@ -0,0 +1,66 @@
+package addon
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+func TestExportOpenAPI(t *testing.T) {
+	jsonHeader := http.Header{"Content-Type": {"application/json; charset=utf-8"}}
+	newFlow := func(method, rawURL, reqBody string, status int, resBody string) *proxy.Flow {
+		u, _ := url.Parse(rawURL)
+		return &proxy.Flow{
+			Request:  &proxy.Request{Method: method, URL: u, Header: jsonHeader, Body: []byte(reqBody)},
+			Response: &proxy.Response{StatusCode: status, Header: jsonHeader, Body: []byte(resBody)},
+		}
+	}
+	flows := []*proxy.Flow{
+		newFlow("GET", "https://api.example.com/users/1?fields=name", "", 200, `{"id":1,"name":"a","tags":["x"]}`),
+		newFlow("GET", "https://api.example.com/users/2", "", 200, `{"id":2,"name":null,"score":1.5}`),
+		newFlow("GET", "https://api.example.com/users/3", "", 404, `{"error":"not found"}`),
+		newFlow("POST", "https://api.example.com/users", `{"name":"b"}`, 201, `{"id":4}`),
+	}
+
+	data, err := ExportOpenAPI(flows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := new(openapiDoc)
+	if err := json.Unmarshal(data, doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doc.Servers) != 1 || doc.Servers[0].URL != "https://api.example.com" {
+		t.Fatalf("unexpected servers %+v", doc.Servers)
+	}
+	if len(doc.Paths) != 2 {
+		t.Fatalf("expected /users and /users/{id}, got %v", doc.Paths)
+	}
+
+	get := doc.Paths["/users/{id}"]["get"]
+	if get == nil || len(get.Parameters) != 2 || get.Parameters[0].Name != "id" || get.Parameters[0].In != "path" || get.Parameters[1].Name != "fields" {
+		t.Fatalf("unexpected get operation %+v", get)
+	}
+	if len(get.Responses) != 2 || get.Responses["404"].Description != "Not Found" {
+		t.Fatalf("unexpected responses %+v", get.Responses)
+	}
+	schema := get.Responses["200"].Content["application/json"].Schema
+	if schema.Type != "object" || schema.Properties["id"].Type != "integer" || schema.Properties["tags"].Items.Type != "string" {
+		t.Fatalf("unexpected schema %+v", schema)
+	}
+	if name := schema.Properties["name"]; name.Type != "string" || !name.Nullable {
+		t.Fatalf("expected nullable string, got %+v", name)
+	}
+	if schema.Properties["score"].Type != "number" {
+		t.Fatalf("expected merged property score, got %+v", schema.Properties)
+	}
+
+	post := doc.Paths["/users"]["post"]
+	if post == nil || post.RequestBody.Content["application/json"].Schema.Properties["name"].Type != "string" {
+		t.Fatalf("unexpected post operation %+v", post)
+	}
+}
@@ -0,0 +1,297 @@
+package addon
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+	log "github.com/sirupsen/logrus"
+)
+
+// pcapng block types
+const (
+	pcapngSectionHeader      = 0x0A0D0D0A
+	pcapngInterfaceDesc      = 0x00000001
+	pcapngEnhancedPacket     = 0x00000006
+	pcapngDecryptionSecrets  = 0x0000000A
+	pcapngSecretsTypeTlsKeys = 0x544c534b // TLS Key Log
+	pcapngLinkTypeRaw        = 101        // raw IPv4 or IPv6
+	pcapngMaxSegment         = 60000
+)
+
+// tcp flags
+const (
+	tcpFin = 0x01
+	tcpSyn = 0x02
+	tcpPsh = 0x08
+	tcpAck = 0x10
+)
+
+// Pcapng write the raw (tls encrypted) traffic of client and server connections to a pcapng file,
+// with the tls keys embedded as Decryption Secrets Block, so Wireshark can decrypt it without a separate keylog file.
+// TCP/IP headers are synthesized from the connection addresses.
+//
+// Pcapng must be wired into the Options before creating the proxy:
+//
+//	p := addon.NewPcapng(w)
+//	opts.OnClientRawBytes = p.ClientBytes
+//	opts.OnServerRawBytes = p.ServerBytes
+//	opts.KeyLogWriter = p
+//
+// and added as an addon to close the tcp streams when connections are closed.
+type Pcapng struct {
+	mu      sync.Mutex
+	out     io.Writer
+	closer  io.Closer // the file opened by NewPcapngWithFilename
+	err     error
+	streams map[string]*pcapngStream
+	nextIP  byte
+}
+
+// one tcp stream: a client connection or a server connection
+type pcapngStream struct {
+	local  *net.TCPAddr // proxy side
+	remote *net.TCPAddr // client or server side
+	// next sequence number of each direction
+	localSeq  uint32
+	remoteSeq uint32
+}
+
+func NewPcapng(out io.Writer) *Pcapng {
+	p := &Pcapng{
+		out:     out,
+		streams: make(map[string]*pcapngStream),
+		nextIP:  1,
+	}
+	p.writeHeader()
+	return p
+}
+
+func NewPcapngWithFilename(filename string) *Pcapng {
+	out, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		panic(err)
+	}
+	p := NewPcapng(out)
+	p.closer = out
+	return p
+}
+
+// Stop close the file opened by NewPcapngWithFilename, return the first write error if any
+func (p *Pcapng) Stop() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	err := p.err
+	if p.closer != nil {
+		if closeErr := p.closer.Close(); err == nil {
+			err = closeErr
+		}
+		p.closer = nil
+	}
+	return err
+}
+
+// ClientBytes can be used as Options.OnClientRawBytes
+func (p *Pcapng) ClientBytes(connCtx *proxy.ConnContext, data []byte, direction proxy.Direction) {
+	conn := connCtx.ClientConn.Conn
+	p.packet("c"+connCtx.Id(), conn.LocalAddr(), conn.RemoteAddr(), data, direction)
+}
+
+// ServerBytes can be used as Options.OnServerRawBytes
+func (p *Pcapng) ServerBytes(connCtx *proxy.ConnContext, data []byte, direction proxy.Direction) {
+	conn := connCtx.ServerConn.Conn
+	p.packet("s"+connCtx.ServerConn.Id, conn.LocalAddr(), conn.RemoteAddr(), data, direction)
+}
+
+// Write key log lines, can be used as Options.KeyLogWriter
+func (p *Pcapng) Write(keyLog []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	body := make([]byte, 8, 8+len(keyLog)+3)
+	binary.LittleEndian.PutUint32(body[0:], pcapngSecretsTypeTlsKeys)
+	binary.LittleEndian.PutUint32(body[4:], uint32(len(keyLog)))
+	body = append(body, keyLog...)
+	p.writeBlock(pcapngDecryptionSecrets, body)
+	if p.err != nil {
+		return 0, p.err
+	}
+	return len(keyLog), nil
+}
+
+func (p *Pcapng) ClientDisconnected(client *proxy.ClientConn) {
+	p.close("c" + client.Id)
+}
+
+func (p *Pcapng) ServerDisconnected(connCtx *proxy.ConnContext) {
+	p.close("s" + connCtx.ServerConn.Id)
+}
+
+func (p *Pcapng) packet(id string, local, remote net.Addr, data []byte, direction proxy.Direction) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stream, ok := p.streams[id]
+	if !ok {
+		stream = p.newStream(local, remote)
+		p.streams[id] = stream
+		// tcp handshake, let Wireshark follow the stream
+		p.writeTcp(stream, false, tcpSyn, nil)
+		stream.remoteSeq++
+		p.writeTcp(stream, true, tcpSyn|tcpAck, nil)
+		stream.localSeq++
+		p.writeTcp(stream, false, tcpAck, nil)
+	}
+
+	fromLocal := direction == proxy.DirectionWrite
+	for len(data) > 0 {
+		n := len(data)
+		if n > pcapngMaxSegment {
+			n = pcapngMaxSegment
+		}
+		p.writeTcp(stream, fromLocal, tcpPsh|tcpAck, data[:n])
+		if fromLocal {
+			stream.localSeq += uint32(n)
+		} else {
+			stream.remoteSeq += uint32(n)
+		}
+		data = data[n:]
+	}
+}
+
+func (p *Pcapng) close(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stream, ok := p.streams[id]
+	if !ok {
+		return
+	}
+	delete(p.streams, id)
+	p.writeTcp(stream, true, tcpFin|tcpAck, nil)
+	stream.localSeq++
+	p.writeTcp(stream, false, tcpFin|tcpAck, nil)
+	stream.remoteSeq++
+	p.writeTcp(stream, true, tcpAck, nil)
+}
+
+// use the real tcp addresses, or synthesize one for other connections such as net.Pipe
+func (p *Pcapng) newStream(local, remote net.Addr) *pcapngStream {
+	l, lok := local.(*net.TCPAddr)
+	r, rok := remote.(*net.TCPAddr)
+	if !lok || !rok || (l.IP.To4() == nil) != (r.IP.To4() == nil) {
+		l = &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 8080}
+		r = &net.TCPAddr{IP: net.IPv4(10, 0, 1, p.nextIP), Port: 10000 + int(p.nextIP)}
+		p.nextIP++
+		if p.nextIP == 0 {
+			p.nextIP = 1
+		}
+	}
+	return &pcapngStream{local: l, remote: r}
+}
+
+func (p *Pcapng) writeTcp(stream *pcapngStream, fromLocal bool, flags byte, payload []byte) {
+	src, dst := stream.remote, stream.local
+	seq, ack := stream.remoteSeq, stream.localSeq
+	if fromLocal {
+		src, dst = stream.local, stream.remote
+		seq, ack = stream.localSeq, stream.remoteSeq
+	}
+	if flags&tcpAck == 0 {
+		ack = 0
+	}
+
+	tcp := make([]byte, 20, 20+len(payload))
+	binary.BigEndian.PutUint16(tcp[0:], uint16(src.Port))
+	binary.BigEndian.PutUint16(tcp[2:], uint16(dst.Port))
+	binary.BigEndian.PutUint32(tcp[4:], seq)
+	binary.BigEndian.PutUint32(tcp[8:], ack)
+	tcp[12] = 5 << 4 // data offset
+	tcp[13] = flags
+	binary.BigEndian.PutUint16(tcp[14:], 65535) // window
+	tcp = append(tcp, payload...)
+
+	var ip []byte
+	if src4, dst4 := src.IP.To4(), dst.IP.To4(); src4 != nil && dst4 != nil {
+		ip = make([]byte, 20, 20+len(tcp))
+		ip[0] = 0x45
+		binary.BigEndian.PutUint16(ip[2:], uint16(20+len(tcp)))
+		ip[6] = 0x40 // don't fragment
+		ip[8] = 64   // ttl
+		ip[9] = 6    // tcp
+		copy(ip[12:16], src4)
+		copy(ip[16:20], dst4)
+		binary.BigEndian.PutUint16(ip[10:], ipChecksum(ip))
+	} else {
+		ip = make([]byte, 40, 40+len(tcp))
+		ip[0] = 0x60
+		binary.BigEndian.PutUint16(ip[4:], uint16(len(tcp)))
+		ip[6] = 6  // tcp
+		ip[7] = 64 // hop limit
+		copy(ip[8:24], src.IP.To16())
+		copy(ip[24:40], dst.IP.To16())
+	}
+	// tcp checksum is left 0, Wireshark does not validate it by default
+	p.writePacket(append(ip, tcp...))
+}
+
+func ipChecksum(header []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(header); i += 2 {
+		sum += uint32(header[i])<<8 | uint32(header[i+1])
+	}
+	for sum > 0xffff {
+		sum = sum>>16 + sum&0xffff
+	}
+	return ^uint16(sum)
+}
+
+func (p *Pcapng) writeHeader() {
+	shb := make([]byte, 16)
+	binary.LittleEndian.PutUint32(shb[0:], 0x1A2B3C4D) // byte-order magic
+	binary.LittleEndian.PutUint16(shb[4:], 1)          // major version
+	binary.LittleEndian.PutUint16(shb[6:], 0)          // minor version
+	binary.LittleEndian.PutUint64(shb[8:], 0xFFFFFFFFFFFFFFFF)
+	p.writeBlock(pcapngSectionHeader, shb)
+
+	idb := make([]byte, 8)
+	binary.LittleEndian.PutUint16(idb[0:], pcapngLinkTypeRaw)
+	binary.LittleEndian.PutUint32(idb[4:], 0) // no snap length limit
+	p.writeBlock(pcapngInterfaceDesc, idb)
+}
+
+func (p *Pcapng) writePacket(data []byte) {
+	ts := uint64(time.Now().UnixMicro())
+	body := make([]byte, 20, 20+len(data)+3)
+	binary.LittleEndian.PutUint32(body[0:], 0) // interface id
+	binary.LittleEndian.PutUint32(body[4:], uint32(ts>>32))
+	binary.LittleEndian.PutUint32(body[8:], uint32(ts))
+	binary.LittleEndian.PutUint32(body[12:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(body[16:], uint32(len(data)))
+	body = append(body, data...)
+	p.writeBlock(pcapngEnhancedPacket, body)
+}
+
+// write block, pad body to 32 bits
+func (p *Pcapng) writeBlock(blockType uint32, body []byte) {
+	if p.err != nil {
+		return
+	}
+	for len(body)%4 != 0 {
+		body = append(body, 0)
+	}
+	total := uint32(12 + len(body))
+	block := make([]byte, 8, total)
+	binary.LittleEndian.PutUint32(block[0:], blockType)
+	binary.LittleEndian.PutUint32(block[4:], total)
+	block = append(block, body...)
+	block = binary.LittleEndian.AppendUint32(block, total)
+	if _, err := p.out.Write(block); err != nil {
+		log.Errorf("pcapng write error: %v", err)
+		p.err = err
+	}
+}
@@ -0,0 +1,73 @@
+package addon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+func TestPcapng(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewPcapng(buf)
+	local := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 9080}
+	remote := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 50000}
+	p.Write([]byte("CLIENT_RANDOM 00 11\n"))
+	p.packet("c1", local, remote, []byte("hello"), proxy.DirectionRead)
+	p.packet("c1", local, remote, []byte("world"), proxy.DirectionWrite)
+	p.close("c1")
+
+	var types []uint32
+	var packets [][]byte
+	data := buf.Bytes()
+	for len(data) > 0 {
+		blockType := binary.LittleEndian.Uint32(data[0:])
+		total := binary.LittleEndian.Uint32(data[4:])
+		if total%4 != 0 || binary.LittleEndian.Uint32(data[total-4:]) != total {
+			t.Fatalf("invalid block length %v", total)
+		}
+		types = append(types, blockType)
+		switch blockType {
+		case pcapngEnhancedPacket:
+			capLen := binary.LittleEndian.Uint32(data[20:])
+			packets = append(packets, data[28:28+capLen])
+		case pcapngDecryptionSecrets:
+			if n := binary.LittleEndian.Uint32(data[12:]); string(data[16:16+n]) != "CLIENT_RANDOM 00 11\n" {
+				t.Fatalf("unexpected secrets %q", data[16:16+n])
+			}
+		}
+		data = data[total:]
+	}
+
+	expected := []uint32{pcapngSectionHeader, pcapngInterfaceDesc, pcapngDecryptionSecrets}
+	for i := 0; i < 3+2+3; i++ { // handshake, data, close
+		expected = append(expected, pcapngEnhancedPacket)
+	}
+	if len(types) != len(expected) {
+		t.Fatalf("expected blocks %v, but got %v", expected, types)
+	}
+	for i := range types {
+		if types[i] != expected[i] {
+			t.Fatalf("expected blocks %v, but got %v", expected, types)
+		}
+	}
+
+	// ipv4 + tcp header
+	if payload := packets[3][40:]; string(payload) != "hello" {
+		t.Fatalf("expected hello, but got %q", payload)
+	}
+	if srcPort := binary.BigEndian.Uint16(packets[3][20:]); srcPort != 50000 {
+		t.Fatalf("expected hello sent from client port, but got %v", srcPort)
+	}
+	if payload := packets[4][40:]; string(payload) != "world" {
+		t.Fatalf("expected world, but got %q", payload)
+	}
+	if seq := binary.BigEndian.Uint32(packets[4][24:]); seq != 1 {
+		t.Fatalf("expected seq 1 of proxy side, but got %v", seq)
+	}
+	if ack := binary.BigEndian.Uint32(packets[4][28:]); ack != 6 {
+		t.Fatalf("expected ack 6, but got %v", ack)
+	}
+}
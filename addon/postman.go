@@ -0,0 +1,191 @@
+package addon
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// Postman Collection Format v2.1
+type postmanCollection struct {
+	Info     postmanInfo       `json:"info"`
+	Item     []*postmanFolder  `json:"item"`
+	Variable []postmanVariable `json:"variable"`
+}
+
+type postmanInfo struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+type postmanVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type postmanFolder struct {
+	Name string         `json:"name"`
+	Item []*postmanItem `json:"item"`
+}
+
+type postmanItem struct {
+	Name     string            `json:"name"`
+	Request  *postmanRequest   `json:"request"`
+	Response []postmanResponse `json:"response"`
+}
+
+type postmanRequest struct {
+	Method string          `json:"method"`
+	Header []postmanHeader `json:"header"`
+	Body   *postmanBody    `json:"body,omitempty"`
+	URL    postmanURL      `json:"url"`
+}
+
+type postmanHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type postmanBody struct {
+	Mode string `json:"mode"`
+	Raw  string `json:"raw"`
+}
+
+type postmanURL struct {
+	Raw   string          `json:"raw"`
+	Host  []string        `json:"host"`
+	Path  []string        `json:"path,omitempty"`
+	Query []postmanHeader `json:"query,omitempty"`
+}
+
+type postmanResponse struct {
+	Name            string          `json:"name"`
+	OriginalRequest *postmanRequest `json:"originalRequest"`
+	Status          string          `json:"status"`
+	Code            int             `json:"code"`
+	Header          []postmanHeader `json:"header"`
+	Body            string          `json:"body"`
+}
+
+// ExportPostman write the flows as a Postman v2.1 collection, requests are grouped by host.
+// The scheme and host of each group is the variable "baseUrl_<host>", such as {{baseUrl_example_com}}, to be replaced for other environments.
+// The response of the flow is kept as the example response. CONNECT flows are skipped.
+// Folders and requests are ordered by the start time of request, then by flow id.
+func ExportPostman(w io.Writer, flows []*proxy.Flow) error {
+	collection := &postmanCollection{
+		Info:     postmanInfo{Name: "go-mitmproxy", Schema: postmanSchema},
+		Item:     make([]*postmanFolder, 0),
+		Variable: make([]postmanVariable, 0),
+	}
+	folders := make(map[string]*postmanFolder)
+
+	for _, f := range sortFlows(flows) {
+		if f.Request == nil || f.Request.Method == http.MethodConnect {
+			continue
+		}
+		host := f.Request.URL.Host
+		folder, ok := folders[host]
+		if !ok {
+			folder = &postmanFolder{Name: host, Item: make([]*postmanItem, 0)}
+			folders[host] = folder
+			collection.Item = append(collection.Item, folder)
+			collection.Variable = append(collection.Variable, postmanVariable{
+				Key:   postmanBaseUrlKey(host),
+				Value: f.Request.URL.Scheme + "://" + host,
+			})
+		}
+
+		req := newPostmanRequest(f.Request)
+		item := &postmanItem{
+			Name:     f.Request.Method + " " + f.Request.URL.Path,
+			Request:  req,
+			Response: make([]postmanResponse, 0),
+		}
+		if f.Response != nil {
+			item.Response = append(item.Response, newPostmanResponse(item.Name, req, f.Response))
+		}
+		folder.Item = append(folder.Item, item)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(collection)
+}
+
+// variable names of postman are better without dots and colons
+func postmanBaseUrlKey(host string) string {
+	return "baseUrl_" + strings.NewReplacer(".", "_", ":", "_", "-", "_").Replace(host)
+}
+
+func newPostmanRequest(r *proxy.Request) *postmanRequest {
+	baseUrl := "{{" + postmanBaseUrlKey(r.URL.Host) + "}}"
+	u := postmanURL{
+		Raw:  baseUrl + r.URL.RequestURI(),
+		Host: []string{baseUrl},
+	}
+	if path := strings.Trim(r.URL.Path, "/"); path != "" {
+		u.Path = strings.Split(path, "/")
+	}
+	query := r.URL.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range query[k] {
+			u.Query = append(u.Query, postmanHeader{Key: k, Value: v})
+		}
+	}
+
+	req := &postmanRequest{
+		Method: r.Method,
+		Header: postmanHeaders(r.Header),
+		URL:    u,
+	}
+	if len(r.Body) > 0 {
+		req.Body = &postmanBody{Mode: "raw", Raw: string(r.Body)}
+	}
+	return req
+}
+
+func newPostmanResponse(name string, req *postmanRequest, r *proxy.Response) postmanResponse {
+	header := r.Header
+	body := r.Body
+	// postman shows the body as is, decode it
+	if decoded, err := r.DecodedBody(); err == nil && r.Header.Get("Content-Encoding") != "" {
+		body = decoded
+		header = header.Clone()
+		header.Del("Content-Encoding")
+		header.Del("Content-Length")
+	}
+	return postmanResponse{
+		Name:            name,
+		OriginalRequest: req,
+		Status:          http.StatusText(r.StatusCode),
+		Code:            r.StatusCode,
+		Header:          postmanHeaders(header),
+		Body:            string(body),
+	}
+}
+
+func postmanHeaders(header http.Header) []postmanHeader {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	headers := make([]postmanHeader, 0, len(keys))
+	for _, k := range keys {
+		for _, v := range header[k] {
+			headers = append(headers, postmanHeader{Key: k, Value: v})
+		}
+	}
+	return headers
+}
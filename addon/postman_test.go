@@ -0,0 +1,62 @@
+package addon
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+func TestExportPostman(t *testing.T) {
+	newFlow := func(method, rawURL, body string, status int) *proxy.Flow {
+		u, _ := url.Parse(rawURL)
+		f := &proxy.Flow{
+			Request: &proxy.Request{Method: method, URL: u, Header: http.Header{"Accept": {"*/*"}}, Body: []byte(body)},
+		}
+		if status > 0 {
+			f.Response = &proxy.Response{StatusCode: status, Header: http.Header{"Content-Type": {"application/json"}}, Body: []byte(`{"ok":true}`)}
+		}
+		return f
+	}
+	flows := []*proxy.Flow{
+		newFlow("CONNECT", "https://api.example.com:443", "", 200),
+		newFlow("GET", "https://api.example.com/users?page=2", "", 200),
+		newFlow("POST", "http://other.com/login", "user=a", 0),
+		newFlow("DELETE", "https://api.example.com/users/1", "", 204),
+	}
+
+	buf := new(bytes.Buffer)
+	if err := ExportPostman(buf, flows); err != nil {
+		t.Fatal(err)
+	}
+	collection := new(postmanCollection)
+	if err := json.Unmarshal(buf.Bytes(), collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if collection.Info.Schema != postmanSchema {
+		t.Fatalf("unexpected schema %v", collection.Info.Schema)
+	}
+	if len(collection.Item) != 2 || collection.Item[0].Name != "api.example.com" || len(collection.Item[0].Item) != 2 || collection.Item[1].Name != "other.com" {
+		t.Fatalf("expected grouped by host, got %+v", collection.Item)
+	}
+	if len(collection.Variable) != 2 || collection.Variable[0] != (postmanVariable{Key: "baseUrl_api_example_com", Value: "https://api.example.com"}) {
+		t.Fatalf("unexpected variables %+v", collection.Variable)
+	}
+
+	get := collection.Item[0].Item[0]
+	if get.Request.URL.Raw != "{{baseUrl_api_example_com}}/users?page=2" || len(get.Request.URL.Query) != 1 || get.Request.URL.Path[0] != "users" {
+		t.Fatalf("unexpected url %+v", get.Request.URL)
+	}
+	if len(get.Response) != 1 || get.Response[0].Code != 200 || get.Response[0].Body != `{"ok":true}` {
+		t.Fatalf("unexpected response %+v", get.Response)
+	}
+
+	post := collection.Item[1].Item[0]
+	if post.Request.Body == nil || post.Request.Body.Raw != "user=a" || len(post.Response) != 0 {
+		t.Fatalf("unexpected post %+v", post)
+	}
+}
@@ -0,0 +1,68 @@
+package addon
+
+import (
+	"net/http"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+// RequestID make sure the requests sent to server carry a request id header, to correlate the logs of client and server
+// without a tracing stack. The id of the request is echoed in the response to client.
+//
+// A missing id is generated from the id of the flow, so it also matches the logs of the proxy, or the request is
+// replied with 400 if Require.
+type RequestID struct {
+	proxy.BaseAddon
+	Header  string // X-Request-Id if empty
+	Require bool
+}
+
+func NewRequestID(header string, require bool) *RequestID {
+	return &RequestID{Header: header, Require: require}
+}
+
+func (r *RequestID) header() string {
+	if r.Header == "" {
+		return "X-Request-Id"
+	}
+	return r.Header
+}
+
+func (r *RequestID) Requestheaders(f *proxy.Flow) {
+	if f.Request.Method == "CONNECT" {
+		return
+	}
+	header := r.header()
+	if f.Request.Header.Get(header) != "" {
+		return
+	}
+	if r.Require {
+		f.Response = &proxy.Response{
+			StatusCode: http.StatusBadRequest,
+			Header:     http.Header{"Content-Type": {"text/plain; charset=utf-8"}},
+			Body:       []byte("missing " + header),
+		}
+		return
+	}
+	f.Request.Header.Set(header, f.Id)
+}
+
+// the streamed responses are not passed to Response
+func (r *RequestID) Responseheaders(f *proxy.Flow) {
+	r.echo(f)
+}
+
+// the responses replied by addons are not passed to Responseheaders
+func (r *RequestID) Response(f *proxy.Flow) {
+	r.echo(f)
+}
+
+func (r *RequestID) echo(f *proxy.Flow) {
+	header := r.header()
+	if id := f.Request.Header.Get(header); id != "" {
+		if f.Response.Header == nil {
+			f.Response.Header = http.Header{}
+		}
+		f.Response.Header.Set(header, id)
+	}
+}
@@ -0,0 +1,53 @@
+package addon
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+func TestRequestID(t *testing.T) {
+	newFlow := func(header http.Header) *proxy.Flow {
+		u, _ := url.Parse("http://example.com/")
+		return &proxy.Flow{
+			Id:      "flow-id",
+			Request: &proxy.Request{Method: "GET", URL: u, Header: header},
+		}
+	}
+	reply := func(r *RequestID, f *proxy.Flow) {
+		r.Requestheaders(f)
+		if f.Response == nil {
+			f.Response = &proxy.Response{StatusCode: 200, Header: http.Header{}}
+			r.Responseheaders(f)
+		}
+		r.Response(f)
+	}
+
+	r := NewRequestID("", false)
+	// generated
+	f := newFlow(http.Header{})
+	reply(r, f)
+	if f.Request.Header.Get("X-Request-Id") != "flow-id" || f.Response.Header.Get("X-Request-Id") != "flow-id" {
+		t.Fatalf("expected generated id sent and echoed, but got %q %q", f.Request.Header.Get("X-Request-Id"), f.Response.Header.Get("X-Request-Id"))
+	}
+	// kept
+	f = newFlow(http.Header{"X-Request-Id": {"client-id"}})
+	reply(r, f)
+	if f.Request.Header.Get("X-Request-Id") != "client-id" || f.Response.Header.Get("X-Request-Id") != "client-id" {
+		t.Fatalf("expected id of client kept, but got %q %q", f.Request.Header.Get("X-Request-Id"), f.Response.Header.Get("X-Request-Id"))
+	}
+
+	r = NewRequestID("X-Trace", true)
+	f = newFlow(http.Header{})
+	reply(r, f)
+	if f.Response.StatusCode != http.StatusBadRequest || string(f.Response.Body) != "missing X-Trace" {
+		t.Fatalf("expected 400 of missing id, but got %v %q", f.Response.StatusCode, f.Response.Body)
+	}
+	f = newFlow(http.Header{"X-Trace": {"abc"}})
+	reply(r, f)
+	if f.Response.StatusCode != 200 || f.Response.Header.Get("X-Trace") != "abc" {
+		t.Fatalf("expected id echoed, but got %v %v", f.Response.StatusCode, f.Response.Header)
+	}
+}
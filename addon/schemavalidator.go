@@ -0,0 +1,374 @@
+package addon
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/match"
+)
+
+// SchemaRule the JSON schema of the responses of an endpoint.
+// Supported keywords: type, enum, const, properties, required, additionalProperties, items, minItems, maxItems,
+// minLength, maxLength, pattern, minimum, maximum, exclusiveMinimum, exclusiveMaximum, allOf, anyOf, oneOf, not,
+// the others are ignored.
+type SchemaRule struct {
+	URLFilter string          // pattern of the request url, such as "*api.example.com/users/*", empty matches all
+	Method    []string        // empty matches all
+	Status    int             // status code of the response, 0 matches all
+	Schema    json.RawMessage // JSON schema
+}
+
+// SchemaViolation the response not valid against the schema
+type SchemaViolation struct {
+	Flow   *proxy.Flow
+	Rule   *SchemaRule
+	Errors []string // such as "$.items[0].id: expected integer, got string"
+}
+
+// SchemaValidator validate json response bodies against the schema of the first matched rule.
+// Violations are logged and passed to OnViolation, the response is not changed unless Block is set.
+type SchemaValidator struct {
+	proxy.BaseAddon
+	Block       bool // replace the violating response with 502
+	OnViolation func(v *SchemaViolation)
+
+	rules      []*schemaRule
+	violations atomic.Int64
+}
+
+type schemaRule struct {
+	SchemaRule
+	schema *jsonSchema
+}
+
+func NewSchemaValidator(rules []SchemaRule) (*SchemaValidator, error) {
+	sv := &SchemaValidator{}
+	for _, rule := range rules {
+		var v interface{}
+		if err := json.Unmarshal(rule.Schema, &v); err != nil {
+			return nil, fmt.Errorf("schema validator: %v: %w", rule.URLFilter, err)
+		}
+		schema, err := parseJSONSchema(v)
+		if err != nil {
+			return nil, fmt.Errorf("schema validator: %v: %w", rule.URLFilter, err)
+		}
+		sv.rules = append(sv.rules, &schemaRule{SchemaRule: rule, schema: schema})
+	}
+	return sv, nil
+}
+
+// Violations the count of the violating responses
+func (sv *SchemaValidator) Violations() int64 {
+	return sv.violations.Load()
+}
+
+func (sv *SchemaValidator) Response(f *proxy.Flow) {
+	if f.Response == nil || f.Response.BodyReader != nil {
+		return
+	}
+	rule := sv.match(f)
+	if rule == nil {
+		return
+	}
+	body, err := f.Response.DecodedBody()
+	if err != nil || len(body) == 0 {
+		return
+	}
+
+	var errs []string
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		errs = append(errs, "$: invalid json: "+err.Error())
+	} else {
+		rule.schema.validate(v, "$", &errs)
+	}
+	if len(errs) == 0 {
+		return
+	}
+
+	sv.violations.Add(1)
+	log.Warnf("schema validator: %v %v: %v", f.Request.Method, f.Request.URL, strings.Join(errs, "; "))
+	if sv.OnViolation != nil {
+		sv.OnViolation(&SchemaViolation{Flow: f, Rule: &rule.SchemaRule, Errors: errs})
+	}
+	if sv.Block {
+		data, _ := json.Marshal(map[string]interface{}{"error": "schema violation", "violations": errs})
+		f.Response = &proxy.Response{
+			StatusCode: http.StatusBadGateway,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       data,
+		}
+	}
+}
+
+func (sv *SchemaValidator) match(f *proxy.Flow) *schemaRule {
+	for _, rule := range sv.rules {
+		if rule.URLFilter != "" && !match.Match(f.Request.URL.String(), rule.URLFilter) {
+			continue
+		}
+		if len(rule.Method) > 0 && !lo.Contains(rule.Method, f.Request.Method) {
+			continue
+		}
+		if rule.Status != 0 && rule.Status != f.Response.StatusCode {
+			continue
+		}
+		return rule
+	}
+	return nil
+}
+
+type jsonSchema struct {
+	reject bool // the false schema
+
+	types                []string
+	enum                 []interface{}
+	properties           map[string]*jsonSchema
+	required             []string
+	additionalProperties *jsonSchema
+	items                *jsonSchema
+	minItems, maxItems   *float64
+	minLength, maxLength *float64
+	pattern              *regexp.Regexp
+	minimum, maximum     *float64
+	exclusiveMin         *float64
+	exclusiveMax         *float64
+	allOf, anyOf, oneOf  []*jsonSchema
+	not                  *jsonSchema
+}
+
+func parseJSONSchema(v interface{}) (*jsonSchema, error) {
+	switch v := v.(type) {
+	case bool:
+		return &jsonSchema{reject: !v}, nil
+	case map[string]interface{}:
+		return parseJSONSchemaObject(v)
+	}
+	return nil, fmt.Errorf("invalid schema %v", v)
+}
+
+func parseJSONSchemaObject(m map[string]interface{}) (*jsonSchema, error) {
+	s := &jsonSchema{}
+	var err error
+
+	switch t := m["type"].(type) {
+	case nil:
+	case string:
+		s.types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid type %v", t)
+			}
+			s.types = append(s.types, name)
+		}
+	default:
+		return nil, fmt.Errorf("invalid type %v", t)
+	}
+
+	if enum, ok := m["enum"].([]interface{}); ok {
+		s.enum = enum
+	}
+	if c, ok := m["const"]; ok {
+		s.enum = []interface{}{c}
+	}
+
+	if props, ok := m["properties"].(map[string]interface{}); ok {
+		s.properties = make(map[string]*jsonSchema, len(props))
+		for name, prop := range props {
+			if s.properties[name], err = parseJSONSchema(prop); err != nil {
+				return nil, fmt.Errorf("properties.%v: %w", name, err)
+			}
+		}
+	}
+	if required, ok := m["required"].([]interface{}); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				s.required = append(s.required, name)
+			}
+		}
+	}
+	if ap, ok := m["additionalProperties"]; ok {
+		if s.additionalProperties, err = parseJSONSchema(ap); err != nil {
+			return nil, fmt.Errorf("additionalProperties: %w", err)
+		}
+	}
+	if items, ok := m["items"]; ok {
+		if s.items, err = parseJSONSchema(items); err != nil {
+			return nil, fmt.Errorf("items: %w", err)
+		}
+	}
+	if not, ok := m["not"]; ok {
+		if s.not, err = parseJSONSchema(not); err != nil {
+			return nil, fmt.Errorf("not: %w", err)
+		}
+	}
+	for key, list := range map[string]*[]*jsonSchema{"allOf": &s.allOf, "anyOf": &s.anyOf, "oneOf": &s.oneOf} {
+		schemas, ok := m[key].([]interface{})
+		if !ok {
+			continue
+		}
+		for i, item := range schemas {
+			sub, err := parseJSONSchema(item)
+			if err != nil {
+				return nil, fmt.Errorf("%v[%d]: %w", key, i, err)
+			}
+			*list = append(*list, sub)
+		}
+	}
+
+	if pattern, ok := m["pattern"].(string); ok {
+		if s.pattern, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("pattern: %w", err)
+		}
+	}
+	for key, field := range map[string]**float64{
+		"minItems": &s.minItems, "maxItems": &s.maxItems,
+		"minLength": &s.minLength, "maxLength": &s.maxLength,
+		"minimum": &s.minimum, "maximum": &s.maximum,
+		"exclusiveMinimum": &s.exclusiveMin, "exclusiveMaximum": &s.exclusiveMax,
+	} {
+		if n, ok := m[key].(float64); ok {
+			*field = &n
+		}
+	}
+	return s, nil
+}
+
+func (s *jsonSchema) validate(v interface{}, path string, errs *[]string) {
+	if s.reject {
+		*errs = append(*errs, path+": not allowed")
+		return
+	}
+
+	addf := func(format string, args ...interface{}) {
+		*errs = append(*errs, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if len(s.types) > 0 && !lo.ContainsBy(s.types, func(t string) bool { return jsonTypeIs(v, t) }) {
+		addf("expected %v, got %v", strings.Join(s.types, " or "), jsonTypeOf(v))
+		return
+	}
+	if s.enum != nil && !lo.ContainsBy(s.enum, func(e interface{}) bool { return reflect.DeepEqual(e, v) }) {
+		addf("value not in enum")
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				addf("missing required property %q", name)
+			}
+		}
+		for name, value := range v {
+			if prop, ok := s.properties[name]; ok {
+				prop.validate(value, path+"."+name, errs)
+			} else if s.additionalProperties != nil {
+				s.additionalProperties.validate(value, path+"."+name, errs)
+			}
+		}
+	case []interface{}:
+		if s.minItems != nil && float64(len(v)) < *s.minItems {
+			addf("expected at least %v items, got %d", *s.minItems, len(v))
+		}
+		if s.maxItems != nil && float64(len(v)) > *s.maxItems {
+			addf("expected at most %v items, got %d", *s.maxItems, len(v))
+		}
+		if s.items != nil {
+			for i, item := range v {
+				s.items.validate(item, path+"["+strconv.Itoa(i)+"]", errs)
+			}
+		}
+	case string:
+		n := float64(len([]rune(v)))
+		if s.minLength != nil && n < *s.minLength {
+			addf("expected length at least %v, got %v", *s.minLength, n)
+		}
+		if s.maxLength != nil && n > *s.maxLength {
+			addf("expected length at most %v, got %v", *s.maxLength, n)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			addf("%q not match pattern %v", v, s.pattern)
+		}
+	case float64:
+		if s.minimum != nil && v < *s.minimum {
+			addf("expected minimum %v, got %v", *s.minimum, v)
+		}
+		if s.maximum != nil && v > *s.maximum {
+			addf("expected maximum %v, got %v", *s.maximum, v)
+		}
+		if s.exclusiveMin != nil && v <= *s.exclusiveMin {
+			addf("expected greater than %v, got %v", *s.exclusiveMin, v)
+		}
+		if s.exclusiveMax != nil && v >= *s.exclusiveMax {
+			addf("expected less than %v, got %v", *s.exclusiveMax, v)
+		}
+	}
+
+	for _, sub := range s.allOf {
+		sub.validate(v, path, errs)
+	}
+	if len(s.anyOf) > 0 && s.countValid(s.anyOf, v) == 0 {
+		addf("not valid against anyOf")
+	}
+	if len(s.oneOf) > 0 {
+		if n := s.countValid(s.oneOf, v); n != 1 {
+			addf("expected valid against exactly one of oneOf, got %d", n)
+		}
+	}
+	if s.not != nil && s.countValid([]*jsonSchema{s.not}, v) == 1 {
+		addf("should not be valid against not")
+	}
+}
+
+func (s *jsonSchema) countValid(schemas []*jsonSchema, v interface{}) int {
+	n := 0
+	for _, sub := range schemas {
+		var errs []string
+		sub.validate(v, "", &errs)
+		if len(errs) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func jsonTypeIs(v interface{}, t string) bool {
+	if t == "integer" {
+		n, ok := v.(float64)
+		return ok && n == math.Trunc(n)
+	}
+	if t == "number" {
+		_, ok := v.(float64)
+		return ok
+	}
+	return jsonTypeOf(v) == t
+}
+
+func jsonTypeOf(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "unknown"
+}
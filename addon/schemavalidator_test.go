@@ -0,0 +1,92 @@
+package addon
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+func TestSchemaValidator(t *testing.T) {
+	userSchema := `{
+		"type": "object",
+		"required": ["id", "name"],
+		"additionalProperties": false,
+		"properties": {
+			"id": {"type": "integer", "minimum": 1},
+			"name": {"type": "string", "minLength": 1},
+			"role": {"enum": ["admin", "user"]},
+			"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2}
+		}
+	}`
+	newValidator := func(block bool) *SchemaValidator {
+		t.Helper()
+		sv, err := NewSchemaValidator([]SchemaRule{
+			{URLFilter: "*api.example.com/users/*", Method: []string{"GET"}, Status: 200, Schema: []byte(userSchema)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sv.Block = block
+		return sv
+	}
+	newFlow := func(rawURL string, status int, body string) *proxy.Flow {
+		u, _ := url.Parse(rawURL)
+		return &proxy.Flow{
+			Request:  &proxy.Request{Method: "GET", URL: u, Header: http.Header{}},
+			Response: &proxy.Response{StatusCode: status, Header: http.Header{"Content-Type": {"application/json"}}, Body: []byte(body)},
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		sv := newValidator(false)
+		sv.OnViolation = func(v *SchemaViolation) { t.Fatalf("unexpected violation %v", v.Errors) }
+		sv.Response(newFlow("https://api.example.com/users/1", 200, `{"id":1,"name":"a","role":"admin","tags":["x"]}`))
+		sv.Response(newFlow("https://api.example.com/users/1", 404, `{"error":"not found"}`))
+		sv.Response(newFlow("https://api.example.com/orders/1", 200, `[]`))
+		if sv.Violations() != 0 {
+			t.Fatal("expected no violation")
+		}
+	})
+
+	t.Run("violation", func(t *testing.T) {
+		sv := newValidator(false)
+		var errs []string
+		sv.OnViolation = func(v *SchemaViolation) { errs = v.Errors }
+		body := `{"id":1.5,"role":"root","tags":["x",2,"z"],"extra":true}`
+		f := newFlow("https://api.example.com/users/1", 200, body)
+		sv.Response(f)
+		if sv.Violations() != 1 || string(f.Response.Body) != body {
+			t.Fatalf("expected violation recorded without changing response, got %v %s", sv.Violations(), f.Response.Body)
+		}
+		want := []string{
+			`$: missing required property "name"`,
+			"$.id: expected integer, got number",
+			"$.role: value not in enum",
+			"$.tags: expected at most 2 items, got 3",
+			"$.tags[1]: expected string, got number",
+			"$.extra: not allowed",
+		}
+		got := strings.Join(errs, "\n")
+		for _, w := range want {
+			if !strings.Contains(got, w) {
+				t.Fatalf("expected %q in violations:\n%v", w, got)
+			}
+		}
+	})
+
+	t.Run("block", func(t *testing.T) {
+		sv := newValidator(true)
+		f := newFlow("https://api.example.com/users/1", 200, `not json`)
+		sv.Response(f)
+		if f.Response.StatusCode != 502 || !strings.Contains(string(f.Response.Body), "invalid json") {
+			t.Fatalf("expected blocked response, got %v %s", f.Response.StatusCode, f.Response.Body)
+		}
+	})
+
+	if _, err := NewSchemaValidator([]SchemaRule{{Schema: []byte(`{"pattern": "("}`)}}); err == nil {
+		t.Fatal("expected error of invalid pattern")
+	}
+}
@@ -0,0 +1,174 @@
+// Package script runs a Starlark script as an addon, to read and modify the flows without writing and compiling Go.
+// It is a subpackage so the proxy itself does not depend on the interpreter.
+//
+// The script defines the hooks it needs, both are optional and get the flow as the only argument:
+//
+//	def request(flow):
+//	    flow.request.headers.delete("Authorization")
+//
+//	def response(flow):
+//	    if flow.response.status_code == 404:
+//	        flow.response.body = "not found"
+//
+// The flow has the attributes:
+//
+//	flow.id                         string
+//	flow.request.method             string, settable
+//	flow.request.url                string, settable
+//	flow.request.host               string
+//	flow.request.path               string
+//	flow.request.headers            headers
+//	flow.request.body               string, settable with string or bytes
+//	flow.response                   None before the response is received or replied
+//	flow.response.status_code       int, settable
+//	flow.response.headers           headers
+//	flow.response.body              string, decoded by Content-Encoding, settable with string or bytes
+//	flow.reply(status_code, body="", headers={})
+//	                                reply the client without sending the request to server, in the request hook
+//
+// and the headers:
+//
+//	headers[name]                   the first value, error if missing
+//	headers[name] = value           replace the values
+//	name in headers
+//	headers.get(name, default=None) the first value
+//	headers.add(name, value)        add a value
+//	headers.delete(name)            delete the values
+//	headers.keys()                  the names, sorted
+//
+// print writes to the log. An error of the script, such as a failed call, sets Flow.AddonError, the flow is then
+// blocked or forwarded by proxy.Options.AddonErrorPolicy. The hooks are not called for the flows in Stream mode.
+package script
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+	log "github.com/sirupsen/logrus"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// the script file is checked for change at most once in the interval, when the flows arrive
+var pollInterval = time.Second
+
+// Script runs the hooks of a Starlark script file, the script is reloaded when the file changes.
+// A script failed to reload is logged and the previous one is kept.
+type Script struct {
+	proxy.BaseAddon
+	filename string
+
+	mu        sync.Mutex
+	loaded    *loadedScript
+	checkedAt time.Time
+}
+
+type loadedScript struct {
+	request  starlark.Callable
+	response starlark.Callable
+	modTime  time.Time
+	size     int64
+}
+
+// New loads the script file, an error is returned if it can not be read or run.
+func New(filename string) (*Script, error) {
+	loaded, err := load(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &Script{filename: filename, loaded: loaded, checkedAt: time.Now()}, nil
+}
+
+func load(filename string) (*loadedScript, error) {
+	stat, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, newThread(filename), filename, src, nil)
+	if err != nil {
+		return nil, fmt.Errorf("script %v: %w", filename, err)
+	}
+	loaded := &loadedScript{modTime: stat.ModTime(), size: stat.Size()}
+	if loaded.request, err = lookupHook(globals, "request"); err != nil {
+		return nil, fmt.Errorf("script %v: %w", filename, err)
+	}
+	if loaded.response, err = lookupHook(globals, "response"); err != nil {
+		return nil, fmt.Errorf("script %v: %w", filename, err)
+	}
+	return loaded, nil
+}
+
+// nil if the script does not define the hook
+func lookupHook(globals starlark.StringDict, name string) (starlark.Callable, error) {
+	v, ok := globals[name]
+	if !ok {
+		return nil, nil
+	}
+	fn, ok := v.(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("%v is %v, not a function", name, v.Type())
+	}
+	return fn, nil
+}
+
+func newThread(name string) *starlark.Thread {
+	return &starlark.Thread{
+		Name: name,
+		Print: func(_ *starlark.Thread, msg string) {
+			log.Infof("script: %v", msg)
+		},
+	}
+}
+
+// the loaded script, reloaded if the file changed since it was checked
+func (s *Script) current() *loadedScript {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.checkedAt) < pollInterval {
+		return s.loaded
+	}
+	s.checkedAt = time.Now()
+	stat, err := os.Stat(s.filename)
+	if err != nil {
+		log.Warnf("script: %v", err)
+		return s.loaded
+	}
+	if stat.ModTime().Equal(s.loaded.modTime) && stat.Size() == s.loaded.size {
+		return s.loaded
+	}
+	loaded, err := load(s.filename)
+	if err != nil {
+		log.Warnf("reload script error, keep the previous script: %v", err)
+		// not retry until the file changes again
+		s.loaded = &loadedScript{request: s.loaded.request, response: s.loaded.response, modTime: stat.ModTime(), size: stat.Size()}
+		return s.loaded
+	}
+	s.loaded = loaded
+	log.Infof("script reloaded: %v", s.filename)
+	return s.loaded
+}
+
+func (s *Script) Request(f *proxy.Flow) {
+	s.call(f, s.current().request)
+}
+
+func (s *Script) Response(f *proxy.Flow) {
+	s.call(f, s.current().response)
+}
+
+func (s *Script) call(f *proxy.Flow, hook starlark.Callable) {
+	if hook == nil {
+		return
+	}
+	// the globals are frozen after the script is run, so the hooks can be called by the flows concurrently
+	if _, err := starlark.Call(newThread(s.filename), hook, starlark.Tuple{&flowValue{f: f}}, nil); err != nil {
+		f.AddonError = fmt.Errorf("script %v: %w", hook.Name(), err)
+	}
+}
@@ -0,0 +1,140 @@
+package script
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+)
+
+func writeScript(t *testing.T, filename, src string) {
+	t.Helper()
+	if err := os.WriteFile(filename, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newFlow(rawurl string) *proxy.Flow {
+	u, _ := url.Parse(rawurl)
+	return &proxy.Flow{
+		Id:      "1",
+		Request: &proxy.Request{Method: "GET", URL: u, Header: http.Header{"Authorization": {"secret"}}},
+	}
+}
+
+func TestScript(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "script.star")
+	writeScript(t, filename, `
+def request(flow):
+    flow.request.headers.delete("Authorization")
+    flow.request.headers["X-Script"] = flow.request.host
+    if flow.request.path == "/blocked":
+        flow.reply(403, "blocked", {"Content-Type": "text/plain"})
+
+def response(flow):
+    if "secret" in flow.response.body:
+        flow.response.body = flow.response.body.replace("secret", "***")
+    flow.response.status_code = 299
+`)
+	s, err := New(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := newFlow("https://example.com/page")
+	s.Request(f)
+	if f.AddonError != nil {
+		t.Fatal(f.AddonError)
+	}
+	if got := f.Request.Header; got.Get("Authorization") != "" || got.Get("X-Script") != "example.com" {
+		t.Fatalf("unexpected request header %v", got)
+	}
+	if f.Response != nil {
+		t.Fatal("expected not replied")
+	}
+
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, _ = w.Write([]byte("the secret is here"))
+	_ = w.Close()
+	f.Response = &proxy.Response{StatusCode: 200, Header: http.Header{"Content-Encoding": {"gzip"}}, Body: gz.Bytes()}
+	s.Response(f)
+	if f.AddonError != nil {
+		t.Fatal(f.AddonError)
+	}
+	if f.Response.StatusCode != 299 || string(f.Response.Body) != "the *** is here" || f.Response.Header.Get("Content-Encoding") != "" {
+		t.Fatalf("unexpected response %v %q %v", f.Response.StatusCode, f.Response.Body, f.Response.Header)
+	}
+
+	f = newFlow("https://example.com/blocked")
+	s.Request(f)
+	if f.Response == nil || f.Response.StatusCode != 403 || string(f.Response.Body) != "blocked" || f.Response.Header.Get("Content-Type") != "text/plain" {
+		t.Fatalf("expected replied, got %+v", f.Response)
+	}
+}
+
+func TestScriptError(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "script.star")
+
+	writeScript(t, filename, "def request(flow):\n    flow.request.headers.add(\"X-Test\", 1)\n")
+	s, err := New(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := newFlow("http://example.com/")
+	s.Request(f)
+	if f.AddonError == nil {
+		t.Fatal("expected addon error")
+	}
+	// the response hook is not defined
+	s.Response(f)
+
+	writeScript(t, filename, "def request(flow)\n")
+	if _, err := New(filename); err == nil {
+		t.Fatal("expected syntax error")
+	}
+	writeScript(t, filename, "request = 1\n")
+	if _, err := New(filename); err == nil {
+		t.Fatal("expected not a function error")
+	}
+}
+
+func TestScriptReload(t *testing.T) {
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 0
+
+	filename := filepath.Join(t.TempDir(), "script.star")
+	writeScript(t, filename, "def request(flow):\n    flow.request.headers[\"X-Version\"] = \"1\"\n")
+	s, err := New(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	version := func() string {
+		f := newFlow("http://example.com/")
+		s.Request(f)
+		if f.AddonError != nil {
+			t.Fatal(f.AddonError)
+		}
+		return f.Request.Header.Get("X-Version")
+	}
+	if v := version(); v != "1" {
+		t.Fatalf("expected version 1, got %q", v)
+	}
+
+	writeScript(t, filename, "def request(flow):\n    flow.request.headers[\"X-Version\"] = \"22\"\n")
+	if v := version(); v != "22" {
+		t.Fatalf("expected reloaded version 22, got %q", v)
+	}
+
+	// an invalid script is not loaded
+	writeScript(t, filename, "def request(flow)\n")
+	if v := version(); v != "22" {
+		t.Fatalf("expected previous version 22, got %q", v)
+	}
+}
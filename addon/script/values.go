@@ -0,0 +1,324 @@
+package script
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/lqqyt2423/go-mitmproxy/proxy"
+	"go.starlark.net/starlark"
+)
+
+// the values wrap the flow being handled, they are mutable and not hashable, Freeze does nothing
+
+type flowValue struct {
+	f *proxy.Flow
+}
+
+var _ starlark.HasAttrs = (*flowValue)(nil)
+
+func (v *flowValue) String() string        { return fmt.Sprintf("<flow %v>", v.f.Id) }
+func (v *flowValue) Type() string          { return "flow" }
+func (v *flowValue) Freeze()               {}
+func (v *flowValue) Truth() starlark.Bool  { return starlark.True }
+func (v *flowValue) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: flow") }
+
+func (v *flowValue) AttrNames() []string {
+	return []string{"id", "reply", "request", "response"}
+}
+
+func (v *flowValue) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "id":
+		return starlark.String(v.f.Id), nil
+	case "request":
+		return &requestValue{r: v.f.Request}, nil
+	case "response":
+		if v.f.Response == nil {
+			return starlark.None, nil
+		}
+		return &responseValue{r: v.f.Response}, nil
+	case "reply":
+		return starlark.NewBuiltin("reply", v.reply), nil
+	}
+	return nil, nil
+}
+
+func (v *flowValue) reply(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var statusCode int
+	var body starlark.Value = starlark.String("")
+	var headers *starlark.Dict
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "status_code", &statusCode, "body?", &body, "headers?", &headers); err != nil {
+		return nil, err
+	}
+	bytes, err := bodyBytes(body)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", b.Name(), err)
+	}
+	header := http.Header{}
+	if headers != nil {
+		for _, item := range headers.Items() {
+			name, ok1 := starlark.AsString(item[0])
+			value, ok2 := starlark.AsString(item[1])
+			if !ok1 || !ok2 {
+				return nil, fmt.Errorf("%v: headers want string names and values, got %v: %v", b.Name(), item[0].Type(), item[1].Type())
+			}
+			header.Add(name, value)
+		}
+	}
+	v.f.Response = &proxy.Response{StatusCode: statusCode, Header: header, Body: bytes}
+	return starlark.None, nil
+}
+
+type requestValue struct {
+	r *proxy.Request
+}
+
+var _ starlark.HasSetField = (*requestValue)(nil)
+
+func (v *requestValue) String() string        { return fmt.Sprintf("<request %v %v>", v.r.Method, v.r.URL) }
+func (v *requestValue) Type() string          { return "request" }
+func (v *requestValue) Freeze()               {}
+func (v *requestValue) Truth() starlark.Bool  { return starlark.True }
+func (v *requestValue) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: request") }
+
+func (v *requestValue) AttrNames() []string {
+	return []string{"body", "headers", "host", "method", "path", "url"}
+}
+
+func (v *requestValue) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "method":
+		return starlark.String(v.r.Method), nil
+	case "url":
+		return starlark.String(v.r.URL.String()), nil
+	case "host":
+		return starlark.String(v.r.URL.Host), nil
+	case "path":
+		return starlark.String(v.r.URL.Path), nil
+	case "headers":
+		return &headersValue{h: &v.r.Header}, nil
+	case "body":
+		return starlark.String(v.r.Body), nil
+	}
+	return nil, nil
+}
+
+func (v *requestValue) SetField(name string, val starlark.Value) error {
+	switch name {
+	case "method":
+		s, ok := starlark.AsString(val)
+		if !ok {
+			return fmt.Errorf("request.method wants string, got %v", val.Type())
+		}
+		v.r.Method = s
+		return nil
+	case "url":
+		s, ok := starlark.AsString(val)
+		if !ok {
+			return fmt.Errorf("request.url wants string, got %v", val.Type())
+		}
+		u, err := url.Parse(s)
+		if err != nil {
+			return err
+		}
+		v.r.URL = u
+		return nil
+	case "body":
+		bytes, err := bodyBytes(val)
+		if err != nil {
+			return fmt.Errorf("request.body %w", err)
+		}
+		v.r.Body = bytes
+		return nil
+	}
+	return starlark.NoSuchAttrError(fmt.Sprintf("request has no settable field %v", name))
+}
+
+type responseValue struct {
+	r *proxy.Response
+}
+
+var _ starlark.HasSetField = (*responseValue)(nil)
+
+func (v *responseValue) String() string        { return fmt.Sprintf("<response %v>", v.r.StatusCode) }
+func (v *responseValue) Type() string          { return "response" }
+func (v *responseValue) Freeze()               {}
+func (v *responseValue) Truth() starlark.Bool  { return starlark.True }
+func (v *responseValue) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: response") }
+
+func (v *responseValue) AttrNames() []string {
+	return []string{"body", "headers", "status_code"}
+}
+
+func (v *responseValue) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "status_code":
+		return starlark.MakeInt(v.r.StatusCode), nil
+	case "headers":
+		return &headersValue{h: &v.r.Header}, nil
+	case "body":
+		// DecodedBody is cached, not changed by the body set before
+		if enc := v.r.Header.Get("Content-Encoding"); enc == "" || enc == "identity" {
+			return starlark.String(v.r.Body), nil
+		}
+		body, err := v.r.DecodedBody()
+		if err != nil {
+			body = v.r.Body
+		}
+		return starlark.String(body), nil
+	}
+	return nil, nil
+}
+
+func (v *responseValue) SetField(name string, val starlark.Value) error {
+	switch name {
+	case "status_code":
+		code, err := starlark.AsInt32(val)
+		if err != nil {
+			return fmt.Errorf("response.status_code %w", err)
+		}
+		v.r.StatusCode = code
+		return nil
+	case "body":
+		bytes, err := bodyBytes(val)
+		if err != nil {
+			return fmt.Errorf("response.body %w", err)
+		}
+		// the body is set decoded, as it is read
+		v.r.Body = bytes
+		if v.r.Header == nil {
+			v.r.Header = http.Header{}
+		}
+		v.r.Header.Del("Content-Encoding")
+		v.r.Header.Set("Content-Length", strconv.Itoa(len(bytes)))
+		return nil
+	}
+	return starlark.NoSuchAttrError(fmt.Sprintf("response has no settable field %v", name))
+}
+
+func bodyBytes(val starlark.Value) ([]byte, error) {
+	switch val := val.(type) {
+	case starlark.String:
+		return []byte(val), nil
+	case starlark.Bytes:
+		return []byte(val), nil
+	}
+	return nil, fmt.Errorf("wants string or bytes, got %v", val.Type())
+}
+
+// h points to the header of the request or response, it is created when a value is set
+type headersValue struct {
+	h *http.Header
+}
+
+var (
+	_ starlark.HasAttrs  = (*headersValue)(nil)
+	_ starlark.HasSetKey = (*headersValue)(nil)
+)
+
+func (v *headersValue) String() string        { return fmt.Sprintf("<headers %v>", v.keys()) }
+func (v *headersValue) Type() string          { return "headers" }
+func (v *headersValue) Freeze()               {}
+func (v *headersValue) Truth() starlark.Bool  { return len(*v.h) > 0 }
+func (v *headersValue) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: headers") }
+
+func (v *headersValue) keys() []string {
+	keys := make([]string, 0, len(*v.h))
+	for key := range *v.h {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (v *headersValue) header() http.Header {
+	if *v.h == nil {
+		*v.h = http.Header{}
+	}
+	return *v.h
+}
+
+func (v *headersValue) Get(k starlark.Value) (starlark.Value, bool, error) {
+	name, ok := starlark.AsString(k)
+	if !ok {
+		return nil, false, fmt.Errorf("headers key wants string, got %v", k.Type())
+	}
+	values := v.h.Values(name)
+	if len(values) == 0 {
+		return nil, false, nil
+	}
+	return starlark.String(values[0]), true, nil
+}
+
+func (v *headersValue) SetKey(k, val starlark.Value) error {
+	name, ok1 := starlark.AsString(k)
+	value, ok2 := starlark.AsString(val)
+	if !ok1 || !ok2 {
+		return fmt.Errorf("headers want string names and values, got %v: %v", k.Type(), val.Type())
+	}
+	v.header().Set(name, value)
+	return nil
+}
+
+func (v *headersValue) AttrNames() []string {
+	return []string{"add", "delete", "get", "keys"}
+}
+
+func (v *headersValue) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "get":
+		return starlark.NewBuiltin("get", v.get), nil
+	case "add":
+		return starlark.NewBuiltin("add", v.add), nil
+	case "delete":
+		return starlark.NewBuiltin("delete", v.delete), nil
+	case "keys":
+		return starlark.NewBuiltin("keys", v.keysBuiltin), nil
+	}
+	return nil, nil
+}
+
+func (v *headersValue) get(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var name string
+	var def starlark.Value = starlark.None
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name, "default?", &def); err != nil {
+		return nil, err
+	}
+	if values := v.h.Values(name); len(values) > 0 {
+		return starlark.String(values[0]), nil
+	}
+	return def, nil
+}
+
+func (v *headersValue) add(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var name, value string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name, "value", &value); err != nil {
+		return nil, err
+	}
+	v.header().Add(name, value)
+	return starlark.None, nil
+}
+
+func (v *headersValue) delete(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var name string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name); err != nil {
+		return nil, err
+	}
+	v.h.Del(name)
+	return starlark.None, nil
+}
+
+func (v *headersValue) keysBuiltin(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	keys := v.keys()
+	elems := make([]starlark.Value, len(keys))
+	for i, key := range keys {
+		elems[i] = starlark.String(key)
+	}
+	return starlark.NewList(elems), nil
+}
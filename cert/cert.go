@@ -15,6 +15,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -30,15 +31,33 @@ import (
 
 var errCaNotFound = errors.New("ca not found")
 
+// CertStorage stores the leaf certificates minted by CA, such as in redis to be shared by multiple proxy instances.
+// The instances must share the same CA, the certificates are signed by it.
+type CertStorage interface {
+	Get(name string) (*tls.Certificate, bool)
+	Put(name string, cert *tls.Certificate)
+}
+
+// CertStorageDeleter is implemented by the CertStorage which can delete a certificate, for CA.Evict
+type CertStorageDeleter interface {
+	Delete(name string)
+}
+
 type CA struct {
 	rsa.PrivateKey
 	RootCert  x509.Certificate
 	StorePath string
 
+	// consulted after the in-memory cache before minting, newly minted certs are put into it, nil to only cache in memory
+	Storage CertStorage
+
 	cache *lru.Cache
 	group *singleflight.Group
+	chain [][]byte // sent after the minted certs, RootCert and its issuers when it is an intermediate ca
 
 	cacheMu sync.Mutex
+	names   map[string]struct{} // names in cache
+	gen     uint64              // increased by Evict and ClearCache, the certs looked up before are not cached then
 }
 
 func createCert() (*rsa.PrivateKey, *x509.Certificate, error) {
@@ -127,6 +146,80 @@ func NewCA(path string) (*CA, error) {
 	return ca, nil
 }
 
+// NewCAFromPEM use an existing ca, such as the intermediate ca of an organization, which clients already trust.
+// certPEM is the ca certificate, optionally followed by its issuers, which are sent to clients after the minted certs.
+// The certificate must be a ca and keyPEM must be its rsa private key, in PKCS#8 or PKCS#1.
+func NewCAFromPEM(certPEM, keyPEM []byte) (*CA, error) {
+	var chain []*x509.Certificate
+	for rest := certPEM; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse ca certificate: %w", err)
+		}
+		chain = append(chain, c)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("no CERTIFICATE in ca certificate pem")
+	}
+	caCert := chain[0]
+	if !caCert.BasicConstraintsValid || !caCert.IsCA {
+		return nil, fmt.Errorf("certificate %v is not a ca, basic constraints CA:TRUE is required", caCert.Subject)
+	}
+
+	var keyBlock *pem.Block
+	for rest := keyPEM; ; {
+		keyBlock, rest = pem.Decode(rest)
+		if keyBlock == nil || strings.HasSuffix(keyBlock.Type, "PRIVATE KEY") {
+			break
+		}
+	}
+	if keyBlock == nil {
+		return nil, errors.New("no PRIVATE KEY in ca key pem")
+	}
+	key, err := parsePrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse ca private key: %w", err)
+	}
+	if !key.PublicKey.Equal(caCert.PublicKey) {
+		return nil, fmt.Errorf("private key does not match the ca certificate %v", caCert.Subject)
+	}
+
+	ca := &CA{
+		PrivateKey: *key,
+		RootCert:   *caCert,
+		cache:      lru.New(100),
+		group:      new(singleflight.Group),
+	}
+	// clients only trust the root, an intermediate ca and its issuers are sent with the minted certs
+	if caCert.CheckSignatureFrom(caCert) != nil {
+		for _, c := range chain {
+			ca.chain = append(ca.chain, c.Raw)
+		}
+	}
+	return ca, nil
+}
+
+// NewCAFromFiles is NewCAFromPEM with the pem files
+func NewCAFromFiles(certFile, keyFile string) (*CA, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewCAFromPEM(certPEM, keyPEM)
+}
+
 func getStorePath(path string) (string, error) {
 	if path == "" {
 		homeDir, err := os.UserHomeDir()
@@ -205,24 +298,9 @@ func (ca *CA) load() error {
 		return fmt.Errorf("%v 中不存在 CERTIFICATE", caFile)
 	}
 
-	var privateKey *rsa.PrivateKey
-	key, err := x509.ParsePKCS8PrivateKey(keyDERBlock.Bytes)
+	privateKey, err := parsePrivateKey(keyDERBlock.Bytes)
 	if err != nil {
-		// fix #14
-		if strings.Contains(err.Error(), "use ParsePKCS1PrivateKey instead") {
-			privateKey, err = x509.ParsePKCS1PrivateKey(keyDERBlock.Bytes)
-			if err != nil {
-				return err
-			}
-		} else {
-			return err
-		}
-	} else {
-		if v, ok := key.(*rsa.PrivateKey); ok {
-			privateKey = v
-		} else {
-			return errors.New("found unknown rsa private key type in PKCS#8 wrapping")
-		}
+		return err
 	}
 	ca.PrivateKey = *privateKey
 
@@ -235,6 +313,22 @@ func (ca *CA) load() error {
 	return nil
 }
 
+// rsa private key in PKCS#8 or PKCS#1
+func parsePrivateKey(der []byte) (*rsa.PrivateKey, error) {
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		// fix #14
+		if strings.Contains(err.Error(), "use ParsePKCS1PrivateKey instead") {
+			return x509.ParsePKCS1PrivateKey(der)
+		}
+		return nil, err
+	}
+	if v, ok := key.(*rsa.PrivateKey); ok {
+		return v, nil
+	}
+	return nil, errors.New("found unknown rsa private key type in PKCS#8 wrapping")
+}
+
 func (ca *CA) create() error {
 	key, cert, err := createCert()
 	if err != nil {
@@ -300,29 +394,95 @@ func (ca *CA) saveCert() error {
 }
 
 func (ca *CA) GetCert(commonName string) (*tls.Certificate, error) {
+	cert, _, err := ca.LookupCert(commonName)
+	return cert, err
+}
+
+// LookupCert is like GetCert, cached is false only when the cert is minted by this call.
+// Concurrent calls for the same commonName wait the same minting, and are reported as cached.
+func (ca *CA) LookupCert(commonName string) (cert *tls.Certificate, cached bool, err error) {
 	ca.cacheMu.Lock()
 	if val, ok := ca.cache.Get(commonName); ok {
 		ca.cacheMu.Unlock()
 		log.Debugf("ca GetCert: %v", commonName)
-		return val.(*tls.Certificate), nil
+		return val.(*tls.Certificate), true, nil
 	}
+	gen := ca.gen
 	ca.cacheMu.Unlock()
 
+	minted := false
 	val, err := ca.group.Do(commonName, func() (interface{}, error) {
+		if ca.Storage != nil {
+			if cert, ok := ca.Storage.Get(commonName); ok {
+				ca.cacheAdd(commonName, cert, gen)
+				return cert, nil
+			}
+		}
+		minted = true
 		cert, err := ca.DummyCert(commonName)
 		if err == nil {
-			ca.cacheMu.Lock()
-			ca.cache.Add(commonName, cert)
-			ca.cacheMu.Unlock()
+			ca.cacheAdd(commonName, cert, gen)
+			if ca.Storage != nil {
+				ca.Storage.Put(commonName, cert)
+			}
 		}
 		return cert, err
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	return val.(*tls.Certificate), !minted, nil
+}
+
+// put cert into the in-memory cache, unless it is evicted or cleared since the lookup of generation gen
+func (ca *CA) cacheAdd(name string, cert *tls.Certificate, gen uint64) {
+	ca.cacheMu.Lock()
+	defer ca.cacheMu.Unlock()
+	if gen != ca.gen {
+		return
+	}
+	if ca.names == nil {
+		ca.names = make(map[string]struct{})
+		ca.cache.OnEvicted = func(key lru.Key, _ interface{}) {
+			delete(ca.names, key.(string))
+		}
+	}
+	ca.cache.Add(name, cert)
+	ca.names[name] = struct{}{}
+}
+
+// CachedHosts return the names of the leaf certificates in the in-memory cache, sorted
+func (ca *CA) CachedHosts() []string {
+	ca.cacheMu.Lock()
+	defer ca.cacheMu.Unlock()
+	names := make([]string, 0, len(ca.names))
+	for name := range ca.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Evict remove the leaf certificate of host from the cache, a new one is minted by the next lookup,
+// such as after the options of minting are changed. It is also deleted from Storage if it is a CertStorageDeleter.
+func (ca *CA) Evict(host string) {
+	ca.cacheMu.Lock()
+	ca.cache.Remove(host)
+	ca.gen++
+	ca.cacheMu.Unlock()
+	if d, ok := ca.Storage.(CertStorageDeleter); ok {
+		d.Delete(host)
 	}
+}
 
-	return val.(*tls.Certificate), nil
+// ClearCache remove all leaf certificates from the in-memory cache, Storage is kept
+func (ca *CA) ClearCache() {
+	ca.cacheMu.Lock()
+	defer ca.cacheMu.Unlock()
+	ca.cache.Clear()
+	ca.gen++
 }
 
 // TODO: 是否应该支持多个 SubjectAltName
@@ -353,7 +513,7 @@ func (ca *CA) DummyCert(commonName string) (*tls.Certificate, error) {
 	}
 
 	cert := &tls.Certificate{
-		Certificate: [][]byte{certBytes},
+		Certificate: append([][]byte{certBytes}, ca.chain...),
 		PrivateKey:  &ca.PrivateKey,
 	}
 
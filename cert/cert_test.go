@@ -2,9 +2,23 @@ package cert
 
 import (
 	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"io/ioutil"
+	"math/big"
 	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/golang/groupcache/singleflight"
 )
 
 func TestGetStorePath(t *testing.T) {
@@ -40,3 +54,187 @@ func TestNewCA(t *testing.T) {
 		t.Fatal("pem content should equal")
 	}
 }
+
+func TestLookupCert(t *testing.T) {
+	ca, err := NewCAMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c1, cached, err := ca.LookupCert("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached {
+		t.Fatal("first lookup should mint the cert")
+	}
+
+	c2, cached, err := ca.LookupCert("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cached || c1 != c2 {
+		t.Fatal("second lookup should hit the cache")
+	}
+}
+
+func TestEvictCert(t *testing.T) {
+	ca, err := NewCAMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage := &testCertStorage{certs: make(map[string]*tls.Certificate)}
+	ca.Storage = storage
+	c1, _, err := ca.LookupCert("b.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ca.LookupCert("a.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if hosts := ca.CachedHosts(); !slices.Equal(hosts, []string{"a.example.com", "b.example.com"}) {
+		t.Fatalf("unexpected cached hosts %v", hosts)
+	}
+
+	// evicted from the cache and storage, minted again
+	ca.Evict("b.example.com")
+	if hosts := ca.CachedHosts(); !slices.Equal(hosts, []string{"a.example.com"}) {
+		t.Fatalf("unexpected cached hosts after evict %v", hosts)
+	}
+	c2, cached, err := ca.LookupCert("b.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached || c1 == c2 || storage.certs["b.example.com"] != c2 {
+		t.Fatal("expected a new cert minted after evict")
+	}
+
+	ca.ClearCache()
+	if hosts := ca.CachedHosts(); len(hosts) != 0 {
+		t.Fatalf("expected empty cache, but got %v", hosts)
+	}
+	if _, cached, _ := ca.LookupCert("b.example.com"); !cached {
+		t.Fatal("expected the cert got from storage after clear")
+	}
+	if hosts := ca.CachedHosts(); !slices.Equal(hosts, []string{"b.example.com"}) {
+		t.Fatalf("unexpected cached hosts %v", hosts)
+	}
+}
+
+type testCertStorage struct {
+	mu    sync.Mutex
+	certs map[string]*tls.Certificate
+}
+
+func (s *testCertStorage) Get(name string) (*tls.Certificate, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.certs[name]
+	return c, ok
+}
+
+func (s *testCertStorage) Put(name string, c *tls.Certificate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.certs[name] = c
+}
+
+func (s *testCertStorage) Delete(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.certs, name)
+}
+
+func TestCertStorage(t *testing.T) {
+	storage := &testCertStorage{certs: make(map[string]*tls.Certificate)}
+	ca1, err := NewCAMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca1.Storage = storage
+	c1, cached, err := ca1.LookupCert("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached || storage.certs["example.com"] != c1 {
+		t.Fatal("minted cert should be put into storage")
+	}
+
+	// another instance with the same storage
+	ca2 := &CA{PrivateKey: ca1.PrivateKey, RootCert: ca1.RootCert, Storage: storage, cache: lru.New(100), group: new(singleflight.Group)}
+	c2, cached, err := ca2.LookupCert("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cached || c1 != c2 {
+		t.Fatal("cert should be got from storage")
+	}
+}
+
+func TestNewCAFromPEM(t *testing.T) {
+	root, err := NewCAMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encode := func(typ string, der []byte) []byte {
+		return pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+	}
+	newCert := func(isCA bool) ([]byte, []byte) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(time.Now().UnixNano()),
+			Subject:               pkix.Name{CommonName: "intermediate"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			BasicConstraintsValid: true,
+			IsCA:                  isCA,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, &root.RootCert, &key.PublicKey, &root.PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return encode("CERTIFICATE", der), encode("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key))
+	}
+
+	certPEM, keyPEM := newCert(true)
+	ca, err := NewCAFromPEM(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := ca.GetCert("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leaf.Certificate) != 2 || !bytes.Equal(leaf.Certificate[1], ca.RootCert.Raw) {
+		t.Fatal("intermediate ca should be sent with the minted cert")
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(&root.RootCert)
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(&ca.RootCert)
+	c, _ := x509.ParseCertificate(leaf.Certificate[0])
+	if _, err := c.Verify(x509.VerifyOptions{DNSName: "example.com", Roots: roots, Intermediates: intermediates}); err != nil {
+		t.Fatal(err)
+	}
+
+	rootKey, _ := x509.MarshalPKCS8PrivateKey(&root.PrivateKey)
+	rootCA, err := NewCAFromPEM(encode("CERTIFICATE", root.RootCert.Raw), encode("PRIVATE KEY", rootKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaf, _ := rootCA.GetCert("example.com"); len(leaf.Certificate) != 1 {
+		t.Fatal("self-signed ca should not be sent")
+	}
+
+	if _, err := NewCAFromPEM(certPEM, encode("PRIVATE KEY", rootKey)); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("expected key mismatch error, got %v", err)
+	}
+	leafPEM, leafKeyPEM := newCert(false)
+	if _, err := NewCAFromPEM(leafPEM, leafKeyPEM); err == nil || !strings.Contains(err.Error(), "is not a ca") {
+		t.Fatalf("expected not ca error, got %v", err)
+	}
+}
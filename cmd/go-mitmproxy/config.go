@@ -25,14 +25,39 @@ func loadConfigFromCli() *Config {
 	flag.BoolVar(&config.SslInsecure, "ssl_insecure", false, "not verify upstream server SSL/TLS certificates.")
 	flag.Var((*arrayValue)(&config.IgnoreHosts), "ignore_hosts", "a list of ignore hosts")
 	flag.Var((*arrayValue)(&config.AllowHosts), "allow_hosts", "a list of allow hosts")
+	flag.Var((*arrayValue)(&config.PreflightOrigins), "preflight_origins", "answer CORS preflight requests of the origins locally, * for any")
+	flag.StringVar(&config.CORSOrigin, "cors_origin", "", "add Access-Control-Allow-Origin to the responses, such as *")
+	flag.Var((*arrayValue)(&config.CORSHosts), "cors_hosts", "hosts of the responses cors_origin is added to, all if empty")
 	flag.StringVar(&config.CertPath, "cert_path", "", "path of generate cert files")
+	flag.StringVar(&config.CaCert, "ca_cert", "", "cert file of an existing ca to sign certs instead of the generated one, may be followed by its issuers")
+	flag.StringVar(&config.CaKey, "ca_key", "", "key file of the ca_cert")
 	flag.IntVar(&config.Debug, "debug", 0, "debug mode: 1 - print debug log, 2 - show debug from")
 	flag.StringVar(&config.Dump, "dump", "", "dump filename")
 	flag.IntVar(&config.DumpLevel, "dump_level", 0, "dump level: 0 - header, 1 - header + body")
-	flag.StringVar(&config.Upstream, "upstream", "", "upstream proxy")
+	flag.StringVar(&config.JSONLines, "jsonl", "", "write each flow as a json line to the filename, - for stdout, with the bodies if dump_level is 1")
+	flag.StringVar(&config.Pcapng, "pcapng", "", "write traffic to the pcapng filename, with tls keys embedded for Wireshark")
+	flag.StringVar(&config.Upstream, "upstream", "", "upstream proxy, http:// or socks5:// with optional user:pass@")
+	flag.StringVar(&config.NoProxy, "no_proxy", "", "hosts not use upstream proxy, same syntax as NO_PROXY, such as .internal,10.0.0.0/8")
 	flag.BoolVar(&config.UpstreamCert, "upstream_cert", true, "connect to upstream server to look up certificate details")
 	flag.StringVar(&config.MapRemote, "map_remote", "", "map remote config filename")
 	flag.StringVar(&config.MapLocal, "map_local", "", "map local config filename")
+	flag.Var((*arrayValue)(&config.Block), "block", "block the hosts or urls matching the patterns, such as *.doubleclick.net or example.com/track/*")
+	flag.IntVar(&config.BlockStatus, "block_status", 0, "status code of the blocked requests, 403 by default")
+	flag.StringVar(&config.RequestID, "request_id", "", "header of the request id generated for upstream requests and echoed in responses, such as X-Request-Id")
+	flag.BoolVar(&config.RequestIDRequire, "request_id_require", false, "reply 400 to the requests without the request_id header instead of generating it")
+	flag.StringVar(&config.ProxyCert, "proxy_cert", "", "cert file of the proxy server, serve as https proxy")
+	flag.StringVar(&config.ProxyKey, "proxy_key", "", "key file of the proxy_cert")
+	flag.StringVar(&config.ProxyClientCA, "proxy_client_ca", "", "ca cert file of the client certs required to connect to the https proxy")
+	flag.BoolVar(&config.ProxyH2, "proxy_h2", false, "negotiate h2 with clients of https proxy, tunnel with h2 CONNECT")
+	flag.StringVar(&config.RulesFile, "rules_file", "", "json rules filename of host map and body stubs, reloaded when changed")
+	flag.StringVar(&config.Script, "script", "", "starlark script filename of request(flow) and response(flow) hooks, reloaded when changed")
+	flag.StringVar(&config.AdminAddr, "admin_addr", "", "admin listen addr of /healthz, /readyz and /stats, such as :9082")
+	flag.BoolVar(&config.Transparent, "transparent", false, "transparent mode, clients are redirected to the proxy by iptables REDIRECT, linux only")
+	flag.StringVar(&config.Reverse, "reverse", "", "reverse proxy mode, send all requests to the upstream, such as https://backend:8443")
+	flag.Var((*arrayValue)(&config.ReverseRoutes), "reverse_route", "route of reverse proxy mode, path prefix or host to upstream, such as /api=http://api:8080")
+	flag.BoolVar(&config.StreamResponses, "stream_responses", false, "forward response bodies without buffering when no addon needs them")
+	flag.BoolVar(&config.StreamPartial, "stream_partial", false, "forward the bodies of 206 partial content responses without buffering")
+	flag.BoolVar(&config.PoolUpstreamConns, "pool_upstream_conns", false, "reuse upstream connections of plain http requests across client connections")
 	flag.StringVar(&config.filename, "f", "", "read config from the filename")
 	flag.Parse()
 
@@ -57,9 +82,24 @@ func mergeConfigs(fileConfig, cliConfig *Config) *Config {
 	if len(cliConfig.AllowHosts) > 0 {
 		config.AllowHosts = cliConfig.AllowHosts
 	}
+	if len(cliConfig.PreflightOrigins) > 0 {
+		config.PreflightOrigins = cliConfig.PreflightOrigins
+	}
+	if cliConfig.CORSOrigin != "" {
+		config.CORSOrigin = cliConfig.CORSOrigin
+	}
+	if len(cliConfig.CORSHosts) > 0 {
+		config.CORSHosts = cliConfig.CORSHosts
+	}
 	if cliConfig.CertPath != "" {
 		config.CertPath = cliConfig.CertPath
 	}
+	if cliConfig.CaCert != "" {
+		config.CaCert = cliConfig.CaCert
+	}
+	if cliConfig.CaKey != "" {
+		config.CaKey = cliConfig.CaKey
+	}
 	if cliConfig.Debug != 0 {
 		config.Debug = cliConfig.Debug
 	}
@@ -69,9 +109,57 @@ func mergeConfigs(fileConfig, cliConfig *Config) *Config {
 	if cliConfig.DumpLevel != 0 {
 		config.DumpLevel = cliConfig.DumpLevel
 	}
+	if cliConfig.JSONLines != "" {
+		config.JSONLines = cliConfig.JSONLines
+	}
+	if cliConfig.Pcapng != "" {
+		config.Pcapng = cliConfig.Pcapng
+	}
 	if cliConfig.Upstream != "" {
 		config.Upstream = cliConfig.Upstream
 	}
+	if cliConfig.NoProxy != "" {
+		config.NoProxy = cliConfig.NoProxy
+	}
+	if cliConfig.ProxyCert != "" {
+		config.ProxyCert = cliConfig.ProxyCert
+	}
+	if cliConfig.ProxyKey != "" {
+		config.ProxyKey = cliConfig.ProxyKey
+	}
+	if cliConfig.ProxyClientCA != "" {
+		config.ProxyClientCA = cliConfig.ProxyClientCA
+	}
+	if cliConfig.ProxyH2 {
+		config.ProxyH2 = cliConfig.ProxyH2
+	}
+	if cliConfig.RulesFile != "" {
+		config.RulesFile = cliConfig.RulesFile
+	}
+	if cliConfig.Script != "" {
+		config.Script = cliConfig.Script
+	}
+	if cliConfig.AdminAddr != "" {
+		config.AdminAddr = cliConfig.AdminAddr
+	}
+	if cliConfig.Transparent {
+		config.Transparent = cliConfig.Transparent
+	}
+	if cliConfig.Reverse != "" {
+		config.Reverse = cliConfig.Reverse
+	}
+	if len(cliConfig.ReverseRoutes) > 0 {
+		config.ReverseRoutes = cliConfig.ReverseRoutes
+	}
+	if cliConfig.StreamResponses {
+		config.StreamResponses = cliConfig.StreamResponses
+	}
+	if cliConfig.StreamPartial {
+		config.StreamPartial = cliConfig.StreamPartial
+	}
+	if cliConfig.PoolUpstreamConns {
+		config.PoolUpstreamConns = cliConfig.PoolUpstreamConns
+	}
 	if !cliConfig.UpstreamCert {
 		config.UpstreamCert = cliConfig.UpstreamCert
 	}
@@ -81,6 +169,18 @@ func mergeConfigs(fileConfig, cliConfig *Config) *Config {
 	if cliConfig.MapLocal != "" {
 		config.MapLocal = cliConfig.MapLocal
 	}
+	if len(cliConfig.Block) > 0 {
+		config.Block = cliConfig.Block
+	}
+	if cliConfig.BlockStatus != 0 {
+		config.BlockStatus = cliConfig.BlockStatus
+	}
+	if cliConfig.RequestID != "" {
+		config.RequestID = cliConfig.RequestID
+	}
+	if cliConfig.RequestIDRequire {
+		config.RequestIDRequire = cliConfig.RequestIDRequire
+	}
 	return config
 }
 
@@ -1,12 +1,17 @@
 package main
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	rawLog "log"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
 
 	"github.com/lqqyt2423/go-mitmproxy/addon"
+	"github.com/lqqyt2423/go-mitmproxy/addon/script"
 	"github.com/lqqyt2423/go-mitmproxy/internal/helper"
 	"github.com/lqqyt2423/go-mitmproxy/proxy"
 	"github.com/lqqyt2423/go-mitmproxy/web"
@@ -16,19 +21,44 @@ import (
 type Config struct {
 	version bool // show go-mitmproxy version
 
-	Addr         string   // proxy listen addr
-	WebAddr      string   // web interface listen addr
-	SslInsecure  bool     // not verify upstream server SSL/TLS certificates.
-	IgnoreHosts  []string // a list of ignore hosts
-	AllowHosts   []string // a list of allow hosts
-	CertPath     string   // path of generate cert files
-	Debug        int      // debug mode: 1 - print debug log, 2 - show debug from
-	Dump         string   // dump filename
-	DumpLevel    int      // dump level: 0 - header, 1 - header + body
-	Upstream     string   // upstream proxy
-	UpstreamCert bool     // Connect to upstream server to look up certificate details. Default: True
-	MapRemote    string   // map remote config filename
-	MapLocal     string   // map local config filename
+	Addr              string   // proxy listen addr
+	WebAddr           string   // web interface listen addr
+	SslInsecure       bool     // not verify upstream server SSL/TLS certificates.
+	IgnoreHosts       []string // a list of ignore hosts
+	AllowHosts        []string // a list of allow hosts
+	PreflightOrigins  []string // answer CORS preflight requests of the origins locally
+	CORSOrigin        string   // Access-Control-Allow-Origin added to the responses of CORSHosts
+	CORSHosts         []string // hosts of the responses CORSOrigin is added to, all if empty
+	CertPath          string   // path of generate cert files
+	CaCert            string   // cert file of an existing ca
+	CaKey             string   // key file of CaCert
+	Debug             int      // debug mode: 1 - print debug log, 2 - show debug from
+	Dump              string   // dump filename
+	DumpLevel         int      // dump level: 0 - header, 1 - header + body
+	JSONLines         string   // write each flow as a json line to the filename, - for stdout
+	Pcapng            string   // pcapng filename, with tls keys embedded
+	Upstream          string   // upstream proxy
+	NoProxy           string   // hosts not use upstream proxy, same syntax as NO_PROXY
+	UpstreamCert      bool     // Connect to upstream server to look up certificate details. Default: True
+	MapRemote         string   // map remote config filename
+	MapLocal          string   // map local config filename
+	Block             []string // patterns of the hosts or urls blocked, such as *.doubleclick.net or example.com/track/*
+	BlockStatus       int      // status code of the blocked requests, 403 if not set
+	RequestID         string   // header of the request id added to upstream requests and echoed to clients, such as X-Request-Id
+	RequestIDRequire  bool     // reply 400 to the requests without RequestID instead of generating it
+	ProxyCert         string   // cert file of the proxy server, clients connect to the proxy over tls
+	ProxyKey          string   // key file of ProxyCert
+	ProxyClientCA     string   // ca cert file of the client certs required by the https proxy
+	ProxyH2           bool     // negotiate h2 with clients connecting over tls
+	RulesFile         string   // json rules filename, reloaded when changed
+	Script            string   // starlark script filename of request and response hooks, reloaded when changed
+	AdminAddr         string   // admin listen addr of health checks and stats
+	Transparent       bool     // transparent mode, clients are redirected by iptables
+	Reverse           string   // reverse proxy mode, all requests are sent to this upstream url
+	ReverseRoutes     []string // routes of reverse proxy mode, such as /api=http://api:8080, the others are sent to Reverse
+	StreamResponses   bool     // forward response bodies without buffering when no addon needs them
+	StreamPartial     bool     // forward the bodies of 206 partial content responses without buffering
+	PoolUpstreamConns bool     // reuse upstream connections of plain http requests across client connections
 
 	filename string // read config from the filename
 }
@@ -51,12 +81,79 @@ func main() {
 	})
 
 	opts := &proxy.Options{
-		Debug:             config.Debug,
-		Addr:              config.Addr,
-		StreamLargeBodies: 1024 * 1024 * 5,
-		SslInsecure:       config.SslInsecure,
-		CaRootPath:        config.CertPath,
-		Upstream:          config.Upstream,
+		Debug:                config.Debug,
+		Addr:                 config.Addr,
+		StreamLargeBodies:    1024 * 1024 * 5,
+		SslInsecure:          config.SslInsecure,
+		CaRootPath:           config.CertPath,
+		Upstream:             config.Upstream,
+		UpstreamNoProxy:      config.NoProxy,
+		InterceptHosts:       config.AllowHosts,
+		RulesFile:            config.RulesFile,
+		AdminAddr:            config.AdminAddr,
+		Transparent:          config.Transparent,
+		StreamResponses:      config.StreamResponses,
+		StreamPartialContent: config.StreamPartial,
+		PoolUpstreamConns:    config.PoolUpstreamConns,
+	}
+
+	if len(config.PreflightOrigins) > 0 {
+		opts.HandlePreflight = &proxy.CORSPreflight{AllowOrigins: config.PreflightOrigins, AllowCredentials: true}
+	}
+
+	if config.CORSOrigin != "" {
+		opts.CORS = &proxy.CORSHeaders{AllowOrigin: config.CORSOrigin, Hosts: config.CORSHosts}
+	}
+
+	if config.Reverse != "" {
+		u, err := url.Parse(config.Reverse)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts.ReverseUpstream = u
+	}
+	for _, route := range config.ReverseRoutes {
+		match, upstream, ok := strings.Cut(route, "=")
+		if !ok {
+			log.Fatalf("invalid reverse route %v, expected match=upstream", route)
+		}
+		u, err := url.Parse(upstream)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts.ReverseRoutes = append(opts.ReverseRoutes, proxy.ReverseRoute{Match: match, Upstream: u})
+	}
+
+	if config.CaCert != "" {
+		opts.CA = &proxy.CAConfig{CertFile: config.CaCert, KeyFile: config.CaKey}
+	}
+
+	if config.ProxyCert != "" {
+		c, err := tls.LoadX509KeyPair(config.ProxyCert, config.ProxyKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts.ProxyTLSCert = &c
+		opts.ProxyH2 = config.ProxyH2
+		if config.ProxyClientCA != "" {
+			caPEM, err := os.ReadFile(config.ProxyClientCA)
+			if err != nil {
+				log.Fatal(err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caPEM) {
+				log.Fatalf("no cert found in %v", config.ProxyClientCA)
+			}
+			opts.ProxyClientCAs = pool
+		}
+	}
+
+	var pcapng *addon.Pcapng
+	if config.Pcapng != "" {
+		pcapng = addon.NewPcapngWithFilename(config.Pcapng)
+		opts.OnClientRawBytes = pcapng.ClientBytes
+		opts.OnServerRawBytes = pcapng.ServerBytes
+		opts.KeyLogWriter = pcapng
 	}
 
 	p, err := proxy.NewProxy(opts)
@@ -70,23 +167,24 @@ func main() {
 	}
 
 	log.Infof("go-mitmproxy version %v\n", p.Version)
+	log.Infof("effective options: %v\n", p.Config())
 
 	if len(config.IgnoreHosts) > 0 {
 		p.SetShouldInterceptRule(func(req *http.Request) bool {
 			return !helper.MatchHost(req.Host, config.IgnoreHosts)
 		})
 	}
-	if len(config.AllowHosts) > 0 {
-		p.SetShouldInterceptRule(func(req *http.Request) bool {
-			return helper.MatchHost(req.Host, config.AllowHosts)
-		})
-	}
 
 	if !config.UpstreamCert {
 		p.AddAddon(proxy.NewUpstreamCertAddon(false))
 		log.Infoln("UpstreamCert config false")
 	}
 
+	// before the others, they see the request id
+	if config.RequestID != "" {
+		p.AddAddon(addon.NewRequestID(config.RequestID, config.RequestIDRequire))
+	}
+
 	p.AddAddon(&proxy.LogAddon{})
 	p.AddAddon(web.NewWebAddon(config.WebAddr))
 
@@ -108,10 +206,43 @@ func main() {
 		}
 	}
 
+	if config.Script != "" {
+		s, err := script.New(config.Script)
+		if err != nil {
+			log.Fatal(err)
+		}
+		p.AddAddon(s)
+	}
+
+	if len(config.Block) > 0 {
+		status := config.BlockStatus
+		if status == 0 {
+			status = http.StatusForbidden
+		}
+		p.AddAddon(addon.NewBlockList(config.Block, status))
+	}
+
 	if config.Dump != "" {
 		dumper := addon.NewDumperWithFilename(config.Dump, config.DumpLevel)
 		p.AddAddon(dumper)
 	}
 
+	if config.JSONLines != "" {
+		jsonlOpts := addon.JSONLinesOptions{IncludeBody: config.DumpLevel == 1}
+		if config.JSONLines == "-" {
+			p.AddAddon(addon.NewJSONLinesDumper(os.Stdout, jsonlOpts))
+		} else {
+			dumper, err := addon.NewJSONLinesDumperWithFilename(config.JSONLines, jsonlOpts)
+			if err != nil {
+				log.Fatal(err)
+			}
+			p.AddAddon(dumper)
+		}
+	}
+
+	if pcapng != nil {
+		p.AddHooks(pcapng)
+	}
+
 	log.Fatal(p.Start())
 }
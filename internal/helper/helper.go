@@ -14,22 +14,28 @@ import (
 // 如果未达到 limit，则成功读取进入 buffer
 // 否则 buffer 返回 nil，且返回新 Reader，状态为未读取前
 func ReaderToBuffer(r io.Reader, limit int64) ([]byte, io.Reader, error) {
-	buf := bytes.NewBuffer(make([]byte, 0))
+	buf, newReader, truncated, err := ReaderToBufferPrefix(r, limit)
+	if err != nil || truncated {
+		return nil, newReader, err
+	}
+	return buf, nil, nil
+}
+
+// 同 ReaderToBuffer，但达到 limit 时 buffer 返回已读取的前 limit 字节，truncated 为 true
+func ReaderToBufferPrefix(r io.Reader, limit int64) (buf []byte, newReader io.Reader, truncated bool, err error) {
+	b := bytes.NewBuffer(make([]byte, 0))
 	lr := io.LimitReader(r, limit)
 
-	_, err := io.Copy(buf, lr)
-	if err != nil {
-		return nil, nil, err
+	if _, err := io.Copy(b, lr); err != nil {
+		return nil, nil, false, err
 	}
 
-	// 达到上限
-	if int64(buf.Len()) == limit {
-		// 返回新的 Reader
-		return nil, io.MultiReader(bytes.NewBuffer(buf.Bytes()), r), nil
+	// 达到上限，返回新的 Reader
+	if int64(b.Len()) == limit {
+		return b.Bytes(), io.MultiReader(bytes.NewReader(b.Bytes()), r), true, nil
 	}
 
-	// 返回 buffer
-	return buf.Bytes(), nil, nil
+	return b.Bytes(), nil, false, nil
 }
 
 func NewStructFromFile(filename string, v interface{}) error {
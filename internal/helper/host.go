@@ -1,6 +1,9 @@
 package helper
 
-import "strings"
+import (
+	"net"
+	"strings"
+)
 
 // MatchHost detect hosts is match address
 func MatchHost(address string, hosts []string) bool {
@@ -24,10 +27,11 @@ func matchHostname(hostname string, h string) bool {
 	return h == hostname
 }
 
+// hostname without brackets and port of address, port is empty if address has no port, such as "::1"
 func splitHostPort(address string) (string, string) {
-	index := strings.LastIndex(address, ":")
-	if index == -1 {
-		return address, ""
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return strings.Trim(address, "[]"), ""
 	}
-	return address[:index], address[index+1:]
+	return host, port
 }
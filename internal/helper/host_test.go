@@ -97,4 +97,15 @@ func TestMatchHost(t *testing.T) {
 	if result != expected {
 		t.Errorf("Expected %t but got %t", expected, result)
 	}
+
+	// Test case 8: IPv6 literal
+	address = "[2606:4700::1]:443"
+	for _, host := range []string{"2606:4700::1", "[2606:4700::1]", "[2606:4700::1]:443"} {
+		if !MatchHost(address, []string{host}) {
+			t.Errorf("Expected %v matches %v", address, host)
+		}
+	}
+	if MatchHost(address, []string{"[2606:4700::1]:80"}) {
+		t.Errorf("Expected %v not matches other port", address)
+	}
 }
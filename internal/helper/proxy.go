@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"encoding/base64"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"net/url"
@@ -15,9 +16,22 @@ import (
 	"golang.org/x/net/proxy"
 )
 
-// GetProxyConn connect proxy
+// ErrProxyAuthRequired the http proxy replied 407 to CONNECT, the credentials in the proxy url are missing or wrong
+var ErrProxyAuthRequired = errors.New("proxy authentication required")
+
+// Proxy-Authorization of the credentials in the proxy url, empty if none
+func ProxyAuthorization(proxyUrl *url.URL) string {
+	if proxyUrl.User == nil {
+		return ""
+	}
+	pass, _ := proxyUrl.User.Password()
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(proxyUrl.User.Username()+":"+pass))
+}
+
+// GetProxyConn connect proxy, the host of proxy is resolved by resolver, nil for the default one
 // ref: http/transport.go dialConn func
-func GetProxyConn(ctx context.Context, proxyUrl *url.URL, address string, sslInsecure bool) (net.Conn, error) {
+func GetProxyConn(ctx context.Context, proxyUrl *url.URL, address string, sslInsecure bool, resolver *net.Resolver) (net.Conn, error) {
+	forward := &net.Dialer{Resolver: resolver}
 	var conn net.Conn
 	if proxyUrl.Scheme == "socks5" {
 		//检测socks5认证信息
@@ -28,7 +42,7 @@ func GetProxyConn(ctx context.Context, proxyUrl *url.URL, address string, sslIns
 			proxyAuth.User = user
 			proxyAuth.Password = pass
 		}
-		dialer, err := proxy.SOCKS5("tcp", proxyUrl.Host, proxyAuth, proxy.Direct)
+		dialer, err := proxy.SOCKS5("tcp", proxyUrl.Host, proxyAuth, forward)
 		if err != nil {
 			return nil, err
 		}
@@ -37,12 +51,11 @@ func GetProxyConn(ctx context.Context, proxyUrl *url.URL, address string, sslIns
 		})
 		conn, err = dc.DialContext(ctx, "tcp", address)
 		if err != nil {
-			conn.Close()
-			return nil, err
+			return nil, fmt.Errorf("socks5 upstream %v: %w", proxyUrl.Host, err)
 		}
 		return conn, err
 	} else {
-		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", proxyUrl.Host)
+		conn, err := forward.DialContext(ctx, "tcp", proxyUrl.Host)
 		if err != nil {
 			return nil, err
 		}
@@ -68,8 +81,8 @@ func GetProxyConn(ctx context.Context, proxyUrl *url.URL, address string, sslIns
 			Host:   address,
 			Header: http.Header{},
 		}
-		if proxyUrl.User != nil {
-			connectReq.Header.Set("Proxy-Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(proxyUrl.User.String())))
+		if auth := ProxyAuthorization(proxyUrl); auth != "" {
+			connectReq.Header.Set("Proxy-Authorization", auth)
 		}
 		connectCtx, cancel := context.WithTimeout(ctx, 1*time.Minute)
 		defer cancel()
@@ -99,6 +112,10 @@ func GetProxyConn(ctx context.Context, proxyUrl *url.URL, address string, sslIns
 			conn.Close()
 			return nil, err
 		}
+		if resp.StatusCode == http.StatusProxyAuthRequired {
+			conn.Close()
+			return nil, fmt.Errorf("%w: %v", ErrProxyAuthRequired, proxyUrl.Redacted())
+		}
 		if resp.StatusCode != 200 {
 			_, text, ok := strings.Cut(resp.Status, " ")
 			conn.Close()
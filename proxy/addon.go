@@ -3,49 +3,410 @@ package proxy
 import (
 	"io"
 	"net/http"
-	"time"
+	"slices"
 
 	log "github.com/sirupsen/logrus"
 )
 
-type Addon interface {
+// Small interfaces for each hook, an addon can implement only the hooks it needs.
+// The proxy type-asserts each capability, see Proxy.AddAddon.
+
+type ClientConnectedObserver interface {
 	// A client has connected to mitmproxy. Note that a connection can correspond to multiple HTTP requests.
 	ClientConnected(*ClientConn)
+}
 
+type ClientDisconnectedObserver interface {
 	// A client connection has been closed (either by us or the client).
+	// Called exactly once per connection. The side closed first is reported first:
+	// if the client closes, ClientDisconnected is called before ServerDisconnected, and vice versa.
 	ClientDisconnected(*ClientConn)
+}
 
+type ServerConnectedObserver interface {
 	// Mitmproxy has connected to a server.
 	ServerConnected(*ConnContext)
+}
 
+type ServerDisconnectedObserver interface {
 	// A server connection has been closed (either by us or the server).
+	// Called exactly once per server connection, see ClientDisconnectedObserver for the order.
 	ServerDisconnected(*ConnContext)
+}
 
+type TlsEstablishedServerObserver interface {
 	// The TLS handshake with the server has been completed successfully.
 	TlsEstablishedServer(*ConnContext)
+}
+
+type TlsEstablishedClientObserver interface {
+	// The TLS handshake with the client of an intercepted connection has been completed, ClientConn.TlsClientHello and ClientConn.Ja3 are set.
+	TlsEstablishedClient(*ConnContext)
+}
+
+type Stopper interface {
+	// The proxy is closed by Proxy.Close, flush buffered data and release the resources of addon.
+	Stop() error
+}
 
+type RequestheadersInterceptor interface {
 	// HTTP request headers were successfully read. At this point, the body is empty.
+	// It is also called for the CONNECT request, setting Flow.Response then refuses the tunnel with it.
 	Requestheaders(*Flow)
+}
 
+type RequestInterceptor interface {
 	// The full HTTP request has been read.
+	// Setting Flow.Response here (or in Requestheaders) replies without sending the request upstream, the reply still goes through Response.
+	// Flow.Request.Header after it is sent upstream as is, except hop-by-hop headers such as Connection and Proxy-Authorization.
 	Request(*Flow)
+}
 
+type ResponseheadersInterceptor interface {
 	// HTTP response headers were successfully read. At this point, the body is empty.
 	Responseheaders(*Flow)
+}
 
+type ResponseInterceptor interface {
 	// The full HTTP response has been read.
+	// Flow.Response.Header after it is sent to the client as is, except hop-by-hop headers, and Content-Length is set to the length of the body.
 	Response(*Flow)
+}
 
+type StreamRequestModifier interface {
 	// Stream request body modifier
 	StreamRequestModifier(*Flow, io.Reader) io.Reader
+}
 
+type StreamResponseModifier interface {
 	// Stream response body modifier
 	StreamResponseModifier(*Flow, io.Reader) io.Reader
+}
+
+type RequestBodyChunkInterceptor interface {
+	// A chunk of the request body is forwarded in Stream mode, return chunk to keep it or the bytes to forward instead.
+	// chunk is only valid during the call. The request is sent chunked then, without Content-Length.
+	RequestBodyChunk(f *Flow, chunk []byte) []byte
+}
+
+type ResponseBodyChunkInterceptor interface {
+	// A chunk of the response body is forwarded in Stream mode, return chunk to keep it or the bytes to forward instead.
+	// chunk is only valid during the call. The response is sent without Content-Length then.
+	ResponseBodyChunk(f *Flow, chunk []byte) []byte
+}
+
+type TcpDataInterceptor interface {
+	// Bytes of the raw tcp tunnel of ConnContext.RawTcp are forwarded, fromClient is false for the bytes sent by server.
+	// Return data to keep it or the bytes to forward instead, data is only valid during the call.
+	TcpData(connCtx *ConnContext, fromClient bool, data []byte) []byte
+}
 
+type AccessProxyServerHandler interface {
 	// onAccessProxyServer
 	AccessProxyServer(req *http.Request, res http.ResponseWriter)
 }
 
+type ConnTimingsObserver interface {
+	// A client connection has been closed, ConnContext.Timings() returns the latency of each phase.
+	ConnTimings(*ConnContext)
+}
+
+type LargeBodyObserver interface {
+	// A buffered request or response body is larger than Options.LargeBodyThreshold.
+	LargeBody(f *Flow, isResponse bool, size int)
+}
+
+type FlowErrorObserver interface {
+	// A flow did not complete normally (upstream error, timeout, aborted), Flow.Error is set and Flow.Response may be nil.
+	FlowError(f *Flow)
+}
+
+type SlowHeadersObserver interface {
+	// Flow.HeaderDuration is longer than Options.SlowHeadersThreshold.
+	SlowHeaders(f *Flow)
+}
+
+type CertIssuedObserver interface {
+	// A leaf cert is served for the client connection, cached is false when it is newly minted.
+	CertIssued(connCtx *ConnContext, serverName string, cached bool)
+}
+
+type WebSocketMessageObserver interface {
+	// A message of the websocket upgraded by the flow is forwarded, msg must not be modified.
+	WebSocketMessage(f *Flow, msg *WebSocketMessage)
+}
+
+type UpstreamRewriter interface {
+	// Rewrite the host:port of the intercepted upstream server before dialing, return addr to keep it.
+	// The client still gets the cert of the original host, the upstream cert is verified against the new host.
+	RewriteUpstream(connCtx *ConnContext, addr string) string
+}
+
+type ConnectHandler interface {
+	// A CONNECT request is received, before deciding whether to intercept it and dialing the server.
+	// Return the host:port to connect instead, or "" to keep host. The new host is also the SNI sent to the server.
+	// An error rejects the CONNECT with 502 and closes the client connection, Flow.Error of the CONNECT flow is set to it.
+	Connect(connCtx *ConnContext, host string) (string, error)
+}
+
+type TlsHandshakeErrorObserver interface {
+	// The TLS handshake with server failed after the retries, the connection is closed then.
+	// The reason of a certificate verification failure is returned by UpstreamCertErrorReason(err).
+	TlsHandshakeError(connCtx *ConnContext, err error)
+}
+
+type ClientTlsHandshakeErrorObserver interface {
+	// The TLS handshake with client failed, such as the client does not trust the CA or offers no supported version.
+	// The connection is closed then. info has the details of the client and the failure.
+	ClientTlsHandshakeError(connCtx *ConnContext, info *ClientTlsError)
+}
+
+type ClientHelloHandler interface {
+	// The ClientHello of a tls connection to be intercepted is received, before the certificate is issued.
+	// ClientConn.TlsClientHello and ClientConn.Ja3 are set, they are nil and "" if the ClientHello is larger than 4KB.
+	// Set ConnContext.Intercept to false to tunnel the connection instead, such as the traffic of apps pinning certificates.
+	ClientHello(connCtx *ConnContext)
+}
+
+type ResponseStreamer interface {
+	// Options.StreamResponses is set and response headers were read, return false if the addon needs the body in Response.
+	// The body is forwarded to the client without buffering when no addon returns false,
+	// Response is still called with the headers but the body is nil and Flow.Stream is true.
+	StreamResponse(*Flow) bool
+}
+
+// ConnectionObserver observe all connection events
+type ConnectionObserver interface {
+	ClientConnectedObserver
+	ClientDisconnectedObserver
+	ServerConnectedObserver
+	ServerDisconnectedObserver
+	TlsEstablishedServerObserver
+}
+
+// Addon implement all hooks, keep for backward compatibility
+type Addon interface {
+	ConnectionObserver
+	RequestheadersInterceptor
+	RequestInterceptor
+	ResponseheadersInterceptor
+	ResponseInterceptor
+	StreamRequestModifier
+	StreamResponseModifier
+	AccessProxyServerHandler
+}
+
+// Hook identify addon hooks, can be combined as a bitmask
+type Hook uint32
+
+const (
+	HookClientConnected Hook = 1 << iota
+	HookClientDisconnected
+	HookServerConnected
+	HookServerDisconnected
+	HookTlsEstablishedServer
+	HookRequestheaders
+	HookRequest
+	HookResponseheaders
+	HookResponse
+	HookStreamRequestModifier
+	HookStreamResponseModifier
+	HookAccessProxyServer
+	HookConnTimings
+	HookLargeBody
+	HookFlowError
+	HookSlowHeaders
+	HookCertIssued
+	HookWebSocketMessage
+	HookRewriteUpstream
+	HookTlsEstablishedClient
+	HookStop
+	HookConnect
+	HookStreamResponse
+	HookTlsHandshakeError
+	HookRequestBodyChunk
+	HookResponseBodyChunk
+	HookTcpData
+	HookClientTlsHandshakeError
+	HookClientHello
+
+	hookEnd
+	HookAll = hookEnd - 1
+)
+
+// HookAdvertiser can be implemented by addon to advertise which hooks it implements,
+// the proxy will skip calling other hooks of the addon.
+// Useful when embedding BaseAddon, which implements all hooks.
+type HookAdvertiser interface {
+	Hooks() Hook
+}
+
+// OriginalHeadersAdvertiser can be implemented by addon to advertise which headers it reads from Flow.OriginalRequest
+// and Flow.OriginalResponse, such as []string{"User-Agent"}, nil for none of them.
+// When all the addons implement it, only the headers advertised are copied to them instead of all, to save the
+// allocations of lightweight addons at high request rates. The others are nil, Options.OnFlowComplete sees the same.
+type OriginalHeadersAdvertiser interface {
+	OriginalHeaders() []string
+}
+
+// addons of each hook
+type addonHooks struct {
+	clientConnected         []ClientConnectedObserver
+	clientDisconnected      []ClientDisconnectedObserver
+	serverConnected         []ServerConnectedObserver
+	serverDisconnected      []ServerDisconnectedObserver
+	tlsEstablishedServer    []TlsEstablishedServerObserver
+	requestheaders          []RequestheadersInterceptor
+	request                 []RequestInterceptor
+	responseheaders         []ResponseheadersInterceptor
+	response                []ResponseInterceptor
+	streamRequestModifier   []StreamRequestModifier
+	streamResponseModifier  []StreamResponseModifier
+	accessProxyServer       []AccessProxyServerHandler
+	connTimings             []ConnTimingsObserver
+	largeBody               []LargeBodyObserver
+	flowError               []FlowErrorObserver
+	slowHeaders             []SlowHeadersObserver
+	certIssued              []CertIssuedObserver
+	webSocketMessage        []WebSocketMessageObserver
+	rewriteUpstream         []UpstreamRewriter
+	tlsEstablishedClient    []TlsEstablishedClientObserver
+	stop                    []Stopper
+	connect                 []ConnectHandler
+	streamResponse          []ResponseStreamer
+	tlsHandshakeError       []TlsHandshakeErrorObserver
+	requestBodyChunk        []RequestBodyChunkInterceptor
+	responseBodyChunk       []ResponseBodyChunkInterceptor
+	tcpData                 []TcpDataInterceptor
+	clientTlsHandshakeError []ClientTlsHandshakeErrorObserver
+	clientHello             []ClientHelloHandler
+
+	addons             int
+	originalHeaders    []string // canonical keys advertised by OriginalHeadersAdvertiser
+	allOriginalHeaders bool     // an addon does not implement OriginalHeadersAdvertiser
+}
+
+func (h *addonHooks) add(addon interface{}) {
+	h.addons++
+	if a, ok := addon.(OriginalHeadersAdvertiser); ok {
+		for _, name := range a.OriginalHeaders() {
+			if key := http.CanonicalHeaderKey(name); !slices.Contains(h.originalHeaders, key) {
+				h.originalHeaders = append(h.originalHeaders, key)
+			}
+		}
+	} else {
+		h.allOriginalHeaders = true
+	}
+
+	hooks := HookAll
+	if a, ok := addon.(HookAdvertiser); ok {
+		hooks = a.Hooks()
+	}
+
+	if a, ok := addon.(ClientConnectedObserver); ok && hooks&HookClientConnected != 0 {
+		h.clientConnected = append(h.clientConnected, a)
+	}
+	if a, ok := addon.(ClientDisconnectedObserver); ok && hooks&HookClientDisconnected != 0 {
+		h.clientDisconnected = append(h.clientDisconnected, a)
+	}
+	if a, ok := addon.(ServerConnectedObserver); ok && hooks&HookServerConnected != 0 {
+		h.serverConnected = append(h.serverConnected, a)
+	}
+	if a, ok := addon.(ServerDisconnectedObserver); ok && hooks&HookServerDisconnected != 0 {
+		h.serverDisconnected = append(h.serverDisconnected, a)
+	}
+	if a, ok := addon.(TlsEstablishedServerObserver); ok && hooks&HookTlsEstablishedServer != 0 {
+		h.tlsEstablishedServer = append(h.tlsEstablishedServer, a)
+	}
+	if a, ok := addon.(RequestheadersInterceptor); ok && hooks&HookRequestheaders != 0 {
+		h.requestheaders = append(h.requestheaders, a)
+	}
+	if a, ok := addon.(RequestInterceptor); ok && hooks&HookRequest != 0 {
+		h.request = append(h.request, a)
+	}
+	if a, ok := addon.(ResponseheadersInterceptor); ok && hooks&HookResponseheaders != 0 {
+		h.responseheaders = append(h.responseheaders, a)
+	}
+	if a, ok := addon.(ResponseInterceptor); ok && hooks&HookResponse != 0 {
+		h.response = append(h.response, a)
+	}
+	if a, ok := addon.(StreamRequestModifier); ok && hooks&HookStreamRequestModifier != 0 {
+		h.streamRequestModifier = append(h.streamRequestModifier, a)
+	}
+	if a, ok := addon.(StreamResponseModifier); ok && hooks&HookStreamResponseModifier != 0 {
+		h.streamResponseModifier = append(h.streamResponseModifier, a)
+	}
+	if a, ok := addon.(AccessProxyServerHandler); ok && hooks&HookAccessProxyServer != 0 {
+		h.accessProxyServer = append(h.accessProxyServer, a)
+	}
+	if a, ok := addon.(ConnTimingsObserver); ok && hooks&HookConnTimings != 0 {
+		h.connTimings = append(h.connTimings, a)
+	}
+	if a, ok := addon.(LargeBodyObserver); ok && hooks&HookLargeBody != 0 {
+		h.largeBody = append(h.largeBody, a)
+	}
+	if a, ok := addon.(FlowErrorObserver); ok && hooks&HookFlowError != 0 {
+		h.flowError = append(h.flowError, a)
+	}
+	if a, ok := addon.(SlowHeadersObserver); ok && hooks&HookSlowHeaders != 0 {
+		h.slowHeaders = append(h.slowHeaders, a)
+	}
+	if a, ok := addon.(CertIssuedObserver); ok && hooks&HookCertIssued != 0 {
+		h.certIssued = append(h.certIssued, a)
+	}
+	if a, ok := addon.(WebSocketMessageObserver); ok && hooks&HookWebSocketMessage != 0 {
+		h.webSocketMessage = append(h.webSocketMessage, a)
+	}
+	if a, ok := addon.(UpstreamRewriter); ok && hooks&HookRewriteUpstream != 0 {
+		h.rewriteUpstream = append(h.rewriteUpstream, a)
+	}
+	if a, ok := addon.(TlsEstablishedClientObserver); ok && hooks&HookTlsEstablishedClient != 0 {
+		h.tlsEstablishedClient = append(h.tlsEstablishedClient, a)
+	}
+	if a, ok := addon.(Stopper); ok && hooks&HookStop != 0 {
+		h.stop = append(h.stop, a)
+	}
+	if a, ok := addon.(ConnectHandler); ok && hooks&HookConnect != 0 {
+		h.connect = append(h.connect, a)
+	}
+	if a, ok := addon.(ResponseStreamer); ok && hooks&HookStreamResponse != 0 {
+		h.streamResponse = append(h.streamResponse, a)
+	}
+	if a, ok := addon.(TlsHandshakeErrorObserver); ok && hooks&HookTlsHandshakeError != 0 {
+		h.tlsHandshakeError = append(h.tlsHandshakeError, a)
+	}
+	if a, ok := addon.(RequestBodyChunkInterceptor); ok && hooks&HookRequestBodyChunk != 0 {
+		h.requestBodyChunk = append(h.requestBodyChunk, a)
+	}
+	if a, ok := addon.(ResponseBodyChunkInterceptor); ok && hooks&HookResponseBodyChunk != 0 {
+		h.responseBodyChunk = append(h.responseBodyChunk, a)
+	}
+	if a, ok := addon.(TcpDataInterceptor); ok && hooks&HookTcpData != 0 {
+		h.tcpData = append(h.tcpData, a)
+	}
+	if a, ok := addon.(ClientTlsHandshakeErrorObserver); ok && hooks&HookClientTlsHandshakeError != 0 {
+		h.clientTlsHandshakeError = append(h.clientTlsHandshakeError, a)
+	}
+	if a, ok := addon.(ClientHelloHandler); ok && hooks&HookClientHello != 0 {
+		h.clientHello = append(h.clientHello, a)
+	}
+}
+
+// whether addon implements any of the hook interfaces added by addonHooks.add
+func implementsHook(addon interface{}) bool {
+	switch addon.(type) {
+	case ClientConnectedObserver, ClientDisconnectedObserver, ServerConnectedObserver, ServerDisconnectedObserver,
+		TlsEstablishedServerObserver, RequestheadersInterceptor, RequestInterceptor, ResponseheadersInterceptor,
+		ResponseInterceptor, StreamRequestModifier, StreamResponseModifier, AccessProxyServerHandler,
+		ConnTimingsObserver, LargeBodyObserver, FlowErrorObserver, SlowHeadersObserver, CertIssuedObserver,
+		WebSocketMessageObserver, UpstreamRewriter, TlsEstablishedClientObserver, Stopper, ConnectHandler,
+		ResponseStreamer, TlsHandshakeErrorObserver, RequestBodyChunkInterceptor, ResponseBodyChunkInterceptor,
+		TcpDataInterceptor, ClientTlsHandshakeErrorObserver, ClientHelloHandler:
+		return true
+	}
+	return false
+}
+
 // BaseAddon do nothing
 type BaseAddon struct{}
 
@@ -72,7 +433,7 @@ func (addon *LogAddon) ClientConnected(client *ClientConn) {
 }
 
 func (addon *LogAddon) ClientDisconnected(client *ClientConn) {
-	log.Infof("%v client disconnect\n", client.Conn.RemoteAddr())
+	log.Infof("%v client disconnect (%v)\n", client.Conn.RemoteAddr(), client.CloseReason)
 }
 
 func (addon *LogAddon) ServerConnected(connCtx *ConnContext) {
@@ -80,12 +441,11 @@ func (addon *LogAddon) ServerConnected(connCtx *ConnContext) {
 }
 
 func (addon *LogAddon) ServerDisconnected(connCtx *ConnContext) {
-	log.Infof("%v server disconnect %v (%v->%v) - %v\n", connCtx.ClientConn.Conn.RemoteAddr(), connCtx.ServerConn.Address, connCtx.ServerConn.Conn.LocalAddr(), connCtx.ServerConn.Conn.RemoteAddr(), connCtx.FlowCount)
+	log.Infof("%v server disconnect %v (%v->%v) - %v (%v)\n", connCtx.ClientConn.Conn.RemoteAddr(), connCtx.ServerConn.Address, connCtx.ServerConn.Conn.LocalAddr(), connCtx.ServerConn.Conn.RemoteAddr(), connCtx.FlowCount, connCtx.ServerConn.CloseReason)
 }
 
 func (addon *LogAddon) Requestheaders(f *Flow) {
 	log.Debugf("%v Requestheaders %v %v\n", f.ConnContext.ClientConn.Conn.RemoteAddr(), f.Request.Method, f.Request.URL.String())
-	start := time.Now()
 	go func() {
 		<-f.Done()
 		var StatusCode int
@@ -96,10 +456,22 @@ func (addon *LogAddon) Requestheaders(f *Flow) {
 		if f.Response != nil && f.Response.Body != nil {
 			contentLen = len(f.Response.Body)
 		}
-		log.Infof("%v %v %v %v %v - %v ms\n", f.ConnContext.ClientConn.Conn.RemoteAddr(), f.Request.Method, f.Request.URL.String(), StatusCode, contentLen, time.Since(start).Milliseconds())
+		upstream := ""
+		if u := f.EffectiveUpstreamURL(); u != nil && u.String() != f.Request.URL.String() {
+			upstream = " (upstream " + u.String() + ")"
+		}
+		log.Infof("%v %v %v%v %v %v - %v ms\n", f.ConnContext.ClientConn.Conn.RemoteAddr(), f.Request.Method, f.Request.URL.String(), upstream, StatusCode, contentLen, f.Duration().Milliseconds())
 	}()
 }
 
+func (addon *LogAddon) Hooks() Hook {
+	return HookClientConnected | HookClientDisconnected | HookServerConnected | HookServerDisconnected | HookRequestheaders
+}
+
+func (addon *LogAddon) OriginalHeaders() []string {
+	return nil
+}
+
 type UpstreamCertAddon struct {
 	BaseAddon
 	UpstreamCert bool // Connect to upstream server to look up certificate details.
@@ -112,3 +484,11 @@ func NewUpstreamCertAddon(upstreamCert bool) *UpstreamCertAddon {
 func (addon *UpstreamCertAddon) ClientConnected(conn *ClientConn) {
 	conn.UpstreamCert = addon.UpstreamCert
 }
+
+func (addon *UpstreamCertAddon) Hooks() Hook {
+	return HookClientConnected
+}
+
+func (addon *UpstreamCertAddon) OriginalHeaders() []string {
+	return nil
+}
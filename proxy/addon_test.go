@@ -0,0 +1,65 @@
+package proxy
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+type testResponseOnlyAddon struct {
+	BaseAddon
+	requestheaders int32
+	response       int32
+}
+
+func (addon *testResponseOnlyAddon) Requestheaders(*Flow) {
+	atomic.AddInt32(&addon.requestheaders, 1)
+}
+
+func (addon *testResponseOnlyAddon) Response(*Flow) {
+	atomic.AddInt32(&addon.response, 1)
+}
+
+func (addon *testResponseOnlyAddon) Hooks() Hook {
+	return HookResponse
+}
+
+func TestHookAdvertiser(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testResponseOnlyAddon{}
+	helper.testProxy.AddAddon(addon)
+
+	testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+	if n := atomic.LoadInt32(&addon.requestheaders); n != 0 {
+		t.Fatalf("expected Requestheaders skipped, but called %v times", n)
+	}
+	if n := atomic.LoadInt32(&addon.response); n != 1 {
+		t.Fatalf("expected Response called once, but called %v times", n)
+	}
+}
+
+// implement only ResponseInterceptor
+type testSetHeaderAddon struct{}
+
+func (addon *testSetHeaderAddon) Response(f *Flow) {
+	f.Response.Header.Set("x-response-only", "1")
+}
+
+func TestPartialAddon(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.AddHooks(&testSetHeaderAddon{})
+	// implement no hook
+	addons := helper.testProxy.hooks.addons
+	helper.testProxy.AddHooks(&struct{ name string }{})
+	if n := helper.testProxy.hooks.addons; n != addons {
+		t.Fatalf("expected the value without hook ignored, but got %v addons", n)
+	}
+
+	resp, body := testGetResponse(t, "http://example.com/", helper.getProxyClient())
+	if string(body) != "ok" || resp.Header.Get("x-response-only") != "1" {
+		t.Fatalf("unexpected response: %v %v", resp.Header, string(body))
+	}
+}
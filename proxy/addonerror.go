@@ -0,0 +1,60 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// AddonErrorPolicy how the flow goes on when an addon sets Flow.AddonError, see Options.AddonErrorPolicy
+type AddonErrorPolicy int
+
+const (
+	AddonErrorFailOpen   AddonErrorPolicy = iota // log the error and forward the flow as if the addon did nothing
+	AddonErrorFailClosed                         // block the flow with 502, its Flow.Error wraps ErrAddonFailed
+)
+
+func (p AddonErrorPolicy) String() string {
+	if p == AddonErrorFailClosed {
+		return "fail-closed"
+	}
+	return "fail-open"
+}
+
+// ErrAddonFailed the flow is blocked by Options.AddonErrorPolicy for Flow.AddonError
+var ErrAddonFailed = errors.New("addon failed")
+
+// whether f is blocked by Options.AddonErrorPolicy, Flow.Error is set then
+func (proxy *Proxy) addonFailed(f *Flow) bool {
+	if f.AddonError == nil || proxy.Opts.AddonErrorPolicy != AddonErrorFailClosed {
+		return false
+	}
+	if proxy.Opts.DryRun {
+		dryRunLog(f).Infof("dry run: would block for addon error: %v", f.AddonError)
+		return false
+	}
+	f.Error = fmt.Errorf("%w: %w", ErrAddonFailed, f.AddonError)
+	log.Warnf("%v %v blocked: %v", f.Request.Method, f.Request.URL, f.Error)
+	return true
+}
+
+// reply 502 if f is blocked by Options.AddonErrorPolicy
+func (a *attacker) replyAddonFailed(res http.ResponseWriter, f *Flow) bool {
+	if !a.proxy.addonFailed(f) {
+		return false
+	}
+	res.WriteHeader(http.StatusBadGateway)
+	io.WriteString(res, ErrAddonFailed.Error())
+	return true
+}
+
+// the flows going on with Flow.AddonError are logged once when they finish
+func logAddonError(f *Flow) {
+	if f.AddonError == nil || errors.Is(f.Error, ErrAddonFailed) {
+		return
+	}
+	log.Warnf("%v %v forwarded with addon error: %v", f.Request.Method, f.Request.URL, f.AddonError)
+}
@@ -0,0 +1,119 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type testAddonErrorAddon struct {
+	BaseAddon
+}
+
+func (addon *testAddonErrorAddon) Request(f *Flow) {
+	if f.Request.Header.Get("X-Request-Error") != "" {
+		f.AddonError = errors.New("auth server unavailable")
+	}
+}
+
+func (addon *testAddonErrorAddon) Response(f *Flow) {
+	if f.Request.Header.Get("X-Response-Error") != "" {
+		f.AddonError = errors.New("validation failed")
+	}
+}
+
+func TestAddonErrorPolicy(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.AddAddon(&testAddonErrorAddon{})
+	flows := make(chan *Flow, 4)
+	helper.testProxy.Opts.OnFlowComplete = func(f *Flow) {
+		if f.Request.Method != "CONNECT" {
+			flows <- f
+		}
+	}
+
+	for _, policy := range []AddonErrorPolicy{AddonErrorFailOpen, AddonErrorFailClosed} {
+		helper.testProxy.Opts.AddonErrorPolicy = policy
+		for _, url := range []string{"http://example.com/", "https://example.com/"} {
+			for _, header := range []string{"X-Request-Error", "X-Response-Error"} {
+				t.Run(fmt.Sprintf("%v %v %v", policy, url, header), func(t *testing.T) {
+					req, err := http.NewRequest("GET", url, nil)
+					handleError(t, err)
+					req.Header.Set(header, "1")
+					resp, err := helper.getProxyClient().Do(req)
+					handleError(t, err)
+					body, err := io.ReadAll(resp.Body)
+					resp.Body.Close()
+					handleError(t, err)
+					f := <-flows
+					if f.AddonError == nil {
+						t.Fatal("expected Flow.AddonError")
+					}
+					if policy == AddonErrorFailOpen {
+						if resp.StatusCode != 200 || string(body) != "ok" || f.Error != nil {
+							t.Fatalf("expected forwarded, but got %v %q %v", resp.Status, body, f.Error)
+						}
+						return
+					}
+					if resp.StatusCode != 502 || string(body) != ErrAddonFailed.Error() {
+						t.Fatalf("expected blocked, but got %v %q", resp.Status, body)
+					}
+					if !errors.Is(f.Error, ErrAddonFailed) || !errors.Is(f.Error, f.AddonError) {
+						t.Fatalf("expected ErrAddonFailed, but got %v", f.Error)
+					}
+					if header == "X-Request-Error" && f.EffectiveUpstreamURL() != nil {
+						t.Fatal("expected the request not sent to upstream")
+					}
+				})
+			}
+		}
+	}
+}
+
+type testPanicAddon struct {
+	BaseAddon
+}
+
+func (addon *testPanicAddon) Request(f *Flow) {
+	panic("nil map")
+}
+
+func TestAddonPanicFailClosed(t *testing.T) {
+	flows := make(chan *Flow, 1)
+	helper := &testPipeHelper{opts: &Options{
+		AddonErrorPolicy: AddonErrorFailClosed,
+		OnFlowComplete: func(f *Flow) {
+			if f.Request.Method != "CONNECT" {
+				flows <- f
+			}
+		},
+	}}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.AddAddon(&testPanicAddon{})
+
+	for _, url := range []string{"http://example.com/", "https://example.com/"} {
+		t.Run(url, func(t *testing.T) {
+			resp, err := helper.getProxyClient().Get(url)
+			handleError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			handleError(t, err)
+			if resp.StatusCode != 502 || string(body) != ErrAddonFailed.Error() {
+				t.Fatalf("expected blocked, but got %v %q", resp.Status, body)
+			}
+			f := <-flows
+			if f.AddonError == nil || !strings.Contains(f.AddonError.Error(), "addon panic: nil map") {
+				t.Fatalf("expected Flow.AddonError of the panic, but got %v", f.AddonError)
+			}
+			if !errors.Is(f.Error, ErrAddonFailed) {
+				t.Fatalf("expected ErrAddonFailed, but got %v", f.Error)
+			}
+		})
+	}
+}
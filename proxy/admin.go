@@ -0,0 +1,70 @@
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// serve the admin endpoints on Options.AdminAddr, separated from the proxy listener
+func (proxy *Proxy) startAdmin() {
+	server := &http.Server{
+		Addr:    proxy.Opts.AdminAddr,
+		Handler: proxy.adminHandler(),
+	}
+	proxy.adminServer = server
+	go func() {
+		log.Infof("Proxy admin listen at %v\n", server.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("admin server: %v", err)
+		}
+	}()
+}
+
+// /healthz: the process is alive
+// /readyz: the proxy is accepting connections, 503 before listening or once closing
+// /stats: ProxyStats in json
+// /conns/close?id=: POST to gracefully close the client connection, see Proxy.CloseConn
+func (proxy *Proxy) adminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&proxy.entry.listening) == 0 || proxy.isClosing() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(proxy.Stats())
+	})
+	mux.HandleFunc("/conns/close", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := proxy.CloseConn(r.URL.Query().Get("id")); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrConnNotFound) {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+	return mux
+}
+
+// close the admin server after the proxy is shut down, /readyz reports 503 while draining
+func (proxy *Proxy) closeAdmin() {
+	if proxy.adminServer != nil {
+		proxy.adminServer.Close()
+	}
+}
@@ -0,0 +1,39 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminHandler(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+
+	get := func(path string) (int, string) {
+		rec := httptest.NewRecorder()
+		helper.testProxy.adminHandler().ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec.Code, rec.Body.String()
+	}
+	if code, _ := get("/healthz"); code != 200 {
+		t.Fatalf("expected healthz 200, got %v", code)
+	}
+	if code, _ := get("/readyz"); code != 200 {
+		t.Fatalf("expected readyz 200, got %v", code)
+	}
+	code, body := get("/stats")
+	var stats ProxyStats
+	if err := json.Unmarshal([]byte(body), &stats); code != 200 || err != nil || stats.AcceptedConns != 1 {
+		t.Fatalf("expected stats, got %v %v", code, body)
+	}
+
+	helper.testProxy.Close()
+	if code, _ := get("/readyz"); code != 503 {
+		t.Fatalf("expected readyz 503 after close, got %v", code)
+	}
+	if code, _ := get("/healthz"); code != 200 {
+		t.Fatalf("expected healthz 200 after close, got %v", code)
+	}
+}
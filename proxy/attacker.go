@@ -1,13 +1,24 @@
 package proxy
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"crypto/tls"
+	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/lqqyt2423/go-mitmproxy/cert"
 	"github.com/lqqyt2423/go-mitmproxy/internal/helper"
@@ -16,18 +27,33 @@ import (
 )
 
 type attackerListener struct {
-	connChan chan net.Conn
+	connChan  chan net.Conn
+	closeChan chan struct{} // closed by Close, when the attacker server is shut down
+	closeOnce sync.Once
 }
 
 func (l *attackerListener) accept(conn net.Conn) {
-	l.connChan <- conn
+	select {
+	case l.connChan <- conn:
+	case <-l.closeChan:
+		conn.Close()
+	}
 }
 
 func (l *attackerListener) Accept() (net.Conn, error) {
-	c := <-l.connChan
-	return c, nil
+	select {
+	case c := <-l.connChan:
+		return c, nil
+	case <-l.closeChan:
+		return nil, net.ErrClosed
+	}
 }
-func (l *attackerListener) Close() error   { return nil }
+
+func (l *attackerListener) Close() error {
+	l.closeOnce.Do(func() { close(l.closeChan) })
+	return nil
+}
+
 func (l *attackerListener) Addr() net.Addr { return nil }
 
 type attackerConn struct {
@@ -42,41 +68,73 @@ type attacker struct {
 	h2Server *http2.Server
 	client   *http.Client
 	listener *attackerListener
+
+	// upstream connections of plain http requests shared by client connections, nil if not Options.PoolUpstreamConns
+	pool       *http.Transport
+	poolClient *http.Client
 }
 
 func newAttacker(proxy *Proxy) (*attacker, error) {
-	ca, err := cert.NewCA(proxy.Opts.CaRootPath)
+	ca, err := proxy.loadCA()
 	if err != nil {
 		return nil, err
 	}
+	ca.Storage = proxy.Opts.CertStorage
 
 	a := &attacker{
 		proxy: proxy,
 		ca:    ca,
 		client: &http.Client{
 			Transport: &http.Transport{
-				Proxy:              proxy.realUpstreamProxy(),
-				ForceAttemptHTTP2:  true,
-				DisableCompression: true, // To get the original response from the server, set Transport.DisableCompression to true.
+				Proxy: proxy.realUpstreamProxy(),
+				OnProxyConnectResponse: func(ctx context.Context, proxyURL *url.URL, connectReq *http.Request, connectRes *http.Response) error {
+					if connectRes.StatusCode == http.StatusProxyAuthRequired {
+						return fmt.Errorf("%w: %v", ErrUpstreamProxyAuth, proxyURL.Redacted())
+					}
+					return nil
+				},
+				DialContext:            proxy.dialContext,
+				ForceAttemptHTTP2:      true,
+				DisableCompression:     true, // To get the original response from the server, set Transport.DisableCompression to true.
+				MaxResponseHeaderBytes: proxy.Opts.UpstreamMaxResponseHeaderBytes,
 				TLSClientConfig: &tls.Config{
 					InsecureSkipVerify: proxy.Opts.SslInsecure,
-					KeyLogWriter:       helper.GetTlsKeyLogWriter(),
+					KeyLogWriter:       proxy.serverKeyLogWriter(),
+					RootCAs:            proxy.Opts.UpstreamRootCAs,
+					MinVersion:         proxy.Opts.UpstreamTLSMinVersion,
+					MaxVersion:         proxy.Opts.UpstreamTLSMaxVersion,
+					Renegotiation:      proxy.Opts.UpstreamTLSRenegotiation,
 				},
 			},
+			Timeout: proxy.Opts.RequestTimeout,
 			CheckRedirect: func(req *http.Request, via []*http.Request) error {
 				// 禁止自动重定向
 				return http.ErrUseLastResponse
 			},
 		},
 		listener: &attackerListener{
-			connChan: make(chan net.Conn),
+			connChan:  make(chan net.Conn),
+			closeChan: make(chan struct{}),
 		},
 	}
 
+	// the requests of a client connection are sent to several upstreams by ReverseRoutes
+	if (proxy.Opts.PoolUpstreamConns || len(proxy.Opts.ReverseRoutes) > 0) && proxy.Opts.UpstreamRoundTripper == nil {
+		a.pool = a.newUpstreamPool()
+		a.poolClient = &http.Client{
+			Transport: a.pool,
+			Timeout:   proxy.Opts.RequestTimeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				// 禁止自动重定向
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+
 	a.server = &http.Server{
 		Handler: a,
 		ConnContext: func(ctx context.Context, c net.Conn) context.Context {
-			return context.WithValue(ctx, connContextKey, c.(*attackerConn).connCtx)
+			return c.(*attackerConn).connCtx.serverContext(ctx)
 		},
 	}
 
@@ -93,30 +151,39 @@ func (a *attacker) start() error {
 }
 
 func (a *attacker) serveConn(clientTlsConn *tls.Conn, connCtx *ConnContext) {
-	connCtx.ClientConn.NegotiatedProtocol = clientTlsConn.ConnectionState().NegotiatedProtocol
+	clientTlsState := clientTlsConn.ConnectionState()
+	connCtx.ClientConn.NegotiatedProtocol = clientTlsState.NegotiatedProtocol
+	connCtx.ClientConn.TLSVersion = tls.VersionName(clientTlsState.Version)
+	connCtx.ClientConn.CipherSuite = tls.CipherSuiteName(clientTlsState.CipherSuite)
+	log.Debugf("client %v tls established: %v %v", connCtx.ClientConn.Conn.RemoteAddr(), connCtx.ClientConn.TLSVersion, connCtx.ClientConn.CipherSuite)
+	for _, addon := range a.proxy.hooks.tlsEstablishedClient {
+		addon.TlsEstablishedClient(connCtx)
+	}
+	clientConn := newTapConn(clientTlsConn, connCtx, a.proxy.Opts.OnClientBytes)
 
-	if connCtx.ClientConn.NegotiatedProtocol == "h2" && connCtx.ServerConn != nil {
-		connCtx.ServerConn.client = &http.Client{
-			Transport: &http2.Transport{
+	// Options.ALPNPassthrough, the protocol negotiated with server is not http
+	if proto := connCtx.ClientConn.NegotiatedProtocol; a.proxy.Opts.ALPNPassthrough && proto != "" && proto != "h2" && proto != "http/1.1" && connCtx.ServerConn != nil {
+		log.Debugf("client %v negotiated %v, forward as is", connCtx.ClientConn.Conn.RemoteAddr(), proto)
+		transfer(log.WithField("in", "Proxy.attacker.serveConn"), newTapConn(connCtx.ServerConn.tlsConn, connCtx, a.proxy.Opts.OnServerBytes), clientConn)
+		return
+	}
+
+	if connCtx.ClientConn.NegotiatedProtocol == "h2" {
+		// without ServerConn, the server is dialed by the first request, see httpsLazyAttack
+		// h2 may be offered to client by Options.ClientALPN when server does not support it, the requests are sent by http/1.1
+		if connCtx.ServerConn != nil && connCtx.ServerConn.NegotiatedProtocol == "h2" && a.proxy.Opts.UpstreamRoundTripper == nil {
+			connCtx.ServerConn.client = newServerClient(connCtx, &http2.Transport{
 				DialTLSContext: func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
-					return connCtx.ServerConn.tlsConn, nil
+					return newTapConn(connCtx.ServerConn.tlsConn, connCtx, a.proxy.Opts.OnServerBytes), nil
 				},
 				DisableCompression: true,
-			},
-			CheckRedirect: func(req *http.Request, via []*http.Request) error {
-				// 禁止自动重定向
-				return http.ErrUseLastResponse
-			},
+				MaxHeaderListSize:  a.proxy.upstreamMaxHeaderListSize(),
+			})
 		}
 
-		ctx := context.WithValue(context.Background(), connContextKey, connCtx)
-		ctx, cancel := context.WithCancel(ctx)
-		go func() {
-			<-connCtx.ClientConn.Conn.(*wrapClientConn).closeChan
-			cancel()
-		}()
+		ctx := context.WithValue(connCtx.ctx, connContextKey, connCtx)
 		go func() {
-			a.h2Server.ServeConn(clientTlsConn, &http2.ServeConnOpts{
+			a.h2Server.ServeConn(clientConn, &http2.ServeConnOpts{
 				Context:    ctx,
 				Handler:    a,
 				BaseConfig: a.server,
@@ -126,17 +193,24 @@ func (a *attacker) serveConn(clientTlsConn *tls.Conn, connCtx *ConnContext) {
 	}
 
 	a.listener.accept(&attackerConn{
-		Conn:    clientTlsConn,
+		Conn:    clientConn,
 		connCtx: connCtx,
 	})
 }
 
 func (a *attacker) ServeHTTP(res http.ResponseWriter, req *http.Request) {
-	if strings.EqualFold(req.Header.Get("Connection"), "Upgrade") && strings.EqualFold(req.Header.Get("Upgrade"), "websocket") {
-		// wss
-		defaultWebSocket.wss(res, req)
+	if a.proxy.blockMethod(res, req) || a.proxy.rejectLongLine(res, req) {
 		return
 	}
+	if req.Host == "" {
+		connCtx := req.Context().Value(connContextKey).(*ConnContext)
+		if !a.proxy.Opts.DeriveMissingHost || connCtx.connectHost == "" {
+			res.WriteHeader(400)
+			io.WriteString(res, "missing required Host header")
+			return
+		}
+		req.Host = connCtx.connectHost
+	}
 
 	if req.URL.Scheme == "" {
 		req.URL.Scheme = "https"
@@ -150,38 +224,30 @@ func (a *attacker) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 func (a *attacker) initHttpDialFn(req *http.Request) {
 	connCtx := req.Context().Value(connContextKey).(*ConnContext)
 	connCtx.dialFn = func(ctx context.Context) error {
-		// todo: proxy
-		addr := helper.CanonicalAddr(req.URL)
-		c, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+		c, addr, err := a.dialHttpServer(ctx, connCtx, req)
 		if err != nil {
 			return err
 		}
 		proxy := a.proxy
-		cw := &wrapServerConn{
-			Conn:    c,
-			proxy:   proxy,
-			connCtx: connCtx,
-		}
+		cw := newWrapServerConn(c, proxy, connCtx)
 
-		serverConn := newServerConn()
+		serverConn := newServerConn(proxy.newId())
 		serverConn.Conn = cw
 		serverConn.Address = addr
-		serverConn.client = &http.Client{
-			Transport: &http.Transport{
-				DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
-					return cw, nil
-				},
-				ForceAttemptHTTP2:  false, // disable http2
-				DisableCompression: true,  // To get the original response from the server, set Transport.DisableCompression to true.
-			},
-			CheckRedirect: func(req *http.Request, via []*http.Request) error {
-				// 禁止自动重定向
-				return http.ErrUseLastResponse
-			},
-		}
-
 		connCtx.ServerConn = serverConn
-		for _, addon := range proxy.Addons {
+		serverConn.statusLine = newStatusLineConn(newTapConn(cw, connCtx, proxy.Opts.OnServerBytes))
+		dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return serverConn.statusLine, nil
+		}
+		serverConn.client = newServerClient(connCtx, &http.Transport{
+			DialContext:            dial,
+			DialTLSContext:         dial,
+			ForceAttemptHTTP2:      false, // disable http2
+			DisableCompression:     true,  // To get the original response from the server, set Transport.DisableCompression to true.
+			MaxResponseHeaderBytes: proxy.Opts.UpstreamMaxResponseHeaderBytes,
+		})
+		atomic.AddInt64(&proxy.counters.activeServerConns, 1)
+		for _, addon := range proxy.hooks.serverConnected {
 			addon.ServerConnected(connCtx)
 		}
 
@@ -189,19 +255,122 @@ func (a *attacker) initHttpDialFn(req *http.Request) {
 	}
 }
 
+// dial the server of the plain http request, return the connection and the address rewritten by addons
+func (a *attacker) dialHttpServer(ctx context.Context, connCtx *ConnContext, req *http.Request) (net.Conn, string, error) {
+	addr := a.proxy.rewriteUpstream(connCtx, helper.CanonicalAddr(req.URL))
+	proxyUrl, err := a.proxy.getUpstreamProxyUrl(req)
+	if err != nil {
+		return nil, "", err
+	}
+	start := time.Now()
+	c, err := a.proxy.dialRetry(ctx, connCtx, func(ctx context.Context) (net.Conn, error) {
+		// todo: http upstream proxies often refuse CONNECT to plain http ports, only socks5 is used here
+		if proxyUrl != nil && proxyUrl.Scheme == "socks5" {
+			ctx, cancel := a.proxy.withDialTimeout(ctx, addr)
+			defer cancel()
+			c, err := helper.GetProxyConn(ctx, proxyUrl, addr, a.proxy.Opts.SslInsecure, a.proxy.Opts.Resolver)
+			return c, dialErr(err)
+		}
+		return a.proxy.dialContext(ctx, "tcp", addr)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	connect := time.Since(start)
+	connCtx.setTimings(func(t *ConnTimings) { t.UpstreamConnect = connect })
+	// the https request, such as of Options.ReverseUpstream, http.Transport takes the conn as tls by DialTLSContext
+	if req.URL.Scheme == "https" {
+		tlsConn := tls.Client(c, a.proxy.httpsServerTLSConfig(connCtx, req.URL.Hostname(), addr))
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			c.Close()
+			return nil, "", err
+		}
+		c = tlsConn
+	}
+	return c, addr, nil
+}
+
+// issue cert for the client connection, trigger CertIssuedObserver
+func (a *attacker) getCert(connCtx *ConnContext, serverName string) (*tls.Certificate, error) {
+	c, cached, err := a.getCA(connCtx).LookupCert(serverName)
+	if err != nil {
+		return nil, err
+	}
+	for _, addon := range a.proxy.hooks.certIssued {
+		addon.CertIssued(connCtx, serverName, cached)
+	}
+	return c, nil
+}
+
+// name of the cert issued to the client, the host of CONNECT if client sends no SNI, such as to an IP literal target.
+// The cert of an IP has it as IP SAN.
+func certName(connCtx *ConnContext, serverName string) string {
+	if serverName != "" || connCtx.connectHost == "" {
+		return serverName
+	}
+	if host, _, err := net.SplitHostPort(connCtx.connectHost); err == nil {
+		return host
+	}
+	return strings.Trim(connCtx.connectHost, "[]")
+}
+
+// the CA to sign the certificate for the client connection
+func (a *attacker) getCA(connCtx *ConnContext) *cert.CA {
+	if a.proxy.Opts.SelectCA != nil {
+		if ca := a.proxy.Opts.SelectCA(connCtx); ca != nil {
+			return ca
+		}
+	}
+	return a.ca
+}
+
+// new http client for ServerConn, use Options.UpstreamRoundTripper if set
+func newServerClient(connCtx *ConnContext, transport http.RoundTripper) *http.Client {
+	if fn := connCtx.proxy.Opts.UpstreamRoundTripper; fn != nil {
+		transport = fn(connCtx)
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   connCtx.proxy.Opts.RequestTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			// 禁止自动重定向
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
 // send clientHello to server, server handshake
 func (a *attacker) serverTlsHandshake(ctx context.Context, connCtx *ConnContext) error {
 	proxy := a.proxy
 	clientHello := connCtx.ClientConn.clientHello
 	serverConn := connCtx.ServerConn
 
+	// verify the cert of the rewritten host, the client still gets the cert of clientHello.ServerName
+	// without SNI, such as the target is an IP literal, verify the cert against the host of the address
+	serverName := clientHello.ServerName
+	if serverConn.rewritten || serverName == "" {
+		if host, _, err := net.SplitHostPort(serverConn.Address); err == nil {
+			serverName = host
+		}
+	}
+
 	serverTlsConfig := &tls.Config{
-		InsecureSkipVerify: proxy.Opts.SslInsecure,
-		KeyLogWriter:       helper.GetTlsKeyLogWriter(),
-		ServerName:         clientHello.ServerName,
+		InsecureSkipVerify: proxy.Opts.SslInsecure || connCtx.SkipUpstreamVerify,
+		RootCAs:            proxy.Opts.UpstreamRootCAs,
+		KeyLogWriter:       proxy.serverKeyLogWriter(),
+		ServerName:         serverName,
 		NextProtos:         clientHello.SupportedProtos,
 		// CurvePreferences:   clientHello.SupportedCurves, // todo: 如果打开会出错
-		CipherSuites: clientHello.CipherSuites,
+		CipherSuites:  clientHello.CipherSuites,
+		Renegotiation: proxy.Opts.UpstreamTLSRenegotiation,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if c := proxy.clientCert(serverConn.Address); c != nil {
+				return c, nil
+			}
+			// send no cert, server decides whether it is required
+			serverConn.clientCertMissing = true
+			return &tls.Certificate{}, nil
+		},
 	}
 	if len(clientHello.SupportedVersions) > 0 {
 		minVersion := clientHello.SupportedVersions[0]
@@ -217,34 +386,156 @@ func (a *attacker) serverTlsHandshake(ctx context.Context, connCtx *ConnContext)
 		serverTlsConfig.MinVersion = minVersion
 		serverTlsConfig.MaxVersion = maxVersion
 	}
+	// 强制指定的版本优先于客户端支持的版本
+	if v := proxy.Opts.UpstreamTLSMinVersion; v != 0 {
+		serverTlsConfig.MinVersion = v
+	}
+	if v := proxy.Opts.UpstreamTLSMaxVersion; v != 0 {
+		serverTlsConfig.MaxVersion = v
+		if serverTlsConfig.MinVersion > v {
+			serverTlsConfig.MinVersion = v
+		}
+	}
 	serverTlsConn := tls.Client(serverConn.Conn, serverTlsConfig)
 	serverConn.tlsConn = serverTlsConn
+	if timeout := proxy.timeouts(serverConn.Address).Handshake; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	start := time.Now()
 	if err := serverTlsConn.HandshakeContext(ctx); err != nil {
-		return err
+		serverConn.tlsErr = serverConn.clientCertErr(err)
+		return serverConn.tlsErr
 	}
+	serverConn.tlsErr = nil
+	handshake := time.Since(start)
+	connCtx.setTimings(func(t *ConnTimings) { t.UpstreamHandshake = handshake })
 	serverTlsState := serverTlsConn.ConnectionState()
 	serverConn.tlsState = &serverTlsState
-	for _, addon := range proxy.Addons {
+	serverConn.OfferedProtos = serverTlsConfig.NextProtos
+	serverConn.NegotiatedProtocol = serverTlsState.NegotiatedProtocol
+	serverConn.TLSVersion = tls.VersionName(serverTlsState.Version)
+	serverConn.CipherSuite = tls.CipherSuiteName(serverTlsState.CipherSuite)
+	log.Debugf("server %v tls established: %v %v", serverConn.Address, serverConn.TLSVersion, serverConn.CipherSuite)
+	if serverTlsState.NegotiatedProtocol != "h2" && slices.Contains(serverTlsConfig.NextProtos, "h2") {
+		log.Debugf("server %v does not support h2, offered %v, negotiated %q", serverConn.Address, serverTlsConfig.NextProtos, serverTlsState.NegotiatedProtocol)
+	}
+	for _, addon := range proxy.hooks.tlsEstablishedServer {
 		addon.TlsEstablishedServer(connCtx)
 	}
 
-	serverConn.client = &http.Client{
-		Transport: &http.Transport{
-			DialTLSContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
-				return serverTlsConn, nil
+	// http.Transport only uses http2 when DialTLSContext returns *tls.Conn, taps need explicit http2.Transport
+	if proxy.Opts.OnServerBytes != nil && serverTlsState.NegotiatedProtocol == "h2" {
+		serverConn.client = newServerClient(connCtx, &http2.Transport{
+			DialTLSContext: func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
+				return newTapConn(serverTlsConn, connCtx, proxy.Opts.OnServerBytes), nil
 			},
-			ForceAttemptHTTP2:  true,
-			DisableCompression: true, // To get the original response from the server, set Transport.DisableCompression to true.
-		},
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			// 禁止自动重定向
-			return http.ErrUseLastResponse
-		},
+			DisableCompression: true,
+			MaxHeaderListSize:  proxy.upstreamMaxHeaderListSize(),
+		})
+		return nil
+	}
+
+	dialConn := newTapConn(serverTlsConn, connCtx, proxy.Opts.OnServerBytes)
+	// http.Transport only uses http2 when DialTLSContext returns *tls.Conn, the status lines are recorded for http/1 only
+	if serverTlsState.NegotiatedProtocol != "h2" {
+		serverConn.statusLine = newStatusLineConn(dialConn)
+		dialConn = serverConn.statusLine
 	}
+	serverConn.client = newServerClient(connCtx, &http.Transport{
+		DialTLSContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialConn, nil
+		},
+		ForceAttemptHTTP2:      true,
+		DisableCompression:     true, // To get the original response from the server, set Transport.DisableCompression to true.
+		MaxResponseHeaderBytes: proxy.Opts.UpstreamMaxResponseHeaderBytes,
+		// the only connection is shared by the streams of h2 client when server does not support h2, send requests one by one
+		MaxConnsPerHost: 1,
+	})
 
 	return nil
 }
 
+// serverTlsHandshake, redial and handshake again on transient errors, at most Options.UpstreamHandshakeRetries times,
+// or Options.MaxRetries times with backoff if UpstreamHandshakeRetries is not set. Each retry dials once, the transient
+// dial errors take from the same budget instead of retrying in dialRetry again.
+func (a *attacker) serverTlsHandshakeRetry(ctx context.Context, connCtx *ConnContext, req *http.Request) error {
+	retries, backoff := a.proxy.Opts.UpstreamHandshakeRetries, false
+	if retries == 0 {
+		retries, backoff = a.proxy.Opts.MaxRetries, true
+	}
+	err := a.serverTlsHandshake(ctx, connCtx)
+	var dialErr error
+	for i := 0; err != nil && i < retries && ctx.Err() == nil; i++ {
+		transient := isTransientError(err)
+		if dialErr != nil {
+			transient = isTransientDialError(dialErr)
+		}
+		if !transient {
+			break
+		}
+		log.Debugf("server %v tls handshake error: %v, retry %v", connCtx.ServerConn.Address, err, i+1)
+		if backoff && !a.proxy.retryWait(ctx, i) {
+			break
+		}
+		connCtx.UpstreamRetries++
+		// replace the underlying connection, the server conn is kept for the hooks already triggered
+		wc := connCtx.ServerConn.Conn.(*wrapServerConn)
+		wc.Conn.Close()
+		var conn net.Conn
+		conn, dialErr = a.proxy.getUpstreamConnOnce(ctx, req, connCtx.ServerConn.Address)
+		if dialErr != nil {
+			err = dialErr
+			continue
+		}
+		wc.Conn = conn
+		err = a.serverTlsHandshake(ctx, connCtx)
+	}
+	if dialErr != nil {
+		return dialErr
+	}
+	if err != nil {
+		for _, addon := range a.proxy.hooks.tlsHandshakeError {
+			addon.TlsHandshakeError(connCtx, err)
+		}
+	}
+	return err
+}
+
+// the error of the upstream tls handshake shown to client
+func upstreamTlsErrorText(connCtx *ConnContext, err error) string {
+	if reason := UpstreamCertErrorReason(err); reason != "" {
+		return fmt.Sprintf("certificate of upstream %v is not trusted (%v): %v\n", connCtx.ServerConn.Address, reason, err)
+	}
+	return fmt.Sprintf("tls handshake with upstream %v failed: %v\n", connCtx.ServerConn.Address, err)
+}
+
+// the client handshake is completed without the server, reply its first request with 502 and the error, then close
+func replyUpstreamTlsError(conn net.Conn, connCtx *ConnContext, err error) {
+	req, rerr := http.ReadRequest(bufio.NewReader(conn))
+	if rerr != nil {
+		return
+	}
+	body := upstreamTlsErrorText(connCtx, err)
+	res := &http.Response{
+		StatusCode:    http.StatusBadGateway,
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}},
+		ContentLength: int64(len(body)),
+		Body:          io.NopCloser(strings.NewReader(body)),
+		Close:         true,
+		Request:       req,
+	}
+	res.Write(conn)
+}
+
+// the connection is reset or closed by peer
+func isTransientError(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE)
+}
+
 func (a *attacker) initHttpsDialFn(req *http.Request) {
 	connCtx := req.Context().Value(connContextKey).(*ConnContext)
 
@@ -253,7 +544,7 @@ func (a *attacker) initHttpsDialFn(req *http.Request) {
 		if err != nil {
 			return err
 		}
-		if err := a.serverTlsHandshake(ctx, connCtx); err != nil {
+		if err := a.serverTlsHandshakeRetry(ctx, connCtx, req); err != nil {
 			return err
 		}
 		return nil
@@ -264,27 +555,29 @@ func (a *attacker) httpsDial(ctx context.Context, req *http.Request) (net.Conn,
 	proxy := a.proxy
 	connCtx := req.Context().Value(connContextKey).(*ConnContext)
 
-	plainConn, err := proxy.getUpstreamConn(ctx, req)
+	addr := proxy.rewriteUpstream(connCtx, req.Host)
+	start := time.Now()
+	plainConn, err := proxy.getUpstreamConn(ctx, req, addr)
 	if err != nil {
 		return nil, err
 	}
+	connect := time.Since(start)
+	connCtx.setTimings(func(t *ConnTimings) { t.UpstreamConnect = connect })
 
-	serverConn := newServerConn()
-	serverConn.Address = req.Host
-	serverConn.Conn = &wrapServerConn{
-		Conn:    plainConn,
-		proxy:   proxy,
-		connCtx: connCtx,
-	}
+	serverConn := newServerConn(proxy.newId())
+	serverConn.Address = addr
+	serverConn.rewritten = addr != req.Host || connCtx.connectRewritten
+	serverConn.Conn = newWrapServerConn(plainConn, proxy, connCtx)
 	connCtx.ServerConn = serverConn
-	for _, addon := range connCtx.proxy.Addons {
+	atomic.AddInt64(&proxy.counters.activeServerConns, 1)
+	for _, addon := range connCtx.proxy.hooks.serverConnected {
 		addon.ServerConnected(connCtx)
 	}
 
 	return serverConn.Conn, nil
 }
 
-func (a *attacker) httpsTlsDial(ctx context.Context, cconn net.Conn, conn net.Conn) {
+func (a *attacker) httpsTlsDial(ctx context.Context, cconn net.Conn, conn net.Conn, req *http.Request) {
 	connCtx := cconn.(*wrapClientConn).connCtx
 	log := log.WithFields(log.Fields{
 		"in":   "Proxy.attacker.httpsTlsDial",
@@ -298,9 +591,11 @@ func (a *attacker) httpsTlsDial(ctx context.Context, cconn net.Conn, conn net.Co
 	errChan2 := make(chan error, 1)
 	clientHandshakeDoneChan := make(chan struct{})
 
-	clientTlsConn := tls.Server(cconn, &tls.Config{
+	helloConn := newClientHelloConn(cconn)
+	clientTlsConn := tls.Server(helloConn, &tls.Config{
 		SessionTicketsDisabled: true, // 设置此值为 true ，确保每次都会调用下面的 GetConfigForClient 方法
 		GetConfigForClient: func(chi *tls.ClientHelloInfo) (*tls.Config, error) {
+			recordClientHello(connCtx, helloConn)
 			clientHelloChan <- chi
 			nextProtos := make([]string, 0)
 
@@ -313,8 +608,9 @@ func (a *attacker) httpsTlsDial(ctx context.Context, cconn net.Conn, conn net.Co
 					nextProtos = append([]string{serverTlsState.NegotiatedProtocol}, nextProtos...)
 				}
 			}
+			nextProtos = a.clientALPN(connCtx, nextProtos)
 
-			c, err := a.ca.GetCert(chi.ServerName)
+			c, err := a.getCert(connCtx, certName(connCtx, chi.ServerName))
 			if err != nil {
 				return nil, err
 			}
@@ -322,10 +618,12 @@ func (a *attacker) httpsTlsDial(ctx context.Context, cconn net.Conn, conn net.Co
 				SessionTicketsDisabled: true,
 				Certificates:           []tls.Certificate{*c},
 				NextProtos:             nextProtos,
+				KeyLogWriter:           a.proxy.Opts.KeyLogWriter,
 			}, nil
 
 		},
 	})
+	start := time.Now()
 	go func() {
 		if err := clientTlsConn.HandshakeContext(ctx); err != nil {
 			errChan1 <- err
@@ -337,19 +635,33 @@ func (a *attacker) httpsTlsDial(ctx context.Context, cconn net.Conn, conn net.Co
 	// get clientHello from client
 	select {
 	case err := <-errChan1:
+		connCtx.setCloseReason(CloseReasonClientTlsError)
 		cconn.Close()
 		conn.Close()
 		log.Error(err)
+		a.clientTlsHandshakeError(connCtx, helloConn, err)
 		return
 	case clientHello = <-clientHelloChan:
 	}
 	connCtx.ClientConn.clientHello = clientHello
 
-	if err := a.serverTlsHandshake(ctx, connCtx); err != nil {
-		cconn.Close()
-		conn.Close()
-		errChan2 <- err
+	if err := a.serverTlsHandshakeRetry(ctx, connCtx, req); err != nil {
 		log.Error(err)
+		connCtx.setCloseReason(CloseReasonUpstreamError)
+		defer conn.Close()
+		defer cconn.Close()
+		cconn.SetDeadline(time.Now().Add(5 * time.Second))
+		// the certificate of server is not trusted, tell the client why by http, otherwise it gets a tls alert
+		if UpstreamCertErrorReason(err) != "" {
+			serverTlsStateChan <- &tls.ConnectionState{}
+		} else {
+			errChan2 <- err
+		}
+		select {
+		case <-errChan1:
+		case <-clientHandshakeDoneChan:
+			replyUpstreamTlsError(clientTlsConn, connCtx, err)
+		}
 		return
 	}
 	serverTlsStateChan <- connCtx.ServerConn.tlsState
@@ -357,17 +669,89 @@ func (a *attacker) httpsTlsDial(ctx context.Context, cconn net.Conn, conn net.Co
 	// wait client handshake finish
 	select {
 	case err := <-errChan1:
+		connCtx.setCloseReason(CloseReasonClientTlsError)
 		cconn.Close()
 		conn.Close()
 		log.Error(err)
+		a.clientTlsHandshakeError(connCtx, helloConn, err)
 		return
 	case <-clientHandshakeDoneChan:
 	}
+	a.proxy.clearHandshakeDeadline(cconn)
+	// client handshake waits for the server handshake, exclude it
+	handshake := time.Since(start)
+	connCtx.setTimings(func(t *ConnTimings) { t.ClientHandshake = handshake - t.UpstreamHandshake })
+	connCtx.markHeaderStart()
 
 	// will go to attacker.ServeHTTP
 	a.serveConn(clientTlsConn, connCtx)
 }
 
+// pass the details of the failed tls handshake with client to ClientTlsHandshakeError of addons
+func (a *attacker) clientTlsHandshakeError(connCtx *ConnContext, helloConn *clientHelloConn, err error) {
+	hooks := a.proxy.hooks.clientTlsHandshakeError
+	if len(hooks) == 0 {
+		return
+	}
+	// the handshake can fail before GetConfigForClient, such as the ClientHello is not complete
+	if connCtx.ClientConn.TlsClientHello == nil {
+		recordClientHello(connCtx, helloConn)
+	}
+	info := newClientTlsError(connCtx, err)
+	for _, addon := range hooks {
+		addon.ClientTlsHandshakeError(connCtx, info)
+	}
+}
+
+// the request sent to server, built by Options.BuildUpstreamRequest or from f.Request.
+// body is the request body of the flow, streamLength is its Content-Length when it is streamed as is, 0 if unknown
+func (a *attacker) buildUpstreamRequest(f *Flow, ctx context.Context, body io.Reader, streamLength int64) (*http.Request, error) {
+	if build := a.proxy.Opts.BuildUpstreamRequest; build != nil {
+		proxyReq, err := build(f)
+		if err != nil {
+			return nil, err
+		}
+		if proxyReq != nil {
+			proxyReq = proxyReq.WithContext(ctx)
+			if proxyReq.Body == nil && body != nil {
+				proxyReq.Body = io.NopCloser(body)
+				proxyReq.ContentLength = streamLength
+				if r, ok := body.(*bytes.Reader); ok {
+					if r.Len() == 0 {
+						proxyReq.Body = http.NoBody
+					}
+					proxyReq.ContentLength = int64(r.Len())
+				}
+			}
+			return proxyReq, nil
+		}
+	}
+
+	proxyReq, err := http.NewRequestWithContext(ctx, f.Request.Method, f.Request.URL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if streamLength > 0 {
+		proxyReq.ContentLength = streamLength
+	}
+	// the headers after Request are sent as is, except the hop-by-hop ones of the client connection
+	for key, value := range f.Request.Header {
+		for _, v := range value {
+			proxyReq.Header.Add(key, v)
+		}
+	}
+	removeHopHeaders(proxyReq.Header)
+	return proxyReq, nil
+}
+
+// ALPN offered to client by Options.ClientALPN
+func (a *attacker) clientALPN(connCtx *ConnContext, offered []string) []string {
+	if a.proxy.Opts.ClientALPN == nil {
+		return offered
+	}
+	return a.proxy.Opts.ClientALPN(connCtx, offered)
+}
+
 func (a *attacker) httpsLazyAttack(ctx context.Context, cconn net.Conn, req *http.Request) {
 	connCtx := cconn.(*wrapClientConn).connCtx
 	log := log.WithFields(log.Fields{
@@ -375,26 +759,41 @@ func (a *attacker) httpsLazyAttack(ctx context.Context, cconn net.Conn, req *htt
 		"host": connCtx.ClientConn.Conn.RemoteAddr().String(),
 	})
 
-	clientTlsConn := tls.Server(cconn, &tls.Config{
+	nextProtos := []string{"http/1.1"}
+	if a.proxy.Opts.EnableHTTP2 {
+		// server is not connected yet, negotiate h2 with client anyway, fallback when server does not support h2
+		nextProtos = []string{"h2", "http/1.1"}
+	}
+	helloConn := newClientHelloConn(cconn)
+	clientTlsConn := tls.Server(helloConn, &tls.Config{
 		SessionTicketsDisabled: true, // 设置此值为 true ，确保每次都会调用下面的 GetConfigForClient 方法
 		GetConfigForClient: func(chi *tls.ClientHelloInfo) (*tls.Config, error) {
+			recordClientHello(connCtx, helloConn)
 			connCtx.ClientConn.clientHello = chi
-			c, err := a.ca.GetCert(chi.ServerName)
+			c, err := a.getCert(connCtx, certName(connCtx, chi.ServerName))
 			if err != nil {
 				return nil, err
 			}
 			return &tls.Config{
 				SessionTicketsDisabled: true,
 				Certificates:           []tls.Certificate{*c},
-				NextProtos:             []string{"http/1.1"}, // only support http/1.1
+				NextProtos:             a.clientALPN(connCtx, nextProtos),
+				KeyLogWriter:           a.proxy.Opts.KeyLogWriter,
 			}, nil
 		},
 	})
+	start := time.Now()
 	if err := clientTlsConn.HandshakeContext(ctx); err != nil {
+		connCtx.setCloseReason(CloseReasonClientTlsError)
 		cconn.Close()
 		log.Error(err)
+		a.clientTlsHandshakeError(connCtx, helloConn, err)
 		return
 	}
+	handshake := time.Since(start)
+	connCtx.setTimings(func(t *ConnTimings) { t.ClientHandshake = handshake })
+	a.proxy.clearHandshakeDeadline(cconn)
+	connCtx.markHeaderStart()
 
 	// will go to attacker.ServeHTTP
 	a.initHttpsDialFn(req)
@@ -410,138 +809,334 @@ func (a *attacker) attack(res http.ResponseWriter, req *http.Request) {
 		"method": req.Method,
 	})
 
+	f := proxy.newFlow()
+	f.setRequest(newRequest(req))
+	f.ConnContext = req.Context().Value(connContextKey).(*ConnContext)
+	f.ConnContext.addFlow(f)
+	if hello := f.ConnContext.ClientConn.TlsClientHello; hello != nil {
+		f.ClientTLS = hello.clone()
+	}
+	defer proxy.finishFlow(f)
+
 	reply := func(response *Response, body io.Reader) {
+		if response.Delay > 0 {
+			timer := time.NewTimer(response.Delay)
+			select {
+			case <-timer.C:
+			case <-req.Context().Done():
+				timer.Stop()
+				f.Error = req.Context().Err()
+				log.Debugf("client gone while delaying response: %v", f.Error)
+				return
+			}
+		}
+		// the headers after Response are sent as is, except the hop-by-hop ones of the upstream connection
 		if response.Header != nil {
 			for key, value := range response.Header {
 				for _, v := range value {
 					res.Header().Add(key, v)
 				}
 			}
+			removeHopHeaders(res.Header())
 		}
-		if response.close {
+		// the body may be changed by addons
+		if body == nil && response.BodyReader == nil && len(response.Trailer) == 0 && hasContentLength(f.Request.Method, response.StatusCode) {
+			n := strconv.Itoa(len(response.Body))
+			if cl := res.Header().Get("Content-Length"); proxy.Opts.AutoFixContentLength && cl != "" && cl != n {
+				log.Infof("fix Content-Length %v of the response body of %v bytes", cl, n)
+			}
+			res.Header().Set("Content-Length", n)
+		} else if response.BodyReader != nil {
+			a.dropContentLength(f, res.Header(), "Response.BodyReader")
+		}
+		if response.close || atomic.LoadInt32(&f.ConnContext.draining) == 1 {
 			res.Header().Add("Connection", "close")
 		}
+		// declare trailers, or http/1 response with small body will be sent with Content-Length and without them
+		for key := range response.Trailer {
+			res.Header().Add("Trailer", key)
+		}
 		res.WriteHeader(response.StatusCode)
+		// StatusCode may be changed by addons to one without body, such as 304, the body is dropped
+		if !hasContentLength(f.Request.Method, response.StatusCode) {
+			return
+		}
 
 		if body != nil {
 			_, err := io.Copy(res, body)
 			if err != nil {
 				logErr(log, err)
+				f.Error = err
 			}
 		}
 		if response.BodyReader != nil {
 			_, err := io.Copy(res, response.BodyReader)
 			if err != nil {
 				logErr(log, err)
+				f.Error = err
 			}
 		}
-		if response.Body != nil && len(response.Body) > 0 {
+		// the body is streamed, Response.Body is not the whole body when Flow.BodyTruncated
+		if body == nil && len(response.Body) > 0 {
 			_, err := res.Write(response.Body)
 			if err != nil {
 				logErr(log, err)
+				f.Error = err
 			}
 		}
+		writeTrailer(res, response.Trailer)
 	}
 
-	// when addons panic
+	// the response is set by addons before the request is sent, upstream is not connected for it
+	// other addons still observe it in Response
+	replyLocal := func() {
+		for _, addon := range proxy.hooks.response {
+			f.timeAddon(func() { addon.Response(f) })
+		}
+		if a.replyAddonFailed(res, f) {
+			return
+		}
+		reply(f.Response, nil)
+	}
+
+	// when addons panic, the flow is blocked with 502 by AddonErrorFailClosed as for Flow.AddonError
 	defer func() {
 		if err := recover(); err != nil {
 			log.Warnf("Recovered: %v\n", err)
+			f.AddonError = errors.Join(f.AddonError, fmt.Errorf("addon panic: %v", err))
+			if !a.replyAddonFailed(res, f) {
+				f.Error = f.AddonError
+			}
 		}
 	}()
 
-	f := newFlow()
-	f.Request = newRequest(req)
-	f.ConnContext = req.Context().Value(connContextKey).(*ConnContext)
-	defer f.finish()
-
-	f.ConnContext.FlowCount = f.ConnContext.FlowCount + 1
+	f.Request.ConnRequestCount = atomic.AddUint32(&f.ConnContext.FlowCount, 1) // streams of h2
+	f.Request.ConnReused = f.Request.ConnRequestCount > 1
+	proxy.recordHeaderDuration(f, req)
 
 	rawReqUrlHost := f.Request.URL.Host
 	rawReqUrlScheme := f.Request.URL.Scheme
 
+	f.OriginalRequest = f.Request.snapshotWith(proxy.originalHeader(f.Request.Header))
+	proxy.setRouteLabel(f)
+	// the fast path without addons, the bodies are streamed
+	if proxy.passthrough.Load() {
+		f.Stream = true
+	}
+
 	// trigger addon event Requestheaders
-	for _, addon := range proxy.Addons {
-		addon.Requestheaders(f)
+	for _, addon := range proxy.hooks.requestheaders {
+		f.timeAddon(func() { addon.Requestheaders(f) })
+		if a.replyAddonFailed(res, f) {
+			return
+		}
 		if f.Response != nil {
-			reply(f.Response, nil)
+			if a.dryRunReply(f) {
+				f.Response = nil
+				continue
+			}
+			replyLocal()
+			return
+		}
+	}
+
+	// CORS preflight answered locally, Options.HandlePreflight
+	if f.Response = proxy.preflightResponse(f.Request); f.Response != nil {
+		if a.dryRunReply(f) {
+			f.Response = nil
+		} else {
+			replyLocal()
 			return
 		}
 	}
 
 	// Read request body
 	var reqBody io.Reader = req.Body
+	limit, truncate := proxy.bodyLimit(f)
+	reqTruncated := false
+	// the declared length reaches the limit, such as a large upload by PUT, stream at once instead of buffering the limit first
+	if !f.Stream && !truncate && req.ContentLength >= limit {
+		log.Infof("request body size %v >= %v, stream\n", req.ContentLength, limit)
+		f.Stream = true
+	}
 	if !f.Stream {
-		reqBuf, r, err := helper.ReaderToBuffer(req.Body, proxy.Opts.StreamLargeBodies)
+		reqBuf, r, truncated, err := helper.ReaderToBufferPrefix(req.Body, limit)
 		reqBody = r
 		if err != nil {
 			log.Error(err)
+			f.Error = err
 			res.WriteHeader(502)
 			return
 		}
 
-		if reqBuf == nil {
-			log.Warnf("request body size >= %v\n", proxy.Opts.StreamLargeBodies)
+		if truncated && !truncate {
+			log.Warnf("request body size >= %v\n", limit)
 			f.Stream = true
 		} else {
+			if truncated {
+				log.Warnf("request body size >= %v, truncated for addons\n", limit)
+				f.BodyTruncated = true
+				reqTruncated = true
+			} else {
+				// addons may read Raw().Body for inspection, the request is always forwarded from f.Request.Body
+				req.Body = io.NopCloser(bytes.NewReader(reqBuf))
+			}
 			f.Request.Body = reqBuf
+			f.OriginalRequest.Body = bytes.Clone(reqBuf)
+			proxy.addBuffered(f, 2*len(reqBuf))
+			a.checkLargeBody(f, false, len(reqBuf))
 
 			// trigger addon event Request
-			for _, addon := range proxy.Addons {
-				addon.Request(f)
+			for _, addon := range proxy.hooks.request {
+				f.timeAddon(func() { addon.Request(f) })
+				if a.replyAddonFailed(res, f) {
+					return
+				}
 				if f.Response != nil {
-					reply(f.Response, nil)
+					if a.dryRunReply(f) {
+						f.Response = nil
+						continue
+					}
+					replyLocal()
 					return
 				}
 			}
-			reqBody = bytes.NewReader(f.Request.Body)
+			if proxy.Opts.DryRun {
+				a.dryRunRequest(f)
+			}
+			// the truncated body is forwarded as received
+			if !reqTruncated {
+				reqBody = bytes.NewReader(f.Request.Body)
+			}
 		}
 	}
+	if proxy.Opts.DryRun && f.Stream {
+		a.dryRunRequest(f)
+	}
 
-	for _, addon := range proxy.Addons {
-		reqBody = addon.StreamRequestModifier(f, reqBody)
+	if proxy.Opts.NoUpstream {
+		f.Response = proxy.defaultResponse()
+		replyLocal()
+		return
 	}
 
-	proxyReqCtx := context.WithValue(req.Context(), proxyReqCtxKey, req)
-	proxyReq, err := http.NewRequestWithContext(proxyReqCtx, f.Request.Method, f.Request.URL.String(), reqBody)
+	reqBodyReplaced := false
+	for _, addon := range proxy.hooks.streamRequestModifier {
+		if proxy.Opts.DryRun {
+			break
+		}
+		f.timeAddon(func() {
+			in := reqBody
+			reqBody = addon.StreamRequestModifier(f, in)
+			reqBodyReplaced = reqBodyReplaced || !sameReader(in, reqBody)
+		})
+	}
+	if chunks := proxy.requestBodyChunks(f, reqBody); !sameReader(reqBody, chunks) {
+		reqBody = chunks
+		reqBodyReplaced = true
+	}
+
+	proxyReqCtx, cancelProxyReq := context.WithCancelCause(context.WithValue(req.Context(), proxyReqCtxKey, req))
+	defer cancelProxyReq(nil)
+	// stream 模式下请求体直接转发，未被 StreamRequestModifier 替换时保留 Content-Length，否则使用 chunked 编码
+	// proxyReqCtx 在客户端断开时取消，上游请求随之中止
+	var streamLength int64
+	if (f.Stream || reqTruncated) && !reqBodyReplaced && req.ContentLength > 0 {
+		streamLength = req.ContentLength
+	}
+	proxyReq, err := a.buildUpstreamRequest(f, proxyReqCtx, reqBody, streamLength)
 	if err != nil {
 		log.Error(err)
+		f.Error = err
 		res.WriteHeader(502)
 		return
 	}
 
-	for key, value := range f.Request.Header {
-		for _, v := range value {
-			proxyReq.Header.Add(key, v)
-		}
-	}
-
 	useSeparateClient := f.UseSeparateClient
 	if !useSeparateClient {
-		if rawReqUrlHost != f.Request.URL.Host || rawReqUrlScheme != f.Request.URL.Scheme {
+		if rawReqUrlHost != proxyReq.URL.Host || rawReqUrlScheme != proxyReq.URL.Scheme {
 			useSeparateClient = true
 		}
 	}
 
+	if proxy.limiter != nil {
+		release, err := proxy.limiter.acquire(req.Context(), f.Request.URL.Host)
+		if err != nil {
+			log.Warnf("upstream queue: %v", err)
+			f.Error = err
+			res.WriteHeader(proxy.Opts.QueueRejectStatus)
+			return
+		}
+		defer release()
+	}
+
 	var proxyRes *http.Response
+	var sendAt time.Time
+	var headerTimer *time.Timer
 	if useSeparateClient {
+		f.upstreamURL = a.upstreamURL(f, helper.CanonicalAddr(f.Request.URL))
+		sendAt = time.Now()
+		headerTimer = proxy.startReadTimeout(cancelProxyReq, helper.CanonicalAddr(f.Request.URL))
 		proxyRes, err = a.client.Do(proxyReq)
+	} else if req.Context().Value(pooledReqKey) != nil {
+		addr := helper.CanonicalAddr(f.Request.URL)
+		f.upstreamURL = a.upstreamURL(f, addr)
+		sendAt = time.Now()
+		headerTimer = proxy.startReadTimeout(cancelProxyReq, addr)
+		proxyRes, err = a.roundTripPooled(f, proxyReq)
 	} else {
-		if f.ConnContext.ServerConn == nil && f.ConnContext.dialFn != nil {
-			if err := f.ConnContext.dialFn(req.Context()); err != nil {
+		if f.ConnContext.dialFn != nil {
+			if err := f.ConnContext.dialServer(req.Context()); err != nil {
 				log.Error(err)
+				f.Error = err
 				res.WriteHeader(502)
+				if UpstreamCertErrorReason(err) != "" {
+					io.WriteString(res, upstreamTlsErrorText(f.ConnContext, err))
+				}
 				return
 			}
 		}
+		f.upstreamURL = a.upstreamURL(f, f.ConnContext.ServerConn.Address)
+		sendAt = time.Now()
+		headerTimer = proxy.startReadTimeout(cancelProxyReq, f.ConnContext.ServerConn.Address)
 		proxyRes, err = f.ConnContext.ServerConn.client.Do(proxyReq)
 	}
+	if headerTimer != nil {
+		headerTimer.Stop()
+	}
 	if err != nil {
+		if cause := context.Cause(proxyReqCtx); errors.Is(cause, errReadTimeout) {
+			err = cause
+		} else if !useSeparateClient && f.ConnContext.ServerConn != nil {
+			err = f.ConnContext.ServerConn.clientCertErr(err)
+		}
+		err = responseHeadersErr(err)
+		f.Error = err
+		if errors.Is(err, ErrUpstreamHeadersTooLarge) {
+			log.Warn(err)
+			res.WriteHeader(502)
+			io.WriteString(res, ErrUpstreamHeadersTooLarge.Error())
+			return
+		}
+		if isUpstreamClosedErr(err) {
+			log.Warnf("%v: %v", errUpstreamClosed, err)
+			res.WriteHeader(502)
+			io.WriteString(res, errUpstreamClosed.Error())
+			return
+		}
 		logErr(log, err)
 		res.WriteHeader(502)
 		return
 	}
 
+	f.Timings.FirstByte = time.Since(sendAt)
+	if !useSeparateClient {
+		f.ConnContext.firstByteOnce.Do(func() {
+			f.ConnContext.setTimings(func(t *ConnTimings) { t.FirstByte = f.Timings.FirstByte })
+		})
+		f.ConnContext.takeTimings(f)
+	}
+
 	if proxyRes.Close {
 		f.ConnContext.closeAfterResponse = true
 	}
@@ -552,42 +1147,249 @@ func (a *attacker) attack(res http.ResponseWriter, req *http.Request) {
 		StatusCode: proxyRes.StatusCode,
 		Header:     proxyRes.Header,
 		close:      proxyRes.Close,
+
+		maxDecodedSize: proxy.Opts.MaxDecompressedSize,
+	}
+	if !useSeparateClient && f.ConnContext.ServerConn.statusLine != nil {
+		f.Response.RawStatusLine = f.ConnContext.ServerConn.statusLine.take()
 	}
+	f.OriginalResponse = f.Response.snapshotWith(proxy.originalHeader(f.Response.Header))
+	proxy.injectCORS(f)
 
 	// trigger addon event Responseheaders
-	for _, addon := range proxy.Addons {
-		addon.Responseheaders(f)
+	for _, addon := range proxy.hooks.responseheaders {
+		f.timeAddon(func() { addon.Responseheaders(f) })
+		if a.replyAddonFailed(res, f) {
+			return
+		}
 		if f.Response.Body != nil {
+			if a.dryRunReply(f) {
+				f.Response.Body = nil
+				continue
+			}
 			reply(f.Response, nil)
 			return
 		}
 	}
 
+	if proxyRes.StatusCode == http.StatusSwitchingProtocols && isWebSocketUpgrade(f.Request.Header) {
+		for _, addon := range proxy.hooks.response {
+			f.timeAddon(func() { addon.Response(f) })
+		}
+		if a.replyAddonFailed(res, f) {
+			return
+		}
+		a.webSocket(res, f, proxyRes)
+		return
+	}
+
+	if stub := proxy.matchBodyStub(f); stub != nil {
+		if proxy.Opts.DryRun {
+			dryRunLog(f).Infof("dry run: would stub response body with %v bytes", len(stub.Body))
+		} else {
+			log.Debugf("stub response body of %v", f.Request.URL)
+			stub.apply(f.Response)
+			reply(f.Response, nil)
+			return
+		}
+	}
+
+	// no addon needs the body, only the headers are passed to Response
+	if !f.Stream && proxy.streamResponse(f) {
+		f.Stream = true
+		for _, addon := range proxy.hooks.response {
+			f.timeAddon(func() { addon.Response(f) })
+		}
+		if a.replyAddonFailed(res, f) {
+			return
+		}
+	}
+
 	// Read response body
 	var resBody io.Reader = proxyRes.Body
+	limit, truncate = proxy.bodyLimit(f)
+	if !f.Stream && !truncate && proxyRes.ContentLength >= limit {
+		log.Infof("response body size %v >= %v, stream\n", proxyRes.ContentLength, limit)
+		f.Stream = true
+	}
 	if !f.Stream {
-		resBuf, r, err := helper.ReaderToBuffer(proxyRes.Body, proxy.Opts.StreamLargeBodies)
+		resBuf, r, truncated, err := helper.ReaderToBufferPrefix(proxyRes.Body, limit)
 		resBody = r
 		if err != nil {
 			log.Error(err)
+			f.Error = err
 			res.WriteHeader(502)
 			return
 		}
-		if resBuf == nil {
-			log.Warnf("response body size >= %v\n", proxy.Opts.StreamLargeBodies)
+		if truncated && !truncate {
+			log.Warnf("response body size >= %v\n", limit)
 			f.Stream = true
 		} else {
+			if truncated {
+				log.Warnf("response body size >= %v, truncated for addons\n", limit)
+				f.BodyTruncated = true
+			}
 			f.Response.Body = resBuf
+			f.Response.RawBody = resBuf
+			f.Response.Trailer = proxyRes.Trailer
+			f.OriginalResponse.Body = bytes.Clone(resBuf)
+			proxy.addBuffered(f, 2*len(resBuf))
+			f.OriginalResponse.Trailer = proxyRes.Trailer.Clone()
+			a.checkLargeBody(f, true, len(resBuf))
+			// the truncated body can not be decoded
+			preserveEncoding := proxy.Opts.PreserveEncoding && !truncated
+			if preserveEncoding {
+				f.Response.decodeForAddons()
+			}
 
 			// trigger addon event Response
-			for _, addon := range proxy.Addons {
-				addon.Response(f)
+			for _, addon := range proxy.hooks.response {
+				f.timeAddon(func() { addon.Response(f) })
+			}
+			if a.replyAddonFailed(res, f) {
+				return
+			}
+			if preserveEncoding {
+				f.Response.encodePreserved()
 			}
 		}
 	}
-	for _, addon := range proxy.Addons {
-		resBody = addon.StreamResponseModifier(f, resBody)
+	if proxy.Opts.DryRun {
+		a.dryRunResponse(f)
+	}
+	resBodyReplaced := false
+	for _, addon := range proxy.hooks.streamResponseModifier {
+		if proxy.Opts.DryRun {
+			break
+		}
+		f.timeAddon(func() {
+			in := resBody
+			resBody = addon.StreamResponseModifier(f, in)
+			resBodyReplaced = resBodyReplaced || !sameReader(in, resBody)
+		})
 	}
+	if resBodyReplaced {
+		a.dropContentLength(f, f.Response.Header, "StreamResponseModifier")
+	}
+	resBody = proxy.responseBodyChunks(f, resBody)
 
+	if f.Stream {
+		// only the keys declared by server are known before the body is read
+		f.Response.Trailer = proxyRes.Trailer
+	}
 	reply(f.Response, resBody)
+	if f.Stream {
+		f.Response.Trailer = proxyRes.Trailer
+		writeTrailer(res, f.Response.Trailer)
+	}
+}
+
+// whether a hook returns the body reader it is given, the readers of a type not comparable at run time, such as a struct
+// holding a slice, are taken as replaced instead of panicking on ==
+func sameReader(in, out io.Reader) bool {
+	if in == nil || out == nil {
+		return in == out
+	}
+	if reflect.TypeOf(in) != reflect.TypeOf(out) || !reflect.ValueOf(in).Comparable() {
+		return false
+	}
+	return in == out
+}
+
+// the response body of unknown length is set by addons, send it without the Content-Length of server by
+// Options.AutoFixContentLength, chunked for http/1.1
+func (a *attacker) dropContentLength(f *Flow, header http.Header, by string) {
+	if !a.proxy.Opts.AutoFixContentLength || header.Get("Content-Length") == "" || !hasContentLength(f.Request.Method, f.Response.StatusCode) {
+		return
+	}
+	log.WithField("url", f.Request.URL).Infof("remove Content-Length %v of the response body set by %v", header.Get("Content-Length"), by)
+	header.Del("Content-Length")
+}
+
+// copy of the header kept in Flow.OriginalRequest and Flow.OriginalResponse, limited by OriginalHeadersAdvertiser
+func (proxy *Proxy) originalHeader(header http.Header) http.Header {
+	hooks := &proxy.hooks
+	// dry run restores the flow from the original one
+	if hooks.addons == 0 || hooks.allOriginalHeaders || proxy.Opts.DryRun {
+		return header.Clone()
+	}
+	if len(hooks.originalHeaders) == 0 {
+		return nil
+	}
+	c := make(http.Header, len(hooks.originalHeaders))
+	for _, key := range hooks.originalHeaders {
+		if values, ok := header[key]; ok {
+			c[key] = slices.Clone(values)
+		}
+	}
+	return c
+}
+
+// whether to forward the response body of f without buffering, see Options.StreamResponses and Options.StreamPartialContent
+func (proxy *Proxy) streamResponse(f *Flow) bool {
+	if proxy.Opts.StreamPartialContent && f.Response.IsPartial() {
+		return true
+	}
+	if !proxy.Opts.StreamResponses {
+		return false
+	}
+	for _, addon := range proxy.hooks.streamResponse {
+		stream := true
+		f.timeAddon(func() { stream = addon.StreamResponse(f) })
+		if !stream {
+			return false
+		}
+	}
+	return true
+}
+
+// the upstream request is cancelled with it when the response headers are not received in time
+var errReadTimeout = errors.New("timeout awaiting response headers")
+
+// cancel the upstream request to addr when response headers are not received in Options.ReadTimeout or Options.HostTimeouts,
+// nil if not limited
+// http2.Transport has no ResponseHeaderTimeout, so it is done by the context of request for all transports
+func (proxy *Proxy) startReadTimeout(cancel context.CancelCauseFunc, addr string) *time.Timer {
+	timeout := proxy.timeouts(addr).Read
+	if timeout <= 0 {
+		return nil
+	}
+	return time.AfterFunc(timeout, func() { cancel(errReadTimeout) })
+}
+
+// url of the request sent to addr, for Flow.EffectiveUpstreamURL
+func (a *attacker) upstreamURL(f *Flow, addr string) *url.URL {
+	u := *f.Request.URL
+	network, dialAddr := a.proxy.dialAddr("tcp", addr)
+	if network == "unix" {
+		u.Host = "unix:" + dialAddr
+	} else if dialAddr != helper.CanonicalAddr(f.Request.URL) {
+		u.Host = dialAddr
+	}
+	return &u
+}
+
+// send trailers after the body
+func writeTrailer(res http.ResponseWriter, trailer http.Header) {
+	for key, values := range trailer {
+		if len(values) > 0 {
+			res.Header()[http.TrailerPrefix+key] = values
+		}
+	}
+}
+
+// warn when the buffered body is larger than Options.LargeBodyThreshold
+func (a *attacker) checkLargeBody(f *Flow, isResponse bool, size int) {
+	threshold := a.proxy.Opts.LargeBodyThreshold
+	if threshold <= 0 || int64(size) < threshold {
+		return
+	}
+	kind := "request"
+	if isResponse {
+		kind = "response"
+	}
+	log.Warnf("buffered large %v body: %v %v - %v bytes\n", kind, f.Request.Method, f.Request.URL.String(), size)
+	for _, addon := range a.proxy.hooks.largeBody {
+		f.timeAddon(func() { addon.LargeBody(f, isResponse, size) })
+	}
 }
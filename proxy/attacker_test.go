@@ -0,0 +1,1662 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lqqyt2423/go-mitmproxy/cert"
+)
+
+type countRoundTripper struct {
+	http.RoundTripper
+	count *int32
+}
+
+func (rt *countRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(rt.count, 1)
+	return rt.RoundTripper.RoundTrip(req)
+}
+
+func TestUpstreamRoundTripper(t *testing.T) {
+	var count int32
+	helper := &testPipeHelper{
+		opts: &Options{
+			UpstreamRoundTripper: func(connCtx *ConnContext) http.RoundTripper {
+				return &countRoundTripper{
+					RoundTripper: &http.Transport{
+						DialContext:    connCtx.ServerConn.DialContext,
+						DialTLSContext: connCtx.ServerConn.DialContext,
+					},
+					count: &count,
+				}
+			},
+		},
+	}
+	helper.init(t)
+	defer helper.close()
+
+	proxyClient := helper.getProxyClient()
+	testSendRequest(t, "http://example.com/", proxyClient, "ok")
+	testSendRequest(t, "https://example.com/", proxyClient, "ok")
+	if n := atomic.LoadInt32(&count); n != 2 {
+		t.Fatalf("expected 2 requests by custom round tripper, but got %v", n)
+	}
+}
+
+func TestPassthrough(t *testing.T) {
+	helper := &testPipeHelper{noAddons: true}
+	helper.init(t)
+	defer helper.close()
+
+	body := strings.Repeat("a", 1<<20)
+	for _, url := range []string{"http://example.com/echo", "https://example.com/echo"} {
+		resp, err := helper.getProxyClient().Post(url, "text/plain", strings.NewReader(body))
+		handleError(t, err)
+		got, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		handleError(t, err)
+		if string(got) != body || resp.Header.Get("X-Content-Length") != strconv.Itoa(len(body)) {
+			t.Fatalf("%v: expected body forwarded with Content-Length, but got %v bytes %v", url, len(got), resp.Header.Get("X-Content-Length"))
+		}
+	}
+	// decided by Start, before the first connection is accepted
+	if !helper.testProxy.passthrough.Load() {
+		t.Fatal("expected passthrough without addons")
+	}
+
+	// the flows are buffered for the addon added later
+	addon := &testFlowTimeAddon{flows: make(chan *Flow, 1)}
+	helper.testProxy.AddAddon(addon)
+	testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+	if f := <-addon.flows; f.Stream || string(f.Response.Body) != "ok" {
+		t.Fatalf("expected buffered flow, but got stream %v body %q", f.Stream, f.Response.Body)
+	}
+}
+
+func BenchmarkPassthrough(b *testing.B) {
+	body := strings.Repeat("a", 256<<10)
+	for _, noAddons := range []bool{true, false} {
+		b.Run(fmt.Sprintf("noAddons=%v", noAddons), func(b *testing.B) {
+			helper := &testPipeHelper{noAddons: noAddons}
+			helper.init(b)
+			defer helper.close()
+
+			proxyClient := helper.getProxyClient()
+			b.SetBytes(int64(len(body)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				resp, err := proxyClient.Post("http://example.com/echo", "text/plain", strings.NewReader(body))
+				handleError(b, err)
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+		})
+	}
+}
+
+func TestMissingHost(t *testing.T) {
+	t.Run("reject by default", func(t *testing.T) {
+		helper := &testPipeHelper{}
+		helper.init(t)
+		defer helper.close()
+		resp := helper.sendRawTlsRequest(t, "GET / HTTP/1.0\r\n\r\n")
+		if resp.StatusCode != 400 {
+			t.Fatalf("expected 400, but got %v", resp.StatusCode)
+		}
+	})
+
+	t.Run("derive from CONNECT", func(t *testing.T) {
+		helper := &testPipeHelper{opts: &Options{DeriveMissingHost: true}}
+		helper.init(t)
+		defer helper.close()
+		resp := helper.sendRawTlsRequest(t, "GET / HTTP/1.0\r\n\r\n")
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != 200 || string(body) != "ok" {
+			t.Fatalf("expected 200 ok, but got %v %s", resp.StatusCode, body)
+		}
+	})
+}
+
+type testHeaderMutationAddon struct {
+	BaseAddon
+}
+
+func (addon *testHeaderMutationAddon) Request(f *Flow) {
+	f.Request.Header.Set("X-Added", "added")
+}
+
+func (addon *testHeaderMutationAddon) Response(f *Flow) {
+	f.Response.Header.Del("X-Secret")
+	f.Response.Body = append(f.Response.Body, "!"...)
+}
+
+func TestHeaderMutation(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.AddAddon(&testHeaderMutationAddon{})
+	proxyClient := helper.getProxyClient()
+
+	get := func(t *testing.T, endpoint string, header http.Header) *http.Response {
+		req, err := http.NewRequest("GET", endpoint, nil)
+		handleError(t, err)
+		req.Header = header
+		res, err := proxyClient.Do(req)
+		handleError(t, err)
+		return res
+	}
+
+	for _, scheme := range []string{"http", "https"} {
+		t.Run(scheme, func(t *testing.T) {
+			res := get(t, scheme+"://example.com/header?name=X-Added", nil)
+			body, err := io.ReadAll(res.Body)
+			res.Body.Close()
+			handleError(t, err)
+			if string(body) != "added!" {
+				t.Fatalf("expected the added request header reaches the origin, but got %q", body)
+			}
+			if v := res.Header.Get("X-Secret"); v != "" {
+				t.Fatalf("expected the stripped response header not sent, but got %v", v)
+			}
+			if res.ContentLength != int64(len(body)) {
+				t.Fatalf("expected Content-Length %v of the changed body, but got %v", len(body), res.ContentLength)
+			}
+
+			// hop-by-hop headers of the client connection are not forwarded
+			res = get(t, scheme+"://example.com/header?name=Keep-Alive", http.Header{"Keep-Alive": {"timeout=5"}})
+			body, err = io.ReadAll(res.Body)
+			res.Body.Close()
+			handleError(t, err)
+			if string(body) != "!" {
+				t.Fatalf("expected Keep-Alive not forwarded, but got %q", body)
+			}
+		})
+	}
+}
+
+type testTlsVersionAddon struct {
+	versions chan uint16
+}
+
+func (addon *testTlsVersionAddon) TlsEstablishedServer(connCtx *ConnContext) {
+	addon.versions <- connCtx.ServerConn.TlsState().Version
+}
+
+func TestUpstreamTLSVersion(t *testing.T) {
+	helper := &testPipeHelper{
+		opts: &Options{
+			UpstreamTLSMaxVersion: tls.VersionTLS12,
+		},
+	}
+	helper.init(t)
+	defer helper.close()
+	addon := &testTlsVersionAddon{versions: make(chan uint16, 1)}
+	helper.testProxy.AddHooks(addon)
+
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+	if v := <-addon.versions; v != tls.VersionTLS12 {
+		t.Fatalf("expected TLS 1.2, but got %v", tls.VersionName(v))
+	}
+}
+
+type testServerAlpnAddon struct {
+	serverConns chan *ServerConn
+}
+
+func (addon *testServerAlpnAddon) TlsEstablishedServer(connCtx *ConnContext) {
+	addon.serverConns <- connCtx.ServerConn
+}
+
+func TestServerConnAlpn(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testServerAlpnAddon{serverConns: make(chan *ServerConn, 1)}
+	helper.testProxy.AddHooks(addon)
+
+	proxyClient := helper.getProxyClient()
+	proxyClient.Transport.(*http.Transport).ForceAttemptHTTP2 = true
+	testSendRequest(t, "https://example.com/", proxyClient, "ok")
+	serverConn := <-addon.serverConns
+	if !slices.Contains(serverConn.OfferedProtos, "h2") {
+		t.Fatalf("expected h2 offered, but got %v", serverConn.OfferedProtos)
+	}
+	// test https server does not set NextProtos
+	if serverConn.NegotiatedProtocol != "" {
+		t.Fatalf("expected no protocol negotiated, but got %q", serverConn.NegotiatedProtocol)
+	}
+}
+
+func TestUpstreamRootCAs(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.Opts.SslInsecure = false
+
+	t.Run("trust the server CA", func(t *testing.T) {
+		pool := x509.NewCertPool()
+		pool.AddCert(&helper.serverCA.RootCert)
+		helper.testProxy.Opts.UpstreamRootCAs = pool
+		testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+	})
+
+	t.Run("not trust the server CA", func(t *testing.T) {
+		helper.testProxy.Opts.UpstreamRootCAs = x509.NewCertPool()
+		resp, err := helper.getProxyClient().Get("https://example.com/")
+		handleError(t, err)
+		resp.Body.Close()
+		if resp.StatusCode != 502 {
+			t.Fatalf("expected 502, but got %v", resp.Status)
+		}
+	})
+}
+
+type testTlsHandshakeErrorAddon struct {
+	errors chan error
+}
+
+func (addon *testTlsHandshakeErrorAddon) TlsHandshakeError(connCtx *ConnContext, err error) {
+	addon.errors <- err
+}
+
+func TestUpstreamTlsHandshakeError(t *testing.T) {
+	for _, upstreamCert := range []bool{true, false} {
+		t.Run(fmt.Sprintf("upstream cert %v", upstreamCert), func(t *testing.T) {
+			helper := &testPipeHelper{}
+			helper.init(t)
+			defer helper.close()
+			helper.testProxy.Opts.SslInsecure = false
+			helper.testProxy.AddAddon(NewUpstreamCertAddon(upstreamCert))
+			addon := &testTlsHandshakeErrorAddon{errors: make(chan error, 1)}
+			helper.testProxy.AddHooks(addon)
+
+			resp, err := helper.getProxyClient().Get("https://example.com/")
+			handleError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			handleError(t, err)
+			if resp.StatusCode != 502 || !strings.Contains(string(body), "example.com:443 is not trusted (unknown authority)") {
+				t.Fatalf("expected 502 with the reason, but got %v %s", resp.Status, body)
+			}
+			select {
+			case err := <-addon.errors:
+				if reason := UpstreamCertErrorReason(err); reason != "unknown authority" {
+					t.Fatalf("expected unknown authority, but got %q of %v", reason, err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("expected TlsHandshakeError called")
+			}
+		})
+	}
+
+	t.Run("name mismatch", func(t *testing.T) {
+		helper := &testPipeHelper{}
+		helper.init(t)
+		defer helper.close()
+		helper.testProxy.Opts.SslInsecure = false
+		pool := x509.NewCertPool()
+		pool.AddCert(&helper.serverCA.RootCert)
+		helper.testProxy.Opts.UpstreamRootCAs = pool
+		addon := &testTlsHandshakeErrorAddon{errors: make(chan error, 1)}
+		helper.testProxy.AddHooks(addon)
+
+		resp, err := helper.getProxyClient().Get("https://other.com/")
+		handleError(t, err)
+		resp.Body.Close()
+		if resp.StatusCode != 502 {
+			t.Fatalf("expected 502, but got %v", resp.Status)
+		}
+		if reason := UpstreamCertErrorReason(<-addon.errors); reason != "name mismatch" {
+			t.Fatalf("expected name mismatch, but got %q", reason)
+		}
+	})
+}
+
+type testLargeBodyAddon struct {
+	sizes chan int
+}
+
+func (addon *testLargeBodyAddon) LargeBody(f *Flow, isResponse bool, size int) {
+	if isResponse {
+		addon.sizes <- size
+	}
+}
+
+func TestLargeBodyThreshold(t *testing.T) {
+	helper := &testPipeHelper{
+		opts: &Options{LargeBodyThreshold: 100},
+	}
+	helper.init(t)
+	defer helper.close()
+	addon := &testLargeBodyAddon{sizes: make(chan int, 1)}
+	helper.testProxy.AddHooks(addon)
+
+	proxyClient := helper.getProxyClient()
+	testSendRequest(t, "http://example.com/", proxyClient, "ok")
+
+	body := strings.Repeat("a", 200)
+	resp, err := proxyClient.Post("http://example.com/echo", "text/plain", strings.NewReader(body))
+	handleError(t, err)
+	resp.Body.Close()
+	select {
+	case size := <-addon.sizes:
+		if size != 200 {
+			t.Fatalf("expected size 200, but got %v", size)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected LargeBody called")
+	}
+}
+
+type testStreamResponseAddon struct {
+	needBody bool
+	bodies   chan []byte
+}
+
+func (addon *testStreamResponseAddon) StreamResponse(f *Flow) bool {
+	return !addon.needBody
+}
+
+func (addon *testStreamResponseAddon) Response(f *Flow) {
+	if f.Stream != (f.Response.Body == nil) {
+		panic("unexpected Flow.Stream")
+	}
+	addon.bodies <- f.Response.Body
+}
+
+func TestStreamResponses(t *testing.T) {
+	helper := &testPipeHelper{
+		opts: &Options{StreamResponses: true},
+	}
+	helper.init(t)
+	defer helper.close()
+	streamer := &testStreamResponseAddon{bodies: make(chan []byte, 1)}
+	helper.testProxy.AddHooks(streamer)
+
+	proxyClient := helper.getProxyClient()
+
+	t.Run("stream", func(t *testing.T) {
+		testSendRequest(t, "http://example.com/", proxyClient, "ok")
+		select {
+		case b := <-streamer.bodies:
+			if b != nil {
+				t.Fatalf("expected no body in Response, but got %v bytes", len(b))
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected Response called")
+		}
+	})
+
+	t.Run("addon needs body", func(t *testing.T) {
+		helper.testProxy.AddHooks(&testStreamResponseAddon{needBody: true, bodies: make(chan []byte, 1)})
+		testSendRequest(t, "https://example.com/", proxyClient, "ok")
+		select {
+		case b := <-streamer.bodies:
+			if string(b) != "ok" {
+				t.Fatalf("expected buffered body, but got %v bytes", len(b))
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected Response called")
+		}
+	})
+}
+
+type testTruncatedBodyAddon struct {
+	BaseAddon
+	bodies chan string
+}
+
+func (addon *testTruncatedBodyAddon) Request(f *Flow) {
+	addon.bodies <- fmt.Sprintf("%v:%s", f.BodyTruncated, f.Request.Body)
+	f.Request.Body = []byte("changed")
+}
+
+func (addon *testTruncatedBodyAddon) Response(f *Flow) {
+	addon.bodies <- fmt.Sprintf("%v:%s", f.BodyTruncated, f.Response.Body)
+}
+
+func TestMaxBodySize(t *testing.T) {
+	helper := &testPipeHelper{
+		opts: &Options{MaxBodySize: 10},
+	}
+	helper.init(t)
+	defer helper.close()
+	addon := &testTruncatedBodyAddon{bodies: make(chan string, 2)}
+	helper.testProxy.AddAddon(addon)
+
+	proxyClient := helper.getProxyClient()
+	for _, scheme := range []string{"http", "https"} {
+		t.Run(scheme, func(t *testing.T) {
+			body := strings.Repeat("a", 100)
+			resp, err := proxyClient.Post(scheme+"://example.com/echo", "text/plain", strings.NewReader(body))
+			handleError(t, err)
+			defer resp.Body.Close()
+			got, err := io.ReadAll(resp.Body)
+			handleError(t, err)
+			if string(got) != body {
+				t.Fatalf("expected the full body forwarded, but got %v bytes", len(got))
+			}
+			if cl := resp.Header.Get("X-Content-Length"); cl != "100" {
+				t.Fatalf("expected Content-Length of the request kept, but got %q", cl)
+			}
+			for _, kind := range []string{"request", "response"} {
+				if seen := <-addon.bodies; seen != "true:"+body[:10] {
+					t.Fatalf("expected truncated %v body, but got %q", kind, seen)
+				}
+			}
+		})
+	}
+
+	t.Run("small body", func(t *testing.T) {
+		resp, err := proxyClient.Post("http://example.com/echo", "text/plain", strings.NewReader("small"))
+		handleError(t, err)
+		defer resp.Body.Close()
+		got, err := io.ReadAll(resp.Body)
+		handleError(t, err)
+		if string(got) != "changed" {
+			t.Fatalf("expected the body changed by addon, but got %q", got)
+		}
+		<-addon.bodies
+		if seen := <-addon.bodies; seen != "false:changed" {
+			t.Fatalf("expected full response body, but got %q", seen)
+		}
+	})
+}
+
+type testSkipVerifyAddon struct {
+	host string
+}
+
+func (addon *testSkipVerifyAddon) Requestheaders(f *Flow) {
+	if f.Request.Method == "CONNECT" && f.Request.URL.Host == addon.host {
+		f.ConnContext.SkipUpstreamVerify = true
+	}
+}
+
+func TestSkipUpstreamVerify(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.Opts.SslInsecure = false
+	helper.testProxy.AddHooks(&testSkipVerifyAddon{host: "example.com:443"})
+
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+
+	resp, err := helper.getProxyClient().Get("https://another.com/")
+	handleError(t, err)
+	resp.Body.Close()
+	if resp.StatusCode != 502 {
+		t.Fatalf("expected 502, but got %v", resp.Status)
+	}
+}
+
+func TestUpstreamClosedBeforeResponse(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+
+	// server of closed.com closes the connection after reading the request, the request is not failed to write then
+	dial := helper.testProxy.Opts.DialContext
+	helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if strings.HasPrefix(addr, "closed.com:") {
+			c1, c2 := net.Pipe()
+			go func() {
+				http.ReadRequest(bufio.NewReader(c2))
+				c2.Close()
+			}()
+			return c1, nil
+		}
+		return dial(ctx, network, addr)
+	}
+
+	resp, err := helper.getProxyClient().Get("http://closed.com/")
+	handleError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	handleError(t, err)
+	if resp.StatusCode != 502 || string(body) != errUpstreamClosed.Error() {
+		t.Fatalf("expected 502 %v, but got %v %s", errUpstreamClosed, resp.StatusCode, body)
+	}
+}
+
+type testStubAddon struct {
+	BaseAddon
+	responses int32
+}
+
+func (addon *testStubAddon) Request(f *Flow) {
+	if f.Request.URL.Path == "/stub" {
+		f.Response = &Response{StatusCode: 200, Body: []byte("stub")}
+	}
+}
+
+func (addon *testStubAddon) Response(f *Flow) {
+	atomic.AddInt32(&addon.responses, 1)
+}
+
+func TestNoUpstream(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.Opts.NoUpstream = true
+	addon := &testStubAddon{}
+	helper.testProxy.AddAddon(addon)
+
+	var dials int32
+	dial := helper.testProxy.Opts.DialContext
+	helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		atomic.AddInt32(&dials, 1)
+		return dial(ctx, network, addr)
+	}
+
+	check := func(url string, status int, body string) {
+		t.Helper()
+		resp, err := helper.getProxyClient().Get(url)
+		handleError(t, err)
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		handleError(t, err)
+		if resp.StatusCode != status || string(b) != body {
+			t.Fatalf("%v: expected %v %v, but got %v %s", url, status, body, resp.StatusCode, b)
+		}
+	}
+
+	check("http://example.com/stub", 200, "stub")
+	check("https://example.com/stub", 200, "stub")
+	check("http://example.com/", 501, "no upstream")
+	check("https://example.com/", 501, "no upstream")
+
+	helper.testProxy.Opts.DefaultResponse = &Response{StatusCode: 404, Body: []byte("not found")}
+	check("https://example.com/other", 404, "not found")
+
+	if n := atomic.LoadInt32(&dials); n != 0 {
+		t.Fatalf("expected no upstream dial, but got %v", n)
+	}
+	// the stubs and the default responses are observed by Response
+	if n := atomic.LoadInt32(&addon.responses); n != 5 {
+		t.Fatalf("expected 5 responses observed, but got %v", n)
+	}
+}
+
+type testCertIssuedAddon struct {
+	BaseAddon
+	mu     sync.Mutex
+	issued []bool // cached of each cert
+}
+
+func (addon *testCertIssuedAddon) CertIssued(connCtx *ConnContext, serverName string, cached bool) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	if serverName == "example.com" {
+		addon.issued = append(addon.issued, cached)
+	}
+}
+
+func TestCertIssued(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testCertIssuedAddon{}
+	helper.testProxy.AddAddon(addon)
+
+	// each client has its own connection
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	if !slices.Equal(addon.issued, []bool{false, true}) {
+		t.Fatalf("expected minted and then cached, but got %v", addon.issued)
+	}
+}
+
+type testDelayAddon struct {
+	BaseAddon
+	delay time.Duration
+	errs  chan *Flow
+}
+
+func (addon *testDelayAddon) FlowError(f *Flow) {
+	addon.errs <- f
+}
+
+func (addon *testDelayAddon) Response(f *Flow) {
+	if f.Request.URL.Path == "/echo" {
+		f.Response.Delay = addon.delay
+	}
+}
+
+func TestResponseDelay(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testDelayAddon{delay: 200 * time.Millisecond, errs: make(chan *Flow, 10)}
+	helper.testProxy.AddAddon(addon)
+	client := helper.getProxyClient()
+
+	start := time.Now()
+	testSendRequest(t, "https://example.com/", client, "ok")
+	if d := time.Since(start); d >= 200*time.Millisecond {
+		t.Fatalf("expected no delay, got %v", d)
+	}
+
+	start = time.Now()
+	resp, err := client.Post("https://example.com/echo", "text/plain", strings.NewReader("hi"))
+	handleError(t, err)
+	resp.Body.Close()
+	if d := time.Since(start); d < 200*time.Millisecond {
+		t.Fatalf("expected delay, got %v", d)
+	}
+
+	// client gone
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://example.com/echo", strings.NewReader("hi"))
+	handleError(t, err)
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected client timeout")
+	}
+	select {
+	case f := <-addon.errs:
+		if !errors.Is(f.Error, context.Canceled) {
+			t.Fatalf("expected canceled, got %v", f.Error)
+		}
+	case <-time.After(150 * time.Millisecond):
+		t.Fatal("expected the delay aborted")
+	}
+}
+
+// server closes the connection once received data
+type testResetConn struct {
+	net.Conn
+}
+
+func (c *testResetConn) Read(b []byte) (int, error) {
+	return 0, io.EOF
+}
+
+func (c *testResetConn) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func TestUpstreamHandshakeRetries(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+
+	// the first dial to :443 is closed in the tls handshake
+	var dials int32
+	dialContext := helper.testProxy.Opts.DialContext
+	helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dialContext(ctx, network, addr)
+		if err == nil && strings.HasSuffix(addr, ":443") && atomic.AddInt32(&dials, 1) == 1 {
+			conn.Close()
+			return &testResetConn{conn}, nil
+		}
+		return conn, err
+	}
+
+	resp, err := helper.getProxyClient().Get("https://example.com/")
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode == 200 {
+			t.Fatal("expected error without retry")
+		}
+	}
+
+	atomic.StoreInt32(&dials, 0)
+	helper.testProxy.Opts.UpstreamHandshakeRetries = 1
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+	if atomic.LoadInt32(&dials) != 2 {
+		t.Fatalf("expected 2 dials, got %v", dials)
+	}
+}
+
+func TestUpstreamHandshakeRetryBudget(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{MaxRetries: 2, RetryBackoff: time.Millisecond}}
+	helper.init(t)
+	defer helper.close()
+
+	// the first dial to :443 is closed in the tls handshake, the redials are refused
+	var dials int32
+	dialContext := helper.testProxy.Opts.DialContext
+	helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if !strings.HasSuffix(addr, ":443") {
+			return dialContext(ctx, network, addr)
+		}
+		if atomic.AddInt32(&dials, 1) > 1 {
+			return nil, &net.OpError{Op: "dial", Net: network, Err: syscall.ECONNREFUSED}
+		}
+		conn, err := dialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		conn.Close()
+		return &testResetConn{conn}, nil
+	}
+
+	resp, err := helper.getProxyClient().Get("https://example.com/")
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode == 200 {
+			t.Fatal("expected error of the refused redials")
+		}
+	}
+	// the redials of the handshake retries are not retried again by dialRetry
+	if n := atomic.LoadInt32(&dials); n != 3 {
+		t.Fatalf("expected 1 dial and 2 redials, got %v", n)
+	}
+}
+
+func TestEnableHTTP2Lazy(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{EnableHTTP2: true}}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.AddAddon(NewUpstreamCertAddon(false))
+	addon := &testFlowTimeAddon{flows: make(chan *Flow, 10)}
+	helper.testProxy.AddAddon(addon)
+
+	proxyClient := helper.getProxyClient()
+	proxyClient.Transport.(*http.Transport).ForceAttemptHTTP2 = true
+
+	// test https server does not support h2, streams are sent one by one
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := proxyClient.Get("https://example.com/slow")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.ProtoMajor != 2 || string(body) != "ok" {
+				t.Errorf("expected ok over h2, got %v %q", resp.Proto, body)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < 4; i++ {
+		f := <-addon.flows
+		if f.Request.Method == "CONNECT" {
+			continue
+		}
+		if f.ConnContext == nil || f.ConnContext.ClientConn.NegotiatedProtocol != "h2" || f.ConnContext.ServerConn.NegotiatedProtocol == "h2" {
+			t.Fatal("expected h2 client and http/1.1 server")
+		}
+	}
+}
+
+type testEffectiveUpstreamAddon struct {
+	BaseAddon
+	mu   sync.Mutex
+	urls []string
+}
+
+func (addon *testEffectiveUpstreamAddon) Requestheaders(f *Flow) {
+	if f.Request.URL.Path == "/old" {
+		f.Request.URL.Path = "/"
+	}
+}
+
+func (addon *testEffectiveUpstreamAddon) Response(f *Flow) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.urls = append(addon.urls, f.EffectiveUpstreamURL().String())
+}
+
+func TestEffectiveUpstreamURL(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{HostMap: map[string]string{"mapped.com": "example.com"}}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testEffectiveUpstreamAddon{}
+	helper.testProxy.AddAddon(addon)
+	helper.testProxy.AddHooks(&testRewriteUpstreamAddon{targets: map[string]string{"api.prod.com:443": "example.com:443"}})
+	client := helper.getProxyClient()
+
+	testSendRequest(t, "http://example.com/old?a=1", client, "ok")
+	testSendRequest(t, "http://mapped.com/", helper.getProxyClient(), "ok")
+	testSendRequest(t, "https://api.prod.com/", client, "ok")
+
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	expected := []string{"http://example.com/?a=1", "http://example.com:80/", "https://example.com:443/"}
+	if !slices.Equal(addon.urls, expected) {
+		t.Fatalf("expected %v, got %v", expected, addon.urls)
+	}
+}
+
+type testLocalResponseAddon struct {
+	BaseAddon
+	mu               sync.Mutex
+	serverConnected  int
+	observedResponse []string
+}
+
+func (addon *testLocalResponseAddon) Request(f *Flow) {
+	if f.Request.URL.Path == "/mock" {
+		f.Response = &Response{StatusCode: 200, Header: http.Header{"Content-Type": {"text/plain"}}, Body: []byte("mocked")}
+	}
+}
+
+func (addon *testLocalResponseAddon) ServerConnected(*ConnContext) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.serverConnected++
+}
+
+func (addon *testLocalResponseAddon) Response(f *Flow) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.observedResponse = append(addon.observedResponse, string(f.Response.Body))
+}
+
+func TestLocalResponse(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.AddAddon(NewUpstreamCertAddon(false))
+	addon := &testLocalResponseAddon{}
+	helper.testProxy.AddAddon(addon)
+
+	testSendRequest(t, "http://example.com/mock", helper.getProxyClient(), "mocked")
+	testSendRequest(t, "https://example.com/mock", helper.getProxyClient(), "mocked")
+
+	addon.mu.Lock()
+	if addon.serverConnected != 0 || !slices.Equal(addon.observedResponse, []string{"mocked", "mocked"}) {
+		t.Fatalf("expected no server connected and responses observed, got %v %v", addon.serverConnected, addon.observedResponse)
+	}
+	addon.mu.Unlock()
+
+	testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	if addon.serverConnected != 1 {
+		t.Fatalf("expected server connected, got %v", addon.serverConnected)
+	}
+}
+
+func TestUpstreamTLSRenegotiation(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{UpstreamTLSRenegotiation: tls.RenegotiateFreelyAsClient}}
+	helper.init(t)
+	defer helper.close()
+
+	// go tls servers never renegotiate, check the config and the handshake still works
+	if r := helper.testProxy.attacker.client.Transport.(*http.Transport).TLSClientConfig.Renegotiation; r != tls.RenegotiateFreelyAsClient {
+		t.Fatalf("expected renegotiation of separate client set, got %v", r)
+	}
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+}
+
+type testTlsEstablishedClientAddon struct {
+	BaseAddon
+	hellos chan *ClientConn
+}
+
+func (addon *testTlsEstablishedClientAddon) TlsEstablishedClient(connCtx *ConnContext) {
+	addon.hellos <- connCtx.ClientConn
+}
+
+func TestTlsEstablishedClient(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testTlsEstablishedClientAddon{hellos: make(chan *ClientConn, 10)}
+	helper.testProxy.AddAddon(addon)
+
+	t.Run("ja3", func(t *testing.T) {
+		testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+		client := <-addon.hellos
+		hello := client.TlsClientHello
+		if hello == nil {
+			t.Fatal("expected client hello")
+		}
+		if hello.ServerName != "example.com" {
+			t.Fatalf("expected server name example.com, got %v", hello.ServerName)
+		}
+		if len(hello.CipherSuites) == 0 || len(hello.Extensions) == 0 || len(hello.SupportedCurves) == 0 {
+			t.Fatalf("unexpected client hello: %+v", hello)
+		}
+		if _, err := hex.DecodeString(client.Ja3); err != nil || len(client.Ja3) != 32 {
+			t.Fatalf("expected md5 hex of ja3, got %q", client.Ja3)
+		}
+		if client.Ja3 != hello.Ja3() {
+			t.Fatalf("expected %v, got %v", hello.Ja3(), client.Ja3)
+		}
+	})
+
+	t.Run("empty sni", func(t *testing.T) {
+		conn, err := helper.dial(context.Background(), "tcp", "proxy.pipe")
+		handleError(t, err)
+		defer conn.Close()
+		_, err = io.WriteString(conn, "CONNECT example.com:443 HTTP/1.1\r\nHost: example.com:443\r\n\r\n")
+		handleError(t, err)
+		resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+		handleError(t, err)
+		if resp.StatusCode != 200 {
+			t.Fatalf("CONNECT failed: %v", resp.Status)
+		}
+		tlsConn := tls.Client(conn, &tls.Config{InsecureSkipVerify: true, NextProtos: []string{"http/1.1"}})
+		_, err = io.WriteString(tlsConn, "GET / HTTP/1.1\r\nHost: example.com\r\nConnection: close\r\n\r\n")
+		handleError(t, err)
+		resp, err = http.ReadResponse(bufio.NewReader(tlsConn), nil)
+		handleError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != "ok" {
+			t.Fatalf("expected ok, got %v %q", resp.Status, body)
+		}
+
+		client := <-addon.hellos
+		if client.TlsClientHello == nil || client.TlsClientHello.ServerName != "" || client.Ja3 == "" || !slices.Contains(client.TlsClientHello.ALPN, "http/1.1") {
+			t.Fatalf("unexpected client hello: %+v", client.TlsClientHello)
+		}
+	})
+}
+
+func TestCloseDelimitedResponse(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{StreamLargeBodies: 64 * 1024}}
+	helper.init(t)
+	defer helper.close()
+
+	// HTTP/1.0 server without Content-Length, the body ends when the connection is closed
+	serve := func(ln net.Listener) {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				req, err := http.ReadRequest(bufio.NewReader(conn))
+				if err != nil {
+					return
+				}
+				size, _ := strconv.Atoi(req.URL.Query().Get("size"))
+				io.WriteString(conn, "HTTP/1.0 200 OK\r\nContent-Type: text/plain\r\n\r\n"+strings.Repeat("x", size))
+			}()
+		}
+	}
+	rawLn := NewPipeListener()
+	defer rawLn.Close()
+	go serve(rawLn)
+	c, err := helper.serverCA.GetCert("close.example.com")
+	handleError(t, err)
+	tlsLn := NewPipeListener()
+	defer tlsLn.Close()
+	go serve(tls.NewListener(tlsLn, &tls.Config{Certificates: []tls.Certificate{*c}}))
+	dial := helper.testProxy.Opts.DialContext
+	helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		switch addr {
+		case "close.example.com:80":
+			return rawLn.DialContext(ctx, network, addr)
+		case "close.example.com:443":
+			return tlsLn.DialContext(ctx, network, addr)
+		}
+		return dial(ctx, network, addr)
+	}
+
+	for _, scheme := range []string{"http", "https"} {
+		client := helper.getProxyClient()
+		// buffered and streamed
+		for _, size := range []int{1000, 200 * 1024} {
+			resp, err := client.Get(fmt.Sprintf("%v://close.example.com/?size=%v", scheme, size))
+			handleError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			handleError(t, err)
+			if len(body) != size {
+				t.Fatalf("%v: expected body of %v bytes, got %v", scheme, size, len(body))
+			}
+			if !resp.Close {
+				t.Fatalf("%v: expected the client connection closed after the close delimited body", scheme)
+			}
+		}
+
+		// normal responses keep the connection alive
+		accepted := helper.testProxy.Stats().AcceptedConns
+		testSendRequest(t, scheme+"://example.com/", client, "ok")
+		testSendRequest(t, scheme+"://example.com/", client, "ok")
+		if n := helper.testProxy.Stats().AcceptedConns - accepted; n != 1 {
+			t.Fatalf("%v: expected 1 keep-alive connection, got %v", scheme, n)
+		}
+	}
+}
+
+type testStatusOverrideAddon struct {
+	BaseAddon
+	lines chan string
+}
+
+func (addon *testStatusOverrideAddon) Response(f *Flow) {
+	switch {
+	case bytes.Contains(f.Response.Body, []byte("error")):
+		f.Response.StatusCode = 500
+	case f.Request.URL.Query().Get("status") != "":
+		f.Response.StatusCode, _ = strconv.Atoi(f.Request.URL.Query().Get("status"))
+	}
+	addon.lines <- string(f.Response.RawStatusLine)
+}
+
+func TestResponseStatusOverride(t *testing.T) {
+	flows := make(chan *Flow, 1)
+	helper := &testPipeHelper{opts: &Options{OnFlowComplete: func(f *Flow) { flows <- f }}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testStatusOverrideAddon{lines: make(chan string, 3)}
+	helper.testProxy.AddAddon(addon)
+	client := helper.getProxyClient()
+
+	check := func(url string, wantStatus int, wantStatusText string, wantBody string) {
+		t.Helper()
+		resp, err := client.Get(url)
+		handleError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		handleError(t, err)
+		if resp.StatusCode != wantStatus || resp.Status != wantStatusText || string(body) != wantBody {
+			t.Fatalf("expected %q %q, but got %q %q", wantStatusText, wantBody, resp.Status, body)
+		}
+		if line := <-addon.lines; line != "HTTP/1.1 200 OK\r\n" {
+			t.Fatalf("expected raw status line of server, but got %q", line)
+		}
+		if f := <-flows; f.Error != nil {
+			t.Fatalf("expected no error, but got %v", f.Error)
+		}
+	}
+	// 200 with error body is sent as 500, the body is kept
+	check("http://example.com/", 200, "200 OK", "ok")
+	req, err := http.NewRequest("GET", "http://example.com/header?name=X-Msg", nil)
+	handleError(t, err)
+	req.Header.Set("X-Msg", "some error")
+	resp, err := client.Do(req)
+	handleError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	handleError(t, err)
+	if resp.Status != "500 Internal Server Error" || string(body) != "some error" {
+		t.Fatalf("expected 500 with body of server, but got %q %q", resp.Status, body)
+	}
+	<-addon.lines
+	<-flows
+
+	// the body is dropped for the status without body
+	check("http://example.com/?status=304", 304, "304 Not Modified", "")
+}
+
+func TestALPNPassthrough(t *testing.T) {
+	// server of a protocol other than http negotiated by ALPN, echo the decrypted bytes
+	c, err := cert.NewCAMemory()
+	handleError(t, err)
+	serverCert, err := c.GetCert("acme.example.com")
+	handleError(t, err)
+	acmeLn := NewPipeListener()
+	defer acmeLn.Close()
+	go func() {
+		ln := tls.NewListener(acmeLn, &tls.Config{Certificates: []tls.Certificate{*serverCert}, NextProtos: []string{"acme-tls/1"}})
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	dial := func(t *testing.T, helper *testPipeHelper) (*tls.Conn, error) {
+		conn, err := helper.dial(context.Background(), "tcp", "proxy.pipe")
+		handleError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		io.WriteString(conn, "CONNECT acme.example.com:443 HTTP/1.1\r\nHost: acme.example.com:443\r\n\r\n")
+		br := bufio.NewReader(conn)
+		resp, err := http.ReadResponse(br, nil)
+		handleError(t, err)
+		if resp.StatusCode != 200 {
+			t.Fatalf("CONNECT failed: %v", resp.Status)
+		}
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: "acme.example.com", InsecureSkipVerify: true, NextProtos: []string{"acme-tls/1"}})
+		return tlsConn, tlsConn.Handshake()
+	}
+	newHelper := func(t *testing.T, passthrough bool) *testPipeHelper {
+		helper := &testPipeHelper{opts: &Options{ALPNPassthrough: passthrough}}
+		helper.init(t)
+		helper.testProxy.AddAddon(NewUpstreamCertAddon(false))
+		helperDial := helper.testProxy.Opts.DialContext
+		helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if addr == "acme.example.com:443" {
+				return acmeLn.DialContext(ctx, network, addr)
+			}
+			return helperDial(ctx, network, addr)
+		}
+		return helper
+	}
+
+	t.Run("off", func(t *testing.T) {
+		helper := newHelper(t, false)
+		defer helper.close()
+		// the client is offered http/1.1 before server is connected
+		if _, err := dial(t, helper); err == nil {
+			t.Fatal("expected no application protocol error")
+		}
+	})
+
+	t.Run("on", func(t *testing.T) {
+		helper := newHelper(t, true)
+		defer helper.close()
+		conn, err := dial(t, helper)
+		handleError(t, err)
+		if proto := conn.ConnectionState().NegotiatedProtocol; proto != "acme-tls/1" {
+			t.Fatalf("expected acme-tls/1 negotiated, but got %q", proto)
+		}
+		_, err = io.WriteString(conn, "not http\n")
+		handleError(t, err)
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		handleError(t, err)
+		if line != "not http\n" {
+			t.Fatalf("expected the bytes forwarded as is, but got %q", line)
+		}
+		// http still works
+		testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+	})
+}
+
+type testContentLengthAddon struct {
+	BaseAddon
+}
+
+func (addon *testContentLengthAddon) Requestheaders(f *Flow) {
+	if by := f.Request.URL.Query().Get("by"); by == "stream" || by == "slice" {
+		f.Stream = true
+	}
+}
+
+// not comparable, == of two values panics
+type testSliceReader struct {
+	parts []string
+	r     *strings.Reader
+}
+
+func (r testSliceReader) Read(p []byte) (int, error) {
+	return r.r.Read(p)
+}
+
+func (addon *testContentLengthAddon) Response(f *Flow) {
+	switch f.Request.URL.Query().Get("by") {
+	case "body":
+		f.Response.Body = f.Response.Body[:3]
+		f.Response.Header.Set("Content-Length", "100")
+	case "reader":
+		f.Response.BodyReader = strings.NewReader(string(f.Response.Body[:3]))
+		f.Response.Body = nil
+	}
+}
+
+func (addon *testContentLengthAddon) StreamResponseModifier(f *Flow, r io.Reader) io.Reader {
+	switch f.Request.URL.Query().Get("by") {
+	case "stream":
+		return io.LimitReader(r, 3)
+	case "slice":
+		b, _ := io.ReadAll(io.LimitReader(r, 3))
+		return testSliceReader{parts: []string{string(b)}, r: strings.NewReader(string(b))}
+	}
+	return r
+}
+
+func TestAutoFixContentLength(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.AddAddon(&testContentLengthAddon{})
+	// returns the reader of testContentLengthAddon unchanged
+	helper.testProxy.AddAddon(&BaseAddon{})
+
+	post := func(by string) (string, error) {
+		resp, err := helper.getProxyClient().Post("http://example.com/echo?by="+by, "text/plain", strings.NewReader("0123456789"))
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	// the buffered body is always sent with its length
+	body, err := post("body")
+	handleError(t, err)
+	if body != "012" {
+		t.Fatalf("expected shortened body, but got %q", body)
+	}
+
+	for _, by := range []string{"reader", "stream", "slice"} {
+		helper.testProxy.Opts.AutoFixContentLength = false
+		if body, err := post(by); err == nil {
+			t.Fatalf("%v: expected malformed response with the Content-Length of server, but got %q", by, body)
+		}
+		helper.testProxy.Opts.AutoFixContentLength = true
+		body, err := post(by)
+		handleError(t, err)
+		if body != "012" {
+			t.Fatalf("%v: expected shortened body, but got %q", by, body)
+		}
+	}
+}
+
+func TestSameReader(t *testing.T) {
+	r := strings.NewReader("body")
+	slice := testSliceReader{parts: []string{"body"}, r: r}
+	cases := []struct {
+		in, out io.Reader
+		same    bool
+	}{
+		{r, r, true},
+		{r, strings.NewReader("body"), false},
+		{r, slice, false},
+		{slice, slice, false}, // not comparable, == panics
+		{nil, nil, true},
+		{nil, r, false},
+	}
+	for i, c := range cases {
+		if same := sameReader(c.in, c.out); same != c.same {
+			t.Fatalf("case %v: expected %v, but got %v", i, c.same, same)
+		}
+	}
+}
+
+func TestClientALPN(t *testing.T) {
+	for _, upstreamCert := range []bool{false, true} {
+		t.Run(fmt.Sprintf("upstreamCert=%v", upstreamCert), func(t *testing.T) {
+			helper := &testPipeHelper{opts: &Options{
+				EnableHTTP2: true,
+				ClientALPN: func(connCtx *ConnContext, offered []string) []string {
+					switch connCtx.ClientConn.TlsClientHello.ServerName {
+					case "h1.example.com":
+						return []string{"http/1.1"}
+					case "h2.example.com":
+						// server does not support h2
+						return []string{"h2", "http/1.1"}
+					}
+					return offered
+				},
+			}}
+			helper.init(t)
+			defer helper.close()
+			helper.testProxy.AddAddon(NewUpstreamCertAddon(upstreamCert))
+
+			for _, c := range []struct {
+				host      string
+				wantMajor int
+			}{
+				{"h1.example.com", 1},
+				{"h2.example.com", 2},
+			} {
+				client := helper.getProxyClient()
+				client.Transport.(*http.Transport).ForceAttemptHTTP2 = true
+				resp, err := client.Get("https://" + c.host + "/")
+				handleError(t, err)
+				body, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				handleError(t, err)
+				if resp.ProtoMajor != c.wantMajor || string(body) != "ok" {
+					t.Fatalf("%v: expected http/%v, but got %v %q", c.host, c.wantMajor, resp.Proto, body)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildUpstreamRequest(t *testing.T) {
+	errBuild := errors.New("can not build")
+	helper := &testPipeHelper{opts: &Options{
+		BuildUpstreamRequest: func(f *Flow) (*http.Request, error) {
+			switch f.Request.URL.Query().Get("build") {
+			case "crafted":
+				req, err := http.NewRequest("POST", "http://example.com/echo", strings.NewReader("crafted"))
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set("X-Crafted", "1")
+				return req, nil
+			case "flow-body":
+				req, err := http.NewRequest("PUT", "http://example.com/echo", nil)
+				if err != nil {
+					return nil, err
+				}
+				return req, nil
+			case "error":
+				return nil, errBuild
+			}
+			return nil, nil
+		},
+	}}
+	helper.init(t)
+	defer helper.close()
+	flows := make(chan *Flow, 1)
+	helper.testProxy.Opts.OnFlowComplete = func(f *Flow) { flows <- f }
+	client := helper.getProxyClient()
+
+	t.Run("crafted", func(t *testing.T) {
+		testSendRequest(t, "http://example.com/?build=crafted", client, "crafted")
+		<-flows
+	})
+
+	t.Run("body of the flow", func(t *testing.T) {
+		resp, err := client.Post("http://example.com/echo?build=flow-body", "text/plain", strings.NewReader("from client"))
+		handleError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		handleError(t, err)
+		if string(body) != "from client" || resp.Header.Get("X-Content-Length") != "11" {
+			t.Fatalf("expected the body of the flow with its length, but got %q %v", body, resp.Header.Get("X-Content-Length"))
+		}
+		<-flows
+	})
+
+	t.Run("default", func(t *testing.T) {
+		testSendRequest(t, "http://example.com/", client, "ok")
+		<-flows
+	})
+
+	t.Run("error", func(t *testing.T) {
+		resp, err := client.Get("http://example.com/?build=error")
+		handleError(t, err)
+		resp.Body.Close()
+		if resp.StatusCode != 502 {
+			t.Fatalf("expected 502, but got %v", resp.Status)
+		}
+		if f := <-flows; !errors.Is(f.Error, errBuild) {
+			t.Fatalf("expected the build error, but got %v", f.Error)
+		}
+	})
+}
+
+type testOriginalHeadersAddon struct {
+	names []string
+	count atomic.Int32
+}
+
+func (addon *testOriginalHeadersAddon) Requestheaders(f *Flow) {
+	addon.count.Add(1)
+}
+
+func (addon *testOriginalHeadersAddon) OriginalHeaders() []string {
+	return addon.names
+}
+
+func TestOriginalHeaders(t *testing.T) {
+	helper := &testPipeHelper{noAddons: true}
+	helper.init(t)
+	defer helper.close()
+	flows := make(chan *Flow, 1)
+	helper.testProxy.Opts.OnFlowComplete = func(f *Flow) { flows <- f }
+	helper.testProxy.AddHooks(&testOriginalHeadersAddon{})
+	helper.testProxy.AddAddon(NewUpstreamCertAddon(false))
+	client := helper.getProxyClient()
+
+	send := func() *Flow {
+		req, err := http.NewRequest("GET", "http://example.com/", nil)
+		handleError(t, err)
+		req.Header.Set("User-Agent", "test")
+		req.Header.Set("X-Other", "1")
+		resp, err := client.Do(req)
+		handleError(t, err)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return <-flows
+	}
+
+	f := send()
+	if f.OriginalRequest.Header != nil || f.OriginalResponse.Header != nil {
+		t.Fatalf("expected no headers copied, but got %v %v", f.OriginalRequest.Header, f.OriginalResponse.Header)
+	}
+	if f.Request.Header.Get("X-Other") != "1" || f.Response.Header.Get("Content-Length") != "2" {
+		t.Fatalf("expected the headers of the flow kept, but got %v %v", f.Request.Header, f.Response.Header)
+	}
+
+	helper.testProxy.AddHooks(&testOriginalHeadersAddon{names: []string{"user-agent", "content-length"}})
+	f = send()
+	if len(f.OriginalRequest.Header) != 1 || f.OriginalRequest.Header.Get("User-Agent") != "test" {
+		t.Fatalf("expected only User-Agent copied, but got %v", f.OriginalRequest.Header)
+	}
+	if len(f.OriginalResponse.Header) != 1 || f.OriginalResponse.Header.Get("Content-Length") != "2" {
+		t.Fatalf("expected only Content-Length copied, but got %v", f.OriginalResponse.Header)
+	}
+
+	// an addon may read all of them
+	helper.testProxy.AddAddon(&BaseAddon{})
+	f = send()
+	if f.OriginalRequest.Header.Get("X-Other") != "1" {
+		t.Fatalf("expected all headers copied, but got %v", f.OriginalRequest.Header)
+	}
+}
+
+type testLargeUploadAddon struct {
+	BaseAddon
+	chunks  chan int
+	request atomic.Bool
+}
+
+func (addon *testLargeUploadAddon) Request(f *Flow) {
+	addon.request.Store(true)
+}
+
+func (addon *testLargeUploadAddon) RequestBodyChunk(f *Flow, chunk []byte) []byte {
+	addon.chunks <- len(chunk)
+	return chunk
+}
+
+func TestStreamLargeUpload(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{StreamLargeBodies: 1024}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testLargeUploadAddon{chunks: make(chan int, 10)}
+	helper.testProxy.AddAddon(addon)
+
+	pr, pw := io.Pipe()
+	req, err := http.NewRequest("PUT", "http://example.com/echo", pr)
+	handleError(t, err)
+	req.ContentLength = 4096
+	type result struct {
+		resp *http.Response
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		resp, err := helper.getProxyClient().Do(req)
+		results <- result{resp, err}
+	}()
+
+	// forwarded before the limit is read
+	pw.Write(make([]byte, 100))
+	select {
+	case n := <-addon.chunks:
+		if n != 100 {
+			t.Fatalf("expected the first 100 bytes streamed, but got %v", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the body streamed before the limit is read")
+	}
+	pw.Write(make([]byte, 3996))
+	pw.Close()
+
+	r := <-results
+	handleError(t, r.err)
+	body, err := io.ReadAll(r.resp.Body)
+	r.resp.Body.Close()
+	handleError(t, err)
+	// RequestBodyChunk may change the length, it is sent chunked
+	if len(body) != 4096 {
+		t.Fatalf("expected 4096 bytes sent, but got %v", len(body))
+	}
+	if addon.request.Load() {
+		t.Fatal("expected Request not called for the streamed body")
+	}
+
+	t.Run("keep Content-Length", func(t *testing.T) {
+		helper := &testPipeHelper{opts: &Options{StreamLargeBodies: 1024}}
+		helper.init(t)
+		defer helper.close()
+		addon := &testLargeUploadAddon{}
+		helper.testProxy.AddHooks(&struct{ RequestInterceptor }{addon})
+
+		req, err := http.NewRequest("PUT", "http://example.com/echo", bytes.NewReader(make([]byte, 4096)))
+		handleError(t, err)
+		resp, err := helper.getProxyClient().Do(req)
+		handleError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		handleError(t, err)
+		if len(body) != 4096 || resp.Header.Get("X-Content-Length") != "4096" {
+			t.Fatalf("expected 4096 bytes sent with Content-Length, but got %v %v", len(body), resp.Header.Get("X-Content-Length"))
+		}
+		if addon.request.Load() {
+			t.Fatal("expected Request not called for the streamed body")
+		}
+	})
+}
+
+type testClientHelloAddon struct {
+	mu     sync.Mutex
+	ja3s   []string
+	tunnel map[string]bool // JA3 hashes not intercepted
+}
+
+func (addon *testClientHelloAddon) ClientHello(connCtx *ConnContext) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	ja3 := connCtx.ClientConn.Ja3
+	addon.ja3s = append(addon.ja3s, ja3)
+	if addon.tunnel[ja3] {
+		connCtx.Intercept = false
+	}
+}
+
+func TestClientHelloIntercept(t *testing.T) {
+	for _, upstreamCert := range []bool{false, true} {
+		t.Run(fmt.Sprintf("upstreamCert=%v", upstreamCert), func(t *testing.T) {
+			helper := &testPipeHelper{}
+			helper.init(t)
+			defer helper.close()
+			helper.testProxy.AddAddon(NewUpstreamCertAddon(upstreamCert))
+			addon := &testClientHelloAddon{tunnel: map[string]bool{}}
+			helper.testProxy.AddHooks(addon)
+			serverCert, err := helper.serverCA.GetCert("example.com")
+			handleError(t, err)
+
+			// the "app" offers only TLS 1.2 with a cipher suite, a different fingerprint from the "browser"
+			browser := helper.getProxyClient()
+			app := helper.getProxyClient()
+			app.Transport.(*http.Transport).TLSClientConfig = &tls.Config{
+				InsecureSkipVerify: true,
+				MaxVersion:         tls.VersionTLS12,
+				CipherSuites:       []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+			}
+			tunneled := func(client *http.Client) bool {
+				resp, err := client.Get("https://example.com/")
+				handleError(t, err)
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+				client.CloseIdleConnections()
+				return bytes.Equal(resp.TLS.PeerCertificates[0].Raw, serverCert.Certificate[0])
+			}
+
+			if tunneled(app) {
+				t.Fatal("expected the app intercepted before its fingerprint is known")
+			}
+			addon.mu.Lock()
+			appJa3 := addon.ja3s[0]
+			addon.tunnel[appJa3] = true
+			addon.mu.Unlock()
+			if appJa3 == "" {
+				t.Fatal("expected the JA3 of the app")
+			}
+
+			if !tunneled(app) {
+				t.Fatal("expected the app tunneled by its JA3")
+			}
+			if tunneled(browser) {
+				t.Fatal("expected the browser intercepted")
+			}
+			addon.mu.Lock()
+			defer addon.mu.Unlock()
+			if len(addon.ja3s) != 3 || addon.ja3s[2] == appJa3 {
+				t.Fatalf("unexpected fingerprints %v", addon.ja3s)
+			}
+		})
+	}
+}
+
+func TestUpstreamMaxResponseHeaderBytes(t *testing.T) {
+	for _, limit := range []int64{2048, 1 << 20} {
+		t.Run(fmt.Sprintf("limit=%v", limit), func(t *testing.T) {
+			helper := &testPipeHelper{
+				opts: &Options{UpstreamMaxResponseHeaderBytes: limit},
+				handlers: map[string]http.HandlerFunc{"/cookies": func(w http.ResponseWriter, r *http.Request) {
+					n, _ := strconv.Atoi(r.URL.Query().Get("n"))
+					for i := 0; i < n; i++ {
+						http.SetCookie(w, &http.Cookie{Name: fmt.Sprintf("c%v", i), Value: strings.Repeat("v", 100)})
+					}
+					w.Write([]byte("ok"))
+				}},
+			}
+			helper.init(t)
+			defer helper.close()
+			flows := make(chan *Flow, 4)
+			helper.testProxy.Opts.OnFlowComplete = func(f *Flow) {
+				if f.Request.Method != "CONNECT" {
+					flows <- f
+				}
+			}
+
+			for _, scheme := range []string{"http", "https"} {
+				resp, err := helper.getProxyClient().Get(scheme + "://example.com/cookies?n=50")
+				if err != nil && scheme == "https" && limit < 5000 {
+					// the aborted server connection of https closes the client connection with it
+					if f := <-flows; !errors.Is(f.Error, ErrUpstreamHeadersTooLarge) {
+						t.Fatalf("%v: expected ErrUpstreamHeadersTooLarge, but got %v", scheme, f.Error)
+					}
+					continue
+				}
+				handleError(t, err)
+				body, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				handleError(t, err)
+				f := <-flows
+				if limit > 5000 {
+					if resp.StatusCode != 200 || len(resp.Cookies()) != 50 {
+						t.Fatalf("%v: expected 50 cookies, but got %v %v", scheme, resp.Status, len(resp.Cookies()))
+					}
+					continue
+				}
+				if resp.StatusCode != 502 || string(body) != ErrUpstreamHeadersTooLarge.Error() {
+					t.Fatalf("%v: expected 502 with the reason, but got %v %q", scheme, resp.Status, body)
+				}
+				if !errors.Is(f.Error, ErrUpstreamHeadersTooLarge) {
+					t.Fatalf("%v: expected ErrUpstreamHeadersTooLarge, but got %v", scheme, f.Error)
+				}
+			}
+		})
+	}
+}
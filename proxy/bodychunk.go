@@ -0,0 +1,71 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+)
+
+// size of the chunks passed to RequestBodyChunk and ResponseBodyChunk, as io.Copy
+const bodyChunkSize = 32 * 1024
+
+// reader of the streamed body, each chunk read is passed to hook and the bytes returned by it are read instead
+type bodyChunkReader struct {
+	r       io.Reader
+	hook    func(chunk []byte) []byte
+	buf     []byte
+	pending []byte // returned by hook, not read yet
+	err     error  // of r, returned once pending is read
+}
+
+func newBodyChunkReader(r io.Reader, hook func(chunk []byte) []byte) *bodyChunkReader {
+	return &bodyChunkReader{
+		r:    r,
+		hook: hook,
+		buf:  make([]byte, bodyChunkSize),
+	}
+}
+
+func (r *bodyChunkReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		n, err := r.r.Read(r.buf)
+		r.err = err
+		if n > 0 {
+			r.pending = r.hook(r.buf[:n])
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// request body of the streamed flow passed to RequestBodyChunk of addons, not in dry run as StreamRequestModifier
+func (proxy *Proxy) requestBodyChunks(f *Flow, body io.Reader) io.Reader {
+	hooks := proxy.hooks.requestBodyChunk
+	if len(hooks) == 0 || !f.Stream || proxy.Opts.DryRun || body == http.NoBody {
+		return body
+	}
+	return newBodyChunkReader(body, func(chunk []byte) []byte {
+		for _, addon := range hooks {
+			chunk = addon.RequestBodyChunk(f, chunk)
+		}
+		return chunk
+	})
+}
+
+// response body of the streamed flow passed to ResponseBodyChunk of addons
+func (proxy *Proxy) responseBodyChunks(f *Flow, body io.Reader) io.Reader {
+	hooks := proxy.hooks.responseBodyChunk
+	if len(hooks) == 0 || !f.Stream || proxy.Opts.DryRun {
+		return body
+	}
+	f.Response.Header.Del("Content-Length")
+	return newBodyChunkReader(body, func(chunk []byte) []byte {
+		for _, addon := range hooks {
+			chunk = addon.ResponseBodyChunk(f, chunk)
+		}
+		return chunk
+	})
+}
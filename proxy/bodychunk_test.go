@@ -0,0 +1,124 @@
+package proxy
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testStreamRequestAddon struct{}
+
+func (addon *testStreamRequestAddon) Requestheaders(f *Flow) {
+	f.Stream = true
+}
+
+type testBodyChunkAddon struct {
+	BaseAddon
+	mu            sync.Mutex
+	requestBytes  int
+	responseBytes int
+}
+
+func (addon *testBodyChunkAddon) Requestheaders(f *Flow) {
+	f.Stream = true
+}
+
+func (addon *testBodyChunkAddon) RequestBodyChunk(f *Flow, chunk []byte) []byte {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.requestBytes += len(chunk)
+	return bytes.ToUpper(chunk)
+}
+
+func (addon *testBodyChunkAddon) ResponseBodyChunk(f *Flow, chunk []byte) []byte {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.responseBytes += len(chunk)
+	return append(bytes.Clone(chunk), '!')
+}
+
+func TestBodyChunk(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testBodyChunkAddon{}
+	helper.testProxy.AddAddon(addon)
+	proxyClient := helper.getProxyClient()
+
+	body := strings.Repeat("hello", 10000)
+	for _, rawurl := range []string{"http://example.com/echo", "https://example.com/echo"} {
+		addon.mu.Lock()
+		addon.requestBytes, addon.responseBytes = 0, 0
+		addon.mu.Unlock()
+
+		res, err := proxyClient.Post(rawurl, "text/plain", strings.NewReader(body))
+		handleError(t, err)
+		got, err := io.ReadAll(res.Body)
+		handleError(t, err)
+		res.Body.Close()
+
+		addon.mu.Lock()
+		if addon.requestBytes != len(body) || addon.responseBytes != len(body) {
+			t.Fatalf("expected %v bytes observed, but got %v %v", len(body), addon.requestBytes, addon.responseBytes)
+		}
+		addon.mu.Unlock()
+		if want := strings.ToUpper(body); string(bytes.ReplaceAll(got, []byte("!"), nil)) != want || !bytes.HasSuffix(got, []byte("!")) {
+			t.Fatalf("expected the chunks replaced, but got %v bytes", len(got))
+		}
+	}
+
+	// no body
+	testSendRequest(t, "http://example.com/", proxyClient, "ok!")
+}
+
+func TestStreamRequestBody(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.AddHooks(&testStreamRequestAddon{})
+	proxyClient := helper.getProxyClient()
+	body := strings.Repeat("a", 1024*1024)
+
+	t.Run("known length", func(t *testing.T) {
+		req, err := http.NewRequest("PUT", "http://example.com/echo", strings.NewReader(body))
+		handleError(t, err)
+		resp, err := proxyClient.Do(req)
+		handleError(t, err)
+		got, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		handleError(t, err)
+		if string(got) != body {
+			t.Fatalf("expected body of %v bytes, but got %v", len(body), len(got))
+		}
+		if cl := resp.Header.Get("X-Content-Length"); cl != strconv.Itoa(len(body)) {
+			t.Fatalf("expected server received Content-Length %v, but got %v", len(body), cl)
+		}
+	})
+
+	t.Run("unknown length", func(t *testing.T) {
+		pr, pw := io.Pipe()
+		go func() {
+			for i := 0; i < 16; i++ {
+				pw.Write([]byte(body[:len(body)/16]))
+			}
+			pw.Close()
+		}()
+		req, err := http.NewRequest("PUT", "http://example.com/echo", pr)
+		handleError(t, err)
+		resp, err := proxyClient.Do(req)
+		handleError(t, err)
+		got, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		handleError(t, err)
+		if string(got) != body {
+			t.Fatalf("expected body of %v bytes, but got %v", len(body), len(got))
+		}
+		if te := resp.Header.Get("X-Transfer-Encoding"); te != "chunked" {
+			t.Fatalf("expected server received chunked body, but got %q", te)
+		}
+	})
+}
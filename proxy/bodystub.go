@@ -0,0 +1,74 @@
+package proxy
+
+import (
+	"mime"
+	"net/http"
+	"slices"
+
+	"github.com/tidwall/match"
+)
+
+// BodyStub replace the entire response body of matched flows with Body, the upstream body is not read.
+// Filters are matched against the response headers, all of the set filters must match.
+type BodyStub struct {
+	ContentTypeFilter string   // pattern of the media type of response, such as "image/*", empty matches all
+	URLFilter         string   // pattern of the request url, such as "*.example.com/track*", empty matches all
+	StatusFilter      []int    // status codes of response, such as 500, empty matches all
+	HeaderFilter      []string // names of headers the response must have
+	Body              []byte
+	ContentType       string            // Content-Type of the stub body, keep the one of server if empty
+	StatusCode        int               // replace the status code of response, 0 to keep
+	Header            map[string]string // headers set on the response
+}
+
+func (stub *BodyStub) match(f *Flow) bool {
+	if len(stub.StatusFilter) > 0 && !slices.Contains(stub.StatusFilter, f.Response.StatusCode) {
+		return false
+	}
+	for _, key := range stub.HeaderFilter {
+		if _, ok := f.Response.Header[http.CanonicalHeaderKey(key)]; !ok {
+			return false
+		}
+	}
+	if stub.ContentTypeFilter != "" {
+		mediaType, _, err := mime.ParseMediaType(f.Response.Header.Get("Content-Type"))
+		if err != nil || !match.Match(mediaType, stub.ContentTypeFilter) {
+			return false
+		}
+	}
+	if stub.URLFilter != "" && !match.Match(f.Request.URL.String(), stub.URLFilter) {
+		return false
+	}
+	return true
+}
+
+// return the first matched stub of Options.BodyStubs, then of Options.RulesFile
+func (proxy *Proxy) matchBodyStub(f *Flow) *BodyStub {
+	for _, stubs := range [][]BodyStub{proxy.Opts.BodyStubs, proxy.rulesBodyStubs()} {
+		for i := range stubs {
+			if stub := &stubs[i]; stub.match(f) {
+				return stub
+			}
+		}
+	}
+	return nil
+}
+
+func (stub *BodyStub) apply(res *Response) {
+	res.Header.Del("Content-Length")
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Etag")
+	if stub.ContentType != "" {
+		res.Header.Set("Content-Type", stub.ContentType)
+	}
+	for key, value := range stub.Header {
+		res.Header.Set(key, value)
+	}
+	if stub.StatusCode != 0 {
+		res.StatusCode = stub.StatusCode
+	}
+	res.Body = stub.Body
+	if res.Body == nil {
+		res.Body = []byte{}
+	}
+}
@@ -0,0 +1,68 @@
+package proxy
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestBodyStubs(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{BodyStubs: []BodyStub{
+		{ContentTypeFilter: "application/*", Body: []byte("none")},
+		{ContentTypeFilter: "text/*", URLFilter: "*example.com/", Body: []byte("stub"), ContentType: "image/gif"},
+	}}}
+	helper.init(t)
+	defer helper.close()
+	proxyClient := helper.getProxyClient()
+
+	resp, err := proxyClient.Get("https://example.com/")
+	handleError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	handleError(t, err)
+	resp.Body.Close()
+	if string(body) != "stub" || resp.Header.Get("Content-Type") != "image/gif" {
+		t.Fatalf("expected stub body, got %q %v", body, resp.Header.Get("Content-Type"))
+	}
+
+	// url not matched
+	resp, err = proxyClient.Post("https://example.com/echo", "text/plain", strings.NewReader("hello"))
+	handleError(t, err)
+	body, err = io.ReadAll(resp.Body)
+	handleError(t, err)
+	resp.Body.Close()
+	if string(body) != "hello" {
+		t.Fatalf("expected upstream body, got %q", body)
+	}
+}
+
+func TestBodyStubsResponseFilter(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{BodyStubs: []BodyStub{
+		{StatusFilter: []int{500}, Body: []byte("friendly"), Header: map[string]string{"X-Stub": "1"}},
+		{HeaderFilter: []string{"x-content-length"}, Body: []byte("echo stub"), StatusCode: 201},
+	}}}
+	helper.init(t)
+	defer helper.close()
+	proxyClient := helper.getProxyClient()
+
+	for _, c := range []struct {
+		url    string
+		status int
+		body   string
+	}{
+		{"http://example.com/error", 500, "friendly"},
+		{"http://example.com/echo", 201, "echo stub"},
+		{"http://example.com/", 200, "ok"},
+	} {
+		resp, err := proxyClient.Get(c.url)
+		handleError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		handleError(t, err)
+		resp.Body.Close()
+		if resp.StatusCode != c.status || string(body) != c.body {
+			t.Fatalf("%v: expected %v %q, got %v %q", c.url, c.status, c.body, resp.StatusCode, body)
+		}
+		if c.status == 500 && resp.Header.Get("X-Stub") != "1" {
+			t.Fatalf("expected header set by stub, got %v", resp.Header)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package proxy
+
+import (
+	"fmt"
+
+	"github.com/lqqyt2423/go-mitmproxy/cert"
+)
+
+// CAConfig an existing ca for Options.CA, in pem bytes or files, the bytes are used when both are set.
+// The certificate may be followed by its issuers, see cert.NewCAFromPEM.
+type CAConfig struct {
+	CertPEM []byte
+	KeyPEM  []byte
+
+	CertFile string
+	KeyFile  string
+}
+
+// the default ca, Options.CA or the one stored in Options.CaRootPath
+func (proxy *Proxy) loadCA() (*cert.CA, error) {
+	c := proxy.Opts.CA
+	if c == nil {
+		return cert.NewCA(proxy.Opts.CaRootPath)
+	}
+	var ca *cert.CA
+	var err error
+	if len(c.CertPEM) > 0 || len(c.KeyPEM) > 0 {
+		ca, err = cert.NewCAFromPEM(c.CertPEM, c.KeyPEM)
+	} else {
+		ca, err = cert.NewCAFromFiles(c.CertFile, c.KeyFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load Options.CA: %w", err)
+	}
+	return ca, nil
+}
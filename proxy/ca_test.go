@@ -0,0 +1,43 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/lqqyt2423/go-mitmproxy/cert"
+)
+
+func TestOptionsCA(t *testing.T) {
+	ca, err := cert.NewCAMemory()
+	handleError(t, err)
+	key, err := x509.MarshalPKCS8PrivateKey(&ca.PrivateKey)
+	handleError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.RootCert.Raw})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key})
+
+	helper := &testPipeHelper{opts: &Options{CA: &CAConfig{CertPEM: certPEM, KeyPEM: keyPEM}}}
+	helper.init(t)
+	defer helper.close()
+	if root := helper.testProxy.GetCertificate(); !root.Equal(&ca.RootCert) {
+		t.Fatal("expected the ca of Options.CA")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(&ca.RootCert)
+	client := helper.getProxyClient()
+	client.Transport.(*http.Transport).TLSClientConfig = &tls.Config{RootCAs: pool}
+	testSendRequest(t, "https://example.com/", client, "ok")
+
+	other, err := cert.NewCAMemory()
+	handleError(t, err)
+	otherKey, err := x509.MarshalPKCS8PrivateKey(&other.PrivateKey)
+	handleError(t, err)
+	_, err = NewProxy(&Options{CA: &CAConfig{CertPEM: certPEM, KeyPEM: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: otherKey})}})
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("expected key mismatch error, but got %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package proxy
+
+import (
+	"hash/fnv"
+	"net"
+
+	"github.com/lqqyt2423/go-mitmproxy/cert"
+)
+
+// NewCARollout return a func for Options.SelectCA, to roll out a new CA gradually.
+// Clients are assigned by the hash of their IP, so the same client always get the same CA.
+// percent of clients (0 - 100) use newCA, others use the default CA.
+func NewCARollout(newCA *cert.CA, percent int) func(connCtx *ConnContext) *cert.CA {
+	return func(connCtx *ConnContext) *cert.CA {
+		if clientBucket(connCtx) < percent {
+			return newCA
+		}
+		return nil
+	}
+}
+
+// bucket 0 - 99 of the client ip
+func clientBucket(connCtx *ConnContext) int {
+	addr := connCtx.ClientConn.Conn.RemoteAddr().String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	h := fnv.New32a()
+	h.Write([]byte(addr))
+	return int(h.Sum32() % 100)
+}
@@ -4,25 +4,54 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net"
 	"net/http"
+	"net/http/httptrace"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 
-	uuid "github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// CloseReason why a connection was closed
+type CloseReason string
+
+const (
+	CloseReasonNormal         CloseReason = "normal"           // closed by the peer, or after the response
+	CloseReasonClientClosed   CloseReason = "client closed"    // server connection closed because the client connection was closed
+	CloseReasonServerClosed   CloseReason = "server closed"    // client connection closed because the server connection was closed
+	CloseReasonClientTlsError CloseReason = "client tls error" // tls handshake with client failed
+	CloseReasonUpstreamError  CloseReason = "upstream error"   // connect or tls handshake with server failed
+	CloseReasonShutdown       CloseReason = "shutdown"         // proxy is closing or shutting down
+	CloseReasonCloseConn      CloseReason = "close conn"       // closed by Proxy.CloseConn
+	CloseReasonMaxLifetime    CloseReason = "max lifetime"     // older than Options.MaxConnLifetime
+	CloseReasonIdleTimeout    CloseReason = "idle timeout"     // no bytes read or written for Options.IdleTimeout
+	CloseReasonClientAborted  CloseReason = "client aborted"   // the client went away before the response of a flow was sent
 )
 
 // client connection
 type ClientConn struct {
-	Id                 uuid.UUID
+	Id                 string
 	Conn               net.Conn
 	Tls                bool
 	NegotiatedProtocol string
-	UpstreamCert       bool // Connect to upstream server to look up certificate details. Default: True
-	clientHello        *tls.ClientHelloInfo
+	TLSVersion         string      // negotiated tls version of the handshake with client, such as "TLS 1.3"
+	CipherSuite        string      // negotiated cipher suite of the handshake with client, such as "TLS_AES_128_GCM_SHA256"
+	UpstreamCert       bool        // Connect to upstream server to look up certificate details. Default: True
+	CloseReason        CloseReason // set before ClientDisconnected is called
+	// ClientHello of the intercepted tls connection and its JA3 fingerprint, set before TlsEstablishedClient is called
+	TlsClientHello *TlsClientHello
+	Ja3            string
+	clientHello    *tls.ClientHelloInfo
 }
 
-func newClientConn(c net.Conn) *ClientConn {
+func newClientConn(id string, c net.Conn) *ClientConn {
 	return &ClientConn{
-		Id:           uuid.NewV4(),
+		Id:           id,
 		Conn:         c,
 		Tls:          false,
 		UpstreamCert: true,
@@ -33,27 +62,58 @@ func (c *ClientConn) MarshalJSON() ([]byte, error) {
 	m := make(map[string]interface{})
 	m["id"] = c.Id
 	m["tls"] = c.Tls
+	if c.Ja3 != "" {
+		m["ja3"] = c.Ja3
+	}
 	m["address"] = c.Conn.RemoteAddr().String()
+	if c.Tls {
+		m["tlsVersion"] = c.TLSVersion
+		m["cipherSuite"] = c.CipherSuite
+	}
 	return json.Marshal(m)
 }
 
 // server connection
 type ServerConn struct {
-	Id      uuid.UUID
+	Id      string
 	Address string
 	Conn    net.Conn
 
-	client   *http.Client
-	tlsConn  *tls.Conn
-	tlsState *tls.ConnectionState
+	// ALPN of the tls handshake with server, TLS does not expose the full list supported by server,
+	// so only the protocols offered by us (from client hello) and the one server selected are known.
+	OfferedProtos      []string
+	NegotiatedProtocol string
+
+	TLSVersion  string // negotiated tls version of the handshake with server
+	CipherSuite string // negotiated cipher suite of the handshake with server
+
+	CloseReason CloseReason // set before ServerDisconnected is called
+
+	client    *http.Client
+	tlsConn   *tls.Conn
+	tlsState  *tls.ConnectionState
+	tlsErr    error // error of the last tls handshake with server
+	rewritten bool  // Address is rewritten by UpstreamRewriter or ConnectHandler
+
+	clientCertMissing bool // server requested a client cert, none of Options.ClientCerts matches
+
+	statusLine *statusLineConn // records status lines of http/1 responses, nil for http2
 }
 
-func newServerConn() *ServerConn {
+func newServerConn(id string) *ServerConn {
 	return &ServerConn{
-		Id: uuid.NewV4(),
+		Id: id,
 	}
 }
 
+// make the error of a server requiring a client cert clear, tls 1.3 servers reject it after the handshake
+func (c *ServerConn) clientCertErr(err error) error {
+	if err == nil || !c.clientCertMissing || errors.Is(err, errNoClientCert) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", errNoClientCert, err)
+}
+
 func (c *ServerConn) MarshalJSON() ([]byte, error) {
 	m := make(map[string]interface{})
 	m["id"] = c.Id
@@ -63,13 +123,42 @@ func (c *ServerConn) MarshalJSON() ([]byte, error) {
 		peername = c.Conn.RemoteAddr().String()
 	}
 	m["peername"] = peername
+	if c.tlsState != nil {
+		m["tlsVersion"] = c.TLSVersion
+		m["cipherSuite"] = c.CipherSuite
+	}
 	return json.Marshal(m)
 }
 
+// DialContext return the established connection to server, tls connection if handshake with server is done.
+// Can be used as DialContext or DialTLSContext of the transport returned by Options.UpstreamRoundTripper.
+func (c *ServerConn) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if c.tlsConn != nil {
+		return c.tlsConn, nil
+	}
+	return c.Conn, nil
+}
+
+// the connection is shared by client connections, Options.PoolUpstreamConns
+func (c *ServerConn) pooled() bool {
+	_, ok := c.Conn.(*pooledServerConn)
+	return ok
+}
+
 func (c *ServerConn) TlsState() *tls.ConnectionState {
 	return c.tlsState
 }
 
+// ConnTimings latency of each phase of a client connection
+type ConnTimings struct {
+	AcceptAt          time.Time     // when the client connection was accepted
+	ClientHandshake   time.Duration // tls handshake with client
+	UpstreamDNS       time.Duration // resolve the host of server or upstream proxy by net.Resolver, part of UpstreamConnect
+	UpstreamConnect   time.Duration // dial to server, include the CONNECT to upstream proxy
+	UpstreamHandshake time.Duration // tls handshake with server
+	FirstByte         time.Duration // from sending the first request to server until its response headers are received
+}
+
 // connection context ctx key
 var connContextKey = new(struct{})
 
@@ -78,21 +167,417 @@ type ConnContext struct {
 	ClientConn *ClientConn `json:"clientConn"`
 	ServerConn *ServerConn `json:"serverConn"`
 	Intercept  bool        `json:"intercept"` // Indicates whether to parse HTTPS
-	FlowCount  uint32      `json:"-"`         // Number of HTTP requests made on the same connection
+	FlowCount  uint32      `json:"-"`         // Number of HTTP requests made on the same connection, read it atomically
+	// Times of dialing or tls handshake with server again after transient errors, see Options.MaxRetries
+	UpstreamRetries int `json:"-"`
+
+	// Not verify the server certificate of this connection, overrides Options.SslInsecure.
+	// Set it in ClientConnected or Requestheaders, before the tls handshake with server.
+	// Requests sent by the separate client (Flow.UseSeparateClient) are not affected.
+	SkipUpstreamVerify bool `json:"-"`
+
+	// Tunnel the CONNECT as raw tcp, such as to smtp or a custom binary protocol, neither tls nor http is parsed.
+	// The bytes are passed to TcpDataInterceptor addons and half-closes are forwarded. Set it in Requestheaders of the
+	// CONNECT flow, it takes precedence over Intercept, which only decides whether an unparsed tunnel is observed.
+	RawTcp bool `json:"-"`
 
 	proxy              *Proxy
+	connectHost        string                      // host of the CONNECT request
+	connectRewritten   bool                        // connectHost is rewritten by ConnectHandler
+	transparentAddr    string                      // original destination of the connection redirected to the proxy, Options.Transparent
 	closeAfterResponse bool                        // after http response, http server will close the connection
+	draining           int32                       // closing by Proxy.CloseConn, set atomically
 	dialFn             func(context.Context) error // when begin request, if there no ServerConn, use this func to dial
+	dialMu             sync.Mutex                  // streams of h2 client dial concurrently
+	dialErr            error
+	firstByteOnce      sync.Once
+	timingsMu          sync.Mutex
+	timings            ConnTimings // written by the goroutines of client, server and h2 streams, guarded by timingsMu
+	timingsTaken       int32       // the phases of timings are reported by a flow, reset by a new dial, see Flow.Timings
+	closeOnce          sync.Once
+	closeErr           error
+	closeChan          chan struct{} // closed when client connection is closed
+	ctx                context.Context
+	cancel             context.CancelFunc // cancel ctx when client connection is closed
+	closeReasonMu      sync.Mutex
+	lastActive         int64 // unix nano of last read or write of client and server connection
+	bytesRead          int64 // read from client, for ClientBytes
+	bytesWritten       int64 // written to client, for ClientBytes
+	headerStart        int64 // unix nano of the first byte of next request received
+	flowsMu            sync.Mutex
+	flows              []*Flow                  // the last connFlowsRetention flows of the connection
+	throttle           atomic.Pointer[Throttle] // set by SetThrottle
+	downstreamBucket   throttleBucket
+	upstreamBucket     throttleBucket
+	values             sync.Map // set by SetValue
 }
 
+// flows kept by ConnContext for ConnContext.Flows
+const connFlowsRetention = 100
+
 func newConnContext(c net.Conn, proxy *Proxy) *ConnContext {
-	clientConn := newClientConn(c)
-	return &ConnContext{
+	clientConn := newClientConn(proxy.newId(), c)
+	now := time.Now()
+	ctx, cancel := context.WithCancel(context.Background())
+	connCtx := &ConnContext{
 		ClientConn: clientConn,
+		timings:    ConnTimings{AcceptAt: now},
 		proxy:      proxy,
+		closeChan:  make(chan struct{}),
+		ctx:        ctx,
+		cancel:     cancel,
+		lastActive: now.UnixNano(),
+	}
+	if d := proxy.Opts.MaxConnLifetime; d > 0 {
+		go connCtx.closeAfterLifetime(d)
 	}
+	if d := proxy.Opts.IdleTimeout; d > 0 {
+		go connCtx.closeAfterIdle(d)
+	}
+	proxy.conns.add(connCtx)
+	atomic.AddInt64(&proxy.counters.acceptedConns, 1)
+	return connCtx
+}
+
+// Timings snapshot of the phase latency, complete when ConnTimingsObserver is called
+func (connCtx *ConnContext) Timings() ConnTimings {
+	connCtx.timingsMu.Lock()
+	defer connCtx.timingsMu.Unlock()
+	return connCtx.timings
 }
 
-func (connCtx *ConnContext) Id() uuid.UUID {
+func (connCtx *ConnContext) setTimings(fn func(t *ConnTimings)) {
+	connCtx.timingsMu.Lock()
+	defer connCtx.timingsMu.Unlock()
+	fn(&connCtx.timings)
+}
+
+func (connCtx *ConnContext) Id() string {
 	return connCtx.ClientConn.Id
 }
+
+// Context is cancelled when the client connection is closed, the upstream dial, tls handshake and requests of the
+// connection are aborted with it
+func (connCtx *ConnContext) Context() context.Context {
+	return connCtx.ctx
+}
+
+// ctx of the requests served by http.Server on the client connection, it is cancelled when the client connection is
+// closed, unlike the request context which is not cancelled by http.Server once the connection is hijacked for CONNECT
+func (connCtx *ConnContext) serverContext(ctx context.Context) context.Context {
+	ctx, cancel := context.WithCancel(context.WithValue(ctx, connContextKey, connCtx))
+	context.AfterFunc(connCtx.ctx, cancel)
+	return ctx
+}
+
+// LastActive return the time of last read or write of the client and server connection
+func (connCtx *ConnContext) LastActive() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&connCtx.lastActive))
+}
+
+// ClientBytes return the raw bytes read from and written to the client connection so far, including tls records
+func (connCtx *ConnContext) ClientBytes() (read int64, written int64) {
+	return atomic.LoadInt64(&connCtx.bytesRead), atomic.LoadInt64(&connCtx.bytesWritten)
+}
+
+// after tls handshake with client, the request may be already read into the tls buffer
+func (connCtx *ConnContext) markHeaderStart() {
+	atomic.StoreInt64(&connCtx.headerStart, time.Now().UnixNano())
+}
+
+// duration from the first byte of the request received to the headers received at now
+func (connCtx *ConnContext) headerDuration(now time.Time) time.Duration {
+	start := atomic.SwapInt64(&connCtx.headerStart, 0)
+	if start == 0 {
+		return 0
+	}
+	return now.Sub(time.Unix(0, start))
+}
+
+// dial server by dialFn if not connected yet, only the first call dials, the others wait it and get the same result
+func (connCtx *ConnContext) dialServer(ctx context.Context) error {
+	connCtx.dialMu.Lock()
+	defer connCtx.dialMu.Unlock()
+	if connCtx.dialErr != nil {
+		return connCtx.dialErr
+	}
+	if connCtx.ServerConn != nil || connCtx.dialFn == nil {
+		return nil
+	}
+	connCtx.dialErr = connCtx.dialFn(ctx)
+	return connCtx.dialErr
+}
+
+func (connCtx *ConnContext) addFlow(f *Flow) {
+	connCtx.flowsMu.Lock()
+	defer connCtx.flowsMu.Unlock()
+	if len(connCtx.flows) >= connFlowsRetention {
+		connCtx.flows = slices.Delete(connCtx.flows, 0, len(connCtx.flows)-connFlowsRetention+1)
+	}
+	connCtx.flows = append(connCtx.flows, f)
+}
+
+// Flows the flows of the connection in the order they are received, including the CONNECT request and the in-flight ones.
+// Only the last 100 flows are kept.
+func (connCtx *ConnContext) Flows() []*Flow {
+	connCtx.flowsMu.Lock()
+	defer connCtx.flowsMu.Unlock()
+	return slices.Clone(connCtx.flows)
+}
+
+// Value set by SetValue on the connection for key, nil if not set.
+// The values are shared by the hooks and flows of the connection, such as a token parsed in Connect and used by every flow.
+func (connCtx *ConnContext) Value(key any) any {
+	v, _ := connCtx.values.Load(key)
+	return v
+}
+
+// SetValue set the value of key on the connection, nil deletes it. Keys should be of an unexported type as context.WithValue.
+// The values are cleared after ClientDisconnected of addons when the client connection is closed.
+func (connCtx *ConnContext) SetValue(key, value any) {
+	if value == nil {
+		connCtx.values.Delete(key)
+		return
+	}
+	connCtx.values.Store(key, value)
+}
+
+// flows of the connection not finished yet
+func (connCtx *ConnContext) activeFlows() []*Flow {
+	var active []*Flow
+	for _, f := range connCtx.Flows() {
+		select {
+		case <-f.Done():
+		default:
+			active = append(active, f)
+		}
+	}
+	return active
+}
+
+func (connCtx *ConnContext) touch() {
+	atomic.StoreInt64(&connCtx.lastActive, time.Now().UnixNano())
+}
+
+// active client connections of proxy
+type connRegistry struct {
+	mu    sync.Mutex
+	conns map[string]*ConnContext
+}
+
+func (r *connRegistry) add(connCtx *ConnContext) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conns == nil {
+		r.conns = make(map[string]*ConnContext)
+	}
+	r.conns[connCtx.Id()] = connCtx
+}
+
+func (r *connRegistry) remove(connCtx *ConnContext) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.conns, connCtx.Id())
+}
+
+func (r *connRegistry) get(id string) *ConnContext {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.conns[id]
+}
+
+func (r *connRegistry) len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.conns)
+}
+
+func (r *connRegistry) list() []*ConnContext {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := make([]*ConnContext, 0, len(r.conns))
+	for _, connCtx := range r.conns {
+		list = append(list, connCtx)
+	}
+	return list
+}
+
+// close client connection and then server connection, both wrapClientConn.Close and wrapServerConn.Close delegate to it.
+// Only the first call closes and triggers the disconnect hooks, the others return immediately.
+// Closing the server connection happens outside of closeOnce, so a re-entrant close from wrapServerConn does not deadlock.
+func (connCtx *ConnContext) close() error {
+	first := false
+	connCtx.closeOnce.Do(func() {
+		first = true
+		// before cancelling the flows, which are not aborted by the client then
+		connCtx.setClientCloseReason(connCtx.defaultCloseReason())
+		connCtx.closeErr = connCtx.ClientConn.Conn.(*wrapClientConn).Conn.Close()
+		close(connCtx.closeChan)
+		connCtx.cancel()
+		connCtx.proxy.conns.remove(connCtx)
+		atomic.AddInt64(&connCtx.proxy.counters.closedConns, 1)
+	})
+	if !first {
+		return connCtx.closeErr
+	}
+	log.Debugln("in ConnContext close", connCtx.ClientConn.Conn.RemoteAddr())
+
+	for _, addon := range connCtx.proxy.hooks.clientDisconnected {
+		addon.ClientDisconnected(connCtx.ClientConn)
+	}
+	for _, addon := range connCtx.proxy.hooks.connTimings {
+		addon.ConnTimings(connCtx)
+	}
+	connCtx.values.Range(func(key, _ any) bool {
+		connCtx.values.Delete(key)
+		return true
+	})
+
+	// the upstream connection of the pool outlives the client connection
+	if connCtx.ServerConn != nil && connCtx.ServerConn.Conn != nil && !connCtx.ServerConn.pooled() {
+		connCtx.setServerCloseReason(CloseReasonClientClosed)
+		connCtx.ServerConn.Conn.Close()
+	}
+
+	return connCtx.closeErr
+}
+
+// close the connection once it lives longer than Options.MaxConnLifetime
+func (connCtx *ConnContext) closeAfterLifetime(d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		log.Debugf("connection %v reaches max lifetime %v", connCtx.Id(), d)
+		connCtx.closeGracefully(CloseReasonMaxLifetime)
+	case <-connCtx.closeChan:
+	}
+}
+
+// close the connection once no bytes are read or written for Options.IdleTimeout, the flows in progress keep it open
+func (connCtx *ConnContext) closeAfterIdle(d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+		case <-connCtx.closeChan:
+			return
+		}
+		idle := time.Since(connCtx.LastActive())
+		if idle < d {
+			timer.Reset(d - idle)
+			continue
+		}
+		if len(connCtx.activeFlows()) > 0 {
+			timer.Reset(d)
+			continue
+		}
+		log.Debugf("connection %v idle for %v", connCtx.Id(), idle.Round(time.Millisecond))
+		connCtx.setClientCloseReason(CloseReasonIdleTimeout)
+		connCtx.close()
+		return
+	}
+}
+
+// the http/1 requests in progress are cancelled when the client closes its connection before the responses are sent,
+// the connection is then closed with CloseReasonClientAborted. The cancelled h2 streams do not close the connection.
+func (connCtx *ConnContext) markClientAborted() bool {
+	for _, f := range connCtx.activeFlows() {
+		raw := f.Request.Raw()
+		if raw != nil && raw.ProtoMajor == 1 && raw.Context().Err() != nil {
+			connCtx.setClientCloseReason(CloseReasonClientAborted)
+			return true
+		}
+	}
+	return false
+}
+
+// close after the flows in progress finish, for Proxy.CloseConn and Options.MaxConnLifetime
+func (connCtx *ConnContext) closeGracefully(reason CloseReason) error {
+	connCtx.setClientCloseReason(reason)
+	// the http/1 responses written from now on close the connection
+	atomic.StoreInt32(&connCtx.draining, 1)
+
+	active := connCtx.activeFlows()
+	if len(active) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), connDrainTimeout)
+		defer cancel()
+	wait:
+		for _, f := range active {
+			select {
+			case <-f.Done():
+			case <-ctx.Done():
+				log.Warnf("close connection %v with flows in progress, %v", connCtx.Id(), reason)
+				break wait
+			}
+		}
+		timer := time.NewTimer(closeConnGrace)
+		defer timer.Stop()
+		select {
+		case <-connCtx.closeChan:
+			return nil
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+	return connCtx.close()
+}
+
+// ctx of dialing the server, the dns resolution is recorded in Timings().UpstreamDNS
+func (connCtx *ConnContext) traceDial(ctx context.Context) context.Context {
+	connCtx.setTimings(func(t *ConnTimings) { t.UpstreamDNS = 0 })
+	atomic.StoreInt32(&connCtx.timingsTaken, 0)
+	var dnsStart time.Time
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			d := time.Since(dnsStart)
+			connCtx.setTimings(func(t *ConnTimings) { t.UpstreamDNS = d })
+		},
+	})
+}
+
+// set the connection phases of Flow.Timings, only the first flow after the connection is established or the server is
+// dialed again reports them, they are 0 for the flows reusing the connection
+func (connCtx *ConnContext) takeTimings(f *Flow) {
+	if !atomic.CompareAndSwapInt32(&connCtx.timingsTaken, 0, 1) {
+		return
+	}
+	t := connCtx.Timings()
+	f.Timings.DNS = t.UpstreamDNS
+	f.Timings.Connect = t.UpstreamConnect
+	f.Timings.ClientHandshake = t.ClientHandshake
+	f.Timings.ServerHandshake = t.UpstreamHandshake
+}
+
+// set the close reason of both sides, if not set yet
+func (connCtx *ConnContext) setCloseReason(reason CloseReason) {
+	connCtx.setClientCloseReason(reason)
+	connCtx.setServerCloseReason(reason)
+}
+
+func (connCtx *ConnContext) setClientCloseReason(reason CloseReason) {
+	connCtx.closeReasonMu.Lock()
+	defer connCtx.closeReasonMu.Unlock()
+	if connCtx.ClientConn.CloseReason == "" {
+		connCtx.ClientConn.CloseReason = reason
+	}
+}
+
+func (connCtx *ConnContext) setServerCloseReason(reason CloseReason) {
+	connCtx.closeReasonMu.Lock()
+	defer connCtx.closeReasonMu.Unlock()
+	if connCtx.ServerConn != nil && connCtx.ServerConn.CloseReason == "" {
+		connCtx.ServerConn.CloseReason = reason
+	}
+}
+
+func (connCtx *ConnContext) defaultCloseReason() CloseReason {
+	if connCtx.proxy.isClosing() {
+		return CloseReasonShutdown
+	}
+	return CloseReasonNormal
+}
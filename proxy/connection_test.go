@@ -1,15 +1,24 @@
 package proxy
 
 import (
+	"bufio"
+	"context"
 	"crypto/tls"
+	"errors"
 	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
 
-func testGetResponse(t *testing.T, endpoint string, client *http.Client) (*http.Response, []byte) {
+func testGetResponse(t testing.TB, endpoint string, client *http.Client) (*http.Response, []byte) {
 	t.Helper()
 	req, err := http.NewRequest("GET", endpoint, nil)
 	handleError(t, err)
@@ -54,9 +63,9 @@ func TestConnection(t *testing.T) {
 	testProxy.AddAddon(&testConnectionAddon{})
 	getProxyClient := helper.getProxyClient
 	defer helper.ln.Close()
-	go helper.server.Serve(helper.ln)
 	defer helper.tlsPlainLn.Close()
 	helper.server.TLSConfig.NextProtos = []string{"h2"}
+	go helper.server.Serve(helper.ln)
 	go helper.server.ServeTLS(helper.tlsPlainLn, "", "")
 	go testProxy.Start()
 	time.Sleep(time.Millisecond * 10) // wait for test proxy startup
@@ -120,9 +129,9 @@ func TestConnectionOffUpstreamCert(t *testing.T) {
 	testProxy.AddAddon(&testConnectionAddon{})
 	getProxyClient := helper.getProxyClient
 	defer helper.ln.Close()
-	go helper.server.Serve(helper.ln)
 	defer helper.tlsPlainLn.Close()
 	helper.server.TLSConfig.NextProtos = []string{"h2"}
+	go helper.server.Serve(helper.ln)
 	go helper.server.ServeTLS(helper.tlsPlainLn, "", "")
 	go testProxy.Start()
 	time.Sleep(time.Millisecond * 10) // wait for test proxy startup
@@ -172,3 +181,452 @@ func TestConnectionOffUpstreamCert(t *testing.T) {
 		})
 	})
 }
+
+type testDisconnectCountAddon struct {
+	client int32
+	server int32
+}
+
+func (addon *testDisconnectCountAddon) ClientDisconnected(*ClientConn) {
+	atomic.AddInt32(&addon.client, 1)
+}
+
+func (addon *testDisconnectCountAddon) ServerDisconnected(*ConnContext) {
+	atomic.AddInt32(&addon.server, 1)
+}
+
+func TestDisconnectHooksOnce(t *testing.T) {
+	for _, isTls := range []bool{false, true} {
+		for i := 0; i < 100; i++ {
+			addon := &testDisconnectCountAddon{}
+			proxy := &Proxy{Opts: &Options{}}
+			proxy.AddHooks(addon)
+
+			c1, c2 := net.Pipe()
+			defer c2.Close()
+			cw := newWrapClientConn(c1, proxy)
+			connCtx := newConnContext(cw, proxy)
+			connCtx.ClientConn.Tls = isTls
+			cw.connCtx = connCtx
+
+			s1, s2 := net.Pipe()
+			defer s2.Close()
+			sw := newWrapServerConn(s1, proxy, connCtx)
+			connCtx.ServerConn = newServerConn("server")
+			connCtx.ServerConn.Conn = sw
+
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				cw.Close()
+			}()
+			go func() {
+				defer wg.Done()
+				sw.Close()
+			}()
+			wg.Wait()
+			cw.Close()
+			sw.Close()
+
+			if addon.client != 1 || addon.server != 1 {
+				t.Fatalf("tls %v: expected each disconnect hook called once, but got client %v, server %v", isTls, addon.client, addon.server)
+			}
+		}
+	}
+}
+
+type testConnTimingsAddon struct {
+	timings chan ConnTimings
+}
+
+func (addon *testConnTimingsAddon) ConnTimings(connCtx *ConnContext) {
+	if connCtx.ClientConn.Tls {
+		addon.timings <- connCtx.Timings()
+	}
+}
+
+func TestConnTimings(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testConnTimingsAddon{timings: make(chan ConnTimings, 1)}
+	helper.testProxy.AddHooks(addon)
+
+	proxyClient := helper.getProxyClient()
+	testSendRequest(t, "https://example.com/", proxyClient, "ok")
+	proxyClient.CloseIdleConnections()
+
+	timings := <-addon.timings
+	if timings.AcceptAt.IsZero() {
+		t.Fatal("expected AcceptAt")
+	}
+	if timings.ClientHandshake <= 0 || timings.UpstreamConnect <= 0 || timings.UpstreamHandshake <= 0 || timings.FirstByte <= 0 {
+		t.Fatalf("expected all phases recorded: %+v", timings)
+	}
+}
+
+type testClientBytesAddon struct {
+	bytes chan [2]int64
+}
+
+func (addon *testClientBytesAddon) ConnTimings(connCtx *ConnContext) {
+	read, written := connCtx.ClientBytes()
+	addon.bytes <- [2]int64{read, written}
+}
+
+func TestClientBytes(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testClientBytesAddon{bytes: make(chan [2]int64, 1)}
+	helper.testProxy.AddHooks(addon)
+
+	conn, err := helper.dial(context.Background(), "tcp", "proxy.pipe")
+	handleError(t, err)
+	req := "GET http://example.com/ HTTP/1.1\r\nHost: example.com\r\nConnection: close\r\n\r\n"
+	_, err = io.WriteString(conn, req)
+	handleError(t, err)
+	res, err := io.ReadAll(conn)
+	handleError(t, err)
+	conn.Close()
+
+	if b := <-addon.bytes; b[0] != int64(len(req)) || b[1] != int64(len(res)) {
+		t.Fatalf("expected %v bytes read and %v written, but got %v", len(req), len(res), b)
+	}
+}
+
+func TestConnReused(t *testing.T) {
+	flows := make(chan *Flow, 10)
+	helper := &testPipeHelper{opts: &Options{
+		OnFlowComplete: func(f *Flow) {
+			flows <- f
+		},
+	}}
+	helper.init(t)
+	defer helper.close()
+
+	proxyClient := helper.getProxyClient()
+	for i := 1; i <= 3; i++ {
+		testSendRequest(t, "http://example.com/", proxyClient, "ok")
+		f := <-flows
+		if f.Request.ConnRequestCount != uint32(i) || f.Request.ConnReused != (i > 1) {
+			t.Fatalf("expected request %v of the connection, but got %v reused %v", i, f.Request.ConnRequestCount, f.Request.ConnReused)
+		}
+	}
+
+	testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+	if f := <-flows; f.Request.ConnRequestCount != 1 || f.Request.ConnReused {
+		t.Fatalf("expected the first request of a new connection, but got %v reused %v", f.Request.ConnRequestCount, f.Request.ConnReused)
+	}
+}
+
+type testCloseReasonAddon struct {
+	client chan CloseReason
+	server chan CloseReason
+}
+
+func (addon *testCloseReasonAddon) ClientDisconnected(client *ClientConn) {
+	addon.client <- client.CloseReason
+}
+
+func (addon *testCloseReasonAddon) ServerDisconnected(connCtx *ConnContext) {
+	addon.server <- connCtx.ServerConn.CloseReason
+}
+
+func TestCloseReason(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testCloseReasonAddon{client: make(chan CloseReason, 1), server: make(chan CloseReason, 1)}
+	helper.testProxy.AddHooks(addon)
+
+	t.Run("client closed", func(t *testing.T) {
+		proxyClient := helper.getProxyClient()
+		testSendRequest(t, "https://example.com/", proxyClient, "ok")
+		proxyClient.CloseIdleConnections()
+		if r := <-addon.client; r != CloseReasonNormal {
+			t.Fatalf("expected client close reason %v, but got %v", CloseReasonNormal, r)
+		}
+		if r := <-addon.server; r != CloseReasonClientClosed {
+			t.Fatalf("expected server close reason %v, but got %v", CloseReasonClientClosed, r)
+		}
+	})
+
+	t.Run("client tls error", func(t *testing.T) {
+		conn, err := helper.dial(context.Background(), "tcp", "proxy.pipe")
+		handleError(t, err)
+		defer conn.Close()
+		_, err = io.WriteString(conn, "CONNECT example.com:443 HTTP/1.1\r\nHost: example.com:443\r\n\r\n")
+		handleError(t, err)
+		resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+		handleError(t, err)
+		resp.Body.Close()
+		// tls record with a malformed ClientHello
+		_, err = conn.Write([]byte{0x16, 0x03, 0x01, 0x00, 0x05, 0x01, 0x00, 0x00, 0x01, 0x00})
+		handleError(t, err)
+		go io.Copy(io.Discard, conn) // read the alert, net.Pipe is unbuffered
+		if r := <-addon.client; r != CloseReasonClientTlsError {
+			t.Fatalf("expected client close reason %v, but got %v", CloseReasonClientTlsError, r)
+		}
+		if r := <-addon.server; r != CloseReasonClientTlsError {
+			t.Fatalf("expected server close reason %v, but got %v", CloseReasonClientTlsError, r)
+		}
+	})
+
+	t.Run("client aborted", func(t *testing.T) {
+		conn, err := helper.dial(context.Background(), "tcp", "proxy.pipe")
+		handleError(t, err)
+		_, err = io.WriteString(conn, "GET http://example.com/block HTTP/1.1\r\nHost: example.com\r\n\r\n")
+		handleError(t, err)
+		<-helper.blockStarted
+		conn.Close()
+		<-helper.blockCancelled
+		if r := <-addon.client; r != CloseReasonClientAborted {
+			t.Fatalf("expected client close reason %v, but got %v", CloseReasonClientAborted, r)
+		}
+		if r := <-addon.server; r != CloseReasonClientClosed {
+			t.Fatalf("expected server close reason %v, but got %v", CloseReasonClientClosed, r)
+		}
+	})
+}
+
+func TestIdleTimeout(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{IdleTimeout: 100 * time.Millisecond}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testCloseReasonAddon{client: make(chan CloseReason, 1), server: make(chan CloseReason, 1)}
+	helper.testProxy.AddHooks(addon)
+
+	proxyClient := helper.getProxyClient()
+	start := time.Now()
+	testSendRequest(t, "https://example.com/", proxyClient, "ok")
+	if r := <-addon.client; r != CloseReasonIdleTimeout {
+		t.Fatalf("expected client close reason %v, but got %v", CloseReasonIdleTimeout, r)
+	}
+	if d := time.Since(start); d < 100*time.Millisecond {
+		t.Fatalf("expected closed after idle timeout, but closed after %v", d)
+	}
+	if r := <-addon.server; r != CloseReasonClientClosed {
+		t.Fatalf("expected server close reason %v, but got %v", CloseReasonClientClosed, r)
+	}
+}
+
+type testConnFlowsAddon struct {
+	BaseAddon
+	mu      sync.Mutex
+	connCtx *ConnContext
+}
+
+func (addon *testConnFlowsAddon) Requestheaders(f *Flow) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.connCtx = f.ConnContext
+}
+
+func TestConnContextFlows(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testConnFlowsAddon{}
+	helper.testProxy.AddAddon(addon)
+
+	client := helper.getProxyClient()
+	testSendRequest(t, "https://example.com/", client, "ok")
+	testSendRequest(t, "https://example.com/slow", client, "ok")
+
+	addon.mu.Lock()
+	connCtx := addon.connCtx
+	addon.mu.Unlock()
+	var got []string
+	for _, f := range connCtx.Flows() {
+		got = append(got, f.Request.Method+" "+f.Request.URL.Path)
+	}
+	if expected := []string{"CONNECT ", "GET /", "GET /slow"}; !slices.Equal(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+
+	connCtx = &ConnContext{}
+	for i := 0; i < connFlowsRetention+10; i++ {
+		connCtx.addFlow(newFlow(strconv.Itoa(i)))
+	}
+	if flows := connCtx.Flows(); len(flows) != connFlowsRetention || flows[0].Id != "10" {
+		t.Fatalf("expected the last %v flows kept, got %v from %v", connFlowsRetention, len(flows), flows[0].Id)
+	}
+}
+
+type testCloseConnAddon struct {
+	BaseAddon
+	conns        chan *ConnContext
+	disconnected chan CloseReason
+}
+
+func (addon *testCloseConnAddon) Requestheaders(f *Flow) {
+	addon.conns <- f.ConnContext
+}
+
+func (addon *testCloseConnAddon) ClientDisconnected(client *ClientConn) {
+	addon.disconnected <- client.CloseReason
+}
+
+func TestCloseConn(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testCloseConnAddon{conns: make(chan *ConnContext, 1), disconnected: make(chan CloseReason, 1)}
+	helper.testProxy.AddAddon(addon)
+
+	if err := helper.testProxy.CloseConn("unknown"); !errors.Is(err, ErrConnNotFound) {
+		t.Fatalf("expected ErrConnNotFound, but got %v", err)
+	}
+	rec := httptest.NewRecorder()
+	helper.testProxy.adminHandler().ServeHTTP(rec, httptest.NewRequest("POST", "/conns/close?id=unknown", nil))
+	if rec.Code != 404 {
+		t.Fatalf("expected 404 of unknown connection, but got %v", rec.Code)
+	}
+
+	// the flow in progress finishes before the connection is closed
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		testSendRequest(t, "http://example.com/slow", helper.getProxyClient(), "ok")
+	}()
+	connCtx := <-addon.conns
+	rec = httptest.NewRecorder()
+	helper.testProxy.adminHandler().ServeHTTP(rec, httptest.NewRequest("POST", "/conns/close?id="+connCtx.Id(), nil))
+	if rec.Code != 200 {
+		t.Fatalf("expected 200, but got %v %v", rec.Code, rec.Body.String())
+	}
+	<-done
+	if reason := <-addon.disconnected; reason != CloseReasonCloseConn {
+		t.Fatalf("expected close reason %v, but got %v", CloseReasonCloseConn, reason)
+	}
+}
+
+func TestMaxConnLifetime(t *testing.T) {
+	flows := make(chan *Flow, 10)
+	helper := &testPipeHelper{opts: &Options{
+		MaxConnLifetime: 20 * time.Millisecond,
+		OnFlowComplete:  func(f *Flow) { flows <- f },
+	}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testCloseConnAddon{conns: make(chan *ConnContext, 10), disconnected: make(chan CloseReason, 10)}
+	helper.testProxy.AddAddon(addon)
+	proxyClient := helper.getProxyClient()
+
+	// the flow in progress when the lifetime is reached finishes
+	testSendRequest(t, "http://example.com/slow", proxyClient, "ok")
+	if reason := <-addon.disconnected; reason != CloseReasonMaxLifetime {
+		t.Fatalf("expected close reason %v, but got %v", CloseReasonMaxLifetime, reason)
+	}
+	testSendRequest(t, "http://example.com/", proxyClient, "ok")
+	<-flows
+	if f := <-flows; f.Request.ConnReused {
+		t.Fatal("expected the request sent on a new connection")
+	}
+}
+
+func TestConnContextCancel(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	for _, u := range []string{"http://example.com/block", "https://example.com/block"} {
+		t.Run(u, func(t *testing.T) {
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				helper.getProxyClient().Get(u)
+			}()
+			<-helper.blockStarted
+			conns := helper.testProxy.conns.list()
+			if len(conns) != 1 {
+				t.Fatalf("expected 1 connection, but got %v", len(conns))
+			}
+			connCtx := conns[0]
+			if connCtx.Context().Err() != nil {
+				t.Fatal("expected context of the open connection not cancelled")
+			}
+			connCtx.close()
+
+			select {
+			case <-connCtx.Context().Done():
+			case <-time.After(time.Second):
+				t.Fatal("expected context cancelled after the client connection is closed")
+			}
+			select {
+			case <-helper.blockCancelled:
+			case <-time.After(time.Second):
+				t.Fatal("expected upstream request cancelled after the client connection is closed")
+			}
+			<-done
+		})
+	}
+}
+
+type testConnValueKey struct{}
+
+type testConnValueAddon struct {
+	BaseAddon
+	conns        chan *ConnContext
+	disconnected chan any
+}
+
+func (addon *testConnValueAddon) Requestheaders(f *Flow) {
+	if f.Request.Method == "CONNECT" {
+		f.ConnContext.SetValue(testConnValueKey{}, f.Request.Header.Get("X-Token"))
+	}
+}
+
+func (addon *testConnValueAddon) Response(f *Flow) {
+	if token, ok := f.ConnContext.Value(testConnValueKey{}).(string); ok {
+		f.Response.Header.Set("X-Token", token)
+	}
+	select {
+	case addon.conns <- f.ConnContext:
+	default:
+	}
+}
+
+func (addon *testConnValueAddon) ClientDisconnected(client *ClientConn) {
+	connCtx := <-addon.conns
+	addon.disconnected <- connCtx.Value(testConnValueKey{})
+}
+
+func TestConnContextValue(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testConnValueAddon{conns: make(chan *ConnContext, 1), disconnected: make(chan any, 1)}
+	helper.testProxy.AddAddon(addon)
+
+	client := helper.getProxyClient()
+	transport := client.Transport.(*http.Transport)
+	transport.ProxyConnectHeader = http.Header{"X-Token": {"abc"}}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get("https://example.com/")
+		handleError(t, err)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if token := resp.Header.Get("X-Token"); token != "abc" {
+			t.Fatalf("request %v: expected the token of the connection, but got %q", i, token)
+		}
+	}
+
+	transport.CloseIdleConnections()
+	select {
+	case v := <-addon.disconnected:
+		if v != "abc" {
+			t.Fatalf("expected the value kept in ClientDisconnected, but got %v", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected ClientDisconnected called")
+	}
+
+	connCtx := &ConnContext{}
+	connCtx.SetValue(testConnValueKey{}, 1)
+	connCtx.SetValue(testConnValueKey{}, nil)
+	if v := connCtx.Value(testConnValueKey{}); v != nil {
+		t.Fatalf("expected the value deleted, but got %v", v)
+	}
+}
@@ -0,0 +1,71 @@
+package proxy
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// buckets idle for this long are full again, they are removed at most once per interval
+const connLimiterGCInterval = 10 * time.Second
+
+// token bucket of new connections per client ip, for Options.MaxNewConnsPerIPPerSec
+// the burst is the same as the rate, a bucket refills in a second
+type connLimiter struct {
+	rate float64
+
+	mu      sync.Mutex
+	buckets map[string]*connBucket
+	lastGC  time.Time
+}
+
+type connBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newConnLimiter(rate int) *connLimiter {
+	return &connLimiter{
+		rate:    float64(rate),
+		buckets: make(map[string]*connBucket),
+		lastGC:  time.Now(),
+	}
+}
+
+// take a token of ip at now, false if the rate is exceeded
+func (l *connLimiter) allow(ip string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastGC) >= connLimiterGCInterval {
+		for key, b := range l.buckets {
+			if now.Sub(b.last) >= time.Second {
+				delete(l.buckets, key)
+			}
+		}
+		l.lastGC = now
+	}
+
+	b, ok := l.buckets[ip]
+	if !ok {
+		b = &connBucket{tokens: l.rate, last: now}
+		l.buckets[ip] = b
+	} else {
+		b.tokens = min(l.rate, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// ip of the remote address, the whole address if it has no port, such as PipeListener
+func remoteIP(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
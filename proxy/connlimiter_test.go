@@ -0,0 +1,56 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMaxNewConnsPerIP(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{MaxNewConnsPerIPPerSec: 10}}
+	helper.init(t)
+	defer helper.close()
+
+	n := 100
+	served := 0
+	for i := 0; i < n; i++ {
+		conn, err := helper.dial(context.Background(), "tcp", "proxy.pipe")
+		handleError(t, err)
+		conn.SetDeadline(time.Now().Add(time.Second))
+		if _, err := io.WriteString(conn, "GET http://example.com/ HTTP/1.1\r\nHost: example.com\r\n\r\n"); err == nil {
+			if resp, err := http.ReadResponse(bufio.NewReader(conn), nil); err == nil {
+				resp.Body.Close()
+				served++
+			}
+		}
+		conn.Close()
+	}
+	// the burst and the tokens refilled during the test
+	if served < 10 || served > 20 {
+		t.Fatalf("expected about 10 connections served, but got %v", served)
+	}
+	if rejected := helper.testProxy.Stats().RejectedConns; rejected != int64(n-served) {
+		t.Fatalf("expected %v rejected connections, but got %v", n-served, rejected)
+	}
+
+	t.Run("refill and gc", func(t *testing.T) {
+		l := newConnLimiter(2)
+		now := time.Now()
+		if !l.allow("a", now) || !l.allow("a", now) || l.allow("a", now) {
+			t.Fatal("expected burst of 2")
+		}
+		if !l.allow("b", now) {
+			t.Fatal("expected ips limited separately")
+		}
+		if !l.allow("a", now.Add(500*time.Millisecond)) {
+			t.Fatal("expected a token refilled")
+		}
+		l.allow("c", now.Add(connLimiterGCInterval+time.Second))
+		if len(l.buckets) != 1 {
+			t.Fatalf("expected idle buckets removed, but got %v", len(l.buckets))
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package proxy
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrPartialContent returned by Response.DecodedBody for an encoded body of a 206 response which is not the complete
+// resource, a part of the encoded stream can not be decoded. The body is forwarded as is.
+var ErrPartialContent = errors.New("partial content can not be decoded")
+
+// ContentRange of a 206 Partial Content response, the body is the bytes from Start to End of the resource
+type ContentRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`  // inclusive
+	Size  int64 `json:"size"` // of the complete resource, -1 if unknown
+}
+
+// Length of the body
+func (cr *ContentRange) Length() int64 {
+	return cr.End - cr.Start + 1
+}
+
+// Complete reports whether the range is the complete resource
+func (cr *ContentRange) Complete() bool {
+	return cr.Start == 0 && cr.Size == cr.End+1
+}
+
+// IsPartial reports whether the response is 206 Partial Content, the body is only a part of the resource.
+// Addons inspecting the body should not expect the complete content, such as to parse or decode it.
+func (r *Response) IsPartial() bool {
+	return r.StatusCode == http.StatusPartialContent
+}
+
+// ContentRange parsed from the Content-Range header of the 206 response, such as "bytes 0-99/1000".
+// nil if the response is not partial, or the body is multipart/byteranges of several ranges.
+func (r *Response) ContentRange() *ContentRange {
+	if !r.IsPartial() {
+		return nil
+	}
+	return parseContentRange(r.Header.Get("Content-Range"))
+}
+
+func parseContentRange(s string) *ContentRange {
+	s, ok := strings.CutPrefix(s, "bytes ")
+	if !ok {
+		return nil
+	}
+	rng, size, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return nil
+	}
+	start, end, ok := strings.Cut(rng, "-")
+	if !ok {
+		return nil
+	}
+	cr := &ContentRange{Size: -1}
+	var err error
+	if cr.Start, err = strconv.ParseInt(start, 10, 64); err != nil || cr.Start < 0 {
+		return nil
+	}
+	if cr.End, err = strconv.ParseInt(end, 10, 64); err != nil || cr.End < cr.Start {
+		return nil
+	}
+	if size != "*" {
+		if cr.Size, err = strconv.ParseInt(size, 10, 64); err != nil || cr.Size <= cr.End {
+			return nil
+		}
+	}
+	return cr
+}
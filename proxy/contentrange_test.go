@@ -0,0 +1,113 @@
+package proxy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var testMediaBody = bytes.Repeat([]byte("0123456789"), 10)
+
+type testPartialContentAddon struct {
+	BaseAddon
+	flows chan *Flow
+}
+
+func (addon *testPartialContentAddon) Response(f *Flow) {
+	addon.flows <- f
+}
+
+func TestPartialContent(t *testing.T) {
+	for _, stream := range []bool{false, true} {
+		t.Run(fmt.Sprintf("stream=%v", stream), func(t *testing.T) {
+			helper := &testPipeHelper{
+				opts: &Options{StreamPartialContent: stream},
+				handlers: map[string]http.HandlerFunc{"/media": func(w http.ResponseWriter, r *http.Request) {
+					http.ServeContent(w, r, "media.bin", time.Time{}, bytes.NewReader(testMediaBody))
+				}},
+			}
+			helper.init(t)
+			defer helper.close()
+			addon := &testPartialContentAddon{flows: make(chan *Flow, 1)}
+			helper.testProxy.AddAddon(addon)
+
+			req, err := http.NewRequest("GET", "https://example.com/media", nil)
+			handleError(t, err)
+			req.Header.Set("Range", "bytes=10-19")
+			resp, err := helper.getProxyClient().Do(req)
+			handleError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			handleError(t, err)
+			if resp.StatusCode != 206 || resp.Header.Get("Content-Range") != "bytes 10-19/100" || resp.ContentLength != 10 {
+				t.Fatalf("expected 206 of bytes 10-19/100, but got %v %v %v", resp.Status, resp.Header.Get("Content-Range"), resp.ContentLength)
+			}
+			if !bytes.Equal(body, testMediaBody[10:20]) {
+				t.Fatalf("unexpected body %q", body)
+			}
+
+			f := <-addon.flows
+			cr := f.Response.ContentRange()
+			if !f.Response.IsPartial() || cr == nil || *cr != (ContentRange{Start: 10, End: 19, Size: 100}) || cr.Length() != 10 || cr.Complete() {
+				t.Fatalf("unexpected content range %+v", cr)
+			}
+			if f.Stream != stream {
+				t.Fatalf("expected Stream %v, but got %v", stream, f.Stream)
+			}
+			if !stream && !bytes.Equal(f.Response.Body, body) {
+				t.Fatalf("expected the partial body for addons, but got %q", f.Response.Body)
+			}
+			if stream && f.Response.Body != nil {
+				t.Fatalf("expected no body in stream mode, but got %q", f.Response.Body)
+			}
+
+			// the complete resource is not partial
+			testSendRequest(t, "https://example.com/media", helper.getProxyClient(), string(testMediaBody))
+			if f := <-addon.flows; f.Response.IsPartial() || f.Stream {
+				t.Fatalf("expected buffered complete response, but got %v stream %v", f.Response.StatusCode, f.Stream)
+			}
+		})
+	}
+
+	t.Run("decode partial body", func(t *testing.T) {
+		body, err := encode("gzip", testMediaBody)
+		handleError(t, err)
+		res := &Response{
+			StatusCode: 206,
+			Header:     http.Header{"Content-Encoding": {"gzip"}, "Content-Range": {fmt.Sprintf("bytes 0-9/%v", len(body))}},
+			Body:       body[:10],
+		}
+		if _, err := res.DecodedBody(); !errors.Is(err, ErrPartialContent) {
+			t.Fatalf("expected ErrPartialContent, but got %v", err)
+		}
+
+		res = &Response{
+			StatusCode: 206,
+			Header:     http.Header{"Content-Encoding": {"gzip"}, "Content-Range": {fmt.Sprintf("bytes 0-%v/%v", len(body)-1, len(body))}},
+			Body:       body,
+		}
+		if decoded, err := res.DecodedBody(); err != nil || !bytes.Equal(decoded, testMediaBody) {
+			t.Fatalf("expected the complete range decoded, but got %v", err)
+		}
+	})
+
+	t.Run("parse content range", func(t *testing.T) {
+		for s, want := range map[string]*ContentRange{
+			"bytes 0-99/100":  {Start: 0, End: 99, Size: 100},
+			"bytes 5-9/*":     {Start: 5, End: 9, Size: -1},
+			"bytes */100":     nil,
+			"bytes 9-5/100":   nil,
+			"bytes 0-100/100": nil,
+			"items 0-9/100":   nil,
+		} {
+			got := parseContentRange(s)
+			if (got == nil) != (want == nil) || got != nil && *got != *want {
+				t.Fatalf("%q: expected %+v, but got %+v", s, want, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// With Options.DryRun, the modifications of addons are logged and then discarded,
+// the original request and response are forwarded.
+
+// reply by addon is logged and discarded, return false if not dry run
+func (a *attacker) dryRunReply(f *Flow) bool {
+	if !a.proxy.Opts.DryRun {
+		return false
+	}
+	dryRunLog(f).Infof("dry run: would reply %v with %v bytes body", f.Response.StatusCode, len(f.Response.Body))
+	return true
+}
+
+func (a *attacker) dryRunRequest(f *Flow) {
+	origin := f.OriginalRequest
+	req := f.Request
+	var changes []string
+	if req.Method != origin.Method {
+		changes = append(changes, fmt.Sprintf("method %v -> %v", origin.Method, req.Method))
+	}
+	if req.URL.String() != origin.URL.String() {
+		changes = append(changes, fmt.Sprintf("url %v -> %v", origin.URL, req.URL))
+	}
+	changes = append(changes, diffHeader(origin.Header, req.Header)...)
+	if origin.Body != nil && !bytes.Equal(origin.Body, req.Body) {
+		changes = append(changes, fmt.Sprintf("body %v bytes -> %v bytes", len(origin.Body), len(req.Body)))
+	}
+	logDryRun(f, "request", changes)
+
+	f.Request = origin.snapshot()
+	f.UseSeparateClient = false
+}
+
+func (a *attacker) dryRunResponse(f *Flow) {
+	origin := f.OriginalResponse
+	res := f.Response
+	var changes []string
+	if res.StatusCode != origin.StatusCode {
+		changes = append(changes, fmt.Sprintf("status %v -> %v", origin.StatusCode, res.StatusCode))
+	}
+	changes = append(changes, diffHeader(origin.Header, res.Header)...)
+	if origin.Body != nil && !bytes.Equal(origin.Body, res.Body) {
+		changes = append(changes, fmt.Sprintf("body %v bytes -> %v bytes", len(origin.Body), len(res.Body)))
+	}
+	logDryRun(f, "response", changes)
+
+	f.Response = origin.snapshot()
+}
+
+func dryRunLog(f *Flow) *log.Entry {
+	return log.WithFields(log.Fields{
+		"in":     "Proxy.attacker.dryRun",
+		"url":    f.OriginalRequest.URL,
+		"method": f.OriginalRequest.Method,
+	})
+}
+
+func logDryRun(f *Flow, part string, changes []string) {
+	if len(changes) == 0 {
+		return
+	}
+	entry := dryRunLog(f)
+	for _, change := range changes {
+		entry.Infof("dry run: would modify %v %v", part, change)
+	}
+}
+
+// header changes from a to b, sorted by key
+func diffHeader(a, b http.Header) []string {
+	keys := make(map[string]struct{})
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var changes []string
+	for _, k := range sorted {
+		av, aok := a[k]
+		bv, bok := b[k]
+		switch {
+		case !aok:
+			changes = append(changes, fmt.Sprintf("header + %v: %v", k, strings.Join(bv, ", ")))
+		case !bok:
+			changes = append(changes, fmt.Sprintf("header - %v: %v", k, strings.Join(av, ", ")))
+		case strings.Join(av, "\x00") != strings.Join(bv, "\x00"):
+			changes = append(changes, fmt.Sprintf("header ~ %v: %v -> %v", k, strings.Join(av, ", "), strings.Join(bv, ", ")))
+		}
+	}
+	return changes
+}
@@ -0,0 +1,69 @@
+package proxy
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+)
+
+type testDryRunAddon struct {
+	BaseAddon
+}
+
+func (addon *testDryRunAddon) Requestheaders(f *Flow) {
+	if f.Request.URL.Path == "/reply" {
+		f.Response = &Response{StatusCode: 403, Body: []byte("blocked")}
+	}
+}
+
+func (addon *testDryRunAddon) Request(f *Flow) {
+	f.Request.Header.Set("X-Dry-Run", "1")
+	f.Request.Body = []byte("changed")
+}
+
+func (addon *testDryRunAddon) Response(f *Flow) {
+	f.Response.StatusCode = 500
+	f.Response.Body = []byte("modified")
+}
+
+func TestDryRun(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{DryRun: true}}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.AddAddon(&testDryRunAddon{})
+
+	hook := logtest.NewGlobal()
+	defer log.StandardLogger().ReplaceHooks(make(log.LevelHooks))
+
+	resp, err := helper.getProxyClient().Post("http://example.com/echo", "text/plain", strings.NewReader("origin"))
+	handleError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	handleError(t, err)
+	if resp.StatusCode != 200 || string(body) != "origin" {
+		t.Fatalf("expected original 200 origin, but got %v %s", resp.StatusCode, body)
+	}
+	testSendRequest(t, "http://example.com/reply", helper.getProxyClient(), "ok")
+
+	var logs []string
+	for _, entry := range hook.AllEntries() {
+		if strings.HasPrefix(entry.Message, "dry run:") {
+			logs = append(logs, entry.Message)
+		}
+	}
+	for _, want := range []string{
+		"dry run: would modify request header + X-Dry-Run: 1",
+		"dry run: would modify request body 6 bytes -> 7 bytes",
+		"dry run: would modify response status 200 -> 500",
+		"dry run: would modify response body 6 bytes -> 8 bytes",
+		"dry run: would reply 403 with 7 bytes body",
+	} {
+		if !slices.Contains(logs, want) {
+			t.Errorf("expected log %q, but got %q", want, logs)
+		}
+	}
+}
@@ -3,12 +3,18 @@ package proxy
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
 	"io"
 	"net"
 	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/lqqyt2423/go-mitmproxy/internal/helper"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/http2"
 )
 
 // wrap tcpListener for remote client
@@ -18,40 +24,135 @@ type wrapListener struct {
 }
 
 func (l *wrapListener) Accept() (net.Conn, error) {
-	c, err := l.Listener.Accept()
+	c, err := l.acceptConn()
 	if err != nil {
 		return nil, err
 	}
+	return l.wrap(c), nil
+}
 
+// accept the next connection within Options.MaxNewConnsPerIPPerSec, the connections over the limit are closed at once
+func (l *wrapListener) acceptConn() (net.Conn, error) {
 	proxy := l.proxy
+	for {
+		c, err := l.Listener.Accept()
+		if err != nil || proxy.connLimiter == nil {
+			return c, err
+		}
+		ip := remoteIP(c.RemoteAddr())
+		if proxy.connLimiter.allow(ip, time.Now()) {
+			return c, nil
+		}
+		atomic.AddInt64(&proxy.counters.rejectedConns, 1)
+		if proxy.Opts.ConnRateLogOnly {
+			log.Warnf("new connections of %v exceed %v per second", ip, proxy.Opts.MaxNewConnsPerIPPerSec)
+			return c, nil
+		}
+		log.Debugf("new connections of %v exceed %v per second, closed", ip, proxy.Opts.MaxNewConnsPerIPPerSec)
+		c.Close()
+	}
+}
+
+func (l *wrapListener) wrap(c net.Conn) *wrapClientConn {
+	proxy := l.proxy
+	if proxy.Opts.ProxyTLSCert != nil {
+		nextProtos := []string{"http/1.1"}
+		if proxy.Opts.ProxyH2 {
+			nextProtos = []string{"h2", "http/1.1"}
+		}
+		clientAuth := proxy.Opts.ProxyClientAuth
+		if proxy.Opts.ProxyClientCAs != nil && clientAuth == tls.NoClientCert {
+			clientAuth = tls.RequireAndVerifyClientCert
+		}
+		// handshake lazily on first read, not to block accept
+		c = tls.Server(c, &tls.Config{
+			Certificates: []tls.Certificate{*proxy.Opts.ProxyTLSCert},
+			NextProtos:   nextProtos,
+			ClientCAs:    proxy.Opts.ProxyClientCAs,
+			ClientAuth:   clientAuth,
+		})
+	}
 	wc := newWrapClientConn(c, proxy)
 	connCtx := newConnContext(wc, proxy)
 	wc.connCtx = connCtx
 
-	for _, addon := range proxy.Addons {
+	for _, addon := range proxy.hooks.clientConnected {
 		addon.ClientConnected(connCtx.ClientConn)
 	}
 
-	return wc, nil
+	return wc
+}
+
+// accept connections in the background, each is passed to handle in its own goroutine not to block accept, such as
+// for the tls handshake or peeking the first bytes. handle hands the ones for http.Server to serve.
+type dispatchListener struct {
+	*wrapListener
+	handle   func(wc *wrapClientConn)
+	conns    chan net.Conn
+	done     chan struct{}
+	err      error
+	loopOnce sync.Once
+}
+
+func newDispatchListener(l *wrapListener, handle func(wc *wrapClientConn)) *dispatchListener {
+	return &dispatchListener{
+		wrapListener: l,
+		handle:       handle,
+		conns:        make(chan net.Conn),
+		done:         make(chan struct{}),
+	}
+}
+
+func (l *dispatchListener) Accept() (net.Conn, error) {
+	l.loopOnce.Do(func() {
+		go l.acceptLoop()
+	})
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.done:
+		return nil, l.err
+	}
+}
+
+func (l *dispatchListener) acceptLoop() {
+	for {
+		c, err := l.acceptConn()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				time.Sleep(5 * time.Millisecond)
+				continue
+			}
+			l.err = err
+			close(l.done)
+			return
+		}
+		go l.handle(l.wrap(c))
+	}
+}
+
+// hand wc to http.Server, it is closed if the listener is closed meanwhile
+func (l *dispatchListener) serve(wc *wrapClientConn) {
+	select {
+	case l.conns <- wc:
+	case <-l.done:
+		wc.Close()
+	}
 }
 
 // wrap tcpConn for remote client
 type wrapClientConn struct {
 	net.Conn
-	r         *bufio.Reader
-	proxy     *Proxy
-	connCtx   *ConnContext
-	closed    bool
-	closeErr  error
-	closeChan chan struct{}
+	r       *bufio.Reader
+	proxy   *Proxy
+	connCtx *ConnContext
 }
 
 func newWrapClientConn(c net.Conn, proxy *Proxy) *wrapClientConn {
 	return &wrapClientConn{
-		Conn:      c,
-		r:         bufio.NewReader(c),
-		proxy:     proxy,
-		closeChan: make(chan struct{}),
+		Conn:  c,
+		r:     bufio.NewReader(c),
+		proxy: proxy,
 	}
 }
 
@@ -60,58 +161,116 @@ func (c *wrapClientConn) Peek(n int) ([]byte, error) {
 }
 
 func (c *wrapClientConn) Read(data []byte) (int, error) {
-	return c.r.Read(data)
-}
-
-func (c *wrapClientConn) Close() error {
-	if c.closed {
-		return c.closeErr
+	n, err := c.r.Read(data)
+	if n > 0 {
+		c.connCtx.touch()
+		atomic.AddInt64(&c.connCtx.bytesRead, int64(n))
+		atomic.CompareAndSwapInt64(&c.connCtx.headerStart, 0, time.Now().UnixNano())
+		if fn := c.proxy.Opts.OnClientRawBytes; fn != nil {
+			fn(c.connCtx, data[:n], DirectionRead)
+		}
+		if c.tapped() {
+			c.proxy.Opts.OnClientBytes(c.connCtx, data[:n], DirectionRead)
+		}
 	}
-	log.Debugln("in wrapClientConn close", c.connCtx.ClientConn.Conn.RemoteAddr())
-
-	c.closed = true
-	c.closeErr = c.Conn.Close()
-	close(c.closeChan)
+	return n, err
+}
 
-	for _, addon := range c.proxy.Addons {
-		addon.ClientDisconnected(c.connCtx.ClientConn)
+func (c *wrapClientConn) Write(data []byte) (int, error) {
+	if len(data) > 0 {
+		c.connCtx.touch()
+		if fn := c.proxy.Opts.OnClientRawBytes; fn != nil {
+			fn(c.connCtx, data, DirectionWrite)
+		}
+		if c.tapped() {
+			c.proxy.Opts.OnClientBytes(c.connCtx, data, DirectionWrite)
+		}
 	}
+	n, err := c.connCtx.throttledWrite(c.Conn, data, false, c.connCtx.closeChan)
+	atomic.AddInt64(&c.connCtx.bytesWritten, int64(n))
+	return n, err
+}
 
-	if c.connCtx.ServerConn != nil && c.connCtx.ServerConn.Conn != nil {
-		c.connCtx.ServerConn.Conn.Close()
-	}
+// plain http proxy requests are tapped here, after CONNECT the tunnel is tapped above tls in attacker
+func (c *wrapClientConn) tapped() bool {
+	return c.proxy.Opts.OnClientBytes != nil && c.connCtx.connectHost == ""
+}
 
-	return c.closeErr
+func (c *wrapClientConn) Close() error {
+	return c.connCtx.close()
 }
 
 // wrap tcpConn for remote server
 type wrapServerConn struct {
 	net.Conn
-	proxy    *Proxy
-	connCtx  *ConnContext
-	closed   bool
-	closeErr error
+	proxy     *Proxy
+	connCtx   *ConnContext
+	closeOnce sync.Once
+	closeErr  error
+	closeChan chan struct{} // wake writers blocked by Throttle
+}
+
+func newWrapServerConn(c net.Conn, proxy *Proxy, connCtx *ConnContext) *wrapServerConn {
+	return &wrapServerConn{
+		Conn:      c,
+		proxy:     proxy,
+		connCtx:   connCtx,
+		closeChan: make(chan struct{}),
+	}
 }
 
+func (c *wrapServerConn) Read(data []byte) (int, error) {
+	n, err := c.Conn.Read(data)
+	if n > 0 {
+		c.connCtx.touch()
+		if fn := c.proxy.Opts.OnServerRawBytes; fn != nil {
+			fn(c.connCtx, data[:n], DirectionRead)
+		}
+	}
+	return n, err
+}
+
+func (c *wrapServerConn) Write(data []byte) (int, error) {
+	if len(data) > 0 {
+		c.connCtx.touch()
+		if fn := c.proxy.Opts.OnServerRawBytes; fn != nil {
+			fn(c.connCtx, data, DirectionWrite)
+		}
+	}
+	return c.connCtx.throttledWrite(c.Conn, data, true, c.closeChan)
+}
+
+// Close only the first call closes the connection and triggers the disconnect hooks.
+// When the client connection is also to be closed, it goes through ConnContext.close.
 func (c *wrapServerConn) Close() error {
-	if c.closed {
+	first := false
+	c.closeOnce.Do(func() {
+		first = true
+		c.closeErr = c.Conn.Close()
+		close(c.closeChan)
+	})
+	if !first {
 		return c.closeErr
 	}
 	log.Debugln("in wrapServerConn close", c.connCtx.ClientConn.Conn.RemoteAddr())
+	atomic.AddInt64(&c.proxy.counters.activeServerConns, -1)
+	// the transport closes the connection of the request cancelled by the client
+	if c.connCtx.markClientAborted() {
+		c.connCtx.setServerCloseReason(CloseReasonClientClosed)
+	}
+	c.connCtx.setServerCloseReason(c.connCtx.defaultCloseReason())
 
-	c.closed = true
-	c.closeErr = c.Conn.Close()
-
-	for _, addon := range c.proxy.Addons {
+	for _, addon := range c.proxy.hooks.serverDisconnected {
 		addon.ServerDisconnected(c.connCtx)
 	}
 
+	c.connCtx.setClientCloseReason(CloseReasonServerClosed)
 	if !c.connCtx.ClientConn.Tls {
-		c.connCtx.ClientConn.Conn.(*wrapClientConn).Conn.(*net.TCPConn).CloseRead()
+		closeRead(c.connCtx.ClientConn.Conn.(*wrapClientConn).Conn)
 	} else {
 		// if keep-alive connection close
 		if !c.connCtx.closeAfterResponse {
-			c.connCtx.ClientConn.Conn.Close()
+			c.connCtx.close()
 		}
 	}
 
@@ -121,58 +280,123 @@ func (c *wrapServerConn) Close() error {
 type entry struct {
 	proxy  *Proxy
 	server *http.Server
+
+	// h2 proxy connections, not tracked by server
+	h2Server  *http2.Server
+	h2Conns   map[*wrapClientConn]struct{}
+	h2ConnsMu sync.Mutex
+
+	listening int32 // set once the listener is ready, for /readyz of Options.AdminAddr
 }
 
 func newEntry(proxy *Proxy) *entry {
-	e := &entry{proxy: proxy}
+	e := &entry{
+		proxy:    proxy,
+		h2Server: &http2.Server{},
+		h2Conns:  make(map[*wrapClientConn]struct{}),
+	}
 	e.server = &http.Server{
 		Addr:    proxy.Opts.Addr,
 		Handler: e,
 		ConnContext: func(ctx context.Context, c net.Conn) context.Context {
-			return context.WithValue(ctx, connContextKey, c.(*wrapClientConn).connCtx)
+			return c.(*wrapClientConn).connCtx.serverContext(ctx)
 		},
 	}
+	// let server.Shutdown send GOAWAY to h2 proxy connections
+	if err := http2.ConfigureServer(e.server, e.h2Server); err != nil {
+		log.Warnf("configure h2 server: %v", err)
+	}
 	return e
 }
 
 func (e *entry) start() error {
-	addr := e.server.Addr
-	if addr == "" {
-		addr = ":http"
-	}
-	ln, err := net.Listen("tcp", addr)
-	if err != nil {
-		return err
+	ln := e.proxy.Opts.Listener
+	if ln == nil {
+		addr := e.server.Addr
+		if addr == "" {
+			addr = ":http"
+		}
+		lc := &net.ListenConfig{}
+		if e.proxy.Opts.ReusePort {
+			lc.Control = reusePortControl
+		}
+		var err error
+		ln, err = lc.Listen(context.Background(), "tcp", addr)
+		if err != nil {
+			return err
+		}
 	}
 
-	log.Infof("Proxy start listen at %v\n", e.server.Addr)
+	log.Infof("Proxy start listen at %v\n", ln.Addr())
+	atomic.StoreInt32(&e.listening, 1)
 	pln := &wrapListener{
 		Listener: ln,
 		proxy:    e.proxy,
 	}
+	if e.proxy.Opts.Transparent {
+		l := &transparentListener{entry: e}
+		l.dispatchListener = newDispatchListener(pln, l.dispatch)
+		return e.server.Serve(l)
+	}
+	if e.proxy.Opts.ProxyTLSCert != nil && e.proxy.Opts.ProxyH2 {
+		l := &h2Listener{entry: e}
+		l.dispatchListener = newDispatchListener(pln, l.handshake)
+		return e.server.Serve(l)
+	}
 	return e.server.Serve(pln)
 }
 
 func (e *entry) close() error {
-	return e.server.Close()
+	err := e.server.Close()
+	e.closeH2Conns()
+	return err
 }
 
+// h2 proxy connections are sent GOAWAY by server.Shutdown, waiting them is left to Proxy.ShutdownWithReport
 func (e *entry) shutdown(ctx context.Context) error {
 	return e.server.Shutdown(ctx)
 }
 
 func (e *entry) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	proxy := e.proxy
+	if proxy.blockMethod(res, req) || proxy.rejectLongLine(res, req) {
+		return
+	}
+
+	if proxy.isReverse() {
+		if req.Method == "CONNECT" {
+			res.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if !proxy.reverseRequest(req) {
+			log.Debugf("no reverse upstream of %v%v", req.Host, req.URL.Path)
+			res.WriteHeader(http.StatusNotFound)
+			return
+		}
+	}
 
 	// proxy via connect tunnel
 	if req.Method == "CONNECT" {
+		if req.ProtoMajor == 2 {
+			e.handleH2Connect(res, req)
+			return
+		}
 		e.handleConnect(res, req)
 		return
 	}
 
+	// request in origin-form of the redirected connection of Options.Transparent
+	if connCtx := req.Context().Value(connContextKey).(*ConnContext); connCtx.transparentAddr != "" && !req.URL.IsAbs() {
+		if req.Host == "" {
+			req.Host = connCtx.transparentAddr
+		}
+		req.URL.Scheme = "http"
+		req.URL.Host = req.Host
+	}
+
 	if !req.URL.IsAbs() || req.URL.Host == "" {
 		res = helper.NewResponseCheck(res)
-		for _, addon := range proxy.Addons {
+		for _, addon := range proxy.hooks.accessProxyServer {
 			addon.AccessProxyServer(req, res)
 		}
 		if res, ok := res.(*helper.ResponseCheck); ok {
@@ -185,7 +409,11 @@ func (e *entry) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	}
 
 	// http proxy
-	proxy.attacker.initHttpDialFn(req)
+	if proxy.attacker.pool != nil {
+		req = req.WithContext(context.WithValue(req.Context(), pooledReqKey, true))
+	} else {
+		proxy.attacker.initHttpDialFn(req)
+	}
 	proxy.attacker.attack(res, req)
 }
 
@@ -197,16 +425,71 @@ func (e *entry) handleConnect(res http.ResponseWriter, req *http.Request) {
 		"host": req.Host,
 	})
 
-	shouldIntercept := proxy.shouldIntercept == nil || proxy.shouldIntercept(req)
-	f := newFlow()
-	f.Request = newRequest(req)
+	f := proxy.newFlow()
+	f.setRequest(newRequest(req))
 	f.ConnContext = req.Context().Value(connContextKey).(*ConnContext)
+	f.ConnContext.addFlow(f)
+	defer proxy.finishFlow(f)
+	proxy.recordHeaderDuration(f, req)
+
+	if err := proxy.connect(f.ConnContext, req); err != nil {
+		log.Error(err)
+		f.Error = err
+		if req.ProtoMajor == 1 {
+			res.Header().Set("Connection", "close")
+		}
+		res.WriteHeader(http.StatusBadGateway)
+		return
+	}
+
+	shouldIntercept := proxy.shouldIntercept == nil || proxy.shouldIntercept(req)
+	if len(proxy.Opts.InterceptHosts) > 0 && !helper.MatchHost(req.Host, proxy.Opts.InterceptHosts) {
+		shouldIntercept = false
+	}
+	if proxy.interceptFilter != nil && !proxy.interceptFilter.match(req.Host) {
+		shouldIntercept = false
+	}
 	f.ConnContext.Intercept = shouldIntercept
-	defer f.finish()
+	f.ConnContext.connectHost = req.Host
+	proxy.setRouteLabel(f)
 
 	// trigger addon event Requestheaders
-	for _, addon := range proxy.Addons {
-		addon.Requestheaders(f)
+	for _, addon := range proxy.hooks.requestheaders {
+		f.timeAddon(func() { addon.Requestheaders(f) })
+	}
+	if proxy.addonFailed(f) {
+		if req.ProtoMajor == 1 {
+			res.Header().Set("Connection", "close")
+		}
+		res.WriteHeader(http.StatusBadGateway)
+		return
+	}
+
+	// refused by addons which set Flow.Response, such as addon.BlockList
+	if f.Response != nil {
+		if proxy.attacker.dryRunReply(f) {
+			f.Response = nil
+		} else {
+			e.refuseConnect(res, req, f)
+			return
+		}
+	}
+
+	if e.proxy.Opts.NoUpstream {
+		if !shouldIntercept {
+			res.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		// 不预先连接上游服务器
+		log.Debugf("begin intercept %v without upstream", req.Host)
+		e.httpsDialLazyAttack(res, req, f)
+		return
+	}
+
+	if f.ConnContext.RawTcp {
+		log.Debugf("begin raw tcp tunnel %v", req.Host)
+		e.directTransfer(res, req, f)
+		return
 	}
 
 	if !shouldIntercept {
@@ -215,7 +498,8 @@ func (e *entry) handleConnect(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	if f.ConnContext.ClientConn.UpstreamCert {
+	// the protocols of server are known only after it is connected
+	if f.ConnContext.ClientConn.UpstreamCert || proxy.Opts.ALPNPassthrough {
 		e.httpsDialFirstAttack(res, req, f)
 		return
 	}
@@ -224,16 +508,64 @@ func (e *entry) handleConnect(res http.ResponseWriter, req *http.Request) {
 	e.httpsDialLazyAttack(res, req, f)
 }
 
-func (e *entry) establishConnection(res http.ResponseWriter, f *Flow) (net.Conn, error) {
-	cconn, _, err := res.(http.Hijacker).Hijack()
-	if err != nil {
-		res.WriteHeader(502)
-		return nil, err
+// pass the ClientHello peeked from client to ClientHelloHandler of addons, return false if the connection is not
+// intercepted then
+func (e *entry) clientHello(cconn net.Conn, connCtx *ConnContext) bool {
+	hooks := e.proxy.hooks.clientHello
+	if len(hooks) == 0 {
+		return true
 	}
-	_, err = io.WriteString(cconn, "HTTP/1.1 200 Connection Established\r\n\r\n")
-	if err != nil {
-		cconn.Close()
-		return nil, err
+	if hello, err := peekClientHello(cconn.(*wrapClientConn)); err != nil {
+		log.Debugf("client %v: peek client hello: %v", connCtx.ClientConn.Conn.RemoteAddr(), err)
+	} else {
+		connCtx.ClientConn.TlsClientHello = hello
+		connCtx.ClientConn.Ja3 = hello.Ja3()
+	}
+	for _, addon := range hooks {
+		addon.ClientHello(connCtx)
+	}
+	return connCtx.Intercept
+}
+
+// reply the CONNECT with Flow.Response instead of establishing the tunnel, Response.BodyReader is not sent
+func (e *entry) refuseConnect(res http.ResponseWriter, req *http.Request, f *Flow) {
+	log.Debugf("connect %v refused with %v", req.Host, f.Response.StatusCode)
+	for key, values := range f.Response.Header {
+		res.Header()[key] = values
+	}
+	if req.ProtoMajor == 1 {
+		res.Header().Set("Connection", "close")
+	}
+	res.Header().Set("Content-Length", strconv.Itoa(len(f.Response.Body)))
+	res.WriteHeader(f.Response.StatusCode)
+	res.Write(f.Response.Body)
+}
+
+func (e *entry) establishConnection(res http.ResponseWriter, f *Flow) (net.Conn, error) {
+	var cconn net.Conn
+	wc := f.ConnContext.ClientConn.Conn.(*wrapClientConn)
+	if tres, ok := res.(*transparentResponseWriter); ok {
+		// redirected tls connection of Options.Transparent: no CONNECT to reply, the connection is the tunnel
+		tres.established = true
+		cconn = wc
+	} else if stream, ok := wc.Conn.(*h2StreamConn); ok {
+		// h2 CONNECT: the stream is the tunnel
+		res.WriteHeader(200)
+		stream.flusher.Flush()
+		stream.established = true
+		cconn = wc
+	} else {
+		var err error
+		cconn, _, err = res.(http.Hijacker).Hijack()
+		if err != nil {
+			res.WriteHeader(502)
+			return nil, err
+		}
+		_, err = io.WriteString(cconn, "HTTP/1.1 200 Connection Established\r\n\r\n")
+		if err != nil {
+			cconn.Close()
+			return nil, err
+		}
 	}
 
 	f.Response = &Response{
@@ -242,8 +574,8 @@ func (e *entry) establishConnection(res http.ResponseWriter, f *Flow) (net.Conn,
 	}
 
 	// trigger addon event Responseheaders
-	for _, addon := range e.proxy.Addons {
-		addon.Responseheaders(f)
+	for _, addon := range e.proxy.hooks.responseheaders {
+		f.timeAddon(func() { addon.Responseheaders(f) })
 	}
 
 	return cconn, nil
@@ -256,14 +588,34 @@ func (e *entry) directTransfer(res http.ResponseWriter, req *http.Request, f *Fl
 		"host": req.Host,
 	})
 
-	conn, err := proxy.getUpstreamConn(req.Context(), req)
+	start := time.Now()
+	conn, err := proxy.getUpstreamConn(req.Context(), req, req.Host)
 	if err != nil {
 		log.Error(err)
+		f.Error = err
 		res.WriteHeader(502)
 		return
 	}
+	connect := time.Since(start)
+	f.ConnContext.setTimings(func(t *ConnTimings) { t.UpstreamConnect = connect })
 	defer conn.Close()
 
+	// not a wrapServerConn, the tunnel is not counted in ProxyStats.ActiveServerConns
+	serverConn := newServerConn(proxy.newId())
+	serverConn.Address = req.Host
+	serverConn.Conn = conn
+	f.ConnContext.ServerConn = serverConn
+	for _, addon := range proxy.hooks.serverConnected {
+		addon.ServerConnected(f.ConnContext)
+	}
+	serverDisconnected := sync.OnceFunc(func() {
+		f.ConnContext.setServerCloseReason(f.ConnContext.defaultCloseReason())
+		for _, addon := range proxy.hooks.serverDisconnected {
+			addon.ServerDisconnected(f.ConnContext)
+		}
+	})
+	defer serverDisconnected()
+
 	cconn, err := e.establishConnection(res, f)
 	if err != nil {
 		log.Error(err)
@@ -271,7 +623,15 @@ func (e *entry) directTransfer(res http.ResponseWriter, req *http.Request, f *Fl
 	}
 	defer cconn.Close()
 
-	transfer(log, conn, cconn)
+	if !f.ConnContext.RawTcp {
+		transfer(log, &throttledConn{Conn: conn, connCtx: f.ConnContext}, cconn)
+		return
+	}
+	// the side ended first is reported first
+	if proxy.tcpTunnel(log, f.ConnContext, &throttledConn{Conn: conn, connCtx: f.ConnContext}, cconn) {
+		f.ConnContext.setClientCloseReason(CloseReasonServerClosed)
+		serverDisconnected()
+	}
 }
 
 func (e *entry) httpsDialFirstAttack(res http.ResponseWriter, req *http.Request, f *Flow) {
@@ -284,6 +644,7 @@ func (e *entry) httpsDialFirstAttack(res http.ResponseWriter, req *http.Request,
 	conn, err := proxy.attacker.httpsDial(req.Context(), req)
 	if err != nil {
 		log.Error(err)
+		f.Error = err
 		res.WriteHeader(502)
 		return
 	}
@@ -294,6 +655,7 @@ func (e *entry) httpsDialFirstAttack(res http.ResponseWriter, req *http.Request,
 		log.Error(err)
 		return
 	}
+	proxy.setHandshakeDeadline(cconn)
 
 	peek, err := cconn.(*wrapClientConn).Peek(3)
 	if err != nil {
@@ -303,6 +665,7 @@ func (e *entry) httpsDialFirstAttack(res http.ResponseWriter, req *http.Request,
 		return
 	}
 	if !helper.IsTls(peek) {
+		proxy.clearHandshakeDeadline(cconn)
 		// todo: http, ws
 		transfer(log, conn, cconn)
 		cconn.Close()
@@ -310,9 +673,18 @@ func (e *entry) httpsDialFirstAttack(res http.ResponseWriter, req *http.Request,
 		return
 	}
 
+	if !e.clientHello(cconn, f.ConnContext) {
+		proxy.clearHandshakeDeadline(cconn)
+		log.Debugf("begin transpond %v by ClientHello", req.Host)
+		transfer(log, conn, cconn)
+		cconn.Close()
+		conn.Close()
+		return
+	}
+
 	// is tls
 	f.ConnContext.ClientConn.Tls = true
-	proxy.attacker.httpsTlsDial(req.Context(), cconn, conn)
+	proxy.attacker.httpsTlsDial(req.Context(), cconn, conn, req)
 }
 
 func (e *entry) httpsDialLazyAttack(res http.ResponseWriter, req *http.Request, f *Flow) {
@@ -327,6 +699,7 @@ func (e *entry) httpsDialLazyAttack(res http.ResponseWriter, req *http.Request,
 		log.Error(err)
 		return
 	}
+	proxy.setHandshakeDeadline(cconn)
 
 	peek, err := cconn.(*wrapClientConn).Peek(3)
 	if err != nil {
@@ -335,7 +708,13 @@ func (e *entry) httpsDialLazyAttack(res http.ResponseWriter, req *http.Request,
 		return
 	}
 
-	if !helper.IsTls(peek) {
+	intercept := helper.IsTls(peek) && e.clientHello(cconn, f.ConnContext)
+	if !intercept {
+		proxy.clearHandshakeDeadline(cconn)
+		if proxy.Opts.NoUpstream {
+			cconn.Close()
+			return
+		}
 		// todo: http, ws
 		conn, err := proxy.attacker.httpsDial(req.Context(), req)
 		if err != nil {
@@ -0,0 +1,220 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lqqyt2423/go-mitmproxy/cert"
+)
+
+// refuse the CONNECT of blocked.com
+type testRefuseConnectAddon struct {
+	BaseAddon
+}
+
+func (addon *testRefuseConnectAddon) Requestheaders(f *Flow) {
+	if f.Request.Method == "CONNECT" && f.Request.URL.Hostname() == "blocked.com" {
+		f.Response = &Response{StatusCode: 403, Body: []byte("blocked")}
+	}
+}
+
+func TestRefuseConnect(t *testing.T) {
+	flows := make(chan *Flow, 10)
+	helper := &testPipeHelper{opts: &Options{OnFlowComplete: func(f *Flow) { flows <- f }}}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.AddAddon(&testRefuseConnectAddon{})
+
+	_, err := helper.getProxyClient().Get("https://blocked.com/")
+	if err == nil || !strings.Contains(err.Error(), "Forbidden") {
+		t.Fatalf("expected connect refused, but got %v", err)
+	}
+	f := <-flows
+	if f.Request.Method != "CONNECT" || f.Response == nil || f.Response.StatusCode != 403 {
+		t.Fatalf("expected refused connect flow completed with its response, but got %v %v", f.Request.Method, f.Response)
+	}
+
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+}
+
+func TestRapidConnectDisconnect(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testDisconnectCountAddon{}
+	helper.testProxy.AddHooks(addon)
+
+	base := runtime.NumGoroutine()
+	n := 200
+	for i := 0; i < n; i++ {
+		conn, err := helper.dial(context.Background(), "tcp", "proxy.pipe")
+		handleError(t, err)
+		if i%2 == 0 {
+			// close before any request
+			conn.Close()
+			continue
+		}
+		_, err = io.WriteString(conn, "CONNECT example.com:443 HTTP/1.1\r\nHost: example.com:443\r\n\r\n")
+		handleError(t, err)
+		resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+		handleError(t, err)
+		resp.Body.Close()
+		conn.Close()
+	}
+
+	deadline := time.Now().Add(time.Second * 2)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt32(&addon.client) == int32(n) && runtime.NumGoroutine() <= base+5 {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	if c := atomic.LoadInt32(&addon.client); c != int32(n) {
+		t.Fatalf("expected %v ClientDisconnected, but got %v", n, c)
+	}
+	if g := runtime.NumGoroutine(); g > base+5 {
+		t.Fatalf("goroutine leak: %v before, %v after", base, g)
+	}
+}
+
+func TestProxyTLSCert(t *testing.T) {
+	proxyCA, err := cert.NewCAMemory()
+	handleError(t, err)
+	c, err := proxyCA.GetCert("proxy.pipe")
+	handleError(t, err)
+
+	helper := &testPipeHelper{opts: &Options{ProxyTLSCert: c}}
+	helper.init(t)
+	defer helper.close()
+
+	// trust the proxy server cert and the mitm certs
+	pool := x509.NewCertPool()
+	pool.AddCert(&proxyCA.RootCert)
+	rootCert := helper.testProxy.GetCertificate()
+	pool.AddCert(&rootCert)
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: helper.dial,
+			TLSClientConfig: &tls.Config{
+				RootCAs: pool,
+			},
+			Proxy: func(r *http.Request) (*url.URL, error) {
+				return url.Parse("https://proxy.pipe")
+			},
+		},
+	}
+	testSendRequest(t, "http://example.com/", client, "ok")
+	testSendRequest(t, "https://example.com/", client, "ok")
+
+	// plain http proxy request is refused
+	_, err = helper.getProxyClient().Get("http://example.com/")
+	if err == nil {
+		t.Fatal("expected error using plain http proxy")
+	}
+}
+
+func TestProxyClientCAs(t *testing.T) {
+	proxyCA, err := cert.NewCAMemory()
+	handleError(t, err)
+	c, err := proxyCA.GetCert("proxy.pipe")
+	handleError(t, err)
+
+	clientCA, err := cert.NewCAMemory()
+	handleError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(&clientCA.RootCert)
+
+	helper := &testPipeHelper{opts: &Options{ProxyTLSCert: c, ProxyClientCAs: clientCAs}}
+	helper.init(t)
+	defer helper.close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(&proxyCA.RootCert)
+	rootCert := helper.testProxy.GetCertificate()
+	pool.AddCert(&rootCert)
+	newClient := func(certs []tls.Certificate) *http.Client {
+		return &http.Client{
+			Transport: &http.Transport{
+				DialContext: helper.dial,
+				TLSClientConfig: &tls.Config{
+					RootCAs:      pool,
+					Certificates: certs,
+				},
+				Proxy: func(r *http.Request) (*url.URL, error) {
+					return url.Parse("https://proxy.pipe")
+				},
+			},
+		}
+	}
+
+	t.Run("authorized", func(t *testing.T) {
+		clientCert, err := clientCA.GetCert("alice")
+		handleError(t, err)
+		client := newClient([]tls.Certificate{*clientCert})
+		testSendRequest(t, "http://example.com/", client, "ok")
+		testSendRequest(t, "https://example.com/", client, "ok")
+	})
+
+	t.Run("no client cert", func(t *testing.T) {
+		_, err := newClient(nil).Get("http://example.com/")
+		if err == nil {
+			t.Fatal("expected error without client cert")
+		}
+	})
+
+	t.Run("untrusted client cert", func(t *testing.T) {
+		otherCA, err := cert.NewCAMemory()
+		handleError(t, err)
+		clientCert, err := otherCA.GetCert("mallory")
+		handleError(t, err)
+		_, err = newClient([]tls.Certificate{*clientCert}).Get("http://example.com/")
+		if err == nil {
+			t.Fatal("expected error with untrusted client cert")
+		}
+	})
+}
+
+type testInterceptHostsAddon struct {
+	BaseAddon
+	mu    sync.Mutex
+	hosts []string
+}
+
+func (addon *testInterceptHostsAddon) Requestheaders(f *Flow) {
+	if f.Request.Method == "CONNECT" {
+		return
+	}
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.hosts = append(addon.hosts, f.Request.URL.Hostname())
+}
+
+func TestInterceptHosts(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{InterceptHosts: []string{"*.myapi.com"}}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testInterceptHostsAddon{}
+	helper.testProxy.AddAddon(addon)
+
+	proxyClient := helper.getProxyClient()
+	testSendRequest(t, "https://api.myapi.com/", proxyClient, "ok")
+	testSendRequest(t, "https://example.com/", proxyClient, "ok")
+	testSendRequest(t, "http://example.com/", proxyClient, "ok")
+
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	if strings.Join(addon.hosts, ",") != "api.myapi.com,example.com" {
+		t.Fatalf("expected only api.myapi.com and plain http intercepted, got %v", addon.hosts)
+	}
+}
@@ -1,13 +1,13 @@
 package proxy
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
 	"net/url"
-
-	uuid "github.com/satori/go.uuid"
+	"time"
 )
 
 // flow http request
@@ -16,7 +16,14 @@ type Request struct {
 	URL    *url.URL
 	Proto  string
 	Header http.Header
-	Body   []byte
+	Body   []byte // forwarded to server, reading it in addons does not consume it
+
+	StartAt time.Time // time when the request is received, in UTC
+
+	// the client connection served requests before this one, by keep-alive or concurrent h2 streams
+	ConnReused bool
+	// requests served by the client connection so far including this one, ConnContext.FlowCount when it is received
+	ConnRequestCount uint32
 
 	raw *http.Request
 }
@@ -35,12 +42,57 @@ func (r *Request) Raw() *http.Request {
 	return r.raw
 }
 
+// SetQueryParam set the query parameter key of the request url to value, replace the existing values.
+// The query is encoded again, sorted by key.
+func (r *Request) SetQueryParam(key, value string) {
+	query := r.URL.Query()
+	query.Set(key, value)
+	r.URL.RawQuery = query.Encode()
+}
+
+// DelQueryParam delete the query parameter key of the request url, the query is encoded again if key exists
+func (r *Request) DelQueryParam(key string) {
+	query := r.URL.Query()
+	if !query.Has(key) {
+		return
+	}
+	query.Del(key)
+	r.URL.RawQuery = query.Encode()
+}
+
+// copy of the request, URL, header and body are copied
+func (r *Request) snapshot() *Request {
+	return r.snapshotWith(r.Header.Clone())
+}
+
+// copy of the request with the copied header
+func (r *Request) snapshotWith(header http.Header) *Request {
+	u := *r.URL
+	return &Request{
+		Method:  r.Method,
+		URL:     &u,
+		Proto:   r.Proto,
+		Header:  header,
+		Body:    bytes.Clone(r.Body),
+		StartAt: r.StartAt,
+		raw:     r.raw,
+
+		ConnReused:       r.ConnReused,
+		ConnRequestCount: r.ConnRequestCount,
+	}
+}
+
 func (req *Request) MarshalJSON() ([]byte, error) {
 	r := make(map[string]interface{})
 	r["method"] = req.Method
 	r["url"] = req.URL.String()
 	r["proto"] = req.Proto
 	r["header"] = req.Header
+	r["startAt"] = req.StartAt
+	if req.ConnRequestCount > 0 {
+		r["connReused"] = req.ConnReused
+		r["connRequestCount"] = req.ConnRequestCount
+	}
 	return json.Marshal(r)
 }
 
@@ -94,44 +146,174 @@ func (req *Request) UnmarshalJSON(data []byte) error {
 
 // flow http response
 type Response struct {
+	// may be changed by addons in Responseheaders or Response, the body is kept
+	// the status line sent to client has the standard reason phrase of it, RawStatusLine is not rewritten
 	StatusCode int         `json:"statusCode"`
 	Header     http.Header `json:"header"`
 	Body       []byte      `json:"-"`
 	BodyReader io.Reader
+	EndAt      time.Time `json:"endAt"` // time when the response is finished, in UTC
+
+	// body as received from server, still encoded by Content-Encoding, while DecodedBody returns the decoded one
+	// it shares the bytes of Body until Body is replaced by the decoded body, for Options.PreserveEncoding or ReplaceToDecodedBody
+	// nil in Stream mode
+	RawBody []byte `json:"-"`
+
+	// trailers sent by server after the body, sent to client after the body too
+	// in Stream mode, it is set after the body is forwarded
+	Trailer http.Header `json:"trailer,omitempty"`
+
+	// status line of the response as sent by server, such as "HTTP/1.1 200 OK\r\n" with the line ending
+	// it keeps the status and reason of server when StatusCode is changed by addons
+	// nil for http2 and the requests sent by the separate client
+	RawStatusLine []byte `json:"-"`
+
+	// set by addons to delay writing the response to client, such as in Response to simulate latency
+	Delay time.Duration `json:"-"`
 
 	close bool // connection close
 
 	decodedBody []byte
 	decoded     bool // decoded reports whether the response was sent compressed but was decoded to decodedBody.
 	decodedErr  error
+	// Options.MaxDecompressedSize of the proxy received the response, 0 means no limit
+	maxDecodedSize int64
+
+	preservedEncoding string // Content-Encoding removed for Options.PreserveEncoding
+}
+
+// copy of the response, header and body are copied, BodyReader is not kept
+func (r *Response) snapshot() *Response {
+	return r.snapshotWith(r.Header.Clone())
+}
+
+// copy of the response with the copied header
+func (r *Response) snapshotWith(header http.Header) *Response {
+	return &Response{
+		StatusCode:    r.StatusCode,
+		Header:        header,
+		Body:          bytes.Clone(r.Body),
+		Trailer:       r.Trailer.Clone(),
+		close:         r.close,
+		RawStatusLine: r.RawStatusLine,
+
+		maxDecodedSize: r.maxDecodedSize,
+	}
+}
+
+// FlowTimings latency of each phase of a flow.
+// The phases of the connection are reported by the first flow after it is established or the server is dialed again,
+// they are 0 for the flows reusing it.
+type FlowTimings struct {
+	DNS             time.Duration // resolve the host of server, 0 for an ip or Options.DialContext not using net.Resolver
+	Connect         time.Duration // dial to server, include DNS and the CONNECT to upstream proxy
+	ClientHandshake time.Duration // tls handshake with client
+	ServerHandshake time.Duration // tls handshake with server, done lazily by the first request without ClientConn.UpstreamCert
+	FirstByte       time.Duration // from sending the request to server until its response headers are received
+	Total           time.Duration // Flow.Duration, set when the flow finishes such as in Options.OnFlowComplete
 }
 
 // flow
 type Flow struct {
-	Id          uuid.UUID
+	Id          string
 	ConnContext *ConnContext
 	Request     *Request
 	Response    *Response
 
+	// Request and Response as received, before addons modify them
+	// Body is nil in Stream mode
+	OriginalRequest  *Request
+	OriginalResponse *Response
+
+	// set when the flow does not complete normally, Response may be nil then
+	Error error
+
+	// set by addons failing to handle the flow in Requestheaders, Request, Responseheaders or Response, such as an auth addon
+	// which can not reach its server. The flow is blocked or forwarded by Options.AddonErrorPolicy after the hook returns.
+	AddonError error
+
+	// from the first byte of the request received to the full request headers received, 0 for http2
+	HeaderDuration time.Duration
+
 	// https://docs.mitmproxy.org/stable/overview-features/#streaming
 	// 如果为 true，则不缓冲 Request.Body 和 Response.Body，且不进入之后的 Addon.Request 和 Addon.Response
-	Stream            bool
+	Stream bool
+	// Request.Body or Response.Body is truncated to Options.MaxBodySize for addons, the full body is forwarded as is
+	// addons must not assume they have the full content, changes to the truncated body are ignored
+	BodyTruncated     bool
 	UseSeparateClient bool // use separate http client to send http request
+	Replay            bool // the flow is sent by Proxy.Replay, not received from client
 	done              chan struct{}
+
+	// snapshot of the ClientHello of the intercepted tls client connection, nil for plain http
+	// Proxy.ReplayWithOptions can send the request with these parameters
+	ClientTLS *TlsClientHello
+
+	// latency of each phase, set before Responseheaders except Total
+	Timings FlowTimings
+
+	// time spent in the hooks of addons called with the flow, such as Requestheaders and Response, which the flow waits for.
+	// The body chunk and WebSocketMessage hooks running as the bodies stream are not counted. Complete by OnFlowComplete.
+	AddonTime time.Duration
+
+	// low cardinality route of the request by Options.MetricsRouteLabel, such as /users/{id}, empty if not set
+	RouteLabel string
+
+	// keep monotonic clock readings to compute duration
+	startTime time.Time
+	endTime   time.Time
+
+	upstreamURL   *url.URL // set before the request is sent to upstream
+	bufferedBytes int64    // counted for Options.MaxTotalBufferedBytes
 }
 
-func newFlow() *Flow {
+func newFlow(id string) *Flow {
 	return &Flow{
-		Id:   uuid.NewV4(),
-		done: make(chan struct{}),
+		Id:        id,
+		done:      make(chan struct{}),
+		startTime: time.Now(),
 	}
 }
 
+// set Request and record its start time
+func (f *Flow) setRequest(req *Request) {
+	f.Request = req
+	f.Request.StartAt = f.startTime.UTC()
+}
+
 func (f *Flow) Done() <-chan struct{} {
 	return f.done
 }
 
+// Duration of the flow, from request received to response finished, measured by monotonic clock.
+// Returns the duration until now if the flow is not finished.
+// EffectiveUpstreamURL the url actually requested upstream, after addons modified the request and the host is rewritten by
+// UpstreamRewriter and Options.HostMap. The host is "unix:<path>" for unix sockets.
+// nil if the request is not sent to upstream, such as the response is set by addons.
+func (f *Flow) EffectiveUpstreamURL() *url.URL {
+	return f.upstreamURL
+}
+
+func (f *Flow) Duration() time.Duration {
+	if f.endTime.IsZero() {
+		return time.Since(f.startTime)
+	}
+	return f.endTime.Sub(f.startTime)
+}
+
+// call a hook of addons and count its time in AddonTime
+func (f *Flow) timeAddon(call func()) {
+	start := time.Now()
+	call()
+	f.AddonTime += time.Since(start)
+}
+
 func (f *Flow) finish() {
+	f.endTime = time.Now()
+	f.Timings.Total = f.endTime.Sub(f.startTime)
+	if f.Response != nil {
+		f.Response.EndAt = f.endTime.UTC()
+	}
 	close(f.done)
 }
 
@@ -140,5 +322,11 @@ func (f *Flow) MarshalJSON() ([]byte, error) {
 	j["id"] = f.Id
 	j["request"] = f.Request
 	j["response"] = f.Response
+	if f.Replay {
+		j["replay"] = true
+	}
+	if f.BodyTruncated {
+		j["bodyTruncated"] = true
+	}
 	return json.Marshal(j)
 }
@@ -0,0 +1,218 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testFlowTimeAddon struct {
+	BaseAddon
+	flows chan *Flow
+}
+
+func (addon *testFlowTimeAddon) Requestheaders(f *Flow) {
+	go func() {
+		<-f.Done()
+		addon.flows <- f
+	}()
+}
+
+func TestFlowTimestamps(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testFlowTimeAddon{flows: make(chan *Flow, 1)}
+	helper.testProxy.AddAddon(addon)
+
+	before := time.Now()
+	testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+	f := <-addon.flows
+	after := time.Now()
+
+	if f.Request.StartAt.Location() != time.UTC || f.Response.EndAt.Location() != time.UTC {
+		t.Fatal("expected timestamps in UTC")
+	}
+	if f.Request.StartAt.Before(before.Round(0)) || f.Response.EndAt.After(after.Round(0)) {
+		t.Fatalf("timestamps %v - %v out of range %v - %v", f.Request.StartAt, f.Response.EndAt, before, after)
+	}
+	if f.Response.EndAt.Before(f.Request.StartAt) {
+		t.Fatal("expected EndAt after StartAt")
+	}
+	if d := f.Duration(); d <= 0 || d > after.Sub(before) {
+		t.Fatalf("unexpected duration %v", d)
+	}
+}
+
+type testAddonTimeAddon struct {
+	BaseAddon
+}
+
+func (addon *testAddonTimeAddon) Requestheaders(f *Flow) {
+	time.Sleep(30 * time.Millisecond)
+}
+
+func (addon *testAddonTimeAddon) Response(f *Flow) {
+	time.Sleep(20 * time.Millisecond)
+}
+
+func TestFlowAddonTime(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	flows := make(chan *Flow, 4)
+	helper.testProxy.Opts.OnFlowComplete = func(f *Flow) {
+		if f.Request.Method != "CONNECT" {
+			flows <- f
+		}
+	}
+
+	testSendRequest(t, "http://example.com/slow", helper.getProxyClient(), "ok")
+	if f := <-flows; f.AddonTime >= 50*time.Millisecond {
+		t.Fatalf("expected the upstream latency not counted, got %v", f.AddonTime)
+	}
+
+	helper.testProxy.AddAddon(&testAddonTimeAddon{})
+	for _, url := range []string{"http://example.com/slow", "https://example.com/slow"} {
+		testSendRequest(t, url, helper.getProxyClient(), "ok")
+		f := <-flows
+		if f.AddonTime < 50*time.Millisecond || f.AddonTime >= f.Duration()-50*time.Millisecond {
+			t.Fatalf("%v: expected 50ms in addons of %v, got %v", url, f.Duration(), f.AddonTime)
+		}
+	}
+}
+
+type testFlowTimingsAddon struct {
+	BaseAddon
+	timings chan FlowTimings
+}
+
+func (addon *testFlowTimingsAddon) Response(f *Flow) {
+	addon.timings <- f.Timings
+}
+
+func TestFlowTimings(t *testing.T) {
+	flows := make(chan *Flow, 10)
+	helper := &testPipeHelper{opts: &Options{OnFlowComplete: func(f *Flow) { flows <- f }}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testFlowTimingsAddon{timings: make(chan FlowTimings, 2)}
+	helper.testProxy.AddAddon(addon)
+
+	// the server is dialed lazily by the first request
+	helper.testProxy.AddAddon(NewUpstreamCertAddon(false))
+	proxyClient := helper.getProxyClient()
+	testSendRequest(t, "https://example.com/", proxyClient, "ok")
+	testSendRequest(t, "https://example.com/", proxyClient, "ok")
+
+	first, second := <-addon.timings, <-addon.timings
+	if first.Connect <= 0 || first.ClientHandshake <= 0 || first.ServerHandshake <= 0 || first.FirstByte <= 0 {
+		t.Fatalf("expected all phases of the first flow: %+v", first)
+	}
+	if second.Connect != 0 || second.ClientHandshake != 0 || second.ServerHandshake != 0 || second.FirstByte <= 0 {
+		t.Fatalf("expected only FirstByte of the flow reusing the connection: %+v", second)
+	}
+	<-flows // CONNECT
+	if f := <-flows; f.Timings.Total <= 0 || f.Timings.Total != f.Duration() {
+		t.Fatalf("expected Total of the finished flow: %+v", f.Timings)
+	}
+
+	// dns by net.Resolver
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	handleError(t, err)
+	defer ln.Close()
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+	connCtx := &ConnContext{}
+	c, err := (&net.Dialer{}).DialContext(connCtx.traceDial(context.Background()), "tcp", net.JoinHostPort("localhost", port))
+	handleError(t, err)
+	c.Close()
+	if connCtx.Timings().UpstreamDNS <= 0 {
+		t.Fatal("expected UpstreamDNS recorded")
+	}
+}
+
+type testReadBodyAddon struct {
+	names chan string
+}
+
+func (addon *testReadBodyAddon) Request(f *Flow) {
+	var v struct{ Name string }
+	if err := json.NewDecoder(f.Request.Raw().Body).Decode(&v); err != nil {
+		addon.names <- err.Error()
+		return
+	}
+	if err := json.Unmarshal(f.Request.Body, &v); err != nil {
+		addon.names <- err.Error()
+		return
+	}
+	addon.names <- v.Name
+}
+
+func TestAddonReadRequestBody(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testReadBodyAddon{names: make(chan string, 1)}
+	helper.testProxy.AddHooks(addon)
+
+	body := `{"name":"go-mitmproxy"}`
+	resp, err := helper.getProxyClient().Post("http://example.com/echo", "application/json", strings.NewReader(body))
+	handleError(t, err)
+	defer resp.Body.Close()
+	got, err := io.ReadAll(resp.Body)
+	handleError(t, err)
+	if name := <-addon.names; name != "go-mitmproxy" {
+		t.Fatalf("expected addon parsed name, but got %v", name)
+	}
+	if string(got) != body {
+		t.Fatalf("expected server received %v, but got %v", body, string(got))
+	}
+}
+
+type testModifyAddon struct {
+	BaseAddon
+}
+
+func (addon *testModifyAddon) Request(f *Flow) {
+	f.Request.Header.Set("X-Modified", "1")
+	f.Request.Body = []byte("modified") // same length as the original
+}
+
+func (addon *testModifyAddon) Response(f *Flow) {
+	f.Response.StatusCode = 201
+	f.Response.Body = append(f.Response.Body, "!"...)
+	f.Response.Header.Del("Content-Length")
+}
+
+func TestOriginalRequestResponse(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.AddAddon(&testModifyAddon{})
+	addon := &testFlowTimeAddon{flows: make(chan *Flow, 1)}
+	helper.testProxy.AddAddon(addon)
+
+	resp, err := helper.getProxyClient().Post("http://example.com/echo", "text/plain", strings.NewReader("original"))
+	handleError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	handleError(t, err)
+	if string(body) != "modified!" {
+		t.Fatalf("expected modified!, but got %v", string(body))
+	}
+
+	f := <-addon.flows
+	if f.OriginalRequest.Header.Get("X-Modified") != "" || string(f.OriginalRequest.Body) != "original" {
+		t.Fatalf("unexpected original request: %v %s", f.OriginalRequest.Header, f.OriginalRequest.Body)
+	}
+	if f.Request.Header.Get("X-Modified") != "1" {
+		t.Fatal("expected modified request header")
+	}
+	if f.OriginalResponse.StatusCode != 200 || string(f.OriginalResponse.Body) != "modified" {
+		t.Fatalf("unexpected original response: %v %s", f.OriginalResponse.StatusCode, f.OriginalResponse.Body)
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"compress/flate"
 	"compress/gzip"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -35,6 +38,19 @@ func (r *Response) IsTextContentType() bool {
 	return false
 }
 
+// ContentSniffer detects the content type of the body for Response.DetectedContentType, can be replaced by a better one.
+var ContentSniffer func(body []byte) string = http.DetectContentType
+
+// DetectedContentType return the content type sniffed from the decoded body, the Content-Type header is not used.
+// Return "" if the body is not buffered, such as in Stream mode, or can not be decoded.
+func (r *Response) DetectedContentType() string {
+	body, err := r.DecodedBody()
+	if err != nil || body == nil {
+		return ""
+	}
+	return ContentSniffer(body)
+}
+
 func (r *Response) DecodedBody() ([]byte, error) {
 	if r.decodedBody != nil {
 		return r.decodedBody, nil
@@ -59,10 +75,21 @@ func (r *Response) DecodedBody() ([]byte, error) {
 		return r.decodedBody, nil
 	}
 
-	decodedBody, decodedErr := decode(enc, r.Body)
+	if r.IsPartial() {
+		if cr := r.ContentRange(); cr == nil || !cr.Complete() {
+			r.decodedErr = ErrPartialContent
+			return nil, r.decodedErr
+		}
+	}
+
+	decodedBody, decodedErr := decodeLimit(enc, r.Body, r.maxDecodedSize)
 	if decodedErr != nil {
 		r.decodedErr = decodedErr
-		log.Error(r.decodedErr)
+		if errors.Is(decodedErr, ErrDecodedBodyTooLarge) {
+			log.Warn(r.decodedErr)
+		} else {
+			log.Error(r.decodedErr)
+		}
 		return nil, decodedErr
 	}
 
@@ -77,56 +104,134 @@ func (r *Response) ReplaceToDecodedBody() {
 		return
 	}
 
+	r.keepRawBody()
 	r.Body = body
 	r.Header.Del("Content-Encoding")
 	r.Header.Set("Content-Length", strconv.Itoa(len(body)))
 	r.Header.Del("Transfer-Encoding")
 }
 
+// keep the encoded body in RawBody before Body is replaced by the decoded one, for the responses set by addons
+func (r *Response) keepRawBody() {
+	if r.RawBody == nil {
+		r.RawBody = r.Body
+	}
+}
+
+// encodings can be decoded and encoded again for Options.PreserveEncoding
+var preservableEncodings = []string{"gzip", "deflate", "br", "zstd"}
+
+// Options.PreserveEncoding, replace the body with the decoded one before the Response addons, a body failed to decode is kept as is
+func (r *Response) decodeForAddons() {
+	enc := r.Header.Get("Content-Encoding")
+	if len(r.Body) == 0 || !slices.Contains(preservableEncodings, enc) {
+		return
+	}
+	body, err := r.DecodedBody()
+	if err != nil {
+		return
+	}
+	r.keepRawBody()
+	r.Body = body
+	r.Header.Del("Content-Encoding")
+	r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	r.preservedEncoding = enc
+	// the body may be modified by addons
+	r.decodedBody = nil
+	r.decoded = false
+}
+
+// encode the body again after the Response addons, unless the addons set Content-Encoding
+func (r *Response) encodePreserved() {
+	enc := r.preservedEncoding
+	r.preservedEncoding = ""
+	if enc == "" || r.Header.Get("Content-Encoding") != "" || len(r.Body) == 0 {
+		return
+	}
+	body, err := encode(enc, r.Body)
+	if err != nil {
+		log.Errorf("encode response body with %v: %v", enc, err)
+		r.Header.Set("Content-Length", strconv.Itoa(len(r.Body)))
+		return
+	}
+	r.Body = body
+	r.Header.Set("Content-Encoding", enc)
+	r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+}
+
+func encode(enc string, body []byte) ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, len(body)/2))
+	var w io.WriteCloser
+	var err error
+	switch enc {
+	case "gzip":
+		w = gzip.NewWriter(buf)
+	case "br":
+		w = brotli.NewWriter(buf)
+	case "deflate":
+		w, err = flate.NewWriter(buf, flate.DefaultCompression)
+	case "zstd":
+		w, err = zstd.NewWriter(buf)
+	default:
+		return nil, errEncodingNotSupport
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(body); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func decode(enc string, body []byte) ([]byte, error) {
-	if enc == "gzip" {
-		dreader, err := gzip.NewReader(bytes.NewReader(body))
-		if err != nil {
-			return nil, err
-		}
-		buf := bytes.NewBuffer(make([]byte, 0))
-		_, err = io.Copy(buf, dreader)
-		if err != nil {
-			return nil, err
-		}
-		err = dreader.Close()
-		if err != nil {
-			return nil, err
-		}
-		return buf.Bytes(), nil
-	} else if enc == "br" {
-		dreader := brotli.NewReader(bytes.NewReader(body))
-		buf := bytes.NewBuffer(make([]byte, 0))
-		_, err := io.Copy(buf, dreader)
-		if err != nil {
-			return nil, err
-		}
-		return buf.Bytes(), nil
-	} else if enc == "deflate" {
-		dreader := flate.NewReader(bytes.NewReader(body))
-		buf := bytes.NewBuffer(make([]byte, 0))
-		_, err := io.Copy(buf, dreader)
-		if err != nil {
-			return nil, err
-		}
-		return buf.Bytes(), nil
-	} else if enc == "zstd" {
-		dreader, err := zstd.NewReader(bytes.NewReader(body))
+	return decodeLimit(enc, body, 0)
+}
+
+// ErrDecodedBodyTooLarge returned by Response.DecodedBody when the decoded body exceeds Options.MaxDecompressedSize,
+// the body is kept undecoded and forwarded as is
+var ErrDecodedBodyTooLarge = errors.New("decoded body too large")
+
+// decode body, at most limit bytes are decoded if limit > 0
+func decodeLimit(enc string, body []byte, limit int64) ([]byte, error) {
+	var dreader io.Reader
+	switch enc {
+	case "gzip":
+		gr, err := gzip.NewReader(bytes.NewReader(body))
 		if err != nil {
 			return nil, err
 		}
-		buf := bytes.NewBuffer(make([]byte, 0))
-		_, err = io.Copy(buf, dreader)
+		defer gr.Close()
+		dreader = gr
+	case "br":
+		dreader = brotli.NewReader(bytes.NewReader(body))
+	case "deflate":
+		fr := flate.NewReader(bytes.NewReader(body))
+		defer fr.Close()
+		dreader = fr
+	case "zstd":
+		zr, err := zstd.NewReader(bytes.NewReader(body))
 		if err != nil {
 			return nil, err
 		}
-		return buf.Bytes(), nil
+		defer zr.Close()
+		dreader = zr
+	default:
+		return nil, errEncodingNotSupport
 	}
 
-	return nil, errEncodingNotSupport
+	if limit > 0 {
+		dreader = io.LimitReader(dreader, limit+1)
+	}
+	buf := bytes.NewBuffer(make([]byte, 0))
+	if _, err := io.Copy(buf, dreader); err != nil {
+		return nil, err
+	}
+	if limit > 0 && int64(buf.Len()) > limit {
+		return nil, fmt.Errorf("%w: more than %v bytes of %v", ErrDecodedBodyTooLarge, limit, enc)
+	}
+	return buf.Bytes(), nil
 }
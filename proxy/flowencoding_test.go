@@ -0,0 +1,162 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestDetectedContentType(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte("<!DOCTYPE html><html></html>"))
+	zw.Close()
+	res := &Response{
+		Header: http.Header{"Content-Type": {"application/octet-stream"}, "Content-Encoding": {"gzip"}},
+		Body:   buf.Bytes(),
+	}
+	if ct := res.DetectedContentType(); ct != "text/html; charset=utf-8" {
+		t.Fatalf("expected html detected, got %v", ct)
+	}
+	if ct := (&Response{Header: http.Header{}}).DetectedContentType(); ct != "" {
+		t.Fatalf("expected empty for no body, got %v", ct)
+	}
+}
+
+// /encoded?enc=gzip replies the body encoded by enc, with the Accept-Encoding of request in X-Accept-Encoding
+var testEncodedHandlers = map[string]http.HandlerFunc{
+	"/encoded": func(w http.ResponseWriter, r *http.Request) {
+		enc := r.URL.Query().Get("enc")
+		body := []byte("encoded body")
+		if encoded, err := encode(enc, body); err == nil {
+			body = encoded
+		}
+		w.Header().Set("Content-Encoding", enc)
+		w.Header().Set("X-Accept-Encoding", r.Header.Get("Accept-Encoding"))
+		w.Write(body)
+	},
+}
+
+type testPreserveEncodingAddon struct {
+	BaseAddon
+	bodies chan string
+	raws   chan []byte
+}
+
+func (addon *testPreserveEncodingAddon) Response(f *Flow) {
+	addon.bodies <- f.Response.Header.Get("Content-Encoding") + ":" + string(f.Response.Body)
+	addon.raws <- f.Response.RawBody
+	if f.Response.Header.Get("Content-Encoding") == "" {
+		f.Response.Body = append(f.Response.Body, '!')
+	}
+}
+
+func TestPreserveEncoding(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{PreserveEncoding: true}, handlers: testEncodedHandlers}
+	helper.init(t)
+	defer helper.close()
+	addon := &testPreserveEncodingAddon{bodies: make(chan string, 1), raws: make(chan []byte, 1)}
+	helper.testProxy.AddAddon(addon)
+
+	get := func(t *testing.T, enc string) (*http.Response, []byte) {
+		client := helper.getProxyClient()
+		client.Transport.(*http.Transport).DisableCompression = true
+		req, err := http.NewRequest("GET", "https://example.com/encoded?enc="+enc, nil)
+		handleError(t, err)
+		req.Header.Set("Accept-Encoding", enc)
+		resp, err := client.Do(req)
+		handleError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		handleError(t, err)
+		if resp.Header.Get("X-Accept-Encoding") != enc {
+			t.Fatalf("expected Accept-Encoding of client sent to server, got %q", resp.Header.Get("X-Accept-Encoding"))
+		}
+		if resp.Header.Get("Content-Encoding") != enc {
+			t.Fatalf("expected Content-Encoding %v, got %q", enc, resp.Header.Get("Content-Encoding"))
+		}
+		return resp, body
+	}
+
+	for _, enc := range []string{"gzip", "deflate", "br", "zstd"} {
+		t.Run(enc, func(t *testing.T) {
+			_, body := get(t, enc)
+			if seen := <-addon.bodies; seen != ":encoded body" {
+				t.Fatalf("expected addon to see decoded body, got %q", seen)
+			}
+			raw, err := decode(enc, <-addon.raws)
+			handleError(t, err)
+			if string(raw) != "encoded body" {
+				t.Fatalf("expected RawBody as received from server, got %q", raw)
+			}
+			decoded, err := decode(enc, body)
+			handleError(t, err)
+			if string(decoded) != "encoded body!" {
+				t.Fatalf("expected modified body encoded again, got %q", decoded)
+			}
+		})
+	}
+
+	t.Run("unknown encoding", func(t *testing.T) {
+		_, body := get(t, "compress")
+		if seen := <-addon.bodies; seen != "compress:encoded body" {
+			t.Fatalf("expected addon to see the body as is, got %q", seen)
+		}
+		if raw := <-addon.raws; string(raw) != "encoded body" {
+			t.Fatalf("expected RawBody same as Body, got %q", raw)
+		}
+		if string(body) != "encoded body" {
+			t.Fatalf("expected body untouched, got %q", body)
+		}
+	})
+}
+
+type testMaxDecompressedAddon struct {
+	BaseAddon
+	errs chan error
+}
+
+func (addon *testMaxDecompressedAddon) Response(f *Flow) {
+	_, err := f.Response.DecodedBody()
+	addon.errs <- err
+	f.Response.ReplaceToDecodedBody()
+}
+
+func TestMaxDecompressedSize(t *testing.T) {
+	bomb, err := encode("gzip", make([]byte, 64<<20))
+	handleError(t, err)
+	if _, err := decodeLimit("gzip", bomb, 1<<20); !errors.Is(err, ErrDecodedBodyTooLarge) {
+		t.Fatalf("expected ErrDecodedBodyTooLarge, got %v", err)
+	}
+	if decoded, err := decodeLimit("gzip", bomb, 64<<20); err != nil || len(decoded) != 64<<20 {
+		t.Fatalf("expected decoded within limit, got %v %v", len(decoded), err)
+	}
+
+	helper := &testPipeHelper{opts: &Options{MaxDecompressedSize: 5, PreserveEncoding: true}, handlers: testEncodedHandlers}
+	helper.init(t)
+	defer helper.close()
+	addon := &testMaxDecompressedAddon{errs: make(chan error, 1)}
+	helper.testProxy.AddAddon(addon)
+
+	client := helper.getProxyClient()
+	client.Transport.(*http.Transport).DisableCompression = true
+	resp, err := client.Get("https://example.com/encoded?enc=gzip")
+	handleError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	handleError(t, err)
+	if err := <-addon.errs; !errors.Is(err, ErrDecodedBodyTooLarge) {
+		t.Fatalf("expected ErrDecodedBodyTooLarge in addon, got %v", err)
+	}
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected the compressed body forwarded, got Content-Encoding %q", resp.Header.Get("Content-Encoding"))
+	}
+	decoded, err := decode("gzip", body)
+	handleError(t, err)
+	if string(decoded) != "encoded body" {
+		t.Fatalf("expected the original body, got %q", decoded)
+	}
+}
@@ -0,0 +1,37 @@
+package proxy
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// IsGrpc reports whether the response is of grpc, by the content type
+func (r *Response) IsGrpc() bool {
+	contentType := r.Header.Get("Content-Type")
+	return contentType == "application/grpc" || strings.HasPrefix(contentType, "application/grpc+") || strings.HasPrefix(contentType, "application/grpc;")
+}
+
+// GrpcStatus return the grpc-status and the decoded grpc-message of the response.
+// They are in the trailers, or in the headers of a trailers-only response. ok is false if no grpc-status found.
+func (r *Response) GrpcStatus() (code int, message string, ok bool) {
+	for _, h := range []http.Header{r.Trailer, r.Header} {
+		values, found := h["Grpc-Status"]
+		if !found || len(values) == 0 {
+			continue
+		}
+		code, err := strconv.Atoi(strings.TrimSpace(values[0]))
+		if err != nil {
+			return 0, "", false
+		}
+		if msgs := h["Grpc-Message"]; len(msgs) > 0 {
+			// percent-encoded
+			if message, err = url.PathUnescape(msgs[0]); err != nil {
+				message = msgs[0]
+			}
+		}
+		return code, message, true
+	}
+	return 0, "", false
+}
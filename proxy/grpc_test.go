@@ -0,0 +1,42 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"testing"
+)
+
+// reply grpc status 5 in the trailers
+func testGrpcHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+	w.Write([]byte("grpc"))
+	w.Header().Set("Grpc-Status", "5")
+	w.Header().Set("Grpc-Message", "user%20not%20found")
+}
+
+func TestGrpcStatus(t *testing.T) {
+	helper := &testPipeHelper{handlers: map[string]http.HandlerFunc{"/grpc": testGrpcHandler}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testFlowTimeAddon{flows: make(chan *Flow, 2)}
+	helper.testProxy.AddAddon(addon)
+
+	resp, err := helper.getProxyClient().Get("https://example.com/grpc")
+	handleError(t, err)
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.Trailer.Get("Grpc-Status") != "5" {
+		t.Fatalf("expected trailer forwarded to client, got %v", resp.Trailer)
+	}
+
+	<-addon.flows // CONNECT
+	f := <-addon.flows
+	if !f.Response.IsGrpc() {
+		t.Fatal("expected grpc response")
+	}
+	code, message, ok := f.Response.GrpcStatus()
+	if !ok || code != 5 || message != "user not found" {
+		t.Fatalf("unexpected grpc status: %v %q %v", code, message, ok)
+	}
+}
@@ -0,0 +1,139 @@
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/http2"
+)
+
+// With Options.ProxyH2, the tls handshake of the proxy connection is done before handing it to http.Server,
+// because http.Server only negotiates h2 on *tls.Conn. h2 connections are served here, the others go to http.Server.
+type h2Listener struct {
+	*dispatchListener
+	entry *entry
+}
+
+func (l *h2Listener) handshake(wc *wrapClientConn) {
+	tlsConn := wc.Conn.(*tls.Conn)
+	l.entry.proxy.setHandshakeDeadline(wc)
+	if err := tlsConn.Handshake(); err != nil {
+		wc.connCtx.setCloseReason(CloseReasonClientTlsError)
+		wc.Close()
+		log.Debugf("proxy tls handshake error: %v", err)
+		return
+	}
+	l.entry.proxy.clearHandshakeDeadline(wc)
+
+	if tlsConn.ConnectionState().NegotiatedProtocol != "h2" {
+		l.serve(wc)
+		return
+	}
+
+	l.entry.trackH2Conn(wc, true)
+	defer l.entry.trackH2Conn(wc, false)
+	ctx := context.WithValue(wc.connCtx.ctx, connContextKey, wc.connCtx)
+	l.entry.h2Server.ServeConn(wc, &http2.ServeConnOpts{
+		Context:    ctx,
+		Handler:    l.entry,
+		BaseConfig: l.entry.server,
+	})
+	wc.Close()
+}
+
+func (e *entry) trackH2Conn(wc *wrapClientConn, add bool) {
+	e.h2ConnsMu.Lock()
+	defer e.h2ConnsMu.Unlock()
+	if add {
+		e.h2Conns[wc] = struct{}{}
+	} else {
+		delete(e.h2Conns, wc)
+	}
+}
+
+func (e *entry) closeH2Conns() {
+	e.h2ConnsMu.Lock()
+	defer e.h2ConnsMu.Unlock()
+	for wc := range e.h2Conns {
+		wc.Close()
+	}
+}
+
+// CONNECT over h2 proxy connection: each stream is a tunnel with its own ConnContext
+func (e *entry) handleH2Connect(res http.ResponseWriter, req *http.Request) {
+	proxy := e.proxy
+	parent := req.Context().Value(connContextKey).(*ConnContext)
+
+	stream := &h2StreamConn{
+		body:    req.Body,
+		w:       res,
+		flusher: res.(http.Flusher),
+		local:   parent.ClientConn.Conn.LocalAddr(),
+		remote:  parent.ClientConn.Conn.RemoteAddr(),
+	}
+	wc := newWrapClientConn(stream, proxy)
+	connCtx := newConnContext(wc, proxy)
+	wc.connCtx = connCtx
+	for _, addon := range proxy.hooks.clientConnected {
+		addon.ClientConnected(connCtx.ClientConn)
+	}
+
+	e.handleConnect(res, req.WithContext(context.WithValue(req.Context(), connContextKey, connCtx)))
+	if !stream.established {
+		wc.Close()
+	}
+
+	// the stream ends when handler returns, wait the tunnel to be closed
+	<-connCtx.closeChan
+	stream.mu.Lock()
+	stream.finished = true
+	stream.mu.Unlock()
+}
+
+// tunnel of h2 CONNECT stream, read from request body and write to response
+type h2StreamConn struct {
+	body    io.ReadCloser
+	w       io.Writer
+	flusher http.Flusher
+	local   net.Addr
+	remote  net.Addr
+
+	established bool // response 200 is sent
+
+	mu       sync.Mutex
+	finished bool // handler returned, must not write anymore
+}
+
+func (c *h2StreamConn) Read(b []byte) (int, error) {
+	return c.body.Read(b)
+}
+
+func (c *h2StreamConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.finished {
+		return 0, net.ErrClosed
+	}
+	n, err := c.w.Write(b)
+	if err != nil {
+		return n, err
+	}
+	c.flusher.Flush()
+	return n, nil
+}
+
+func (c *h2StreamConn) Close() error {
+	return c.body.Close()
+}
+
+func (c *h2StreamConn) LocalAddr() net.Addr                { return c.local }
+func (c *h2StreamConn) RemoteAddr() net.Addr               { return c.remote }
+func (c *h2StreamConn) SetDeadline(t time.Time) error      { return nil }
+func (c *h2StreamConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *h2StreamConn) SetWriteDeadline(t time.Time) error { return nil }
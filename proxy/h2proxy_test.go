@@ -0,0 +1,88 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/lqqyt2423/go-mitmproxy/cert"
+	"golang.org/x/net/http2"
+)
+
+func TestProxyH2(t *testing.T) {
+	proxyCA, err := cert.NewCAMemory()
+	handleError(t, err)
+	c, err := proxyCA.GetCert("proxy.pipe")
+	handleError(t, err)
+
+	helper := &testPipeHelper{opts: &Options{ProxyTLSCert: c, ProxyH2: true}}
+	helper.init(t)
+	defer helper.close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(&proxyCA.RootCert)
+	pool.AddCert(&helper.serverCA.RootCert)
+	rootCert := helper.testProxy.GetCertificate()
+	pool.AddCert(&rootCert)
+
+	var dials int32
+	tr := &http2.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: pool},
+		DialTLSContext: func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
+			atomic.AddInt32(&dials, 1)
+			c, err := helper.dial(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+			return tls.Client(c, cfg), nil
+		},
+	}
+	defer tr.CloseIdleConnections()
+
+	connect := func(host string) net.Conn {
+		pr, pw := io.Pipe()
+		req := &http.Request{
+			Method: "CONNECT",
+			URL:    &url.URL{Scheme: "https", Host: "proxy.pipe:443"},
+			Host:   host,
+			Header: make(http.Header),
+			Body:   pr,
+		}
+		resp, err := tr.RoundTrip(req)
+		handleError(t, err)
+		if resp.StatusCode != 200 {
+			t.Fatalf("expected CONNECT 200, but got %v", resp.StatusCode)
+		}
+		return &testH2Conn{Reader: resp.Body, Writer: pw}
+	}
+
+	get := func(conn io.ReadWriter, expected string) {
+		t.Helper()
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Host = "example.com"
+		handleError(t, req.Write(conn))
+		resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+		handleError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		handleError(t, err)
+		if string(body) != expected {
+			t.Fatalf("expected %v, but got %s", expected, body)
+		}
+	}
+
+	// two tunnels share one h2 connection
+	get(tls.Client(connect("example.com:443"), &tls.Config{ServerName: "example.com", RootCAs: pool}), "ok")
+	get(connect("example.com:80"), "ok")
+
+	if n := atomic.LoadInt32(&dials); n != 1 {
+		t.Fatalf("expected 1 proxy connection, but got %v", n)
+	}
+}
@@ -1,8 +1,10 @@
 package proxy
 
 import (
+	"errors"
 	"io"
 	"net"
+	"net/http"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -19,6 +21,13 @@ var normalErrMsgs []string = []string{
 	"use of closed network connection",
 }
 
+var errUpstreamClosed = errors.New("upstream closed connection before sending response")
+
+// 上游服务器接受连接后，在返回任何响应之前关闭了连接
+func isUpstreamClosedErr(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || strings.Contains(err.Error(), "server closed idle connection")
+}
+
 // 仅打印预料之外的错误信息
 func logErr(log *log.Entry, err error) (loged bool) {
 	msg := err.Error()
@@ -35,6 +44,59 @@ func logErr(log *log.Entry, err error) (loged bool) {
 	return
 }
 
+// 逐跳（hop-by-hop）首部只对单个连接有效，不转发，RFC 7230 6.1
+var hopHeaders = []string{
+	"Connection",
+	"Proxy-Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// 删除逐跳首部及 Connection 中列出的首部，websocket 的 Upgrade 和 gRPC 需要的 TE: trailers 保留
+func removeHopHeaders(header http.Header) {
+	upgrade := isWebSocketUpgrade(header)
+	upgradeValue := header.Get("Upgrade")
+	teTrailers := headerContainsToken(header, "Te", "trailers")
+	for _, v := range header.Values("Connection") {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				header.Del(s)
+			}
+		}
+	}
+	for _, key := range hopHeaders {
+		header.Del(key)
+	}
+	if upgrade {
+		header.Set("Connection", "Upgrade")
+		header.Set("Upgrade", upgradeValue)
+	}
+	if teTrailers {
+		header.Set("Te", "trailers")
+	}
+}
+
+// 响应的 Content-Length 是否为其 body 的长度，HEAD 请求和 304 的 Content-Length 为资源的长度，1xx 和 204 没有 body
+func hasContentLength(method string, statusCode int) bool {
+	if method == http.MethodHead || statusCode < 200 || statusCode == http.StatusNoContent || statusCode == http.StatusNotModified {
+		return false
+	}
+	return true
+}
+
+// 关闭连接的读端，如 *net.TCPConn；不支持半关闭的连接（如 net.Pipe）则忽略
+func closeRead(conn net.Conn) error {
+	if c, ok := conn.(interface{ CloseRead() error }); ok {
+		return c.CloseRead()
+	}
+	return nil
+}
+
 // 转发流量
 func transfer(log *log.Entry, server, client io.ReadWriteCloser) {
 	done := make(chan struct{})
@@ -58,8 +120,8 @@ func transfer(log *log.Entry, server, client io.ReadWriteCloser) {
 		server.Close()
 
 		if clientConn, ok := client.(*wrapClientConn); ok {
-			err := clientConn.Conn.(*net.TCPConn).CloseRead()
-			log.Debugln("clientConn.Conn.(*net.TCPConn).CloseRead()", err)
+			err := closeRead(clientConn.Conn)
+			log.Debugln("closeRead(clientConn.Conn)", err)
 		}
 
 		select {
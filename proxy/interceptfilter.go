@@ -0,0 +1,58 @@
+package proxy
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// InterceptFilter decide which CONNECT targets are intercepted, the others are tunneled without tls handshake with client.
+// Regexps are matched against the target url such as "https://api.example.com:443", so "^https://api\.example\.com:" selects a host.
+type InterceptFilter struct {
+	Include []string // the target must match one of them if not empty
+	Exclude []string // the target matching one of them is not intercepted, checked after Include
+}
+
+type interceptFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+func newInterceptFilter(filter *InterceptFilter) (*interceptFilter, error) {
+	compile := func(exprs []string) ([]*regexp.Regexp, error) {
+		var res []*regexp.Regexp
+		for _, expr := range exprs {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("intercept filter: %w", err)
+			}
+			res = append(res, re)
+		}
+		return res, nil
+	}
+	include, err := compile(filter.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := compile(filter.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	return &interceptFilter{include: include, exclude: exclude}, nil
+}
+
+// host is host:port of the CONNECT request
+func (filter *interceptFilter) match(host string) bool {
+	target := "https://" + host
+	matchAny := func(res []*regexp.Regexp) bool {
+		for _, re := range res {
+			if re.MatchString(target) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(filter.include) > 0 && !matchAny(filter.include) {
+		return false
+	}
+	return !matchAny(filter.exclude)
+}
@@ -0,0 +1,56 @@
+package proxy
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+type testInterceptFilterAddon struct {
+	BaseAddon
+	mu        sync.Mutex
+	issued    []string
+	connected []string
+}
+
+func (addon *testInterceptFilterAddon) CertIssued(connCtx *ConnContext, serverName string, cached bool) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.issued = append(addon.issued, serverName)
+}
+
+func (addon *testInterceptFilterAddon) ServerConnected(connCtx *ConnContext) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.connected = append(addon.connected, fmt.Sprintf("%v %v", connCtx.ServerConn.Address, connCtx.Intercept))
+}
+
+func TestInterceptFilter(t *testing.T) {
+	if _, err := NewProxy(&Options{InterceptFilter: &InterceptFilter{Include: []string{"("}}}); err == nil {
+		t.Fatal("expected error of invalid regexp")
+	}
+
+	helper := &testPipeHelper{opts: &Options{InterceptFilter: &InterceptFilter{
+		Include: []string{`example\.com:`},
+		Exclude: []string{`^https://skip\.example\.com:`},
+	}}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testInterceptFilterAddon{}
+	helper.testProxy.AddAddon(addon)
+
+	for _, host := range []string{"example.com", "skip.example.com", "other.com"} {
+		testSendRequest(t, "https://"+host+"/", helper.getProxyClient(), "ok")
+	}
+
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	if !slices.Equal(addon.issued, []string{"example.com"}) {
+		t.Fatalf("expected cert only issued for example.com, got %v", addon.issued)
+	}
+	want := []string{"example.com:443 true", "skip.example.com:443 false", "other.com:443 false"}
+	if !slices.Equal(addon.connected, want) {
+		t.Fatalf("expected ServerConnected %v, got %v", want, addon.connected)
+	}
+}
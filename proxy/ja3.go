@@ -0,0 +1,293 @@
+package proxy
+
+import (
+	"crypto/md5"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// TlsClientHello fields of the ClientHello sent by client, in the order of the wire.
+// tls.ClientHelloInfo does not expose the extensions, so the ClientHello is parsed from the bytes read.
+type TlsClientHello struct {
+	Version           uint16 // legacy_version, 0x0303 for TLS 1.2 and 1.3
+	ServerName        string // empty when client does not send SNI
+	CipherSuites      []uint16
+	Extensions        []uint16
+	SupportedCurves   []uint16
+	SupportedPoints   []uint8
+	SupportedVersions []uint16
+	ALPN              []string
+}
+
+// Ja3String the JA3 fingerprint before hashing, GREASE values are skipped
+// "SSLVersion,Ciphers,Extensions,EllipticCurves,EllipticCurvePointFormats"
+func (hello *TlsClientHello) Ja3String() string {
+	join := func(values []uint16) string {
+		s := make([]string, 0, len(values))
+		for _, v := range values {
+			if !isGrease(v) {
+				s = append(s, strconv.Itoa(int(v)))
+			}
+		}
+		return strings.Join(s, "-")
+	}
+	points := make([]uint16, 0, len(hello.SupportedPoints))
+	for _, p := range hello.SupportedPoints {
+		points = append(points, uint16(p))
+	}
+	return strconv.Itoa(int(hello.Version)) + "," + join(hello.CipherSuites) + "," + join(hello.Extensions) + "," + join(hello.SupportedCurves) + "," + join(points)
+}
+
+// Ja3 md5 of Ja3String in hex
+func (hello *TlsClientHello) Ja3() string {
+	sum := md5.Sum([]byte(hello.Ja3String()))
+	return hex.EncodeToString(sum[:])
+}
+
+func (hello *TlsClientHello) clone() *TlsClientHello {
+	c := *hello
+	c.CipherSuites = slices.Clone(hello.CipherSuites)
+	c.Extensions = slices.Clone(hello.Extensions)
+	c.SupportedCurves = slices.Clone(hello.SupportedCurves)
+	c.SupportedPoints = slices.Clone(hello.SupportedPoints)
+	c.SupportedVersions = slices.Clone(hello.SupportedVersions)
+	c.ALPN = slices.Clone(hello.ALPN)
+	return &c
+}
+
+// set the parameters of the ClientHello supported by crypto/tls to cfg.
+// Extension order and GREASE can not be mimicked, TLS 1.3 cipher suites are not configurable.
+func (hello *TlsClientHello) applyTo(cfg *tls.Config) {
+	var versions []uint16
+	for _, v := range hello.SupportedVersions {
+		if v >= tls.VersionTLS10 && v <= tls.VersionTLS13 {
+			versions = append(versions, v)
+		}
+	}
+	if len(versions) == 0 && hello.Version >= tls.VersionTLS10 && hello.Version <= tls.VersionTLS12 {
+		versions = append(versions, hello.Version)
+	}
+	if len(versions) > 0 {
+		cfg.MinVersion = slices.Min(versions)
+		cfg.MaxVersion = slices.Max(versions)
+	}
+
+	known := make(map[uint16]bool)
+	for _, s := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		if !slices.Contains(s.SupportedVersions, tls.VersionTLS13) {
+			known[s.ID] = true
+		}
+	}
+	cfg.CipherSuites = nil
+	for _, id := range hello.CipherSuites {
+		if known[id] {
+			cfg.CipherSuites = append(cfg.CipherSuites, id)
+		}
+	}
+
+	cfg.CurvePreferences = nil
+	for _, id := range hello.SupportedCurves {
+		switch curve := tls.CurveID(id); curve {
+		case tls.X25519, tls.CurveP256, tls.CurveP384, tls.CurveP521:
+			cfg.CurvePreferences = append(cfg.CurvePreferences, curve)
+		}
+	}
+
+	cfg.NextProtos = slices.Clone(hello.ALPN)
+	if hello.ServerName != "" {
+		cfg.ServerName = hello.ServerName
+	}
+}
+
+// RFC 8701
+func isGrease(v uint16) bool {
+	return v&0x0f0f == 0x0a0a && v>>8 == v&0xff
+}
+
+// record the bytes read from client until the ClientHello is parsed
+type clientHelloConn struct {
+	net.Conn
+	recording bool
+	buf       []byte
+}
+
+func newClientHelloConn(c net.Conn) *clientHelloConn {
+	return &clientHelloConn{Conn: c, recording: true}
+}
+
+func (c *clientHelloConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	// called in the handshake goroutine, as GetConfigForClient
+	if c.recording && n > 0 {
+		c.buf = append(c.buf, b[:n]...)
+	}
+	return n, err
+}
+
+// parse the ClientHello and stop recording, called in GetConfigForClient when the ClientHello is fully read
+func (c *clientHelloConn) clientHello() (*TlsClientHello, error) {
+	c.recording = false
+	buf := c.buf
+	c.buf = nil
+	return parseClientHello(buf)
+}
+
+// set ClientConn.TlsClientHello and ClientConn.Ja3
+func recordClientHello(connCtx *ConnContext, c *clientHelloConn) {
+	hello, err := c.clientHello()
+	if err != nil {
+		log.Debugf("client %v: %v", connCtx.ClientConn.Conn.RemoteAddr(), err)
+		return
+	}
+	connCtx.ClientConn.TlsClientHello = hello
+	connCtx.ClientConn.Ja3 = hello.Ja3()
+}
+
+var errInvalidClientHello = errors.New("invalid client hello")
+
+// peek the ClientHello of the client without consuming it, it must be in a single record which fits in the read buffer
+func peekClientHello(c *wrapClientConn) (*TlsClientHello, error) {
+	header, err := c.Peek(5)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.Peek(5 + int(binary.BigEndian.Uint16(header[3:5])))
+	if err != nil {
+		return nil, err
+	}
+	return parseClientHello(data)
+}
+
+// parse the ClientHello from tls records, it may span several records
+func parseClientHello(data []byte) (*TlsClientHello, error) {
+	var msg []byte
+	for len(data) >= 5 {
+		if data[0] != 22 { // handshake
+			return nil, errInvalidClientHello
+		}
+		n := int(binary.BigEndian.Uint16(data[3:5]))
+		if len(data) < 5+n {
+			break
+		}
+		msg = append(msg, data[5:5+n]...)
+		data = data[5+n:]
+	}
+	if len(msg) < 4 || msg[0] != 1 { // client_hello
+		return nil, errInvalidClientHello
+	}
+	n := int(msg[1])<<16 | int(msg[2])<<8 | int(msg[3])
+	if len(msg) < 4+n {
+		return nil, errInvalidClientHello
+	}
+	r := &helloReader{b: msg[4 : 4+n]}
+
+	hello := &TlsClientHello{Version: r.uint16()}
+	r.skip(32) // random
+	r.bytes(int(r.uint8()))
+	suites := r.reader(int(r.uint16()))
+	for !suites.empty() {
+		hello.CipherSuites = append(hello.CipherSuites, suites.uint16())
+	}
+	r.bytes(int(r.uint8())) // compression methods
+
+	if !r.empty() {
+		exts := r.reader(int(r.uint16()))
+		for !exts.empty() {
+			typ := exts.uint16()
+			ext := exts.reader(int(exts.uint16()))
+			hello.Extensions = append(hello.Extensions, typ)
+			switch typ {
+			case 0: // server_name
+				list := ext.reader(int(ext.uint16()))
+				for !list.empty() {
+					nameType := list.uint8()
+					name := list.bytes(int(list.uint16()))
+					if nameType == 0 {
+						hello.ServerName = string(name)
+					}
+				}
+			case 10: // supported_groups
+				list := ext.reader(int(ext.uint16()))
+				for !list.empty() {
+					hello.SupportedCurves = append(hello.SupportedCurves, list.uint16())
+				}
+			case 11: // ec_point_formats
+				hello.SupportedPoints = append(hello.SupportedPoints, ext.bytes(int(ext.uint8()))...)
+			case 16: // application_layer_protocol_negotiation
+				list := ext.reader(int(ext.uint16()))
+				for !list.empty() {
+					hello.ALPN = append(hello.ALPN, string(list.bytes(int(list.uint8()))))
+				}
+			case 43: // supported_versions
+				list := ext.reader(int(ext.uint8()))
+				for !list.empty() {
+					hello.SupportedVersions = append(hello.SupportedVersions, list.uint16())
+				}
+			}
+			if ext.err {
+				r.err = true
+			}
+		}
+		if exts.err {
+			r.err = true
+		}
+	}
+	if r.err || suites.err {
+		return nil, errInvalidClientHello
+	}
+	return hello, nil
+}
+
+// reader of the big endian fields, err is set when reading out of bounds
+type helloReader struct {
+	b   []byte
+	err bool
+}
+
+func (r *helloReader) empty() bool {
+	return len(r.b) == 0 || r.err
+}
+
+func (r *helloReader) bytes(n int) []byte {
+	if len(r.b) < n {
+		r.err = true
+		r.b = nil
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *helloReader) skip(n int) {
+	r.bytes(n)
+}
+
+func (r *helloReader) uint8() uint8 {
+	b := r.bytes(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *helloReader) uint16() uint16 {
+	b := r.bytes(2)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint16(b)
+}
+
+func (r *helloReader) reader(n int) *helloReader {
+	b := r.bytes(n)
+	return &helloReader{b: b, err: r.err}
+}
@@ -0,0 +1,21 @@
+package proxy
+
+import (
+	"testing"
+)
+
+func TestParseClientHelloGrease(t *testing.T) {
+	hello := &TlsClientHello{
+		Version:         0x0303,
+		CipherSuites:    []uint16{0x0a0a, 4865, 4866},
+		Extensions:      []uint16{0x1a1a, 0, 10, 11},
+		SupportedCurves: []uint16{0x2a2a, 29, 23},
+		SupportedPoints: []uint8{0},
+	}
+	if s := hello.Ja3String(); s != "771,4865-4866,0-10-11,29-23,0" {
+		t.Fatalf("unexpected ja3 string: %v", s)
+	}
+	if _, err := parseClientHello([]byte{23, 3, 3, 0, 1, 0}); err == nil {
+		t.Fatal("expected error of non handshake record")
+	}
+}
@@ -0,0 +1,142 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrQueueFull the request is rejected because the upstream queue of the host is full, see Options.QueueSize
+var ErrQueueFull = errors.New("upstream queue is full")
+
+// ErrQueueTimeout the request is rejected because it waits in the upstream queue longer than Options.QueueTimeout
+var ErrQueueTimeout = errors.New("upstream queue wait timeout")
+
+// QueueStats state of the per-host upstream queue
+type QueueStats struct {
+	Active   int    // requests holding a slot
+	Waiting  int    // requests waiting in queue
+	Rejected uint64 // requests rejected because of full queue or wait timeout, since the host has requests in progress
+}
+
+type hostQueue struct {
+	slots    chan struct{}
+	refs     int // requests holding or waiting for a slot, guarded by hostLimiter.mu, evicted when 0
+	waiting  int32
+	rejected uint64
+}
+
+// limit concurrent upstream requests per host, with a bounded wait queue in front
+type hostLimiter struct {
+	limit     int
+	queueSize int
+	timeout   time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*hostQueue // only the hosts with requests in progress
+
+	// totals of all hosts, for ProxyStats
+	active   int64
+	waiting  int64
+	rejected uint64
+}
+
+func newHostLimiter(limit int, queueSize int, timeout time.Duration) *hostLimiter {
+	return &hostLimiter{
+		limit:     limit,
+		queueSize: queueSize,
+		timeout:   timeout,
+		hosts:     make(map[string]*hostQueue),
+	}
+}
+
+func (l *hostLimiter) getQueue(host string) *hostQueue {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	q, ok := l.hosts[host]
+	if !ok {
+		q = &hostQueue{slots: make(chan struct{}, l.limit)}
+		l.hosts[host] = q
+	}
+	q.refs++
+	return q
+}
+
+// the queue of an idle host is removed, so the map does not grow with the distinct hosts
+func (l *hostLimiter) putQueue(host string, q *hostQueue) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	q.refs--
+	if q.refs == 0 {
+		delete(l.hosts, host)
+	}
+}
+
+// acquire a slot for host, the returned release func must be called when the request is done
+func (l *hostLimiter) acquire(ctx context.Context, host string) (func(), error) {
+	q := l.getQueue(host)
+	release := func() {
+		<-q.slots
+		atomic.AddInt64(&l.active, -1)
+		l.putQueue(host, q)
+	}
+
+	select {
+	case q.slots <- struct{}{}:
+		atomic.AddInt64(&l.active, 1)
+		return release, nil
+	default:
+	}
+
+	if int(atomic.AddInt32(&q.waiting, 1)) > l.queueSize {
+		atomic.AddInt32(&q.waiting, -1)
+		l.reject(host, q)
+		return nil, ErrQueueFull
+	}
+	atomic.AddInt64(&l.waiting, 1)
+	defer func() {
+		atomic.AddInt32(&q.waiting, -1)
+		atomic.AddInt64(&l.waiting, -1)
+	}()
+
+	var timeout <-chan time.Time
+	if l.timeout > 0 {
+		timer := time.NewTimer(l.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case q.slots <- struct{}{}:
+		atomic.AddInt64(&l.active, 1)
+		return release, nil
+	case <-timeout:
+		l.reject(host, q)
+		return nil, ErrQueueTimeout
+	case <-ctx.Done():
+		l.putQueue(host, q)
+		return nil, ctx.Err()
+	}
+}
+
+func (l *hostLimiter) reject(host string, q *hostQueue) {
+	atomic.AddUint64(&q.rejected, 1)
+	atomic.AddUint64(&l.rejected, 1)
+	l.putQueue(host, q)
+}
+
+func (l *hostLimiter) stats() map[string]QueueStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stats := make(map[string]QueueStats, len(l.hosts))
+	for host, q := range l.hosts {
+		stats[host] = QueueStats{
+			Active:   len(q.slots),
+			Waiting:  int(atomic.LoadInt32(&q.waiting)),
+			Rejected: atomic.LoadUint64(&q.rejected),
+		}
+	}
+	return stats
+}
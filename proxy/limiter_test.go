@@ -0,0 +1,90 @@
+package proxy
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUpstreamQueue(t *testing.T) {
+	var flowRejected int32
+	helper := &testPipeHelper{
+		opts: &Options{
+			MaxConcurrentPerHost: 5,
+			QueueSize:            20,
+			QueueTimeout:         time.Millisecond * 200,
+			OnFlowComplete: func(f *Flow) {
+				if errors.Is(f.Error, ErrQueueFull) || errors.Is(f.Error, ErrQueueTimeout) {
+					atomic.AddInt32(&flowRejected, 1)
+				}
+			},
+		},
+	}
+	helper.init(t)
+	defer helper.close()
+
+	proxyClient := helper.getProxyClient()
+	var wg sync.WaitGroup
+	var okCount, rejectCount int32
+	done := make(chan struct{})
+	waiting := make(chan int64)
+	go func() {
+		var maxWaiting int64
+		for {
+			select {
+			case <-done:
+				waiting <- maxWaiting
+				return
+			case <-time.After(5 * time.Millisecond):
+				maxWaiting = max(maxWaiting, helper.testProxy.Stats().QueueWaiting)
+			}
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := proxyClient.Get("http://example.com/slow")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode == 200 {
+				atomic.AddInt32(&okCount, 1)
+			} else if resp.StatusCode == 503 {
+				atomic.AddInt32(&rejectCount, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	maxWaiting := <-waiting
+
+	if max := atomic.LoadInt32(&helper.maxConcurrent); max > 5 {
+		t.Fatalf("expected max concurrent <= 5, but got %v", max)
+	}
+	// 5 run immediately and some queued ones get a slot before timeout
+	if okCount <= 5 {
+		t.Fatalf("expected queued requests succeed, but only %v ok", okCount)
+	}
+	if rejectCount == 0 || okCount+rejectCount != 100 {
+		t.Fatalf("unexpected result: ok %v, rejected %v", okCount, rejectCount)
+	}
+	if flowRejected != rejectCount {
+		t.Fatalf("expected Flow.Error of %v rejected, but got %v", rejectCount, flowRejected)
+	}
+	if maxWaiting == 0 {
+		t.Fatal("expected requests waiting in queue")
+	}
+	stats := helper.testProxy.Stats()
+	if stats.QueueRejected != uint64(rejectCount) || stats.QueueActive != 0 || stats.QueueWaiting != 0 {
+		t.Fatalf("unexpected queue stats %+v", stats)
+	}
+	// the idle host is evicted
+	if hosts := helper.testProxy.QueueStats(); len(hosts) != 0 {
+		t.Fatalf("expected no queue of idle hosts, but got %v", hosts)
+	}
+}
@@ -0,0 +1,49 @@
+package proxy
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Config return a copy of the effective options, secrets are redacted
+func (proxy *Proxy) Config() Options {
+	opts := *proxy.Opts
+	opts.Upstream = redactURL(opts.Upstream)
+	return opts
+}
+
+// String format options for logging, func, pointer and interface fields only show whether they are set
+func (opts Options) String() string {
+	v := reflect.ValueOf(opts)
+	t := v.Type()
+	fields := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		var val interface{}
+		switch field.Kind() {
+		case reflect.Func, reflect.Ptr, reflect.Interface, reflect.Map, reflect.Chan:
+			if field.IsNil() {
+				continue
+			}
+			val = "<set>"
+		default:
+			val = field.Interface()
+		}
+		fields = append(fields, fmt.Sprintf("%v=%v", t.Field(i).Name, val))
+	}
+	return "{" + strings.Join(fields, " ") + "}"
+}
+
+// hide password of url
+func redactURL(rawurl string) string {
+	if rawurl == "" {
+		return rawurl
+	}
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "<invalid url>"
+	}
+	return u.Redacted()
+}
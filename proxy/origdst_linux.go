@@ -0,0 +1,47 @@
+package proxy
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"strconv"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// original destination of the connection redirected by iptables REDIRECT or TPROXY, by SO_ORIGINAL_DST
+func originalDst(c net.Conn) (string, error) {
+	sc, ok := c.(syscall.Conn)
+	if !ok {
+		return "", errors.New("original destination: not a socket")
+	}
+	rc, err := sc.SyscallConn()
+	if err != nil {
+		return "", err
+	}
+	var addr string
+	var sockErr error
+	err = rc.Control(func(fd uintptr) {
+		// sockaddr_in is returned in the 16 bytes of IPv6Mreq
+		if mreq, err := unix.GetsockoptIPv6Mreq(int(fd), unix.SOL_IP, unix.SO_ORIGINAL_DST); err == nil {
+			b := mreq.Multiaddr
+			addr = net.JoinHostPort(net.IP(b[4:8]).String(), strconv.Itoa(int(binary.BigEndian.Uint16(b[2:4]))))
+			return
+		}
+		// sockaddr_in6 is returned in the Addr of IPv6MTUInfo, IP6T_SO_ORIGINAL_DST is the same as SO_ORIGINAL_DST
+		info, err := unix.GetsockoptIPv6MTUInfo(int(fd), unix.SOL_IPV6, unix.SO_ORIGINAL_DST)
+		if err != nil {
+			sockErr = err
+			return
+		}
+		// Port of RawSockaddrInet6 is in network byte order
+		port := binary.BigEndian.Uint16((*[2]byte)(unsafe.Pointer(&info.Addr.Port))[:])
+		addr = net.JoinHostPort(net.IP(info.Addr.Addr[:]).String(), strconv.Itoa(int(port)))
+	})
+	if err != nil {
+		return "", err
+	}
+	return addr, sockErr
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package proxy
+
+import (
+	"errors"
+	"net"
+)
+
+func originalDst(c net.Conn) (string, error) {
+	return "", errors.New("SO_ORIGINAL_DST is not supported on this platform, set Options.OriginalDst")
+}
@@ -0,0 +1,68 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+)
+
+var errPipeListenerClosed = errors.New("pipe listener closed")
+
+type pipeAddr struct{}
+
+func (pipeAddr) Network() string { return "pipe" }
+func (pipeAddr) String() string  { return "pipe" }
+
+// PipeListener is an in-memory net.Listener based on net.Pipe.
+// Every dial creates a pipe pair, one side is returned to the dialer and the other side is accepted by the listener.
+// It can be used as Options.Listener and Options.DialContext to run the whole proxy in-process without real sockets.
+type PipeListener struct {
+	connChan chan net.Conn
+	done     chan struct{}
+	once     sync.Once
+}
+
+func NewPipeListener() *PipeListener {
+	return &PipeListener{
+		connChan: make(chan net.Conn),
+		done:     make(chan struct{}),
+	}
+}
+
+func (l *PipeListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.connChan:
+		return c, nil
+	case <-l.done:
+		return nil, errPipeListenerClosed
+	}
+}
+
+func (l *PipeListener) Close() error {
+	l.once.Do(func() {
+		close(l.done)
+	})
+	return nil
+}
+
+func (l *PipeListener) Addr() net.Addr {
+	return pipeAddr{}
+}
+
+// DialContext connect to the listener, network and addr are ignored
+func (l *PipeListener) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	serverConn, clientConn := net.Pipe()
+	select {
+	case l.connChan <- serverConn:
+		return clientConn, nil
+	case <-l.done:
+		serverConn.Close()
+		clientConn.Close()
+		return nil, errPipeListenerClosed
+	case <-ctx.Done():
+		serverConn.Close()
+		clientConn.Close()
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,221 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lqqyt2423/go-mitmproxy/cert"
+)
+
+type testPipeHelper struct {
+	opts     *Options                    // optional base options
+	noAddons bool                        // interceptAddon is not added, such as for the passthrough fast path
+	handlers map[string]http.HandlerFunc // endpoints of the test servers used by a single test, by pattern
+
+	httpLn    *PipeListener
+	httpsLn   *PipeListener
+	proxyLn   *PipeListener
+	testProxy *Proxy
+	startOnce sync.Once
+	serverCA  *cert.CA // issue the certificate of https server
+
+	// concurrent requests of /slow
+	concurrent    int32
+	maxConcurrent int32
+
+	// /block notifies blockStarted, then waits until the request is cancelled and notifies blockCancelled
+	blockStarted   chan struct{}
+	blockCancelled chan struct{}
+}
+
+func (helper *testPipeHelper) init(t testing.TB) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Content-Length", strconv.FormatInt(r.ContentLength, 10))
+		w.Header().Set("X-Transfer-Encoding", strings.Join(r.TransferEncoding, ","))
+		body, _ := io.ReadAll(r.Body) // http/1 server does not support reading body after writing response
+		w.Write(body)
+	})
+	mux.HandleFunc("/header", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Secret", "secret")
+		w.Write([]byte(r.Header.Get(r.URL.Query().Get("name"))))
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "internal error", 500)
+	})
+	mux.HandleFunc("/tls", func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil {
+			fmt.Fprintf(w, "%v %v %v", tls.VersionName(r.TLS.Version), tls.CipherSuiteName(r.TLS.CipherSuite), r.Proto)
+		}
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&helper.concurrent, 1)
+		defer atomic.AddInt32(&helper.concurrent, -1)
+		for {
+			max := atomic.LoadInt32(&helper.maxConcurrent)
+			if n <= max || atomic.CompareAndSwapInt32(&helper.maxConcurrent, max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond * 50)
+		w.Write([]byte("ok"))
+	})
+	for pattern, handler := range helper.handlers {
+		mux.HandleFunc(pattern, handler)
+	}
+	helper.blockStarted = make(chan struct{}, 1)
+	helper.blockCancelled = make(chan struct{}, 1)
+	mux.HandleFunc("/block", func(w http.ResponseWriter, r *http.Request) {
+		helper.blockStarted <- struct{}{}
+		select {
+		case <-r.Context().Done():
+			helper.blockCancelled <- struct{}{}
+		case <-time.After(5 * time.Second):
+		}
+	})
+
+	helper.httpLn = NewPipeListener()
+	go (&http.Server{Handler: mux}).Serve(helper.httpLn)
+
+	ca, err := cert.NewCAMemory()
+	handleError(t, err)
+	helper.serverCA = ca
+	c, err := ca.GetCert("example.com")
+	handleError(t, err)
+	helper.httpsLn = NewPipeListener()
+	tlsLn := tls.NewListener(helper.httpsLn, &tls.Config{Certificates: []tls.Certificate{*c}})
+	go (&http.Server{Handler: mux}).Serve(tlsLn)
+
+	helper.proxyLn = NewPipeListener()
+	opts := helper.opts
+	if opts == nil {
+		opts = &Options{}
+	}
+	opts.Listener = helper.proxyLn
+	opts.SslInsecure = true
+	opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if strings.HasSuffix(addr, ":443") {
+			return helper.httpsLn.DialContext(ctx, network, addr)
+		}
+		return helper.httpLn.DialContext(ctx, network, addr)
+	}
+	testProxy, err := NewProxy(opts)
+	handleError(t, err)
+	if !helper.noAddons {
+		testProxy.AddAddon(&interceptAddon{})
+	}
+	helper.testProxy = testProxy
+}
+
+// dial the proxy, it is started by the first dial, after the test has set the options and added the addons
+func (helper *testPipeHelper) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	helper.startOnce.Do(func() {
+		go helper.testProxy.Start()
+	})
+	return helper.proxyLn.DialContext(ctx, network, addr)
+}
+
+func init() {
+	// tests close connections with flows that never finish, such as raw tunnels and h2 connections
+	connDrainTimeout = 100 * time.Millisecond
+}
+
+func (helper *testPipeHelper) close() {
+	helper.testProxy.Close()
+	helper.proxyLn.Close()
+	helper.httpLn.Close()
+	helper.httpsLn.Close()
+}
+
+func (helper *testPipeHelper) getProxyClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: helper.dial,
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true,
+			},
+			Proxy: func(r *http.Request) (*url.URL, error) {
+				return url.Parse("http://proxy.pipe")
+			},
+		},
+	}
+}
+
+// send raw http request to https://example.com through CONNECT tunnel
+func (helper *testPipeHelper) sendRawTlsRequest(t testing.TB, raw string) *http.Response {
+	t.Helper()
+	conn, err := helper.dial(context.Background(), "tcp", "proxy.pipe")
+	handleError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	_, err = io.WriteString(conn, "CONNECT example.com:443 HTTP/1.1\r\nHost: example.com:443\r\n\r\n")
+	handleError(t, err)
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	handleError(t, err)
+	if resp.StatusCode != 200 {
+		t.Fatalf("CONNECT failed: %v", resp.Status)
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: "example.com", InsecureSkipVerify: true})
+	_, err = io.WriteString(tlsConn, raw)
+	handleError(t, err)
+	resp, err = http.ReadResponse(bufio.NewReader(tlsConn), nil)
+	handleError(t, err)
+	return resp
+}
+
+func TestProxyOverPipe(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+
+	proxyClient := helper.getProxyClient()
+
+	t.Run("can proxy http", func(t *testing.T) {
+		testSendRequest(t, "http://example.com/", proxyClient, "ok")
+	})
+
+	t.Run("can proxy https", func(t *testing.T) {
+		testSendRequest(t, "https://example.com/", proxyClient, "ok")
+	})
+
+	t.Run("can intercept request", func(t *testing.T) {
+		testSendRequest(t, "http://example.com/intercept-request", proxyClient, "intercept-request")
+		testSendRequest(t, "https://example.com/intercept-request", proxyClient, "intercept-request")
+	})
+
+	t.Run("can intercept response", func(t *testing.T) {
+		testSendRequest(t, "http://example.com/intercept-response", proxyClient, "intercept-response")
+		testSendRequest(t, "https://example.com/intercept-response", proxyClient, "intercept-response")
+	})
+}
+
+func BenchmarkProxyOverPipe(b *testing.B) {
+	helper := &testPipeHelper{}
+	helper.init(b)
+	defer helper.close()
+
+	proxyClient := helper.getProxyClient()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		testSendRequest(b, "http://example.com/", proxyClient, "ok")
+	}
+}
@@ -0,0 +1,165 @@
+package proxy
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// upstream connections kept alive by the pool of Options.PoolUpstreamConns
+const (
+	poolMaxIdleConnsPerHost = 16
+	poolIdleConnTimeout     = 90 * time.Second
+)
+
+// the plain http request is sent by the pool of Options.PoolUpstreamConns
+// not new(struct{}), pointers to zero size values may be equal to the other keys such as connContextKey
+var pooledReqKey = new(byte)
+
+// transport shared by the plain http requests of all client connections, see Options.PoolUpstreamConns
+func (a *attacker) newUpstreamPool() *http.Transport {
+	return &http.Transport{
+		DialContext:            a.dialPooled,
+		DialTLSContext:         a.dialPooled,
+		ForceAttemptHTTP2:      false, // disable http2
+		DisableCompression:     true,  // To get the original response from the server, set Transport.DisableCompression to true.
+		MaxIdleConnsPerHost:    poolMaxIdleConnsPerHost,
+		IdleConnTimeout:        poolIdleConnTimeout,
+		MaxResponseHeaderBytes: a.proxy.Opts.UpstreamMaxResponseHeaderBytes,
+	}
+}
+
+// dial a new upstream connection of the pool, ServerConnected is triggered with the client connection which dials it
+func (a *attacker) dialPooled(ctx context.Context, network, addr string) (net.Conn, error) {
+	req := ctx.Value(proxyReqCtxKey).(*http.Request)
+	connCtx := req.Context().Value(connContextKey).(*ConnContext)
+	c, addr, err := a.dialHttpServer(ctx, connCtx, req)
+	if err != nil {
+		return nil, err
+	}
+	proxy := a.proxy
+	pc := &pooledServerConn{
+		Conn:      c,
+		proxy:     proxy,
+		closeChan: make(chan struct{}),
+	}
+	pc.connCtx.Store(connCtx)
+
+	serverConn := newServerConn(proxy.newId())
+	serverConn.Conn = pc
+	serverConn.Address = addr
+	serverConn.statusLine = newStatusLineConn(pc)
+	pc.serverConn = serverConn
+
+	atomic.AddInt64(&proxy.counters.activeServerConns, 1)
+	hookCtx := pc.hookConnCtx(connCtx)
+	for _, addon := range proxy.hooks.serverConnected {
+		addon.ServerConnected(hookCtx)
+	}
+	return serverConn.statusLine, nil
+}
+
+// send the plain http request by the pool, ConnContext.ServerConn is set to the upstream connection it is sent on
+func (a *attacker) roundTripPooled(f *Flow, proxyReq *http.Request) (*http.Response, error) {
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			pc := info.Conn.(*statusLineConn).Conn.(*pooledServerConn)
+			pc.connCtx.Store(f.ConnContext)
+			f.ConnContext.ServerConn = pc.serverConn
+			f.upstreamURL = a.upstreamURL(f, pc.serverConn.Address)
+		},
+	}
+	proxyReq = proxyReq.WithContext(httptrace.WithClientTrace(proxyReq.Context(), trace))
+	return a.poolClient.Do(proxyReq)
+}
+
+// upstream connection of the pool, it outlives the client connections and is shared by them one request at a time.
+// Bytes and hooks are reported with the client connection of the request being sent on it.
+type pooledServerConn struct {
+	net.Conn
+	proxy      *Proxy
+	serverConn *ServerConn
+	connCtx    atomic.Pointer[ConnContext]
+	closeOnce  sync.Once
+	closeErr   error
+	closeChan  chan struct{} // wake writers blocked by Throttle
+}
+
+func (c *pooledServerConn) Read(data []byte) (int, error) {
+	n, err := c.Conn.Read(data)
+	if n > 0 {
+		connCtx := c.connCtx.Load()
+		connCtx.touch()
+		if fn := c.proxy.Opts.OnServerRawBytes; fn != nil {
+			fn(connCtx, data[:n], DirectionRead)
+		}
+		if fn := c.proxy.Opts.OnServerBytes; fn != nil {
+			fn(connCtx, data[:n], DirectionRead)
+		}
+	}
+	return n, err
+}
+
+func (c *pooledServerConn) Write(data []byte) (int, error) {
+	connCtx := c.connCtx.Load()
+	if len(data) > 0 {
+		connCtx.touch()
+		if fn := c.proxy.Opts.OnServerRawBytes; fn != nil {
+			fn(connCtx, data, DirectionWrite)
+		}
+		if fn := c.proxy.Opts.OnServerBytes; fn != nil {
+			fn(connCtx, data, DirectionWrite)
+		}
+	}
+	return connCtx.throttledWrite(c.Conn, data, true, c.closeChan)
+}
+
+// Close unlike wrapServerConn, the client connection is not affected
+func (c *pooledServerConn) Close() error {
+	first := false
+	c.closeOnce.Do(func() {
+		first = true
+		c.closeErr = c.Conn.Close()
+		close(c.closeChan)
+	})
+	if !first {
+		return c.closeErr
+	}
+	connCtx := c.connCtx.Load()
+	log.Debugln("in pooledServerConn close", c.serverConn.Address)
+	atomic.AddInt64(&c.proxy.counters.activeServerConns, -1)
+	connCtx.closeReasonMu.Lock()
+	if c.serverConn.CloseReason == "" {
+		c.serverConn.CloseReason = connCtx.defaultCloseReason()
+	}
+	connCtx.closeReasonMu.Unlock()
+
+	hookCtx := c.hookConnCtx(connCtx)
+	for _, addon := range c.proxy.hooks.serverDisconnected {
+		addon.ServerDisconnected(hookCtx)
+	}
+	return c.closeErr
+}
+
+// copy of the client connection for the server hooks, its ServerConn is this upstream connection,
+// while the client connection may have sent the later requests on another one of the pool
+func (c *pooledServerConn) hookConnCtx(connCtx *ConnContext) *ConnContext {
+	return &ConnContext{
+		ClientConn:         connCtx.ClientConn,
+		ServerConn:         c.serverConn,
+		Intercept:          connCtx.Intercept,
+		FlowCount:          atomic.LoadUint32(&connCtx.FlowCount),
+		UpstreamRetries:    connCtx.UpstreamRetries,
+		SkipUpstreamVerify: connCtx.SkipUpstreamVerify,
+		RawTcp:             connCtx.RawTcp,
+		proxy:              connCtx.proxy,
+		ctx:                connCtx.ctx,
+		lastActive:         atomic.LoadInt64(&connCtx.lastActive),
+	}
+}
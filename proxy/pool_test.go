@@ -0,0 +1,130 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type testPoolAddon struct {
+	BaseAddon
+	serverConnected    int32
+	serverDisconnected int32
+	flows              chan *Flow
+}
+
+func (addon *testPoolAddon) ServerConnected(*ConnContext) {
+	atomic.AddInt32(&addon.serverConnected, 1)
+}
+
+func (addon *testPoolAddon) ServerDisconnected(*ConnContext) {
+	atomic.AddInt32(&addon.serverDisconnected, 1)
+}
+
+func (addon *testPoolAddon) Response(f *Flow) {
+	addon.flows <- f
+}
+
+func TestPoolUpstreamConns(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{PoolUpstreamConns: true}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testPoolAddon{flows: make(chan *Flow, 10)}
+	helper.testProxy.AddAddon(addon)
+
+	// a new client connection for each request
+	proxyClient := helper.getProxyClient()
+	proxyClient.Transport.(*http.Transport).DisableKeepAlives = true
+	var flows []*Flow
+	for i := 0; i < 3; i++ {
+		testSendRequest(t, "http://example.com/", proxyClient, "ok")
+		flows = append(flows, <-addon.flows)
+	}
+	if n := atomic.LoadInt32(&addon.serverConnected); n != 1 {
+		t.Fatalf("expected 1 upstream connection, but got %v", n)
+	}
+	if n := atomic.LoadInt32(&addon.serverDisconnected); n != 0 {
+		t.Fatalf("expected the upstream connection kept alive, but got %v disconnected", n)
+	}
+	for _, f := range flows[1:] {
+		if f.ConnContext == flows[0].ConnContext {
+			t.Fatal("expected flows of different client connections")
+		}
+		if f.ConnContext.ServerConn != flows[0].ConnContext.ServerConn {
+			t.Fatal("expected flows sent on the same upstream connection")
+		}
+		if f.EffectiveUpstreamURL() == nil || len(f.Response.RawStatusLine) == 0 {
+			t.Fatalf("expected upstream url and status line of the pooled connection, but got %v %q", f.EffectiveUpstreamURL(), f.Response.RawStatusLine)
+		}
+	}
+}
+
+type testPoolServerConnAddon struct {
+	BaseAddon
+	mu           sync.Mutex
+	connected    []*ServerConn
+	disconnected []*ServerConn
+}
+
+func (addon *testPoolServerConnAddon) ServerConnected(connCtx *ConnContext) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.connected = append(addon.connected, connCtx.ServerConn)
+}
+
+func (addon *testPoolServerConnAddon) ServerDisconnected(connCtx *ConnContext) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	if connCtx.ServerConn.CloseReason == "" {
+		panic("expected close reason of the disconnected server conn")
+	}
+	addon.disconnected = append(addon.disconnected, connCtx.ServerConn)
+}
+
+func TestPoolServerConnHooks(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{PoolUpstreamConns: true}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testPoolServerConnAddon{}
+	helper.testProxy.AddAddon(addon)
+
+	// the client connection sends on two upstream connections, the hooks get the one connected or closed
+	proxyClient := helper.getProxyClient()
+	testSendRequest(t, "http://example.com/", proxyClient, "ok")
+	testSendRequest(t, "http://example.org/", proxyClient, "ok")
+	helper.testProxy.attacker.pool.CloseIdleConnections()
+
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	if len(addon.connected) != 2 || addon.connected[0] == addon.connected[1] {
+		t.Fatalf("expected 2 upstream connections connected, but got %v", addon.connected)
+	}
+	if len(addon.disconnected) != 2 || addon.disconnected[0] == addon.disconnected[1] {
+		t.Fatalf("expected the 2 upstream connections disconnected, but got %v", addon.disconnected)
+	}
+	for _, serverConn := range addon.disconnected {
+		if serverConn != addon.connected[0] && serverConn != addon.connected[1] {
+			t.Fatalf("expected disconnected %v of the connected ones", serverConn.Id)
+		}
+	}
+}
+
+func BenchmarkPoolUpstreamConns(b *testing.B) {
+	for _, pool := range []bool{false, true} {
+		b.Run(fmt.Sprintf("pool=%v", pool), func(b *testing.B) {
+			helper := &testPipeHelper{opts: &Options{PoolUpstreamConns: pool}}
+			helper.init(b)
+			defer helper.close()
+
+			// chatty clients open a new connection for each request
+			proxyClient := helper.getProxyClient()
+			proxyClient.Transport.(*http.Transport).DisableKeepAlives = true
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				testSendRequest(b, "http://example.com/", proxyClient, "ok")
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package proxy
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lqqyt2423/go-mitmproxy/internal/helper"
+)
+
+// CORSPreflight the CORS headers of the preflight requests answered by the proxy, see Options.HandlePreflight
+type CORSPreflight struct {
+	AllowOrigins     []string // origins answered locally, "*" for any, the others are forwarded to server
+	AllowMethods     []string // empty allows the Access-Control-Request-Method of the request
+	AllowHeaders     []string // empty allows the Access-Control-Request-Headers of the request
+	AllowCredentials bool
+	MaxAge           time.Duration // Access-Control-Max-Age, 0 not sent
+}
+
+// response of the CORS preflight request answered locally, nil if it is not one or the origin is not allowed
+func (proxy *Proxy) preflightResponse(req *Request) *Response {
+	cors := proxy.Opts.HandlePreflight
+	if cors == nil || req.Method != http.MethodOptions {
+		return nil
+	}
+	origin := req.Header.Get("Origin")
+	requestMethod := req.Header.Get("Access-Control-Request-Method")
+	if origin == "" || requestMethod == "" {
+		return nil
+	}
+	if !slices.Contains(cors.AllowOrigins, "*") && !slices.Contains(cors.AllowOrigins, origin) {
+		return nil
+	}
+
+	header := http.Header{}
+	// the origin is echoed back, "*" is not allowed with credentials
+	header.Set("Access-Control-Allow-Origin", origin)
+	header.Set("Vary", "Origin")
+	if len(cors.AllowMethods) > 0 {
+		header.Set("Access-Control-Allow-Methods", strings.Join(cors.AllowMethods, ", "))
+	} else {
+		header.Set("Access-Control-Allow-Methods", requestMethod)
+	}
+	if len(cors.AllowHeaders) > 0 {
+		header.Set("Access-Control-Allow-Headers", strings.Join(cors.AllowHeaders, ", "))
+	} else if h := req.Header.Get("Access-Control-Request-Headers"); h != "" {
+		header.Set("Access-Control-Allow-Headers", h)
+	}
+	if cors.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+	if cors.MaxAge > 0 {
+		header.Set("Access-Control-Max-Age", strconv.Itoa(int(cors.MaxAge.Seconds())))
+	}
+	return &Response{
+		StatusCode: http.StatusNoContent,
+		Header:     header,
+	}
+}
+
+// CORSHeaders the CORS headers added to the upstream responses, see Options.CORS
+type CORSHeaders struct {
+	Hosts            []string // hosts of the requests, such as "api.example.com" or "*.example.com", empty for all
+	AllowOrigin      string   // such as "*", empty echoes the Origin of the request
+	AllowCredentials bool
+	ExposeHeaders    []string
+	Override         bool // replace the CORS headers sent by server, they are kept by default
+}
+
+// add the CORS headers of Options.CORS to the upstream response
+func (proxy *Proxy) injectCORS(f *Flow) {
+	cors := proxy.Opts.CORS
+	if cors == nil {
+		return
+	}
+	if len(cors.Hosts) > 0 && !helper.MatchHost(f.Request.URL.Host, cors.Hosts) {
+		return
+	}
+	header := f.Response.Header
+	set := func(key, value string) {
+		if cors.Override || header.Get(key) == "" {
+			header.Set(key, value)
+		}
+	}
+
+	origin := cors.AllowOrigin
+	if origin == "" {
+		origin = f.Request.Header.Get("Origin")
+		if origin == "" {
+			return
+		}
+		if cors.Override || header.Get("Access-Control-Allow-Origin") == "" {
+			header.Add("Vary", "Origin")
+		}
+	}
+	set("Access-Control-Allow-Origin", origin)
+	if cors.AllowCredentials {
+		set("Access-Control-Allow-Credentials", "true")
+	}
+	if len(cors.ExposeHeaders) > 0 {
+		set("Access-Control-Expose-Headers", strings.Join(cors.ExposeHeaders, ", "))
+	}
+}
@@ -0,0 +1,104 @@
+package proxy
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestHandlePreflight(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{
+		HandlePreflight: &CORSPreflight{
+			AllowOrigins: []string{"http://localhost:3000"},
+			MaxAge:       time.Hour,
+		},
+	}}
+	helper.init(t)
+	defer helper.close()
+	proxyClient := helper.getProxyClient()
+
+	preflight := func(origin string) *http.Response {
+		req, err := http.NewRequest("OPTIONS", "https://example.com/echo", nil)
+		handleError(t, err)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", "PUT")
+		req.Header.Set("Access-Control-Request-Headers", "content-type")
+		res, err := proxyClient.Do(req)
+		handleError(t, err)
+		res.Body.Close()
+		return res
+	}
+
+	res := preflight("http://localhost:3000")
+	if res.StatusCode != 204 {
+		t.Fatalf("expected 204, but got %v", res.StatusCode)
+	}
+	for key, want := range map[string]string{
+		"Access-Control-Allow-Origin":  "http://localhost:3000",
+		"Access-Control-Allow-Methods": "PUT",
+		"Access-Control-Allow-Headers": "content-type",
+		"Access-Control-Max-Age":       "3600",
+	} {
+		if got := res.Header.Get(key); got != want {
+			t.Fatalf("expected %v: %v, but got %v", key, want, got)
+		}
+	}
+	// echoed by the server
+	if res.Header.Get("X-Content-Length") != "" {
+		t.Fatal("expected preflight not forwarded")
+	}
+
+	// not allowed origin is forwarded
+	res = preflight("http://other.com")
+	if res.StatusCode != 200 || res.Header.Get("X-Content-Length") == "" || res.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Fatalf("expected preflight forwarded, but got %v %v", res.StatusCode, res.Header)
+	}
+}
+
+func TestCORS(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{
+		CORS: &CORSHeaders{Hosts: []string{"example.com"}, AllowOrigin: "*", ExposeHeaders: []string{"X-Secret"}},
+	}}
+	helper.init(t)
+	defer helper.close()
+	proxyClient := helper.getProxyClient()
+
+	get := func(rawurl string) *http.Response {
+		res, err := proxyClient.Get(rawurl)
+		handleError(t, err)
+		res.Body.Close()
+		return res
+	}
+	for _, rawurl := range []string{"http://example.com/header", "https://example.com/header"} {
+		res := get(rawurl)
+		if res.Header.Get("Access-Control-Allow-Origin") != "*" || res.Header.Get("Access-Control-Expose-Headers") != "X-Secret" {
+			t.Fatalf("%v: expected cors headers, but got %v", rawurl, res.Header)
+		}
+	}
+	if res := get("http://other.com/header"); res.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Fatalf("expected no cors headers of other hosts, but got %v", res.Header)
+	}
+
+	// the headers of server are kept unless Override
+	u, _ := url.Parse("http://example.com/")
+	newFlow := func() *Flow {
+		return &Flow{
+			Request:  &Request{URL: u, Header: http.Header{"Origin": {"http://localhost:3000"}}},
+			Response: &Response{Header: http.Header{"Access-Control-Allow-Origin": {"https://example.com"}}},
+		}
+	}
+	cors := helper.testProxy.Opts.CORS
+	cors.AllowOrigin = ""
+	f := newFlow()
+	helper.testProxy.injectCORS(f)
+	if got := f.Response.Header.Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Fatalf("expected header of server kept, but got %v", got)
+	}
+	cors.Override = true
+	f = newFlow()
+	helper.testProxy.injectCORS(f)
+	if got := f.Response.Header.Get("Access-Control-Allow-Origin"); got != "http://localhost:3000" || f.Response.Header.Get("Vary") != "Origin" {
+		t.Fatalf("expected origin echoed, but got %v", f.Response.Header)
+	}
+}
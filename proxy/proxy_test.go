@@ -1,29 +1,42 @@
 package proxy
 
 import (
+	"bufio"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/lqqyt2423/go-mitmproxy/cert"
+	"golang.org/x/net/http2"
 )
 
-func handleError(t *testing.T, err error) {
+func handleError(t testing.TB, err error) {
 	t.Helper()
 	if err != nil {
 		t.Fatal(err)
 	}
 }
 
-func testSendRequest(t *testing.T, endpoint string, client *http.Client, bodyWant string) {
+func testSendRequest(t testing.TB, endpoint string, client *http.Client, bodyWant string) {
 	t.Helper()
 	req, err := http.NewRequest("GET", endpoint, nil)
 	handleError(t, err)
@@ -712,3 +725,1092 @@ func TestOffUpstreamCert(t *testing.T) {
 		testOrderAddonInstance.contains(t, "TlsEstablishedServer")
 	})
 }
+
+func TestUpstreamNoProxy(t *testing.T) {
+	p, err := NewProxy(&Options{
+		Upstream:        "http://127.0.0.1:8080",
+		UpstreamNoProxy: ".internal,10.0.0.0/8,example.com:8443",
+	})
+	handleError(t, err)
+
+	cases := []struct {
+		method string
+		url    string
+		host   string
+		direct bool
+	}{
+		{"CONNECT", "", "api.internal:443", true},
+		{"CONNECT", "", "internal:443", false},
+		{"CONNECT", "", "10.1.2.3:443", true},
+		{"CONNECT", "", "11.1.2.3:443", false},
+		{"CONNECT", "", "example.com:8443", true},
+		{"CONNECT", "", "example.com:443", false},
+		{"GET", "http://a.b.internal/", "a.b.internal", true},
+		{"GET", "http://example.com/", "example.com", false},
+	}
+	for _, c := range cases {
+		req, err := http.NewRequest(c.method, c.url, nil)
+		handleError(t, err)
+		req.Host = c.host
+		proxyUrl, err := p.getUpstreamProxyUrl(req)
+		handleError(t, err)
+		if direct := proxyUrl == nil; direct != c.direct {
+			t.Fatalf("%v %v: expected direct %v, but got proxy %v", c.method, c.host, c.direct, proxyUrl)
+		}
+	}
+}
+
+func TestIDGenerator(t *testing.T) {
+	var seq int32
+	helper := &testPipeHelper{
+		opts: &Options{
+			IDGenerator: func() string {
+				return strconv.Itoa(int(atomic.AddInt32(&seq, 1)))
+			},
+		},
+	}
+	helper.init(t)
+	defer helper.close()
+	addon := &testFlowTimeAddon{flows: make(chan *Flow, 1)}
+	helper.testProxy.AddAddon(addon)
+
+	testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+	f := <-addon.flows
+	if f.ConnContext.Id() != "1" || f.Id != "2" || f.ConnContext.ServerConn.Id != "3" {
+		t.Fatalf("unexpected ids: conn %v, flow %v, server %v", f.ConnContext.Id(), f.Id, f.ConnContext.ServerConn.Id)
+	}
+}
+
+func TestOnFlowComplete(t *testing.T) {
+	flows := make(chan *Flow, 10)
+	helper := &testPipeHelper{opts: &Options{
+		OnFlowComplete: func(f *Flow) {
+			flows <- f
+			panic("flow complete panic")
+		},
+	}}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.AddHooks(&testSetHeaderAddon{})
+
+	proxyClient := helper.getProxyClient()
+	testSendRequest(t, "http://example.com/", proxyClient, "ok")
+	f := <-flows
+	if f.Response == nil || f.Response.Header.Get("x-response-only") == "" || f.Response.EndAt.IsZero() {
+		t.Fatalf("expected flow completed after the Response hooks, but got %+v", f.Response)
+	}
+	select {
+	case <-f.Done():
+	default:
+		t.Fatal("expected flow done")
+	}
+
+	// still works after the callback panics
+	testSendRequest(t, "https://example.com/", proxyClient, "ok")
+	methods := []string{(<-flows).Request.Method, (<-flows).Request.Method}
+	slices.Sort(methods)
+	if !slices.Equal(methods, []string{"CONNECT", "GET"}) {
+		t.Fatalf("expected CONNECT and GET flows, but got %v", methods)
+	}
+}
+
+func TestMetricsRouteLabel(t *testing.T) {
+	labels := make(chan string, 10)
+	helper := &testPipeHelper{opts: &Options{
+		MetricsRouteLabel: func(req *Request) string {
+			if strings.HasPrefix(req.URL.Path, "/users/") {
+				return "/users/{id}"
+			}
+			return ""
+		},
+		OnFlowComplete: func(f *Flow) {
+			labels <- f.RouteLabel
+		},
+	}}
+	helper.init(t)
+	defer helper.close()
+
+	proxyClient := helper.getProxyClient()
+	testSendRequest(t, "http://example.com/", proxyClient, "ok")
+	if label := <-labels; label != "" {
+		t.Fatalf("expected no route label, but got %v", label)
+	}
+	res, err := proxyClient.Get("http://example.com/users/42")
+	handleError(t, err)
+	res.Body.Close()
+	if label := <-labels; label != "/users/{id}" {
+		t.Fatalf("expected route label /users/{id}, but got %v", label)
+	}
+}
+
+// tunnel of h2 CONNECT on client side
+type testH2Conn struct {
+	io.Reader
+	io.Writer
+}
+
+func (c *testH2Conn) Close() error                     { return nil }
+func (c *testH2Conn) LocalAddr() net.Addr              { return &net.TCPAddr{} }
+func (c *testH2Conn) RemoteAddr() net.Addr             { return &net.TCPAddr{} }
+func (c *testH2Conn) SetDeadline(time.Time) error      { return nil }
+func (c *testH2Conn) SetReadDeadline(time.Time) error  { return nil }
+func (c *testH2Conn) SetWriteDeadline(time.Time) error { return nil }
+
+type testFlowErrorAddon struct {
+	BaseAddon
+	hosts chan string
+}
+
+func (addon *testFlowErrorAddon) FlowError(f *Flow) {
+	if f.Response == nil && f.Error != nil {
+		addon.hosts <- f.Request.URL.Host
+	}
+}
+
+func TestFlowError(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+
+	dial := helper.testProxy.Opts.DialContext
+	helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if strings.HasPrefix(addr, "fail.com:") {
+			return nil, errors.New("dial refused")
+		}
+		return dial(ctx, network, addr)
+	}
+	addon := &testFlowErrorAddon{hosts: make(chan string, 10)}
+	helper.testProxy.AddAddon(addon)
+
+	testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+	for i := 0; i < 2; i++ {
+		resp, err := helper.getProxyClient().Get("http://fail.com/")
+		handleError(t, err)
+		resp.Body.Close()
+		if resp.StatusCode != 502 {
+			t.Fatalf("expected 502, but got %v", resp.StatusCode)
+		}
+	}
+
+	// count by host
+	errs := make(map[string]int)
+	for i := 0; i < 2; i++ {
+		select {
+		case host := <-addon.hosts:
+			errs[host]++
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting FlowError")
+		}
+	}
+	if errs["fail.com"] != 2 || len(addon.hosts) != 0 {
+		t.Fatalf("expected 2 errors of fail.com only, but got %v", errs)
+	}
+}
+
+func TestHostMapUnixSocket(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "app.sock")
+	ln, err := net.Listen("unix", sock)
+	handleError(t, err)
+	defer ln.Close()
+	go http.Serve(ln, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("unix " + r.Host))
+	}))
+
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.Opts.HostMap = map[string]string{"api.local": "unix:" + sock}
+	dial := helper.testProxy.Opts.DialContext
+	helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if network == "unix" {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		}
+		return dial(ctx, network, addr)
+	}
+
+	testSendRequest(t, "http://api.local/", helper.getProxyClient(), "unix api.local")
+	testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+}
+
+func TestShutdownWithReport(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+
+	// an idle keep-alive connection is drained
+	client := helper.getProxyClient()
+	testSendRequest(t, "http://example.com/", client, "ok")
+
+	// an open CONNECT tunnel is not finished before the deadline
+	conn, err := helper.dial(context.Background(), "tcp", "proxy.pipe")
+	handleError(t, err)
+	defer conn.Close()
+	_, err = io.WriteString(conn, "CONNECT example.com:443 HTTP/1.1\r\nHost: example.com:443\r\n\r\n")
+	handleError(t, err)
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	handleError(t, err)
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, but got %v", resp.StatusCode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	report, err := helper.testProxy.ShutdownWithReport(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, but got %v", err)
+	}
+	if report.Drained != 1 || report.ForceClosed != 1 {
+		t.Fatalf("expected 1 drained and 1 force closed, but got %+v", report)
+	}
+	if n := helper.testProxy.conns.len(); n != 0 {
+		t.Fatalf("expected no active connection, but got %v", n)
+	}
+}
+
+type testSlowHeadersAddon struct {
+	BaseAddon
+	flows chan *Flow
+}
+
+func (addon *testSlowHeadersAddon) SlowHeaders(f *Flow) {
+	addon.flows <- f
+}
+
+func TestSlowHeaders(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{SlowHeadersThreshold: 50 * time.Millisecond}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testSlowHeadersAddon{flows: make(chan *Flow, 10)}
+	helper.testProxy.AddAddon(addon)
+
+	conn, err := helper.dial(context.Background(), "tcp", "proxy.pipe")
+	handleError(t, err)
+	defer conn.Close()
+	br := bufio.NewReader(conn)
+	send := func(delay time.Duration) {
+		t.Helper()
+		_, err := io.WriteString(conn, "GET http://example.com/ HTTP/1.1\r\nHost: example.com\r\n")
+		handleError(t, err)
+		time.Sleep(delay)
+		_, err = io.WriteString(conn, "\r\n")
+		handleError(t, err)
+		resp, err := http.ReadResponse(br, nil)
+		handleError(t, err)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	send(100 * time.Millisecond)
+	send(0) // fast request on the same connection
+
+	select {
+	case f := <-addon.flows:
+		if f.HeaderDuration < 100*time.Millisecond {
+			t.Fatalf("expected HeaderDuration >= 100ms, but got %v", f.HeaderDuration)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected SlowHeaders triggered")
+	}
+	select {
+	case f := <-addon.flows:
+		t.Fatalf("expected only one slow flow, but got another %v", f.HeaderDuration)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestBlockedMethods(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+
+	check := func(method, url string, status int) {
+		t.Helper()
+		req, err := http.NewRequest(method, url, nil)
+		handleError(t, err)
+		resp, err := helper.getProxyClient().Do(req)
+		handleError(t, err)
+		resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Fatalf("%v %v: expected %v, but got %v", method, url, status, resp.StatusCode)
+		}
+	}
+
+	// TRACE is blocked by default
+	check("TRACE", "http://example.com/", 405)
+	check("TRACE", "https://example.com/", 405)
+	check("GET", "https://example.com/", 200)
+
+	helper.testProxy.Opts.BlockedMethods = []string{"delete"}
+	check("TRACE", "http://example.com/", 200)
+	check("DELETE", "https://example.com/", 405)
+}
+
+func TestRejectLongLine(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+
+	check := func(url string, header http.Header, status int) {
+		t.Helper()
+		req, err := http.NewRequest("GET", url, nil)
+		handleError(t, err)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp, err := helper.getProxyClient().Do(req)
+		handleError(t, err)
+		resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Fatalf("expected %v, but got %v", status, resp.StatusCode)
+		}
+	}
+
+	long := strings.Repeat("a", 100*1024)
+	check("http://example.com/?q="+long, nil, 414)
+	check("https://example.com/?q="+long, nil, 414)
+	check("https://example.com/", http.Header{"X-Long": {long}}, 431)
+	check("https://example.com/?q="+long[:1024], http.Header{"X-Short": {long[:1024]}}, 200)
+}
+
+func TestHandshakeTimeout(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{HandshakeTimeout: 100 * time.Millisecond}}
+	helper.init(t)
+	defer helper.close()
+
+	conn, err := helper.dial(context.Background(), "tcp", "proxy.pipe")
+	handleError(t, err)
+	defer conn.Close()
+	_, err = io.WriteString(conn, "CONNECT example.com:443 HTTP/1.1\r\nHost: example.com:443\r\n\r\n")
+	handleError(t, err)
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	handleError(t, err)
+	if resp.StatusCode != 200 {
+		t.Fatalf("CONNECT failed: %v", resp.Status)
+	}
+
+	// stall without client hello
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, err = br.ReadByte()
+	if ne, ok := err.(net.Error); err == nil || (ok && ne.Timeout()) {
+		t.Fatalf("expected conn closed by proxy, got %v", err)
+	}
+
+	// the deadline is cleared after the handshake
+	client := helper.getProxyClient()
+	testSendRequest(t, "https://example.com/slow", client, "ok")
+	time.Sleep(150 * time.Millisecond)
+	testSendRequest(t, "https://example.com/", client, "ok")
+}
+
+// minimal socks5 server with username/password auth, connections are forwarded by dial
+func testSocks5Server(t *testing.T, user, pass string, dial func(addr string) (net.Conn, error)) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	handleError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	serve := func(conn net.Conn) {
+		defer conn.Close()
+		br := bufio.NewReader(conn)
+		buf := make([]byte, 255)
+		// greeting
+		if _, err := io.ReadFull(br, buf[:2]); err != nil {
+			return
+		}
+		if _, err := io.ReadFull(br, buf[:buf[1]]); err != nil {
+			return
+		}
+		conn.Write([]byte{0x05, 0x02})
+		// RFC 1929
+		readField := func() string {
+			n, _ := br.ReadByte()
+			b := make([]byte, n)
+			io.ReadFull(br, b)
+			return string(b)
+		}
+		br.ReadByte()
+		u, p := readField(), readField()
+		if u != user || p != pass {
+			conn.Write([]byte{0x01, 0x01})
+			return
+		}
+		conn.Write([]byte{0x01, 0x00})
+		// request, only domain names are used in tests
+		if _, err := io.ReadFull(br, buf[:4]); err != nil || buf[3] != 0x03 {
+			return
+		}
+		host := readField()
+		if _, err := io.ReadFull(br, buf[:2]); err != nil {
+			return
+		}
+		addr := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(buf[:2]))))
+		upstream, err := dial(addr)
+		if err != nil {
+			conn.Write([]byte{0x05, 0x04, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+			return
+		}
+		defer upstream.Close()
+		conn.Write([]byte{0x05, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+		go io.Copy(upstream, br)
+		io.Copy(conn, upstream)
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serve(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+type testServerAddrAddon struct {
+	BaseAddon
+	mu    sync.Mutex
+	addrs []string
+}
+
+func (addon *testServerAddrAddon) ServerConnected(connCtx *ConnContext) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.addrs = append(addon.addrs, connCtx.ServerConn.Address)
+}
+
+func TestIPv6Literal(t *testing.T) {
+	// example.com is intercepted, the ip is tunneled
+	helper := &testPipeHelper{opts: &Options{InterceptHosts: []string{"example.com", "[2606:4700::1]:443"}}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testServerAddrAddon{}
+	helper.testProxy.AddAddon(addon)
+	proxyClient := helper.getProxyClient()
+
+	// intercepted without SNI, the cert has the ip as IP SAN
+	res, err := proxyClient.Get("https://[2606:4700::1]/")
+	handleError(t, err)
+	res.Body.Close()
+	if res.StatusCode != 200 {
+		t.Fatalf("expected 200, but got %v", res.StatusCode)
+	}
+	leaf := res.TLS.PeerCertificates[0]
+	if len(leaf.IPAddresses) != 1 || leaf.IPAddresses[0].String() != "2606:4700::1" || len(leaf.DNSNames) != 0 {
+		t.Fatalf("expected IP SAN of the cert, but got %v %v", leaf.IPAddresses, leaf.DNSNames)
+	}
+
+	testSendRequest(t, "https://[2606:4700::2]/", proxyClient, "ok")
+	testSendRequest(t, "http://[2606:4700::1]/", proxyClient, "ok")
+
+	// the port is kept for the ip of Options.HostMap
+	helper.testProxy.Opts.HostMap = map[string]string{"v6.local": "::1", "v6-bracketed.local": "[::1]"}
+	for _, host := range []string{"v6.local", "v6-bracketed.local"} {
+		if _, addr := helper.testProxy.dialAddr("tcp", host+":8080"); addr != "[::1]:8080" {
+			t.Fatalf("expected [::1]:8080 of %v, but got %v", host, addr)
+		}
+	}
+
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	if strings.Join(addon.addrs, ",") != "[2606:4700::1]:443,[2606:4700::2]:443,[2606:4700::1]:80" {
+		t.Fatalf("unexpected server addresses %v", addon.addrs)
+	}
+	for _, addr := range addon.addrs {
+		if host, _, err := net.SplitHostPort(addr); err != nil || !strings.HasPrefix(host, "2606:4700::") {
+			t.Fatalf("expected address parsed by net.SplitHostPort, but got %v %v", host, err)
+		}
+	}
+}
+
+func TestSocks5Upstream(t *testing.T) {
+	var targets []string
+	var mu sync.Mutex
+	helper := &testPipeHelper{}
+	socksAddr := testSocks5Server(t, "u", "p", func(addr string) (net.Conn, error) {
+		mu.Lock()
+		targets = append(targets, addr)
+		mu.Unlock()
+		return helper.httpLn.DialContext(context.Background(), "tcp", addr)
+	})
+	helper.opts = &Options{Upstream: "socks5://u:p@" + socksAddr}
+	helper.init(t)
+	defer helper.close()
+	addrAddon := &testServerAddrAddon{}
+	helper.testProxy.AddAddon(addrAddon)
+
+	testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+	mu.Lock()
+	if !slices.Equal(targets, []string{"example.com:80"}) {
+		t.Fatalf("expected dialed through socks5, got %v", targets)
+	}
+	mu.Unlock()
+	addrAddon.mu.Lock()
+	if !slices.Equal(addrAddon.addrs, []string{"example.com:80"}) {
+		t.Fatalf("expected server address recorded, got %v", addrAddon.addrs)
+	}
+	addrAddon.mu.Unlock()
+
+	t.Run("wrong password", func(t *testing.T) {
+		helper.testProxy.Opts.Upstream = "socks5://u:wrong@" + socksAddr
+		resp, err := helper.getProxyClient().Get("http://example.com/")
+		handleError(t, err)
+		resp.Body.Close()
+		if resp.StatusCode != 502 {
+			t.Fatalf("expected 502, got %v", resp.StatusCode)
+		}
+	})
+}
+
+// http proxy accepting CONNECT with the Proxy-Authorization auth, tunnel to the addr dialed by dial
+func testConnectProxyServer(t *testing.T, auth string, dial func(addr string) (net.Conn, error)) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	handleError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	serve := func(conn net.Conn) {
+		defer conn.Close()
+		br := bufio.NewReader(conn)
+		req, err := http.ReadRequest(br)
+		if err != nil || req.Method != "CONNECT" {
+			return
+		}
+		if req.Header.Get("Proxy-Authorization") != auth {
+			conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n\r\n"))
+			return
+		}
+		upstream, err := dial(req.Host)
+		if err != nil {
+			conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+			return
+		}
+		defer upstream.Close()
+		conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go io.Copy(upstream, br)
+		io.Copy(conn, upstream)
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serve(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestHttpUpstreamProxyAuth(t *testing.T) {
+	flows := make(chan *Flow, 10)
+	helper := &testPipeHelper{}
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte("u:p@ss:w"))
+	proxyAddr := testConnectProxyServer(t, auth, func(addr string) (net.Conn, error) {
+		return helper.httpsLn.DialContext(context.Background(), "tcp", addr)
+	})
+	helper.opts = &Options{
+		Upstream: "http://u:" + url.QueryEscape("p@ss:w") + "@" + proxyAddr,
+		OnFlowComplete: func(f *Flow) {
+			flows <- f
+		},
+	}
+	helper.init(t)
+	defer helper.close()
+
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+	for i := 0; i < 2; i++ {
+		if f := <-flows; f.Error != nil {
+			t.Fatalf("expected no error, but got %v", f.Error)
+		}
+	}
+
+	helper.testProxy.Opts.Upstream = "http://u:wrong@" + proxyAddr
+	if _, err := helper.getProxyClient().Get("https://example.com/"); err == nil {
+		t.Fatal("expected error of CONNECT")
+	}
+	if f := <-flows; !errors.Is(f.Error, ErrUpstreamProxyAuth) {
+		t.Fatalf("expected ErrUpstreamProxyAuth, but got %v", f.Error)
+	}
+}
+
+type testRewriteUpstreamAddon struct {
+	targets map[string]string
+}
+
+func (addon *testRewriteUpstreamAddon) RewriteUpstream(connCtx *ConnContext, addr string) string {
+	return addon.targets[addr]
+}
+
+func TestRewriteUpstream(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+
+	// the certificate of upstream https server is for example.com
+	pool := x509.NewCertPool()
+	pool.AddCert(&helper.serverCA.RootCert)
+	helper.testProxy.Opts.SslInsecure = false
+	helper.testProxy.Opts.UpstreamRootCAs = pool
+
+	var mu sync.Mutex
+	var dialed []string
+	dialContext := helper.testProxy.Opts.DialContext
+	helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		mu.Lock()
+		dialed = append(dialed, addr)
+		mu.Unlock()
+		return dialContext(ctx, network, addr)
+	}
+	helper.testProxy.AddHooks(&testRewriteUpstreamAddon{targets: map[string]string{
+		"api.prod.com:443":   "example.com:443",
+		"wrong.prod.com:443": "staging.internal:443",
+		"plain.prod.com:80":  "example.com:8080",
+	}})
+	client := helper.getProxyClient()
+
+	resp, err := client.Get("https://api.prod.com/")
+	handleError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "ok" {
+		t.Fatalf("expected ok, got %v", string(body))
+	}
+	if names := resp.TLS.PeerCertificates[0].DNSNames; !slices.Equal(names, []string{"api.prod.com"}) {
+		t.Fatalf("expected cert of the original host, got %v", names)
+	}
+
+	// upstream cert is verified against the rewritten host, the client gets 502
+	resp, err = client.Get("https://wrong.prod.com/")
+	handleError(t, err)
+	resp.Body.Close()
+	if resp.StatusCode != 502 {
+		t.Fatalf("expected 502, got %v", resp.Status)
+	}
+
+	testSendRequest(t, "http://plain.prod.com/", client, "ok")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !slices.Equal(dialed, []string{"example.com:443", "staging.internal:443", "example.com:8080"}) {
+		t.Fatalf("unexpected dialed addresses %v", dialed)
+	}
+}
+
+// Request of the flow blocks until release is closed
+type testBlockAddon struct {
+	BaseAddon
+	started chan struct{}
+	release chan struct{}
+}
+
+func (addon *testBlockAddon) Request(f *Flow) {
+	addon.started <- struct{}{}
+	<-addon.release
+}
+
+func TestShutdownDrain(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+
+	// idle keep-alive intercepted tunnel
+	client := helper.getProxyClient()
+	testSendRequest(t, "https://example.com/", client, "ok")
+
+	addon := &testBlockAddon{started: make(chan struct{}, 1), release: make(chan struct{})}
+	helper.testProxy.AddAddon(addon)
+
+	// in-flight flow finishes before the connection is closed. It is plain http, the tls close_notify of both sides
+	// closing the connection at once blocks on net.Pipe.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+	}()
+	<-addon.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- helper.testProxy.Shutdown(ctx)
+	}()
+	for !helper.testProxy.isClosing() {
+		runtime.Gosched()
+	}
+	close(addon.release)
+
+	if err := <-shutdown; err != nil {
+		t.Fatalf("expected drained, got %v", err)
+	}
+	<-done
+	if n := helper.testProxy.conns.len(); n != 0 {
+		t.Fatalf("expected no active connection, but got %v", n)
+	}
+}
+
+type testStopAddon struct {
+	BaseAddon
+	err   error
+	stops int32
+}
+
+func (addon *testStopAddon) Stop() error {
+	atomic.AddInt32(&addon.stops, 1)
+	return addon.err
+}
+
+func TestCloseStopsAddons(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{CloseDrainTimeout: 5 * time.Second}}
+	helper.init(t)
+	defer helper.proxyLn.Close()
+	defer helper.httpLn.Close()
+	defer helper.httpsLn.Close()
+	errStop := errors.New("stop error")
+	first := &testStopAddon{}
+	second := &testStopAddon{err: errStop}
+	third := &testStopAddon{err: errors.New("ignored")}
+	helper.testProxy.AddAddon(first)
+	helper.testProxy.AddAddon(second)
+	helper.testProxy.AddAddon(third)
+
+	// the active request is drained before the addons are stopped
+	done := make(chan error, 1)
+	go func() {
+		resp, err := helper.getProxyClient().Get("http://example.com/slow")
+		if err == nil {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if string(body) != "ok" {
+				err = fmt.Errorf("unexpected body %q", body)
+			}
+		}
+		done <- err
+	}()
+	for atomic.LoadInt32(&helper.concurrent) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := helper.testProxy.Close(); !errors.Is(err, errStop) {
+		t.Fatalf("expected first stop error, got %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if first.stops != 1 || second.stops != 1 || third.stops != 1 {
+		t.Fatalf("expected each addon stopped once, got %v %v %v", first.stops, second.stops, third.stops)
+	}
+
+	helper.testProxy.Close()
+	if first.stops != 1 {
+		t.Fatalf("expected addons not stopped again, got %v", first.stops)
+	}
+}
+
+func TestCloseWithoutDrain(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := helper.getProxyClient().Get("http://example.com/block")
+		done <- err
+	}()
+	<-helper.blockStarted
+	start := time.Now()
+	handleError(t, helper.testProxy.Close())
+	if err := <-done; err == nil {
+		t.Fatal("expected the request in progress aborted")
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("expected Close not waiting the request, but took %v", d)
+	}
+}
+
+type testClientCertErrorAddon struct {
+	BaseAddon
+	errs chan error
+}
+
+func (addon *testClientCertErrorAddon) FlowError(f *Flow) {
+	addon.errs <- f.Error
+}
+
+func TestClientCerts(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testClientCertErrorAddon{errs: make(chan error, 10)}
+	helper.testProxy.AddAddon(addon)
+
+	// server requiring a client cert issued by the server CA
+	serverCert, err := helper.serverCA.GetCert("mtls.example.com")
+	handleError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(&helper.serverCA.RootCert)
+	mtlsLn := NewPipeListener()
+	defer mtlsLn.Close()
+	go (&http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.TLS.PeerCertificates[0].Subject.CommonName)
+	})}).Serve(tls.NewListener(mtlsLn, &tls.Config{
+		Certificates: []tls.Certificate{*serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}))
+	dial := helper.testProxy.Opts.DialContext
+	helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if addr == "mtls.example.com:443" {
+			return mtlsLn.DialContext(ctx, network, addr)
+		}
+		return dial(ctx, network, addr)
+	}
+
+	certs := make(map[string]tls.Certificate)
+	for _, name := range []string{"default", "wildcard", "exact"} {
+		c, err := helper.serverCA.GetCert(name)
+		handleError(t, err)
+		certs[name] = *c
+	}
+
+	t.Run("no matching cert", func(t *testing.T) {
+		helper.testProxy.Opts.ClientCerts = map[string]tls.Certificate{"other.com": certs["exact"]}
+		resp, err := helper.getProxyClient().Get("https://mtls.example.com/")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode != 502 {
+				t.Fatalf("expected 502, got %v", resp.StatusCode)
+			}
+		}
+		select {
+		case err := <-addon.errs:
+			if !errors.Is(err, errNoClientCert) {
+				t.Fatalf("expected errNoClientCert, got %v", err)
+			}
+		case <-time.After(time.Second):
+			// the handshake with server fails before the request, the client connection is closed
+			if err == nil {
+				t.Fatal("expected flow error")
+			}
+		}
+	})
+
+	t.Run("most specific cert", func(t *testing.T) {
+		helper.testProxy.Opts.ClientCerts = map[string]tls.Certificate{"*": certs["default"], "*.example.com": certs["wildcard"]}
+		testSendRequest(t, "https://mtls.example.com/", helper.getProxyClient(), "wildcard")
+		helper.testProxy.Opts.ClientCerts["mtls.example.com"] = certs["exact"]
+		testSendRequest(t, "https://mtls.example.com/", helper.getProxyClient(), "exact")
+		delete(helper.testProxy.Opts.ClientCerts, "*.example.com")
+		delete(helper.testProxy.Opts.ClientCerts, "mtls.example.com")
+		testSendRequest(t, "https://mtls.example.com/", helper.getProxyClient(), "default")
+	})
+}
+
+func TestResolver(t *testing.T) {
+	var lookups int32
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			atomic.AddInt32(&lookups, 1)
+			return nil, errors.New("no dns server")
+		},
+	}
+	p := &Proxy{Opts: &Options{Resolver: resolver}}
+	_, err := p.dialContext(context.Background(), "tcp", "nx.example.com:80")
+	if !errors.Is(err, ErrUpstreamDNS) || atomic.LoadInt32(&lookups) == 0 {
+		t.Fatalf("expected ErrUpstreamDNS through the resolver, got %v, lookups %v", err, lookups)
+	}
+	if _, err := p.dialContext(context.Background(), "tcp", "127.0.0.1:1"); err == nil || errors.Is(err, ErrUpstreamDNS) {
+		t.Fatalf("expected a dial error other than ErrUpstreamDNS, got %v", err)
+	}
+
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testTimeoutErrorAddon{}
+	helper.testProxy.AddAddon(addon)
+	dial := helper.testProxy.Opts.DialContext
+	helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if strings.HasPrefix(addr, "nx.example.com") {
+			return nil, &net.OpError{Op: "dial", Net: network, Err: &net.DNSError{Err: "no such host", Name: "nx.example.com", IsNotFound: true}}
+		}
+		return dial(ctx, network, addr)
+	}
+	resp, err := helper.getProxyClient().Get("http://nx.example.com/")
+	handleError(t, err)
+	resp.Body.Close()
+	if resp.StatusCode != 502 {
+		t.Fatalf("expected 502, got %v", resp.StatusCode)
+	}
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	if len(addon.errors) != 1 || !errors.Is(addon.errors[0], ErrUpstreamDNS) {
+		t.Fatalf("expected ErrUpstreamDNS on the flow, got %v", addon.errors)
+	}
+}
+
+type testRetriesAddon struct {
+	BaseAddon
+	mu        sync.Mutex
+	connected int
+	addrs     []string // of the connected servers
+	retries   []int
+}
+
+func (addon *testRetriesAddon) ServerConnected(connCtx *ConnContext) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.connected++
+	addon.addrs = append(addon.addrs, connCtx.ServerConn.Address)
+}
+
+func (addon *testRetriesAddon) Response(f *Flow) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.retries = append(addon.retries, f.ConnContext.UpstreamRetries)
+}
+
+func TestMaxRetries(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{MaxRetries: 2, RetryBackoff: time.Millisecond}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testRetriesAddon{}
+	helper.testProxy.AddAddon(addon)
+
+	// the first 2 dials of each host are refused, dns errors are not retried
+	var mu sync.Mutex
+	dials := make(map[string]int)
+	dial := helper.testProxy.Opts.DialContext
+	helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		mu.Lock()
+		dials[addr]++
+		n := dials[addr]
+		mu.Unlock()
+		if strings.HasPrefix(addr, "nx.example.com") {
+			return nil, &net.OpError{Op: "dial", Net: network, Err: &net.DNSError{Err: "no such host", Name: "nx.example.com", IsNotFound: true}}
+		}
+		if n <= 2 {
+			return nil, &net.OpError{Op: "dial", Net: network, Err: syscall.ECONNREFUSED}
+		}
+		return dial(ctx, network, addr)
+	}
+
+	testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+	resp, err := helper.getProxyClient().Get("http://nx.example.com/")
+	handleError(t, err)
+	resp.Body.Close()
+	if resp.StatusCode != 502 {
+		t.Fatalf("expected 502, got %v", resp.StatusCode)
+	}
+
+	mu.Lock()
+	if dials["example.com:80"] != 3 || dials["example.com:443"] != 3 || dials["nx.example.com:80"] != 1 {
+		t.Fatalf("unexpected dials %v", dials)
+	}
+	mu.Unlock()
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	if addon.connected != 2 || !slices.Equal(addon.retries, []int{2, 2}) {
+		t.Fatalf("expected ServerConnected twice and 2 retries each, got %v %v", addon.connected, addon.retries)
+	}
+}
+
+type testConnectAddon struct {
+	BaseAddon
+	mu        sync.Mutex
+	addresses []string
+	sni       []string
+	errs      []error
+}
+
+func (addon *testConnectAddon) Connect(connCtx *ConnContext, host string) (string, error) {
+	switch host {
+	case "alias.com:443":
+		return "example.com:443", nil
+	case "blocked.com:443":
+		return "", errors.New("blocked")
+	}
+	return "", nil
+}
+
+func (addon *testConnectAddon) Response(f *Flow) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	info, _ := f.ConnContext.ServerConn.TlsInfo()
+	addon.addresses = append(addon.addresses, f.ConnContext.ServerConn.Address)
+	addon.sni = append(addon.sni, info.ServerName)
+}
+
+func (addon *testConnectAddon) FlowError(f *Flow) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.errs = append(addon.errs, f.Error)
+}
+
+func TestConnectHandler(t *testing.T) {
+	for _, upstreamCert := range []bool{true, false} {
+		helper := &testPipeHelper{}
+		helper.init(t)
+		addon := &testConnectAddon{}
+		helper.testProxy.AddAddon(NewUpstreamCertAddon(upstreamCert))
+		helper.testProxy.AddAddon(addon)
+
+		testSendRequest(t, "https://alias.com/", helper.getProxyClient(), "ok")
+		_, err := helper.getProxyClient().Get("https://blocked.com/")
+		if err == nil || !strings.Contains(err.Error(), "Bad Gateway") {
+			t.Fatalf("expected 502 of CONNECT, got %v", err)
+		}
+
+		addon.mu.Lock()
+		if !slices.Equal(addon.addresses, []string{"example.com:443"}) || !slices.Equal(addon.sni, []string{"example.com"}) {
+			t.Fatalf("expected the rewritten address and sni, got %v %v", addon.addresses, addon.sni)
+		}
+		if len(addon.errs) != 1 || addon.errs[0].Error() != "blocked" {
+			t.Fatalf("expected the error of Connect, got %v", addon.errs)
+		}
+		addon.mu.Unlock()
+		helper.close()
+	}
+}
+
+// the errors of the header limits of both transports are recognized by responseHeadersErr
+func TestResponseHeadersErr(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 50; i++ {
+			http.SetCookie(w, &http.Cookie{Name: fmt.Sprintf("c%v", i), Value: strings.Repeat("v", 100)})
+		}
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	transports := map[string]http.RoundTripper{
+		"h1": &http.Transport{
+			TLSClientConfig:        &tls.Config{InsecureSkipVerify: true},
+			MaxResponseHeaderBytes: 1024,
+		},
+		"h2": &http2.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			MaxHeaderListSize: 1024,
+		},
+	}
+	for name, transport := range transports {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", server.URL, nil)
+			handleError(t, err)
+			resp, err := transport.RoundTrip(req)
+			if err == nil {
+				resp.Body.Close()
+				t.Fatalf("expected error of the header limit, but got %v %v", resp.Proto, resp.Status)
+			}
+			if err := responseHeadersErr(err); !errors.Is(err, ErrUpstreamHeadersTooLarge) {
+				t.Fatalf("expected ErrUpstreamHeadersTooLarge, but got %v", err)
+			}
+		})
+	}
+	if err := responseHeadersErr(io.ErrUnexpectedEOF); errors.Is(err, ErrUpstreamHeadersTooLarge) {
+		t.Fatalf("expected other errors not marked, but got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+)
+
+// Replay send the request of the flow again, return the new flow.
+func (proxy *Proxy) Replay(flow *Flow) (*Flow, error) {
+	return proxy.ReplayModified(flow, nil)
+}
+
+// ReplayModified send a clone of the request of the flow, modified by modify before sending, return the new flow.
+// The original flow is untouched. The request is sent by the separate client on fresh connections.
+// Request and Response of addons are triggered for the new flow with Flow.Replay set, its ConnContext is nil.
+func (proxy *Proxy) ReplayModified(flow *Flow, modify func(*Request)) (*Flow, error) {
+	return proxy.ReplayWithOptions(flow, ReplayOptions{Modify: modify})
+}
+
+// ReplayOptions options of Proxy.ReplayWithOptions
+type ReplayOptions struct {
+	Modify func(*Request) // modify the cloned request before sending, as ReplayModified
+
+	// send the request to upstream with the tls parameters of the original client in Flow.ClientTLS:
+	// versions, cipher suites, curves, ALPN and SNI, as far as crypto/tls supports them.
+	// Ignored for plain http flows.
+	ClientTLS bool
+}
+
+// ReplayWithOptions is like ReplayModified, with more options.
+func (proxy *Proxy) ReplayWithOptions(flow *Flow, opts ReplayOptions) (*Flow, error) {
+	modify := opts.Modify
+	if flow == nil || flow.Request == nil {
+		return nil, errors.New("no request to replay")
+	}
+	if flow.Request.Method == http.MethodConnect {
+		return nil, errors.New("can not replay CONNECT request")
+	}
+
+	req := flow.Request.snapshot()
+	req.raw = nil
+	f := proxy.newFlow()
+	f.Replay = true
+	f.OriginalRequest = req.snapshot()
+	if modify != nil {
+		modify(req)
+	}
+	f.setRequest(req)
+	defer proxy.finishFlow(f)
+
+	// the response set by addons is returned without sending the request, as attacker does
+	for _, addon := range proxy.hooks.request {
+		f.timeAddon(func() { addon.Request(f) })
+		if proxy.addonFailed(f) {
+			return f, f.Error
+		}
+		if f.Response != nil {
+			for _, addon := range proxy.hooks.response {
+				f.timeAddon(func() { addon.Response(f) })
+			}
+			if proxy.addonFailed(f) {
+				return f, f.Error
+			}
+			return f, nil
+		}
+	}
+
+	proxyReq, err := http.NewRequest(req.Method, req.URL.String(), bytes.NewReader(req.Body))
+	if err != nil {
+		f.Error = err
+		return f, err
+	}
+	for key, value := range req.Header {
+		for _, v := range value {
+			proxyReq.Header.Add(key, v)
+		}
+	}
+	if host := req.Header.Get("Host"); host != "" {
+		proxyReq.Host = host
+	}
+	// the upstream proxy of attacker client is selected by the request in context
+	proxyReq = proxyReq.WithContext(context.WithValue(context.Background(), proxyReqCtxKey, proxyReq))
+
+	client := proxy.attacker.client
+	if opts.ClientTLS && flow.ClientTLS != nil && proxyReq.URL.Scheme == "https" {
+		f.ClientTLS = flow.ClientTLS.clone()
+		client = proxy.attacker.clientWithTLS(flow.ClientTLS)
+		defer client.CloseIdleConnections()
+	}
+
+	proxyRes, err := client.Do(proxyReq)
+	if err != nil {
+		f.Error = err
+		return f, err
+	}
+	defer proxyRes.Body.Close()
+
+	body, err := io.ReadAll(proxyRes.Body)
+	if err != nil {
+		f.Error = err
+		return f, err
+	}
+	f.Response = &Response{
+		StatusCode: proxyRes.StatusCode,
+		Header:     proxyRes.Header,
+		Body:       body,
+		RawBody:    body,
+		Trailer:    proxyRes.Trailer,
+		close:      proxyRes.Close,
+
+		maxDecodedSize: proxy.Opts.MaxDecompressedSize,
+	}
+	f.OriginalResponse = f.Response.snapshot()
+	if proxy.Opts.PreserveEncoding {
+		f.Response.decodeForAddons()
+	}
+	for _, addon := range proxy.hooks.response {
+		f.timeAddon(func() { addon.Response(f) })
+	}
+	if proxy.addonFailed(f) {
+		return f, f.Error
+	}
+	if proxy.Opts.PreserveEncoding {
+		f.Response.encodePreserved()
+	}
+	return f, nil
+}
+
+// a one-off client like the separate client, with the tls parameters of hello
+func (a *attacker) clientWithTLS(hello *TlsClientHello) *http.Client {
+	transport := a.client.Transport.(*http.Transport).Clone()
+	hello.applyTo(transport.TLSClientConfig)
+	if !slices.Contains(hello.ALPN, "h2") {
+		// not to add h2 to NextProtos
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	client := *a.client
+	client.Transport = transport
+	return &client
+}
@@ -0,0 +1,143 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestReplayModified(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testFlowTimeAddon{flows: make(chan *Flow, 2)}
+	helper.testProxy.AddAddon(addon)
+
+	resp, err := helper.getProxyClient().Post("https://example.com/echo", "text/plain", strings.NewReader("token=old"))
+	handleError(t, err)
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	flow := <-addon.flows
+	if _, err := helper.testProxy.Replay(flow); err == nil {
+		t.Fatal("expected error replaying CONNECT")
+	}
+	flow = <-addon.flows
+
+	f, err := helper.testProxy.ReplayModified(flow, func(req *Request) {
+		req.Header.Set("Authorization", "Bearer new")
+		req.Body = []byte("token=new")
+	})
+	handleError(t, err)
+	if f.Id == flow.Id || f.Response == nil || string(f.Response.Body) != "token=new" {
+		t.Fatalf("expected replayed response token=new, but got %+v", f.Response)
+	}
+	if f.OriginalRequest.Header.Get("Authorization") != "" || string(f.OriginalRequest.Body) != "token=old" {
+		t.Fatal("expected OriginalRequest is the request before modify")
+	}
+	if flow.Request.Header.Get("Authorization") != "" || string(flow.Request.Body) != "token=old" {
+		t.Fatal("expected the original flow untouched")
+	}
+
+	f, err = helper.testProxy.Replay(flow)
+	handleError(t, err)
+	if string(f.Response.Body) != "token=old" {
+		t.Fatalf("expected token=old, but got %s", f.Response.Body)
+	}
+}
+
+type testReplayAddon struct {
+	BaseAddon
+	mu    sync.Mutex
+	hooks []string
+}
+
+func (addon *testReplayAddon) Request(f *Flow) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.hooks = append(addon.hooks, fmt.Sprintf("request %v %v", f.Request.URL.Path, f.Replay))
+	f.Request.Body = []byte("replay " + strconv.FormatBool(f.Replay))
+}
+
+func (addon *testReplayAddon) Response(f *Flow) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.hooks = append(addon.hooks, fmt.Sprintf("response %v %v", f.Request.URL.Path, f.Replay))
+}
+
+func TestReplayAddons(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testReplayAddon{}
+	helper.testProxy.AddAddon(addon)
+
+	newFlow := func(rawURL string) *Flow {
+		u, _ := url.Parse(rawURL)
+		return &Flow{Request: &Request{Method: "GET", URL: u, Header: http.Header{}}}
+	}
+
+	f, err := helper.testProxy.Replay(newFlow("https://example.com/echo"))
+	handleError(t, err)
+	if !f.Replay || string(f.Response.Body) != "replay true" {
+		t.Fatalf("expected the request modified in Request, got %+v", f.Response)
+	}
+	// interceptAddon replies in Request, the request is not sent and the Request of later addons is skipped
+	f, err = helper.testProxy.Replay(newFlow("http://example.com/intercept-request"))
+	handleError(t, err)
+	if string(f.Response.Body) != "intercept-request" || f.EffectiveUpstreamURL() != nil {
+		t.Fatalf("expected the response of addon, got %+v", f.Response)
+	}
+
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	want := []string{"request /echo true", "response /echo true", "response /intercept-request true"}
+	if !slices.Equal(addon.hooks, want) {
+		t.Fatalf("expected hooks %v, got %v", want, addon.hooks)
+	}
+}
+
+func TestReplayClientTLS(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testFlowTimeAddon{flows: make(chan *Flow, 2)}
+	helper.testProxy.AddAddon(addon)
+
+	client := helper.getProxyClient()
+	client.Transport.(*http.Transport).TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: true,
+		MaxVersion:         tls.VersionTLS12,
+		CipherSuites:       []uint16{tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384},
+	}
+	resp, err := client.Get("https://example.com/tls")
+	handleError(t, err)
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	<-addon.flows // CONNECT
+	flow := <-addon.flows
+	if flow.ClientTLS == nil || !slices.Contains(flow.ClientTLS.CipherSuites, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384) {
+		t.Fatalf("expected ClientTLS of flow, got %+v", flow.ClientTLS)
+	}
+
+	f, err := helper.testProxy.Replay(flow)
+	handleError(t, err)
+	if body := string(f.Response.Body); !strings.HasPrefix(body, "TLS 1.3") {
+		t.Fatalf("expected default tls parameters, got %v", body)
+	}
+
+	f, err = helper.testProxy.ReplayWithOptions(flow, ReplayOptions{ClientTLS: true})
+	handleError(t, err)
+	if body := string(f.Response.Body); body != "TLS 1.2 TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384 HTTP/1.1" {
+		t.Fatalf("expected tls parameters of client, got %v", body)
+	}
+	if f.ClientTLS == nil {
+		t.Fatal("expected ClientTLS of replayed flow")
+	}
+}
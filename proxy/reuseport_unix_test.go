@@ -0,0 +1,35 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package proxy
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestReusePort(t *testing.T) {
+	errCh := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		p, err := NewProxy(&Options{Addr: "127.0.0.1:29095", ReusePort: true})
+		handleError(t, err)
+		defer p.Close()
+		go func() {
+			errCh <- p.Start()
+		}()
+	}
+
+	select {
+	case err := <-errCh:
+		t.Fatalf("expected both proxies listen on the same port, but got %v", err)
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	// the proxy server itself responds 400 to non proxy request
+	resp, err := http.Get("http://127.0.0.1:29095/")
+	handleError(t, err)
+	resp.Body.Close()
+	if resp.StatusCode != 400 {
+		t.Fatalf("expected 400, but got %v", resp.StatusCode)
+	}
+}
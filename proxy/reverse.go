@@ -0,0 +1,97 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/lqqyt2423/go-mitmproxy/internal/helper"
+)
+
+// ReverseRoute the upstream of the requests matching Match in reverse proxy mode, see Options.ReverseRoutes
+type ReverseRoute struct {
+	// path prefix such as "/api", host such as "api.example.com" or "*.example.com", or both such as "example.com/api"
+	// the path prefix matches the whole segments, "/api" matches "/api/users" but not "/apis"
+	Match    string
+	Upstream *url.URL
+}
+
+func (r *ReverseRoute) match(host, path string) bool {
+	h, prefix, _ := strings.Cut(r.Match, "/")
+	if h != "" && !helper.MatchHost(host, []string{h}) {
+		return false
+	}
+	if prefix = strings.TrimSuffix(prefix, "/"); prefix == "" {
+		return true
+	}
+	rest, ok := strings.CutPrefix(path, "/"+prefix)
+	return ok && (rest == "" || rest[0] == '/')
+}
+
+// whether the proxy is in reverse proxy mode, by Options.ReverseUpstream or Options.ReverseRoutes
+func (proxy *Proxy) isReverse() bool {
+	return proxy.Opts.ReverseUpstream != nil || len(proxy.Opts.ReverseRoutes) > 0
+}
+
+// upstream of the request in Options.ReverseRoutes, the first matched, or Options.ReverseUpstream
+func (proxy *Proxy) reverseUpstream(host, path string) *url.URL {
+	for i := range proxy.Opts.ReverseRoutes {
+		if route := &proxy.Opts.ReverseRoutes[i]; route.match(host, path) {
+			return route.Upstream
+		}
+	}
+	return proxy.Opts.ReverseUpstream
+}
+
+// send the request to its upstream of reverse proxy mode whatever its host is, the Host requested by client is sent in X-Forwarded-Host
+// return false if no upstream matches
+func (proxy *Proxy) reverseRequest(req *http.Request) bool {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	upstream := proxy.reverseUpstream(host, req.URL.Path)
+	if upstream == nil {
+		return false
+	}
+	if host != "" && req.Header.Get("X-Forwarded-Host") == "" {
+		req.Header.Set("X-Forwarded-Host", host)
+	}
+	req.URL.Scheme = upstream.Scheme
+	req.URL.Host = upstream.Host
+	if prefix := strings.TrimSuffix(upstream.Path, "/"); prefix != "" {
+		if req.URL.RawPath != "" {
+			req.URL.RawPath = strings.TrimSuffix(upstream.EscapedPath(), "/") + req.URL.EscapedPath()
+		}
+		req.URL.Path = prefix + req.URL.Path
+	}
+	req.Host = upstream.Host
+	return true
+}
+
+// check the upstream of reverse proxy mode
+func validReverseUpstream(u *url.URL) error {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid reverse upstream %v, expected http:// or https:// with host", u)
+	}
+	return nil
+}
+
+// tls config of the https server of the plain http request, such as of Options.ReverseUpstream
+func (proxy *Proxy) httpsServerTLSConfig(connCtx *ConnContext, serverName, addr string) *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify: proxy.Opts.SslInsecure || connCtx.SkipUpstreamVerify,
+		RootCAs:            proxy.Opts.UpstreamRootCAs,
+		KeyLogWriter:       proxy.serverKeyLogWriter(),
+		ServerName:         serverName,
+		NextProtos:         []string{"http/1.1"},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if c := proxy.clientCert(addr); c != nil {
+				return c, nil
+			}
+			return &tls.Certificate{}, nil
+		},
+	}
+}
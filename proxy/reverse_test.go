@@ -0,0 +1,105 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestReverseUpstream(t *testing.T) {
+	upstream, _ := url.Parse("http://backend.example.com")
+	flows := make(chan *Flow, 1)
+	helper := &testPipeHelper{opts: &Options{ReverseUpstream: upstream, OnFlowComplete: func(f *Flow) { flows <- f }}}
+	helper.init(t)
+	defer helper.close()
+	get := func(t *testing.T, u, want, wantURL string) {
+		t.Helper()
+		// the upstream connection is kept by the client connection, a new one for each upstream
+		client := &http.Client{Transport: &http.Transport{DialContext: helper.dial}}
+		testSendRequest(t, u, client, want)
+		f := <-flows
+		if f.Request.URL.String() != wantURL {
+			t.Fatalf("expected request to %v, but got %v", wantURL, f.Request.URL)
+		}
+	}
+
+	t.Run("http", func(t *testing.T) {
+		get(t, "http://front.local/header?name=X-Forwarded-Host", "front.local", "http://backend.example.com/header?name=X-Forwarded-Host")
+		// absolute-form of forward proxy is sent to upstream too
+		testSendRequest(t, "http://other.com/header?name=X-Forwarded-Host", helper.getProxyClient(), "other.com")
+		<-flows
+	})
+
+	t.Run("https", func(t *testing.T) {
+		helper.testProxy.Opts.ReverseUpstream, _ = url.Parse("https://example.com/base/")
+		get(t, "http://front.local/path?q=1", "ok", "https://example.com/base/path?q=1")
+	})
+
+	t.Run("refuse connect", func(t *testing.T) {
+		conn, err := helper.dial(context.Background(), "tcp", "proxy.pipe")
+		handleError(t, err)
+		defer conn.Close()
+		io.WriteString(conn, "CONNECT example.com:443 HTTP/1.1\r\nHost: example.com:443\r\n\r\n")
+		resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+		handleError(t, err)
+		if resp.StatusCode != 405 {
+			t.Fatalf("expected 405, but got %v", resp.StatusCode)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		u, _ := url.Parse("backend:8080")
+		if _, err := NewProxy(&Options{ReverseUpstream: u}); err == nil {
+			t.Fatal("expected error of upstream without scheme")
+		}
+	})
+}
+
+func TestReverseRoutes(t *testing.T) {
+	parse := func(s string) *url.URL {
+		u, err := url.Parse(s)
+		handleError(t, err)
+		return u
+	}
+	flows := make(chan *Flow, 1)
+	helper := &testPipeHelper{opts: &Options{
+		ReverseRoutes: []ReverseRoute{
+			{Match: "/api", Upstream: parse("http://api.example.com")},
+			{Match: "admin.example.com", Upstream: parse("https://example.com/admin")},
+		},
+		ReverseUpstream: parse("http://web.example.com"),
+		OnFlowComplete:  func(f *Flow) { flows <- f },
+	}}
+	helper.init(t)
+	defer helper.close()
+	// requests of the same client connection to several upstreams
+	client := &http.Client{Transport: &http.Transport{DialContext: helper.dial, MaxConnsPerHost: 1}}
+
+	for _, c := range []struct {
+		url     string
+		wantURL string
+	}{
+		{"http://front.local/api/users", "http://api.example.com/api/users"},
+		{"http://front.local/", "http://web.example.com/"},
+		{"http://front.local/apis", "http://web.example.com/apis"},
+		{"http://front.local/api", "http://api.example.com/api"},
+		{"http://admin.example.com/x", "https://example.com/admin/x"},
+	} {
+		testSendRequest(t, c.url, client, "ok")
+		if f := <-flows; f.Request.URL.String() != c.wantURL {
+			t.Fatalf("expected %v sent to %v, but got %v", c.url, c.wantURL, f.Request.URL)
+		}
+	}
+
+	// no default upstream
+	helper.testProxy.Opts.ReverseUpstream = nil
+	resp, err := client.Get("http://front.local/")
+	handleError(t, err)
+	resp.Body.Close()
+	if resp.StatusCode != 404 {
+		t.Fatalf("expected 404 of no route, but got %v", resp.StatusCode)
+	}
+}
@@ -0,0 +1,143 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// interval to check the modification of Options.RulesFile
+var rulesPollInterval = time.Second
+
+// RulesFile the json content of Options.RulesFile, such as
+//
+//	{
+//	  "HostMap": {"api.example.com": "127.0.0.1:8080"},
+//	  "BodyStubs": [
+//	    {"ContentTypeFilter": "image/*", "Body": ""},
+//	    {"StatusFilter": [500, 502], "Body": "please try again later", "ContentType": "text/plain"},
+//	    {"URLFilter": "*example.com/config*", "BodyFile": "config.json", "ContentType": "application/json"}
+//	  ]
+//	}
+//
+// The rules are applied after the ones of Options.
+type RulesFile struct {
+	HostMap   map[string]string // same as Options.HostMap
+	BodyStubs []RulesBodyStub   // same as Options.BodyStubs
+}
+
+// RulesBodyStub BodyStub in RulesFile, the body is text or read from BodyFile
+type RulesBodyStub struct {
+	ContentTypeFilter string
+	URLFilter         string
+	StatusFilter      []int
+	HeaderFilter      []string
+	Body              string
+	BodyFile          string // relative to the directory of the rules file
+	ContentType       string
+	StatusCode        int
+	Header            map[string]string
+}
+
+type loadedRules struct {
+	hostMap   map[string]string
+	bodyStubs []BodyStub
+	modTime   time.Time
+	size      int64
+}
+
+func loadRulesFile(filename string) (*loadedRules, error) {
+	stat, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	file := new(RulesFile)
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("parse rules file %v: %w", filename, err)
+	}
+
+	rules := &loadedRules{
+		hostMap: file.HostMap,
+		modTime: stat.ModTime(),
+		size:    stat.Size(),
+	}
+	for _, stub := range file.BodyStubs {
+		body := []byte(stub.Body)
+		if stub.BodyFile != "" {
+			bodyFile := stub.BodyFile
+			if !filepath.IsAbs(bodyFile) {
+				bodyFile = filepath.Join(filepath.Dir(filename), bodyFile)
+			}
+			if body, err = os.ReadFile(bodyFile); err != nil {
+				return nil, err
+			}
+		}
+		rules.bodyStubs = append(rules.bodyStubs, BodyStub{
+			ContentTypeFilter: stub.ContentTypeFilter,
+			URLFilter:         stub.URLFilter,
+			StatusFilter:      stub.StatusFilter,
+			HeaderFilter:      stub.HeaderFilter,
+			Body:              body,
+			ContentType:       stub.ContentType,
+			StatusCode:        stub.StatusCode,
+			Header:            stub.Header,
+		})
+	}
+	return rules, nil
+}
+
+// reload Options.RulesFile when it changes, from Start until the proxy is closed
+// an invalid file is logged and the previous rules are kept
+func (proxy *Proxy) watchRulesFile() {
+	filename := proxy.Opts.RulesFile
+	ticker := time.NewTicker(rulesPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-proxy.done:
+			return
+		case <-ticker.C:
+		}
+		stat, err := os.Stat(filename)
+		if err != nil {
+			log.Warnf("rules file: %v", err)
+			continue
+		}
+		current := proxy.rules.Load()
+		if stat.ModTime().Equal(current.modTime) && stat.Size() == current.size {
+			continue
+		}
+		rules, err := loadRulesFile(filename)
+		if err != nil {
+			log.Warnf("reload rules file error, keep the previous rules: %v", err)
+			// not retry until the file changes again
+			current = &loadedRules{hostMap: current.hostMap, bodyStubs: current.bodyStubs, modTime: stat.ModTime(), size: stat.Size()}
+			proxy.rules.Store(current)
+			continue
+		}
+		proxy.rules.Store(rules)
+		log.Infof("rules file reloaded: %v", filename)
+	}
+}
+
+func (proxy *Proxy) rulesHostMap() map[string]string {
+	if rules := proxy.rules.Load(); rules != nil {
+		return rules.hostMap
+	}
+	return nil
+}
+
+func (proxy *Proxy) rulesBodyStubs() []BodyStub {
+	if rules := proxy.rules.Load(); rules != nil {
+		return rules.bodyStubs
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package proxy
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRulesFile(t *testing.T) {
+	pollInterval := rulesPollInterval
+	rulesPollInterval = 10 * time.Millisecond
+	defer func() { rulesPollInterval = pollInterval }()
+
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "rules.json")
+	writeRules := func(content string) {
+		handleError(t, os.WriteFile(filename, []byte(content), 0644))
+	}
+	handleError(t, os.WriteFile(filepath.Join(dir, "stub.txt"), []byte("from file"), 0644))
+	writeRules(`{"BodyStubs": [{"URLFilter": "*/stub*", "Body": "stubbed"}]}`)
+
+	helper := &testPipeHelper{opts: &Options{RulesFile: filename}}
+	helper.init(t)
+	defer helper.close()
+	client := helper.getProxyClient()
+
+	testSendRequest(t, "http://example.com/stub", client, "stubbed")
+	testSendRequest(t, "http://example.com/", client, "ok")
+
+	waitReload := func(url, expected string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			resp, err := client.Get(url)
+			handleError(t, err)
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if string(body) == expected {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %v after reload, got %v", expected, string(body))
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	writeRules(`{"BodyStubs": [{"URLFilter": "*/stub*", "BodyFile": "stub.txt"}]}`)
+	waitReload("http://example.com/stub", "from file")
+
+	// invalid file keeps the previous rules
+	writeRules(`{"BodyStubs": [`)
+	time.Sleep(50 * time.Millisecond)
+	testSendRequest(t, "http://example.com/stub", client, "from file")
+
+	writeRules(`{}`)
+	waitReload("http://example.com/stub", "ok")
+
+	helper.testProxy.Close()
+	select {
+	case <-helper.testProxy.done:
+	default:
+		t.Fatal("expected the rules watcher stopped by Close")
+	}
+
+	if _, err := NewProxy(&Options{Addr: ":29080", RulesFile: filepath.Join(dir, "missing.json")}); err == nil {
+		t.Fatal("expected error of missing rules file")
+	}
+}
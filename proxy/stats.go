@@ -0,0 +1,90 @@
+package proxy
+
+import (
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ProxyStats gauges and counters of the proxy, returned by Proxy.Stats
+type ProxyStats struct {
+	ActiveClientConns int64 // client connections not closed yet
+	ActiveServerConns int64 // server connections not closed yet, tunnels not intercepted are not counted
+	InFlightFlows     int64 // flows not finished yet
+	AcceptedConns     int64 // total client connections accepted
+	ClosedConns       int64 // total client connections closed
+	RejectedConns     int64 // total client connections over Options.MaxNewConnsPerIPPerSec, not accepted unless Options.ConnRateLogOnly
+	BufferedBytes     int64 // bodies buffered by flows not finished yet, limited by Options.MaxTotalBufferedBytes
+	WrittenBytes      int64 // total bytes written to clients and servers, its rate is limited by Options.GlobalBytesPerSec
+
+	// upstream queues of Options.MaxConcurrentPerHost, for all hosts
+	QueueActive   int64  // requests holding a slot
+	QueueWaiting  int64  // requests waiting in the queues
+	QueueRejected uint64 // total requests rejected because of full queue or wait timeout
+}
+
+type proxyCounters struct {
+	activeServerConns int64
+	inFlightFlows     int64
+	acceptedConns     int64
+	closedConns       int64
+	bufferedBytes     int64
+	rejectedConns     int64
+	writtenBytes      int64
+}
+
+// Stats return the current stats of the proxy, can be polled for monitoring
+func (proxy *Proxy) Stats() ProxyStats {
+	stats := ProxyStats{
+		ActiveClientConns: int64(proxy.conns.len()),
+		ActiveServerConns: atomic.LoadInt64(&proxy.counters.activeServerConns),
+		InFlightFlows:     atomic.LoadInt64(&proxy.counters.inFlightFlows),
+		AcceptedConns:     atomic.LoadInt64(&proxy.counters.acceptedConns),
+		ClosedConns:       atomic.LoadInt64(&proxy.counters.closedConns),
+		RejectedConns:     atomic.LoadInt64(&proxy.counters.rejectedConns),
+		BufferedBytes:     atomic.LoadInt64(&proxy.counters.bufferedBytes),
+		WrittenBytes:      atomic.LoadInt64(&proxy.counters.writtenBytes),
+	}
+	if l := proxy.limiter; l != nil {
+		stats.QueueActive = atomic.LoadInt64(&l.active)
+		stats.QueueWaiting = atomic.LoadInt64(&l.waiting)
+		stats.QueueRejected = atomic.LoadUint64(&l.rejected)
+	}
+	return stats
+}
+
+// new flow counted in ProxyStats.InFlightFlows until finishFlow
+func (proxy *Proxy) newFlow() *Flow {
+	atomic.AddInt64(&proxy.counters.inFlightFlows, 1)
+	return newFlow(proxy.newId())
+}
+
+// limit to buffer the next body of f, with the budget of Options.MaxTotalBufferedBytes left
+// a body reaching the limit is streamed, f is streamed at once when the budget is used up
+func (proxy *Proxy) bufferLimit(f *Flow) int64 {
+	limit := proxy.Opts.StreamLargeBodies
+	if max := proxy.Opts.MaxTotalBufferedBytes; max > 0 {
+		limit = min(limit, max-atomic.LoadInt64(&proxy.counters.bufferedBytes))
+		if limit <= 0 && !f.Stream {
+			log.Warnf("buffered bodies >= %v, stream %v", max, f.Request.URL)
+			f.Stream = true
+		}
+	}
+	return limit
+}
+
+// limit of the next body of f for bufferLimit, true if the body reaching it is truncated for addons by Options.MaxBodySize
+// instead of streamed without calling the addons
+func (proxy *Proxy) bodyLimit(f *Flow) (int64, bool) {
+	limit := proxy.bufferLimit(f)
+	if max := proxy.Opts.MaxBodySize; max > 0 && max <= limit {
+		return max, true
+	}
+	return limit, false
+}
+
+// counted in ProxyStats.BufferedBytes until finishFlow
+func (proxy *Proxy) addBuffered(f *Flow, n int) {
+	f.bufferedBytes += int64(n)
+	atomic.AddInt64(&proxy.counters.bufferedBytes, int64(n))
+}
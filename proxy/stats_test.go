@@ -0,0 +1,121 @@
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProxyStats(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	// WrittenBytes depends on the tls records and is checked once
+	getStats := func() ProxyStats {
+		stats := helper.testProxy.Stats()
+		stats.WrittenBytes = 0
+		return stats
+	}
+
+	client := helper.getProxyClient()
+	testSendRequest(t, "https://example.com/", client, "ok")
+	if helper.testProxy.Stats().WrittenBytes == 0 {
+		t.Fatal("expected written bytes")
+	}
+	stats := getStats()
+	// keep-alive connections
+	expected := ProxyStats{ActiveClientConns: 1, ActiveServerConns: 1, AcceptedConns: 1}
+	if stats != expected {
+		t.Fatalf("expected %+v, got %+v", expected, stats)
+	}
+
+	client.CloseIdleConnections()
+	expected = ProxyStats{AcceptedConns: 1, ClosedConns: 1}
+	deadline := time.Now().Add(time.Second)
+	for stats = getStats(); stats != expected; stats = getStats() {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %+v, got %+v", expected, stats)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+type testBufferBudgetAddon struct {
+	BaseAddon
+	hold      chan struct{}
+	responses chan string
+}
+
+func (addon *testBufferBudgetAddon) Response(f *Flow) {
+	if f.Request.Header.Get("X-Hold") != "" {
+		<-addon.hold
+	}
+	addon.responses <- string(f.Response.Body)
+}
+
+func TestMaxTotalBufferedBytes(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{MaxTotalBufferedBytes: 30000}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testBufferBudgetAddon{hold: make(chan struct{}), responses: make(chan string, 10)}
+	helper.testProxy.AddAddon(addon)
+
+	post := func(body string, hold bool) error {
+		req, err := http.NewRequest("POST", "http://example.com/echo", strings.NewReader(body))
+		if err != nil {
+			return err
+		}
+		if hold {
+			req.Header.Set("X-Hold", "1")
+		}
+		resp, err := helper.getProxyClient().Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		got, err := io.ReadAll(resp.Body)
+		if err == nil && string(got) != body {
+			err = fmt.Errorf("unexpected body of %v bytes", len(got))
+		}
+		return err
+	}
+	waitBuffered := func(n int64) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for helper.testProxy.Stats().BufferedBytes != n {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %v bytes buffered, got %v", n, helper.testProxy.Stats().BufferedBytes)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	large := strings.Repeat("a", 8000)
+	held := make(chan error, 1)
+	go func() { held <- post(large, true) }()
+	// the body and its copy of both request and response
+	waitBuffered(32000)
+
+	// budget used up, the flow is streamed without Response
+	handleError(t, post("small", false))
+	select {
+	case body := <-addon.responses:
+		t.Fatalf("expected streamed flow, got Response of %q", body)
+	default:
+	}
+
+	close(addon.hold)
+	handleError(t, <-held)
+	if body := <-addon.responses; body != large {
+		t.Fatalf("unexpected body of %v bytes", len(body))
+	}
+	waitBuffered(0)
+
+	handleError(t, post("small", false))
+	if body := <-addon.responses; body != "small" {
+		t.Fatalf("expected buffered again after memory freed, got %q", body)
+	}
+}
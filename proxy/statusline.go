@@ -0,0 +1,119 @@
+package proxy
+
+import (
+	"net"
+	"sync"
+)
+
+const maxRawStatusLine = 8 << 10
+
+const (
+	statusLineIdle    = iota // the body or nothing is being read
+	statusLineReading        // the status line is being read
+	statusLineHeaders        // the headers after the status line are being read
+)
+
+// record the status lines of http/1 responses as read from server, before http.Transport parses them
+type statusLineConn struct {
+	net.Conn
+
+	mu         sync.Mutex
+	state      int
+	line       []byte // the status line being read
+	headerLine int    // length of the header line being read, without \r
+	last       []byte // the final status line of the last response, 1xx are skipped
+}
+
+func newStatusLineConn(c net.Conn) *statusLineConn {
+	return &statusLineConn{Conn: c}
+}
+
+// a request is written, the next bytes read start with the status line
+func (c *statusLineConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	if c.state == statusLineIdle {
+		c.state = statusLineReading
+		c.line = nil
+	}
+	c.mu.Unlock()
+	return c.Conn.Write(b)
+}
+
+func (c *statusLineConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.mu.Lock()
+		c.scan(b[:n])
+		c.mu.Unlock()
+	}
+	return n, err
+}
+
+func (c *statusLineConn) scan(data []byte) {
+	for _, ch := range data {
+		switch c.state {
+		case statusLineIdle:
+			return
+		case statusLineReading:
+			if len(c.line) < maxRawStatusLine {
+				c.line = append(c.line, ch)
+			}
+			if ch == '\n' {
+				c.state = statusLineHeaders
+				c.headerLine = 0
+			}
+		case statusLineHeaders:
+			if ch == '\n' {
+				if c.headerLine == 0 {
+					c.endHeaders()
+				}
+				c.headerLine = 0
+			} else if ch != '\r' {
+				c.headerLine++
+			}
+		}
+	}
+}
+
+// http.Transport skips 1xx responses except 101, the status line of the next response is read then
+func (c *statusLineConn) endHeaders() {
+	if code := rawStatusCode(c.line); code >= 100 && code < 200 && code != 101 {
+		c.state = statusLineReading
+		c.line = nil
+		return
+	}
+	c.last = c.line
+	c.line = nil
+	c.state = statusLineIdle
+}
+
+// the status line of the response just read, nil if none
+func (c *statusLineConn) take() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	line := c.last
+	c.last = nil
+	return line
+}
+
+// status code of "HTTP/1.1 200 OK", 0 if it is malformed
+func rawStatusCode(line []byte) int {
+	i := 0
+	for i < len(line) && line[i] != ' ' {
+		i++
+	}
+	for i < len(line) && line[i] == ' ' {
+		i++
+	}
+	if len(line) < i+3 {
+		return 0
+	}
+	code := 0
+	for _, ch := range line[i : i+3] {
+		if ch < '0' || ch > '9' {
+			return 0
+		}
+		code = code*10 + int(ch-'0')
+	}
+	return code
+}
@@ -0,0 +1,75 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+)
+
+type testRawStatusLineAddon struct {
+	BaseAddon
+	mu    sync.Mutex
+	lines []string
+}
+
+func (addon *testRawStatusLineAddon) Response(f *Flow) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.lines = append(addon.lines, string(f.Response.RawStatusLine))
+}
+
+func TestRawStatusLine(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testRawStatusLineAddon{}
+	helper.testProxy.AddAddon(addon)
+
+	// server sending an interim response and a status line with bare \n
+	rawLn := NewPipeListener()
+	defer rawLn.Close()
+	go func() {
+		for {
+			conn, err := rawLn.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				br := bufio.NewReader(conn)
+				for {
+					req, err := http.ReadRequest(br)
+					if err != nil {
+						return
+					}
+					io.Copy(io.Discard, req.Body)
+					io.WriteString(conn, "HTTP/1.1 100 Continue\r\n\r\nHTTP/1.1  200  Fine\nContent-Length: 2\n\nok")
+				}
+			}()
+		}
+	}()
+	dial := helper.testProxy.Opts.DialContext
+	helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if addr == "raw.example.com:80" {
+			return rawLn.DialContext(ctx, network, addr)
+		}
+		return dial(ctx, network, addr)
+	}
+
+	client := helper.getProxyClient()
+	testSendRequest(t, "http://raw.example.com/", client, "ok")
+	testSendRequest(t, "http://raw.example.com/", client, "ok")
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	want := []string{"HTTP/1.1  200  Fine\n", "HTTP/1.1  200  Fine\n", "HTTP/1.1 200 OK\r\n"}
+	if !slices.Equal(addon.lines, want) {
+		t.Fatalf("expected raw status lines %q, got %q", want, addon.lines)
+	}
+}
@@ -0,0 +1,65 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"net"
+)
+
+// Direction of the bytes passed to Options.OnClientBytes and Options.OnServerBytes
+type Direction int
+
+const (
+	DirectionRead  Direction = iota // read from the connection by proxy
+	DirectionWrite                  // written to the connection by proxy, reported before the write
+)
+
+func (d Direction) String() string {
+	if d == DirectionWrite {
+		return "write"
+	}
+	return "read"
+}
+
+// tap the plaintext bytes of a connection
+type tapConn struct {
+	net.Conn
+	connCtx *ConnContext
+	fn      func(connCtx *ConnContext, data []byte, direction Direction)
+}
+
+func (c *tapConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.fn(c.connCtx, b[:n], DirectionRead)
+	}
+	return n, err
+}
+
+// tap before writing, so the bytes are reported before the peer can reply to them
+func (c *tapConn) Write(b []byte) (int, error) {
+	if len(b) > 0 {
+		c.fn(c.connCtx, b, DirectionWrite)
+	}
+	return c.Conn.Write(b)
+}
+
+// keep ConnectionState of the tls connection, http2 uses it to check the negotiated protocol and tls version
+type tapTlsConn struct {
+	*tapConn
+	tlsConn *tls.Conn
+}
+
+func (c *tapTlsConn) ConnectionState() tls.ConnectionState {
+	return c.tlsConn.ConnectionState()
+}
+
+func newTapConn(c net.Conn, connCtx *ConnContext, fn func(connCtx *ConnContext, data []byte, direction Direction)) net.Conn {
+	if fn == nil {
+		return c
+	}
+	tc := &tapConn{Conn: c, connCtx: connCtx, fn: fn}
+	if tlsConn, ok := c.(*tls.Conn); ok {
+		return &tapTlsConn{tapConn: tc, tlsConn: tlsConn}
+	}
+	return tc
+}
@@ -0,0 +1,55 @@
+package proxy
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testByteTap struct {
+	mu  sync.Mutex
+	buf map[Direction]*strings.Builder
+}
+
+func (tap *testByteTap) fn(connCtx *ConnContext, data []byte, direction Direction) {
+	tap.mu.Lock()
+	defer tap.mu.Unlock()
+	if tap.buf == nil {
+		tap.buf = make(map[Direction]*strings.Builder)
+	}
+	if tap.buf[direction] == nil {
+		tap.buf[direction] = &strings.Builder{}
+	}
+	tap.buf[direction].Write(data)
+}
+
+func (tap *testByteTap) contains(t *testing.T, direction Direction, s string) {
+	t.Helper()
+	tap.mu.Lock()
+	defer tap.mu.Unlock()
+	if b := tap.buf[direction]; b == nil || !strings.Contains(b.String(), s) {
+		t.Fatalf("expected %v bytes contain %q", direction, s)
+	}
+}
+
+func TestByteTaps(t *testing.T) {
+	for _, endpoint := range []string{"http://example.com/", "https://example.com/"} {
+		t.Run(endpoint, func(t *testing.T) {
+			clientTap, serverTap := &testByteTap{}, &testByteTap{}
+			helper := &testPipeHelper{
+				opts: &Options{
+					OnClientBytes: clientTap.fn,
+					OnServerBytes: serverTap.fn,
+				},
+			}
+			helper.init(t)
+			defer helper.close()
+
+			testSendRequest(t, endpoint, helper.getProxyClient(), "ok")
+			clientTap.contains(t, DirectionRead, "GET ")
+			clientTap.contains(t, DirectionWrite, "HTTP/1.1 200 OK")
+			serverTap.contains(t, DirectionWrite, "GET / HTTP/1.1\r\nHost: example.com\r\n")
+			serverTap.contains(t, DirectionRead, "HTTP/1.1 200 OK")
+		})
+	}
+}
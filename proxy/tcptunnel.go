@@ -0,0 +1,79 @@
+package proxy
+
+import (
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// copy the bytes of the raw tcp tunnel of ConnContext.RawTcp both ways, passing them to TcpData of addons.
+// A half-close of one side is forwarded to the other, the tunnel ends when both directions end or one fails.
+// Return true if the server side ended first.
+func (proxy *Proxy) tcpTunnel(log *log.Entry, connCtx *ConnContext, server, client net.Conn) bool {
+	var once sync.Once
+	serverFirst := false
+	end := func(fromClient bool) {
+		once.Do(func() {
+			serverFirst = !fromClient
+		})
+	}
+
+	var aborted atomic.Bool
+	abort := func() {
+		aborted.Store(true)
+		// the disconnect hooks are triggered by the caller in the order the sides ended, the client is not closed here
+		server.Close()
+		client.SetDeadline(time.Unix(1, 0))
+		if wc, ok := client.(*wrapClientConn); ok {
+			// deadlines of the stream of h2 CONNECT are not supported
+			if _, ok := wc.Conn.(*h2StreamConn); ok {
+				client.Close()
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	pipe := func(dst, src net.Conn, fromClient bool) {
+		defer wg.Done()
+		var r io.Reader = src
+		if hooks := proxy.hooks.tcpData; len(hooks) > 0 {
+			r = newBodyChunkReader(src, func(data []byte) []byte {
+				for _, addon := range hooks {
+					data = addon.TcpData(connCtx, fromClient, data)
+				}
+				return data
+			})
+		}
+		_, err := io.Copy(dst, r)
+		end(fromClient)
+		if err != nil && !aborted.Load() {
+			logErr(log, err)
+		}
+		// the peer can still send after the half-close, abort both ways on errors
+		if err != nil || !closeWrite(dst) {
+			abort()
+		}
+	}
+	wg.Add(2)
+	go pipe(server, client, true)
+	go pipe(client, server, false)
+	wg.Wait()
+	return serverFirst
+}
+
+// half-close the write side of conn, return false if it is not supported
+func closeWrite(conn net.Conn) bool {
+	switch c := conn.(type) {
+	case *wrapClientConn:
+		return closeWrite(c.Conn)
+	case *throttledConn:
+		return closeWrite(c.Conn)
+	case interface{ CloseWrite() error }:
+		return c.CloseWrite() == nil
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testRawTcpAddon struct {
+	BaseAddon
+	mu     sync.Mutex
+	events []string
+}
+
+func (addon *testRawTcpAddon) Requestheaders(f *Flow) {
+	if f.Request.Method == "CONNECT" {
+		f.ConnContext.RawTcp = true
+	}
+}
+
+func (addon *testRawTcpAddon) TcpData(connCtx *ConnContext, fromClient bool, data []byte) []byte {
+	if fromClient {
+		return bytes.ToUpper(data)
+	}
+	return data
+}
+
+func (addon *testRawTcpAddon) ClientDisconnected(*ClientConn) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.events = append(addon.events, "client")
+}
+
+func (addon *testRawTcpAddon) ServerDisconnected(*ConnContext) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.events = append(addon.events, "server")
+}
+
+func (addon *testRawTcpAddon) takeEvents() string {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	events := strings.Join(addon.events, ",")
+	addon.events = nil
+	return events
+}
+
+func TestRawTcp(t *testing.T) {
+	// smtp like: the server speaks first, replies after the client half-closes, or closes right after the banner
+	listen := func(closeFirst bool) net.Listener {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		handleError(t, err)
+		go func() {
+			for {
+				c, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					defer c.Close()
+					io.WriteString(c, "220 ready\r\n")
+					if closeFirst {
+						return
+					}
+					data, _ := io.ReadAll(c)
+					io.WriteString(c, "got "+string(data))
+				}()
+			}
+		}()
+		return ln
+	}
+	serverLn := listen(false)
+	defer serverLn.Close()
+	closingLn := listen(true)
+	defer closingLn.Close()
+
+	proxyLn, err := net.Listen("tcp", "127.0.0.1:0")
+	handleError(t, err)
+	p, err := NewProxy(&Options{Listener: proxyLn})
+	handleError(t, err)
+	addon := &testRawTcpAddon{}
+	p.AddAddon(addon)
+	go p.Start()
+	defer p.Close()
+
+	connect := func(addr string) (*net.TCPConn, *bufio.Reader) {
+		c, err := net.Dial("tcp", proxyLn.Addr().String())
+		handleError(t, err)
+		io.WriteString(c, "CONNECT "+addr+" HTTP/1.1\r\nHost: "+addr+"\r\n\r\n")
+		r := bufio.NewReader(c)
+		res, err := http.ReadResponse(r, nil)
+		handleError(t, err)
+		if res.StatusCode != 200 {
+			t.Fatalf("expected 200, but got %v", res.StatusCode)
+		}
+		banner, err := r.ReadString('\n')
+		handleError(t, err)
+		if banner != "220 ready\r\n" {
+			t.Fatalf("unexpected banner %q", banner)
+		}
+		return c.(*net.TCPConn), r
+	}
+	waitEvents := func(want string) {
+		t.Helper()
+		for i := 0; i < 100; i++ {
+			if events := addon.takeEvents(); events != "" {
+				if events != want {
+					t.Fatalf("expected disconnect order %v, but got %v", want, events)
+				}
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatal("expected disconnect hooks")
+	}
+
+	// the half-close of client is forwarded, the reply of server is still received
+	c, r := connect(serverLn.Addr().String())
+	io.WriteString(c, "quit")
+	handleError(t, c.CloseWrite())
+	rest, err := io.ReadAll(r)
+	handleError(t, err)
+	if string(rest) != "got QUIT" {
+		t.Fatalf("expected reply to the bytes modified by TcpData, but got %q", rest)
+	}
+	c.Close()
+	waitEvents("client,server")
+
+	// the server closes first, the client reads EOF
+	c, r = connect(closingLn.Addr().String())
+	rest, err = io.ReadAll(r)
+	handleError(t, err)
+	if len(rest) != 0 {
+		t.Fatalf("expected EOF, but got %q", rest)
+	}
+	c.Close()
+	waitEvents("server,client")
+}
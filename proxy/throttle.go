@@ -0,0 +1,179 @@
+package proxy
+
+import (
+	"io"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Throttle limit the bandwidth and add latency of connections, to simulate slow links
+type Throttle struct {
+	DownstreamBytesPerSecond int64         // written to client, 0 means unlimited
+	UpstreamBytesPerSecond   int64         // written to server, 0 means unlimited
+	Latency                  time.Duration // added before a write when the direction has been idle, for both directions
+	LatencyJitter            time.Duration // the added latency varies randomly within Latency ± LatencyJitter, not below 0
+	Seed                     uint64        // seed of the jitter, the same order of writes gets the same latencies, 0 for a random seed
+
+	rand atomic.Pointer[jitterRand] // created by the first jitter
+}
+
+type jitterRand struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// latency added before a write after idle, with a random jitter
+func (t *Throttle) latency() time.Duration {
+	if t.LatencyJitter <= 0 {
+		return t.Latency
+	}
+	r := t.rand.Load()
+	if r == nil {
+		seed := t.Seed
+		if seed == 0 {
+			seed = rand.Uint64()
+		}
+		t.rand.CompareAndSwap(nil, &jitterRand{rand: rand.New(rand.NewPCG(seed, seed))})
+		r = t.rand.Load()
+	}
+	r.mu.Lock()
+	jitter := time.Duration(r.rand.Int64N(int64(2*t.LatencyJitter)+1)) - t.LatencyJitter
+	r.mu.Unlock()
+	return max(t.Latency+jitter, 0)
+}
+
+// SetThrottle override Options.Throttle for this connection, such as in Requestheaders for some hosts.
+// &Throttle{} removes the limit, nil uses Options.Throttle again.
+func (connCtx *ConnContext) SetThrottle(t *Throttle) {
+	connCtx.throttle.Store(t)
+}
+
+// Throttle return the throttle of the connection, nil if not limited
+func (connCtx *ConnContext) Throttle() *Throttle {
+	if t := connCtx.throttle.Load(); t != nil {
+		return t
+	}
+	return connCtx.proxy.Opts.Throttle
+}
+
+// write data to client or server with the throttle of connection and Options.GlobalBytesPerSec
+func (connCtx *ConnContext) throttledWrite(w io.Writer, data []byte, upstream bool, done <-chan struct{}) (int, error) {
+	proxy := connCtx.proxy
+	if rate := proxy.Opts.GlobalBytesPerSec; rate > 0 {
+		w = &bucketWriter{Writer: w, bucket: &proxy.globalBucket, rate: rate, done: done}
+	}
+	n, err := connCtx.connThrottledWrite(w, data, upstream, done)
+	atomic.AddInt64(&proxy.counters.writtenBytes, int64(n))
+	return n, err
+}
+
+func (connCtx *ConnContext) connThrottledWrite(w io.Writer, data []byte, upstream bool, done <-chan struct{}) (int, error) {
+	t := connCtx.Throttle()
+	if t == nil {
+		return w.Write(data)
+	}
+	b, rate := &connCtx.downstreamBucket, t.DownstreamBytesPerSecond
+	if upstream {
+		b, rate = &connCtx.upstreamBucket, t.UpstreamBytesPerSecond
+	}
+	if (t.Latency > 0 || t.LatencyJitter > 0) && !sleepUntil(b.latencyAt(t), done) {
+		return 0, net.ErrClosed
+	}
+	return b.write(w, data, rate, done)
+}
+
+// pacing of one direction of a connection, or of all the connections for Options.GlobalBytesPerSec
+type throttleBucket struct {
+	mu        sync.Mutex
+	next      time.Time // when the next chunk can be written
+	lastWrite time.Time
+}
+
+// write data in chunks of 100ms at rate, blocked writer returns net.ErrClosed when done is closed
+func (b *throttleBucket) write(w io.Writer, data []byte, rate int64, done <-chan struct{}) (int, error) {
+	if rate <= 0 {
+		return w.Write(data)
+	}
+	chunk := int(max(rate/10, 1))
+	written := 0
+	for written < len(data) {
+		n := min(chunk, len(data)-written)
+		if !sleepUntil(b.reserve(n, rate), done) {
+			return written, net.ErrClosed
+		}
+		m, err := w.Write(data[written : written+n])
+		written += m
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// writer paced by the shared bucket, the chunks of connections are interleaved in the order of their writes
+type bucketWriter struct {
+	io.Writer
+	bucket *throttleBucket
+	rate   int64
+	done   <-chan struct{}
+}
+
+func (w *bucketWriter) Write(data []byte) (int, error) {
+	return w.bucket.write(w.Writer, data, w.rate, w.done)
+}
+
+// the latency is added once for continuous writes
+func (b *throttleBucket) latencyAt(t *Throttle) time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	at := now
+	if now.Sub(b.lastWrite) >= t.Latency {
+		at = now.Add(t.latency())
+	}
+	b.lastWrite = at
+	return at
+}
+
+// reserve n bytes, return the time to write them
+func (b *throttleBucket) reserve(n int, rate int64) time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	if b.next.Before(now) {
+		b.next = now
+	}
+	at := b.next
+	b.next = b.next.Add(time.Duration(int64(n) * int64(time.Second) / rate))
+	b.lastWrite = at
+	return at
+}
+
+// return false if done is closed before t
+func sleepUntil(t time.Time, done <-chan struct{}) bool {
+	d := time.Until(t)
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-done:
+		return false
+	}
+}
+
+// upstream connection of the not intercepted tunnel, which is not a wrapServerConn
+type throttledConn struct {
+	net.Conn
+	connCtx *ConnContext
+}
+
+func (c *throttledConn) Write(data []byte) (int, error) {
+	return c.connCtx.throttledWrite(c.Conn, data, true, c.connCtx.closeChan)
+}
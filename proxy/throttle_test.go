@@ -0,0 +1,175 @@
+package proxy
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testThrottleAddon struct {
+	BaseAddon
+}
+
+func (addon *testThrottleAddon) Requestheaders(f *Flow) {
+	if f.Request.Header.Get("X-Fast") != "" {
+		f.ConnContext.SetThrottle(&Throttle{})
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{Throttle: &Throttle{DownstreamBytesPerSecond: 50000}}}
+	helper.init(t)
+	defer helper.close()
+	helper.testProxy.AddAddon(&testThrottleAddon{})
+
+	body := strings.Repeat("a", 20000)
+	post := func(t *testing.T, url string, fast bool) time.Duration {
+		req, err := http.NewRequest("POST", url, strings.NewReader(body))
+		handleError(t, err)
+		if fast {
+			req.Header.Set("X-Fast", "1")
+		}
+		start := time.Now()
+		resp, err := helper.getProxyClient().Do(req)
+		handleError(t, err)
+		defer resp.Body.Close()
+		got, err := io.ReadAll(resp.Body)
+		handleError(t, err)
+		if string(got) != body {
+			t.Fatalf("unexpected body of %v bytes", len(got))
+		}
+		return time.Since(start)
+	}
+
+	for _, url := range []string{"http://example.com/echo", "https://example.com/echo"} {
+		t.Run(url, func(t *testing.T) {
+			// the first 100ms chunk is written at once
+			if d := post(t, url, false); d < 250*time.Millisecond {
+				t.Fatalf("expected throttled to 50KB/s, took %v", d)
+			}
+			if d := post(t, url, true); d >= 250*time.Millisecond {
+				t.Fatalf("expected not throttled by SetThrottle, took %v", d)
+			}
+		})
+	}
+
+	t.Run("latency", func(t *testing.T) {
+		helper.testProxy.Opts.Throttle = &Throttle{Latency: 100 * time.Millisecond}
+		defer func() { helper.testProxy.Opts.Throttle = nil }()
+		start := time.Now()
+		testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+		// request to server and response to client
+		if d := time.Since(start); d < 200*time.Millisecond {
+			t.Fatalf("expected latency of both directions, took %v", d)
+		}
+	})
+
+	t.Run("latency jitter", func(t *testing.T) {
+		helper.testProxy.Opts.Throttle = &Throttle{Latency: 100 * time.Millisecond, LatencyJitter: 40 * time.Millisecond}
+		defer func() { helper.testProxy.Opts.Throttle = nil }()
+		start := time.Now()
+		testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+		if d := time.Since(start); d < 120*time.Millisecond {
+			t.Fatalf("expected at least 60ms latency of both directions, took %v", d)
+		}
+	})
+}
+
+func TestThrottleLatencyJitter(t *testing.T) {
+	latencies := func(th *Throttle) []time.Duration {
+		var ds []time.Duration
+		for i := 0; i < 100; i++ {
+			ds = append(ds, th.latency())
+		}
+		return ds
+	}
+	ds := latencies(&Throttle{Latency: 100 * time.Millisecond, LatencyJitter: 40 * time.Millisecond, Seed: 1})
+	varied := false
+	for _, d := range ds {
+		if d < 60*time.Millisecond || d > 140*time.Millisecond {
+			t.Fatalf("expected latency within 100ms ± 40ms, got %v", d)
+		}
+		varied = varied || d != ds[0]
+	}
+	if !varied {
+		t.Fatal("expected varied latencies")
+	}
+	if again := latencies(&Throttle{Latency: 100 * time.Millisecond, LatencyJitter: 40 * time.Millisecond, Seed: 1}); !slices.Equal(ds, again) {
+		t.Fatal("expected the same latencies with the same seed")
+	}
+	for _, d := range latencies(&Throttle{Latency: 10 * time.Millisecond, LatencyJitter: 40 * time.Millisecond}) {
+		if d < 0 || d > 50*time.Millisecond {
+			t.Fatalf("expected latency within 0 and 50ms, got %v", d)
+		}
+	}
+}
+
+func TestThrottleCloseBlockedWriter(t *testing.T) {
+	c1, c2 := net.Pipe()
+	defer c1.Close()
+	defer c2.Close()
+	go io.Copy(io.Discard, c2)
+
+	b := &throttleBucket{}
+	done := make(chan struct{})
+	result := make(chan error, 1)
+	go func() {
+		_, err := b.write(c1, make([]byte, 100), 10, done)
+		result <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(done)
+	select {
+	case err := <-result:
+		if !errors.Is(err, net.ErrClosed) {
+			t.Fatalf("expected net.ErrClosed, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocked writer not woken by close")
+	}
+}
+
+func TestGlobalBytesPerSec(t *testing.T) {
+	helper := &testPipeHelper{opts: &Options{GlobalBytesPerSec: 200000}}
+	helper.init(t)
+	defer helper.close()
+
+	// 4 connections each write 50KB to server and 50KB to client, 400KB in total
+	body := strings.Repeat("a", 50000)
+	written := helper.testProxy.Stats().WrittenBytes
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := helper.getProxyClient().Post("http://example.com/echo", "text/plain", strings.NewReader(body))
+			handleError(t, err)
+			defer resp.Body.Close()
+			got, err := io.ReadAll(resp.Body)
+			handleError(t, err)
+			if string(got) != body {
+				t.Errorf("unexpected body of %v bytes", len(got))
+			}
+		}()
+	}
+	wg.Wait()
+	// the first 100ms chunk is written at once
+	if d := time.Since(start); d < 1800*time.Millisecond {
+		t.Fatalf("expected the total throttled to 200KB/s, took %v", d)
+	}
+	// the last write may return after the client has read it
+	deadline := time.Now().Add(time.Second)
+	for n := helper.testProxy.Stats().WrittenBytes - written; n < 400000; n = helper.testProxy.Stats().WrittenBytes - written {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected at least 400000 bytes written, got %v", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,32 @@
+package proxy
+
+import "time"
+
+// HostTimeouts timeouts of the upstream hosts in Options.HostTimeouts, 0 uses the global option
+type HostTimeouts struct {
+	Dial      time.Duration // overrides Options.DialTimeout
+	Handshake time.Duration // tls handshake with the server, no global option, 0 means no limit
+	Read      time.Duration // overrides Options.ReadTimeout
+}
+
+// timeouts for the upstream host:port, Options.HostTimeouts over the global options
+func (proxy *Proxy) timeouts(addr string) HostTimeouts {
+	t := HostTimeouts{
+		Dial: proxy.Opts.DialTimeout,
+		Read: proxy.Opts.ReadTimeout,
+	}
+	host, ok := matchHostPattern(proxy.Opts.HostTimeouts, addr)
+	if !ok {
+		return t
+	}
+	if host.Dial > 0 {
+		t.Dial = host.Dial
+	}
+	if host.Handshake > 0 {
+		t.Handshake = host.Handshake
+	}
+	if host.Read > 0 {
+		t.Read = host.Read
+	}
+	return t
+}
@@ -0,0 +1,128 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testTimeoutErrorAddon struct {
+	BaseAddon
+	mu     sync.Mutex
+	errors []error
+}
+
+func (addon *testTimeoutErrorAddon) FlowError(f *Flow) {
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	addon.errors = append(addon.errors, f.Error)
+}
+
+// wait until n errors are reported, FlowError of a closed https connection may be called after the client sees it closed
+func (addon *testTimeoutErrorAddon) waitErrors(n int) []error {
+	for i := 0; ; i++ {
+		addon.mu.Lock()
+		errs := slices.Clone(addon.errors)
+		addon.mu.Unlock()
+		if len(errs) >= n || i == 100 {
+			return errs
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestUpstreamTimeouts(t *testing.T) {
+	// 502, or 0 when the connection is closed: the server connection of https is shared by the client connection, it is closed on timeout
+	getStatus := func(t *testing.T, helper *testPipeHelper, url string) int {
+		t.Helper()
+		start := time.Now()
+		resp, err := helper.getProxyClient().Get(url)
+		if d := time.Since(start); d > time.Second {
+			t.Fatalf("expected timeout quickly, took %v", d)
+		}
+		if err != nil {
+			return 0
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	t.Run("dial", func(t *testing.T) {
+		helper := &testPipeHelper{opts: &Options{DialTimeout: 20 * time.Millisecond}}
+		helper.init(t)
+		defer helper.close()
+		dialContext := helper.testProxy.Opts.DialContext
+		helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if strings.HasPrefix(addr, "blackhole.com") {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return dialContext(ctx, network, addr)
+		}
+		if status := getStatus(t, helper, "http://blackhole.com/"); status != 502 {
+			t.Fatalf("expected 502, got %v", status)
+		}
+		testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+	})
+
+	t.Run("read", func(t *testing.T) {
+		helper := &testPipeHelper{opts: &Options{ReadTimeout: 20 * time.Millisecond}}
+		helper.init(t)
+		defer helper.close()
+		addon := &testTimeoutErrorAddon{}
+		helper.testProxy.AddAddon(addon)
+		if status := getStatus(t, helper, "http://example.com/slow"); status != 502 {
+			t.Fatalf("expected 502, got %v", status)
+		}
+		if status := getStatus(t, helper, "https://example.com/slow"); status != 502 && status != 0 {
+			t.Fatalf("expected 502 or closed, got %v", status)
+		}
+		if errs := addon.waitErrors(2); len(errs) != 2 || !errors.Is(errs[0], errReadTimeout) || !errors.Is(errs[1], errReadTimeout) {
+			t.Fatalf("expected read timeout errors, got %v", errs)
+		}
+		testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+	})
+
+	t.Run("host", func(t *testing.T) {
+		helper := &testPipeHelper{opts: &Options{
+			ReadTimeout: 20 * time.Millisecond,
+			HostTimeouts: map[string]HostTimeouts{
+				"example.com": {Read: time.Second},
+				"*.internal":  {Handshake: 20 * time.Millisecond},
+			},
+		}}
+		helper.init(t)
+		defer helper.close()
+		dialContext := helper.testProxy.Opts.DialContext
+		helper.testProxy.Opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if strings.HasPrefix(addr, "stuck.internal") {
+				// never answers the tls handshake
+				c, _ := net.Pipe()
+				return c, nil
+			}
+			return dialContext(ctx, network, addr)
+		}
+		testSendRequest(t, "http://example.com/slow", helper.getProxyClient(), "ok")
+		if status := getStatus(t, helper, "http://other.com/slow"); status != 502 {
+			t.Fatalf("expected 502, got %v", status)
+		}
+		if status := getStatus(t, helper, "https://stuck.internal/"); status != 502 && status != 0 {
+			t.Fatalf("expected 502 or closed, got %v", status)
+		}
+	})
+
+	t.Run("request", func(t *testing.T) {
+		helper := &testPipeHelper{opts: &Options{RequestTimeout: 20 * time.Millisecond}}
+		helper.init(t)
+		defer helper.close()
+		if status := getStatus(t, helper, "https://example.com/slow"); status != 502 && status != 0 {
+			t.Fatalf("expected 502 or closed, got %v", status)
+		}
+		testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+	})
+}
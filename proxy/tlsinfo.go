@@ -0,0 +1,159 @@
+package proxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// TlsInfo negotiated parameters of the tls handshake with server, plain values to be logged or serialized
+type TlsInfo struct {
+	Version            string        `json:"version"`     // such as "TLS 1.3"
+	CipherSuite        string        `json:"cipherSuite"` // such as "TLS_AES_128_GCM_SHA256"
+	NegotiatedProtocol string        `json:"negotiatedProtocol"`
+	ServerName         string        `json:"serverName"`
+	DidResume          bool          `json:"didResume"`
+	PeerCertificates   []TlsCertInfo `json:"peerCertificates"` // sent by server, leaf first
+	// the certificate chain is verified, false when the verification is skipped by Options.SslInsecure or ConnContext.SkipUpstreamVerify
+	Verified bool `json:"verified"`
+}
+
+// TlsCertInfo summary of a certificate sent by server
+type TlsCertInfo struct {
+	Subject      string    `json:"subject"`
+	Issuer       string    `json:"issuer"`
+	SerialNumber string    `json:"serialNumber"`
+	DNSNames     []string  `json:"dnsNames"`
+	NotBefore    time.Time `json:"notBefore"`
+	NotAfter     time.Time `json:"notAfter"`
+	SHA256       string    `json:"sha256"` // fingerprint of the DER in hex
+}
+
+// TlsInfo of the handshake with server, nil if the connection is not tls or the handshake is not done yet.
+// Returns the error of the handshake if it failed.
+func (c *ServerConn) TlsInfo() (*TlsInfo, error) {
+	if c.tlsErr != nil {
+		return nil, c.tlsErr
+	}
+	state := c.tlsState
+	if state == nil {
+		return nil, nil
+	}
+	info := &TlsInfo{
+		Version:            c.TLSVersion,
+		CipherSuite:        c.CipherSuite,
+		NegotiatedProtocol: state.NegotiatedProtocol,
+		ServerName:         state.ServerName,
+		DidResume:          state.DidResume,
+		Verified:           len(state.VerifiedChains) > 0,
+	}
+	for _, cert := range state.PeerCertificates {
+		sum := sha256.Sum256(cert.Raw)
+		info.PeerCertificates = append(info.PeerCertificates, TlsCertInfo{
+			Subject:      cert.Subject.String(),
+			Issuer:       cert.Issuer.String(),
+			SerialNumber: cert.SerialNumber.String(),
+			DNSNames:     cert.DNSNames,
+			NotBefore:    cert.NotBefore,
+			NotAfter:     cert.NotAfter,
+			SHA256:       hex.EncodeToString(sum[:]),
+		})
+	}
+	return info, nil
+}
+
+// UpstreamCertErrorReason why the certificate of server failed the verification:
+// "expired" (or not yet valid), "name mismatch", "unknown authority" or "invalid", "" if err is not a verification error.
+func UpstreamCertErrorReason(err error) string {
+	var verifyErr *tls.CertificateVerificationError
+	if !errors.As(err, &verifyErr) {
+		return ""
+	}
+	var hostnameErr x509.HostnameError
+	var authorityErr x509.UnknownAuthorityError
+	var invalidErr x509.CertificateInvalidError
+	switch {
+	case errors.As(err, &hostnameErr):
+		return "name mismatch"
+	case errors.As(err, &authorityErr):
+		return "unknown authority"
+	case errors.As(err, &invalidErr) && invalidErr.Reason == x509.Expired:
+		return "expired"
+	}
+	return "invalid"
+}
+
+// ClientTlsError details of a failed tls handshake with client, passed to ClientTlsHandshakeError of addons.
+// The offered parameters are empty if the ClientHello was not received or could not be parsed.
+type ClientTlsError struct {
+	ClientAddr   string   `json:"clientAddr"`
+	ServerName   string   `json:"serverName"`   // SNI, empty when client does not send it
+	Versions     []string `json:"versions"`     // offered by client, such as "TLS 1.3"
+	CipherSuites []string `json:"cipherSuites"` // offered by client, such as "TLS_AES_128_GCM_SHA256"
+	ALPN         []string `json:"alpn"`
+	Reason       string   `json:"reason"` // see ClientTlsErrorReason
+	Err          error    `json:"-"`
+}
+
+func newClientTlsError(connCtx *ConnContext, err error) *ClientTlsError {
+	info := &ClientTlsError{
+		ClientAddr: connCtx.ClientConn.Conn.RemoteAddr().String(),
+		Reason:     ClientTlsErrorReason(err),
+		Err:        err,
+	}
+	hello := connCtx.ClientConn.TlsClientHello
+	if hello == nil {
+		return info
+	}
+	info.ServerName = hello.ServerName
+	info.ALPN = hello.ALPN
+	versions := hello.SupportedVersions
+	if len(versions) == 0 {
+		versions = []uint16{hello.Version}
+	}
+	for _, v := range versions {
+		if !isGrease(v) {
+			info.Versions = append(info.Versions, tls.VersionName(v))
+		}
+	}
+	for _, c := range hello.CipherSuites {
+		if !isGrease(c) {
+			info.CipherSuites = append(info.CipherSuites, tls.CipherSuiteName(c))
+		}
+	}
+	return info
+}
+
+// ClientTlsErrorReason why the tls handshake with client failed:
+// "untrusted certificate" (the client rejects the certificate, usually the CA is not installed),
+// "unsupported version", "no shared cipher", "no application protocol", "timeout",
+// "closed" (the client closes the connection without an alert, some clients do so for untrusted certificates)
+// or "error" for the others.
+func ClientTlsErrorReason(err error) string {
+	msg := err.Error()
+	var netErr net.Error
+	switch {
+	case strings.Contains(msg, "bad certificate"), strings.Contains(msg, "unknown certificate"),
+		strings.Contains(msg, "certificate unknown"), strings.Contains(msg, "unsupported certificate"):
+		return "untrusted certificate"
+	case strings.Contains(msg, "unsupported versions"), strings.Contains(msg, "protocol version"):
+		return "unsupported version"
+	case strings.Contains(msg, "no cipher suite"), strings.Contains(msg, "handshake failure"):
+		return "no shared cipher"
+	case strings.Contains(msg, "application protocol"):
+		return "no application protocol"
+	case errors.As(err, &netErr) && netErr.Timeout(), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, net.ErrClosed),
+		strings.Contains(msg, "connection reset"):
+		return "closed"
+	}
+	return "error"
+}
@@ -0,0 +1,164 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTLSCipherSuite(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testFlowTimeAddon{flows: make(chan *Flow, 2)}
+	helper.testProxy.AddAddon(addon)
+
+	client := helper.getProxyClient()
+	client.Transport.(*http.Transport).TLSClientConfig.MaxVersion = tls.VersionTLS12
+	client.Transport.(*http.Transport).TLSClientConfig.CipherSuites = []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}
+	testSendRequest(t, "https://example.com/", client, "ok")
+	<-addon.flows // CONNECT
+	f := <-addon.flows
+
+	clientConn := f.ConnContext.ClientConn
+	if clientConn.TLSVersion != "TLS 1.2" || clientConn.CipherSuite != "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256" {
+		t.Fatalf("unexpected client tls: %v %v", clientConn.TLSVersion, clientConn.CipherSuite)
+	}
+	serverConn := f.ConnContext.ServerConn
+	if serverConn.TLSVersion == "" || serverConn.CipherSuite == "" {
+		t.Fatalf("unexpected server tls: %v %v", serverConn.TLSVersion, serverConn.CipherSuite)
+	}
+}
+
+type testTlsInfoAddon struct {
+	BaseAddon
+	infos chan *TlsInfo
+	errs  chan error
+}
+
+func (addon *testTlsInfoAddon) Response(f *Flow) {
+	info, err := f.ConnContext.ServerConn.TlsInfo()
+	if err != nil {
+		addon.errs <- err
+	}
+	addon.infos <- info
+}
+
+func (addon *testTlsInfoAddon) ServerDisconnected(connCtx *ConnContext) {
+	if _, err := connCtx.ServerConn.TlsInfo(); err != nil {
+		addon.errs <- err
+	}
+}
+
+func TestServerTlsInfo(t *testing.T) {
+	helper := &testPipeHelper{}
+	helper.init(t)
+	defer helper.close()
+	addon := &testTlsInfoAddon{infos: make(chan *TlsInfo, 3), errs: make(chan error, 3)}
+	helper.testProxy.AddAddon(addon)
+
+	testSendRequest(t, "http://example.com/", helper.getProxyClient(), "ok")
+	if info := <-addon.infos; info != nil {
+		t.Fatalf("expected no tls info of http, got %+v", info)
+	}
+
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+	info := <-addon.infos
+	if info == nil || info.Version != "TLS 1.3" || info.CipherSuite == "" || info.ServerName != "example.com" || info.Verified {
+		t.Fatalf("unexpected tls info %+v", info)
+	}
+	if len(info.PeerCertificates) != 1 || !slices.Equal(info.PeerCertificates[0].DNSNames, []string{"example.com"}) || len(info.PeerCertificates[0].SHA256) != 64 {
+		t.Fatalf("unexpected peer certificates %+v", info.PeerCertificates)
+	}
+	if _, err := json.Marshal(info); err != nil {
+		t.Fatal(err)
+	}
+
+	// verified by the root of the test server
+	helper.testProxy.Opts.SslInsecure = false
+	roots := x509.NewCertPool()
+	roots.AddCert(&helper.serverCA.RootCert)
+	helper.testProxy.Opts.UpstreamRootCAs = roots
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+	if info := <-addon.infos; info == nil || !info.Verified {
+		t.Fatalf("expected verified tls info, got %+v", info)
+	}
+
+	// verification failed
+	helper.testProxy.Opts.UpstreamRootCAs = x509.NewCertPool()
+	resp, err := helper.getProxyClient().Get("https://example.com/")
+	handleError(t, err)
+	resp.Body.Close()
+	if resp.StatusCode != 502 {
+		t.Fatalf("expected 502, got %v", resp.Status)
+	}
+	select {
+	case err := <-addon.errs:
+		var certErr *tls.CertificateVerificationError
+		if !errors.As(err, &certErr) {
+			t.Fatalf("expected certificate verification error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected tls error of ServerConn")
+	}
+}
+
+type testClientTlsErrorAddon struct {
+	infos chan *ClientTlsError
+}
+
+func (addon *testClientTlsErrorAddon) ClientTlsHandshakeError(connCtx *ConnContext, info *ClientTlsError) {
+	addon.infos <- info
+}
+
+func TestClientTlsHandshakeError(t *testing.T) {
+	for _, upstreamCert := range []bool{false, true} {
+		t.Run(fmt.Sprintf("upstreamCert=%v", upstreamCert), func(t *testing.T) {
+			helper := &testPipeHelper{}
+			helper.init(t)
+			defer helper.close()
+			helper.testProxy.AddAddon(NewUpstreamCertAddon(upstreamCert))
+			addon := &testClientTlsErrorAddon{infos: make(chan *ClientTlsError, 1)}
+			helper.testProxy.AddHooks(addon)
+
+			for _, c := range []struct {
+				name       string
+				config     *tls.Config
+				wantReason string
+			}{
+				{"not trust the CA", &tls.Config{RootCAs: x509.NewCertPool()}, "untrusted certificate"},
+				{"old version", &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS10, MaxVersion: tls.VersionTLS10}, "unsupported version"},
+			} {
+				// the versions offered by client are also offered to server, which refuses them first
+				if upstreamCert && c.config.MaxVersion != 0 {
+					continue
+				}
+				client := helper.getProxyClient()
+				client.Transport.(*http.Transport).TLSClientConfig = c.config
+				if _, err := client.Get("https://example.com/"); err == nil {
+					t.Fatalf("%v: expected error", c.name)
+				}
+				select {
+				case info := <-addon.infos:
+					if info.Reason != c.wantReason || info.ServerName != "example.com" || info.ClientAddr == "" || info.Err == nil {
+						t.Fatalf("%v: unexpected %+v", c.name, info)
+					}
+					if !slices.Contains(info.CipherSuites, "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA") {
+						t.Fatalf("%v: expected the offered cipher suites, but got %v", c.name, info.CipherSuites)
+					}
+					if c.config.MaxVersion == tls.VersionTLS10 && !slices.Equal(info.Versions, []string{"TLS 1.0"}) {
+						t.Fatalf("%v: expected TLS 1.0 offered, but got %v", c.name, info.Versions)
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("%v: expected ClientTlsHandshakeError called", c.name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package proxy
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/lqqyt2423/go-mitmproxy/internal/helper"
+	log "github.com/sirupsen/logrus"
+)
+
+// With Options.Transparent, clients are redirected to the proxy (such as by iptables REDIRECT) without CONNECT.
+// A tls connection is handled as a CONNECT tunnel to its original destination, the SNI of its ClientHello is only the
+// ServerName of the upstream handshake and the name of the generated certificate, the client can not pick the server to dial.
+// The others go to http.Server, requests in origin-form are sent to their Host. Connections not redirected are served as a normal proxy.
+type transparentListener struct {
+	*dispatchListener
+	entry *entry
+}
+
+// peek the first bytes of the redirected connection, not to block accept
+func (l *transparentListener) dispatch(wc *wrapClientConn) {
+	proxy := l.entry.proxy
+	dst, err := proxy.originalDst(wc.Conn)
+	if err != nil {
+		log.Debugf("client %v original destination: %v", wc.RemoteAddr(), err)
+	} else if dst != wc.LocalAddr().String() {
+		wc.connCtx.transparentAddr = dst
+	}
+
+	if wc.connCtx.transparentAddr != "" {
+		proxy.setHandshakeDeadline(wc)
+		peek, err := wc.Peek(3)
+		if err != nil {
+			wc.Close()
+			log.Debugf("client %v peek: %v", wc.RemoteAddr(), err)
+			return
+		}
+		if helper.IsTls(peek) {
+			l.entry.handleTransparentTls(wc, wc.connCtx.transparentAddr)
+			return
+		}
+		proxy.clearHandshakeDeadline(wc)
+	}
+
+	l.serve(wc)
+}
+
+// handle the redirected tls connection as CONNECT host
+func (e *entry) handleTransparentTls(wc *wrapClientConn, host string) {
+	req := &http.Request{
+		Method:     "CONNECT",
+		URL:        &url.URL{Host: host},
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Host:       host,
+		RemoteAddr: wc.RemoteAddr().String(),
+		RequestURI: host,
+	}
+	res := &transparentResponseWriter{header: make(http.Header)}
+	ctx := context.WithValue(wc.connCtx.ctx, connContextKey, wc.connCtx)
+	e.handleConnect(res, req.WithContext(ctx))
+	if !res.established {
+		wc.Close()
+	}
+}
+
+// response of the CONNECT not sent by client, the status is only logged
+type transparentResponseWriter struct {
+	header      http.Header
+	established bool // the client connection is taken as the tunnel
+}
+
+func (w *transparentResponseWriter) Header() http.Header         { return w.header }
+func (w *transparentResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *transparentResponseWriter) WriteHeader(statusCode int) {
+	log.Debugf("transparent tunnel response %v", statusCode)
+}
+
+// Options.OriginalDst, SO_ORIGINAL_DST by default
+func (proxy *Proxy) originalDst(c net.Conn) (string, error) {
+	if proxy.Opts.OriginalDst != nil {
+		return proxy.Opts.OriginalDst(c)
+	}
+	return originalDst(c)
+}
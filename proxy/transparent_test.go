@@ -0,0 +1,68 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestTransparent(t *testing.T) {
+	var dst atomic.Value
+	helper := &testPipeHelper{opts: &Options{
+		Transparent: true,
+		OriginalDst: func(c net.Conn) (string, error) {
+			return dst.Load().(string), nil
+		},
+	}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testRetriesAddon{} // counts ServerConnected
+	helper.testProxy.AddAddon(addon)
+
+	// the client connects to the proxy as if it is the server
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext:     helper.dial,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	dst.Store("10.0.0.1:443")
+	resp, err := client.Get("https://example.com/tls")
+	handleError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.HasPrefix(string(body), "TLS") {
+		t.Fatalf("expected response of the https server, got %q", body)
+	}
+	rootCert := helper.testProxy.GetCertificate()
+	if err := resp.TLS.PeerCertificates[0].CheckSignatureFrom(&rootCert); err != nil {
+		t.Fatalf("expected the cert issued by proxy: %v", err)
+	}
+	// the SNI names the cert, but not the server to dial
+	if names := resp.TLS.PeerCertificates[0].DNSNames; !slices.Contains(names, "example.com") {
+		t.Fatalf("expected the cert of the SNI, got %v", names)
+	}
+	client.CloseIdleConnections()
+
+	dst.Store("10.0.0.1:80")
+	testSendRequest(t, "http://example.com/", client, "ok")
+
+	// not redirected, a normal proxy connection
+	dst.Store("pipe")
+	testSendRequest(t, "https://example.com/", helper.getProxyClient(), "ok")
+
+	addon.mu.Lock()
+	defer addon.mu.Unlock()
+	if addon.connected != 3 {
+		t.Fatalf("expected 3 server connections, got %v", addon.connected)
+	}
+	if addon.addrs[0] != "10.0.0.1:443" {
+		t.Fatalf("expected the original destination dialed for tls, got %v", addon.addrs[0])
+	}
+}
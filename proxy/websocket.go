@@ -1,66 +1,263 @@
 package proxy
 
 import (
-	"crypto/tls"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
-	"net/http/httputil"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
 )
 
-// 当前仅做了转发 websocket 流量
+// websocket 握手经过正常的 flow 流程，握手成功后原样转发双向的帧，同时解析出消息通知 addon
 
-type webSocket struct{}
+// opcodes of websocket frame
+const (
+	WebSocketContinuation = 0x0
+	WebSocketText         = 0x1
+	WebSocketBinary       = 0x2
+	WebSocketClose        = 0x8
+	WebSocketPing         = 0x9
+	WebSocketPong         = 0xA
+)
 
-var defaultWebSocket webSocket
+// max size of a message to be parsed, frames are always forwarded
+const webSocketMaxMessage = 32 * 1024 * 1024
 
-// func (s *webSocket) ws(conn net.Conn, host string) {
-// 	log := log.WithField("in", "webSocket.ws").WithField("host", host)
+// WebSocketMessage a message of websocket, continuation frames are joined.
+// Control frames (close, ping, pong) are messages too.
+type WebSocketMessage struct {
+	Opcode     int    // WebSocketText, WebSocketBinary, WebSocketClose, WebSocketPing or WebSocketPong
+	FromClient bool   // sent by client, or by server
+	Data       []byte // unmasked, and decompressed when permessage-deflate is negotiated
+}
 
-// 	defer conn.Close()
-// 	remoteConn, err := net.Dial("tcp", host)
-// 	if err != nil {
-// 		logErr(log, err)
-// 		return
-// 	}
-// 	defer remoteConn.Close()
-// 	transfer(log, conn, remoteConn)
-// }
+func isWebSocketUpgrade(header http.Header) bool {
+	return headerContainsToken(header, "Connection", "upgrade") && strings.EqualFold(header.Get("Upgrade"), "websocket")
+}
 
-func (s *webSocket) wss(res http.ResponseWriter, req *http.Request) {
-	log := log.WithField("in", "webSocket.wss").WithField("host", req.Host)
+func headerContainsToken(header http.Header, key, token string) bool {
+	for _, v := range header.Values(key) {
+		for _, s := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(s), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
 
-	upgradeBuf, err := httputil.DumpRequest(req, false)
-	if err != nil {
-		log.Errorf("DumpRequest: %v\n", err)
+// after the 101 response of server, forward frames between client and server
+func (a *attacker) webSocket(res http.ResponseWriter, f *Flow, proxyRes *http.Response) {
+	log := log.WithField("in", "Proxy.attacker.webSocket").WithField("host", f.Request.URL.Host)
+
+	server, ok := proxyRes.Body.(io.ReadWriteCloser)
+	if !ok {
+		f.Error = errors.New("websocket: upstream body is not writable")
+		log.Error(f.Error)
 		res.WriteHeader(502)
 		return
 	}
+	defer server.Close()
 
-	cconn, _, err := res.(http.Hijacker).Hijack()
+	hijacker, ok := res.(http.Hijacker)
+	if !ok {
+		f.Error = errors.New("websocket: client connection can not be hijacked")
+		log.Error(f.Error)
+		res.WriteHeader(502)
+		return
+	}
+	cconn, brw, err := hijacker.Hijack()
 	if err != nil {
-		log.Errorf("Hijack: %v\n", err)
+		f.Error = err
+		log.Error(err)
 		res.WriteHeader(502)
 		return
 	}
 	defer cconn.Close()
 
-	host := req.Host
-	if !strings.Contains(host, ":") {
-		host = host + ":443"
-	}
-	conn, err := tls.Dial("tcp", host, nil)
-	if err != nil {
-		log.Errorf("tls.Dial: %v\n", err)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "HTTP/1.1 %d %s\r\n", f.Response.StatusCode, http.StatusText(f.Response.StatusCode))
+	f.Response.Header.Write(&buf)
+	buf.WriteString("\r\n")
+	if _, err := cconn.Write(buf.Bytes()); err != nil {
+		f.Error = err
+		logErr(log, err)
 		return
 	}
-	defer conn.Close()
 
-	_, err = conn.Write(upgradeBuf)
-	if err != nil {
-		log.Errorf("wss upgrade: %v\n", err)
+	// the extension accepted by server, with parameters such as "permessage-deflate; client_max_window_bits=15"
+	deflate := strings.Contains(strings.ToLower(strings.Join(f.Response.Header.Values("Sec-WebSocket-Extensions"), ",")), "permessage-deflate")
+	client := &webSocketConn{
+		Reader:        brw.Reader,
+		WriteCloser:   cconn,
+		parser:        &webSocketParser{a: a, f: f, fromClient: true, deflate: deflate},
+		notifyEnabled: len(a.proxy.hooks.webSocketMessage) > 0,
+	}
+	upstream := &webSocketConn{
+		Reader:        server,
+		WriteCloser:   server,
+		parser:        &webSocketParser{a: a, f: f, fromClient: false, deflate: deflate},
+		notifyEnabled: client.notifyEnabled,
+	}
+	transfer(log, upstream, client)
+}
+
+// frames read are parsed for the addons, and forwarded as is
+type webSocketConn struct {
+	io.Reader
+	io.WriteCloser
+	parser        *webSocketParser
+	notifyEnabled bool
+}
+
+func (c *webSocketConn) Read(b []byte) (int, error) {
+	n, err := c.Reader.Read(b)
+	if n > 0 && c.notifyEnabled {
+		c.parser.feed(b[:n])
+	}
+	return n, err
+}
+
+// parse the byte stream of one direction into messages
+type webSocketParser struct {
+	a          *attacker
+	f          *Flow
+	fromClient bool
+	deflate    bool
+
+	buf        []byte
+	opcode     int // of the message being joined
+	compressed bool
+	message    []byte
+	dict       []byte // last 32KB decompressed output, for context takeover of permessage-deflate
+	broken     bool   // stop parsing after a malformed frame
+}
+
+func (p *webSocketParser) feed(data []byte) {
+	if p.broken {
 		return
 	}
-	transfer(log, conn, cconn)
+	p.buf = append(p.buf, data...)
+	for {
+		n, err := p.frame()
+		if err != nil {
+			log.Debugf("websocket: stop parsing frames: %v", err)
+			p.broken = true
+			p.buf = nil
+			return
+		}
+		if n == 0 {
+			return
+		}
+		p.buf = p.buf[n:]
+	}
+}
+
+// parse one frame from buf, return 0 if incomplete
+func (p *webSocketParser) frame() (int, error) {
+	b := p.buf
+	if len(b) < 2 {
+		return 0, nil
+	}
+	fin := b[0]&0x80 != 0
+	rsv1 := b[0]&0x40 != 0
+	opcode := int(b[0] & 0x0F)
+	masked := b[1]&0x80 != 0
+	length := uint64(b[1] & 0x7F)
+	pos := 2
+	switch length {
+	case 126:
+		if len(b) < pos+2 {
+			return 0, nil
+		}
+		length = uint64(binary.BigEndian.Uint16(b[pos:]))
+		pos += 2
+	case 127:
+		if len(b) < pos+8 {
+			return 0, nil
+		}
+		length = binary.BigEndian.Uint64(b[pos:])
+		pos += 8
+	}
+	if length > webSocketMaxMessage {
+		return 0, fmt.Errorf("frame too large: %v", length)
+	}
+	var mask []byte
+	if masked {
+		if len(b) < pos+4 {
+			return 0, nil
+		}
+		mask = b[pos : pos+4]
+		pos += 4
+	}
+	if uint64(len(b)-pos) < length {
+		return 0, nil
+	}
+	payload := make([]byte, length)
+	copy(payload, b[pos:pos+int(length)])
+	if mask != nil {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	pos += int(length)
+
+	// control frames can be interleaved with the fragments of a message
+	if opcode >= WebSocketClose {
+		p.notify(opcode, payload)
+		return pos, nil
+	}
+
+	if opcode != WebSocketContinuation {
+		p.opcode = opcode
+		p.compressed = rsv1 && p.deflate
+		p.message = nil
+	}
+	if len(p.message)+len(payload) > webSocketMaxMessage {
+		return 0, errors.New("message too large")
+	}
+	p.message = append(p.message, payload...)
+	if !fin {
+		return pos, nil
+	}
+
+	data := p.message
+	p.message = nil
+	if p.compressed {
+		var err error
+		if data, err = p.inflate(data); err != nil {
+			return 0, err
+		}
+	}
+	p.notify(p.opcode, data)
+	return pos, nil
+}
+
+// permessage-deflate, RFC 7692
+func (p *webSocketParser) inflate(data []byte) ([]byte, error) {
+	data = append(data, 0x00, 0x00, 0xff, 0xff)
+	r := flate.NewReaderDict(bytes.NewReader(data), p.dict)
+	defer r.Close()
+	out, err := io.ReadAll(io.LimitReader(r, webSocketMaxMessage))
+	// the message does not end with a final block
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	p.dict = append(p.dict, out...)
+	if len(p.dict) > 32*1024 {
+		p.dict = p.dict[len(p.dict)-32*1024:]
+	}
+	return out, nil
+}
+
+func (p *webSocketParser) notify(opcode int, data []byte) {
+	msg := &WebSocketMessage{Opcode: opcode, FromClient: p.fromClient, Data: data}
+	for _, addon := range p.a.proxy.hooks.webSocketMessage {
+		addon.WebSocketMessage(p.f, msg)
+	}
 }
@@ -0,0 +1,182 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// encode websocket frame, payload is masked when mask is set
+func testWebSocketFrame(b0 byte, payload []byte, mask []byte) []byte {
+	frame := []byte{b0, 0}
+	if mask != nil {
+		frame[1] = 0x80
+	}
+	switch {
+	case len(payload) < 126:
+		frame[1] |= byte(len(payload))
+	default:
+		frame[1] |= 126
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(payload)))
+	}
+	frame = append(frame, mask...)
+	for i, c := range payload {
+		if mask != nil {
+			c ^= mask[i%4]
+		}
+		frame = append(frame, c)
+	}
+	return frame
+}
+
+func testReadWebSocketFrame(r io.Reader) (byte, []byte, error) {
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return 0, nil, err
+	}
+	length := int(head[1] & 0x7F)
+	if length == 126 {
+		ext := make([]byte, 2)
+		if _, err := io.ReadFull(r, ext); err != nil {
+			return 0, nil, err
+		}
+		length = int(binary.BigEndian.Uint16(ext))
+	}
+	var mask []byte
+	if head[1]&0x80 != 0 {
+		mask = make([]byte, 4)
+		if _, err := io.ReadFull(r, mask); err != nil {
+			return 0, nil, err
+		}
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		if mask != nil {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return head[0], payload, nil
+}
+
+// echo frames of websocket, unmasked
+func testWebSocketEcho(w http.ResponseWriter, r *http.Request) {
+	conn, brw, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	io.WriteString(conn, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	if strings.Contains(r.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate") {
+		io.WriteString(conn, "Sec-WebSocket-Extensions: permessage-deflate\r\n")
+	}
+	io.WriteString(conn, "\r\n")
+	for {
+		b0, payload, err := testReadWebSocketFrame(brw)
+		if err != nil {
+			return
+		}
+		if _, err := conn.Write(testWebSocketFrame(b0, payload, nil)); err != nil {
+			return
+		}
+	}
+}
+
+type testWebSocketAddon struct {
+	BaseAddon
+	handshakes chan int
+	messages   chan *WebSocketMessage
+}
+
+func (addon *testWebSocketAddon) Response(f *Flow) {
+	if f.Request.URL.Path == "/ws" {
+		addon.handshakes <- f.Response.StatusCode
+	}
+}
+
+func (addon *testWebSocketAddon) WebSocketMessage(f *Flow, msg *WebSocketMessage) {
+	addon.messages <- msg
+}
+
+func TestWebSocketMessage(t *testing.T) {
+	helper := &testPipeHelper{handlers: map[string]http.HandlerFunc{"/ws": testWebSocketEcho}}
+	helper.init(t)
+	defer helper.close()
+	addon := &testWebSocketAddon{handshakes: make(chan int, 1), messages: make(chan *WebSocketMessage, 20)}
+	helper.testProxy.AddAddon(addon)
+
+	conn, err := helper.dial(context.Background(), "tcp", "proxy.pipe")
+	handleError(t, err)
+	defer conn.Close()
+	io.WriteString(conn, "CONNECT example.com:443 HTTP/1.1\r\nHost: example.com:443\r\n\r\n")
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	handleError(t, err)
+	if resp.StatusCode != 200 {
+		t.Fatalf("CONNECT failed: %v", resp.Status)
+	}
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: "example.com", InsecureSkipVerify: true})
+	io.WriteString(tlsConn, "GET /ws HTTP/1.1\r\nHost: example.com\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n"+
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Extensions: permessage-deflate\r\n\r\n")
+	br := bufio.NewReader(tlsConn)
+	resp, err = http.ReadResponse(br, nil)
+	handleError(t, err)
+	if resp.StatusCode != 101 || <-addon.handshakes != 101 {
+		t.Fatalf("expected 101, got %v", resp.Status)
+	}
+
+	// pipes are not buffered, write while reading the echo
+	go func() {
+		mask := []byte{1, 2, 3, 4}
+		// fragmented text message with a ping between the fragments
+		tlsConn.Write(testWebSocketFrame(WebSocketText, []byte("hel"), mask))
+		tlsConn.Write(testWebSocketFrame(0x80|WebSocketPing, []byte("p"), mask))
+		tlsConn.Write(testWebSocketFrame(0x80|WebSocketContinuation, []byte("lo"), mask))
+		// compressed messages sharing the context
+		buf := new(bytes.Buffer)
+		fw, _ := flate.NewWriter(buf, flate.BestCompression)
+		for _, text := range []string{"hello deflate", "hello deflate"} {
+			buf.Reset()
+			fw.Write([]byte(text))
+			fw.Flush()
+			tlsConn.Write(testWebSocketFrame(0x80|0x40|WebSocketText, bytes.TrimSuffix(buf.Bytes(), []byte{0, 0, 0xff, 0xff}), mask))
+		}
+	}()
+
+	// frames are forwarded untouched and echoed
+	for _, expected := range []string{"hel", "p", "lo", "", ""} {
+		_, payload, err := testReadWebSocketFrame(br)
+		handleError(t, err)
+		if expected != "" && string(payload) != expected {
+			t.Fatalf("expected echo %q, got %q", expected, payload)
+		}
+	}
+
+	type message struct {
+		opcode int
+		data   string
+	}
+	expected := []message{{WebSocketPing, "p"}, {WebSocketText, "hello"}, {WebSocketText, "hello deflate"}, {WebSocketText, "hello deflate"}}
+	got := map[bool][]message{}
+	for len(got[true]) < len(expected) || len(got[false]) < len(expected) {
+		select {
+		case msg := <-addon.messages:
+			got[msg.FromClient] = append(got[msg.FromClient], message{msg.Opcode, string(msg.Data)})
+		case <-time.After(time.Second):
+			t.Fatalf("expected messages %v, got %v", expected, got)
+		}
+	}
+	if !slices.Equal(got[true], expected) || !slices.Equal(got[false], expected) {
+		t.Fatalf("expected messages %v, got %v", expected, got)
+	}
+}
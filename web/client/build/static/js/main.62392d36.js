@@ -1,3 +1,3 @@
-/*! For license information please see main.dcfaa455.js.LICENSE.txt */
-!function(){var e={816:function(e){e.exports=function(){"use strict";function e(t,n,r,a){this.message=t,this.expected=n,this.found=r,this.location=a,this.name="SyntaxError","function"===typeof Error.captureStackTrace&&Error.captureStackTrace(this,e)}return function(e,t){function n(){this.constructor=e}n.prototype=t.prototype,e.prototype=new n}(e,Error),e.buildMessage=function(e,t){var n={literal:function(e){return'"'+a(e.text)+'"'},class:function(e){var t,n="";for(t=0;t<e.parts.length;t++)n+=e.parts[t]instanceof Array?o(e.parts[t][0])+"-"+o(e.parts[t][1]):o(e.parts[t]);return"["+(e.inverted?"^":"")+n+"]"},any:function(e){return"any character"},end:function(e){return"end of input"},other:function(e){return e.description}};function r(e){return e.charCodeAt(0).toString(16).toUpperCase()}function a(e){return e.replace(/\\/g,"\\\\").replace(/"/g,'\\"').replace(/\0/g,"\\0").replace(/\t/g,"\\t").replace(/\n/g,"\\n").replace(/\r/g,"\\r").replace(/[\x00-\x0F]/g,(function(e){return"\\x0"+r(e)})).replace(/[\x10-\x1F\x7F-\x9F]/g,(function(e){return"\\x"+r(e)}))}function o(e){return e.replace(/\\/g,"\\\\").replace(/\]/g,"\\]").replace(/\^/g,"\\^").replace(/-/g,"\\-").replace(/\0/g,"\\0").replace(/\t/g,"\\t").replace(/\n/g,"\\n").replace(/\r/g,"\\r").replace(/[\x00-\x0F]/g,(function(e){return"\\x0"+r(e)})).replace(/[\x10-\x1F\x7F-\x9F]/g,(function(e){return"\\x"+r(e)}))}return"Expected "+function(e){var t,r,a,o=new Array(e.length);for(t=0;t<e.length;t++)o[t]=(a=e[t],n[a.type](a));if(o.sort(),o.length>0){for(t=1,r=1;t<o.length;t++)o[t-1]!==o[t]&&(o[r]=o[t],r++);o.length=r}switch(o.length){case 1:return o[0];case 2:return o[0]+" or "+o[1];default:return o.slice(0,-1).join(", ")+", or "+o[o.length-1]}}(e)+" but "+function(e){return e?'"'+a(e)+'"':"end of input"}(t)+" found."},{SyntaxError:e,parse:function(t,n){n=void 0!==n?n:{};var r,a={},o={Expression:A},l=A,i=L("or",!1),s=L("and",!1),u=L("not",!1),c=L("(",!1),d=L(")",!1),f=/^["]/,p=z(['"'],!1,!1),h=/^[^"]/,v=z(['"'],!0,!1),m=function(){return{type:"keyword",value:O().slice(1,-1)}},y=/^[']/,g=z(["'"],!1,!1),b=/^[^']/,w=z(["'"],!0,!1),x=/^[^ ()]/,k=z([" ","(",")"],!0,!1),S=D("whitespace"),E=/^[ \t\n\r]/,C=z([" ","\t","\n","\r"],!1,!1),N=0,_=0,R=[{line:1,column:1}],j=0,P=[],T=0;if("startRule"in n){if(!(n.startRule in o))throw new Error("Can't start parsing from rule \""+n.startRule+'".');l=o[n.startRule]}function O(){return t.substring(_,N)}function L(e,t){return{type:"literal",text:e,ignoreCase:t}}function z(e,t,n){return{type:"class",parts:e,inverted:t,ignoreCase:n}}function D(e){return{type:"other",description:e}}function F(e){var n,r=R[e];if(r)return r;for(n=e-1;!R[n];)n--;for(r={line:(r=R[n]).line,column:r.column};n<e;)10===t.charCodeAt(n)?(r.line++,r.column=1):r.column++,n++;return R[e]=r,r}function M(e,t){var n=F(e),r=F(t);return{start:{offset:e,line:n.line,column:n.column},end:{offset:t,line:r.line,column:r.column}}}function I(e){N<j||(N>j&&(j=N,P=[]),P.push(e))}function B(t,n,r){return new e(e.buildMessage(t,n),t,n,r)}function A(){return U()}function U(){var e,n,r,o;return e=N,(n=H())!==a&&W()!==a?("or"===t.substr(N,2)?(r="or",N+=2):(r=a,0===T&&I(i)),r!==a&&W()!==a&&(o=U())!==a?(_=e,e=n={type:"or",left:n,right:o}):(N=e,e=a)):(N=e,e=a),e===a&&(e=H()),e}function H(){var e,n,r,o;return e=N,(n=q())!==a&&W()!==a?("and"===t.substr(N,3)?(r="and",N+=3):(r=a,0===T&&I(s)),r!==a&&W()!==a&&(o=H())!==a?(_=e,e=n={type:"and",left:n,right:o}):(N=e,e=a)):(N=e,e=a),e===a&&(e=q()),e}function q(){var e,n,r;return e=N,W()!==a?("not"===t.substr(N,3)?(n="not",N+=3):(n=a,0===T&&I(u)),n!==a&&W()!==a&&(r=V())!==a?(_=e,e={type:"not",expr:r}):(N=e,e=a)):(N=e,e=a),e===a&&(e=V()),e}function V(){var e,n,r,o;return e=N,40===t.charCodeAt(N)?(n="(",N++):(n=a,0===T&&I(c)),n!==a&&W()!==a&&(r=U())!==a&&W()!==a?(41===t.charCodeAt(N)?(o=")",N++):(o=a,0===T&&I(d)),o!==a?(_=e,e=n=r):(N=e,e=a)):(N=e,e=a),e===a&&(e=function(){var e,n,r,o;e=N,f.test(t.charAt(N))?(n=t.charAt(N),N++):(n=a,0===T&&I(p));if(n!==a){for(r=[],h.test(t.charAt(N))?(o=t.charAt(N),N++):(o=a,0===T&&I(v));o!==a;)r.push(o),h.test(t.charAt(N))?(o=t.charAt(N),N++):(o=a,0===T&&I(v));r!==a?(f.test(t.charAt(N))?(o=t.charAt(N),N++):(o=a,0===T&&I(p)),o!==a?(_=e,e=n=m()):(N=e,e=a)):(N=e,e=a)}else N=e,e=a;return e}(),e===a&&(e=function(){var e,n,r,o;e=N,y.test(t.charAt(N))?(n=t.charAt(N),N++):(n=a,0===T&&I(g));if(n!==a){for(r=[],b.test(t.charAt(N))?(o=t.charAt(N),N++):(o=a,0===T&&I(w));o!==a;)r.push(o),b.test(t.charAt(N))?(o=t.charAt(N),N++):(o=a,0===T&&I(w));r!==a?(y.test(t.charAt(N))?(o=t.charAt(N),N++):(o=a,0===T&&I(g)),o!==a?(_=e,e=n=m()):(N=e,e=a)):(N=e,e=a)}else N=e,e=a;return e}(),e===a&&(e=function(){var e,n,r;e=N,n=[],x.test(t.charAt(N))?(r=t.charAt(N),N++):(r=a,0===T&&I(k));for(;r!==a;)n.push(r),x.test(t.charAt(N))?(r=t.charAt(N),N++):(r=a,0===T&&I(k));n!==a&&(_=e,n={type:"keyword",value:O()});return e=n,e}()))),e}function W(){var e,n;for(T++,e=[],E.test(t.charAt(N))?(n=t.charAt(N),N++):(n=a,0===T&&I(C));n!==a;)e.push(n),E.test(t.charAt(N))?(n=t.charAt(N),N++):(n=a,0===T&&I(C));return T--,e===a&&(n=a,0===T&&I(S)),e}if((r=l())!==a&&N===t.length)return r;throw r!==a&&N<t.length&&I({type:"end"}),B(P,j<t.length?t.charAt(j):null,j<t.length?M(j,j+1):M(j,j))}}}()},694:function(e,t){var n;!function(){"use strict";var r={}.hasOwnProperty;function a(){for(var e=[],t=0;t<arguments.length;t++){var n=arguments[t];if(n){var o=typeof n;if("string"===o||"number"===o)e.push(n);else if(Array.isArray(n)){if(n.length){var l=a.apply(null,n);l&&e.push(l)}}else if("object"===o){if(n.toString!==Object.prototype.toString&&!n.toString.toString().includes("[native code]")){e.push(n.toString());continue}for(var i in n)r.call(n,i)&&n[i]&&e.push(i)}}}return e.join(" ")}e.exports?(a.default=a,e.exports=a):void 0===(n=function(){return a}.apply(t,[]))||(e.exports=n)}()},998:function(e,t,n){"use strict";var r=n(458),a={"text/plain":"Text","text/html":"Url",default:"Text"};e.exports=function(e,t){var n,o,l,i,s,u,c=!1;t||(t={}),n=t.debug||!1;try{if(l=r(),i=document.createRange(),s=document.getSelection(),(u=document.createElement("span")).textContent=e,u.ariaHidden="true",u.style.all="unset",u.style.position="fixed",u.style.top=0,u.style.clip="rect(0, 0, 0, 0)",u.style.whiteSpace="pre",u.style.webkitUserSelect="text",u.style.MozUserSelect="text",u.style.msUserSelect="text",u.style.userSelect="text",u.addEventListener("copy",(function(r){if(r.stopPropagation(),t.format)if(r.preventDefault(),"undefined"===typeof r.clipboardData){n&&console.warn("unable to use e.clipboardData"),n&&console.warn("trying IE specific stuff"),window.clipboardData.clearData();var o=a[t.format]||a.default;window.clipboardData.setData(o,e)}else r.clipboardData.clearData(),r.clipboardData.setData(t.format,e);t.onCopy&&(r.preventDefault(),t.onCopy(r.clipboardData))})),document.body.appendChild(u),i.selectNodeContents(u),s.addRange(i),!document.execCommand("copy"))throw new Error("copy command was unsuccessful");c=!0}catch(d){n&&console.error("unable to copy using execCommand: ",d),n&&console.warn("trying IE specific stuff");try{window.clipboardData.setData(t.format||"text",e),t.onCopy&&t.onCopy(window.clipboardData),c=!0}catch(d){n&&console.error("unable to copy using clipboardData: ",d),n&&console.error("falling back to prompt"),o=function(e){var t=(/mac os x/i.test(navigator.userAgent)?"\u2318":"Ctrl")+"+C";return e.replace(/#{\s*key\s*}/g,t)}("message"in t?t.message:"Copy to clipboard: #{key}, Enter"),window.prompt(o,e)}}finally{s&&("function"==typeof s.removeRange?s.removeRange(i):s.removeAllRanges()),u&&document.body.removeChild(u),l()}return c}},400:function(e,t){"use strict";var n=function(e){var t=e.method;return t&&{GET:" -X GET",POST:" -X POST",PUT:" -X PUT",PATCH:" -X PATCH",DELETE:" -X DELETE",HEAD:" -X HEAD",OPTIONS:" -X OPTIONS"}[t.toUpperCase()]||""},r=function(e){return"function"==typeof Headers&&e instanceof Headers},a=function(e,t){return' -H "'.concat(e,": ").concat(t.replace(/(\\|")/g,"\\$1"),'"')},o=function(){var e=arguments.length>0&&void 0!==arguments[0]?arguments[0]:{},t=e.headers,n=!1,o="";return r(t)?t.forEach((function(e,t){"content-length"!==t.toLocaleLowerCase()&&(o+=a(t,e)),"accept-encoding"===t.toLocaleLowerCase()&&(n=!0)})):t&&Object.keys(t).map((function(e){"content-length"!==e.toLocaleLowerCase()&&(o+=a(e,t[e])),"accept-encoding"===e.toLocaleLowerCase()&&(n=!0)})),{params:o,isEncode:n}};function l(e){return e?" --data-binary '".concat("object"==typeof e?JSON.stringify(e):e,"'"):""}function i(e){return e?" --compressed":""}var s=function(e,t){var r,a;"string"==typeof e?(r=e,a=t||{}):(r=(e||{}).url,a=e||{});var s=a.body,u=o(a);return"curl '".concat(r,"'").concat(n(a)).concat(u.params||"").concat(l(s)).concat(i(u.isEncode))};t.ZP=s},176:function(e){"use strict";e.exports=function(e,t,n,r,a,o,l,i){if(!e){var s;if(void 0===t)s=new Error("Minified exception occurred; use the non-minified dev environment for the full error message and additional helpful warnings.");else{var u=[n,r,a,o,l,i],c=0;(s=new Error(t.replace(/%s/g,(function(){return u[c++]})))).name="Invariant Violation"}throw s.framesToPop=1,s}}},888:function(e,t,n){"use strict";var r=n(47);function a(){}function o(){}o.resetWarningCache=a,e.exports=function(){function e(e,t,n,a,o,l){if(l!==r){var i=new Error("Calling PropTypes validators directly is not supported by the `prop-types` package. Use PropTypes.checkPropTypes() to call them. Read more at http://fb.me/use-check-prop-types");throw i.name="Invariant Violation",i}}function t(){return e}e.isRequired=e;var n={array:e,bigint:e,bool:e,func:e,number:e,object:e,string:e,symbol:e,any:e,arrayOf:t,element:e,elementType:e,instanceOf:t,node:e,objectOf:t,oneOf:t,oneOfType:t,shape:t,exact:t,checkPropTypes:o,resetWarningCache:a};return n.PropTypes=n,n}},7:function(e,t,n){e.exports=n(888)()},47:function(e){"use strict";e.exports="SECRET_DO_NOT_PASS_THIS_OR_YOU_WILL_BE_FIRED"},463:function(e,t,n){"use strict";var r=n(791),a=n(296);function o(e){for(var t="https://reactjs.org/docs/error-decoder.html?invariant="+e,n=1;n<arguments.length;n++)t+="&args[]="+encodeURIComponent(arguments[n]);return"Minified React error #"+e+"; visit "+t+" for the full message or use the non-minified dev environment for full errors and additional helpful warnings."}var l=new Set,i={};function s(e,t){u(e,t),u(e+"Capture",t)}function u(e,t){for(i[e]=t,e=0;e<t.length;e++)l.add(t[e])}var c=!("undefined"===typeof window||"undefined"===typeof window.document||"undefined"===typeof window.document.createElement),d=Object.prototype.hasOwnProperty,f=/^[:A-Z_a-z\u00C0-\u00D6\u00D8-\u00F6\u00F8-\u02FF\u0370-\u037D\u037F-\u1FFF\u200C-\u200D\u2070-\u218F\u2C00-\u2FEF\u3001-\uD7FF\uF900-\uFDCF\uFDF0-\uFFFD][:A-Z_a-z\u00C0-\u00D6\u00D8-\u00F6\u00F8-\u02FF\u0370-\u037D\u037F-\u1FFF\u200C-\u200D\u2070-\u218F\u2C00-\u2FEF\u3001-\uD7FF\uF900-\uFDCF\uFDF0-\uFFFD\-.0-9\u00B7\u0300-\u036F\u203F-\u2040]*$/,p={},h={};function v(e,t,n,r,a,o,l){this.acceptsBooleans=2===t||3===t||4===t,this.attributeName=r,this.attributeNamespace=a,this.mustUseProperty=n,this.propertyName=e,this.type=t,this.sanitizeURL=o,this.removeEmptyString=l}var m={};"children dangerouslySetInnerHTML defaultValue defaultChecked innerHTML suppressContentEditableWarning suppressHydrationWarning style".split(" ").forEach((function(e){m[e]=new v(e,0,!1,e,null,!1,!1)})),[["acceptCharset","accept-charset"],["className","class"],["htmlFor","for"],["httpEquiv","http-equiv"]].forEach((function(e){var t=e[0];m[t]=new v(t,1,!1,e[1],null,!1,!1)})),["contentEditable","draggable","spellCheck","value"].forEach((function(e){m[e]=new v(e,2,!1,e.toLowerCase(),null,!1,!1)})),["autoReverse","externalResourcesRequired","focusable","preserveAlpha"].forEach((function(e){m[e]=new v(e,2,!1,e,null,!1,!1)})),"allowFullScreen async autoFocus autoPlay controls default defer disabled disablePictureInPicture disableRemotePlayback formNoValidate hidden loop noModule noValidate open playsInline readOnly required reversed scoped seamless itemScope".split(" ").forEach((function(e){m[e]=new v(e,3,!1,e.toLowerCase(),null,!1,!1)})),["checked","multiple","muted","selected"].forEach((function(e){m[e]=new v(e,3,!0,e,null,!1,!1)})),["capture","download"].forEach((function(e){m[e]=new v(e,4,!1,e,null,!1,!1)})),["cols","rows","size","span"].forEach((function(e){m[e]=new v(e,6,!1,e,null,!1,!1)})),["rowSpan","start"].forEach((function(e){m[e]=new v(e,5,!1,e.toLowerCase(),null,!1,!1)}));var y=/[\-:]([a-z])/g;function g(e){return e[1].toUpperCase()}function b(e,t,n,r){var a=m.hasOwnProperty(t)?m[t]:null;(null!==a?0!==a.type:r||!(2<t.length)||"o"!==t[0]&&"O"!==t[0]||"n"!==t[1]&&"N"!==t[1])&&(function(e,t,n,r){if(null===t||"undefined"===typeof t||function(e,t,n,r){if(null!==n&&0===n.type)return!1;switch(typeof t){case"function":case"symbol":return!0;case"boolean":return!r&&(null!==n?!n.acceptsBooleans:"data-"!==(e=e.toLowerCase().slice(0,5))&&"aria-"!==e);default:return!1}}(e,t,n,r))return!0;if(r)return!1;if(null!==n)switch(n.type){case 3:return!t;case 4:return!1===t;case 5:return isNaN(t);case 6:return isNaN(t)||1>t}return!1}(t,n,a,r)&&(n=null),r||null===a?function(e){return!!d.call(h,e)||!d.call(p,e)&&(f.test(e)?h[e]=!0:(p[e]=!0,!1))}(t)&&(null===n?e.removeAttribute(t):e.setAttribute(t,""+n)):a.mustUseProperty?e[a.propertyName]=null===n?3!==a.type&&"":n:(t=a.attributeName,r=a.attributeNamespace,null===n?e.removeAttribute(t):(n=3===(a=a.type)||4===a&&!0===n?"":""+n,r?e.setAttributeNS(r,t,n):e.setAttribute(t,n))))}"accent-height alignment-baseline arabic-form baseline-shift cap-height clip-path clip-rule color-interpolation color-interpolation-filters color-profile color-rendering dominant-baseline enable-background fill-opacity fill-rule flood-color flood-opacity font-family font-size font-size-adjust font-stretch font-style font-variant font-weight glyph-name glyph-orientation-horizontal glyph-orientation-vertical horiz-adv-x horiz-origin-x image-rendering letter-spacing lighting-color marker-end marker-mid marker-start overline-position overline-thickness paint-order panose-1 pointer-events rendering-intent shape-rendering stop-color stop-opacity strikethrough-position strikethrough-thickness stroke-dasharray stroke-dashoffset stroke-linecap stroke-linejoin stroke-miterlimit stroke-opacity stroke-width text-anchor text-decoration text-rendering underline-position underline-thickness unicode-bidi unicode-range units-per-em v-alphabetic v-hanging v-ideographic v-mathematical vector-effect vert-adv-y vert-origin-x vert-origin-y word-spacing writing-mode xmlns:xlink x-height".split(" ").forEach((function(e){var t=e.replace(y,g);m[t]=new v(t,1,!1,e,null,!1,!1)})),"xlink:actuate xlink:arcrole xlink:role xlink:show xlink:title xlink:type".split(" ").forEach((function(e){var t=e.replace(y,g);m[t]=new v(t,1,!1,e,"http://www.w3.org/1999/xlink",!1,!1)})),["xml:base","xml:lang","xml:space"].forEach((function(e){var t=e.replace(y,g);m[t]=new v(t,1,!1,e,"http://www.w3.org/XML/1998/namespace",!1,!1)})),["tabIndex","crossOrigin"].forEach((function(e){m[e]=new v(e,1,!1,e.toLowerCase(),null,!1,!1)})),m.xlinkHref=new v("xlinkHref",1,!1,"xlink:href","http://www.w3.org/1999/xlink",!0,!1),["src","href","action","formAction"].forEach((function(e){m[e]=new v(e,1,!1,e.toLowerCase(),null,!0,!0)}));var w=r.__SECRET_INTERNALS_DO_NOT_USE_OR_YOU_WILL_BE_FIRED,x=Symbol.for("react.element"),k=Symbol.for("react.portal"),S=Symbol.for("react.fragment"),E=Symbol.for("react.strict_mode"),C=Symbol.for("react.profiler"),N=Symbol.for("react.provider"),_=Symbol.for("react.context"),R=Symbol.for("react.forward_ref"),j=Symbol.for("react.suspense"),P=Symbol.for("react.suspense_list"),T=Symbol.for("react.memo"),O=Symbol.for("react.lazy");Symbol.for("react.scope"),Symbol.for("react.debug_trace_mode");var L=Symbol.for("react.offscreen");Symbol.for("react.legacy_hidden"),Symbol.for("react.cache"),Symbol.for("react.tracing_marker");var z=Symbol.iterator;function D(e){return null===e||"object"!==typeof e?null:"function"===typeof(e=z&&e[z]||e["@@iterator"])?e:null}var F,M=Object.assign;function I(e){if(void 0===F)try{throw Error()}catch(n){var t=n.stack.trim().match(/\n( *(at )?)/);F=t&&t[1]||""}return"\n"+F+e}var B=!1;function A(e,t){if(!e||B)return"";B=!0;var n=Error.prepareStackTrace;Error.prepareStackTrace=void 0;try{if(t)if(t=function(){throw Error()},Object.defineProperty(t.prototype,"props",{set:function(){throw Error()}}),"object"===typeof Reflect&&Reflect.construct){try{Reflect.construct(t,[])}catch(u){var r=u}Reflect.construct(e,[],t)}else{try{t.call()}catch(u){r=u}e.call(t.prototype)}else{try{throw Error()}catch(u){r=u}e()}}catch(u){if(u&&r&&"string"===typeof u.stack){for(var a=u.stack.split("\n"),o=r.stack.split("\n"),l=a.length-1,i=o.length-1;1<=l&&0<=i&&a[l]!==o[i];)i--;for(;1<=l&&0<=i;l--,i--)if(a[l]!==o[i]){if(1!==l||1!==i)do{if(l--,0>--i||a[l]!==o[i]){var s="\n"+a[l].replace(" at new "," at ");return e.displayName&&s.includes("<anonymous>")&&(s=s.replace("<anonymous>",e.displayName)),s}}while(1<=l&&0<=i);break}}}finally{B=!1,Error.prepareStackTrace=n}return(e=e?e.displayName||e.name:"")?I(e):""}function U(e){switch(e.tag){case 5:return I(e.type);case 16:return I("Lazy");case 13:return I("Suspense");case 19:return I("SuspenseList");case 0:case 2:case 15:return e=A(e.type,!1);case 11:return e=A(e.type.render,!1);case 1:return e=A(e.type,!0);default:return""}}function H(e){if(null==e)return null;if("function"===typeof e)return e.displayName||e.name||null;if("string"===typeof e)return e;switch(e){case S:return"Fragment";case k:return"Portal";case C:return"Profiler";case E:return"StrictMode";case j:return"Suspense";case P:return"SuspenseList"}if("object"===typeof e)switch(e.$$typeof){case _:return(e.displayName||"Context")+".Consumer";case N:return(e._context.displayName||"Context")+".Provider";case R:var t=e.render;return(e=e.displayName)||(e=""!==(e=t.displayName||t.name||"")?"ForwardRef("+e+")":"ForwardRef"),e;case T:return null!==(t=e.displayName||null)?t:H(e.type)||"Memo";case O:t=e._payload,e=e._init;try{return H(e(t))}catch(n){}}return null}function q(e){var t=e.type;switch(e.tag){case 24:return"Cache";case 9:return(t.displayName||"Context")+".Consumer";case 10:return(t._context.displayName||"Context")+".Provider";case 18:return"DehydratedFragment";case 11:return e=(e=t.render).displayName||e.name||"",t.displayName||(""!==e?"ForwardRef("+e+")":"ForwardRef");case 7:return"Fragment";case 5:return t;case 4:return"Portal";case 3:return"Root";case 6:return"Text";case 16:return H(t);case 8:return t===E?"StrictMode":"Mode";case 22:return"Offscreen";case 12:return"Profiler";case 21:return"Scope";case 13:return"Suspense";case 19:return"SuspenseList";case 25:return"TracingMarker";case 1:case 0:case 17:case 2:case 14:case 15:if("function"===typeof t)return t.displayName||t.name||null;if("string"===typeof t)return t}return null}function V(e){switch(typeof e){case"boolean":case"number":case"string":case"undefined":case"object":return e;default:return""}}function W(e){var t=e.type;return(e=e.nodeName)&&"input"===e.toLowerCase()&&("checkbox"===t||"radio"===t)}function $(e){e._valueTracker||(e._valueTracker=function(e){var t=W(e)?"checked":"value",n=Object.getOwnPropertyDescriptor(e.constructor.prototype,t),r=""+e[t];if(!e.hasOwnProperty(t)&&"undefined"!==typeof n&&"function"===typeof n.get&&"function"===typeof n.set){var a=n.get,o=n.set;return Object.defineProperty(e,t,{configurable:!0,get:function(){return a.call(this)},set:function(e){r=""+e,o.call(this,e)}}),Object.defineProperty(e,t,{enumerable:n.enumerable}),{getValue:function(){return r},setValue:function(e){r=""+e},stopTracking:function(){e._valueTracker=null,delete e[t]}}}}(e))}function Q(e){if(!e)return!1;var t=e._valueTracker;if(!t)return!0;var n=t.getValue(),r="";return e&&(r=W(e)?e.checked?"true":"false":e.value),(e=r)!==n&&(t.setValue(e),!0)}function K(e){if("undefined"===typeof(e=e||("undefined"!==typeof document?document:void 0)))return null;try{return e.activeElement||e.body}catch(t){return e.body}}function Y(e,t){var n=t.checked;return M({},t,{defaultChecked:void 0,defaultValue:void 0,value:void 0,checked:null!=n?n:e._wrapperState.initialChecked})}function G(e,t){var n=null==t.defaultValue?"":t.defaultValue,r=null!=t.checked?t.checked:t.defaultChecked;n=V(null!=t.value?t.value:n),e._wrapperState={initialChecked:r,initialValue:n,controlled:"checkbox"===t.type||"radio"===t.type?null!=t.checked:null!=t.value}}function X(e,t){null!=(t=t.checked)&&b(e,"checked",t,!1)}function J(e,t){X(e,t);var n=V(t.value),r=t.type;if(null!=n)"number"===r?(0===n&&""===e.value||e.value!=n)&&(e.value=""+n):e.value!==""+n&&(e.value=""+n);else if("submit"===r||"reset"===r)return void e.removeAttribute("value");t.hasOwnProperty("value")?ee(e,t.type,n):t.hasOwnProperty("defaultValue")&&ee(e,t.type,V(t.defaultValue)),null==t.checked&&null!=t.defaultChecked&&(e.defaultChecked=!!t.defaultChecked)}function Z(e,t,n){if(t.hasOwnProperty("value")||t.hasOwnProperty("defaultValue")){var r=t.type;if(!("submit"!==r&&"reset"!==r||void 0!==t.value&&null!==t.value))return;t=""+e._wrapperState.initialValue,n||t===e.value||(e.value=t),e.defaultValue=t}""!==(n=e.name)&&(e.name=""),e.defaultChecked=!!e._wrapperState.initialChecked,""!==n&&(e.name=n)}function ee(e,t,n){"number"===t&&K(e.ownerDocument)===e||(null==n?e.defaultValue=""+e._wrapperState.initialValue:e.defaultValue!==""+n&&(e.defaultValue=""+n))}var te=Array.isArray;function ne(e,t,n,r){if(e=e.options,t){t={};for(var a=0;a<n.length;a++)t["$"+n[a]]=!0;for(n=0;n<e.length;n++)a=t.hasOwnProperty("$"+e[n].value),e[n].selected!==a&&(e[n].selected=a),a&&r&&(e[n].defaultSelected=!0)}else{for(n=""+V(n),t=null,a=0;a<e.length;a++){if(e[a].value===n)return e[a].selected=!0,void(r&&(e[a].defaultSelected=!0));null!==t||e[a].disabled||(t=e[a])}null!==t&&(t.selected=!0)}}function re(e,t){if(null!=t.dangerouslySetInnerHTML)throw Error(o(91));return M({},t,{value:void 0,defaultValue:void 0,children:""+e._wrapperState.initialValue})}function ae(e,t){var n=t.value;if(null==n){if(n=t.children,t=t.defaultValue,null!=n){if(null!=t)throw Error(o(92));if(te(n)){if(1<n.length)throw Error(o(93));n=n[0]}t=n}null==t&&(t=""),n=t}e._wrapperState={initialValue:V(n)}}function oe(e,t){var n=V(t.value),r=V(t.defaultValue);null!=n&&((n=""+n)!==e.value&&(e.value=n),null==t.defaultValue&&e.defaultValue!==n&&(e.defaultValue=n)),null!=r&&(e.defaultValue=""+r)}function le(e){var t=e.textContent;t===e._wrapperState.initialValue&&""!==t&&null!==t&&(e.value=t)}function ie(e){switch(e){case"svg":return"http://www.w3.org/2000/svg";case"math":return"http://www.w3.org/1998/Math/MathML";default:return"http://www.w3.org/1999/xhtml"}}function se(e,t){return null==e||"http://www.w3.org/1999/xhtml"===e?ie(t):"http://www.w3.org/2000/svg"===e&&"foreignObject"===t?"http://www.w3.org/1999/xhtml":e}var ue,ce,de=(ce=function(e,t){if("http://www.w3.org/2000/svg"!==e.namespaceURI||"innerHTML"in e)e.innerHTML=t;else{for((ue=ue||document.createElement("div")).innerHTML="<svg>"+t.valueOf().toString()+"</svg>",t=ue.firstChild;e.firstChild;)e.removeChild(e.firstChild);for(;t.firstChild;)e.appendChild(t.firstChild)}},"undefined"!==typeof MSApp&&MSApp.execUnsafeLocalFunction?function(e,t,n,r){MSApp.execUnsafeLocalFunction((function(){return ce(e,t)}))}:ce);function fe(e,t){if(t){var n=e.firstChild;if(n&&n===e.lastChild&&3===n.nodeType)return void(n.nodeValue=t)}e.textContent=t}var pe={animationIterationCount:!0,aspectRatio:!0,borderImageOutset:!0,borderImageSlice:!0,borderImageWidth:!0,boxFlex:!0,boxFlexGroup:!0,boxOrdinalGroup:!0,columnCount:!0,columns:!0,flex:!0,flexGrow:!0,flexPositive:!0,flexShrink:!0,flexNegative:!0,flexOrder:!0,gridArea:!0,gridRow:!0,gridRowEnd:!0,gridRowSpan:!0,gridRowStart:!0,gridColumn:!0,gridColumnEnd:!0,gridColumnSpan:!0,gridColumnStart:!0,fontWeight:!0,lineClamp:!0,lineHeight:!0,opacity:!0,order:!0,orphans:!0,tabSize:!0,widows:!0,zIndex:!0,zoom:!0,fillOpacity:!0,floodOpacity:!0,stopOpacity:!0,strokeDasharray:!0,strokeDashoffset:!0,strokeMiterlimit:!0,strokeOpacity:!0,strokeWidth:!0},he=["Webkit","ms","Moz","O"];function ve(e,t,n){return null==t||"boolean"===typeof t||""===t?"":n||"number"!==typeof t||0===t||pe.hasOwnProperty(e)&&pe[e]?(""+t).trim():t+"px"}function me(e,t){for(var n in e=e.style,t)if(t.hasOwnProperty(n)){var r=0===n.indexOf("--"),a=ve(n,t[n],r);"float"===n&&(n="cssFloat"),r?e.setProperty(n,a):e[n]=a}}Object.keys(pe).forEach((function(e){he.forEach((function(t){t=t+e.charAt(0).toUpperCase()+e.substring(1),pe[t]=pe[e]}))}));var ye=M({menuitem:!0},{area:!0,base:!0,br:!0,col:!0,embed:!0,hr:!0,img:!0,input:!0,keygen:!0,link:!0,meta:!0,param:!0,source:!0,track:!0,wbr:!0});function ge(e,t){if(t){if(ye[e]&&(null!=t.children||null!=t.dangerouslySetInnerHTML))throw Error(o(137,e));if(null!=t.dangerouslySetInnerHTML){if(null!=t.children)throw Error(o(60));if("object"!==typeof t.dangerouslySetInnerHTML||!("__html"in t.dangerouslySetInnerHTML))throw Error(o(61))}if(null!=t.style&&"object"!==typeof t.style)throw Error(o(62))}}function be(e,t){if(-1===e.indexOf("-"))return"string"===typeof t.is;switch(e){case"annotation-xml":case"color-profile":case"font-face":case"font-face-src":case"font-face-uri":case"font-face-format":case"font-face-name":case"missing-glyph":return!1;default:return!0}}var we=null;function xe(e){return(e=e.target||e.srcElement||window).correspondingUseElement&&(e=e.correspondingUseElement),3===e.nodeType?e.parentNode:e}var ke=null,Se=null,Ee=null;function Ce(e){if(e=ba(e)){if("function"!==typeof ke)throw Error(o(280));var t=e.stateNode;t&&(t=xa(t),ke(e.stateNode,e.type,t))}}function Ne(e){Se?Ee?Ee.push(e):Ee=[e]:Se=e}function _e(){if(Se){var e=Se,t=Ee;if(Ee=Se=null,Ce(e),t)for(e=0;e<t.length;e++)Ce(t[e])}}function Re(e,t){return e(t)}function je(){}var Pe=!1;function Te(e,t,n){if(Pe)return e(t,n);Pe=!0;try{return Re(e,t,n)}finally{Pe=!1,(null!==Se||null!==Ee)&&(je(),_e())}}function Oe(e,t){var n=e.stateNode;if(null===n)return null;var r=xa(n);if(null===r)return null;n=r[t];e:switch(t){case"onClick":case"onClickCapture":case"onDoubleClick":case"onDoubleClickCapture":case"onMouseDown":case"onMouseDownCapture":case"onMouseMove":case"onMouseMoveCapture":case"onMouseUp":case"onMouseUpCapture":case"onMouseEnter":(r=!r.disabled)||(r=!("button"===(e=e.type)||"input"===e||"select"===e||"textarea"===e)),e=!r;break e;default:e=!1}if(e)return null;if(n&&"function"!==typeof n)throw Error(o(231,t,typeof n));return n}var Le=!1;if(c)try{var ze={};Object.defineProperty(ze,"passive",{get:function(){Le=!0}}),window.addEventListener("test",ze,ze),window.removeEventListener("test",ze,ze)}catch(ce){Le=!1}function De(e,t,n,r,a,o,l,i,s){var u=Array.prototype.slice.call(arguments,3);try{t.apply(n,u)}catch(c){this.onError(c)}}var Fe=!1,Me=null,Ie=!1,Be=null,Ae={onError:function(e){Fe=!0,Me=e}};function Ue(e,t,n,r,a,o,l,i,s){Fe=!1,Me=null,De.apply(Ae,arguments)}function He(e){var t=e,n=e;if(e.alternate)for(;t.return;)t=t.return;else{e=t;do{0!==(4098&(t=e).flags)&&(n=t.return),e=t.return}while(e)}return 3===t.tag?n:null}function qe(e){if(13===e.tag){var t=e.memoizedState;if(null===t&&(null!==(e=e.alternate)&&(t=e.memoizedState)),null!==t)return t.dehydrated}return null}function Ve(e){if(He(e)!==e)throw Error(o(188))}function We(e){return null!==(e=function(e){var t=e.alternate;if(!t){if(null===(t=He(e)))throw Error(o(188));return t!==e?null:e}for(var n=e,r=t;;){var a=n.return;if(null===a)break;var l=a.alternate;if(null===l){if(null!==(r=a.return)){n=r;continue}break}if(a.child===l.child){for(l=a.child;l;){if(l===n)return Ve(a),e;if(l===r)return Ve(a),t;l=l.sibling}throw Error(o(188))}if(n.return!==r.return)n=a,r=l;else{for(var i=!1,s=a.child;s;){if(s===n){i=!0,n=a,r=l;break}if(s===r){i=!0,r=a,n=l;break}s=s.sibling}if(!i){for(s=l.child;s;){if(s===n){i=!0,n=l,r=a;break}if(s===r){i=!0,r=l,n=a;break}s=s.sibling}if(!i)throw Error(o(189))}}if(n.alternate!==r)throw Error(o(190))}if(3!==n.tag)throw Error(o(188));return n.stateNode.current===n?e:t}(e))?$e(e):null}function $e(e){if(5===e.tag||6===e.tag)return e;for(e=e.child;null!==e;){var t=$e(e);if(null!==t)return t;e=e.sibling}return null}var Qe=a.unstable_scheduleCallback,Ke=a.unstable_cancelCallback,Ye=a.unstable_shouldYield,Ge=a.unstable_requestPaint,Xe=a.unstable_now,Je=a.unstable_getCurrentPriorityLevel,Ze=a.unstable_ImmediatePriority,et=a.unstable_UserBlockingPriority,tt=a.unstable_NormalPriority,nt=a.unstable_LowPriority,rt=a.unstable_IdlePriority,at=null,ot=null;var lt=Math.clz32?Math.clz32:function(e){return e>>>=0,0===e?32:31-(it(e)/st|0)|0},it=Math.log,st=Math.LN2;var ut=64,ct=4194304;function dt(e){switch(e&-e){case 1:return 1;case 2:return 2;case 4:return 4;case 8:return 8;case 16:return 16;case 32:return 32;case 64:case 128:case 256:case 512:case 1024:case 2048:case 4096:case 8192:case 16384:case 32768:case 65536:case 131072:case 262144:case 524288:case 1048576:case 2097152:return 4194240&e;case 4194304:case 8388608:case 16777216:case 33554432:case 67108864:return 130023424&e;case 134217728:return 134217728;case 268435456:return 268435456;case 536870912:return 536870912;case 1073741824:return 1073741824;default:return e}}function ft(e,t){var n=e.pendingLanes;if(0===n)return 0;var r=0,a=e.suspendedLanes,o=e.pingedLanes,l=268435455&n;if(0!==l){var i=l&~a;0!==i?r=dt(i):0!==(o&=l)&&(r=dt(o))}else 0!==(l=n&~a)?r=dt(l):0!==o&&(r=dt(o));if(0===r)return 0;if(0!==t&&t!==r&&0===(t&a)&&((a=r&-r)>=(o=t&-t)||16===a&&0!==(4194240&o)))return t;if(0!==(4&r)&&(r|=16&n),0!==(t=e.entangledLanes))for(e=e.entanglements,t&=r;0<t;)a=1<<(n=31-lt(t)),r|=e[n],t&=~a;return r}function pt(e,t){switch(e){case 1:case 2:case 4:return t+250;case 8:case 16:case 32:case 64:case 128:case 256:case 512:case 1024:case 2048:case 4096:case 8192:case 16384:case 32768:case 65536:case 131072:case 262144:case 524288:case 1048576:case 2097152:return t+5e3;default:return-1}}function ht(e){return 0!==(e=-1073741825&e.pendingLanes)?e:1073741824&e?1073741824:0}function vt(){var e=ut;return 0===(4194240&(ut<<=1))&&(ut=64),e}function mt(e){for(var t=[],n=0;31>n;n++)t.push(e);return t}function yt(e,t,n){e.pendingLanes|=t,536870912!==t&&(e.suspendedLanes=0,e.pingedLanes=0),(e=e.eventTimes)[t=31-lt(t)]=n}function gt(e,t){var n=e.entangledLanes|=t;for(e=e.entanglements;n;){var r=31-lt(n),a=1<<r;a&t|e[r]&t&&(e[r]|=t),n&=~a}}var bt=0;function wt(e){return 1<(e&=-e)?4<e?0!==(268435455&e)?16:536870912:4:1}var xt,kt,St,Et,Ct,Nt=!1,_t=[],Rt=null,jt=null,Pt=null,Tt=new Map,Ot=new Map,Lt=[],zt="mousedown mouseup touchcancel touchend touchstart auxclick dblclick pointercancel pointerdown pointerup dragend dragstart drop compositionend compositionstart keydown keypress keyup input textInput copy cut paste click change contextmenu reset submit".split(" ");function Dt(e,t){switch(e){case"focusin":case"focusout":Rt=null;break;case"dragenter":case"dragleave":jt=null;break;case"mouseover":case"mouseout":Pt=null;break;case"pointerover":case"pointerout":Tt.delete(t.pointerId);break;case"gotpointercapture":case"lostpointercapture":Ot.delete(t.pointerId)}}function Ft(e,t,n,r,a,o){return null===e||e.nativeEvent!==o?(e={blockedOn:t,domEventName:n,eventSystemFlags:r,nativeEvent:o,targetContainers:[a]},null!==t&&(null!==(t=ba(t))&&kt(t)),e):(e.eventSystemFlags|=r,t=e.targetContainers,null!==a&&-1===t.indexOf(a)&&t.push(a),e)}function Mt(e){var t=ga(e.target);if(null!==t){var n=He(t);if(null!==n)if(13===(t=n.tag)){if(null!==(t=qe(n)))return e.blockedOn=t,void Ct(e.priority,(function(){St(n)}))}else if(3===t&&n.stateNode.current.memoizedState.isDehydrated)return void(e.blockedOn=3===n.tag?n.stateNode.containerInfo:null)}e.blockedOn=null}function It(e){if(null!==e.blockedOn)return!1;for(var t=e.targetContainers;0<t.length;){var n=Yt(e.domEventName,e.eventSystemFlags,t[0],e.nativeEvent);if(null!==n)return null!==(t=ba(n))&&kt(t),e.blockedOn=n,!1;var r=new(n=e.nativeEvent).constructor(n.type,n);we=r,n.target.dispatchEvent(r),we=null,t.shift()}return!0}function Bt(e,t,n){It(e)&&n.delete(t)}function At(){Nt=!1,null!==Rt&&It(Rt)&&(Rt=null),null!==jt&&It(jt)&&(jt=null),null!==Pt&&It(Pt)&&(Pt=null),Tt.forEach(Bt),Ot.forEach(Bt)}function Ut(e,t){e.blockedOn===t&&(e.blockedOn=null,Nt||(Nt=!0,a.unstable_scheduleCallback(a.unstable_NormalPriority,At)))}function Ht(e){function t(t){return Ut(t,e)}if(0<_t.length){Ut(_t[0],e);for(var n=1;n<_t.length;n++){var r=_t[n];r.blockedOn===e&&(r.blockedOn=null)}}for(null!==Rt&&Ut(Rt,e),null!==jt&&Ut(jt,e),null!==Pt&&Ut(Pt,e),Tt.forEach(t),Ot.forEach(t),n=0;n<Lt.length;n++)(r=Lt[n]).blockedOn===e&&(r.blockedOn=null);for(;0<Lt.length&&null===(n=Lt[0]).blockedOn;)Mt(n),null===n.blockedOn&&Lt.shift()}var qt=w.ReactCurrentBatchConfig,Vt=!0;function Wt(e,t,n,r){var a=bt,o=qt.transition;qt.transition=null;try{bt=1,Qt(e,t,n,r)}finally{bt=a,qt.transition=o}}function $t(e,t,n,r){var a=bt,o=qt.transition;qt.transition=null;try{bt=4,Qt(e,t,n,r)}finally{bt=a,qt.transition=o}}function Qt(e,t,n,r){if(Vt){var a=Yt(e,t,n,r);if(null===a)Vr(e,t,r,Kt,n),Dt(e,r);else if(function(e,t,n,r,a){switch(t){case"focusin":return Rt=Ft(Rt,e,t,n,r,a),!0;case"dragenter":return jt=Ft(jt,e,t,n,r,a),!0;case"mouseover":return Pt=Ft(Pt,e,t,n,r,a),!0;case"pointerover":var o=a.pointerId;return Tt.set(o,Ft(Tt.get(o)||null,e,t,n,r,a)),!0;case"gotpointercapture":return o=a.pointerId,Ot.set(o,Ft(Ot.get(o)||null,e,t,n,r,a)),!0}return!1}(a,e,t,n,r))r.stopPropagation();else if(Dt(e,r),4&t&&-1<zt.indexOf(e)){for(;null!==a;){var o=ba(a);if(null!==o&&xt(o),null===(o=Yt(e,t,n,r))&&Vr(e,t,r,Kt,n),o===a)break;a=o}null!==a&&r.stopPropagation()}else Vr(e,t,r,null,n)}}var Kt=null;function Yt(e,t,n,r){if(Kt=null,null!==(e=ga(e=xe(r))))if(null===(t=He(e)))e=null;else if(13===(n=t.tag)){if(null!==(e=qe(t)))return e;e=null}else if(3===n){if(t.stateNode.current.memoizedState.isDehydrated)return 3===t.tag?t.stateNode.containerInfo:null;e=null}else t!==e&&(e=null);return Kt=e,null}function Gt(e){switch(e){case"cancel":case"click":case"close":case"contextmenu":case"copy":case"cut":case"auxclick":case"dblclick":case"dragend":case"dragstart":case"drop":case"focusin":case"focusout":case"input":case"invalid":case"keydown":case"keypress":case"keyup":case"mousedown":case"mouseup":case"paste":case"pause":case"play":case"pointercancel":case"pointerdown":case"pointerup":case"ratechange":case"reset":case"resize":case"seeked":case"submit":case"touchcancel":case"touchend":case"touchstart":case"volumechange":case"change":case"selectionchange":case"textInput":case"compositionstart":case"compositionend":case"compositionupdate":case"beforeblur":case"afterblur":case"beforeinput":case"blur":case"fullscreenchange":case"focus":case"hashchange":case"popstate":case"select":case"selectstart":return 1;case"drag":case"dragenter":case"dragexit":case"dragleave":case"dragover":case"mousemove":case"mouseout":case"mouseover":case"pointermove":case"pointerout":case"pointerover":case"scroll":case"toggle":case"touchmove":case"wheel":case"mouseenter":case"mouseleave":case"pointerenter":case"pointerleave":return 4;case"message":switch(Je()){case Ze:return 1;case et:return 4;case tt:case nt:return 16;case rt:return 536870912;default:return 16}default:return 16}}var Xt=null,Jt=null,Zt=null;function en(){if(Zt)return Zt;var e,t,n=Jt,r=n.length,a="value"in Xt?Xt.value:Xt.textContent,o=a.length;for(e=0;e<r&&n[e]===a[e];e++);var l=r-e;for(t=1;t<=l&&n[r-t]===a[o-t];t++);return Zt=a.slice(e,1<t?1-t:void 0)}function tn(e){var t=e.keyCode;return"charCode"in e?0===(e=e.charCode)&&13===t&&(e=13):e=t,10===e&&(e=13),32<=e||13===e?e:0}function nn(){return!0}function rn(){return!1}function an(e){function t(t,n,r,a,o){for(var l in this._reactName=t,this._targetInst=r,this.type=n,this.nativeEvent=a,this.target=o,this.currentTarget=null,e)e.hasOwnProperty(l)&&(t=e[l],this[l]=t?t(a):a[l]);return this.isDefaultPrevented=(null!=a.defaultPrevented?a.defaultPrevented:!1===a.returnValue)?nn:rn,this.isPropagationStopped=rn,this}return M(t.prototype,{preventDefault:function(){this.defaultPrevented=!0;var e=this.nativeEvent;e&&(e.preventDefault?e.preventDefault():"unknown"!==typeof e.returnValue&&(e.returnValue=!1),this.isDefaultPrevented=nn)},stopPropagation:function(){var e=this.nativeEvent;e&&(e.stopPropagation?e.stopPropagation():"unknown"!==typeof e.cancelBubble&&(e.cancelBubble=!0),this.isPropagationStopped=nn)},persist:function(){},isPersistent:nn}),t}var on,ln,sn,un={eventPhase:0,bubbles:0,cancelable:0,timeStamp:function(e){return e.timeStamp||Date.now()},defaultPrevented:0,isTrusted:0},cn=an(un),dn=M({},un,{view:0,detail:0}),fn=an(dn),pn=M({},dn,{screenX:0,screenY:0,clientX:0,clientY:0,pageX:0,pageY:0,ctrlKey:0,shiftKey:0,altKey:0,metaKey:0,getModifierState:Cn,button:0,buttons:0,relatedTarget:function(e){return void 0===e.relatedTarget?e.fromElement===e.srcElement?e.toElement:e.fromElement:e.relatedTarget},movementX:function(e){return"movementX"in e?e.movementX:(e!==sn&&(sn&&"mousemove"===e.type?(on=e.screenX-sn.screenX,ln=e.screenY-sn.screenY):ln=on=0,sn=e),on)},movementY:function(e){return"movementY"in e?e.movementY:ln}}),hn=an(pn),vn=an(M({},pn,{dataTransfer:0})),mn=an(M({},dn,{relatedTarget:0})),yn=an(M({},un,{animationName:0,elapsedTime:0,pseudoElement:0})),gn=M({},un,{clipboardData:function(e){return"clipboardData"in e?e.clipboardData:window.clipboardData}}),bn=an(gn),wn=an(M({},un,{data:0})),xn={Esc:"Escape",Spacebar:" ",Left:"ArrowLeft",Up:"ArrowUp",Right:"ArrowRight",Down:"ArrowDown",Del:"Delete",Win:"OS",Menu:"ContextMenu",Apps:"ContextMenu",Scroll:"ScrollLock",MozPrintableKey:"Unidentified"},kn={8:"Backspace",9:"Tab",12:"Clear",13:"Enter",16:"Shift",17:"Control",18:"Alt",19:"Pause",20:"CapsLock",27:"Escape",32:" ",33:"PageUp",34:"PageDown",35:"End",36:"Home",37:"ArrowLeft",38:"ArrowUp",39:"ArrowRight",40:"ArrowDown",45:"Insert",46:"Delete",112:"F1",113:"F2",114:"F3",115:"F4",116:"F5",117:"F6",118:"F7",119:"F8",120:"F9",121:"F10",122:"F11",123:"F12",144:"NumLock",145:"ScrollLock",224:"Meta"},Sn={Alt:"altKey",Control:"ctrlKey",Meta:"metaKey",Shift:"shiftKey"};function En(e){var t=this.nativeEvent;return t.getModifierState?t.getModifierState(e):!!(e=Sn[e])&&!!t[e]}function Cn(){return En}var Nn=M({},dn,{key:function(e){if(e.key){var t=xn[e.key]||e.key;if("Unidentified"!==t)return t}return"keypress"===e.type?13===(e=tn(e))?"Enter":String.fromCharCode(e):"keydown"===e.type||"keyup"===e.type?kn[e.keyCode]||"Unidentified":""},code:0,location:0,ctrlKey:0,shiftKey:0,altKey:0,metaKey:0,repeat:0,locale:0,getModifierState:Cn,charCode:function(e){return"keypress"===e.type?tn(e):0},keyCode:function(e){return"keydown"===e.type||"keyup"===e.type?e.keyCode:0},which:function(e){return"keypress"===e.type?tn(e):"keydown"===e.type||"keyup"===e.type?e.keyCode:0}}),_n=an(Nn),Rn=an(M({},pn,{pointerId:0,width:0,height:0,pressure:0,tangentialPressure:0,tiltX:0,tiltY:0,twist:0,pointerType:0,isPrimary:0})),jn=an(M({},dn,{touches:0,targetTouches:0,changedTouches:0,altKey:0,metaKey:0,ctrlKey:0,shiftKey:0,getModifierState:Cn})),Pn=an(M({},un,{propertyName:0,elapsedTime:0,pseudoElement:0})),Tn=M({},pn,{deltaX:function(e){return"deltaX"in e?e.deltaX:"wheelDeltaX"in e?-e.wheelDeltaX:0},deltaY:function(e){return"deltaY"in e?e.deltaY:"wheelDeltaY"in e?-e.wheelDeltaY:"wheelDelta"in e?-e.wheelDelta:0},deltaZ:0,deltaMode:0}),On=an(Tn),Ln=[9,13,27,32],zn=c&&"CompositionEvent"in window,Dn=null;c&&"documentMode"in document&&(Dn=document.documentMode);var Fn=c&&"TextEvent"in window&&!Dn,Mn=c&&(!zn||Dn&&8<Dn&&11>=Dn),In=String.fromCharCode(32),Bn=!1;function An(e,t){switch(e){case"keyup":return-1!==Ln.indexOf(t.keyCode);case"keydown":return 229!==t.keyCode;case"keypress":case"mousedown":case"focusout":return!0;default:return!1}}function Un(e){return"object"===typeof(e=e.detail)&&"data"in e?e.data:null}var Hn=!1;var qn={color:!0,date:!0,datetime:!0,"datetime-local":!0,email:!0,month:!0,number:!0,password:!0,range:!0,search:!0,tel:!0,text:!0,time:!0,url:!0,week:!0};function Vn(e){var t=e&&e.nodeName&&e.nodeName.toLowerCase();return"input"===t?!!qn[e.type]:"textarea"===t}function Wn(e,t,n,r){Ne(r),0<(t=$r(t,"onChange")).length&&(n=new cn("onChange","change",null,n,r),e.push({event:n,listeners:t}))}var $n=null,Qn=null;function Kn(e){Ir(e,0)}function Yn(e){if(Q(wa(e)))return e}function Gn(e,t){if("change"===e)return t}var Xn=!1;if(c){var Jn;if(c){var Zn="oninput"in document;if(!Zn){var er=document.createElement("div");er.setAttribute("oninput","return;"),Zn="function"===typeof er.oninput}Jn=Zn}else Jn=!1;Xn=Jn&&(!document.documentMode||9<document.documentMode)}function tr(){$n&&($n.detachEvent("onpropertychange",nr),Qn=$n=null)}function nr(e){if("value"===e.propertyName&&Yn(Qn)){var t=[];Wn(t,Qn,e,xe(e)),Te(Kn,t)}}function rr(e,t,n){"focusin"===e?(tr(),Qn=n,($n=t).attachEvent("onpropertychange",nr)):"focusout"===e&&tr()}function ar(e){if("selectionchange"===e||"keyup"===e||"keydown"===e)return Yn(Qn)}function or(e,t){if("click"===e)return Yn(t)}function lr(e,t){if("input"===e||"change"===e)return Yn(t)}var ir="function"===typeof Object.is?Object.is:function(e,t){return e===t&&(0!==e||1/e===1/t)||e!==e&&t!==t};function sr(e,t){if(ir(e,t))return!0;if("object"!==typeof e||null===e||"object"!==typeof t||null===t)return!1;var n=Object.keys(e),r=Object.keys(t);if(n.length!==r.length)return!1;for(r=0;r<n.length;r++){var a=n[r];if(!d.call(t,a)||!ir(e[a],t[a]))return!1}return!0}function ur(e){for(;e&&e.firstChild;)e=e.firstChild;return e}function cr(e,t){var n,r=ur(e);for(e=0;r;){if(3===r.nodeType){if(n=e+r.textContent.length,e<=t&&n>=t)return{node:r,offset:t-e};e=n}e:{for(;r;){if(r.nextSibling){r=r.nextSibling;break e}r=r.parentNode}r=void 0}r=ur(r)}}function dr(e,t){return!(!e||!t)&&(e===t||(!e||3!==e.nodeType)&&(t&&3===t.nodeType?dr(e,t.parentNode):"contains"in e?e.contains(t):!!e.compareDocumentPosition&&!!(16&e.compareDocumentPosition(t))))}function fr(){for(var e=window,t=K();t instanceof e.HTMLIFrameElement;){try{var n="string"===typeof t.contentWindow.location.href}catch(r){n=!1}if(!n)break;t=K((e=t.contentWindow).document)}return t}function pr(e){var t=e&&e.nodeName&&e.nodeName.toLowerCase();return t&&("input"===t&&("text"===e.type||"search"===e.type||"tel"===e.type||"url"===e.type||"password"===e.type)||"textarea"===t||"true"===e.contentEditable)}function hr(e){var t=fr(),n=e.focusedElem,r=e.selectionRange;if(t!==n&&n&&n.ownerDocument&&dr(n.ownerDocument.documentElement,n)){if(null!==r&&pr(n))if(t=r.start,void 0===(e=r.end)&&(e=t),"selectionStart"in n)n.selectionStart=t,n.selectionEnd=Math.min(e,n.value.length);else if((e=(t=n.ownerDocument||document)&&t.defaultView||window).getSelection){e=e.getSelection();var a=n.textContent.length,o=Math.min(r.start,a);r=void 0===r.end?o:Math.min(r.end,a),!e.extend&&o>r&&(a=r,r=o,o=a),a=cr(n,o);var l=cr(n,r);a&&l&&(1!==e.rangeCount||e.anchorNode!==a.node||e.anchorOffset!==a.offset||e.focusNode!==l.node||e.focusOffset!==l.offset)&&((t=t.createRange()).setStart(a.node,a.offset),e.removeAllRanges(),o>r?(e.addRange(t),e.extend(l.node,l.offset)):(t.setEnd(l.node,l.offset),e.addRange(t)))}for(t=[],e=n;e=e.parentNode;)1===e.nodeType&&t.push({element:e,left:e.scrollLeft,top:e.scrollTop});for("function"===typeof n.focus&&n.focus(),n=0;n<t.length;n++)(e=t[n]).element.scrollLeft=e.left,e.element.scrollTop=e.top}}var vr=c&&"documentMode"in document&&11>=document.documentMode,mr=null,yr=null,gr=null,br=!1;function wr(e,t,n){var r=n.window===n?n.document:9===n.nodeType?n:n.ownerDocument;br||null==mr||mr!==K(r)||("selectionStart"in(r=mr)&&pr(r)?r={start:r.selectionStart,end:r.selectionEnd}:r={anchorNode:(r=(r.ownerDocument&&r.ownerDocument.defaultView||window).getSelection()).anchorNode,anchorOffset:r.anchorOffset,focusNode:r.focusNode,focusOffset:r.focusOffset},gr&&sr(gr,r)||(gr=r,0<(r=$r(yr,"onSelect")).length&&(t=new cn("onSelect","select",null,t,n),e.push({event:t,listeners:r}),t.target=mr)))}function xr(e,t){var n={};return n[e.toLowerCase()]=t.toLowerCase(),n["Webkit"+e]="webkit"+t,n["Moz"+e]="moz"+t,n}var kr={animationend:xr("Animation","AnimationEnd"),animationiteration:xr("Animation","AnimationIteration"),animationstart:xr("Animation","AnimationStart"),transitionend:xr("Transition","TransitionEnd")},Sr={},Er={};function Cr(e){if(Sr[e])return Sr[e];if(!kr[e])return e;var t,n=kr[e];for(t in n)if(n.hasOwnProperty(t)&&t in Er)return Sr[e]=n[t];return e}c&&(Er=document.createElement("div").style,"AnimationEvent"in window||(delete kr.animationend.animation,delete kr.animationiteration.animation,delete kr.animationstart.animation),"TransitionEvent"in window||delete kr.transitionend.transition);var Nr=Cr("animationend"),_r=Cr("animationiteration"),Rr=Cr("animationstart"),jr=Cr("transitionend"),Pr=new Map,Tr="abort auxClick cancel canPlay canPlayThrough click close contextMenu copy cut drag dragEnd dragEnter dragExit dragLeave dragOver dragStart drop durationChange emptied encrypted ended error gotPointerCapture input invalid keyDown keyPress keyUp load loadedData loadedMetadata loadStart lostPointerCapture mouseDown mouseMove mouseOut mouseOver mouseUp paste pause play playing pointerCancel pointerDown pointerMove pointerOut pointerOver pointerUp progress rateChange reset resize seeked seeking stalled submit suspend timeUpdate touchCancel touchEnd touchStart volumeChange scroll toggle touchMove waiting wheel".split(" ");function Or(e,t){Pr.set(e,t),s(t,[e])}for(var Lr=0;Lr<Tr.length;Lr++){var zr=Tr[Lr];Or(zr.toLowerCase(),"on"+(zr[0].toUpperCase()+zr.slice(1)))}Or(Nr,"onAnimationEnd"),Or(_r,"onAnimationIteration"),Or(Rr,"onAnimationStart"),Or("dblclick","onDoubleClick"),Or("focusin","onFocus"),Or("focusout","onBlur"),Or(jr,"onTransitionEnd"),u("onMouseEnter",["mouseout","mouseover"]),u("onMouseLeave",["mouseout","mouseover"]),u("onPointerEnter",["pointerout","pointerover"]),u("onPointerLeave",["pointerout","pointerover"]),s("onChange","change click focusin focusout input keydown keyup selectionchange".split(" ")),s("onSelect","focusout contextmenu dragend focusin keydown keyup mousedown mouseup selectionchange".split(" ")),s("onBeforeInput",["compositionend","keypress","textInput","paste"]),s("onCompositionEnd","compositionend focusout keydown keypress keyup mousedown".split(" ")),s("onCompositionStart","compositionstart focusout keydown keypress keyup mousedown".split(" ")),s("onCompositionUpdate","compositionupdate focusout keydown keypress keyup mousedown".split(" "));var Dr="abort canplay canplaythrough durationchange emptied encrypted ended error loadeddata loadedmetadata loadstart pause play playing progress ratechange resize seeked seeking stalled suspend timeupdate volumechange waiting".split(" "),Fr=new Set("cancel close invalid load scroll toggle".split(" ").concat(Dr));function Mr(e,t,n){var r=e.type||"unknown-event";e.currentTarget=n,function(e,t,n,r,a,l,i,s,u){if(Ue.apply(this,arguments),Fe){if(!Fe)throw Error(o(198));var c=Me;Fe=!1,Me=null,Ie||(Ie=!0,Be=c)}}(r,t,void 0,e),e.currentTarget=null}function Ir(e,t){t=0!==(4&t);for(var n=0;n<e.length;n++){var r=e[n],a=r.event;r=r.listeners;e:{var o=void 0;if(t)for(var l=r.length-1;0<=l;l--){var i=r[l],s=i.instance,u=i.currentTarget;if(i=i.listener,s!==o&&a.isPropagationStopped())break e;Mr(a,i,u),o=s}else for(l=0;l<r.length;l++){if(s=(i=r[l]).instance,u=i.currentTarget,i=i.listener,s!==o&&a.isPropagationStopped())break e;Mr(a,i,u),o=s}}}if(Ie)throw e=Be,Ie=!1,Be=null,e}function Br(e,t){var n=t[va];void 0===n&&(n=t[va]=new Set);var r=e+"__bubble";n.has(r)||(qr(t,e,2,!1),n.add(r))}function Ar(e,t,n){var r=0;t&&(r|=4),qr(n,e,r,t)}var Ur="_reactListening"+Math.random().toString(36).slice(2);function Hr(e){if(!e[Ur]){e[Ur]=!0,l.forEach((function(t){"selectionchange"!==t&&(Fr.has(t)||Ar(t,!1,e),Ar(t,!0,e))}));var t=9===e.nodeType?e:e.ownerDocument;null===t||t[Ur]||(t[Ur]=!0,Ar("selectionchange",!1,t))}}function qr(e,t,n,r){switch(Gt(t)){case 1:var a=Wt;break;case 4:a=$t;break;default:a=Qt}n=a.bind(null,t,n,e),a=void 0,!Le||"touchstart"!==t&&"touchmove"!==t&&"wheel"!==t||(a=!0),r?void 0!==a?e.addEventListener(t,n,{capture:!0,passive:a}):e.addEventListener(t,n,!0):void 0!==a?e.addEventListener(t,n,{passive:a}):e.addEventListener(t,n,!1)}function Vr(e,t,n,r,a){var o=r;if(0===(1&t)&&0===(2&t)&&null!==r)e:for(;;){if(null===r)return;var l=r.tag;if(3===l||4===l){var i=r.stateNode.containerInfo;if(i===a||8===i.nodeType&&i.parentNode===a)break;if(4===l)for(l=r.return;null!==l;){var s=l.tag;if((3===s||4===s)&&((s=l.stateNode.containerInfo)===a||8===s.nodeType&&s.parentNode===a))return;l=l.return}for(;null!==i;){if(null===(l=ga(i)))return;if(5===(s=l.tag)||6===s){r=o=l;continue e}i=i.parentNode}}r=r.return}Te((function(){var r=o,a=xe(n),l=[];e:{var i=Pr.get(e);if(void 0!==i){var s=cn,u=e;switch(e){case"keypress":if(0===tn(n))break e;case"keydown":case"keyup":s=_n;break;case"focusin":u="focus",s=mn;break;case"focusout":u="blur",s=mn;break;case"beforeblur":case"afterblur":s=mn;break;case"click":if(2===n.button)break e;case"auxclick":case"dblclick":case"mousedown":case"mousemove":case"mouseup":case"mouseout":case"mouseover":case"contextmenu":s=hn;break;case"drag":case"dragend":case"dragenter":case"dragexit":case"dragleave":case"dragover":case"dragstart":case"drop":s=vn;break;case"touchcancel":case"touchend":case"touchmove":case"touchstart":s=jn;break;case Nr:case _r:case Rr:s=yn;break;case jr:s=Pn;break;case"scroll":s=fn;break;case"wheel":s=On;break;case"copy":case"cut":case"paste":s=bn;break;case"gotpointercapture":case"lostpointercapture":case"pointercancel":case"pointerdown":case"pointermove":case"pointerout":case"pointerover":case"pointerup":s=Rn}var c=0!==(4&t),d=!c&&"scroll"===e,f=c?null!==i?i+"Capture":null:i;c=[];for(var p,h=r;null!==h;){var v=(p=h).stateNode;if(5===p.tag&&null!==v&&(p=v,null!==f&&(null!=(v=Oe(h,f))&&c.push(Wr(h,v,p)))),d)break;h=h.return}0<c.length&&(i=new s(i,u,null,n,a),l.push({event:i,listeners:c}))}}if(0===(7&t)){if(s="mouseout"===e||"pointerout"===e,(!(i="mouseover"===e||"pointerover"===e)||n===we||!(u=n.relatedTarget||n.fromElement)||!ga(u)&&!u[ha])&&(s||i)&&(i=a.window===a?a:(i=a.ownerDocument)?i.defaultView||i.parentWindow:window,s?(s=r,null!==(u=(u=n.relatedTarget||n.toElement)?ga(u):null)&&(u!==(d=He(u))||5!==u.tag&&6!==u.tag)&&(u=null)):(s=null,u=r),s!==u)){if(c=hn,v="onMouseLeave",f="onMouseEnter",h="mouse","pointerout"!==e&&"pointerover"!==e||(c=Rn,v="onPointerLeave",f="onPointerEnter",h="pointer"),d=null==s?i:wa(s),p=null==u?i:wa(u),(i=new c(v,h+"leave",s,n,a)).target=d,i.relatedTarget=p,v=null,ga(a)===r&&((c=new c(f,h+"enter",u,n,a)).target=p,c.relatedTarget=d,v=c),d=v,s&&u)e:{for(f=u,h=0,p=c=s;p;p=Qr(p))h++;for(p=0,v=f;v;v=Qr(v))p++;for(;0<h-p;)c=Qr(c),h--;for(;0<p-h;)f=Qr(f),p--;for(;h--;){if(c===f||null!==f&&c===f.alternate)break e;c=Qr(c),f=Qr(f)}c=null}else c=null;null!==s&&Kr(l,i,s,c,!1),null!==u&&null!==d&&Kr(l,d,u,c,!0)}if("select"===(s=(i=r?wa(r):window).nodeName&&i.nodeName.toLowerCase())||"input"===s&&"file"===i.type)var m=Gn;else if(Vn(i))if(Xn)m=lr;else{m=ar;var y=rr}else(s=i.nodeName)&&"input"===s.toLowerCase()&&("checkbox"===i.type||"radio"===i.type)&&(m=or);switch(m&&(m=m(e,r))?Wn(l,m,n,a):(y&&y(e,i,r),"focusout"===e&&(y=i._wrapperState)&&y.controlled&&"number"===i.type&&ee(i,"number",i.value)),y=r?wa(r):window,e){case"focusin":(Vn(y)||"true"===y.contentEditable)&&(mr=y,yr=r,gr=null);break;case"focusout":gr=yr=mr=null;break;case"mousedown":br=!0;break;case"contextmenu":case"mouseup":case"dragend":br=!1,wr(l,n,a);break;case"selectionchange":if(vr)break;case"keydown":case"keyup":wr(l,n,a)}var g;if(zn)e:{switch(e){case"compositionstart":var b="onCompositionStart";break e;case"compositionend":b="onCompositionEnd";break e;case"compositionupdate":b="onCompositionUpdate";break e}b=void 0}else Hn?An(e,n)&&(b="onCompositionEnd"):"keydown"===e&&229===n.keyCode&&(b="onCompositionStart");b&&(Mn&&"ko"!==n.locale&&(Hn||"onCompositionStart"!==b?"onCompositionEnd"===b&&Hn&&(g=en()):(Jt="value"in(Xt=a)?Xt.value:Xt.textContent,Hn=!0)),0<(y=$r(r,b)).length&&(b=new wn(b,e,null,n,a),l.push({event:b,listeners:y}),g?b.data=g:null!==(g=Un(n))&&(b.data=g))),(g=Fn?function(e,t){switch(e){case"compositionend":return Un(t);case"keypress":return 32!==t.which?null:(Bn=!0,In);case"textInput":return(e=t.data)===In&&Bn?null:e;default:return null}}(e,n):function(e,t){if(Hn)return"compositionend"===e||!zn&&An(e,t)?(e=en(),Zt=Jt=Xt=null,Hn=!1,e):null;switch(e){case"paste":default:return null;case"keypress":if(!(t.ctrlKey||t.altKey||t.metaKey)||t.ctrlKey&&t.altKey){if(t.char&&1<t.char.length)return t.char;if(t.which)return String.fromCharCode(t.which)}return null;case"compositionend":return Mn&&"ko"!==t.locale?null:t.data}}(e,n))&&(0<(r=$r(r,"onBeforeInput")).length&&(a=new wn("onBeforeInput","beforeinput",null,n,a),l.push({event:a,listeners:r}),a.data=g))}Ir(l,t)}))}function Wr(e,t,n){return{instance:e,listener:t,currentTarget:n}}function $r(e,t){for(var n=t+"Capture",r=[];null!==e;){var a=e,o=a.stateNode;5===a.tag&&null!==o&&(a=o,null!=(o=Oe(e,n))&&r.unshift(Wr(e,o,a)),null!=(o=Oe(e,t))&&r.push(Wr(e,o,a))),e=e.return}return r}function Qr(e){if(null===e)return null;do{e=e.return}while(e&&5!==e.tag);return e||null}function Kr(e,t,n,r,a){for(var o=t._reactName,l=[];null!==n&&n!==r;){var i=n,s=i.alternate,u=i.stateNode;if(null!==s&&s===r)break;5===i.tag&&null!==u&&(i=u,a?null!=(s=Oe(n,o))&&l.unshift(Wr(n,s,i)):a||null!=(s=Oe(n,o))&&l.push(Wr(n,s,i))),n=n.return}0!==l.length&&e.push({event:t,listeners:l})}var Yr=/\r\n?/g,Gr=/\u0000|\uFFFD/g;function Xr(e){return("string"===typeof e?e:""+e).replace(Yr,"\n").replace(Gr,"")}function Jr(e,t,n){if(t=Xr(t),Xr(e)!==t&&n)throw Error(o(425))}function Zr(){}var ea=null,ta=null;function na(e,t){return"textarea"===e||"noscript"===e||"string"===typeof t.children||"number"===typeof t.children||"object"===typeof t.dangerouslySetInnerHTML&&null!==t.dangerouslySetInnerHTML&&null!=t.dangerouslySetInnerHTML.__html}var ra="function"===typeof setTimeout?setTimeout:void 0,aa="function"===typeof clearTimeout?clearTimeout:void 0,oa="function"===typeof Promise?Promise:void 0,la="function"===typeof queueMicrotask?queueMicrotask:"undefined"!==typeof oa?function(e){return oa.resolve(null).then(e).catch(ia)}:ra;function ia(e){setTimeout((function(){throw e}))}function sa(e,t){var n=t,r=0;do{var a=n.nextSibling;if(e.removeChild(n),a&&8===a.nodeType)if("/$"===(n=a.data)){if(0===r)return e.removeChild(a),void Ht(t);r--}else"$"!==n&&"$?"!==n&&"$!"!==n||r++;n=a}while(n);Ht(t)}function ua(e){for(;null!=e;e=e.nextSibling){var t=e.nodeType;if(1===t||3===t)break;if(8===t){if("$"===(t=e.data)||"$!"===t||"$?"===t)break;if("/$"===t)return null}}return e}function ca(e){e=e.previousSibling;for(var t=0;e;){if(8===e.nodeType){var n=e.data;if("$"===n||"$!"===n||"$?"===n){if(0===t)return e;t--}else"/$"===n&&t++}e=e.previousSibling}return null}var da=Math.random().toString(36).slice(2),fa="__reactFiber$"+da,pa="__reactProps$"+da,ha="__reactContainer$"+da,va="__reactEvents$"+da,ma="__reactListeners$"+da,ya="__reactHandles$"+da;function ga(e){var t=e[fa];if(t)return t;for(var n=e.parentNode;n;){if(t=n[ha]||n[fa]){if(n=t.alternate,null!==t.child||null!==n&&null!==n.child)for(e=ca(e);null!==e;){if(n=e[fa])return n;e=ca(e)}return t}n=(e=n).parentNode}return null}function ba(e){return!(e=e[fa]||e[ha])||5!==e.tag&&6!==e.tag&&13!==e.tag&&3!==e.tag?null:e}function wa(e){if(5===e.tag||6===e.tag)return e.stateNode;throw Error(o(33))}function xa(e){return e[pa]||null}var ka=[],Sa=-1;function Ea(e){return{current:e}}function Ca(e){0>Sa||(e.current=ka[Sa],ka[Sa]=null,Sa--)}function Na(e,t){Sa++,ka[Sa]=e.current,e.current=t}var _a={},Ra=Ea(_a),ja=Ea(!1),Pa=_a;function Ta(e,t){var n=e.type.contextTypes;if(!n)return _a;var r=e.stateNode;if(r&&r.__reactInternalMemoizedUnmaskedChildContext===t)return r.__reactInternalMemoizedMaskedChildContext;var a,o={};for(a in n)o[a]=t[a];return r&&((e=e.stateNode).__reactInternalMemoizedUnmaskedChildContext=t,e.__reactInternalMemoizedMaskedChildContext=o),o}function Oa(e){return null!==(e=e.childContextTypes)&&void 0!==e}function La(){Ca(ja),Ca(Ra)}function za(e,t,n){if(Ra.current!==_a)throw Error(o(168));Na(Ra,t),Na(ja,n)}function Da(e,t,n){var r=e.stateNode;if(t=t.childContextTypes,"function"!==typeof r.getChildContext)return n;for(var a in r=r.getChildContext())if(!(a in t))throw Error(o(108,q(e)||"Unknown",a));return M({},n,r)}function Fa(e){return e=(e=e.stateNode)&&e.__reactInternalMemoizedMergedChildContext||_a,Pa=Ra.current,Na(Ra,e),Na(ja,ja.current),!0}function Ma(e,t,n){var r=e.stateNode;if(!r)throw Error(o(169));n?(e=Da(e,t,Pa),r.__reactInternalMemoizedMergedChildContext=e,Ca(ja),Ca(Ra),Na(Ra,e)):Ca(ja),Na(ja,n)}var Ia=null,Ba=!1,Aa=!1;function Ua(e){null===Ia?Ia=[e]:Ia.push(e)}function Ha(){if(!Aa&&null!==Ia){Aa=!0;var e=0,t=bt;try{var n=Ia;for(bt=1;e<n.length;e++){var r=n[e];do{r=r(!0)}while(null!==r)}Ia=null,Ba=!1}catch(a){throw null!==Ia&&(Ia=Ia.slice(e+1)),Qe(Ze,Ha),a}finally{bt=t,Aa=!1}}return null}var qa=[],Va=0,Wa=null,$a=0,Qa=[],Ka=0,Ya=null,Ga=1,Xa="";function Ja(e,t){qa[Va++]=$a,qa[Va++]=Wa,Wa=e,$a=t}function Za(e,t,n){Qa[Ka++]=Ga,Qa[Ka++]=Xa,Qa[Ka++]=Ya,Ya=e;var r=Ga;e=Xa;var a=32-lt(r)-1;r&=~(1<<a),n+=1;var o=32-lt(t)+a;if(30<o){var l=a-a%5;o=(r&(1<<l)-1).toString(32),r>>=l,a-=l,Ga=1<<32-lt(t)+a|n<<a|r,Xa=o+e}else Ga=1<<o|n<<a|r,Xa=e}function eo(e){null!==e.return&&(Ja(e,1),Za(e,1,0))}function to(e){for(;e===Wa;)Wa=qa[--Va],qa[Va]=null,$a=qa[--Va],qa[Va]=null;for(;e===Ya;)Ya=Qa[--Ka],Qa[Ka]=null,Xa=Qa[--Ka],Qa[Ka]=null,Ga=Qa[--Ka],Qa[Ka]=null}var no=null,ro=null,ao=!1,oo=null;function lo(e,t){var n=Tu(5,null,null,0);n.elementType="DELETED",n.stateNode=t,n.return=e,null===(t=e.deletions)?(e.deletions=[n],e.flags|=16):t.push(n)}function io(e,t){switch(e.tag){case 5:var n=e.type;return null!==(t=1!==t.nodeType||n.toLowerCase()!==t.nodeName.toLowerCase()?null:t)&&(e.stateNode=t,no=e,ro=ua(t.firstChild),!0);case 6:return null!==(t=""===e.pendingProps||3!==t.nodeType?null:t)&&(e.stateNode=t,no=e,ro=null,!0);case 13:return null!==(t=8!==t.nodeType?null:t)&&(n=null!==Ya?{id:Ga,overflow:Xa}:null,e.memoizedState={dehydrated:t,treeContext:n,retryLane:1073741824},(n=Tu(18,null,null,0)).stateNode=t,n.return=e,e.child=n,no=e,ro=null,!0);default:return!1}}function so(e){return 0!==(1&e.mode)&&0===(128&e.flags)}function uo(e){if(ao){var t=ro;if(t){var n=t;if(!io(e,t)){if(so(e))throw Error(o(418));t=ua(n.nextSibling);var r=no;t&&io(e,t)?lo(r,n):(e.flags=-4097&e.flags|2,ao=!1,no=e)}}else{if(so(e))throw Error(o(418));e.flags=-4097&e.flags|2,ao=!1,no=e}}}function co(e){for(e=e.return;null!==e&&5!==e.tag&&3!==e.tag&&13!==e.tag;)e=e.return;no=e}function fo(e){if(e!==no)return!1;if(!ao)return co(e),ao=!0,!1;var t;if((t=3!==e.tag)&&!(t=5!==e.tag)&&(t="head"!==(t=e.type)&&"body"!==t&&!na(e.type,e.memoizedProps)),t&&(t=ro)){if(so(e))throw po(),Error(o(418));for(;t;)lo(e,t),t=ua(t.nextSibling)}if(co(e),13===e.tag){if(!(e=null!==(e=e.memoizedState)?e.dehydrated:null))throw Error(o(317));e:{for(e=e.nextSibling,t=0;e;){if(8===e.nodeType){var n=e.data;if("/$"===n){if(0===t){ro=ua(e.nextSibling);break e}t--}else"$"!==n&&"$!"!==n&&"$?"!==n||t++}e=e.nextSibling}ro=null}}else ro=no?ua(e.stateNode.nextSibling):null;return!0}function po(){for(var e=ro;e;)e=ua(e.nextSibling)}function ho(){ro=no=null,ao=!1}function vo(e){null===oo?oo=[e]:oo.push(e)}var mo=w.ReactCurrentBatchConfig;function yo(e,t){if(e&&e.defaultProps){for(var n in t=M({},t),e=e.defaultProps)void 0===t[n]&&(t[n]=e[n]);return t}return t}var go=Ea(null),bo=null,wo=null,xo=null;function ko(){xo=wo=bo=null}function So(e){var t=go.current;Ca(go),e._currentValue=t}function Eo(e,t,n){for(;null!==e;){var r=e.alternate;if((e.childLanes&t)!==t?(e.childLanes|=t,null!==r&&(r.childLanes|=t)):null!==r&&(r.childLanes&t)!==t&&(r.childLanes|=t),e===n)break;e=e.return}}function Co(e,t){bo=e,xo=wo=null,null!==(e=e.dependencies)&&null!==e.firstContext&&(0!==(e.lanes&t)&&(wi=!0),e.firstContext=null)}function No(e){var t=e._currentValue;if(xo!==e)if(e={context:e,memoizedValue:t,next:null},null===wo){if(null===bo)throw Error(o(308));wo=e,bo.dependencies={lanes:0,firstContext:e}}else wo=wo.next=e;return t}var _o=null;function Ro(e){null===_o?_o=[e]:_o.push(e)}function jo(e,t,n,r){var a=t.interleaved;return null===a?(n.next=n,Ro(t)):(n.next=a.next,a.next=n),t.interleaved=n,Po(e,r)}function Po(e,t){e.lanes|=t;var n=e.alternate;for(null!==n&&(n.lanes|=t),n=e,e=e.return;null!==e;)e.childLanes|=t,null!==(n=e.alternate)&&(n.childLanes|=t),n=e,e=e.return;return 3===n.tag?n.stateNode:null}var To=!1;function Oo(e){e.updateQueue={baseState:e.memoizedState,firstBaseUpdate:null,lastBaseUpdate:null,shared:{pending:null,interleaved:null,lanes:0},effects:null}}function Lo(e,t){e=e.updateQueue,t.updateQueue===e&&(t.updateQueue={baseState:e.baseState,firstBaseUpdate:e.firstBaseUpdate,lastBaseUpdate:e.lastBaseUpdate,shared:e.shared,effects:e.effects})}function zo(e,t){return{eventTime:e,lane:t,tag:0,payload:null,callback:null,next:null}}function Do(e,t,n){var r=e.updateQueue;if(null===r)return null;if(r=r.shared,0!==(2&Rs)){var a=r.pending;return null===a?t.next=t:(t.next=a.next,a.next=t),r.pending=t,Po(e,n)}return null===(a=r.interleaved)?(t.next=t,Ro(r)):(t.next=a.next,a.next=t),r.interleaved=t,Po(e,n)}function Fo(e,t,n){if(null!==(t=t.updateQueue)&&(t=t.shared,0!==(4194240&n))){var r=t.lanes;n|=r&=e.pendingLanes,t.lanes=n,gt(e,n)}}function Mo(e,t){var n=e.updateQueue,r=e.alternate;if(null!==r&&n===(r=r.updateQueue)){var a=null,o=null;if(null!==(n=n.firstBaseUpdate)){do{var l={eventTime:n.eventTime,lane:n.lane,tag:n.tag,payload:n.payload,callback:n.callback,next:null};null===o?a=o=l:o=o.next=l,n=n.next}while(null!==n);null===o?a=o=t:o=o.next=t}else a=o=t;return n={baseState:r.baseState,firstBaseUpdate:a,lastBaseUpdate:o,shared:r.shared,effects:r.effects},void(e.updateQueue=n)}null===(e=n.lastBaseUpdate)?n.firstBaseUpdate=t:e.next=t,n.lastBaseUpdate=t}function Io(e,t,n,r){var a=e.updateQueue;To=!1;var o=a.firstBaseUpdate,l=a.lastBaseUpdate,i=a.shared.pending;if(null!==i){a.shared.pending=null;var s=i,u=s.next;s.next=null,null===l?o=u:l.next=u,l=s;var c=e.alternate;null!==c&&((i=(c=c.updateQueue).lastBaseUpdate)!==l&&(null===i?c.firstBaseUpdate=u:i.next=u,c.lastBaseUpdate=s))}if(null!==o){var d=a.baseState;for(l=0,c=u=s=null,i=o;;){var f=i.lane,p=i.eventTime;if((r&f)===f){null!==c&&(c=c.next={eventTime:p,lane:0,tag:i.tag,payload:i.payload,callback:i.callback,next:null});e:{var h=e,v=i;switch(f=t,p=n,v.tag){case 1:if("function"===typeof(h=v.payload)){d=h.call(p,d,f);break e}d=h;break e;case 3:h.flags=-65537&h.flags|128;case 0:if(null===(f="function"===typeof(h=v.payload)?h.call(p,d,f):h)||void 0===f)break e;d=M({},d,f);break e;case 2:To=!0}}null!==i.callback&&0!==i.lane&&(e.flags|=64,null===(f=a.effects)?a.effects=[i]:f.push(i))}else p={eventTime:p,lane:f,tag:i.tag,payload:i.payload,callback:i.callback,next:null},null===c?(u=c=p,s=d):c=c.next=p,l|=f;if(null===(i=i.next)){if(null===(i=a.shared.pending))break;i=(f=i).next,f.next=null,a.lastBaseUpdate=f,a.shared.pending=null}}if(null===c&&(s=d),a.baseState=s,a.firstBaseUpdate=u,a.lastBaseUpdate=c,null!==(t=a.shared.interleaved)){a=t;do{l|=a.lane,a=a.next}while(a!==t)}else null===o&&(a.shared.lanes=0);Fs|=l,e.lanes=l,e.memoizedState=d}}function Bo(e,t,n){if(e=t.effects,t.effects=null,null!==e)for(t=0;t<e.length;t++){var r=e[t],a=r.callback;if(null!==a){if(r.callback=null,r=n,"function"!==typeof a)throw Error(o(191,a));a.call(r)}}}var Ao=(new r.Component).refs;function Uo(e,t,n,r){n=null===(n=n(r,t=e.memoizedState))||void 0===n?t:M({},t,n),e.memoizedState=n,0===e.lanes&&(e.updateQueue.baseState=n)}var Ho={isMounted:function(e){return!!(e=e._reactInternals)&&He(e)===e},enqueueSetState:function(e,t,n){e=e._reactInternals;var r=eu(),a=tu(e),o=zo(r,a);o.payload=t,void 0!==n&&null!==n&&(o.callback=n),null!==(t=Do(e,o,a))&&(nu(t,e,a,r),Fo(t,e,a))},enqueueReplaceState:function(e,t,n){e=e._reactInternals;var r=eu(),a=tu(e),o=zo(r,a);o.tag=1,o.payload=t,void 0!==n&&null!==n&&(o.callback=n),null!==(t=Do(e,o,a))&&(nu(t,e,a,r),Fo(t,e,a))},enqueueForceUpdate:function(e,t){e=e._reactInternals;var n=eu(),r=tu(e),a=zo(n,r);a.tag=2,void 0!==t&&null!==t&&(a.callback=t),null!==(t=Do(e,a,r))&&(nu(t,e,r,n),Fo(t,e,r))}};function qo(e,t,n,r,a,o,l){return"function"===typeof(e=e.stateNode).shouldComponentUpdate?e.shouldComponentUpdate(r,o,l):!t.prototype||!t.prototype.isPureReactComponent||(!sr(n,r)||!sr(a,o))}function Vo(e,t,n){var r=!1,a=_a,o=t.contextType;return"object"===typeof o&&null!==o?o=No(o):(a=Oa(t)?Pa:Ra.current,o=(r=null!==(r=t.contextTypes)&&void 0!==r)?Ta(e,a):_a),t=new t(n,o),e.memoizedState=null!==t.state&&void 0!==t.state?t.state:null,t.updater=Ho,e.stateNode=t,t._reactInternals=e,r&&((e=e.stateNode).__reactInternalMemoizedUnmaskedChildContext=a,e.__reactInternalMemoizedMaskedChildContext=o),t}function Wo(e,t,n,r){e=t.state,"function"===typeof t.componentWillReceiveProps&&t.componentWillReceiveProps(n,r),"function"===typeof t.UNSAFE_componentWillReceiveProps&&t.UNSAFE_componentWillReceiveProps(n,r),t.state!==e&&Ho.enqueueReplaceState(t,t.state,null)}function $o(e,t,n,r){var a=e.stateNode;a.props=n,a.state=e.memoizedState,a.refs=Ao,Oo(e);var o=t.contextType;"object"===typeof o&&null!==o?a.context=No(o):(o=Oa(t)?Pa:Ra.current,a.context=Ta(e,o)),a.state=e.memoizedState,"function"===typeof(o=t.getDerivedStateFromProps)&&(Uo(e,t,o,n),a.state=e.memoizedState),"function"===typeof t.getDerivedStateFromProps||"function"===typeof a.getSnapshotBeforeUpdate||"function"!==typeof a.UNSAFE_componentWillMount&&"function"!==typeof a.componentWillMount||(t=a.state,"function"===typeof a.componentWillMount&&a.componentWillMount(),"function"===typeof a.UNSAFE_componentWillMount&&a.UNSAFE_componentWillMount(),t!==a.state&&Ho.enqueueReplaceState(a,a.state,null),Io(e,n,a,r),a.state=e.memoizedState),"function"===typeof a.componentDidMount&&(e.flags|=4194308)}function Qo(e,t,n){if(null!==(e=n.ref)&&"function"!==typeof e&&"object"!==typeof e){if(n._owner){if(n=n._owner){if(1!==n.tag)throw Error(o(309));var r=n.stateNode}if(!r)throw Error(o(147,e));var a=r,l=""+e;return null!==t&&null!==t.ref&&"function"===typeof t.ref&&t.ref._stringRef===l?t.ref:(t=function(e){var t=a.refs;t===Ao&&(t=a.refs={}),null===e?delete t[l]:t[l]=e},t._stringRef=l,t)}if("string"!==typeof e)throw Error(o(284));if(!n._owner)throw Error(o(290,e))}return e}function Ko(e,t){throw e=Object.prototype.toString.call(t),Error(o(31,"[object Object]"===e?"object with keys {"+Object.keys(t).join(", ")+"}":e))}function Yo(e){return(0,e._init)(e._payload)}function Go(e){function t(t,n){if(e){var r=t.deletions;null===r?(t.deletions=[n],t.flags|=16):r.push(n)}}function n(n,r){if(!e)return null;for(;null!==r;)t(n,r),r=r.sibling;return null}function r(e,t){for(e=new Map;null!==t;)null!==t.key?e.set(t.key,t):e.set(t.index,t),t=t.sibling;return e}function a(e,t){return(e=Lu(e,t)).index=0,e.sibling=null,e}function l(t,n,r){return t.index=r,e?null!==(r=t.alternate)?(r=r.index)<n?(t.flags|=2,n):r:(t.flags|=2,n):(t.flags|=1048576,n)}function i(t){return e&&null===t.alternate&&(t.flags|=2),t}function s(e,t,n,r){return null===t||6!==t.tag?((t=Mu(n,e.mode,r)).return=e,t):((t=a(t,n)).return=e,t)}function u(e,t,n,r){var o=n.type;return o===S?d(e,t,n.props.children,r,n.key):null!==t&&(t.elementType===o||"object"===typeof o&&null!==o&&o.$$typeof===O&&Yo(o)===t.type)?((r=a(t,n.props)).ref=Qo(e,t,n),r.return=e,r):((r=zu(n.type,n.key,n.props,null,e.mode,r)).ref=Qo(e,t,n),r.return=e,r)}function c(e,t,n,r){return null===t||4!==t.tag||t.stateNode.containerInfo!==n.containerInfo||t.stateNode.implementation!==n.implementation?((t=Iu(n,e.mode,r)).return=e,t):((t=a(t,n.children||[])).return=e,t)}function d(e,t,n,r,o){return null===t||7!==t.tag?((t=Du(n,e.mode,r,o)).return=e,t):((t=a(t,n)).return=e,t)}function f(e,t,n){if("string"===typeof t&&""!==t||"number"===typeof t)return(t=Mu(""+t,e.mode,n)).return=e,t;if("object"===typeof t&&null!==t){switch(t.$$typeof){case x:return(n=zu(t.type,t.key,t.props,null,e.mode,n)).ref=Qo(e,null,t),n.return=e,n;case k:return(t=Iu(t,e.mode,n)).return=e,t;case O:return f(e,(0,t._init)(t._payload),n)}if(te(t)||D(t))return(t=Du(t,e.mode,n,null)).return=e,t;Ko(e,t)}return null}function p(e,t,n,r){var a=null!==t?t.key:null;if("string"===typeof n&&""!==n||"number"===typeof n)return null!==a?null:s(e,t,""+n,r);if("object"===typeof n&&null!==n){switch(n.$$typeof){case x:return n.key===a?u(e,t,n,r):null;case k:return n.key===a?c(e,t,n,r):null;case O:return p(e,t,(a=n._init)(n._payload),r)}if(te(n)||D(n))return null!==a?null:d(e,t,n,r,null);Ko(e,n)}return null}function h(e,t,n,r,a){if("string"===typeof r&&""!==r||"number"===typeof r)return s(t,e=e.get(n)||null,""+r,a);if("object"===typeof r&&null!==r){switch(r.$$typeof){case x:return u(t,e=e.get(null===r.key?n:r.key)||null,r,a);case k:return c(t,e=e.get(null===r.key?n:r.key)||null,r,a);case O:return h(e,t,n,(0,r._init)(r._payload),a)}if(te(r)||D(r))return d(t,e=e.get(n)||null,r,a,null);Ko(t,r)}return null}function v(a,o,i,s){for(var u=null,c=null,d=o,v=o=0,m=null;null!==d&&v<i.length;v++){d.index>v?(m=d,d=null):m=d.sibling;var y=p(a,d,i[v],s);if(null===y){null===d&&(d=m);break}e&&d&&null===y.alternate&&t(a,d),o=l(y,o,v),null===c?u=y:c.sibling=y,c=y,d=m}if(v===i.length)return n(a,d),ao&&Ja(a,v),u;if(null===d){for(;v<i.length;v++)null!==(d=f(a,i[v],s))&&(o=l(d,o,v),null===c?u=d:c.sibling=d,c=d);return ao&&Ja(a,v),u}for(d=r(a,d);v<i.length;v++)null!==(m=h(d,a,v,i[v],s))&&(e&&null!==m.alternate&&d.delete(null===m.key?v:m.key),o=l(m,o,v),null===c?u=m:c.sibling=m,c=m);return e&&d.forEach((function(e){return t(a,e)})),ao&&Ja(a,v),u}function m(a,i,s,u){var c=D(s);if("function"!==typeof c)throw Error(o(150));if(null==(s=c.call(s)))throw Error(o(151));for(var d=c=null,v=i,m=i=0,y=null,g=s.next();null!==v&&!g.done;m++,g=s.next()){v.index>m?(y=v,v=null):y=v.sibling;var b=p(a,v,g.value,u);if(null===b){null===v&&(v=y);break}e&&v&&null===b.alternate&&t(a,v),i=l(b,i,m),null===d?c=b:d.sibling=b,d=b,v=y}if(g.done)return n(a,v),ao&&Ja(a,m),c;if(null===v){for(;!g.done;m++,g=s.next())null!==(g=f(a,g.value,u))&&(i=l(g,i,m),null===d?c=g:d.sibling=g,d=g);return ao&&Ja(a,m),c}for(v=r(a,v);!g.done;m++,g=s.next())null!==(g=h(v,a,m,g.value,u))&&(e&&null!==g.alternate&&v.delete(null===g.key?m:g.key),i=l(g,i,m),null===d?c=g:d.sibling=g,d=g);return e&&v.forEach((function(e){return t(a,e)})),ao&&Ja(a,m),c}return function e(r,o,l,s){if("object"===typeof l&&null!==l&&l.type===S&&null===l.key&&(l=l.props.children),"object"===typeof l&&null!==l){switch(l.$$typeof){case x:e:{for(var u=l.key,c=o;null!==c;){if(c.key===u){if((u=l.type)===S){if(7===c.tag){n(r,c.sibling),(o=a(c,l.props.children)).return=r,r=o;break e}}else if(c.elementType===u||"object"===typeof u&&null!==u&&u.$$typeof===O&&Yo(u)===c.type){n(r,c.sibling),(o=a(c,l.props)).ref=Qo(r,c,l),o.return=r,r=o;break e}n(r,c);break}t(r,c),c=c.sibling}l.type===S?((o=Du(l.props.children,r.mode,s,l.key)).return=r,r=o):((s=zu(l.type,l.key,l.props,null,r.mode,s)).ref=Qo(r,o,l),s.return=r,r=s)}return i(r);case k:e:{for(c=l.key;null!==o;){if(o.key===c){if(4===o.tag&&o.stateNode.containerInfo===l.containerInfo&&o.stateNode.implementation===l.implementation){n(r,o.sibling),(o=a(o,l.children||[])).return=r,r=o;break e}n(r,o);break}t(r,o),o=o.sibling}(o=Iu(l,r.mode,s)).return=r,r=o}return i(r);case O:return e(r,o,(c=l._init)(l._payload),s)}if(te(l))return v(r,o,l,s);if(D(l))return m(r,o,l,s);Ko(r,l)}return"string"===typeof l&&""!==l||"number"===typeof l?(l=""+l,null!==o&&6===o.tag?(n(r,o.sibling),(o=a(o,l)).return=r,r=o):(n(r,o),(o=Mu(l,r.mode,s)).return=r,r=o),i(r)):n(r,o)}}var Xo=Go(!0),Jo=Go(!1),Zo={},el=Ea(Zo),tl=Ea(Zo),nl=Ea(Zo);function rl(e){if(e===Zo)throw Error(o(174));return e}function al(e,t){switch(Na(nl,t),Na(tl,e),Na(el,Zo),e=t.nodeType){case 9:case 11:t=(t=t.documentElement)?t.namespaceURI:se(null,"");break;default:t=se(t=(e=8===e?t.parentNode:t).namespaceURI||null,e=e.tagName)}Ca(el),Na(el,t)}function ol(){Ca(el),Ca(tl),Ca(nl)}function ll(e){rl(nl.current);var t=rl(el.current),n=se(t,e.type);t!==n&&(Na(tl,e),Na(el,n))}function il(e){tl.current===e&&(Ca(el),Ca(tl))}var sl=Ea(0);function ul(e){for(var t=e;null!==t;){if(13===t.tag){var n=t.memoizedState;if(null!==n&&(null===(n=n.dehydrated)||"$?"===n.data||"$!"===n.data))return t}else if(19===t.tag&&void 0!==t.memoizedProps.revealOrder){if(0!==(128&t.flags))return t}else if(null!==t.child){t.child.return=t,t=t.child;continue}if(t===e)break;for(;null===t.sibling;){if(null===t.return||t.return===e)return null;t=t.return}t.sibling.return=t.return,t=t.sibling}return null}var cl=[];function dl(){for(var e=0;e<cl.length;e++)cl[e]._workInProgressVersionPrimary=null;cl.length=0}var fl=w.ReactCurrentDispatcher,pl=w.ReactCurrentBatchConfig,hl=0,vl=null,ml=null,yl=null,gl=!1,bl=!1,wl=0,xl=0;function kl(){throw Error(o(321))}function Sl(e,t){if(null===t)return!1;for(var n=0;n<t.length&&n<e.length;n++)if(!ir(e[n],t[n]))return!1;return!0}function El(e,t,n,r,a,l){if(hl=l,vl=t,t.memoizedState=null,t.updateQueue=null,t.lanes=0,fl.current=null===e||null===e.memoizedState?ii:si,e=n(r,a),bl){l=0;do{if(bl=!1,wl=0,25<=l)throw Error(o(301));l+=1,yl=ml=null,t.updateQueue=null,fl.current=ui,e=n(r,a)}while(bl)}if(fl.current=li,t=null!==ml&&null!==ml.next,hl=0,yl=ml=vl=null,gl=!1,t)throw Error(o(300));return e}function Cl(){var e=0!==wl;return wl=0,e}function Nl(){var e={memoizedState:null,baseState:null,baseQueue:null,queue:null,next:null};return null===yl?vl.memoizedState=yl=e:yl=yl.next=e,yl}function _l(){if(null===ml){var e=vl.alternate;e=null!==e?e.memoizedState:null}else e=ml.next;var t=null===yl?vl.memoizedState:yl.next;if(null!==t)yl=t,ml=e;else{if(null===e)throw Error(o(310));e={memoizedState:(ml=e).memoizedState,baseState:ml.baseState,baseQueue:ml.baseQueue,queue:ml.queue,next:null},null===yl?vl.memoizedState=yl=e:yl=yl.next=e}return yl}function Rl(e,t){return"function"===typeof t?t(e):t}function jl(e){var t=_l(),n=t.queue;if(null===n)throw Error(o(311));n.lastRenderedReducer=e;var r=ml,a=r.baseQueue,l=n.pending;if(null!==l){if(null!==a){var i=a.next;a.next=l.next,l.next=i}r.baseQueue=a=l,n.pending=null}if(null!==a){l=a.next,r=r.baseState;var s=i=null,u=null,c=l;do{var d=c.lane;if((hl&d)===d)null!==u&&(u=u.next={lane:0,action:c.action,hasEagerState:c.hasEagerState,eagerState:c.eagerState,next:null}),r=c.hasEagerState?c.eagerState:e(r,c.action);else{var f={lane:d,action:c.action,hasEagerState:c.hasEagerState,eagerState:c.eagerState,next:null};null===u?(s=u=f,i=r):u=u.next=f,vl.lanes|=d,Fs|=d}c=c.next}while(null!==c&&c!==l);null===u?i=r:u.next=s,ir(r,t.memoizedState)||(wi=!0),t.memoizedState=r,t.baseState=i,t.baseQueue=u,n.lastRenderedState=r}if(null!==(e=n.interleaved)){a=e;do{l=a.lane,vl.lanes|=l,Fs|=l,a=a.next}while(a!==e)}else null===a&&(n.lanes=0);return[t.memoizedState,n.dispatch]}function Pl(e){var t=_l(),n=t.queue;if(null===n)throw Error(o(311));n.lastRenderedReducer=e;var r=n.dispatch,a=n.pending,l=t.memoizedState;if(null!==a){n.pending=null;var i=a=a.next;do{l=e(l,i.action),i=i.next}while(i!==a);ir(l,t.memoizedState)||(wi=!0),t.memoizedState=l,null===t.baseQueue&&(t.baseState=l),n.lastRenderedState=l}return[l,r]}function Tl(){}function Ol(e,t){var n=vl,r=_l(),a=t(),l=!ir(r.memoizedState,a);if(l&&(r.memoizedState=a,wi=!0),r=r.queue,Vl(Dl.bind(null,n,r,e),[e]),r.getSnapshot!==t||l||null!==yl&&1&yl.memoizedState.tag){if(n.flags|=2048,Bl(9,zl.bind(null,n,r,a,t),void 0,null),null===js)throw Error(o(349));0!==(30&hl)||Ll(n,t,a)}return a}function Ll(e,t,n){e.flags|=16384,e={getSnapshot:t,value:n},null===(t=vl.updateQueue)?(t={lastEffect:null,stores:null},vl.updateQueue=t,t.stores=[e]):null===(n=t.stores)?t.stores=[e]:n.push(e)}function zl(e,t,n,r){t.value=n,t.getSnapshot=r,Fl(t)&&Ml(e)}function Dl(e,t,n){return n((function(){Fl(t)&&Ml(e)}))}function Fl(e){var t=e.getSnapshot;e=e.value;try{var n=t();return!ir(e,n)}catch(r){return!0}}function Ml(e){var t=Po(e,1);null!==t&&nu(t,e,1,-1)}function Il(e){var t=Nl();return"function"===typeof e&&(e=e()),t.memoizedState=t.baseState=e,e={pending:null,interleaved:null,lanes:0,dispatch:null,lastRenderedReducer:Rl,lastRenderedState:e},t.queue=e,e=e.dispatch=ni.bind(null,vl,e),[t.memoizedState,e]}function Bl(e,t,n,r){return e={tag:e,create:t,destroy:n,deps:r,next:null},null===(t=vl.updateQueue)?(t={lastEffect:null,stores:null},vl.updateQueue=t,t.lastEffect=e.next=e):null===(n=t.lastEffect)?t.lastEffect=e.next=e:(r=n.next,n.next=e,e.next=r,t.lastEffect=e),e}function Al(){return _l().memoizedState}function Ul(e,t,n,r){var a=Nl();vl.flags|=e,a.memoizedState=Bl(1|t,n,void 0,void 0===r?null:r)}function Hl(e,t,n,r){var a=_l();r=void 0===r?null:r;var o=void 0;if(null!==ml){var l=ml.memoizedState;if(o=l.destroy,null!==r&&Sl(r,l.deps))return void(a.memoizedState=Bl(t,n,o,r))}vl.flags|=e,a.memoizedState=Bl(1|t,n,o,r)}function ql(e,t){return Ul(8390656,8,e,t)}function Vl(e,t){return Hl(2048,8,e,t)}function Wl(e,t){return Hl(4,2,e,t)}function $l(e,t){return Hl(4,4,e,t)}function Ql(e,t){return"function"===typeof t?(e=e(),t(e),function(){t(null)}):null!==t&&void 0!==t?(e=e(),t.current=e,function(){t.current=null}):void 0}function Kl(e,t,n){return n=null!==n&&void 0!==n?n.concat([e]):null,Hl(4,4,Ql.bind(null,t,e),n)}function Yl(){}function Gl(e,t){var n=_l();t=void 0===t?null:t;var r=n.memoizedState;return null!==r&&null!==t&&Sl(t,r[1])?r[0]:(n.memoizedState=[e,t],e)}function Xl(e,t){var n=_l();t=void 0===t?null:t;var r=n.memoizedState;return null!==r&&null!==t&&Sl(t,r[1])?r[0]:(e=e(),n.memoizedState=[e,t],e)}function Jl(e,t,n){return 0===(21&hl)?(e.baseState&&(e.baseState=!1,wi=!0),e.memoizedState=n):(ir(n,t)||(n=vt(),vl.lanes|=n,Fs|=n,e.baseState=!0),t)}function Zl(e,t){var n=bt;bt=0!==n&&4>n?n:4,e(!0);var r=pl.transition;pl.transition={};try{e(!1),t()}finally{bt=n,pl.transition=r}}function ei(){return _l().memoizedState}function ti(e,t,n){var r=tu(e);if(n={lane:r,action:n,hasEagerState:!1,eagerState:null,next:null},ri(e))ai(t,n);else if(null!==(n=jo(e,t,n,r))){nu(n,e,r,eu()),oi(n,t,r)}}function ni(e,t,n){var r=tu(e),a={lane:r,action:n,hasEagerState:!1,eagerState:null,next:null};if(ri(e))ai(t,a);else{var o=e.alternate;if(0===e.lanes&&(null===o||0===o.lanes)&&null!==(o=t.lastRenderedReducer))try{var l=t.lastRenderedState,i=o(l,n);if(a.hasEagerState=!0,a.eagerState=i,ir(i,l)){var s=t.interleaved;return null===s?(a.next=a,Ro(t)):(a.next=s.next,s.next=a),void(t.interleaved=a)}}catch(u){}null!==(n=jo(e,t,a,r))&&(nu(n,e,r,a=eu()),oi(n,t,r))}}function ri(e){var t=e.alternate;return e===vl||null!==t&&t===vl}function ai(e,t){bl=gl=!0;var n=e.pending;null===n?t.next=t:(t.next=n.next,n.next=t),e.pending=t}function oi(e,t,n){if(0!==(4194240&n)){var r=t.lanes;n|=r&=e.pendingLanes,t.lanes=n,gt(e,n)}}var li={readContext:No,useCallback:kl,useContext:kl,useEffect:kl,useImperativeHandle:kl,useInsertionEffect:kl,useLayoutEffect:kl,useMemo:kl,useReducer:kl,useRef:kl,useState:kl,useDebugValue:kl,useDeferredValue:kl,useTransition:kl,useMutableSource:kl,useSyncExternalStore:kl,useId:kl,unstable_isNewReconciler:!1},ii={readContext:No,useCallback:function(e,t){return Nl().memoizedState=[e,void 0===t?null:t],e},useContext:No,useEffect:ql,useImperativeHandle:function(e,t,n){return n=null!==n&&void 0!==n?n.concat([e]):null,Ul(4194308,4,Ql.bind(null,t,e),n)},useLayoutEffect:function(e,t){return Ul(4194308,4,e,t)},useInsertionEffect:function(e,t){return Ul(4,2,e,t)},useMemo:function(e,t){var n=Nl();return t=void 0===t?null:t,e=e(),n.memoizedState=[e,t],e},useReducer:function(e,t,n){var r=Nl();return t=void 0!==n?n(t):t,r.memoizedState=r.baseState=t,e={pending:null,interleaved:null,lanes:0,dispatch:null,lastRenderedReducer:e,lastRenderedState:t},r.queue=e,e=e.dispatch=ti.bind(null,vl,e),[r.memoizedState,e]},useRef:function(e){return e={current:e},Nl().memoizedState=e},useState:Il,useDebugValue:Yl,useDeferredValue:function(e){return Nl().memoizedState=e},useTransition:function(){var e=Il(!1),t=e[0];return e=Zl.bind(null,e[1]),Nl().memoizedState=e,[t,e]},useMutableSource:function(){},useSyncExternalStore:function(e,t,n){var r=vl,a=Nl();if(ao){if(void 0===n)throw Error(o(407));n=n()}else{if(n=t(),null===js)throw Error(o(349));0!==(30&hl)||Ll(r,t,n)}a.memoizedState=n;var l={value:n,getSnapshot:t};return a.queue=l,ql(Dl.bind(null,r,l,e),[e]),r.flags|=2048,Bl(9,zl.bind(null,r,l,n,t),void 0,null),n},useId:function(){var e=Nl(),t=js.identifierPrefix;if(ao){var n=Xa;t=":"+t+"R"+(n=(Ga&~(1<<32-lt(Ga)-1)).toString(32)+n),0<(n=wl++)&&(t+="H"+n.toString(32)),t+=":"}else t=":"+t+"r"+(n=xl++).toString(32)+":";return e.memoizedState=t},unstable_isNewReconciler:!1},si={readContext:No,useCallback:Gl,useContext:No,useEffect:Vl,useImperativeHandle:Kl,useInsertionEffect:Wl,useLayoutEffect:$l,useMemo:Xl,useReducer:jl,useRef:Al,useState:function(){return jl(Rl)},useDebugValue:Yl,useDeferredValue:function(e){return Jl(_l(),ml.memoizedState,e)},useTransition:function(){return[jl(Rl)[0],_l().memoizedState]},useMutableSource:Tl,useSyncExternalStore:Ol,useId:ei,unstable_isNewReconciler:!1},ui={readContext:No,useCallback:Gl,useContext:No,useEffect:Vl,useImperativeHandle:Kl,useInsertionEffect:Wl,useLayoutEffect:$l,useMemo:Xl,useReducer:Pl,useRef:Al,useState:function(){return Pl(Rl)},useDebugValue:Yl,useDeferredValue:function(e){var t=_l();return null===ml?t.memoizedState=e:Jl(t,ml.memoizedState,e)},useTransition:function(){return[Pl(Rl)[0],_l().memoizedState]},useMutableSource:Tl,useSyncExternalStore:Ol,useId:ei,unstable_isNewReconciler:!1};function ci(e,t){try{var n="",r=t;do{n+=U(r),r=r.return}while(r);var a=n}catch(o){a="\nError generating stack: "+o.message+"\n"+o.stack}return{value:e,source:t,stack:a,digest:null}}function di(e,t,n){return{value:e,source:null,stack:null!=n?n:null,digest:null!=t?t:null}}function fi(e,t){try{console.error(t.value)}catch(n){setTimeout((function(){throw n}))}}var pi="function"===typeof WeakMap?WeakMap:Map;function hi(e,t,n){(n=zo(-1,n)).tag=3,n.payload={element:null};var r=t.value;return n.callback=function(){Vs||(Vs=!0,Ws=r),fi(0,t)},n}function vi(e,t,n){(n=zo(-1,n)).tag=3;var r=e.type.getDerivedStateFromError;if("function"===typeof r){var a=t.value;n.payload=function(){return r(a)},n.callback=function(){fi(0,t)}}var o=e.stateNode;return null!==o&&"function"===typeof o.componentDidCatch&&(n.callback=function(){fi(0,t),"function"!==typeof r&&(null===$s?$s=new Set([this]):$s.add(this));var e=t.stack;this.componentDidCatch(t.value,{componentStack:null!==e?e:""})}),n}function mi(e,t,n){var r=e.pingCache;if(null===r){r=e.pingCache=new pi;var a=new Set;r.set(t,a)}else void 0===(a=r.get(t))&&(a=new Set,r.set(t,a));a.has(n)||(a.add(n),e=Cu.bind(null,e,t,n),t.then(e,e))}function yi(e){do{var t;if((t=13===e.tag)&&(t=null===(t=e.memoizedState)||null!==t.dehydrated),t)return e;e=e.return}while(null!==e);return null}function gi(e,t,n,r,a){return 0===(1&e.mode)?(e===t?e.flags|=65536:(e.flags|=128,n.flags|=131072,n.flags&=-52805,1===n.tag&&(null===n.alternate?n.tag=17:((t=zo(-1,1)).tag=2,Do(n,t,1))),n.lanes|=1),e):(e.flags|=65536,e.lanes=a,e)}var bi=w.ReactCurrentOwner,wi=!1;function xi(e,t,n,r){t.child=null===e?Jo(t,null,n,r):Xo(t,e.child,n,r)}function ki(e,t,n,r,a){n=n.render;var o=t.ref;return Co(t,a),r=El(e,t,n,r,o,a),n=Cl(),null===e||wi?(ao&&n&&eo(t),t.flags|=1,xi(e,t,r,a),t.child):(t.updateQueue=e.updateQueue,t.flags&=-2053,e.lanes&=~a,Vi(e,t,a))}function Si(e,t,n,r,a){if(null===e){var o=n.type;return"function"!==typeof o||Ou(o)||void 0!==o.defaultProps||null!==n.compare||void 0!==n.defaultProps?((e=zu(n.type,null,r,t,t.mode,a)).ref=t.ref,e.return=t,t.child=e):(t.tag=15,t.type=o,Ei(e,t,o,r,a))}if(o=e.child,0===(e.lanes&a)){var l=o.memoizedProps;if((n=null!==(n=n.compare)?n:sr)(l,r)&&e.ref===t.ref)return Vi(e,t,a)}return t.flags|=1,(e=Lu(o,r)).ref=t.ref,e.return=t,t.child=e}function Ei(e,t,n,r,a){if(null!==e){var o=e.memoizedProps;if(sr(o,r)&&e.ref===t.ref){if(wi=!1,t.pendingProps=r=o,0===(e.lanes&a))return t.lanes=e.lanes,Vi(e,t,a);0!==(131072&e.flags)&&(wi=!0)}}return _i(e,t,n,r,a)}function Ci(e,t,n){var r=t.pendingProps,a=r.children,o=null!==e?e.memoizedState:null;if("hidden"===r.mode)if(0===(1&t.mode))t.memoizedState={baseLanes:0,cachePool:null,transitions:null},Na(Ls,Os),Os|=n;else{if(0===(1073741824&n))return e=null!==o?o.baseLanes|n:n,t.lanes=t.childLanes=1073741824,t.memoizedState={baseLanes:e,cachePool:null,transitions:null},t.updateQueue=null,Na(Ls,Os),Os|=e,null;t.memoizedState={baseLanes:0,cachePool:null,transitions:null},r=null!==o?o.baseLanes:n,Na(Ls,Os),Os|=r}else null!==o?(r=o.baseLanes|n,t.memoizedState=null):r=n,Na(Ls,Os),Os|=r;return xi(e,t,a,n),t.child}function Ni(e,t){var n=t.ref;(null===e&&null!==n||null!==e&&e.ref!==n)&&(t.flags|=512,t.flags|=2097152)}function _i(e,t,n,r,a){var o=Oa(n)?Pa:Ra.current;return o=Ta(t,o),Co(t,a),n=El(e,t,n,r,o,a),r=Cl(),null===e||wi?(ao&&r&&eo(t),t.flags|=1,xi(e,t,n,a),t.child):(t.updateQueue=e.updateQueue,t.flags&=-2053,e.lanes&=~a,Vi(e,t,a))}function Ri(e,t,n,r,a){if(Oa(n)){var o=!0;Fa(t)}else o=!1;if(Co(t,a),null===t.stateNode)qi(e,t),Vo(t,n,r),$o(t,n,r,a),r=!0;else if(null===e){var l=t.stateNode,i=t.memoizedProps;l.props=i;var s=l.context,u=n.contextType;"object"===typeof u&&null!==u?u=No(u):u=Ta(t,u=Oa(n)?Pa:Ra.current);var c=n.getDerivedStateFromProps,d="function"===typeof c||"function"===typeof l.getSnapshotBeforeUpdate;d||"function"!==typeof l.UNSAFE_componentWillReceiveProps&&"function"!==typeof l.componentWillReceiveProps||(i!==r||s!==u)&&Wo(t,l,r,u),To=!1;var f=t.memoizedState;l.state=f,Io(t,r,l,a),s=t.memoizedState,i!==r||f!==s||ja.current||To?("function"===typeof c&&(Uo(t,n,c,r),s=t.memoizedState),(i=To||qo(t,n,i,r,f,s,u))?(d||"function"!==typeof l.UNSAFE_componentWillMount&&"function"!==typeof l.componentWillMount||("function"===typeof l.componentWillMount&&l.componentWillMount(),"function"===typeof l.UNSAFE_componentWillMount&&l.UNSAFE_componentWillMount()),"function"===typeof l.componentDidMount&&(t.flags|=4194308)):("function"===typeof l.componentDidMount&&(t.flags|=4194308),t.memoizedProps=r,t.memoizedState=s),l.props=r,l.state=s,l.context=u,r=i):("function"===typeof l.componentDidMount&&(t.flags|=4194308),r=!1)}else{l=t.stateNode,Lo(e,t),i=t.memoizedProps,u=t.type===t.elementType?i:yo(t.type,i),l.props=u,d=t.pendingProps,f=l.context,"object"===typeof(s=n.contextType)&&null!==s?s=No(s):s=Ta(t,s=Oa(n)?Pa:Ra.current);var p=n.getDerivedStateFromProps;(c="function"===typeof p||"function"===typeof l.getSnapshotBeforeUpdate)||"function"!==typeof l.UNSAFE_componentWillReceiveProps&&"function"!==typeof l.componentWillReceiveProps||(i!==d||f!==s)&&Wo(t,l,r,s),To=!1,f=t.memoizedState,l.state=f,Io(t,r,l,a);var h=t.memoizedState;i!==d||f!==h||ja.current||To?("function"===typeof p&&(Uo(t,n,p,r),h=t.memoizedState),(u=To||qo(t,n,u,r,f,h,s)||!1)?(c||"function"!==typeof l.UNSAFE_componentWillUpdate&&"function"!==typeof l.componentWillUpdate||("function"===typeof l.componentWillUpdate&&l.componentWillUpdate(r,h,s),"function"===typeof l.UNSAFE_componentWillUpdate&&l.UNSAFE_componentWillUpdate(r,h,s)),"function"===typeof l.componentDidUpdate&&(t.flags|=4),"function"===typeof l.getSnapshotBeforeUpdate&&(t.flags|=1024)):("function"!==typeof l.componentDidUpdate||i===e.memoizedProps&&f===e.memoizedState||(t.flags|=4),"function"!==typeof l.getSnapshotBeforeUpdate||i===e.memoizedProps&&f===e.memoizedState||(t.flags|=1024),t.memoizedProps=r,t.memoizedState=h),l.props=r,l.state=h,l.context=s,r=u):("function"!==typeof l.componentDidUpdate||i===e.memoizedProps&&f===e.memoizedState||(t.flags|=4),"function"!==typeof l.getSnapshotBeforeUpdate||i===e.memoizedProps&&f===e.memoizedState||(t.flags|=1024),r=!1)}return ji(e,t,n,r,o,a)}function ji(e,t,n,r,a,o){Ni(e,t);var l=0!==(128&t.flags);if(!r&&!l)return a&&Ma(t,n,!1),Vi(e,t,o);r=t.stateNode,bi.current=t;var i=l&&"function"!==typeof n.getDerivedStateFromError?null:r.render();return t.flags|=1,null!==e&&l?(t.child=Xo(t,e.child,null,o),t.child=Xo(t,null,i,o)):xi(e,t,i,o),t.memoizedState=r.state,a&&Ma(t,n,!0),t.child}function Pi(e){var t=e.stateNode;t.pendingContext?za(0,t.pendingContext,t.pendingContext!==t.context):t.context&&za(0,t.context,!1),al(e,t.containerInfo)}function Ti(e,t,n,r,a){return ho(),vo(a),t.flags|=256,xi(e,t,n,r),t.child}var Oi,Li,zi,Di={dehydrated:null,treeContext:null,retryLane:0};function Fi(e){return{baseLanes:e,cachePool:null,transitions:null}}function Mi(e,t,n){var r,a=t.pendingProps,l=sl.current,i=!1,s=0!==(128&t.flags);if((r=s)||(r=(null===e||null!==e.memoizedState)&&0!==(2&l)),r?(i=!0,t.flags&=-129):null!==e&&null===e.memoizedState||(l|=1),Na(sl,1&l),null===e)return uo(t),null!==(e=t.memoizedState)&&null!==(e=e.dehydrated)?(0===(1&t.mode)?t.lanes=1:"$!"===e.data?t.lanes=8:t.lanes=1073741824,null):(s=a.children,e=a.fallback,i?(a=t.mode,i=t.child,s={mode:"hidden",children:s},0===(1&a)&&null!==i?(i.childLanes=0,i.pendingProps=s):i=Fu(s,a,0,null),e=Du(e,a,n,null),i.return=t,e.return=t,i.sibling=e,t.child=i,t.child.memoizedState=Fi(n),t.memoizedState=Di,e):Ii(t,s));if(null!==(l=e.memoizedState)&&null!==(r=l.dehydrated))return function(e,t,n,r,a,l,i){if(n)return 256&t.flags?(t.flags&=-257,Bi(e,t,i,r=di(Error(o(422))))):null!==t.memoizedState?(t.child=e.child,t.flags|=128,null):(l=r.fallback,a=t.mode,r=Fu({mode:"visible",children:r.children},a,0,null),(l=Du(l,a,i,null)).flags|=2,r.return=t,l.return=t,r.sibling=l,t.child=r,0!==(1&t.mode)&&Xo(t,e.child,null,i),t.child.memoizedState=Fi(i),t.memoizedState=Di,l);if(0===(1&t.mode))return Bi(e,t,i,null);if("$!"===a.data){if(r=a.nextSibling&&a.nextSibling.dataset)var s=r.dgst;return r=s,Bi(e,t,i,r=di(l=Error(o(419)),r,void 0))}if(s=0!==(i&e.childLanes),wi||s){if(null!==(r=js)){switch(i&-i){case 4:a=2;break;case 16:a=8;break;case 64:case 128:case 256:case 512:case 1024:case 2048:case 4096:case 8192:case 16384:case 32768:case 65536:case 131072:case 262144:case 524288:case 1048576:case 2097152:case 4194304:case 8388608:case 16777216:case 33554432:case 67108864:a=32;break;case 536870912:a=268435456;break;default:a=0}0!==(a=0!==(a&(r.suspendedLanes|i))?0:a)&&a!==l.retryLane&&(l.retryLane=a,Po(e,a),nu(r,e,a,-1))}return vu(),Bi(e,t,i,r=di(Error(o(421))))}return"$?"===a.data?(t.flags|=128,t.child=e.child,t=_u.bind(null,e),a._reactRetry=t,null):(e=l.treeContext,ro=ua(a.nextSibling),no=t,ao=!0,oo=null,null!==e&&(Qa[Ka++]=Ga,Qa[Ka++]=Xa,Qa[Ka++]=Ya,Ga=e.id,Xa=e.overflow,Ya=t),t=Ii(t,r.children),t.flags|=4096,t)}(e,t,s,a,r,l,n);if(i){i=a.fallback,s=t.mode,r=(l=e.child).sibling;var u={mode:"hidden",children:a.children};return 0===(1&s)&&t.child!==l?((a=t.child).childLanes=0,a.pendingProps=u,t.deletions=null):(a=Lu(l,u)).subtreeFlags=14680064&l.subtreeFlags,null!==r?i=Lu(r,i):(i=Du(i,s,n,null)).flags|=2,i.return=t,a.return=t,a.sibling=i,t.child=a,a=i,i=t.child,s=null===(s=e.child.memoizedState)?Fi(n):{baseLanes:s.baseLanes|n,cachePool:null,transitions:s.transitions},i.memoizedState=s,i.childLanes=e.childLanes&~n,t.memoizedState=Di,a}return e=(i=e.child).sibling,a=Lu(i,{mode:"visible",children:a.children}),0===(1&t.mode)&&(a.lanes=n),a.return=t,a.sibling=null,null!==e&&(null===(n=t.deletions)?(t.deletions=[e],t.flags|=16):n.push(e)),t.child=a,t.memoizedState=null,a}function Ii(e,t){return(t=Fu({mode:"visible",children:t},e.mode,0,null)).return=e,e.child=t}function Bi(e,t,n,r){return null!==r&&vo(r),Xo(t,e.child,null,n),(e=Ii(t,t.pendingProps.children)).flags|=2,t.memoizedState=null,e}function Ai(e,t,n){e.lanes|=t;var r=e.alternate;null!==r&&(r.lanes|=t),Eo(e.return,t,n)}function Ui(e,t,n,r,a){var o=e.memoizedState;null===o?e.memoizedState={isBackwards:t,rendering:null,renderingStartTime:0,last:r,tail:n,tailMode:a}:(o.isBackwards=t,o.rendering=null,o.renderingStartTime=0,o.last=r,o.tail=n,o.tailMode=a)}function Hi(e,t,n){var r=t.pendingProps,a=r.revealOrder,o=r.tail;if(xi(e,t,r.children,n),0!==(2&(r=sl.current)))r=1&r|2,t.flags|=128;else{if(null!==e&&0!==(128&e.flags))e:for(e=t.child;null!==e;){if(13===e.tag)null!==e.memoizedState&&Ai(e,n,t);else if(19===e.tag)Ai(e,n,t);else if(null!==e.child){e.child.return=e,e=e.child;continue}if(e===t)break e;for(;null===e.sibling;){if(null===e.return||e.return===t)break e;e=e.return}e.sibling.return=e.return,e=e.sibling}r&=1}if(Na(sl,r),0===(1&t.mode))t.memoizedState=null;else switch(a){case"forwards":for(n=t.child,a=null;null!==n;)null!==(e=n.alternate)&&null===ul(e)&&(a=n),n=n.sibling;null===(n=a)?(a=t.child,t.child=null):(a=n.sibling,n.sibling=null),Ui(t,!1,a,n,o);break;case"backwards":for(n=null,a=t.child,t.child=null;null!==a;){if(null!==(e=a.alternate)&&null===ul(e)){t.child=a;break}e=a.sibling,a.sibling=n,n=a,a=e}Ui(t,!0,n,null,o);break;case"together":Ui(t,!1,null,null,void 0);break;default:t.memoizedState=null}return t.child}function qi(e,t){0===(1&t.mode)&&null!==e&&(e.alternate=null,t.alternate=null,t.flags|=2)}function Vi(e,t,n){if(null!==e&&(t.dependencies=e.dependencies),Fs|=t.lanes,0===(n&t.childLanes))return null;if(null!==e&&t.child!==e.child)throw Error(o(153));if(null!==t.child){for(n=Lu(e=t.child,e.pendingProps),t.child=n,n.return=t;null!==e.sibling;)e=e.sibling,(n=n.sibling=Lu(e,e.pendingProps)).return=t;n.sibling=null}return t.child}function Wi(e,t){if(!ao)switch(e.tailMode){case"hidden":t=e.tail;for(var n=null;null!==t;)null!==t.alternate&&(n=t),t=t.sibling;null===n?e.tail=null:n.sibling=null;break;case"collapsed":n=e.tail;for(var r=null;null!==n;)null!==n.alternate&&(r=n),n=n.sibling;null===r?t||null===e.tail?e.tail=null:e.tail.sibling=null:r.sibling=null}}function $i(e){var t=null!==e.alternate&&e.alternate.child===e.child,n=0,r=0;if(t)for(var a=e.child;null!==a;)n|=a.lanes|a.childLanes,r|=14680064&a.subtreeFlags,r|=14680064&a.flags,a.return=e,a=a.sibling;else for(a=e.child;null!==a;)n|=a.lanes|a.childLanes,r|=a.subtreeFlags,r|=a.flags,a.return=e,a=a.sibling;return e.subtreeFlags|=r,e.childLanes=n,t}function Qi(e,t,n){var r=t.pendingProps;switch(to(t),t.tag){case 2:case 16:case 15:case 0:case 11:case 7:case 8:case 12:case 9:case 14:return $i(t),null;case 1:case 17:return Oa(t.type)&&La(),$i(t),null;case 3:return r=t.stateNode,ol(),Ca(ja),Ca(Ra),dl(),r.pendingContext&&(r.context=r.pendingContext,r.pendingContext=null),null!==e&&null!==e.child||(fo(t)?t.flags|=4:null===e||e.memoizedState.isDehydrated&&0===(256&t.flags)||(t.flags|=1024,null!==oo&&(lu(oo),oo=null))),$i(t),null;case 5:il(t);var a=rl(nl.current);if(n=t.type,null!==e&&null!=t.stateNode)Li(e,t,n,r),e.ref!==t.ref&&(t.flags|=512,t.flags|=2097152);else{if(!r){if(null===t.stateNode)throw Error(o(166));return $i(t),null}if(e=rl(el.current),fo(t)){r=t.stateNode,n=t.type;var l=t.memoizedProps;switch(r[fa]=t,r[pa]=l,e=0!==(1&t.mode),n){case"dialog":Br("cancel",r),Br("close",r);break;case"iframe":case"object":case"embed":Br("load",r);break;case"video":case"audio":for(a=0;a<Dr.length;a++)Br(Dr[a],r);break;case"source":Br("error",r);break;case"img":case"image":case"link":Br("error",r),Br("load",r);break;case"details":Br("toggle",r);break;case"input":G(r,l),Br("invalid",r);break;case"select":r._wrapperState={wasMultiple:!!l.multiple},Br("invalid",r);break;case"textarea":ae(r,l),Br("invalid",r)}for(var s in ge(n,l),a=null,l)if(l.hasOwnProperty(s)){var u=l[s];"children"===s?"string"===typeof u?r.textContent!==u&&(!0!==l.suppressHydrationWarning&&Jr(r.textContent,u,e),a=["children",u]):"number"===typeof u&&r.textContent!==""+u&&(!0!==l.suppressHydrationWarning&&Jr(r.textContent,u,e),a=["children",""+u]):i.hasOwnProperty(s)&&null!=u&&"onScroll"===s&&Br("scroll",r)}switch(n){case"input":$(r),Z(r,l,!0);break;case"textarea":$(r),le(r);break;case"select":case"option":break;default:"function"===typeof l.onClick&&(r.onclick=Zr)}r=a,t.updateQueue=r,null!==r&&(t.flags|=4)}else{s=9===a.nodeType?a:a.ownerDocument,"http://www.w3.org/1999/xhtml"===e&&(e=ie(n)),"http://www.w3.org/1999/xhtml"===e?"script"===n?((e=s.createElement("div")).innerHTML="<script><\/script>",e=e.removeChild(e.firstChild)):"string"===typeof r.is?e=s.createElement(n,{is:r.is}):(e=s.createElement(n),"select"===n&&(s=e,r.multiple?s.multiple=!0:r.size&&(s.size=r.size))):e=s.createElementNS(e,n),e[fa]=t,e[pa]=r,Oi(e,t),t.stateNode=e;e:{switch(s=be(n,r),n){case"dialog":Br("cancel",e),Br("close",e),a=r;break;case"iframe":case"object":case"embed":Br("load",e),a=r;break;case"video":case"audio":for(a=0;a<Dr.length;a++)Br(Dr[a],e);a=r;break;case"source":Br("error",e),a=r;break;case"img":case"image":case"link":Br("error",e),Br("load",e),a=r;break;case"details":Br("toggle",e),a=r;break;case"input":G(e,r),a=Y(e,r),Br("invalid",e);break;case"option":default:a=r;break;case"select":e._wrapperState={wasMultiple:!!r.multiple},a=M({},r,{value:void 0}),Br("invalid",e);break;case"textarea":ae(e,r),a=re(e,r),Br("invalid",e)}for(l in ge(n,a),u=a)if(u.hasOwnProperty(l)){var c=u[l];"style"===l?me(e,c):"dangerouslySetInnerHTML"===l?null!=(c=c?c.__html:void 0)&&de(e,c):"children"===l?"string"===typeof c?("textarea"!==n||""!==c)&&fe(e,c):"number"===typeof c&&fe(e,""+c):"suppressContentEditableWarning"!==l&&"suppressHydrationWarning"!==l&&"autoFocus"!==l&&(i.hasOwnProperty(l)?null!=c&&"onScroll"===l&&Br("scroll",e):null!=c&&b(e,l,c,s))}switch(n){case"input":$(e),Z(e,r,!1);break;case"textarea":$(e),le(e);break;case"option":null!=r.value&&e.setAttribute("value",""+V(r.value));break;case"select":e.multiple=!!r.multiple,null!=(l=r.value)?ne(e,!!r.multiple,l,!1):null!=r.defaultValue&&ne(e,!!r.multiple,r.defaultValue,!0);break;default:"function"===typeof a.onClick&&(e.onclick=Zr)}switch(n){case"button":case"input":case"select":case"textarea":r=!!r.autoFocus;break e;case"img":r=!0;break e;default:r=!1}}r&&(t.flags|=4)}null!==t.ref&&(t.flags|=512,t.flags|=2097152)}return $i(t),null;case 6:if(e&&null!=t.stateNode)zi(0,t,e.memoizedProps,r);else{if("string"!==typeof r&&null===t.stateNode)throw Error(o(166));if(n=rl(nl.current),rl(el.current),fo(t)){if(r=t.stateNode,n=t.memoizedProps,r[fa]=t,(l=r.nodeValue!==n)&&null!==(e=no))switch(e.tag){case 3:Jr(r.nodeValue,n,0!==(1&e.mode));break;case 5:!0!==e.memoizedProps.suppressHydrationWarning&&Jr(r.nodeValue,n,0!==(1&e.mode))}l&&(t.flags|=4)}else(r=(9===n.nodeType?n:n.ownerDocument).createTextNode(r))[fa]=t,t.stateNode=r}return $i(t),null;case 13:if(Ca(sl),r=t.memoizedState,null===e||null!==e.memoizedState&&null!==e.memoizedState.dehydrated){if(ao&&null!==ro&&0!==(1&t.mode)&&0===(128&t.flags))po(),ho(),t.flags|=98560,l=!1;else if(l=fo(t),null!==r&&null!==r.dehydrated){if(null===e){if(!l)throw Error(o(318));if(!(l=null!==(l=t.memoizedState)?l.dehydrated:null))throw Error(o(317));l[fa]=t}else ho(),0===(128&t.flags)&&(t.memoizedState=null),t.flags|=4;$i(t),l=!1}else null!==oo&&(lu(oo),oo=null),l=!0;if(!l)return 65536&t.flags?t:null}return 0!==(128&t.flags)?(t.lanes=n,t):((r=null!==r)!==(null!==e&&null!==e.memoizedState)&&r&&(t.child.flags|=8192,0!==(1&t.mode)&&(null===e||0!==(1&sl.current)?0===zs&&(zs=3):vu())),null!==t.updateQueue&&(t.flags|=4),$i(t),null);case 4:return ol(),null===e&&Hr(t.stateNode.containerInfo),$i(t),null;case 10:return So(t.type._context),$i(t),null;case 19:if(Ca(sl),null===(l=t.memoizedState))return $i(t),null;if(r=0!==(128&t.flags),null===(s=l.rendering))if(r)Wi(l,!1);else{if(0!==zs||null!==e&&0!==(128&e.flags))for(e=t.child;null!==e;){if(null!==(s=ul(e))){for(t.flags|=128,Wi(l,!1),null!==(r=s.updateQueue)&&(t.updateQueue=r,t.flags|=4),t.subtreeFlags=0,r=n,n=t.child;null!==n;)e=r,(l=n).flags&=14680066,null===(s=l.alternate)?(l.childLanes=0,l.lanes=e,l.child=null,l.subtreeFlags=0,l.memoizedProps=null,l.memoizedState=null,l.updateQueue=null,l.dependencies=null,l.stateNode=null):(l.childLanes=s.childLanes,l.lanes=s.lanes,l.child=s.child,l.subtreeFlags=0,l.deletions=null,l.memoizedProps=s.memoizedProps,l.memoizedState=s.memoizedState,l.updateQueue=s.updateQueue,l.type=s.type,e=s.dependencies,l.dependencies=null===e?null:{lanes:e.lanes,firstContext:e.firstContext}),n=n.sibling;return Na(sl,1&sl.current|2),t.child}e=e.sibling}null!==l.tail&&Xe()>Hs&&(t.flags|=128,r=!0,Wi(l,!1),t.lanes=4194304)}else{if(!r)if(null!==(e=ul(s))){if(t.flags|=128,r=!0,null!==(n=e.updateQueue)&&(t.updateQueue=n,t.flags|=4),Wi(l,!0),null===l.tail&&"hidden"===l.tailMode&&!s.alternate&&!ao)return $i(t),null}else 2*Xe()-l.renderingStartTime>Hs&&1073741824!==n&&(t.flags|=128,r=!0,Wi(l,!1),t.lanes=4194304);l.isBackwards?(s.sibling=t.child,t.child=s):(null!==(n=l.last)?n.sibling=s:t.child=s,l.last=s)}return null!==l.tail?(t=l.tail,l.rendering=t,l.tail=t.sibling,l.renderingStartTime=Xe(),t.sibling=null,n=sl.current,Na(sl,r?1&n|2:1&n),t):($i(t),null);case 22:case 23:return du(),r=null!==t.memoizedState,null!==e&&null!==e.memoizedState!==r&&(t.flags|=8192),r&&0!==(1&t.mode)?0!==(1073741824&Os)&&($i(t),6&t.subtreeFlags&&(t.flags|=8192)):$i(t),null;case 24:case 25:return null}throw Error(o(156,t.tag))}function Ki(e,t){switch(to(t),t.tag){case 1:return Oa(t.type)&&La(),65536&(e=t.flags)?(t.flags=-65537&e|128,t):null;case 3:return ol(),Ca(ja),Ca(Ra),dl(),0!==(65536&(e=t.flags))&&0===(128&e)?(t.flags=-65537&e|128,t):null;case 5:return il(t),null;case 13:if(Ca(sl),null!==(e=t.memoizedState)&&null!==e.dehydrated){if(null===t.alternate)throw Error(o(340));ho()}return 65536&(e=t.flags)?(t.flags=-65537&e|128,t):null;case 19:return Ca(sl),null;case 4:return ol(),null;case 10:return So(t.type._context),null;case 22:case 23:return du(),null;default:return null}}Oi=function(e,t){for(var n=t.child;null!==n;){if(5===n.tag||6===n.tag)e.appendChild(n.stateNode);else if(4!==n.tag&&null!==n.child){n.child.return=n,n=n.child;continue}if(n===t)break;for(;null===n.sibling;){if(null===n.return||n.return===t)return;n=n.return}n.sibling.return=n.return,n=n.sibling}},Li=function(e,t,n,r){var a=e.memoizedProps;if(a!==r){e=t.stateNode,rl(el.current);var o,l=null;switch(n){case"input":a=Y(e,a),r=Y(e,r),l=[];break;case"select":a=M({},a,{value:void 0}),r=M({},r,{value:void 0}),l=[];break;case"textarea":a=re(e,a),r=re(e,r),l=[];break;default:"function"!==typeof a.onClick&&"function"===typeof r.onClick&&(e.onclick=Zr)}for(c in ge(n,r),n=null,a)if(!r.hasOwnProperty(c)&&a.hasOwnProperty(c)&&null!=a[c])if("style"===c){var s=a[c];for(o in s)s.hasOwnProperty(o)&&(n||(n={}),n[o]="")}else"dangerouslySetInnerHTML"!==c&&"children"!==c&&"suppressContentEditableWarning"!==c&&"suppressHydrationWarning"!==c&&"autoFocus"!==c&&(i.hasOwnProperty(c)?l||(l=[]):(l=l||[]).push(c,null));for(c in r){var u=r[c];if(s=null!=a?a[c]:void 0,r.hasOwnProperty(c)&&u!==s&&(null!=u||null!=s))if("style"===c)if(s){for(o in s)!s.hasOwnProperty(o)||u&&u.hasOwnProperty(o)||(n||(n={}),n[o]="");for(o in u)u.hasOwnProperty(o)&&s[o]!==u[o]&&(n||(n={}),n[o]=u[o])}else n||(l||(l=[]),l.push(c,n)),n=u;else"dangerouslySetInnerHTML"===c?(u=u?u.__html:void 0,s=s?s.__html:void 0,null!=u&&s!==u&&(l=l||[]).push(c,u)):"children"===c?"string"!==typeof u&&"number"!==typeof u||(l=l||[]).push(c,""+u):"suppressContentEditableWarning"!==c&&"suppressHydrationWarning"!==c&&(i.hasOwnProperty(c)?(null!=u&&"onScroll"===c&&Br("scroll",e),l||s===u||(l=[])):(l=l||[]).push(c,u))}n&&(l=l||[]).push("style",n);var c=l;(t.updateQueue=c)&&(t.flags|=4)}},zi=function(e,t,n,r){n!==r&&(t.flags|=4)};var Yi=!1,Gi=!1,Xi="function"===typeof WeakSet?WeakSet:Set,Ji=null;function Zi(e,t){var n=e.ref;if(null!==n)if("function"===typeof n)try{n(null)}catch(r){Eu(e,t,r)}else n.current=null}function es(e,t,n){try{n()}catch(r){Eu(e,t,r)}}var ts=!1;function ns(e,t,n){var r=t.updateQueue;if(null!==(r=null!==r?r.lastEffect:null)){var a=r=r.next;do{if((a.tag&e)===e){var o=a.destroy;a.destroy=void 0,void 0!==o&&es(t,n,o)}a=a.next}while(a!==r)}}function rs(e,t){if(null!==(t=null!==(t=t.updateQueue)?t.lastEffect:null)){var n=t=t.next;do{if((n.tag&e)===e){var r=n.create;n.destroy=r()}n=n.next}while(n!==t)}}function as(e){var t=e.ref;if(null!==t){var n=e.stateNode;e.tag,e=n,"function"===typeof t?t(e):t.current=e}}function os(e){var t=e.alternate;null!==t&&(e.alternate=null,os(t)),e.child=null,e.deletions=null,e.sibling=null,5===e.tag&&(null!==(t=e.stateNode)&&(delete t[fa],delete t[pa],delete t[va],delete t[ma],delete t[ya])),e.stateNode=null,e.return=null,e.dependencies=null,e.memoizedProps=null,e.memoizedState=null,e.pendingProps=null,e.stateNode=null,e.updateQueue=null}function ls(e){return 5===e.tag||3===e.tag||4===e.tag}function is(e){e:for(;;){for(;null===e.sibling;){if(null===e.return||ls(e.return))return null;e=e.return}for(e.sibling.return=e.return,e=e.sibling;5!==e.tag&&6!==e.tag&&18!==e.tag;){if(2&e.flags)continue e;if(null===e.child||4===e.tag)continue e;e.child.return=e,e=e.child}if(!(2&e.flags))return e.stateNode}}function ss(e,t,n){var r=e.tag;if(5===r||6===r)e=e.stateNode,t?8===n.nodeType?n.parentNode.insertBefore(e,t):n.insertBefore(e,t):(8===n.nodeType?(t=n.parentNode).insertBefore(e,n):(t=n).appendChild(e),null!==(n=n._reactRootContainer)&&void 0!==n||null!==t.onclick||(t.onclick=Zr));else if(4!==r&&null!==(e=e.child))for(ss(e,t,n),e=e.sibling;null!==e;)ss(e,t,n),e=e.sibling}function us(e,t,n){var r=e.tag;if(5===r||6===r)e=e.stateNode,t?n.insertBefore(e,t):n.appendChild(e);else if(4!==r&&null!==(e=e.child))for(us(e,t,n),e=e.sibling;null!==e;)us(e,t,n),e=e.sibling}var cs=null,ds=!1;function fs(e,t,n){for(n=n.child;null!==n;)ps(e,t,n),n=n.sibling}function ps(e,t,n){if(ot&&"function"===typeof ot.onCommitFiberUnmount)try{ot.onCommitFiberUnmount(at,n)}catch(i){}switch(n.tag){case 5:Gi||Zi(n,t);case 6:var r=cs,a=ds;cs=null,fs(e,t,n),ds=a,null!==(cs=r)&&(ds?(e=cs,n=n.stateNode,8===e.nodeType?e.parentNode.removeChild(n):e.removeChild(n)):cs.removeChild(n.stateNode));break;case 18:null!==cs&&(ds?(e=cs,n=n.stateNode,8===e.nodeType?sa(e.parentNode,n):1===e.nodeType&&sa(e,n),Ht(e)):sa(cs,n.stateNode));break;case 4:r=cs,a=ds,cs=n.stateNode.containerInfo,ds=!0,fs(e,t,n),cs=r,ds=a;break;case 0:case 11:case 14:case 15:if(!Gi&&(null!==(r=n.updateQueue)&&null!==(r=r.lastEffect))){a=r=r.next;do{var o=a,l=o.destroy;o=o.tag,void 0!==l&&(0!==(2&o)||0!==(4&o))&&es(n,t,l),a=a.next}while(a!==r)}fs(e,t,n);break;case 1:if(!Gi&&(Zi(n,t),"function"===typeof(r=n.stateNode).componentWillUnmount))try{r.props=n.memoizedProps,r.state=n.memoizedState,r.componentWillUnmount()}catch(i){Eu(n,t,i)}fs(e,t,n);break;case 21:fs(e,t,n);break;case 22:1&n.mode?(Gi=(r=Gi)||null!==n.memoizedState,fs(e,t,n),Gi=r):fs(e,t,n);break;default:fs(e,t,n)}}function hs(e){var t=e.updateQueue;if(null!==t){e.updateQueue=null;var n=e.stateNode;null===n&&(n=e.stateNode=new Xi),t.forEach((function(t){var r=Ru.bind(null,e,t);n.has(t)||(n.add(t),t.then(r,r))}))}}function vs(e,t){var n=t.deletions;if(null!==n)for(var r=0;r<n.length;r++){var a=n[r];try{var l=e,i=t,s=i;e:for(;null!==s;){switch(s.tag){case 5:cs=s.stateNode,ds=!1;break e;case 3:case 4:cs=s.stateNode.containerInfo,ds=!0;break e}s=s.return}if(null===cs)throw Error(o(160));ps(l,i,a),cs=null,ds=!1;var u=a.alternate;null!==u&&(u.return=null),a.return=null}catch(c){Eu(a,t,c)}}if(12854&t.subtreeFlags)for(t=t.child;null!==t;)ms(t,e),t=t.sibling}function ms(e,t){var n=e.alternate,r=e.flags;switch(e.tag){case 0:case 11:case 14:case 15:if(vs(t,e),ys(e),4&r){try{ns(3,e,e.return),rs(3,e)}catch(m){Eu(e,e.return,m)}try{ns(5,e,e.return)}catch(m){Eu(e,e.return,m)}}break;case 1:vs(t,e),ys(e),512&r&&null!==n&&Zi(n,n.return);break;case 5:if(vs(t,e),ys(e),512&r&&null!==n&&Zi(n,n.return),32&e.flags){var a=e.stateNode;try{fe(a,"")}catch(m){Eu(e,e.return,m)}}if(4&r&&null!=(a=e.stateNode)){var l=e.memoizedProps,i=null!==n?n.memoizedProps:l,s=e.type,u=e.updateQueue;if(e.updateQueue=null,null!==u)try{"input"===s&&"radio"===l.type&&null!=l.name&&X(a,l),be(s,i);var c=be(s,l);for(i=0;i<u.length;i+=2){var d=u[i],f=u[i+1];"style"===d?me(a,f):"dangerouslySetInnerHTML"===d?de(a,f):"children"===d?fe(a,f):b(a,d,f,c)}switch(s){case"input":J(a,l);break;case"textarea":oe(a,l);break;case"select":var p=a._wrapperState.wasMultiple;a._wrapperState.wasMultiple=!!l.multiple;var h=l.value;null!=h?ne(a,!!l.multiple,h,!1):p!==!!l.multiple&&(null!=l.defaultValue?ne(a,!!l.multiple,l.defaultValue,!0):ne(a,!!l.multiple,l.multiple?[]:"",!1))}a[pa]=l}catch(m){Eu(e,e.return,m)}}break;case 6:if(vs(t,e),ys(e),4&r){if(null===e.stateNode)throw Error(o(162));a=e.stateNode,l=e.memoizedProps;try{a.nodeValue=l}catch(m){Eu(e,e.return,m)}}break;case 3:if(vs(t,e),ys(e),4&r&&null!==n&&n.memoizedState.isDehydrated)try{Ht(t.containerInfo)}catch(m){Eu(e,e.return,m)}break;case 4:default:vs(t,e),ys(e);break;case 13:vs(t,e),ys(e),8192&(a=e.child).flags&&(l=null!==a.memoizedState,a.stateNode.isHidden=l,!l||null!==a.alternate&&null!==a.alternate.memoizedState||(Us=Xe())),4&r&&hs(e);break;case 22:if(d=null!==n&&null!==n.memoizedState,1&e.mode?(Gi=(c=Gi)||d,vs(t,e),Gi=c):vs(t,e),ys(e),8192&r){if(c=null!==e.memoizedState,(e.stateNode.isHidden=c)&&!d&&0!==(1&e.mode))for(Ji=e,d=e.child;null!==d;){for(f=Ji=d;null!==Ji;){switch(h=(p=Ji).child,p.tag){case 0:case 11:case 14:case 15:ns(4,p,p.return);break;case 1:Zi(p,p.return);var v=p.stateNode;if("function"===typeof v.componentWillUnmount){r=p,n=p.return;try{t=r,v.props=t.memoizedProps,v.state=t.memoizedState,v.componentWillUnmount()}catch(m){Eu(r,n,m)}}break;case 5:Zi(p,p.return);break;case 22:if(null!==p.memoizedState){xs(f);continue}}null!==h?(h.return=p,Ji=h):xs(f)}d=d.sibling}e:for(d=null,f=e;;){if(5===f.tag){if(null===d){d=f;try{a=f.stateNode,c?"function"===typeof(l=a.style).setProperty?l.setProperty("display","none","important"):l.display="none":(s=f.stateNode,i=void 0!==(u=f.memoizedProps.style)&&null!==u&&u.hasOwnProperty("display")?u.display:null,s.style.display=ve("display",i))}catch(m){Eu(e,e.return,m)}}}else if(6===f.tag){if(null===d)try{f.stateNode.nodeValue=c?"":f.memoizedProps}catch(m){Eu(e,e.return,m)}}else if((22!==f.tag&&23!==f.tag||null===f.memoizedState||f===e)&&null!==f.child){f.child.return=f,f=f.child;continue}if(f===e)break e;for(;null===f.sibling;){if(null===f.return||f.return===e)break e;d===f&&(d=null),f=f.return}d===f&&(d=null),f.sibling.return=f.return,f=f.sibling}}break;case 19:vs(t,e),ys(e),4&r&&hs(e);case 21:}}function ys(e){var t=e.flags;if(2&t){try{e:{for(var n=e.return;null!==n;){if(ls(n)){var r=n;break e}n=n.return}throw Error(o(160))}switch(r.tag){case 5:var a=r.stateNode;32&r.flags&&(fe(a,""),r.flags&=-33),us(e,is(e),a);break;case 3:case 4:var l=r.stateNode.containerInfo;ss(e,is(e),l);break;default:throw Error(o(161))}}catch(i){Eu(e,e.return,i)}e.flags&=-3}4096&t&&(e.flags&=-4097)}function gs(e,t,n){Ji=e,bs(e,t,n)}function bs(e,t,n){for(var r=0!==(1&e.mode);null!==Ji;){var a=Ji,o=a.child;if(22===a.tag&&r){var l=null!==a.memoizedState||Yi;if(!l){var i=a.alternate,s=null!==i&&null!==i.memoizedState||Gi;i=Yi;var u=Gi;if(Yi=l,(Gi=s)&&!u)for(Ji=a;null!==Ji;)s=(l=Ji).child,22===l.tag&&null!==l.memoizedState?ks(a):null!==s?(s.return=l,Ji=s):ks(a);for(;null!==o;)Ji=o,bs(o,t,n),o=o.sibling;Ji=a,Yi=i,Gi=u}ws(e)}else 0!==(8772&a.subtreeFlags)&&null!==o?(o.return=a,Ji=o):ws(e)}}function ws(e){for(;null!==Ji;){var t=Ji;if(0!==(8772&t.flags)){var n=t.alternate;try{if(0!==(8772&t.flags))switch(t.tag){case 0:case 11:case 15:Gi||rs(5,t);break;case 1:var r=t.stateNode;if(4&t.flags&&!Gi)if(null===n)r.componentDidMount();else{var a=t.elementType===t.type?n.memoizedProps:yo(t.type,n.memoizedProps);r.componentDidUpdate(a,n.memoizedState,r.__reactInternalSnapshotBeforeUpdate)}var l=t.updateQueue;null!==l&&Bo(t,l,r);break;case 3:var i=t.updateQueue;if(null!==i){if(n=null,null!==t.child)switch(t.child.tag){case 5:case 1:n=t.child.stateNode}Bo(t,i,n)}break;case 5:var s=t.stateNode;if(null===n&&4&t.flags){n=s;var u=t.memoizedProps;switch(t.type){case"button":case"input":case"select":case"textarea":u.autoFocus&&n.focus();break;case"img":u.src&&(n.src=u.src)}}break;case 6:case 4:case 12:case 19:case 17:case 21:case 22:case 23:case 25:break;case 13:if(null===t.memoizedState){var c=t.alternate;if(null!==c){var d=c.memoizedState;if(null!==d){var f=d.dehydrated;null!==f&&Ht(f)}}}break;default:throw Error(o(163))}Gi||512&t.flags&&as(t)}catch(p){Eu(t,t.return,p)}}if(t===e){Ji=null;break}if(null!==(n=t.sibling)){n.return=t.return,Ji=n;break}Ji=t.return}}function xs(e){for(;null!==Ji;){var t=Ji;if(t===e){Ji=null;break}var n=t.sibling;if(null!==n){n.return=t.return,Ji=n;break}Ji=t.return}}function ks(e){for(;null!==Ji;){var t=Ji;try{switch(t.tag){case 0:case 11:case 15:var n=t.return;try{rs(4,t)}catch(s){Eu(t,n,s)}break;case 1:var r=t.stateNode;if("function"===typeof r.componentDidMount){var a=t.return;try{r.componentDidMount()}catch(s){Eu(t,a,s)}}var o=t.return;try{as(t)}catch(s){Eu(t,o,s)}break;case 5:var l=t.return;try{as(t)}catch(s){Eu(t,l,s)}}}catch(s){Eu(t,t.return,s)}if(t===e){Ji=null;break}var i=t.sibling;if(null!==i){i.return=t.return,Ji=i;break}Ji=t.return}}var Ss,Es=Math.ceil,Cs=w.ReactCurrentDispatcher,Ns=w.ReactCurrentOwner,_s=w.ReactCurrentBatchConfig,Rs=0,js=null,Ps=null,Ts=0,Os=0,Ls=Ea(0),zs=0,Ds=null,Fs=0,Ms=0,Is=0,Bs=null,As=null,Us=0,Hs=1/0,qs=null,Vs=!1,Ws=null,$s=null,Qs=!1,Ks=null,Ys=0,Gs=0,Xs=null,Js=-1,Zs=0;function eu(){return 0!==(6&Rs)?Xe():-1!==Js?Js:Js=Xe()}function tu(e){return 0===(1&e.mode)?1:0!==(2&Rs)&&0!==Ts?Ts&-Ts:null!==mo.transition?(0===Zs&&(Zs=vt()),Zs):0!==(e=bt)?e:e=void 0===(e=window.event)?16:Gt(e.type)}function nu(e,t,n,r){if(50<Gs)throw Gs=0,Xs=null,Error(o(185));yt(e,n,r),0!==(2&Rs)&&e===js||(e===js&&(0===(2&Rs)&&(Ms|=n),4===zs&&iu(e,Ts)),ru(e,r),1===n&&0===Rs&&0===(1&t.mode)&&(Hs=Xe()+500,Ba&&Ha()))}function ru(e,t){var n=e.callbackNode;!function(e,t){for(var n=e.suspendedLanes,r=e.pingedLanes,a=e.expirationTimes,o=e.pendingLanes;0<o;){var l=31-lt(o),i=1<<l,s=a[l];-1===s?0!==(i&n)&&0===(i&r)||(a[l]=pt(i,t)):s<=t&&(e.expiredLanes|=i),o&=~i}}(e,t);var r=ft(e,e===js?Ts:0);if(0===r)null!==n&&Ke(n),e.callbackNode=null,e.callbackPriority=0;else if(t=r&-r,e.callbackPriority!==t){if(null!=n&&Ke(n),1===t)0===e.tag?function(e){Ba=!0,Ua(e)}(su.bind(null,e)):Ua(su.bind(null,e)),la((function(){0===(6&Rs)&&Ha()})),n=null;else{switch(wt(r)){case 1:n=Ze;break;case 4:n=et;break;case 16:default:n=tt;break;case 536870912:n=rt}n=ju(n,au.bind(null,e))}e.callbackPriority=t,e.callbackNode=n}}function au(e,t){if(Js=-1,Zs=0,0!==(6&Rs))throw Error(o(327));var n=e.callbackNode;if(ku()&&e.callbackNode!==n)return null;var r=ft(e,e===js?Ts:0);if(0===r)return null;if(0!==(30&r)||0!==(r&e.expiredLanes)||t)t=mu(e,r);else{t=r;var a=Rs;Rs|=2;var l=hu();for(js===e&&Ts===t||(qs=null,Hs=Xe()+500,fu(e,t));;)try{gu();break}catch(s){pu(e,s)}ko(),Cs.current=l,Rs=a,null!==Ps?t=0:(js=null,Ts=0,t=zs)}if(0!==t){if(2===t&&(0!==(a=ht(e))&&(r=a,t=ou(e,a))),1===t)throw n=Ds,fu(e,0),iu(e,r),ru(e,Xe()),n;if(6===t)iu(e,r);else{if(a=e.current.alternate,0===(30&r)&&!function(e){for(var t=e;;){if(16384&t.flags){var n=t.updateQueue;if(null!==n&&null!==(n=n.stores))for(var r=0;r<n.length;r++){var a=n[r],o=a.getSnapshot;a=a.value;try{if(!ir(o(),a))return!1}catch(i){return!1}}}if(n=t.child,16384&t.subtreeFlags&&null!==n)n.return=t,t=n;else{if(t===e)break;for(;null===t.sibling;){if(null===t.return||t.return===e)return!0;t=t.return}t.sibling.return=t.return,t=t.sibling}}return!0}(a)&&(2===(t=mu(e,r))&&(0!==(l=ht(e))&&(r=l,t=ou(e,l))),1===t))throw n=Ds,fu(e,0),iu(e,r),ru(e,Xe()),n;switch(e.finishedWork=a,e.finishedLanes=r,t){case 0:case 1:throw Error(o(345));case 2:case 5:xu(e,As,qs);break;case 3:if(iu(e,r),(130023424&r)===r&&10<(t=Us+500-Xe())){if(0!==ft(e,0))break;if(((a=e.suspendedLanes)&r)!==r){eu(),e.pingedLanes|=e.suspendedLanes&a;break}e.timeoutHandle=ra(xu.bind(null,e,As,qs),t);break}xu(e,As,qs);break;case 4:if(iu(e,r),(4194240&r)===r)break;for(t=e.eventTimes,a=-1;0<r;){var i=31-lt(r);l=1<<i,(i=t[i])>a&&(a=i),r&=~l}if(r=a,10<(r=(120>(r=Xe()-r)?120:480>r?480:1080>r?1080:1920>r?1920:3e3>r?3e3:4320>r?4320:1960*Es(r/1960))-r)){e.timeoutHandle=ra(xu.bind(null,e,As,qs),r);break}xu(e,As,qs);break;default:throw Error(o(329))}}}return ru(e,Xe()),e.callbackNode===n?au.bind(null,e):null}function ou(e,t){var n=Bs;return e.current.memoizedState.isDehydrated&&(fu(e,t).flags|=256),2!==(e=mu(e,t))&&(t=As,As=n,null!==t&&lu(t)),e}function lu(e){null===As?As=e:As.push.apply(As,e)}function iu(e,t){for(t&=~Is,t&=~Ms,e.suspendedLanes|=t,e.pingedLanes&=~t,e=e.expirationTimes;0<t;){var n=31-lt(t),r=1<<n;e[n]=-1,t&=~r}}function su(e){if(0!==(6&Rs))throw Error(o(327));ku();var t=ft(e,0);if(0===(1&t))return ru(e,Xe()),null;var n=mu(e,t);if(0!==e.tag&&2===n){var r=ht(e);0!==r&&(t=r,n=ou(e,r))}if(1===n)throw n=Ds,fu(e,0),iu(e,t),ru(e,Xe()),n;if(6===n)throw Error(o(345));return e.finishedWork=e.current.alternate,e.finishedLanes=t,xu(e,As,qs),ru(e,Xe()),null}function uu(e,t){var n=Rs;Rs|=1;try{return e(t)}finally{0===(Rs=n)&&(Hs=Xe()+500,Ba&&Ha())}}function cu(e){null!==Ks&&0===Ks.tag&&0===(6&Rs)&&ku();var t=Rs;Rs|=1;var n=_s.transition,r=bt;try{if(_s.transition=null,bt=1,e)return e()}finally{bt=r,_s.transition=n,0===(6&(Rs=t))&&Ha()}}function du(){Os=Ls.current,Ca(Ls)}function fu(e,t){e.finishedWork=null,e.finishedLanes=0;var n=e.timeoutHandle;if(-1!==n&&(e.timeoutHandle=-1,aa(n)),null!==Ps)for(n=Ps.return;null!==n;){var r=n;switch(to(r),r.tag){case 1:null!==(r=r.type.childContextTypes)&&void 0!==r&&La();break;case 3:ol(),Ca(ja),Ca(Ra),dl();break;case 5:il(r);break;case 4:ol();break;case 13:case 19:Ca(sl);break;case 10:So(r.type._context);break;case 22:case 23:du()}n=n.return}if(js=e,Ps=e=Lu(e.current,null),Ts=Os=t,zs=0,Ds=null,Is=Ms=Fs=0,As=Bs=null,null!==_o){for(t=0;t<_o.length;t++)if(null!==(r=(n=_o[t]).interleaved)){n.interleaved=null;var a=r.next,o=n.pending;if(null!==o){var l=o.next;o.next=a,r.next=l}n.pending=r}_o=null}return e}function pu(e,t){for(;;){var n=Ps;try{if(ko(),fl.current=li,gl){for(var r=vl.memoizedState;null!==r;){var a=r.queue;null!==a&&(a.pending=null),r=r.next}gl=!1}if(hl=0,yl=ml=vl=null,bl=!1,wl=0,Ns.current=null,null===n||null===n.return){zs=1,Ds=t,Ps=null;break}e:{var l=e,i=n.return,s=n,u=t;if(t=Ts,s.flags|=32768,null!==u&&"object"===typeof u&&"function"===typeof u.then){var c=u,d=s,f=d.tag;if(0===(1&d.mode)&&(0===f||11===f||15===f)){var p=d.alternate;p?(d.updateQueue=p.updateQueue,d.memoizedState=p.memoizedState,d.lanes=p.lanes):(d.updateQueue=null,d.memoizedState=null)}var h=yi(i);if(null!==h){h.flags&=-257,gi(h,i,s,0,t),1&h.mode&&mi(l,c,t),u=c;var v=(t=h).updateQueue;if(null===v){var m=new Set;m.add(u),t.updateQueue=m}else v.add(u);break e}if(0===(1&t)){mi(l,c,t),vu();break e}u=Error(o(426))}else if(ao&&1&s.mode){var y=yi(i);if(null!==y){0===(65536&y.flags)&&(y.flags|=256),gi(y,i,s,0,t),vo(ci(u,s));break e}}l=u=ci(u,s),4!==zs&&(zs=2),null===Bs?Bs=[l]:Bs.push(l),l=i;do{switch(l.tag){case 3:l.flags|=65536,t&=-t,l.lanes|=t,Mo(l,hi(0,u,t));break e;case 1:s=u;var g=l.type,b=l.stateNode;if(0===(128&l.flags)&&("function"===typeof g.getDerivedStateFromError||null!==b&&"function"===typeof b.componentDidCatch&&(null===$s||!$s.has(b)))){l.flags|=65536,t&=-t,l.lanes|=t,Mo(l,vi(l,s,t));break e}}l=l.return}while(null!==l)}wu(n)}catch(w){t=w,Ps===n&&null!==n&&(Ps=n=n.return);continue}break}}function hu(){var e=Cs.current;return Cs.current=li,null===e?li:e}function vu(){0!==zs&&3!==zs&&2!==zs||(zs=4),null===js||0===(268435455&Fs)&&0===(268435455&Ms)||iu(js,Ts)}function mu(e,t){var n=Rs;Rs|=2;var r=hu();for(js===e&&Ts===t||(qs=null,fu(e,t));;)try{yu();break}catch(a){pu(e,a)}if(ko(),Rs=n,Cs.current=r,null!==Ps)throw Error(o(261));return js=null,Ts=0,zs}function yu(){for(;null!==Ps;)bu(Ps)}function gu(){for(;null!==Ps&&!Ye();)bu(Ps)}function bu(e){var t=Ss(e.alternate,e,Os);e.memoizedProps=e.pendingProps,null===t?wu(e):Ps=t,Ns.current=null}function wu(e){var t=e;do{var n=t.alternate;if(e=t.return,0===(32768&t.flags)){if(null!==(n=Qi(n,t,Os)))return void(Ps=n)}else{if(null!==(n=Ki(n,t)))return n.flags&=32767,void(Ps=n);if(null===e)return zs=6,void(Ps=null);e.flags|=32768,e.subtreeFlags=0,e.deletions=null}if(null!==(t=t.sibling))return void(Ps=t);Ps=t=e}while(null!==t);0===zs&&(zs=5)}function xu(e,t,n){var r=bt,a=_s.transition;try{_s.transition=null,bt=1,function(e,t,n,r){do{ku()}while(null!==Ks);if(0!==(6&Rs))throw Error(o(327));n=e.finishedWork;var a=e.finishedLanes;if(null===n)return null;if(e.finishedWork=null,e.finishedLanes=0,n===e.current)throw Error(o(177));e.callbackNode=null,e.callbackPriority=0;var l=n.lanes|n.childLanes;if(function(e,t){var n=e.pendingLanes&~t;e.pendingLanes=t,e.suspendedLanes=0,e.pingedLanes=0,e.expiredLanes&=t,e.mutableReadLanes&=t,e.entangledLanes&=t,t=e.entanglements;var r=e.eventTimes;for(e=e.expirationTimes;0<n;){var a=31-lt(n),o=1<<a;t[a]=0,r[a]=-1,e[a]=-1,n&=~o}}(e,l),e===js&&(Ps=js=null,Ts=0),0===(2064&n.subtreeFlags)&&0===(2064&n.flags)||Qs||(Qs=!0,ju(tt,(function(){return ku(),null}))),l=0!==(15990&n.flags),0!==(15990&n.subtreeFlags)||l){l=_s.transition,_s.transition=null;var i=bt;bt=1;var s=Rs;Rs|=4,Ns.current=null,function(e,t){if(ea=Vt,pr(e=fr())){if("selectionStart"in e)var n={start:e.selectionStart,end:e.selectionEnd};else e:{var r=(n=(n=e.ownerDocument)&&n.defaultView||window).getSelection&&n.getSelection();if(r&&0!==r.rangeCount){n=r.anchorNode;var a=r.anchorOffset,l=r.focusNode;r=r.focusOffset;try{n.nodeType,l.nodeType}catch(x){n=null;break e}var i=0,s=-1,u=-1,c=0,d=0,f=e,p=null;t:for(;;){for(var h;f!==n||0!==a&&3!==f.nodeType||(s=i+a),f!==l||0!==r&&3!==f.nodeType||(u=i+r),3===f.nodeType&&(i+=f.nodeValue.length),null!==(h=f.firstChild);)p=f,f=h;for(;;){if(f===e)break t;if(p===n&&++c===a&&(s=i),p===l&&++d===r&&(u=i),null!==(h=f.nextSibling))break;p=(f=p).parentNode}f=h}n=-1===s||-1===u?null:{start:s,end:u}}else n=null}n=n||{start:0,end:0}}else n=null;for(ta={focusedElem:e,selectionRange:n},Vt=!1,Ji=t;null!==Ji;)if(e=(t=Ji).child,0!==(1028&t.subtreeFlags)&&null!==e)e.return=t,Ji=e;else for(;null!==Ji;){t=Ji;try{var v=t.alternate;if(0!==(1024&t.flags))switch(t.tag){case 0:case 11:case 15:case 5:case 6:case 4:case 17:break;case 1:if(null!==v){var m=v.memoizedProps,y=v.memoizedState,g=t.stateNode,b=g.getSnapshotBeforeUpdate(t.elementType===t.type?m:yo(t.type,m),y);g.__reactInternalSnapshotBeforeUpdate=b}break;case 3:var w=t.stateNode.containerInfo;1===w.nodeType?w.textContent="":9===w.nodeType&&w.documentElement&&w.removeChild(w.documentElement);break;default:throw Error(o(163))}}catch(x){Eu(t,t.return,x)}if(null!==(e=t.sibling)){e.return=t.return,Ji=e;break}Ji=t.return}v=ts,ts=!1}(e,n),ms(n,e),hr(ta),Vt=!!ea,ta=ea=null,e.current=n,gs(n,e,a),Ge(),Rs=s,bt=i,_s.transition=l}else e.current=n;if(Qs&&(Qs=!1,Ks=e,Ys=a),l=e.pendingLanes,0===l&&($s=null),function(e){if(ot&&"function"===typeof ot.onCommitFiberRoot)try{ot.onCommitFiberRoot(at,e,void 0,128===(128&e.current.flags))}catch(t){}}(n.stateNode),ru(e,Xe()),null!==t)for(r=e.onRecoverableError,n=0;n<t.length;n++)r((a=t[n]).value,{componentStack:a.stack,digest:a.digest});if(Vs)throw Vs=!1,e=Ws,Ws=null,e;0!==(1&Ys)&&0!==e.tag&&ku(),l=e.pendingLanes,0!==(1&l)?e===Xs?Gs++:(Gs=0,Xs=e):Gs=0,Ha()}(e,t,n,r)}finally{_s.transition=a,bt=r}return null}function ku(){if(null!==Ks){var e=wt(Ys),t=_s.transition,n=bt;try{if(_s.transition=null,bt=16>e?16:e,null===Ks)var r=!1;else{if(e=Ks,Ks=null,Ys=0,0!==(6&Rs))throw Error(o(331));var a=Rs;for(Rs|=4,Ji=e.current;null!==Ji;){var l=Ji,i=l.child;if(0!==(16&Ji.flags)){var s=l.deletions;if(null!==s){for(var u=0;u<s.length;u++){var c=s[u];for(Ji=c;null!==Ji;){var d=Ji;switch(d.tag){case 0:case 11:case 15:ns(8,d,l)}var f=d.child;if(null!==f)f.return=d,Ji=f;else for(;null!==Ji;){var p=(d=Ji).sibling,h=d.return;if(os(d),d===c){Ji=null;break}if(null!==p){p.return=h,Ji=p;break}Ji=h}}}var v=l.alternate;if(null!==v){var m=v.child;if(null!==m){v.child=null;do{var y=m.sibling;m.sibling=null,m=y}while(null!==m)}}Ji=l}}if(0!==(2064&l.subtreeFlags)&&null!==i)i.return=l,Ji=i;else e:for(;null!==Ji;){if(0!==(2048&(l=Ji).flags))switch(l.tag){case 0:case 11:case 15:ns(9,l,l.return)}var g=l.sibling;if(null!==g){g.return=l.return,Ji=g;break e}Ji=l.return}}var b=e.current;for(Ji=b;null!==Ji;){var w=(i=Ji).child;if(0!==(2064&i.subtreeFlags)&&null!==w)w.return=i,Ji=w;else e:for(i=b;null!==Ji;){if(0!==(2048&(s=Ji).flags))try{switch(s.tag){case 0:case 11:case 15:rs(9,s)}}catch(k){Eu(s,s.return,k)}if(s===i){Ji=null;break e}var x=s.sibling;if(null!==x){x.return=s.return,Ji=x;break e}Ji=s.return}}if(Rs=a,Ha(),ot&&"function"===typeof ot.onPostCommitFiberRoot)try{ot.onPostCommitFiberRoot(at,e)}catch(k){}r=!0}return r}finally{bt=n,_s.transition=t}}return!1}function Su(e,t,n){e=Do(e,t=hi(0,t=ci(n,t),1),1),t=eu(),null!==e&&(yt(e,1,t),ru(e,t))}function Eu(e,t,n){if(3===e.tag)Su(e,e,n);else for(;null!==t;){if(3===t.tag){Su(t,e,n);break}if(1===t.tag){var r=t.stateNode;if("function"===typeof t.type.getDerivedStateFromError||"function"===typeof r.componentDidCatch&&(null===$s||!$s.has(r))){t=Do(t,e=vi(t,e=ci(n,e),1),1),e=eu(),null!==t&&(yt(t,1,e),ru(t,e));break}}t=t.return}}function Cu(e,t,n){var r=e.pingCache;null!==r&&r.delete(t),t=eu(),e.pingedLanes|=e.suspendedLanes&n,js===e&&(Ts&n)===n&&(4===zs||3===zs&&(130023424&Ts)===Ts&&500>Xe()-Us?fu(e,0):Is|=n),ru(e,t)}function Nu(e,t){0===t&&(0===(1&e.mode)?t=1:(t=ct,0===(130023424&(ct<<=1))&&(ct=4194304)));var n=eu();null!==(e=Po(e,t))&&(yt(e,t,n),ru(e,n))}function _u(e){var t=e.memoizedState,n=0;null!==t&&(n=t.retryLane),Nu(e,n)}function Ru(e,t){var n=0;switch(e.tag){case 13:var r=e.stateNode,a=e.memoizedState;null!==a&&(n=a.retryLane);break;case 19:r=e.stateNode;break;default:throw Error(o(314))}null!==r&&r.delete(t),Nu(e,n)}function ju(e,t){return Qe(e,t)}function Pu(e,t,n,r){this.tag=e,this.key=n,this.sibling=this.child=this.return=this.stateNode=this.type=this.elementType=null,this.index=0,this.ref=null,this.pendingProps=t,this.dependencies=this.memoizedState=this.updateQueue=this.memoizedProps=null,this.mode=r,this.subtreeFlags=this.flags=0,this.deletions=null,this.childLanes=this.lanes=0,this.alternate=null}function Tu(e,t,n,r){return new Pu(e,t,n,r)}function Ou(e){return!(!(e=e.prototype)||!e.isReactComponent)}function Lu(e,t){var n=e.alternate;return null===n?((n=Tu(e.tag,t,e.key,e.mode)).elementType=e.elementType,n.type=e.type,n.stateNode=e.stateNode,n.alternate=e,e.alternate=n):(n.pendingProps=t,n.type=e.type,n.flags=0,n.subtreeFlags=0,n.deletions=null),n.flags=14680064&e.flags,n.childLanes=e.childLanes,n.lanes=e.lanes,n.child=e.child,n.memoizedProps=e.memoizedProps,n.memoizedState=e.memoizedState,n.updateQueue=e.updateQueue,t=e.dependencies,n.dependencies=null===t?null:{lanes:t.lanes,firstContext:t.firstContext},n.sibling=e.sibling,n.index=e.index,n.ref=e.ref,n}function zu(e,t,n,r,a,l){var i=2;if(r=e,"function"===typeof e)Ou(e)&&(i=1);else if("string"===typeof e)i=5;else e:switch(e){case S:return Du(n.children,a,l,t);case E:i=8,a|=8;break;case C:return(e=Tu(12,n,t,2|a)).elementType=C,e.lanes=l,e;case j:return(e=Tu(13,n,t,a)).elementType=j,e.lanes=l,e;case P:return(e=Tu(19,n,t,a)).elementType=P,e.lanes=l,e;case L:return Fu(n,a,l,t);default:if("object"===typeof e&&null!==e)switch(e.$$typeof){case N:i=10;break e;case _:i=9;break e;case R:i=11;break e;case T:i=14;break e;case O:i=16,r=null;break e}throw Error(o(130,null==e?e:typeof e,""))}return(t=Tu(i,n,t,a)).elementType=e,t.type=r,t.lanes=l,t}function Du(e,t,n,r){return(e=Tu(7,e,r,t)).lanes=n,e}function Fu(e,t,n,r){return(e=Tu(22,e,r,t)).elementType=L,e.lanes=n,e.stateNode={isHidden:!1},e}function Mu(e,t,n){return(e=Tu(6,e,null,t)).lanes=n,e}function Iu(e,t,n){return(t=Tu(4,null!==e.children?e.children:[],e.key,t)).lanes=n,t.stateNode={containerInfo:e.containerInfo,pendingChildren:null,implementation:e.implementation},t}function Bu(e,t,n,r,a){this.tag=t,this.containerInfo=e,this.finishedWork=this.pingCache=this.current=this.pendingChildren=null,this.timeoutHandle=-1,this.callbackNode=this.pendingContext=this.context=null,this.callbackPriority=0,this.eventTimes=mt(0),this.expirationTimes=mt(-1),this.entangledLanes=this.finishedLanes=this.mutableReadLanes=this.expiredLanes=this.pingedLanes=this.suspendedLanes=this.pendingLanes=0,this.entanglements=mt(0),this.identifierPrefix=r,this.onRecoverableError=a,this.mutableSourceEagerHydrationData=null}function Au(e,t,n,r,a,o,l,i,s){return e=new Bu(e,t,n,i,s),1===t?(t=1,!0===o&&(t|=8)):t=0,o=Tu(3,null,null,t),e.current=o,o.stateNode=e,o.memoizedState={element:r,isDehydrated:n,cache:null,transitions:null,pendingSuspenseBoundaries:null},Oo(o),e}function Uu(e,t,n){var r=3<arguments.length&&void 0!==arguments[3]?arguments[3]:null;return{$$typeof:k,key:null==r?null:""+r,children:e,containerInfo:t,implementation:n}}function Hu(e){if(!e)return _a;e:{if(He(e=e._reactInternals)!==e||1!==e.tag)throw Error(o(170));var t=e;do{switch(t.tag){case 3:t=t.stateNode.context;break e;case 1:if(Oa(t.type)){t=t.stateNode.__reactInternalMemoizedMergedChildContext;break e}}t=t.return}while(null!==t);throw Error(o(171))}if(1===e.tag){var n=e.type;if(Oa(n))return Da(e,n,t)}return t}function qu(e,t,n,r,a,o,l,i,s){return(e=Au(n,r,!0,e,0,o,0,i,s)).context=Hu(null),n=e.current,(o=zo(r=eu(),a=tu(n))).callback=void 0!==t&&null!==t?t:null,Do(n,o,a),e.current.lanes=a,yt(e,a,r),ru(e,r),e}function Vu(e,t,n,r){var a=t.current,o=eu(),l=tu(a);return n=Hu(n),null===t.context?t.context=n:t.pendingContext=n,(t=zo(o,l)).payload={element:e},null!==(r=void 0===r?null:r)&&(t.callback=r),null!==(e=Do(a,t,l))&&(nu(e,a,l,o),Fo(e,a,l)),l}function Wu(e){return(e=e.current).child?(e.child.tag,e.child.stateNode):null}function $u(e,t){if(null!==(e=e.memoizedState)&&null!==e.dehydrated){var n=e.retryLane;e.retryLane=0!==n&&n<t?n:t}}function Qu(e,t){$u(e,t),(e=e.alternate)&&$u(e,t)}Ss=function(e,t,n){if(null!==e)if(e.memoizedProps!==t.pendingProps||ja.current)wi=!0;else{if(0===(e.lanes&n)&&0===(128&t.flags))return wi=!1,function(e,t,n){switch(t.tag){case 3:Pi(t),ho();break;case 5:ll(t);break;case 1:Oa(t.type)&&Fa(t);break;case 4:al(t,t.stateNode.containerInfo);break;case 10:var r=t.type._context,a=t.memoizedProps.value;Na(go,r._currentValue),r._currentValue=a;break;case 13:if(null!==(r=t.memoizedState))return null!==r.dehydrated?(Na(sl,1&sl.current),t.flags|=128,null):0!==(n&t.child.childLanes)?Mi(e,t,n):(Na(sl,1&sl.current),null!==(e=Vi(e,t,n))?e.sibling:null);Na(sl,1&sl.current);break;case 19:if(r=0!==(n&t.childLanes),0!==(128&e.flags)){if(r)return Hi(e,t,n);t.flags|=128}if(null!==(a=t.memoizedState)&&(a.rendering=null,a.tail=null,a.lastEffect=null),Na(sl,sl.current),r)break;return null;case 22:case 23:return t.lanes=0,Ci(e,t,n)}return Vi(e,t,n)}(e,t,n);wi=0!==(131072&e.flags)}else wi=!1,ao&&0!==(1048576&t.flags)&&Za(t,$a,t.index);switch(t.lanes=0,t.tag){case 2:var r=t.type;qi(e,t),e=t.pendingProps;var a=Ta(t,Ra.current);Co(t,n),a=El(null,t,r,e,a,n);var l=Cl();return t.flags|=1,"object"===typeof a&&null!==a&&"function"===typeof a.render&&void 0===a.$$typeof?(t.tag=1,t.memoizedState=null,t.updateQueue=null,Oa(r)?(l=!0,Fa(t)):l=!1,t.memoizedState=null!==a.state&&void 0!==a.state?a.state:null,Oo(t),a.updater=Ho,t.stateNode=a,a._reactInternals=t,$o(t,r,e,n),t=ji(null,t,r,!0,l,n)):(t.tag=0,ao&&l&&eo(t),xi(null,t,a,n),t=t.child),t;case 16:r=t.elementType;e:{switch(qi(e,t),e=t.pendingProps,r=(a=r._init)(r._payload),t.type=r,a=t.tag=function(e){if("function"===typeof e)return Ou(e)?1:0;if(void 0!==e&&null!==e){if((e=e.$$typeof)===R)return 11;if(e===T)return 14}return 2}(r),e=yo(r,e),a){case 0:t=_i(null,t,r,e,n);break e;case 1:t=Ri(null,t,r,e,n);break e;case 11:t=ki(null,t,r,e,n);break e;case 14:t=Si(null,t,r,yo(r.type,e),n);break e}throw Error(o(306,r,""))}return t;case 0:return r=t.type,a=t.pendingProps,_i(e,t,r,a=t.elementType===r?a:yo(r,a),n);case 1:return r=t.type,a=t.pendingProps,Ri(e,t,r,a=t.elementType===r?a:yo(r,a),n);case 3:e:{if(Pi(t),null===e)throw Error(o(387));r=t.pendingProps,a=(l=t.memoizedState).element,Lo(e,t),Io(t,r,null,n);var i=t.memoizedState;if(r=i.element,l.isDehydrated){if(l={element:r,isDehydrated:!1,cache:i.cache,pendingSuspenseBoundaries:i.pendingSuspenseBoundaries,transitions:i.transitions},t.updateQueue.baseState=l,t.memoizedState=l,256&t.flags){t=Ti(e,t,r,n,a=ci(Error(o(423)),t));break e}if(r!==a){t=Ti(e,t,r,n,a=ci(Error(o(424)),t));break e}for(ro=ua(t.stateNode.containerInfo.firstChild),no=t,ao=!0,oo=null,n=Jo(t,null,r,n),t.child=n;n;)n.flags=-3&n.flags|4096,n=n.sibling}else{if(ho(),r===a){t=Vi(e,t,n);break e}xi(e,t,r,n)}t=t.child}return t;case 5:return ll(t),null===e&&uo(t),r=t.type,a=t.pendingProps,l=null!==e?e.memoizedProps:null,i=a.children,na(r,a)?i=null:null!==l&&na(r,l)&&(t.flags|=32),Ni(e,t),xi(e,t,i,n),t.child;case 6:return null===e&&uo(t),null;case 13:return Mi(e,t,n);case 4:return al(t,t.stateNode.containerInfo),r=t.pendingProps,null===e?t.child=Xo(t,null,r,n):xi(e,t,r,n),t.child;case 11:return r=t.type,a=t.pendingProps,ki(e,t,r,a=t.elementType===r?a:yo(r,a),n);case 7:return xi(e,t,t.pendingProps,n),t.child;case 8:case 12:return xi(e,t,t.pendingProps.children,n),t.child;case 10:e:{if(r=t.type._context,a=t.pendingProps,l=t.memoizedProps,i=a.value,Na(go,r._currentValue),r._currentValue=i,null!==l)if(ir(l.value,i)){if(l.children===a.children&&!ja.current){t=Vi(e,t,n);break e}}else for(null!==(l=t.child)&&(l.return=t);null!==l;){var s=l.dependencies;if(null!==s){i=l.child;for(var u=s.firstContext;null!==u;){if(u.context===r){if(1===l.tag){(u=zo(-1,n&-n)).tag=2;var c=l.updateQueue;if(null!==c){var d=(c=c.shared).pending;null===d?u.next=u:(u.next=d.next,d.next=u),c.pending=u}}l.lanes|=n,null!==(u=l.alternate)&&(u.lanes|=n),Eo(l.return,n,t),s.lanes|=n;break}u=u.next}}else if(10===l.tag)i=l.type===t.type?null:l.child;else if(18===l.tag){if(null===(i=l.return))throw Error(o(341));i.lanes|=n,null!==(s=i.alternate)&&(s.lanes|=n),Eo(i,n,t),i=l.sibling}else i=l.child;if(null!==i)i.return=l;else for(i=l;null!==i;){if(i===t){i=null;break}if(null!==(l=i.sibling)){l.return=i.return,i=l;break}i=i.return}l=i}xi(e,t,a.children,n),t=t.child}return t;case 9:return a=t.type,r=t.pendingProps.children,Co(t,n),r=r(a=No(a)),t.flags|=1,xi(e,t,r,n),t.child;case 14:return a=yo(r=t.type,t.pendingProps),Si(e,t,r,a=yo(r.type,a),n);case 15:return Ei(e,t,t.type,t.pendingProps,n);case 17:return r=t.type,a=t.pendingProps,a=t.elementType===r?a:yo(r,a),qi(e,t),t.tag=1,Oa(r)?(e=!0,Fa(t)):e=!1,Co(t,n),Vo(t,r,a),$o(t,r,a,n),ji(null,t,r,!0,e,n);case 19:return Hi(e,t,n);case 22:return Ci(e,t,n)}throw Error(o(156,t.tag))};var Ku="function"===typeof reportError?reportError:function(e){console.error(e)};function Yu(e){this._internalRoot=e}function Gu(e){this._internalRoot=e}function Xu(e){return!(!e||1!==e.nodeType&&9!==e.nodeType&&11!==e.nodeType)}function Ju(e){return!(!e||1!==e.nodeType&&9!==e.nodeType&&11!==e.nodeType&&(8!==e.nodeType||" react-mount-point-unstable "!==e.nodeValue))}function Zu(){}function ec(e,t,n,r,a){var o=n._reactRootContainer;if(o){var l=o;if("function"===typeof a){var i=a;a=function(){var e=Wu(l);i.call(e)}}Vu(t,l,e,a)}else l=function(e,t,n,r,a){if(a){if("function"===typeof r){var o=r;r=function(){var e=Wu(l);o.call(e)}}var l=qu(t,r,e,0,null,!1,0,"",Zu);return e._reactRootContainer=l,e[ha]=l.current,Hr(8===e.nodeType?e.parentNode:e),cu(),l}for(;a=e.lastChild;)e.removeChild(a);if("function"===typeof r){var i=r;r=function(){var e=Wu(s);i.call(e)}}var s=Au(e,0,!1,null,0,!1,0,"",Zu);return e._reactRootContainer=s,e[ha]=s.current,Hr(8===e.nodeType?e.parentNode:e),cu((function(){Vu(t,s,n,r)})),s}(n,t,e,a,r);return Wu(l)}Gu.prototype.render=Yu.prototype.render=function(e){var t=this._internalRoot;if(null===t)throw Error(o(409));Vu(e,t,null,null)},Gu.prototype.unmount=Yu.prototype.unmount=function(){var e=this._internalRoot;if(null!==e){this._internalRoot=null;var t=e.containerInfo;cu((function(){Vu(null,e,null,null)})),t[ha]=null}},Gu.prototype.unstable_scheduleHydration=function(e){if(e){var t=Et();e={blockedOn:null,target:e,priority:t};for(var n=0;n<Lt.length&&0!==t&&t<Lt[n].priority;n++);Lt.splice(n,0,e),0===n&&Mt(e)}},xt=function(e){switch(e.tag){case 3:var t=e.stateNode;if(t.current.memoizedState.isDehydrated){var n=dt(t.pendingLanes);0!==n&&(gt(t,1|n),ru(t,Xe()),0===(6&Rs)&&(Hs=Xe()+500,Ha()))}break;case 13:cu((function(){var t=Po(e,1);if(null!==t){var n=eu();nu(t,e,1,n)}})),Qu(e,1)}},kt=function(e){if(13===e.tag){var t=Po(e,134217728);if(null!==t)nu(t,e,134217728,eu());Qu(e,134217728)}},St=function(e){if(13===e.tag){var t=tu(e),n=Po(e,t);if(null!==n)nu(n,e,t,eu());Qu(e,t)}},Et=function(){return bt},Ct=function(e,t){var n=bt;try{return bt=e,t()}finally{bt=n}},ke=function(e,t,n){switch(t){case"input":if(J(e,n),t=n.name,"radio"===n.type&&null!=t){for(n=e;n.parentNode;)n=n.parentNode;for(n=n.querySelectorAll("input[name="+JSON.stringify(""+t)+'][type="radio"]'),t=0;t<n.length;t++){var r=n[t];if(r!==e&&r.form===e.form){var a=xa(r);if(!a)throw Error(o(90));Q(r),J(r,a)}}}break;case"textarea":oe(e,n);break;case"select":null!=(t=n.value)&&ne(e,!!n.multiple,t,!1)}},Re=uu,je=cu;var tc={usingClientEntryPoint:!1,Events:[ba,wa,xa,Ne,_e,uu]},nc={findFiberByHostInstance:ga,bundleType:0,version:"18.2.0",rendererPackageName:"react-dom"},rc={bundleType:nc.bundleType,version:nc.version,rendererPackageName:nc.rendererPackageName,rendererConfig:nc.rendererConfig,overrideHookState:null,overrideHookStateDeletePath:null,overrideHookStateRenamePath:null,overrideProps:null,overridePropsDeletePath:null,overridePropsRenamePath:null,setErrorHandler:null,setSuspenseHandler:null,scheduleUpdate:null,currentDispatcherRef:w.ReactCurrentDispatcher,findHostInstanceByFiber:function(e){return null===(e=We(e))?null:e.stateNode},findFiberByHostInstance:nc.findFiberByHostInstance||function(){return null},findHostInstancesForRefresh:null,scheduleRefresh:null,scheduleRoot:null,setRefreshHandler:null,getCurrentFiber:null,reconcilerVersion:"18.2.0-next-9e3b772b8-20220608"};if("undefined"!==typeof __REACT_DEVTOOLS_GLOBAL_HOOK__){var ac=__REACT_DEVTOOLS_GLOBAL_HOOK__;if(!ac.isDisabled&&ac.supportsFiber)try{at=ac.inject(rc),ot=ac}catch(ce){}}t.__SECRET_INTERNALS_DO_NOT_USE_OR_YOU_WILL_BE_FIRED=tc,t.createPortal=function(e,t){var n=2<arguments.length&&void 0!==arguments[2]?arguments[2]:null;if(!Xu(t))throw Error(o(200));return Uu(e,t,null,n)},t.createRoot=function(e,t){if(!Xu(e))throw Error(o(299));var n=!1,r="",a=Ku;return null!==t&&void 0!==t&&(!0===t.unstable_strictMode&&(n=!0),void 0!==t.identifierPrefix&&(r=t.identifierPrefix),void 0!==t.onRecoverableError&&(a=t.onRecoverableError)),t=Au(e,1,!1,null,0,n,0,r,a),e[ha]=t.current,Hr(8===e.nodeType?e.parentNode:e),new Yu(t)},t.findDOMNode=function(e){if(null==e)return null;if(1===e.nodeType)return e;var t=e._reactInternals;if(void 0===t){if("function"===typeof e.render)throw Error(o(188));throw e=Object.keys(e).join(","),Error(o(268,e))}return e=null===(e=We(t))?null:e.stateNode},t.flushSync=function(e){return cu(e)},t.hydrate=function(e,t,n){if(!Ju(t))throw Error(o(200));return ec(null,e,t,!0,n)},t.hydrateRoot=function(e,t,n){if(!Xu(e))throw Error(o(405));var r=null!=n&&n.hydratedSources||null,a=!1,l="",i=Ku;if(null!==n&&void 0!==n&&(!0===n.unstable_strictMode&&(a=!0),void 0!==n.identifierPrefix&&(l=n.identifierPrefix),void 0!==n.onRecoverableError&&(i=n.onRecoverableError)),t=qu(t,null,e,1,null!=n?n:null,a,0,l,i),e[ha]=t.current,Hr(e),r)for(e=0;e<r.length;e++)a=(a=(n=r[e])._getVersion)(n._source),null==t.mutableSourceEagerHydrationData?t.mutableSourceEagerHydrationData=[n,a]:t.mutableSourceEagerHydrationData.push(n,a);return new Gu(t)},t.render=function(e,t,n){if(!Ju(t))throw Error(o(200));return ec(null,e,t,!1,n)},t.unmountComponentAtNode=function(e){if(!Ju(e))throw Error(o(40));return!!e._reactRootContainer&&(cu((function(){ec(null,null,e,!1,(function(){e._reactRootContainer=null,e[ha]=null}))})),!0)},t.unstable_batchedUpdates=uu,t.unstable_renderSubtreeIntoContainer=function(e,t,n,r){if(!Ju(n))throw Error(o(200));if(null==e||void 0===e._reactInternals)throw Error(o(38));return ec(e,t,n,!1,r)},t.version="18.2.0-next-9e3b772b8-20220608"},250:function(e,t,n){"use strict";var r=n(164);t.s=r.createRoot,r.hydrateRoot},164:function(e,t,n){"use strict";!function e(){if("undefined"!==typeof __REACT_DEVTOOLS_GLOBAL_HOOK__&&"function"===typeof __REACT_DEVTOOLS_GLOBAL_HOOK__.checkDCE)try{__REACT_DEVTOOLS_GLOBAL_HOOK__.checkDCE(e)}catch(t){console.error(t)}}(),e.exports=n(463)},779:function(e,t,n){"use strict";var r=this&&this.__extends||function(){var e=function(t,n){return e=Object.setPrototypeOf||{__proto__:[]}instanceof Array&&function(e,t){e.__proto__=t}||function(e,t){for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n])},e(t,n)};return function(t,n){function r(){this.constructor=t}e(t,n),t.prototype=null===n?Object.create(n):(r.prototype=n.prototype,new r)}}(),a=this&&this.__assign||function(){return a=Object.assign||function(e){for(var t,n=1,r=arguments.length;n<r;n++)for(var a in t=arguments[n])Object.prototype.hasOwnProperty.call(t,a)&&(e[a]=t[a]);return e},a.apply(this,arguments)},o=this&&this.__rest||function(e,t){var n={};for(var r in e)Object.prototype.hasOwnProperty.call(e,r)&&t.indexOf(r)<0&&(n[r]=e[r]);if(null!=e&&"function"===typeof Object.getOwnPropertySymbols){var a=0;for(r=Object.getOwnPropertySymbols(e);a<r.length;a++)t.indexOf(r[a])<0&&(n[r[a]]=e[r[a]])}return n},l=this&&this.__importStar||function(e){if(e&&e.__esModule)return e;var t={};if(null!=e)for(var n in e)Object.hasOwnProperty.call(e,n)&&(t[n]=e[n]);return t.default=e,t},i=l(n(7)),s=l(n(791));function u(e,t,n){var r=function(e,t,n){var r=n[e+"Style"]||"",a=t&&t[e]||"";return r?r+";"+a:a}(e,t,n);return r?' style="'+r+'"':""}var c={'"':"&quot;","'":"&apos;","&":"&amp;",">":"&gt;","<":"&lt"};var d=function(e){function t(){return null!==e&&e.apply(this,arguments)||this}return r(t,e),t.prototype.render=function(){var e,t=this.props,n=t.json,r=t.data,l=t.replacer,i=t.space,d=t.themeClassName,f=t.theme,p=t.onJSONPrettyError,h=t.onError,v=t.silent,m=t.mainStyle,y=t.keyStyle,g=t.valueStyle,b=t.stringStyle,w=t.booleanStyle,x=t.errorStyle,k=o(t,["json","data","replacer","space","themeClassName","theme","onJSONPrettyError","onError","silent","mainStyle","keyStyle","valueStyle","stringStyle","booleanStyle","errorStyle"]),S={mainStyle:m,keyStyle:y,valueStyle:g,stringStyle:b,booleanStyle:w,errorStyle:x},E=r||n;if("string"===typeof E)try{E=JSON.parse(E)}catch(C){return v||console.warn("[react-json-pretty]: "+C.message),p&&p(C),!p&&h&&(h(C),console.warn("JSONPretty#onError is deprecated, please use JSONPretty#onJSONPrettyError instead")),s.createElement("div",a({},k,{dangerouslySetInnerHTML:{__html:'<pre class="__json-pretty-error__"'+u("error",f,S)+">"+(e=E,(e?e.replace(/<|>|&|"|'/g,(function(e){return c[e]})):e)+"</pre>")}}))}return s.createElement("div",a({},k,{dangerouslySetInnerHTML:{__html:'<pre class="'+d+'"'+u("main",f,S)+">"+this._pretty(f,E,l,+i,S)+"</pre>"}}))},t.prototype._pretty=function(e,t,n,r,a){var o=JSON.stringify(t,"function"===typeof n?n:null,isNaN(r)?2:r);return o?o.replace(/&/g,"&amp;").replace(/\\"([^,])/g,"\\&quot;$1").replace(/</g,"&lt;").replace(/>/g,"&gt;").replace(/^( *)("[^"]+": )?("[^"]*"|[\w.+-]*)?([,[{]|\[\s*\],?|\{\s*\},?)?$/gm,this._replace.bind(null,e,a)):o},t.prototype._replace=function(e,t,n,r,a,o,l){var i="</span>",s='<span class="__json-key__"'+u("key",e,t)+">",c='<span class="__json-value__"'+u("value",e,t)+">",d='<span class="__json-string__"'+u("string",e,t)+">",f='<span class="__json-boolean__"'+u("boolean",e,t)+">",p=r||"";return a&&(p=p+'"'+s+a.replace(/^"|":\s$/g,"")+i+'": '),o&&(p="true"===o||"false"===o?p+f+o+i:p+('"'===o[0]?d:c)+o+i),p+(l||"")},t.propTypes={data:i.any,json:i.any,replacer:i.func,silent:i.bool,space:i.oneOfType([i.number,i.string]),theme:i.object,themeClassName:i.string,onJSONPrettyError:i.func},t.defaultProps={data:"",json:"",silent:!0,space:2,themeClassName:"__json-pretty__"},t}(s.Component);e.exports=d},374:function(e,t,n){"use strict";var r=n(791),a=Symbol.for("react.element"),o=Symbol.for("react.fragment"),l=Object.prototype.hasOwnProperty,i=r.__SECRET_INTERNALS_DO_NOT_USE_OR_YOU_WILL_BE_FIRED.ReactCurrentOwner,s={key:!0,ref:!0,__self:!0,__source:!0};function u(e,t,n){var r,o={},u=null,c=null;for(r in void 0!==n&&(u=""+n),void 0!==t.key&&(u=""+t.key),void 0!==t.ref&&(c=t.ref),t)l.call(t,r)&&!s.hasOwnProperty(r)&&(o[r]=t[r]);if(e&&e.defaultProps)for(r in t=e.defaultProps)void 0===o[r]&&(o[r]=t[r]);return{$$typeof:a,type:e,key:u,ref:c,props:o,_owner:i.current}}t.Fragment=o,t.jsx=u,t.jsxs=u},117:function(e,t){"use strict";var n=Symbol.for("react.element"),r=Symbol.for("react.portal"),a=Symbol.for("react.fragment"),o=Symbol.for("react.strict_mode"),l=Symbol.for("react.profiler"),i=Symbol.for("react.provider"),s=Symbol.for("react.context"),u=Symbol.for("react.forward_ref"),c=Symbol.for("react.suspense"),d=Symbol.for("react.memo"),f=Symbol.for("react.lazy"),p=Symbol.iterator;var h={isMounted:function(){return!1},enqueueForceUpdate:function(){},enqueueReplaceState:function(){},enqueueSetState:function(){}},v=Object.assign,m={};function y(e,t,n){this.props=e,this.context=t,this.refs=m,this.updater=n||h}function g(){}function b(e,t,n){this.props=e,this.context=t,this.refs=m,this.updater=n||h}y.prototype.isReactComponent={},y.prototype.setState=function(e,t){if("object"!==typeof e&&"function"!==typeof e&&null!=e)throw Error("setState(...): takes an object of state variables to update or a function which returns an object of state variables.");this.updater.enqueueSetState(this,e,t,"setState")},y.prototype.forceUpdate=function(e){this.updater.enqueueForceUpdate(this,e,"forceUpdate")},g.prototype=y.prototype;var w=b.prototype=new g;w.constructor=b,v(w,y.prototype),w.isPureReactComponent=!0;var x=Array.isArray,k=Object.prototype.hasOwnProperty,S={current:null},E={key:!0,ref:!0,__self:!0,__source:!0};function C(e,t,r){var a,o={},l=null,i=null;if(null!=t)for(a in void 0!==t.ref&&(i=t.ref),void 0!==t.key&&(l=""+t.key),t)k.call(t,a)&&!E.hasOwnProperty(a)&&(o[a]=t[a]);var s=arguments.length-2;if(1===s)o.children=r;else if(1<s){for(var u=Array(s),c=0;c<s;c++)u[c]=arguments[c+2];o.children=u}if(e&&e.defaultProps)for(a in s=e.defaultProps)void 0===o[a]&&(o[a]=s[a]);return{$$typeof:n,type:e,key:l,ref:i,props:o,_owner:S.current}}function N(e){return"object"===typeof e&&null!==e&&e.$$typeof===n}var _=/\/+/g;function R(e,t){return"object"===typeof e&&null!==e&&null!=e.key?function(e){var t={"=":"=0",":":"=2"};return"$"+e.replace(/[=:]/g,(function(e){return t[e]}))}(""+e.key):t.toString(36)}function j(e,t,a,o,l){var i=typeof e;"undefined"!==i&&"boolean"!==i||(e=null);var s=!1;if(null===e)s=!0;else switch(i){case"string":case"number":s=!0;break;case"object":switch(e.$$typeof){case n:case r:s=!0}}if(s)return l=l(s=e),e=""===o?"."+R(s,0):o,x(l)?(a="",null!=e&&(a=e.replace(_,"$&/")+"/"),j(l,t,a,"",(function(e){return e}))):null!=l&&(N(l)&&(l=function(e,t){return{$$typeof:n,type:e.type,key:t,ref:e.ref,props:e.props,_owner:e._owner}}(l,a+(!l.key||s&&s.key===l.key?"":(""+l.key).replace(_,"$&/")+"/")+e)),t.push(l)),1;if(s=0,o=""===o?".":o+":",x(e))for(var u=0;u<e.length;u++){var c=o+R(i=e[u],u);s+=j(i,t,a,c,l)}else if(c=function(e){return null===e||"object"!==typeof e?null:"function"===typeof(e=p&&e[p]||e["@@iterator"])?e:null}(e),"function"===typeof c)for(e=c.call(e),u=0;!(i=e.next()).done;)s+=j(i=i.value,t,a,c=o+R(i,u++),l);else if("object"===i)throw t=String(e),Error("Objects are not valid as a React child (found: "+("[object Object]"===t?"object with keys {"+Object.keys(e).join(", ")+"}":t)+"). If you meant to render a collection of children, use an array instead.");return s}function P(e,t,n){if(null==e)return e;var r=[],a=0;return j(e,r,"","",(function(e){return t.call(n,e,a++)})),r}function T(e){if(-1===e._status){var t=e._result;(t=t()).then((function(t){0!==e._status&&-1!==e._status||(e._status=1,e._result=t)}),(function(t){0!==e._status&&-1!==e._status||(e._status=2,e._result=t)})),-1===e._status&&(e._status=0,e._result=t)}if(1===e._status)return e._result.default;throw e._result}var O={current:null},L={transition:null},z={ReactCurrentDispatcher:O,ReactCurrentBatchConfig:L,ReactCurrentOwner:S};t.Children={map:P,forEach:function(e,t,n){P(e,(function(){t.apply(this,arguments)}),n)},count:function(e){var t=0;return P(e,(function(){t++})),t},toArray:function(e){return P(e,(function(e){return e}))||[]},only:function(e){if(!N(e))throw Error("React.Children.only expected to receive a single React element child.");return e}},t.Component=y,t.Fragment=a,t.Profiler=l,t.PureComponent=b,t.StrictMode=o,t.Suspense=c,t.__SECRET_INTERNALS_DO_NOT_USE_OR_YOU_WILL_BE_FIRED=z,t.cloneElement=function(e,t,r){if(null===e||void 0===e)throw Error("React.cloneElement(...): The argument must be a React element, but you passed "+e+".");var a=v({},e.props),o=e.key,l=e.ref,i=e._owner;if(null!=t){if(void 0!==t.ref&&(l=t.ref,i=S.current),void 0!==t.key&&(o=""+t.key),e.type&&e.type.defaultProps)var s=e.type.defaultProps;for(u in t)k.call(t,u)&&!E.hasOwnProperty(u)&&(a[u]=void 0===t[u]&&void 0!==s?s[u]:t[u])}var u=arguments.length-2;if(1===u)a.children=r;else if(1<u){s=Array(u);for(var c=0;c<u;c++)s[c]=arguments[c+2];a.children=s}return{$$typeof:n,type:e.type,key:o,ref:l,props:a,_owner:i}},t.createContext=function(e){return(e={$$typeof:s,_currentValue:e,_currentValue2:e,_threadCount:0,Provider:null,Consumer:null,_defaultValue:null,_globalName:null}).Provider={$$typeof:i,_context:e},e.Consumer=e},t.createElement=C,t.createFactory=function(e){var t=C.bind(null,e);return t.type=e,t},t.createRef=function(){return{current:null}},t.forwardRef=function(e){return{$$typeof:u,render:e}},t.isValidElement=N,t.lazy=function(e){return{$$typeof:f,_payload:{_status:-1,_result:e},_init:T}},t.memo=function(e,t){return{$$typeof:d,type:e,compare:void 0===t?null:t}},t.startTransition=function(e){var t=L.transition;L.transition={};try{e()}finally{L.transition=t}},t.unstable_act=function(){throw Error("act(...) is not supported in production builds of React.")},t.useCallback=function(e,t){return O.current.useCallback(e,t)},t.useContext=function(e){return O.current.useContext(e)},t.useDebugValue=function(){},t.useDeferredValue=function(e){return O.current.useDeferredValue(e)},t.useEffect=function(e,t){return O.current.useEffect(e,t)},t.useId=function(){return O.current.useId()},t.useImperativeHandle=function(e,t,n){return O.current.useImperativeHandle(e,t,n)},t.useInsertionEffect=function(e,t){return O.current.useInsertionEffect(e,t)},t.useLayoutEffect=function(e,t){return O.current.useLayoutEffect(e,t)},t.useMemo=function(e,t){return O.current.useMemo(e,t)},t.useReducer=function(e,t,n){return O.current.useReducer(e,t,n)},t.useRef=function(e){return O.current.useRef(e)},t.useState=function(e){return O.current.useState(e)},t.useSyncExternalStore=function(e,t,n){return O.current.useSyncExternalStore(e,t,n)},t.useTransition=function(){return O.current.useTransition()},t.version="18.2.0"},791:function(e,t,n){"use strict";e.exports=n(117)},184:function(e,t,n){"use strict";e.exports=n(374)},813:function(e,t){"use strict";function n(e,t){var n=e.length;e.push(t);e:for(;0<n;){var r=n-1>>>1,a=e[r];if(!(0<o(a,t)))break e;e[r]=t,e[n]=a,n=r}}function r(e){return 0===e.length?null:e[0]}function a(e){if(0===e.length)return null;var t=e[0],n=e.pop();if(n!==t){e[0]=n;e:for(var r=0,a=e.length,l=a>>>1;r<l;){var i=2*(r+1)-1,s=e[i],u=i+1,c=e[u];if(0>o(s,n))u<a&&0>o(c,s)?(e[r]=c,e[u]=n,r=u):(e[r]=s,e[i]=n,r=i);else{if(!(u<a&&0>o(c,n)))break e;e[r]=c,e[u]=n,r=u}}}return t}function o(e,t){var n=e.sortIndex-t.sortIndex;return 0!==n?n:e.id-t.id}if("object"===typeof performance&&"function"===typeof performance.now){var l=performance;t.unstable_now=function(){return l.now()}}else{var i=Date,s=i.now();t.unstable_now=function(){return i.now()-s}}var u=[],c=[],d=1,f=null,p=3,h=!1,v=!1,m=!1,y="function"===typeof setTimeout?setTimeout:null,g="function"===typeof clearTimeout?clearTimeout:null,b="undefined"!==typeof setImmediate?setImmediate:null;function w(e){for(var t=r(c);null!==t;){if(null===t.callback)a(c);else{if(!(t.startTime<=e))break;a(c),t.sortIndex=t.expirationTime,n(u,t)}t=r(c)}}function x(e){if(m=!1,w(e),!v)if(null!==r(u))v=!0,L(k);else{var t=r(c);null!==t&&z(x,t.startTime-e)}}function k(e,n){v=!1,m&&(m=!1,g(N),N=-1),h=!0;var o=p;try{for(w(n),f=r(u);null!==f&&(!(f.expirationTime>n)||e&&!j());){var l=f.callback;if("function"===typeof l){f.callback=null,p=f.priorityLevel;var i=l(f.expirationTime<=n);n=t.unstable_now(),"function"===typeof i?f.callback=i:f===r(u)&&a(u),w(n)}else a(u);f=r(u)}if(null!==f)var s=!0;else{var d=r(c);null!==d&&z(x,d.startTime-n),s=!1}return s}finally{f=null,p=o,h=!1}}"undefined"!==typeof navigator&&void 0!==navigator.scheduling&&void 0!==navigator.scheduling.isInputPending&&navigator.scheduling.isInputPending.bind(navigator.scheduling);var S,E=!1,C=null,N=-1,_=5,R=-1;function j(){return!(t.unstable_now()-R<_)}function P(){if(null!==C){var e=t.unstable_now();R=e;var n=!0;try{n=C(!0,e)}finally{n?S():(E=!1,C=null)}}else E=!1}if("function"===typeof b)S=function(){b(P)};else if("undefined"!==typeof MessageChannel){var T=new MessageChannel,O=T.port2;T.port1.onmessage=P,S=function(){O.postMessage(null)}}else S=function(){y(P,0)};function L(e){C=e,E||(E=!0,S())}function z(e,n){N=y((function(){e(t.unstable_now())}),n)}t.unstable_IdlePriority=5,t.unstable_ImmediatePriority=1,t.unstable_LowPriority=4,t.unstable_NormalPriority=3,t.unstable_Profiling=null,t.unstable_UserBlockingPriority=2,t.unstable_cancelCallback=function(e){e.callback=null},t.unstable_continueExecution=function(){v||h||(v=!0,L(k))},t.unstable_forceFrameRate=function(e){0>e||125<e?console.error("forceFrameRate takes a positive int between 0 and 125, forcing frame rates higher than 125 fps is not supported"):_=0<e?Math.floor(1e3/e):5},t.unstable_getCurrentPriorityLevel=function(){return p},t.unstable_getFirstCallbackNode=function(){return r(u)},t.unstable_next=function(e){switch(p){case 1:case 2:case 3:var t=3;break;default:t=p}var n=p;p=t;try{return e()}finally{p=n}},t.unstable_pauseExecution=function(){},t.unstable_requestPaint=function(){},t.unstable_runWithPriority=function(e,t){switch(e){case 1:case 2:case 3:case 4:case 5:break;default:e=3}var n=p;p=e;try{return t()}finally{p=n}},t.unstable_scheduleCallback=function(e,a,o){var l=t.unstable_now();switch("object"===typeof o&&null!==o?o="number"===typeof(o=o.delay)&&0<o?l+o:l:o=l,e){case 1:var i=-1;break;case 2:i=250;break;case 5:i=1073741823;break;case 4:i=1e4;break;default:i=5e3}return e={id:d++,callback:a,priorityLevel:e,startTime:o,expirationTime:i=o+i,sortIndex:-1},o>l?(e.sortIndex=o,n(c,e),null===r(u)&&e===r(c)&&(m?(g(N),N=-1):m=!0,z(x,o-l))):(e.sortIndex=i,n(u,e),v||h||(v=!0,L(k))),e},t.unstable_shouldYield=j,t.unstable_wrapCallback=function(e){var t=p;return function(){var n=p;p=t;try{return e.apply(this,arguments)}finally{p=n}}}},296:function(e,t,n){"use strict";e.exports=n(813)},458:function(e){e.exports=function(){var e=document.getSelection();if(!e.rangeCount)return function(){};for(var t=document.activeElement,n=[],r=0;r<e.rangeCount;r++)n.push(e.getRangeAt(r));switch(t.tagName.toUpperCase()){case"INPUT":case"TEXTAREA":t.blur();break;default:t=null}return e.removeAllRanges(),function(){"Caret"===e.type&&e.removeAllRanges(),e.rangeCount||n.forEach((function(t){e.addRange(t)})),t&&t.focus()}}},391:function(e){"use strict";var t=function(){};e.exports=t}},t={};function n(r){var a=t[r];if(void 0!==a)return a.exports;var o=t[r]={exports:{}};return e[r].call(o.exports,o,o.exports,n),o.exports}n.m=e,n.n=function(e){var t=e&&e.__esModule?function(){return e.default}:function(){return e};return n.d(t,{a:t}),t},n.d=function(e,t){for(var r in t)n.o(t,r)&&!n.o(e,r)&&Object.defineProperty(e,r,{enumerable:!0,get:t[r]})},n.f={},n.e=function(e){return Promise.all(Object.keys(n.f).reduce((function(t,r){return n.f[r](e,t),t}),[]))},n.u=function(e){return"static/js/"+e+".aae294ae.chunk.js"},n.miniCssF=function(e){},n.g=function(){if("object"===typeof globalThis)return globalThis;try{return this||new Function("return this")()}catch(e){if("object"===typeof window)return window}}(),n.o=function(e,t){return Object.prototype.hasOwnProperty.call(e,t)},function(){var e={},t="mitmproxy-client:";n.l=function(r,a,o,l){if(e[r])e[r].push(a);else{var i,s;if(void 0!==o)for(var u=document.getElementsByTagName("script"),c=0;c<u.length;c++){var d=u[c];if(d.getAttribute("src")==r||d.getAttribute("data-webpack")==t+o){i=d;break}}i||(s=!0,(i=document.createElement("script")).charset="utf-8",i.timeout=120,n.nc&&i.setAttribute("nonce",n.nc),i.setAttribute("data-webpack",t+o),i.src=r),e[r]=[a];var f=function(t,n){i.onerror=i.onload=null,clearTimeout(p);var a=e[r];if(delete e[r],i.parentNode&&i.parentNode.removeChild(i),a&&a.forEach((function(e){return e(n)})),t)return t(n)},p=setTimeout(f.bind(null,void 0,{type:"timeout",target:i}),12e4);i.onerror=f.bind(null,i.onerror),i.onload=f.bind(null,i.onload),s&&document.head.appendChild(i)}}}(),n.r=function(e){"undefined"!==typeof Symbol&&Symbol.toStringTag&&Object.defineProperty(e,Symbol.toStringTag,{value:"Module"}),Object.defineProperty(e,"__esModule",{value:!0})},n.p="/",function(){var e={179:0};n.f.j=function(t,r){var a=n.o(e,t)?e[t]:void 0;if(0!==a)if(a)r.push(a[2]);else{var o=new Promise((function(n,r){a=e[t]=[n,r]}));r.push(a[2]=o);var l=n.p+n.u(t),i=new Error;n.l(l,(function(r){if(n.o(e,t)&&(0!==(a=e[t])&&(e[t]=void 0),a)){var o=r&&("load"===r.type?"missing":r.type),l=r&&r.target&&r.target.src;i.message="Loading chunk "+t+" failed.\n("+o+": "+l+")",i.name="ChunkLoadError",i.type=o,i.request=l,a[1](i)}}),"chunk-"+t,t)}};var t=function(t,r){var a,o,l=r[0],i=r[1],s=r[2],u=0;if(l.some((function(t){return 0!==e[t]}))){for(a in i)n.o(i,a)&&(n.m[a]=i[a]);if(s)s(n)}for(t&&t(r);u<l.length;u++)o=l[u],n.o(e,o)&&e[o]&&e[o][0](),e[o]=0},r=self.webpackChunkmitmproxy_client=self.webpackChunkmitmproxy_client||[];r.forEach(t.bind(null,0)),r.push=t.bind(null,r.push.bind(r))}(),function(){"use strict";var e=n(791),t=n(250);function r(e,t){if(!(e instanceof t))throw new TypeError("Cannot call a class as a function")}function a(e){return a="function"==typeof Symbol&&"symbol"==typeof Symbol.iterator?function(e){return typeof e}:function(e){return e&&"function"==typeof Symbol&&e.constructor===Symbol&&e!==Symbol.prototype?"symbol":typeof e},a(e)}function o(e){var t=function(e,t){if("object"!==a(e)||null===e)return e;var n=e[Symbol.toPrimitive];if(void 0!==n){var r=n.call(e,t||"default");if("object"!==a(r))return r;throw new TypeError("@@toPrimitive must return a primitive value.")}return("string"===t?String:Number)(e)}(e,"string");return"symbol"===a(t)?t:String(t)}function l(e,t){for(var n=0;n<t.length;n++){var r=t[n];r.enumerable=r.enumerable||!1,r.configurable=!0,"value"in r&&(r.writable=!0),Object.defineProperty(e,o(r.key),r)}}function i(e,t,n){return t&&l(e.prototype,t),n&&l(e,n),Object.defineProperty(e,"prototype",{writable:!1}),e}function s(e,t){return s=Object.setPrototypeOf?Object.setPrototypeOf.bind():function(e,t){return e.__proto__=t,e},s(e,t)}function u(e,t){if("function"!==typeof t&&null!==t)throw new TypeError("Super expression must either be null or a function");e.prototype=Object.create(t&&t.prototype,{constructor:{value:e,writable:!0,configurable:!0}}),Object.defineProperty(e,"prototype",{writable:!1}),t&&s(e,t)}function c(e){return c=Object.setPrototypeOf?Object.getPrototypeOf.bind():function(e){return e.__proto__||Object.getPrototypeOf(e)},c(e)}function d(e){if(void 0===e)throw new ReferenceError("this hasn't been initialised - super() hasn't been called");return e}function f(e,t){if(t&&("object"===a(t)||"function"===typeof t))return t;if(void 0!==t)throw new TypeError("Derived constructors may only return object or undefined");return d(e)}function p(e){var t=function(){if("undefined"===typeof Reflect||!Reflect.construct)return!1;if(Reflect.construct.sham)return!1;if("function"===typeof Proxy)return!0;try{return Boolean.prototype.valueOf.call(Reflect.construct(Boolean,[],(function(){}))),!0}catch(e){return!1}}();return function(){var n,r=c(e);if(t){var a=c(this).constructor;n=Reflect.construct(r,arguments,a)}else n=r.apply(this,arguments);return f(this,n)}}function h(e,t,n){return(t=o(t))in e?Object.defineProperty(e,t,{value:n,enumerable:!0,configurable:!0,writable:!0}):e[t]=n,e}function v(e,t){var n=Object.keys(e);if(Object.getOwnPropertySymbols){var r=Object.getOwnPropertySymbols(e);t&&(r=r.filter((function(t){return Object.getOwnPropertyDescriptor(e,t).enumerable}))),n.push.apply(n,r)}return n}function m(e){for(var t=1;t<arguments.length;t++){var n=null!=arguments[t]?arguments[t]:{};t%2?v(Object(n),!0).forEach((function(t){h(e,t,n[t])})):Object.getOwnPropertyDescriptors?Object.defineProperties(e,Object.getOwnPropertyDescriptors(n)):v(Object(n)).forEach((function(t){Object.defineProperty(e,t,Object.getOwnPropertyDescriptor(n,t))}))}return e}function y(e,t){if(null==e)return{};var n,r,a={},o=Object.keys(e);for(r=0;r<o.length;r++)n=o[r],t.indexOf(n)>=0||(a[n]=e[n]);return a}function g(e,t){if(null==e)return{};var n,r,a=y(e,t);if(Object.getOwnPropertySymbols){var o=Object.getOwnPropertySymbols(e);for(r=0;r<o.length;r++)n=o[r],t.indexOf(n)>=0||Object.prototype.propertyIsEnumerable.call(e,n)&&(a[n]=e[n])}return a}var b=n(694),w=n.n(b),x=n(184),k=["xxl","xl","lg","md","sm","xs"],S=e.createContext({prefixes:{},breakpoints:k,minBreakpoint:"xs"});S.Consumer,S.Provider;function E(t,n){var r=(0,e.useContext)(S).prefixes;return t||r[n]||n}function C(){return(0,e.useContext)(S).breakpoints}function N(){return(0,e.useContext)(S).minBreakpoint}var _=["bsPrefix","className","striped","bordered","borderless","hover","size","variant","responsive"],R=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.className,a=e.striped,o=e.bordered,l=e.borderless,i=e.hover,s=e.size,u=e.variant,c=e.responsive,d=g(e,_),f=E(n,"table"),p=w()(r,f,u&&"".concat(f,"-").concat(u),s&&"".concat(f,"-").concat(s),a&&"".concat(f,"-").concat("string"===typeof a?"striped-".concat(a):"striped"),o&&"".concat(f,"-bordered"),l&&"".concat(f,"-borderless"),i&&"".concat(f,"-hover")),h=(0,x.jsx)("table",m(m({},d),{},{className:p,ref:t}));if(c){var v="".concat(f,"-responsive");return"string"===typeof c&&(v="".concat(v,"-").concat(c)),(0,x.jsx)("div",{className:v,children:h})}return h})),j=R,P=n(7),T=n.n(P),O=["as","className","type","tooltip"],L={type:T().string,tooltip:T().bool,as:T().elementType},z=e.forwardRef((function(e,t){var n=e.as,r=void 0===n?"div":n,a=e.className,o=e.type,l=void 0===o?"valid":o,i=e.tooltip,s=void 0!==i&&i,u=g(e,O);return(0,x.jsx)(r,m(m({},u),{},{ref:t,className:w()(a,"".concat(l,"-").concat(s?"tooltip":"feedback"))}))}));z.displayName="Feedback",z.propTypes=L;var D=z,F=e.createContext({}),M=["id","bsPrefix","className","type","isValid","isInvalid","as"],I=e.forwardRef((function(t,n){var r=t.id,a=t.bsPrefix,o=t.className,l=t.type,i=void 0===l?"checkbox":l,s=t.isValid,u=void 0!==s&&s,c=t.isInvalid,d=void 0!==c&&c,f=t.as,p=void 0===f?"input":f,h=g(t,M),v=(0,e.useContext)(F).controlId;return a=E(a,"form-check-input"),(0,x.jsx)(p,m(m({},h),{},{ref:n,type:i,id:r||v,className:w()(o,a,u&&"is-valid",d&&"is-invalid")}))}));I.displayName="FormCheckInput";var B=I,A=["bsPrefix","className","htmlFor"],U=e.forwardRef((function(t,n){var r=t.bsPrefix,a=t.className,o=t.htmlFor,l=g(t,A),i=(0,e.useContext)(F).controlId;return r=E(r,"form-check-label"),(0,x.jsx)("label",m(m({},l),{},{ref:n,htmlFor:o||i,className:w()(a,r)}))}));U.displayName="FormCheckLabel";var H=U;var q=["id","bsPrefix","bsSwitchPrefix","inline","reverse","disabled","isValid","isInvalid","feedbackTooltip","feedback","feedbackType","className","style","title","type","label","children","as"],V=e.forwardRef((function(t,n){var r=t.id,a=t.bsPrefix,o=t.bsSwitchPrefix,l=t.inline,i=void 0!==l&&l,s=t.reverse,u=void 0!==s&&s,c=t.disabled,d=void 0!==c&&c,f=t.isValid,p=void 0!==f&&f,h=t.isInvalid,v=void 0!==h&&h,y=t.feedbackTooltip,b=void 0!==y&&y,k=t.feedback,S=t.feedbackType,C=t.className,N=t.style,_=t.title,R=void 0===_?"":_,j=t.type,P=void 0===j?"checkbox":j,T=t.label,O=t.children,L=t.as,z=void 0===L?"input":L,M=g(t,q);a=E(a,"form-check"),o=E(o,"form-switch");var I=(0,e.useContext)(F).controlId,A=(0,e.useMemo)((function(){return{controlId:r||I}}),[I,r]),U=!O&&null!=T&&!1!==T||function(t,n){return e.Children.toArray(t).some((function(t){return e.isValidElement(t)&&t.type===n}))}(O,H),V=(0,x.jsx)(B,m(m({},M),{},{type:"switch"===P?"checkbox":P,ref:n,isValid:p,isInvalid:v,disabled:d,as:z}));return(0,x.jsx)(F.Provider,{value:A,children:(0,x.jsx)("div",{style:N,className:w()(C,U&&a,i&&"".concat(a,"-inline"),u&&"".concat(a,"-reverse"),"switch"===P&&o),children:O||(0,x.jsxs)(x.Fragment,{children:[V,U&&(0,x.jsx)(H,{title:R,children:T}),k&&(0,x.jsx)(D,{type:S,tooltip:b,children:k})]})})})}));V.displayName="FormCheck";var W=Object.assign(V,{Input:B,Label:H}),$=(n(391),["bsPrefix","type","size","htmlSize","id","className","isValid","isInvalid","plaintext","readOnly","as"]),Q=e.forwardRef((function(t,n){var r,a,o=t.bsPrefix,l=t.type,i=t.size,s=t.htmlSize,u=t.id,c=t.className,d=t.isValid,f=void 0!==d&&d,p=t.isInvalid,v=void 0!==p&&p,y=t.plaintext,b=t.readOnly,k=t.as,S=void 0===k?"input":k,C=g(t,$),N=(0,e.useContext)(F).controlId;(o=E(o,"form-control"),y)?r=h({},"".concat(o,"-plaintext"),!0):(h(a={},o,!0),h(a,"".concat(o,"-").concat(i),i),r=a);return(0,x.jsx)(S,m(m({},C),{},{type:l,size:s,ref:n,readOnly:b,id:u||N,className:w()(c,r,f&&"is-valid",v&&"is-invalid","color"===l&&"".concat(o,"-color"))}))}));Q.displayName="FormControl";var K=Object.assign(Q,{Feedback:D}),Y=/-(.)/g;var G=["className","bsPrefix","as"],X=function(e){return e[0].toUpperCase()+(t=e,t.replace(Y,(function(e,t){return t.toUpperCase()}))).slice(1);var t};function J(t){var n=arguments.length>1&&void 0!==arguments[1]?arguments[1]:{},r=n.displayName,a=void 0===r?X(t):r,o=n.Component,l=n.defaultProps,i=e.forwardRef((function(e,n){var r=e.className,a=e.bsPrefix,l=e.as,i=void 0===l?o||"div":l,s=g(e,G),u=E(a,t);return(0,x.jsx)(i,m({ref:n,className:w()(r,u)},s))}));return i.defaultProps=l,i.displayName=a,i}var Z=J("form-floating"),ee=["controlId","as"],te=e.forwardRef((function(t,n){var r=t.controlId,a=t.as,o=void 0===a?"div":a,l=g(t,ee),i=(0,e.useMemo)((function(){return{controlId:r}}),[r]);return(0,x.jsx)(F.Provider,{value:i,children:(0,x.jsx)(o,m(m({},l),{},{ref:n}))})}));te.displayName="FormGroup";var ne=te;function re(e,t){(null==t||t>e.length)&&(t=e.length);for(var n=0,r=new Array(t);n<t;n++)r[n]=e[n];return r}function ae(e,t){if(e){if("string"===typeof e)return re(e,t);var n=Object.prototype.toString.call(e).slice(8,-1);return"Object"===n&&e.constructor&&(n=e.constructor.name),"Map"===n||"Set"===n?Array.from(e):"Arguments"===n||/^(?:Ui|I)nt(?:8|16|32)(?:Clamped)?Array$/.test(n)?re(e,t):void 0}}function oe(e,t){return function(e){if(Array.isArray(e))return e}(e)||function(e,t){var n=null==e?null:"undefined"!=typeof Symbol&&e[Symbol.iterator]||e["@@iterator"];if(null!=n){var r,a,o,l,i=[],s=!0,u=!1;try{if(o=(n=n.call(e)).next,0===t){if(Object(n)!==n)return;s=!1}else for(;!(s=(r=o.call(n)).done)&&(i.push(r.value),i.length!==t);s=!0);}catch(c){u=!0,a=c}finally{try{if(!s&&null!=n.return&&(l=n.return(),Object(l)!==l))return}finally{if(u)throw a}}return i}}(e,t)||ae(e,t)||function(){throw new TypeError("Invalid attempt to destructure non-iterable instance.\nIn order to be iterable, non-array objects must have a [Symbol.iterator]() method.")}()}var le=["as","bsPrefix","className"],ie=["className"];var se=e.forwardRef((function(e,t){var n=function(e){var t=e.as,n=e.bsPrefix,r=e.className,a=g(e,le);n=E(n,"col");var o=C(),l=N(),i=[],s=[];return o.forEach((function(e){var t,r,o,u=a[e];delete a[e],"object"===typeof u&&null!=u?(t=u.span,r=u.offset,o=u.order):t=u;var c=e!==l?"-".concat(e):"";t&&i.push(!0===t?"".concat(n).concat(c):"".concat(n).concat(c,"-").concat(t)),null!=o&&s.push("order".concat(c,"-").concat(o)),null!=r&&s.push("offset".concat(c,"-").concat(r))})),[m(m({},a),{},{className:w().apply(void 0,[r].concat(i,s))}),{as:t,bsPrefix:n,spans:i}]}(e),r=oe(n,2),a=r[0],o=a.className,l=g(a,ie),i=r[1],s=i.as,u=void 0===s?"div":s,c=i.bsPrefix,d=i.spans;return(0,x.jsx)(u,m(m({},l),{},{ref:t,className:w()(o,!d.length&&c)}))}));se.displayName="Col";var ue=se,ce=["as","bsPrefix","column","visuallyHidden","className","htmlFor"],de=e.forwardRef((function(t,n){var r=t.as,a=void 0===r?"label":r,o=t.bsPrefix,l=t.column,i=t.visuallyHidden,s=t.className,u=t.htmlFor,c=g(t,ce),d=(0,e.useContext)(F).controlId;o=E(o,"form-label");var f="col-form-label";"string"===typeof l&&(f="".concat(f," ").concat(f,"-").concat(l));var p=w()(s,o,i&&"visually-hidden",l&&f);return u=u||d,l?(0,x.jsx)(ue,m({ref:n,as:"label",className:p,htmlFor:u},c)):(0,x.jsx)(a,m({ref:n,className:p,htmlFor:u},c))}));de.displayName="FormLabel",de.defaultProps={column:!1,visuallyHidden:!1};var fe=de,pe=["bsPrefix","className","id"],he=e.forwardRef((function(t,n){var r=t.bsPrefix,a=t.className,o=t.id,l=g(t,pe),i=(0,e.useContext)(F).controlId;return r=E(r,"form-range"),(0,x.jsx)("input",m(m({},l),{},{type:"range",ref:n,className:w()(a,r),id:o||i}))}));he.displayName="FormRange";var ve=he,me=["bsPrefix","size","htmlSize","className","isValid","isInvalid","id"],ye=e.forwardRef((function(t,n){var r=t.bsPrefix,a=t.size,o=t.htmlSize,l=t.className,i=t.isValid,s=void 0!==i&&i,u=t.isInvalid,c=void 0!==u&&u,d=t.id,f=g(t,me),p=(0,e.useContext)(F).controlId;return r=E(r,"form-select"),(0,x.jsx)("select",m(m({},f),{},{size:o,ref:n,className:w()(l,r,a&&"".concat(r,"-").concat(a),s&&"is-valid",c&&"is-invalid"),id:d||p}))}));ye.displayName="FormSelect";var ge=ye,be=["bsPrefix","className","as","muted"],we=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.className,a=e.as,o=void 0===a?"small":a,l=e.muted,i=g(e,be);return n=E(n,"form-text"),(0,x.jsx)(o,m(m({},i),{},{ref:t,className:w()(r,n,l&&"text-muted")}))}));we.displayName="FormText";var xe=we,ke=e.forwardRef((function(e,t){return(0,x.jsx)(W,m(m({},e),{},{ref:t,type:"switch"}))}));ke.displayName="Switch";var Se=Object.assign(ke,{Input:W.Input,Label:W.Label}),Ee=["bsPrefix","className","children","controlId","label"],Ce=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.className,a=e.children,o=e.controlId,l=e.label,i=g(e,Ee);return n=E(n,"form-floating"),(0,x.jsxs)(ne,m(m({ref:t,className:w()(r,n),controlId:o},i),{},{children:[a,(0,x.jsx)("label",{htmlFor:o,children:l})]}))}));Ce.displayName="FloatingLabel";var Ne=Ce,_e=["className","validated","as"],Re={_ref:T().any,validated:T().bool,as:T().elementType},je=e.forwardRef((function(e,t){var n=e.className,r=e.validated,a=e.as,o=void 0===a?"form":a,l=g(e,_e);return(0,x.jsx)(o,m(m({},l),{},{ref:t,className:w()(n,r&&"was-validated")}))}));je.displayName="Form",je.propTypes=Re;var Pe=Object.assign(je,{Group:ne,Control:K,Floating:Z,Check:W,Switch:Se,Label:fe,Text:xe,Range:ve,Select:ge,FloatingLabel:Ne}),Te=["as","disabled"];function Oe(e){var t=e.tagName,n=e.disabled,r=e.href,a=e.target,o=e.rel,l=e.role,i=e.onClick,s=e.tabIndex,u=void 0===s?0:s,c=e.type;t||(t=null!=r||null!=a||null!=o?"a":"button");var d={tagName:t};if("button"===t)return[{type:c||"button",disabled:n},d];var f=function(e){(n||"a"===t&&function(e){return!e||"#"===e.trim()}(r))&&e.preventDefault(),n?e.stopPropagation():null==i||i(e)};return"a"===t&&(r||(r="#"),n&&(r=void 0)),[{role:null!=l?l:"button",disabled:void 0,tabIndex:n?void 0:u,href:r,target:"a"===t?a:void 0,"aria-disabled":n||void 0,rel:"a"===t?o:void 0,onClick:f,onKeyDown:function(e){" "===e.key&&(e.preventDefault(),f(e))}},d]}var Le=e.forwardRef((function(e,t){var n=e.as,r=e.disabled,a=function(e,t){if(null==e)return{};var n,r,a={},o=Object.keys(e);for(r=0;r<o.length;r++)n=o[r],t.indexOf(n)>=0||(a[n]=e[n]);return a}(e,Te),o=oe(Oe(Object.assign({tagName:n,disabled:r},a)),2),l=o[0],i=o[1].tagName;return(0,x.jsx)(i,Object.assign({},a,l,{ref:t}))}));Le.displayName="Button";var ze=["as","bsPrefix","variant","size","active","className"],De=e.forwardRef((function(e,t){var n=e.as,r=e.bsPrefix,a=e.variant,o=e.size,l=e.active,i=e.className,s=g(e,ze),u=E(r,"btn"),c=oe(Oe(m({tagName:n},s)),2),d=c[0],f=c[1].tagName;return(0,x.jsx)(f,m(m(m({},d),s),{},{ref:t,className:w()(i,u,l&&"active",a&&"".concat(u,"-").concat(a),o&&"".concat(u,"-").concat(o),s.href&&s.disabled&&"disabled")}))}));De.displayName="Button",De.defaultProps={variant:"primary",active:!1,disabled:!1};var Fe=De;var Me=n.p+"static/media/github-mark.6fa18895f6e6c7772cab7049f7e05f59.svg",Ie=["bsPrefix","bg","pill","text","className","as"],Be=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.bg,a=e.pill,o=e.text,l=e.className,i=e.as,s=void 0===i?"span":i,u=g(e,Ie),c=E(n,"badge");return(0,x.jsx)(s,m(m({ref:t},u),{},{className:w()(l,c,a&&"rounded-pill",o&&"text-".concat(o),r&&"bg-".concat(r))}))}));Be.displayName="Badge",Be.defaultProps={bg:"primary",pill:!1};var Ae=Be,Ue=!("undefined"===typeof window||!window.document||!window.document.createElement),He=!1,qe=!1;try{var Ve={get passive(){return He=!0},get once(){return qe=He=!0}};Ue&&(window.addEventListener("test",Ve,Ve),window.removeEventListener("test",Ve,!0))}catch(_r){}var We=function(e,t,n,r){if(r&&"boolean"!==typeof r&&!qe){var a=r.once,o=r.capture,l=n;!qe&&a&&(l=n.__once||function e(r){this.removeEventListener(t,e,o),n.call(this,r)},n.__once=l),e.addEventListener(t,l,He?r:o)}e.addEventListener(t,n,r)};function $e(e){return e&&e.ownerDocument||document}var Qe,Ke=function(e,t,n,r){var a=r&&"boolean"!==typeof r?r.capture:r;e.removeEventListener(t,n,a),n.__once&&e.removeEventListener(t,n.__once,a)};function Ye(e){if((!Qe&&0!==Qe||e)&&Ue){var t=document.createElement("div");t.style.position="absolute",t.style.top="-9999px",t.style.width="50px",t.style.height="50px",t.style.overflow="scroll",document.body.appendChild(t),Qe=t.offsetWidth-t.clientWidth,document.body.removeChild(t)}return Qe}var Ge=function(t){var n=(0,e.useRef)(t);return(0,e.useEffect)((function(){n.current=t}),[t]),n};function Xe(t){var n=Ge(t);return(0,e.useCallback)((function(){return n.current&&n.current.apply(n,arguments)}),[n])}var Je=function(e){return e&&"function"!==typeof e?function(t){e.current=t}:e};var Ze=function(t,n){return(0,e.useMemo)((function(){return function(e,t){var n=Je(e),r=Je(t);return function(e){n&&n(e),r&&r(e)}}(t,n)}),[t,n])};function et(t){var n=function(t){var n=(0,e.useRef)(t);return n.current=t,n}(t);(0,e.useEffect)((function(){return function(){return n.current()}}),[])}function tt(e,t){return function(e){var t=$e(e);return t&&t.defaultView||window}(e).getComputedStyle(e,t)}var nt=/([A-Z])/g;var rt=/^ms-/;function at(e){return function(e){return e.replace(nt,"-$1").toLowerCase()}(e).replace(rt,"-ms-")}var ot=/^((translate|rotate|scale)(X|Y|Z|3d)?|matrix(3d)?|perspective|skew(X|Y)?)$/i;var lt=function(e,t){var n="",r="";if("string"===typeof t)return e.style.getPropertyValue(at(t))||tt(e).getPropertyValue(at(t));Object.keys(t).forEach((function(a){var o=t[a];o||0===o?!function(e){return!(!e||!ot.test(e))}(a)?n+=at(a)+": "+o+";":r+=a+"("+o+") ":e.style.removeProperty(at(a))})),r&&(n+="transform: "+r+";"),e.style.cssText+=";"+n};var it=function(e,t,n,r){return We(e,t,n,r),function(){Ke(e,t,n,r)}};function st(e,t,n){void 0===n&&(n=5);var r=!1,a=setTimeout((function(){r||function(e,t,n,r){if(void 0===n&&(n=!1),void 0===r&&(r=!0),e){var a=document.createEvent("HTMLEvents");a.initEvent(t,n,r),e.dispatchEvent(a)}}(e,"transitionend",!0)}),t+n),o=it(e,"transitionend",(function(){r=!0}),{once:!0});return function(){clearTimeout(a),o()}}function ut(e,t,n,r){null==n&&(n=function(e){var t=lt(e,"transitionDuration")||"",n=-1===t.indexOf("ms")?1e3:1;return parseFloat(t)*n}(e)||0);var a=st(e,n,r),o=it(e,"transitionend",t);return function(){a(),o()}}function ct(e){void 0===e&&(e=$e());try{var t=e.activeElement;return t&&t.nodeName?t:null}catch(_r){return e.body}}function dt(e,t){return e.contains?e.contains(t):e.compareDocumentPosition?e===t||!!(16&e.compareDocumentPosition(t)):void 0}var ft=n(164);function pt(e){return function(e){if(Array.isArray(e))return re(e)}(e)||function(e){if("undefined"!==typeof Symbol&&null!=e[Symbol.iterator]||null!=e["@@iterator"])return Array.from(e)}(e)||ae(e)||function(){throw new TypeError("Invalid attempt to spread non-iterable instance.\nIn order to be iterable, non-array objects must have a [Symbol.iterator]() method.")}()}var ht,vt=(ht="modal-open","".concat("data-rr-ui-").concat(ht)),mt=function(){function e(){var t=arguments.length>0&&void 0!==arguments[0]?arguments[0]:{},n=t.ownerDocument,a=t.handleContainerOverflow,o=void 0===a||a,l=t.isRTL,i=void 0!==l&&l;r(this,e),this.handleContainerOverflow=o,this.isRTL=i,this.modals=[],this.ownerDocument=n}return i(e,[{key:"getScrollbarWidth",value:function(){return function(){var e=arguments.length>0&&void 0!==arguments[0]?arguments[0]:document,t=e.defaultView;return Math.abs(t.innerWidth-e.documentElement.clientWidth)}(this.ownerDocument)}},{key:"getElement",value:function(){return(this.ownerDocument||document).body}},{key:"setModalAttributes",value:function(e){}},{key:"removeModalAttributes",value:function(e){}},{key:"setContainerStyle",value:function(e){var t={overflow:"hidden"},n=this.isRTL?"paddingLeft":"paddingRight",r=this.getElement();e.style=h({overflow:r.style.overflow},n,r.style[n]),e.scrollBarWidth&&(t[n]="".concat(parseInt(lt(r,n)||"0",10)+e.scrollBarWidth,"px")),r.setAttribute(vt,""),lt(r,t)}},{key:"reset",value:function(){var e=this;pt(this.modals).forEach((function(t){return e.remove(t)}))}},{key:"removeContainerStyle",value:function(e){var t=this.getElement();t.removeAttribute(vt),Object.assign(t.style,e.style)}},{key:"add",value:function(e){var t=this.modals.indexOf(e);return-1!==t?t:(t=this.modals.length,this.modals.push(e),this.setModalAttributes(e),0!==t||(this.state={scrollBarWidth:this.getScrollbarWidth(),style:{}},this.handleContainerOverflow&&this.setContainerStyle(this.state)),t)}},{key:"remove",value:function(e){var t=this.modals.indexOf(e);-1!==t&&(this.modals.splice(t,1),!this.modals.length&&this.handleContainerOverflow&&this.removeContainerStyle(this.state),this.removeModalAttributes(e))}},{key:"isTopModal",value:function(e){return!!this.modals.length&&this.modals[this.modals.length-1]===e}}]),e}(),yt=mt,gt=(0,e.createContext)(Ue?window:void 0);gt.Provider;function bt(){return(0,e.useContext)(gt)}var wt=function(e,t){return Ue?null==e?(t||$e()).body:("function"===typeof e&&(e=e()),e&&"current"in e&&(e=e.current),e&&("nodeType"in e||e.getBoundingClientRect)?e:null):null};var xt,kt=["show","role","className","style","children","backdrop","keyboard","onBackdropClick","onEscapeKeyDown","transition","backdropTransition","autoFocus","enforceFocus","restoreFocus","restoreFocusOptions","renderDialog","renderBackdrop","manager","container","onShow","onHide","onExit","onExited","onExiting","onEnter","onEntering","onEntered"];function St(t){var n=bt(),r=t||function(e){return xt||(xt=new yt({ownerDocument:null==e?void 0:e.document})),xt}(n),a=(0,e.useRef)({dialog:null,backdrop:null});return Object.assign(a.current,{add:function(){return r.add(a.current)},remove:function(){return r.remove(a.current)},isTopModal:function(){return r.isTopModal(a.current)},setDialogRef:(0,e.useCallback)((function(e){a.current.dialog=e}),[]),setBackdropRef:(0,e.useCallback)((function(e){a.current.backdrop=e}),[])})}var Et=(0,e.forwardRef)((function(t,n){var r=t.show,a=void 0!==r&&r,o=t.role,l=void 0===o?"dialog":o,i=t.className,s=t.style,u=t.children,c=t.backdrop,d=void 0===c||c,f=t.keyboard,p=void 0===f||f,h=t.onBackdropClick,v=t.onEscapeKeyDown,m=t.transition,y=t.backdropTransition,g=t.autoFocus,b=void 0===g||g,w=t.enforceFocus,k=void 0===w||w,S=t.restoreFocus,E=void 0===S||S,C=t.restoreFocusOptions,N=t.renderDialog,_=t.renderBackdrop,R=void 0===_?function(e){return(0,x.jsx)("div",Object.assign({},e))}:_,j=t.manager,P=t.container,T=t.onShow,O=t.onHide,L=void 0===O?function(){}:O,z=t.onExit,D=t.onExited,F=t.onExiting,M=t.onEnter,I=t.onEntering,B=t.onEntered,A=function(e,t){if(null==e)return{};var n,r,a={},o=Object.keys(e);for(r=0;r<o.length;r++)n=o[r],t.indexOf(n)>=0||(a[n]=e[n]);return a}(t,kt),U=function(t,n){var r=bt(),a=oe((0,e.useState)((function(){return wt(t,null==r?void 0:r.document)})),2),o=a[0],l=a[1];if(!o){var i=wt(t);i&&l(i)}return(0,e.useEffect)((function(){n&&o&&n(o)}),[n,o]),(0,e.useEffect)((function(){var e=wt(t);e!==o&&l(e)}),[t,o]),o}(P),H=St(j),q=function(){var t=(0,e.useRef)(!0),n=(0,e.useRef)((function(){return t.current}));return(0,e.useEffect)((function(){return t.current=!0,function(){t.current=!1}}),[]),n.current}(),V=function(t){var n=(0,e.useRef)(null);return(0,e.useEffect)((function(){n.current=t})),n.current}(a),W=oe((0,e.useState)(!a),2),$=W[0],Q=W[1],K=(0,e.useRef)(null);(0,e.useImperativeHandle)(n,(function(){return H}),[H]),Ue&&!V&&a&&(K.current=ct()),m||a||$?a&&$&&Q(!1):Q(!0);var Y=Xe((function(){if(H.add(),te.current=it(document,"keydown",Z),ee.current=it(document,"focus",(function(){return setTimeout(X)}),!0),T&&T(),b){var e=ct(document);H.dialog&&e&&!dt(H.dialog,e)&&(K.current=e,H.dialog.focus())}})),G=Xe((function(){var e;(H.remove(),null==te.current||te.current(),null==ee.current||ee.current(),E)&&(null==(e=K.current)||null==e.focus||e.focus(C),K.current=null)}));(0,e.useEffect)((function(){a&&U&&Y()}),[a,U,Y]),(0,e.useEffect)((function(){$&&G()}),[$,G]),et((function(){G()}));var X=Xe((function(){if(k&&q()&&H.isTopModal()){var e=ct();H.dialog&&e&&!dt(H.dialog,e)&&H.dialog.focus()}})),J=Xe((function(e){e.target===e.currentTarget&&(null==h||h(e),!0===d&&L())})),Z=Xe((function(e){p&&27===e.keyCode&&H.isTopModal()&&(null==v||v(e),e.defaultPrevented||L())})),ee=(0,e.useRef)(),te=(0,e.useRef)(),ne=m;if(!U||!(a||ne&&!$))return null;var re=Object.assign({role:l,ref:H.setDialogRef,"aria-modal":"dialog"===l||void 0},A,{style:s,className:i,tabIndex:-1}),ae=N?N(re):(0,x.jsx)("div",Object.assign({},re,{children:e.cloneElement(u,{role:"document"})}));ne&&(ae=(0,x.jsx)(ne,{appear:!0,unmountOnExit:!0,in:!!a,onExit:z,onExiting:F,onExited:function(){Q(!0),null==D||D.apply(void 0,arguments)},onEnter:M,onEntering:I,onEntered:B,children:ae}));var le=null;if(d){var ie=y;le=R({ref:H.setBackdropRef,onClick:J}),ie&&(le=(0,x.jsx)(ie,{appear:!0,in:!!a,children:le}))}return(0,x.jsx)(x.Fragment,{children:ft.createPortal((0,x.jsxs)(x.Fragment,{children:[le,ae]}),U)})}));Et.displayName="Modal";var Ct=Object.assign(Et,{Manager:yt});function Nt(e,t){for(;!Object.prototype.hasOwnProperty.call(e,t)&&null!==(e=c(e)););return e}function _t(){return _t="undefined"!==typeof Reflect&&Reflect.get?Reflect.get.bind():function(e,t,n){var r=Nt(e,t);if(r){var a=Object.getOwnPropertyDescriptor(r,t);return a.get?a.get.call(arguments.length<3?e:n):a.value}},_t.apply(this,arguments)}var Rt=Function.prototype.bind.call(Function.prototype.call,[].slice);function jt(e,t){return Rt(e.querySelectorAll(t))}function Pt(e,t){return e.replace(new RegExp("(^|\\s)"+t+"(?:\\s|$)","g"),"$1").replace(/\s+/g," ").replace(/^\s*|\s*$/g,"")}var Tt,Ot=".fixed-top, .fixed-bottom, .is-fixed, .sticky-top",Lt=".sticky-top",zt=".navbar-toggler",Dt=function(e){u(n,e);var t=p(n);function n(){return r(this,n),t.apply(this,arguments)}return i(n,[{key:"adjustAndStore",value:function(e,t,n){var r=t.style[e];t.dataset[e]=r,lt(t,h({},e,"".concat(parseFloat(lt(t,e))+n,"px")))}},{key:"restore",value:function(e,t){var n=t.dataset[e];void 0!==n&&(delete t.dataset[e],lt(t,h({},e,n)))}},{key:"setContainerStyle",value:function(e){var t=this;_t(c(n.prototype),"setContainerStyle",this).call(this,e);var r,a,o=this.getElement();if(a="modal-open",(r=o).classList?r.classList.add(a):function(e,t){return e.classList?!!t&&e.classList.contains(t):-1!==(" "+(e.className.baseVal||e.className)+" ").indexOf(" "+t+" ")}(r,a)||("string"===typeof r.className?r.className=r.className+" "+a:r.setAttribute("class",(r.className&&r.className.baseVal||"")+" "+a)),e.scrollBarWidth){var l=this.isRTL?"paddingLeft":"paddingRight",i=this.isRTL?"marginLeft":"marginRight";jt(o,Ot).forEach((function(n){return t.adjustAndStore(l,n,e.scrollBarWidth)})),jt(o,Lt).forEach((function(n){return t.adjustAndStore(i,n,-e.scrollBarWidth)})),jt(o,zt).forEach((function(n){return t.adjustAndStore(i,n,e.scrollBarWidth)}))}}},{key:"removeContainerStyle",value:function(e){var t=this;_t(c(n.prototype),"removeContainerStyle",this).call(this,e);var r,a,o=this.getElement();a="modal-open",(r=o).classList?r.classList.remove(a):"string"===typeof r.className?r.className=Pt(r.className,a):r.setAttribute("class",Pt(r.className&&r.className.baseVal||"",a));var l=this.isRTL?"paddingLeft":"paddingRight",i=this.isRTL?"marginLeft":"marginRight";jt(o,Ot).forEach((function(e){return t.restore(l,e)})),jt(o,Lt).forEach((function(e){return t.restore(i,e)})),jt(o,zt).forEach((function(e){return t.restore(i,e)}))}}]),n}(yt);var Ft=!1,Mt=e.createContext(null),It="unmounted",Bt="exited",At="entering",Ut="entered",Ht="exiting",qt=function(t){var n,r;function a(e,n){var r;r=t.call(this,e,n)||this;var a,o=n&&!n.isMounting?e.enter:e.appear;return r.appearStatus=null,e.in?o?(a=Bt,r.appearStatus=At):a=Ut:a=e.unmountOnExit||e.mountOnEnter?It:Bt,r.state={status:a},r.nextCallback=null,r}r=t,(n=a).prototype=Object.create(r.prototype),n.prototype.constructor=n,s(n,r),a.getDerivedStateFromProps=function(e,t){return e.in&&t.status===It?{status:Bt}:null};var o=a.prototype;return o.componentDidMount=function(){this.updateStatus(!0,this.appearStatus)},o.componentDidUpdate=function(e){var t=null;if(e!==this.props){var n=this.state.status;this.props.in?n!==At&&n!==Ut&&(t=At):n!==At&&n!==Ut||(t=Ht)}this.updateStatus(!1,t)},o.componentWillUnmount=function(){this.cancelNextCallback()},o.getTimeouts=function(){var e,t,n,r=this.props.timeout;return e=t=n=r,null!=r&&"number"!==typeof r&&(e=r.exit,t=r.enter,n=void 0!==r.appear?r.appear:t),{exit:e,enter:t,appear:n}},o.updateStatus=function(e,t){if(void 0===e&&(e=!1),null!==t)if(this.cancelNextCallback(),t===At){if(this.props.unmountOnExit||this.props.mountOnEnter){var n=this.props.nodeRef?this.props.nodeRef.current:ft.findDOMNode(this);n&&function(e){e.scrollTop}(n)}this.performEnter(e)}else this.performExit();else this.props.unmountOnExit&&this.state.status===Bt&&this.setState({status:It})},o.performEnter=function(e){var t=this,n=this.props.enter,r=this.context?this.context.isMounting:e,a=this.props.nodeRef?[r]:[ft.findDOMNode(this),r],o=a[0],l=a[1],i=this.getTimeouts(),s=r?i.appear:i.enter;!e&&!n||Ft?this.safeSetState({status:Ut},(function(){t.props.onEntered(o)})):(this.props.onEnter(o,l),this.safeSetState({status:At},(function(){t.props.onEntering(o,l),t.onTransitionEnd(s,(function(){t.safeSetState({status:Ut},(function(){t.props.onEntered(o,l)}))}))})))},o.performExit=function(){var e=this,t=this.props.exit,n=this.getTimeouts(),r=this.props.nodeRef?void 0:ft.findDOMNode(this);t&&!Ft?(this.props.onExit(r),this.safeSetState({status:Ht},(function(){e.props.onExiting(r),e.onTransitionEnd(n.exit,(function(){e.safeSetState({status:Bt},(function(){e.props.onExited(r)}))}))}))):this.safeSetState({status:Bt},(function(){e.props.onExited(r)}))},o.cancelNextCallback=function(){null!==this.nextCallback&&(this.nextCallback.cancel(),this.nextCallback=null)},o.safeSetState=function(e,t){t=this.setNextCallback(t),this.setState(e,t)},o.setNextCallback=function(e){var t=this,n=!0;return this.nextCallback=function(r){n&&(n=!1,t.nextCallback=null,e(r))},this.nextCallback.cancel=function(){n=!1},this.nextCallback},o.onTransitionEnd=function(e,t){this.setNextCallback(t);var n=this.props.nodeRef?this.props.nodeRef.current:ft.findDOMNode(this),r=null==e&&!this.props.addEndListener;if(n&&!r){if(this.props.addEndListener){var a=this.props.nodeRef?[this.nextCallback]:[n,this.nextCallback],o=a[0],l=a[1];this.props.addEndListener(o,l)}null!=e&&setTimeout(this.nextCallback,e)}else setTimeout(this.nextCallback,0)},o.render=function(){var t=this.state.status;if(t===It)return null;var n=this.props,r=n.children,a=(n.in,n.mountOnEnter,n.unmountOnExit,n.appear,n.enter,n.exit,n.timeout,n.addEndListener,n.onEnter,n.onEntering,n.onEntered,n.onExit,n.onExiting,n.onExited,n.nodeRef,y(n,["children","in","mountOnEnter","unmountOnExit","appear","enter","exit","timeout","addEndListener","onEnter","onEntering","onEntered","onExit","onExiting","onExited","nodeRef"]));return e.createElement(Mt.Provider,{value:null},"function"===typeof r?r(t,a):e.cloneElement(e.Children.only(r),a))},a}(e.Component);function Vt(){}qt.contextType=Mt,qt.propTypes={},qt.defaultProps={in:!1,mountOnEnter:!1,unmountOnExit:!1,appear:!1,enter:!0,exit:!0,onEnter:Vt,onEntering:Vt,onEntered:Vt,onExit:Vt,onExiting:Vt,onExited:Vt},qt.UNMOUNTED=It,qt.EXITED=Bt,qt.ENTERING=At,qt.ENTERED=Ut,qt.EXITING=Ht;var Wt=qt;function $t(e,t){var n=lt(e,t)||"",r=-1===n.indexOf("ms")?1e3:1;return parseFloat(n)*r}function Qt(e,t){var n=$t(e,"transitionDuration"),r=$t(e,"transitionDelay"),a=ut(e,(function(n){n.target===e&&(a(),t(n))}),n+r)}var Kt,Yt=["onEnter","onEntering","onEntered","onExit","onExiting","onExited","addEndListener","children","childRef"],Gt=e.forwardRef((function(t,n){var r=t.onEnter,a=t.onEntering,o=t.onEntered,l=t.onExit,i=t.onExiting,s=t.onExited,u=t.addEndListener,c=t.children,d=t.childRef,f=g(t,Yt),p=(0,e.useRef)(null),h=Ze(p,d),v=function(e){var t;h((t=e)&&"setState"in t?ft.findDOMNode(t):null!=t?t:null)},y=function(e){return function(t){e&&p.current&&e(p.current,t)}},b=(0,e.useCallback)(y(r),[r]),w=(0,e.useCallback)(y(a),[a]),k=(0,e.useCallback)(y(o),[o]),S=(0,e.useCallback)(y(l),[l]),E=(0,e.useCallback)(y(i),[i]),C=(0,e.useCallback)(y(s),[s]),N=(0,e.useCallback)(y(u),[u]);return(0,x.jsx)(Wt,m(m({ref:n},f),{},{onEnter:b,onEntered:k,onEntering:w,onExit:S,onExited:C,onExiting:E,addEndListener:N,nodeRef:p,children:"function"===typeof c?function(e,t){return c(e,m(m({},t),{},{ref:v}))}:e.cloneElement(c,{ref:v})}))})),Xt=["className","children","transitionClasses"],Jt=(h(Kt={},At,"show"),h(Kt,Ut,"show"),Kt),Zt=e.forwardRef((function(t,n){var r=t.className,a=t.children,o=t.transitionClasses,l=void 0===o?{}:o,i=g(t,Xt),s=(0,e.useCallback)((function(e,t){!function(e){e.offsetHeight}(e),null==i.onEnter||i.onEnter(e,t)}),[i]);return(0,x.jsx)(Gt,m(m({ref:n,addEndListener:Qt},i),{},{onEnter:s,childRef:a.ref,children:function(t,n){return e.cloneElement(a,m(m({},n),{},{className:w()("fade",r,a.props.className,Jt[t],l[t])}))}}))}));Zt.defaultProps={in:!1,timeout:300,mountOnEnter:!1,unmountOnExit:!1,appear:!1},Zt.displayName="Fade";var en=Zt,tn=J("modal-body"),nn=e.createContext({onHide:function(){}}),rn=["bsPrefix","className","contentClassName","centered","size","fullscreen","children","scrollable"],an=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.className,a=e.contentClassName,o=e.centered,l=e.size,i=e.fullscreen,s=e.children,u=e.scrollable,c=g(e,rn);n=E(n,"modal");var d="".concat(n,"-dialog"),f="string"===typeof i?"".concat(n,"-fullscreen-").concat(i):"".concat(n,"-fullscreen");return(0,x.jsx)("div",m(m({},c),{},{ref:t,className:w()(d,r,l&&"".concat(n,"-").concat(l),o&&"".concat(d,"-centered"),u&&"".concat(d,"-scrollable"),i&&f),children:(0,x.jsx)("div",{className:w()("".concat(n,"-content"),a),children:s})}))}));an.displayName="ModalDialog";var on=an,ln=J("modal-footer"),sn=["className","variant"],un={"aria-label":T().string,onClick:T().func,variant:T().oneOf(["white"])},cn=e.forwardRef((function(e,t){var n=e.className,r=e.variant,a=g(e,sn);return(0,x.jsx)("button",m({ref:t,type:"button",className:w()("btn-close",r&&"btn-close-".concat(r),n)},a))}));cn.displayName="CloseButton",cn.propTypes=un,cn.defaultProps={"aria-label":"Close"};var dn=cn,fn=["closeLabel","closeVariant","closeButton","onHide","children"],pn=e.forwardRef((function(t,n){var r=t.closeLabel,a=t.closeVariant,o=t.closeButton,l=t.onHide,i=t.children,s=g(t,fn),u=(0,e.useContext)(nn),c=Xe((function(){null==u||u.onHide(),null==l||l()}));return(0,x.jsxs)("div",m(m({ref:n},s),{},{children:[i,o&&(0,x.jsx)(dn,{"aria-label":r,variant:a,onClick:c})]}))}));pn.defaultProps={closeLabel:"Close",closeButton:!1};var hn=pn,vn=["bsPrefix","className"],mn=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.className,a=g(e,vn);return n=E(n,"modal-header"),(0,x.jsx)(hn,m(m({ref:t},a),{},{className:w()(r,n)}))}));mn.displayName="ModalHeader",mn.defaultProps={closeLabel:"Close",closeButton:!1};var yn=mn,gn=function(t){return e.forwardRef((function(e,n){return(0,x.jsx)("div",m(m({},e),{},{ref:n,className:w()(e.className,t)}))}))},bn=J("modal-title",{Component:gn("h4")}),wn=["bsPrefix","className","style","dialogClassName","contentClassName","children","dialogAs","aria-labelledby","aria-describedby","aria-label","show","animation","backdrop","keyboard","onEscapeKeyDown","onShow","onHide","container","autoFocus","enforceFocus","restoreFocus","restoreFocusOptions","onEntered","onExit","onExiting","onEnter","onEntering","onExited","backdropClassName","manager"],xn={show:!1,backdrop:!0,keyboard:!0,autoFocus:!0,enforceFocus:!0,restoreFocus:!0,animation:!0,dialogAs:on};function kn(e){return(0,x.jsx)(en,m(m({},e),{},{timeout:null}))}function Sn(e){return(0,x.jsx)(en,m(m({},e),{},{timeout:null}))}var En=e.forwardRef((function(t,n){var r=t.bsPrefix,a=t.className,o=t.style,l=t.dialogClassName,i=t.contentClassName,s=t.children,u=t.dialogAs,c=t["aria-labelledby"],d=t["aria-describedby"],f=t["aria-label"],p=t.show,h=t.animation,v=t.backdrop,y=t.keyboard,b=t.onEscapeKeyDown,k=t.onShow,C=t.onHide,N=t.container,_=t.autoFocus,R=t.enforceFocus,j=t.restoreFocus,P=t.restoreFocusOptions,T=t.onEntered,O=t.onExit,L=t.onExiting,z=t.onEnter,D=t.onEntering,F=t.onExited,M=t.backdropClassName,I=t.manager,B=g(t,wn),A=oe((0,e.useState)({}),2),U=A[0],H=A[1],q=oe((0,e.useState)(!1),2),V=q[0],W=q[1],$=(0,e.useRef)(!1),Q=(0,e.useRef)(!1),K=(0,e.useRef)(null),Y=oe((0,e.useState)(null),2),G=Y[0],X=Y[1],J=Ze(n,X),Z=Xe(C),ee="rtl"===(0,e.useContext)(S).dir;r=E(r,"modal");var te=(0,e.useMemo)((function(){return{onHide:Z}}),[Z]);function ne(){return I||function(e){return Tt||(Tt=new Dt(e)),Tt}({isRTL:ee})}function re(e){if(Ue){var t=ne().getScrollbarWidth()>0,n=e.scrollHeight>$e(e).documentElement.clientHeight;H({paddingRight:t&&!n?Ye():void 0,paddingLeft:!t&&n?Ye():void 0})}}var ae=Xe((function(){G&&re(G.dialog)}));et((function(){Ke(window,"resize",ae),null==K.current||K.current()}));var le=function(){$.current=!0},ie=function(e){$.current&&G&&e.target===G.dialog&&(Q.current=!0),$.current=!1},se=function(){W(!0),K.current=ut(G.dialog,(function(){W(!1)}))},ue=function(e){"static"!==v?Q.current||e.target!==e.currentTarget?Q.current=!1:null==C||C():function(e){e.target===e.currentTarget&&se()}(e)},ce=(0,e.useCallback)((function(e){return(0,x.jsx)("div",m(m({},e),{},{className:w()("".concat(r,"-backdrop"),M,!h&&"show")}))}),[h,M,r]),de=m(m({},o),U);de.display="block";return(0,x.jsx)(nn.Provider,{value:te,children:(0,x.jsx)(Ct,{show:p,ref:J,backdrop:v,container:N,keyboard:!0,autoFocus:_,enforceFocus:R,restoreFocus:j,restoreFocusOptions:P,onEscapeKeyDown:function(e){y?null==b||b(e):(e.preventDefault(),"static"===v&&se())},onShow:k,onHide:C,onEnter:function(e,t){e&&re(e),null==z||z(e,t)},onEntering:function(e,t){null==D||D(e,t),We(window,"resize",ae)},onEntered:T,onExit:function(e){null==K.current||K.current(),null==O||O(e)},onExiting:L,onExited:function(e){e&&(e.style.display=""),null==F||F(e),Ke(window,"resize",ae)},manager:ne(),transition:h?kn:void 0,backdropTransition:h?Sn:void 0,renderBackdrop:ce,renderDialog:function(e){return(0,x.jsx)("div",m(m({role:"dialog"},e),{},{style:de,className:w()(a,r,V&&"".concat(r,"-static"),!h&&"show"),onClick:v?ue:void 0,onMouseUp:ie,"aria-label":f,"aria-labelledby":c,"aria-describedby":d,children:(0,x.jsx)(u,m(m({},B),{},{onMouseDown:le,className:l,contentClassName:i,children:s}))}))}})})}));En.displayName="Modal",En.defaultProps=xn;var Cn=Object.assign(En,{Body:tn,Header:yn,Title:bn,Footer:ln,Dialog:on,TRANSITION_DURATION:300,BACKDROP_TRANSITION_DURATION:150}),Nn=["bsPrefix","className","as"],_n=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.className,a=e.as,o=void 0===a?"div":a,l=g(e,Nn),i=E(n,"row"),s=C(),u=N(),c="".concat(i,"-cols"),d=[];return s.forEach((function(e){var t,n=l[e];delete l[e],t=null!=n&&"object"===typeof n?n.cols:n;var r=e!==u?"-".concat(e):"";null!=t&&d.push("".concat(c).concat(r,"-").concat(t))})),(0,x.jsx)(o,m(m({ref:t},l),{},{className:w().apply(void 0,[r,i].concat(d))}))}));_n.displayName="Row";var Rn=_n,jn=function(e){u(n,e);var t=p(n);function n(e){var a;return r(this,n),(a=t.call(this,e)).state={show:!1,rule:{method:"ALL",url:"",action:1},haveRules:!1},a.handleClose=a.handleClose.bind(d(a)),a.handleShow=a.handleShow.bind(d(a)),a.handleSave=a.handleSave.bind(d(a)),a}return i(n,[{key:"handleClose",value:function(){this.setState({show:!1})}},{key:"handleShow",value:function(){this.setState({show:!0})}},{key:"handleSave",value:function(){var e=this.state.rule,t=[];e.url&&t.push({method:"ALL"===e.method?"":e.method,url:e.url,action:e.action}),this.props.onSave(t),this.handleClose(),this.setState({haveRules:!!t.length})}},{key:"render",value:function(){var e=this,t=this.state,n=t.rule,r=t.haveRules?"success":"primary";return(0,x.jsxs)("div",{children:[(0,x.jsx)(Fe,{variant:r,size:"sm",onClick:this.handleShow,children:"BreakPoint"}),(0,x.jsxs)(Cn,{show:this.state.show,onHide:this.handleClose,children:[(0,x.jsx)(Cn.Header,{closeButton:!0,children:(0,x.jsx)(Cn.Title,{children:"Set BreakPoint"})}),(0,x.jsxs)(Cn.Body,{children:[(0,x.jsxs)(Pe.Group,{as:Rn,children:[(0,x.jsx)(Pe.Label,{column:!0,sm:2,children:"Method"}),(0,x.jsx)(ue,{sm:10,children:(0,x.jsxs)(Pe.Control,{as:"select",value:n.method,onChange:function(t){e.setState({rule:m(m({},n),{},{method:t.target.value})})},children:[(0,x.jsx)("option",{children:"ALL"}),(0,x.jsx)("option",{children:"GET"}),(0,x.jsx)("option",{children:"POST"}),(0,x.jsx)("option",{children:"PUT"}),(0,x.jsx)("option",{children:"DELETE"})]})})]}),(0,x.jsxs)(Pe.Group,{as:Rn,children:[(0,x.jsx)(Pe.Label,{column:!0,sm:2,children:"URL"}),(0,x.jsx)(ue,{sm:10,children:(0,x.jsx)(Pe.Control,{value:n.url,onChange:function(t){e.setState({rule:m(m({},n),{},{url:t.target.value})})}})})]}),(0,x.jsxs)(Pe.Group,{as:Rn,children:[(0,x.jsx)(Pe.Label,{column:!0,sm:2,children:"Action"}),(0,x.jsx)(ue,{sm:10,children:(0,x.jsxs)(Pe.Control,{as:"select",value:n.action,onChange:function(t){e.setState({rule:m(m({},n),{},{action:parseInt(t.target.value)})})},children:[(0,x.jsx)("option",{value:"1",children:"Request"}),(0,x.jsx)("option",{value:"2",children:"Response"}),(0,x.jsx)("option",{value:"3",children:"Both"})]})})]})]}),(0,x.jsxs)(Cn.Footer,{children:[(0,x.jsx)(Fe,{variant:"secondary",onClick:this.handleClose,children:"Close"}),(0,x.jsx)(Fe,{variant:"primary",onClick:this.handleSave,children:"Save"})]})]})]})}}]),n}(e.Component),Pn=function(e){return!!e&&(!!e.header&&(!!e.header["Content-Type"]&&/text|javascript|json|x-www-form-urlencoded|xml|form-data/.test(e.header["Content-Type"].join(""))))},Tn=function(e){return e?isNaN(e)||e<=0?"0":e<1024?"".concat(e," B"):e<1048576?"".concat((e/1024).toFixed(2)," KB"):"".concat((e/1048576).toFixed(2)," MB"):"0"},On=function(e){for(var t="",n=new Uint8Array(e),r=n.byteLength,a=0;a<r;a++)t+=String.fromCharCode(n[a]);return btoa(t)},Ln=function(e){var t="",n=new Uint8Array(e),r=n.byteLength,a="";t+="00000000:  ";for(var o=0;o<r;o++)t+=n[o].toString(16).padStart(2,"0")+" ",n[o]>=32&&n[o]<=126?a+=String.fromCharCode(n[o]):a+=".",(o+1)%16===0?(t+="   "+a,a="",t+="\n".concat((o+1).toString(16).padStart(8,"0"),":  ")):(o+1)%8===0&&(t+="  ");if(a.length>0){for(var l=a.length;l<16;l++)t+="   ",(l+1)%8===0&&(t+="  ");t+=" "+a}return t};var zn=function(e){u(n,e);var t=p(n);function n(){return r(this,n),t.apply(this,arguments)}return i(n,[{key:"shouldComponentUpdate",value:function(e){return e.isSelected!==this.props.isSelected||!function(e,t){if(e===t)return!0;var n=Object.keys(e),r=Object.keys(t);if(n.length!==r.length)return!1;for(var a=0;a<n.length;a++){var o=n[a];if(void 0===t[o]||e[o]!==t[o])return!1}return!0}(e.flow,this.props.flow)}},{key:"render",value:function(){var e=this,t=this.props.flow,n=[];return this.props.isSelected?n.push("tr-selected"):t.waitIntercept?n.push("tr-wait-intercept"):t.warn&&n.push("tr-wait-warn"),(0,x.jsxs)("tr",{className:n.length?n.join(" "):void 0,onClick:function(){e.props.onShowDetail()},children:[(0,x.jsx)("td",{children:t.no}),(0,x.jsx)("td",{children:t.method}),(0,x.jsx)("td",{children:t.host}),(0,x.jsx)("td",{children:t.path}),(0,x.jsx)("td",{children:t.contentType}),(0,x.jsx)("td",{children:t.statusCode}),(0,x.jsx)("td",{children:t.size}),(0,x.jsx)("td",{children:t.costTime})]})}}]),n}(e.Component),Dn=zn,Fn=n(400),Mn=n(998),In=n.n(Mn),Bn=n(779),An=n.n(Bn);function Un(e,t){var n="undefined"!==typeof Symbol&&e[Symbol.iterator]||e["@@iterator"];if(!n){if(Array.isArray(e)||(n=ae(e))||t&&e&&"number"===typeof e.length){n&&(e=n);var r=0,a=function(){};return{s:a,n:function(){return r>=e.length?{done:!0}:{done:!1,value:e[r++]}},e:function(e){throw e},f:a}}throw new TypeError("Invalid attempt to iterate non-iterable instance.\nIn order to be iterable, non-array objects must have a [Symbol.iterator]() method.")}var o,l=!0,i=!1;return{s:function(){n=n.call(e)},n:function(){var e=n.next();return l=e.done,e},e:function(e){i=!0,o=e},f:function(){try{l||null==n.return||n.return()}finally{if(i)throw o}}}}function Hn(){return Hn=Object.assign?Object.assign.bind():function(e){for(var t=1;t<arguments.length;t++){var n=arguments[t];for(var r in n)Object.prototype.hasOwnProperty.call(n,r)&&(e[r]=n[r])}return e},Hn.apply(this,arguments)}n(176);function qn(e){return"default"+e.charAt(0).toUpperCase()+e.substr(1)}function Vn(e){var t=function(e,t){if("object"!==typeof e||null===e)return e;var n=e[Symbol.toPrimitive];if(void 0!==n){var r=n.call(e,t||"default");if("object"!==typeof r)return r;throw new TypeError("@@toPrimitive must return a primitive value.")}return("string"===t?String:Number)(e)}(e,"string");return"symbol"===typeof t?t:String(t)}function Wn(t,n){return Object.keys(n).reduce((function(r,a){var o,l=r,i=l[qn(a)],s=l[a],u=y(l,[qn(a),a].map(Vn)),c=n[a],d=function(t,n,r){var a=(0,e.useRef)(void 0!==t),o=(0,e.useState)(n),l=o[0],i=o[1],s=void 0!==t,u=a.current;return a.current=s,!s&&u&&l!==n&&i(n),[s?t:l,(0,e.useCallback)((function(e){for(var t=arguments.length,n=new Array(t>1?t-1:0),a=1;a<t;a++)n[a-1]=arguments[a];r&&r.apply(void 0,[e].concat(n)),i(e)}),[r])]}(s,i,t[c]),f=d[0],p=d[1];return Hn({},u,((o={})[a]=f,o[c]=p,o))}),t)}function $n(){var e=this.constructor.getDerivedStateFromProps(this.props,this.state);null!==e&&void 0!==e&&this.setState(e)}function Qn(e){this.setState(function(t){var n=this.constructor.getDerivedStateFromProps(e,t);return null!==n&&void 0!==n?n:null}.bind(this))}function Kn(e,t){try{var n=this.props,r=this.state;this.props=e,this.state=t,this.__reactInternalSnapshotFlag=!0,this.__reactInternalSnapshot=this.getSnapshotBeforeUpdate(n,r)}finally{this.props=n,this.state=r}}$n.__suppressDeprecationWarning=!0,Qn.__suppressDeprecationWarning=!0,Kn.__suppressDeprecationWarning=!0;var Yn="undefined"!==typeof n.g&&n.g.navigator&&"ReactNative"===n.g.navigator.product;"undefined"!==typeof document||Yn?e.useLayoutEffect:e.useEffect,new WeakMap;var Gn=["onKeyDown"];var Xn=e.forwardRef((function(e,t){var n,r=e.onKeyDown,a=function(e,t){if(null==e)return{};var n,r,a={},o=Object.keys(e);for(r=0;r<o.length;r++)n=o[r],t.indexOf(n)>=0||(a[n]=e[n]);return a}(e,Gn),o=oe(Oe(Object.assign({tagName:"a"},a)),1)[0],l=Xe((function(e){o.onKeyDown(e),null==r||r(e)}));return(n=a.href)&&"#"!==n.trim()&&"button"!==a.role?(0,x.jsx)("a",Object.assign({ref:t},a,{onKeyDown:r})):(0,x.jsx)("a",Object.assign({ref:t},a,o,{onKeyDown:l}))}));Xn.displayName="Anchor";var Jn=Xn,Zn=["bsPrefix","show","closeLabel","closeVariant","className","children","variant","onClose","dismissible","transition"],er=gn("h4");er.displayName="DivStyledAsH4";var tr=J("alert-heading",{Component:er}),nr=J("alert-link",{Component:Jn}),rr={variant:"primary",show:!0,transition:en,closeLabel:"Close alert"},ar=e.forwardRef((function(e,t){var n=Wn(e,{show:"onClose"}),r=n.bsPrefix,a=n.show,o=n.closeLabel,l=n.closeVariant,i=n.className,s=n.children,u=n.variant,c=n.onClose,d=n.dismissible,f=n.transition,p=g(n,Zn),h=E(r,"alert"),v=Xe((function(e){c&&c(!1,e)})),y=!0===f?en:f,b=(0,x.jsxs)("div",m(m({role:"alert"},y?void 0:p),{},{ref:t,className:w()(i,h,u&&"".concat(h,"-").concat(u),d&&"".concat(h,"-dismissible")),children:[d&&(0,x.jsx)(dn,{onClick:v,"aria-label":o,variant:l}),s]}));return y?(0,x.jsx)(y,m(m({unmountOnExit:!0},p),{},{ref:void 0,in:a,children:b})):a?b:null}));ar.displayName="Alert",ar.defaultProps=rr;var or,lr=Object.assign(ar,{Link:nr,Heading:tr}),ir=["body"],sr=["body"];!function(e){e[e.CONN=0]="CONN",e[e.CONN_CLOSE=5]="CONN_CLOSE",e[e.REQUEST=1]="REQUEST",e[e.REQUEST_BODY=2]="REQUEST_BODY",e[e.RESPONSE=3]="RESPONSE",e[e.RESPONSE_BODY=4]="RESPONSE_BODY"}(or||(or={}));var ur,cr=[or.CONN,or.CONN_CLOSE,or.REQUEST,or.REQUEST_BODY,or.RESPONSE,or.RESPONSE_BODY];!function(e){e[e.CHANGE_REQUEST=11]="CHANGE_REQUEST",e[e.CHANGE_RESPONSE=12]="CHANGE_RESPONSE",e[e.DROP_REQUEST=13]="DROP_REQUEST",e[e.DROP_RESPONSE=14]="DROP_RESPONSE",e[e.CHANGE_BREAK_POINT_RULES=21]="CHANGE_BREAK_POINT_RULES"}(ur||(ur={}));var dr=function(e,t){if(e===ur.DROP_REQUEST||e===ur.DROP_RESPONSE){var n=new Uint8Array(38);return n[0]=2,n[1]=e,n.set((new TextEncoder).encode(t.id),2),n}var r,a;if(e===ur.CHANGE_REQUEST){var o=t.request;a=o.body,r=g(o,ir)}else{if(e!==ur.CHANGE_RESPONSE)throw new Error("invalid message type");var l=t.response;a=l.body,r=g(l,sr)}a instanceof ArrayBuffer&&(a=new Uint8Array(a));var i=a&&a.byteLength?a.byteLength:0;"Content-Encoding"in r.header&&delete r.header["Content-Encoding"],"Transfer-Encoding"in r.header&&delete r.header["Transfer-Encoding"],r.header["Content-Length"]=[String(i)];var s=(new TextEncoder).encode(JSON.stringify(r)),u=42+s.byteLength+4+i,c=new ArrayBuffer(u),d=new Uint8Array(c);d[0]=2,d[1]=e,d.set((new TextEncoder).encode(t.id),2),d.set(s,42),i&&d.set(a,42+s.byteLength+4);var f=new DataView(c);return f.setUint32(38,s.byteLength),f.setUint32(42+s.byteLength,i),d},fr=function(e){u(n,e);var t=p(n);function n(e){var a;return r(this,n),(a=t.call(this,e)).state={show:!1,alertMsg:"",content:""},a.handleClose=a.handleClose.bind(d(a)),a.handleShow=a.handleShow.bind(d(a)),a.handleSave=a.handleSave.bind(d(a)),a}return i(n,[{key:"showAlert",value:function(e){this.setState({alertMsg:e})}},{key:"handleClose",value:function(){this.setState({show:!1})}},{key:"handleShow",value:function(){var e=this.props.flow,t="";t="request"===(e.response?"response":"request")?function(e){var t="".concat(e.method," ").concat(e.url),n=Object.keys(e.header).map((function(t){var n=e.header[t].join(" \t ");return"".concat(t,": ").concat(n)})).join("\n"),r="";return e.body&&Pn(e)&&(r=(new TextDecoder).decode(e.body)),"".concat(t,"\n\n").concat(n,"\n\n").concat(r)}(e.request):function(e){var t="".concat(e.statusCode),n=Object.keys(e.header).map((function(t){var n=e.header[t].join(" \t ");return"".concat(t,": ").concat(n)})).join("\n"),r="";return e.body&&Pn(e)&&(r=(new TextDecoder).decode(e.body)),"".concat(t,"\n\n").concat(n,"\n\n").concat(r)}(e.response),this.setState({show:!0,alertMsg:"",content:t})}},{key:"handleSave",value:function(){var e=this.props.flow.response?"response":"request",t=this.state.content;if("request"===e){var n=function(e){var t=e.indexOf("\n\n");if(!(t<=0)){var n=oe(e.slice(0,t).split(" "),2),r=n[0],a=n[1];if(r&&a){var o=e.indexOf("\n\n",t+2);if(!(o<=0)){var l,i={},s=Un(e.slice(t+2,o).split("\n"));try{for(s.s();!(l=s.n()).done;){var u=oe(l.value.split(": "),2),c=u[0],d=u[1];if(!c||!d)return;i[c]=d.split(" \t ")}}catch(h){s.e(h)}finally{s.f()}var f,p=e.slice(o+2);return p&&(f=(new TextEncoder).encode(p)),{method:r,url:a,proto:"",header:i,body:f}}}}}(t);if(!n)return void this.showAlert("parse error");this.props.onChangeRequest(n),this.handleClose()}else{var r=function(e){var t=e.indexOf("\n\n");if(!(t<=0)){var n=e.slice(0,t),r=parseInt(n);if(!isNaN(r)){var a=e.indexOf("\n\n",t+2);if(!(a<=0)){var o,l={},i=Un(e.slice(t+2,a).split("\n"));try{for(i.s();!(o=i.n()).done;){var s=oe(o.value.split(": "),2),u=s[0],c=s[1];if(!u||!c)return;l[u]=c.split(" \t ")}}catch(p){i.e(p)}finally{i.f()}var d,f=e.slice(a+2);return f&&(d=(new TextEncoder).encode(f)),{statusCode:r,header:l,body:d}}}}}(t);if(!r)return void this.showAlert("parse error");this.props.onChangeResponse(r),this.handleClose()}}},{key:"render",value:function(){var e=this,t=this.props.flow;if(!t.waitIntercept)return null;var n=this.state.alertMsg,r=t.response?"response":"request";return(0,x.jsxs)("div",{className:"flow-wait-area",children:[(0,x.jsx)(Fe,{size:"sm",onClick:this.handleShow,children:"Edit"}),(0,x.jsx)(Fe,{size:"sm",onClick:function(){var n="response"===r?ur.CHANGE_RESPONSE:ur.CHANGE_REQUEST,a=dr(n,t);e.props.onMessage(a)},children:"Continue"}),(0,x.jsx)(Fe,{size:"sm",onClick:function(){var n="response"===r?ur.DROP_RESPONSE:ur.DROP_REQUEST,a=dr(n,t);e.props.onMessage(a)},children:"Drop"}),(0,x.jsxs)(Cn,{size:"lg",show:this.state.show,onHide:this.handleClose,children:[(0,x.jsx)(Cn.Header,{closeButton:!0,children:(0,x.jsxs)(Cn.Title,{children:["Edit ","request"===r?"Request":"Response"]})}),(0,x.jsxs)(Cn.Body,{children:[(0,x.jsx)(Pe.Group,{children:(0,x.jsx)(Pe.Control,{as:"textarea",rows:10,value:this.state.content,onChange:function(t){e.setState({content:t.target.value})}})}),n?(0,x.jsx)(lr,{variant:"danger",children:n}):null]}),(0,x.jsxs)(Cn.Footer,{children:[(0,x.jsx)(Fe,{variant:"secondary",onClick:this.handleClose,children:"Close"}),(0,x.jsx)(Fe,{variant:"primary",onClick:this.handleSave,children:"Save"})]})]})]})}}]),n}(e.Component),pr=function(e){u(n,e);var t=p(n);function n(e){var a;return r(this,n),(a=t.call(this,e)).state={flowTab:"Detail",copied:!1,requestBodyViewTab:"Raw",responseBodyLineBreak:!1},a}return i(n,[{key:"preview",value:function(){var e=this.props.flow;if(!e)return null;var t=e.response;if(!t)return null;if(!t.body||!t.body.byteLength)return(0,x.jsx)("div",{style:{color:"gray"},children:"No response"});var n=e.previewResponseBody();return n?"image"===n.type?(0,x.jsx)("img",{src:"data:image/png;base64,".concat(n.data)}):"json"===n.type?(0,x.jsx)("div",{children:(0,x.jsx)(An(),{data:n.data,keyStyle:"color: rgb(130,40,144);",stringStyle:"color: rgb(153,68,60);",valueStyle:"color: rgb(25,1,199);",booleanStyle:"color: rgb(94,105,192);"})}):(0,x.jsx)("div",{style:{color:"gray"},children:"Not support preview"}):(0,x.jsx)("div",{style:{color:"gray"},children:"Not support preview"})}},{key:"requestBodyPreview",value:function(){var e=this.props.flow;if(!e)return null;var t=e.previewRequestBody();return t?"json"===t.type?(0,x.jsx)("div",{children:(0,x.jsx)(An(),{data:t.data,keyStyle:"color: rgb(130,40,144);",stringStyle:"color: rgb(153,68,60);",valueStyle:"color: rgb(25,1,199);",booleanStyle:"color: rgb(94,105,192);"})}):"binary"===t.type?(0,x.jsx)("div",{children:(0,x.jsx)("pre",{children:t.data})}):(0,x.jsx)("div",{style:{color:"gray"},children:"Not support preview"}):(0,x.jsx)("div",{style:{color:"gray"},children:"Not support preview"})}},{key:"hexview",value:function(){var e=this.props.flow;if(!e)return null;var t=e.response;return t?t.body&&t.body.byteLength?(0,x.jsx)("pre",{children:e.hexviewResponseBody()}):(0,x.jsx)("div",{style:{color:"gray"},children:"No response"}):null}},{key:"detail",value:function(){var e=this.props.flow;if(!e)return null;var t=e.getConn();return(0,x.jsxs)("div",{children:[(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Flow Info"}),(0,x.jsx)("div",{className:"header-block-content",children:(0,x.jsxs)("p",{children:["Id: ",e.id]})})]}),t?(0,x.jsxs)(x.Fragment,{children:[t.serverConn?(0,x.jsx)(x.Fragment,{children:(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Server Connection"}),(0,x.jsxs)("div",{className:"header-block-content",children:[(0,x.jsxs)("p",{children:["Address: ",t.serverConn.address]}),(0,x.jsxs)("p",{children:["Resolved Address: ",t.serverConn.peername]})]})]})}):null,(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Client Connection"}),(0,x.jsx)("div",{className:"header-block-content",children:(0,x.jsxs)("p",{children:["Address: ",t.clientConn.address]})})]}),(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Connection Info"}),(0,x.jsxs)("div",{className:"header-block-content",children:[(0,x.jsxs)("p",{children:["Id: ",t.clientConn.id]}),(0,x.jsxs)("p",{children:["Intercept: ",t.intercept?"true":"false"]}),null==t.opening?null:(0,x.jsxs)("p",{children:["Opening: ",t.opening?"true":"false"]}),null==t.flowCount?null:(0,x.jsxs)("p",{children:["Flow Count: ",t.flowCount]})]})]})]}):null]})}},{key:"copyAsCurl",value:function(){var e=this,t=this.props.flow;return t?(0,x.jsx)(Fe,{size:"sm",variant:this.state.copied?"success":"primary",disabled:this.state.copied,onClick:function(){var n=(0,Fn.ZP)({url:t.request.url,method:t.request.method,headers:Object.keys(t.request.header).reduce((function(e,n){return e[n]=t.request.header[n][0],e}),{}),body:t.requestBody()});In()(n),e.setState({copied:!0},(function(){setTimeout((function(){e.setState({copied:!1})}),1e3)}))},children:this.state.copied?"Copied":"Copy as cURL"}):null}},{key:"render",value:function(){var e=this;if(!this.props.flow)return null;var t=this.props.flow,n=this.state.flowTab,r=t.request,a=t.response||{},o=[];return t.url&&t.url.search&&t.url.searchParams.forEach((function(e,t){o.push({key:t,value:e})})),(0,x.jsxs)("div",{className:"flow-detail",children:[(0,x.jsxs)("div",{className:"header-tabs",children:[(0,x.jsx)("span",{style:{position:"absolute",top:"2px",left:"0px",cursor:"pointer"},onClick:function(){e.props.onClose()},children:"x"}),(0,x.jsx)(fr,{flow:t,onChangeRequest:function(n){t.request.method=n.method,t.request.url=n.url,t.request.header=n.header,Pn(t.request)&&(t.request.body=n.body),e.props.onReRenderFlows()},onChangeResponse:function(n){t.response||(t.response={}),t.response.statusCode=n.statusCode,t.response.header=n.header,Pn(t.response)&&(t.response.body=n.body),e.props.onReRenderFlows()},onMessage:function(n){e.props.onMessage(n),t.waitIntercept=!1,e.props.onReRenderFlows()}}),(0,x.jsx)("div",{children:this.copyAsCurl()}),(0,x.jsxs)("div",{children:[(0,x.jsx)("span",{className:"Detail"===n?"selected":void 0,onClick:function(){e.setState({flowTab:"Detail"})},children:"Detail"}),(0,x.jsx)("span",{className:"Headers"===n?"selected":void 0,onClick:function(){e.setState({flowTab:"Headers"})},children:"Headers"}),(0,x.jsx)("span",{className:"Preview"===n?"selected":void 0,onClick:function(){e.setState({flowTab:"Preview"})},children:"Preview"}),(0,x.jsx)("span",{className:"Response"===n?"selected":void 0,onClick:function(){e.setState({flowTab:"Response"})},children:"Response"}),(0,x.jsx)("span",{className:"Hexview"===n?"selected":void 0,onClick:function(){e.setState({flowTab:"Hexview"})},children:"Hexview"})]})]}),(0,x.jsxs)("div",{style:{padding:"20px 25px"},children:["Headers"!==n?null:(0,x.jsxs)("div",{children:[(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"General"}),(0,x.jsxs)("div",{className:"header-block-content",children:[(0,x.jsxs)("p",{children:["Request URL: ",r.url]}),(0,x.jsxs)("p",{children:["Request Method: ",r.method]}),(0,x.jsxs)("p",{children:["Status Code: ","".concat(a.statusCode||"(pending)")]})]})]}),a.header?(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Response Headers"}),(0,x.jsx)("div",{className:"header-block-content",children:Object.keys(a.header).map((function(e){return(0,x.jsxs)("p",{children:[e,": ",a.header[e].join(" ")]},e)}))})]}):null,(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Request Headers"}),(0,x.jsx)("div",{className:"header-block-content",children:r.header?Object.keys(r.header).map((function(e){return(0,x.jsxs)("p",{children:[e,": ",r.header[e].join(" ")]},e)})):null})]}),o.length?(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Query String Parameters"}),(0,x.jsx)("div",{className:"header-block-content",children:o.map((function(e,t){var n=e.key,r=e.value;return(0,x.jsxs)("p",{children:[n,": ",r]},"".concat(n,"-").concat(t))}))})]}):null,r.body&&r.body.byteLength?(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Request Body"}),(0,x.jsx)("div",{className:"header-block-content",children:(0,x.jsxs)("div",{children:[(0,x.jsxs)("div",{className:"request-body-detail",style:{marginBottom:"15px"},children:[(0,x.jsx)("span",{className:"Raw"===this.state.requestBodyViewTab?"selected":void 0,onClick:function(){e.setState({requestBodyViewTab:"Raw"})},children:"Raw"}),(0,x.jsx)("span",{className:"Preview"===this.state.requestBodyViewTab?"selected":void 0,onClick:function(){e.setState({requestBodyViewTab:"Preview"})},children:"Preview"})]}),"Raw"!==this.state.requestBodyViewTab?null:(0,x.jsx)("div",{children:t.isTextRequest()?t.requestBody():(0,x.jsx)("span",{style:{color:"gray"},children:"Not text Request"})}),"Preview"!==this.state.requestBodyViewTab?null:(0,x.jsx)("div",{children:this.requestBodyPreview()})]})})]}):null]}),"Response"!==n?null:a.body&&a.body.byteLength?t.isTextResponse()?(0,x.jsxs)("div",{children:[(0,x.jsx)("div",{style:{marginBottom:"20px"},children:(0,x.jsx)(W,{inline:!0,type:"checkbox",checked:this.state.responseBodyLineBreak,onChange:function(t){e.setState({responseBodyLineBreak:t.target.checked})},label:"\u81ea\u52a8\u6362\u884c"})}),(0,x.jsx)("div",{style:{whiteSpace:this.state.responseBodyLineBreak?"pre-wrap":"pre"},children:t.responseBody()})]}):(0,x.jsx)("div",{style:{color:"gray"},children:"Not text response"}):(0,x.jsx)("div",{style:{color:"gray"},children:"No response"}),"Preview"!==n?null:(0,x.jsx)("div",{children:this.preview()}),"Hexview"!==n?null:(0,x.jsx)("div",{children:this.hexview()}),"Detail"!==n?null:(0,x.jsx)("div",{children:this.detail()})]})]})}}]),n}(e.Component),hr=function(e){u(n,e);var t=p(n);function n(e){var a;return r(this,n),(a=t.call(this,e)).x=0,a.w=0,a.state={width:e.width},a.mouseMoveHandler=a.mouseMoveHandler.bind(d(a)),a.mouseUpHandler=a.mouseUpHandler.bind(d(a)),a}return i(n,[{key:"mouseMoveHandler",value:function(e){var t=e.clientX-this.x;this.setState({width:this.w+t})}},{key:"mouseUpHandler",value:function(){document.removeEventListener("mousemove",this.mouseMoveHandler),document.removeEventListener("mouseup",this.mouseUpHandler)}},{key:"render",value:function(){var e=this;return(0,x.jsxs)("th",{style:{width:"".concat(this.state.width,"px"),position:"relative"},children:[this.props.children,(0,x.jsx)("div",{style:{position:"absolute",top:0,right:0,width:"5px",height:"100%",cursor:"col-resize",userSelect:"none"},onMouseDown:function(t){e.x=t.clientX,e.w=e.state.width,document.addEventListener("mousemove",e.mouseMoveHandler),document.addEventListener("mouseup",e.mouseUpHandler)}})]})}}]),n}(e.Component),vr=n(816),mr=n.n(vr),yr=["url","method","code","header","reqheader","resheader","body","reqbody","resbody","all"],gr=function(){function e(t){r(this,e),this.rule=void 0,(t=t.trim())&&(this.rule=mr().parse(t))}return i(e,[{key:"match",value:function(e){return!this.rule||this._match(e,this.rule)}},{key:"_match",value:function(e,t){if("keyword"===t.type)return this.getFlowFilterKeyword(t).match(e);if("not"===t.type)return!this._match(e,t.expr);if("and"===t.type)return this._match(e,t.left)&&this._match(e,t.right);if("or"===t.type)return this._match(e,t.left)||this._match(e,t.right);throw new Error("invalid rule type ".concat(t.type))}},{key:"getFlowFilterKeyword",value:function(e){return e.filter||(e.filter=new br(e.value)),e.filter}}]),e}(),br=function(){function e(t){if(r(this,e),this.keyword=void 0,this.scope="url",t=t.trim()){var n,a=Un(yr);try{for(a.s();!(n=a.n()).done;){var o=n.value;if(t.startsWith("".concat(o,":"))){this.scope=o,t=t.replace("".concat(o,":"),"").trim();break}}}catch(i){a.e(i)}finally{a.f()}if(t)if(t.startsWith("/")&&(t.endsWith("/")||t.endsWith("/i"))){var l;if(t.endsWith("i")&&(l="i",t=t.slice(0,-1)),!(t=t.slice(1,-1).trim()))return;this.keyword=new RegExp(t,l)}else this.keyword=t}}return i(e,[{key:"match",value:function(e){switch(this.scope){case"url":return this.matchUrl(e);case"method":return this.matchMethod(e);case"code":return this.matchCode(e);case"reqheader":return this.matchReqHeader(e);case"resheader":return this.matchResHeader(e);case"header":return this.matchHeader(e);case"reqbody":return this.matchReqBody(e);case"resbody":return this.matchResBody(e);case"body":return this.matchBody(e);case"all":return this.matchAll(e);default:throw new Error("invalid scope ".concat(this.scope))}}},{key:"matchUrl",value:function(e){return this.matchKeyword(e.request.url)}},{key:"matchMethod",value:function(e){return this.matchKeyword(e.request.method)||this.matchKeyword(e.request.method.toLowerCase())}},{key:"matchCode",value:function(e){return!!e.response&&this.matchKeyword(e.response.statusCode.toString())}},{key:"_matchHeader",value:function(e){var t=this;return Object.entries(e).some((function(e){var n=oe(e,2),r=n[0],a=n[1];return[r].concat(a).some((function(e){return t.matchKeyword(e)}))}))}},{key:"matchReqHeader",value:function(e){return this._matchHeader(e.request.header)}},{key:"matchResHeader",value:function(e){return!!e.response&&this._matchHeader(e.response.header)}},{key:"matchHeader",value:function(e){return this.matchReqHeader(e)||this.matchResHeader(e)}},{key:"matchReqBody",value:function(e){var t=e.requestBody();return!!t&&this.matchKeyword(t)}},{key:"matchResBody",value:function(e){var t=e.responseBody();return!!t&&this.matchKeyword(t)}},{key:"matchBody",value:function(e){return this.matchReqBody(e)||this.matchResBody(e)}},{key:"matchAll",value:function(e){return this.matchUrl(e)||this.matchMethod(e)||this.matchHeader(e)||this.matchBody(e)}},{key:"matchKeyword",value:function(e){return!this.keyword||!!e&&(this.keyword instanceof RegExp?this.keyword.test(e):e.includes(this.keyword))}}]),e}(),wr=function(){function e(t,n){r(this,e),this.no=void 0,this.id=void 0,this.connId=void 0,this.waitIntercept=void 0,this.request=void 0,this.response=null,this.url=void 0,this.path=void 0,this._size=0,this.size="0",this.headerContentLengthExist=!1,this.contentType="",this.startTime=Date.now(),this.endTime=0,this.costTime="(pending)",this.status=or.REQUEST,this._isTextRequest=void 0,this._isTextResponse=void 0,this._requestBody=void 0,this._hexviewRequestBody=null,this._responseBody=void 0,this._previewResponseBody=null,this._previewRequestBody=null,this._hexviewResponseBody=null,this.connMgr=void 0,this.conn=void 0,this.no=++e.curNo,this.id=t.id,this.waitIntercept=t.waitIntercept;var a=t.content;this.connId=a.connId,this.request=a.request;var o=this.request.url;o.startsWith("//")&&(o="http:"+o),this.url=new URL(o),this.path=this.url.pathname+this.url.search,this._isTextRequest=null,this._isTextResponse=null,this._requestBody=null,this._responseBody=null,this.connMgr=n}return i(e,[{key:"addRequestBody",value:function(e){return this.status=or.REQUEST_BODY,this.waitIntercept=e.waitIntercept,this.request.body=e.content,this}},{key:"addResponse",value:function(e){return this.status=or.RESPONSE,this.waitIntercept=e.waitIntercept,this.response=e.content,this.response&&this.response.header&&(null!=this.response.header["Content-Type"]&&(this.contentType=this.response.header["Content-Type"][0].split(";")[0],this.contentType.includes("javascript")&&(this.contentType="javascript")),null!=this.response.header["Content-Length"]&&(this.headerContentLengthExist=!0,this._size=parseInt(this.response.header["Content-Length"][0]),this.size=Tn(this._size))),this}},{key:"addResponseBody",value:function(e){return this.status=or.RESPONSE_BODY,this.waitIntercept=e.waitIntercept,this.response&&(this.response.body=e.content),this.endTime=Date.now(),this.costTime=String(this.endTime-this.startTime)+" ms",!this.headerContentLengthExist&&this.response&&this.response.body&&(this._size=this.response.body.byteLength,this.size=Tn(this._size)),this}},{key:"preview",value:function(){var e;return{no:this.no,id:this.id,waitIntercept:this.waitIntercept,host:this.url.host,path:this.path,method:this.request.method,statusCode:this.response?String(this.response.statusCode):"(pending)",size:this.size,costTime:this.costTime,contentType:this.contentType,warn:0===(null===(e=this.getConn())||void 0===e?void 0:e.flowCount)}}},{key:"isTextRequest",value:function(){return null!==this._isTextRequest||(this._isTextRequest=Pn(this.request)),this._isTextRequest}},{key:"requestBody",value:function(){return null!==this._requestBody?this._requestBody:this.isTextRequest()?this.status<or.REQUEST_BODY?"":(this._requestBody=(new TextDecoder).decode(this.request.body),this._requestBody):(this._requestBody="",this._requestBody)}},{key:"hexviewRequestBody",value:function(){var e,t;return null!==this._hexviewRequestBody?this._hexviewRequestBody:this.status<or.REQUEST_BODY?null:null!==(e=this.request)&&void 0!==e&&null!==(t=e.body)&&void 0!==t&&t.byteLength?(this._hexviewRequestBody=Ln(this.request.body),this._hexviewRequestBody):null}},{key:"isTextResponse",value:function(){return this.status<or.RESPONSE?null:(null!==this._isTextResponse||(this._isTextResponse=Pn(this.response)),this._isTextResponse)}},{key:"responseBody",value:function(){var e;return null!==this._responseBody?this._responseBody:this.status<or.RESPONSE?"":this.isTextResponse()?this.status<or.RESPONSE_BODY?"":(this._responseBody=(new TextDecoder).decode(null===(e=this.response)||void 0===e?void 0:e.body),this._responseBody):(this._responseBody="",this._responseBody)}},{key:"previewResponseBody",value:function(){var e,t,n;return this._previewResponseBody?this._previewResponseBody:this.status<or.RESPONSE_BODY?null:null!==(e=this.response)&&void 0!==e&&null!==(t=e.body)&&void 0!==t&&t.byteLength?(this.response.header["Content-Type"]&&(n=this.response.header["Content-Type"][0]),n?(n.startsWith("image/")?this._previewResponseBody={type:"image",data:On(this.response.body)}:n.includes("application/json")&&(this._previewResponseBody={type:"json",data:this.responseBody()}),this._previewResponseBody):null):null}},{key:"previewRequestBody",value:function(){var e;return this._previewRequestBody?this._previewRequestBody:this.status<or.REQUEST_BODY?null:null!==(e=this.request.body)&&void 0!==e&&e.byteLength?(this.isTextRequest()?/json/.test(this.request.header["Content-Type"].join(""))&&(this._previewRequestBody={type:"json",data:this.requestBody()}):this._previewRequestBody={type:"binary",data:this.hexviewRequestBody()},this._previewRequestBody):null}},{key:"hexviewResponseBody",value:function(){var e,t;return null!==this._hexviewResponseBody?this._hexviewResponseBody:this.status<or.RESPONSE_BODY?null:null!==(e=this.response)&&void 0!==e&&null!==(t=e.body)&&void 0!==t&&t.byteLength?(this._hexviewResponseBody=Ln(this.response.body),this._hexviewResponseBody):null}},{key:"getConn",value:function(){return this.conn||(this.conn=this.connMgr.get(this.connId)),this.conn}}]),e}();wr.curNo=0;var xr=function(){function e(){r(this,e),this.items=void 0,this._map=void 0,this.flowFilter=void 0,this.filterTimer=void 0,this.num=void 0,this.max=void 0,this.items=[],this._map=new Map,this.filterTimer=null,this.num=0,this.max=1e3}return i(e,[{key:"showList",value:function(){var e=this;return this.flowFilter?this.items.filter((function(t){return e.flowFilter.match(t)})):this.items}},{key:"add",value:function(e){if(e.no=++this.num,this.items.push(e),this._map.set(e.id,e),this.items.length>this.max){var t=this.items.shift();t&&this._map.delete(t.id)}}},{key:"get",value:function(e){return this._map.get(e)}},{key:"changeFilterLazy",value:function(e,t){var n=this;this.filterTimer&&(clearTimeout(this.filterTimer),this.filterTimer=null),this.filterTimer=setTimeout((function(){try{n.flowFilter=new gr(e),t(null)}catch(r){n.flowFilter=void 0,t(r)}}),300)}},{key:"clear",value:function(){this.items=[],this._map=new Map}}]),e}(),kr=function(){function e(){r(this,e),this._map=void 0,this._map=new Map}return i(e,[{key:"get",value:function(e){return this._map.get(e)}},{key:"add",value:function(e,t){this._map.set(e,t)}},{key:"delete",value:function(e){this._map.delete(e)}}]),e}(),Sr=[1,1,2,2,4,4,8,8,16,16,32,32],Er=function(t){u(a,t);var n=p(a);function a(t){var o;return r(this,a),(o=n.call(this,t)).connMgr=void 0,o.flowMgr=void 0,o.ws=void 0,o.wsUnmountClose=void 0,o.tableBottomRef=void 0,o.wsReconnCount=-1,o.connMgr=new kr,o.flowMgr=new xr,o.state={flows:o.flowMgr.showList(),flow:null,wsStatus:"close",filterInvalid:!1},o.ws=null,o.wsUnmountClose=!1,o.tableBottomRef=e.createRef(),o}return i(a,[{key:"componentDidMount",value:function(){this.initWs()}},{key:"componentWillUnmount",value:function(){this.ws&&(this.wsUnmountClose=!0,this.ws.close(),this.ws=null)}},{key:"initWs",value:function(){var e,t=this;this.ws||(this.setState({wsStatus:"connecting"}),e=new URL(document.URL).host,this.ws=new WebSocket("ws://".concat(e,"/echo")),this.ws.binaryType="arraybuffer",this.ws.onopen=function(){t.wsReconnCount=-1,t.setState({wsStatus:"open"})},this.ws.onerror=function(e){var n;console.error("ERROR:",e),null===(n=t.ws)||void 0===n||n.close()},this.ws.onclose=function(){if(t.setState({wsStatus:"close"}),!t.wsUnmountClose){t.wsReconnCount++,t.ws=null;var e=Sr[t.wsReconnCount]||Sr[Sr.length-1];console.info("will reconnect after ".concat(e," seconds")),setTimeout((function(){t.initWs()}),1e3*e)}},this.ws.onmessage=function(e){var n=function(e){if(e.byteLength<39)return null;var t=new Int8Array(e.slice(0,39));if(2!==t[0])return null;var n=t[1];if(!cr.includes(n))return null;var r={type:n,id:(new TextDecoder).decode(e.slice(2,38)),waitIntercept:1===t[38]};if(39===e.byteLength)return r;if(n===or.REQUEST_BODY||n===or.RESPONSE_BODY)return r.content=e.slice(39),r;if(n===or.CONN_CLOSE){var a=new DataView(e.slice(39));return r.content=a.getUint32(0,!1),r}var o,l=(new TextDecoder).decode(e.slice(39));try{o=JSON.parse(l)}catch(i){return null}return r.content=o,r}(e.data);if(n){if(n.type===or.CONN){var r=n.content;r.intercept&&(r.opening=!0),t.connMgr.add(n.id,r),t.setState({flows:t.state.flows})}else if(n.type===or.CONN_CLOSE){var a=t.connMgr.get(n.id);if(!a)return;a.opening=!1,a.flowCount=n.content,t.setState({flows:t.state.flows}),t.connMgr.delete(n.id)}else if(n.type===or.REQUEST){var o,l=new wr(n,t.connMgr);l.getConn(),t.flowMgr.add(l);var i=!1;null!==(o=t.tableBottomRef)&&void 0!==o&&o.current&&function(e){var t=window.innerWidth||document.documentElement.clientWidth,n=window.innerHeight||document.documentElement.clientHeight,r=e.getBoundingClientRect(),a=r.top,o=r.right,l=r.bottom,i=r.left;return a>=0&&i>=0&&o<=t&&l<=n}(t.tableBottomRef.current)&&(i=!0),t.setState({flows:t.flowMgr.showList()},(function(){var e,n;i&&(null===(e=t.tableBottomRef)||void 0===e||null===(n=e.current)||void 0===n||n.scrollIntoView({behavior:"auto"}))}))}else if(n.type===or.REQUEST_BODY){var s=t.flowMgr.get(n.id);if(!s)return;s.addRequestBody(n),t.setState({flows:t.state.flows})}else if(n.type===or.RESPONSE){var u=t.flowMgr.get(n.id);if(!u)return;u.getConn(),u.addResponse(n),t.setState({flows:t.state.flows})}else if(n.type===or.RESPONSE_BODY){var c=t.flowMgr.get(n.id);if(!c||!c.response)return;c.addResponseBody(n),t.setState({flows:t.state.flows})}}else console.error("parse error:",e.data)})}},{key:"render",value:function(){var e=this,t=this.state.flows;return(0,x.jsxs)("div",{className:"main-table-wrap",children:[(0,x.jsxs)("div",{className:"top-control",children:[(0,x.jsxs)("div",{style:{display:"flex",alignItems:"center"},children:[(0,x.jsx)("div",{style:{marginRight:"10px"},children:(0,x.jsx)(Fe,{size:"sm",onClick:function(){e.flowMgr.clear(),e.setState({flows:e.flowMgr.showList(),flow:null})},children:"Clear"})}),(0,x.jsx)("div",{style:{marginRight:"10px"},children:(0,x.jsx)(Pe.Control,{size:"sm",placeholder:"Filter",style:{width:"350px"},isInvalid:this.state.filterInvalid,onChange:function(t){var n=t.target.value;e.flowMgr.changeFilterLazy(n,(function(t){t&&console.log("changeFilterLazy error",t),e.setState({filterInvalid:!!t,flows:e.flowMgr.showList()})}))}})}),(0,x.jsx)("div",{style:{marginRight:"10px"},children:(0,x.jsx)(jn,{onSave:function(t){var n=function(e,t){if(e!==ur.CHANGE_BREAK_POINT_RULES)throw new Error("invalid message type");var n=(new TextEncoder).encode(JSON.stringify(t)),r=new Uint8Array(2+n.byteLength);return r[0]=2,r[1]=e,r.set(n,2),r}(ur.CHANGE_BREAK_POINT_RULES,t);e.ws&&e.ws.send(n)}})})]}),(0,x.jsxs)("div",{style:{display:"flex",alignItems:"center"},children:[(0,x.jsx)("div",{style:{marginRight:"10px"},children:"open"===this.state.wsStatus?(0,x.jsx)(Ae,{pill:!0,bg:"success",children:"on"}):(0,x.jsx)(Ae,{pill:!0,bg:"danger",children:"off"})}),(0,x.jsx)("a",{href:"https://github.com/lqqyt2423/go-mitmproxy",target:"_blank",rel:"noreferrer",children:(0,x.jsx)("img",{style:{height:"30px"},src:Me,alt:"GitHub Logo"})})]})]}),(0,x.jsxs)("div",{className:"table-wrap-div",children:[(0,x.jsxs)(j,{striped:!0,bordered:!0,size:"sm",style:{tableLayout:"fixed"},children:[(0,x.jsx)("thead",{children:(0,x.jsxs)("tr",{children:[(0,x.jsx)(hr,{width:50,children:"No"}),(0,x.jsx)(hr,{width:80,children:"Method"}),(0,x.jsx)(hr,{width:250,children:"Host"}),(0,x.jsx)(hr,{width:500,children:"Path"}),(0,x.jsx)(hr,{width:150,children:"Type"}),(0,x.jsx)(hr,{width:80,children:"Status"}),(0,x.jsx)(hr,{width:90,children:"Size"}),(0,x.jsx)(hr,{width:90,children:"Time"})]})}),(0,x.jsx)("tbody",{children:t.map((function(t){var n=t.preview();return(0,x.jsx)(Dn,{flow:n,isSelected:!(!e.state.flow||e.state.flow.id!==n.id),onShowDetail:function(){e.setState({flow:t})}},n.id)}))})]}),(0,x.jsx)("div",{ref:this.tableBottomRef,id:"hidden-bottom",style:{height:"0px",visibility:"hidden",marginBottom:"1px"}})]}),(0,x.jsx)(pr,{flow:this.state.flow,onClose:function(){e.setState({flow:null})},onReRenderFlows:function(){e.setState({flows:e.state.flows})},onMessage:function(t){e.ws&&e.ws.send(t)}})]})}}]),a}(e.Component),Cr=Er,Nr=function(e){e&&e instanceof Function&&n.e(496).then(n.bind(n,496)).then((function(t){var n=t.getCLS,r=t.getFID,a=t.getFCP,o=t.getLCP,l=t.getTTFB;n(e),r(e),a(e),o(e),l(e)}))};(0,t.s)(document.getElementById("root")).render((0,x.jsx)(Cr,{})),Nr()}()}();
-//# sourceMappingURL=main.dcfaa455.js.map
\ No newline at end of file
+/*! For license information please see main.62392d36.js.LICENSE.txt */
+!function(){var e={816:function(e){e.exports=function(){"use strict";function e(t,n,r,a){this.message=t,this.expected=n,this.found=r,this.location=a,this.name="SyntaxError","function"===typeof Error.captureStackTrace&&Error.captureStackTrace(this,e)}return function(e,t){function n(){this.constructor=e}n.prototype=t.prototype,e.prototype=new n}(e,Error),e.buildMessage=function(e,t){var n={literal:function(e){return'"'+a(e.text)+'"'},class:function(e){var t,n="";for(t=0;t<e.parts.length;t++)n+=e.parts[t]instanceof Array?o(e.parts[t][0])+"-"+o(e.parts[t][1]):o(e.parts[t]);return"["+(e.inverted?"^":"")+n+"]"},any:function(e){return"any character"},end:function(e){return"end of input"},other:function(e){return e.description}};function r(e){return e.charCodeAt(0).toString(16).toUpperCase()}function a(e){return e.replace(/\\/g,"\\\\").replace(/"/g,'\\"').replace(/\0/g,"\\0").replace(/\t/g,"\\t").replace(/\n/g,"\\n").replace(/\r/g,"\\r").replace(/[\x00-\x0F]/g,(function(e){return"\\x0"+r(e)})).replace(/[\x10-\x1F\x7F-\x9F]/g,(function(e){return"\\x"+r(e)}))}function o(e){return e.replace(/\\/g,"\\\\").replace(/\]/g,"\\]").replace(/\^/g,"\\^").replace(/-/g,"\\-").replace(/\0/g,"\\0").replace(/\t/g,"\\t").replace(/\n/g,"\\n").replace(/\r/g,"\\r").replace(/[\x00-\x0F]/g,(function(e){return"\\x0"+r(e)})).replace(/[\x10-\x1F\x7F-\x9F]/g,(function(e){return"\\x"+r(e)}))}return"Expected "+function(e){var t,r,a,o=new Array(e.length);for(t=0;t<e.length;t++)o[t]=(a=e[t],n[a.type](a));if(o.sort(),o.length>0){for(t=1,r=1;t<o.length;t++)o[t-1]!==o[t]&&(o[r]=o[t],r++);o.length=r}switch(o.length){case 1:return o[0];case 2:return o[0]+" or "+o[1];default:return o.slice(0,-1).join(", ")+", or "+o[o.length-1]}}(e)+" but "+function(e){return e?'"'+a(e)+'"':"end of input"}(t)+" found."},{SyntaxError:e,parse:function(t,n){n=void 0!==n?n:{};var r,a={},o={Expression:A},l=A,i=L("or",!1),s=L("and",!1),u=L("not",!1),c=L("(",!1),d=L(")",!1),f=/^["]/,p=z(['"'],!1,!1),h=/^[^"]/,v=z(['"'],!0,!1),m=function(){return{type:"keyword",value:O().slice(1,-1)}},y=/^[']/,g=z(["'"],!1,!1),b=/^[^']/,w=z(["'"],!0,!1),x=/^[^ ()]/,k=z([" ","(",")"],!0,!1),S=D("whitespace"),E=/^[ \t\n\r]/,C=z([" ","\t","\n","\r"],!1,!1),N=0,_=0,R=[{line:1,column:1}],j=0,P=[],T=0;if("startRule"in n){if(!(n.startRule in o))throw new Error("Can't start parsing from rule \""+n.startRule+'".');l=o[n.startRule]}function O(){return t.substring(_,N)}function L(e,t){return{type:"literal",text:e,ignoreCase:t}}function z(e,t,n){return{type:"class",parts:e,inverted:t,ignoreCase:n}}function D(e){return{type:"other",description:e}}function F(e){var n,r=R[e];if(r)return r;for(n=e-1;!R[n];)n--;for(r={line:(r=R[n]).line,column:r.column};n<e;)10===t.charCodeAt(n)?(r.line++,r.column=1):r.column++,n++;return R[e]=r,r}function M(e,t){var n=F(e),r=F(t);return{start:{offset:e,line:n.line,column:n.column},end:{offset:t,line:r.line,column:r.column}}}function I(e){N<j||(N>j&&(j=N,P=[]),P.push(e))}function B(t,n,r){return new e(e.buildMessage(t,n),t,n,r)}function A(){return U()}function U(){var e,n,r,o;return e=N,(n=H())!==a&&W()!==a?("or"===t.substr(N,2)?(r="or",N+=2):(r=a,0===T&&I(i)),r!==a&&W()!==a&&(o=U())!==a?(_=e,e=n={type:"or",left:n,right:o}):(N=e,e=a)):(N=e,e=a),e===a&&(e=H()),e}function H(){var e,n,r,o;return e=N,(n=q())!==a&&W()!==a?("and"===t.substr(N,3)?(r="and",N+=3):(r=a,0===T&&I(s)),r!==a&&W()!==a&&(o=H())!==a?(_=e,e=n={type:"and",left:n,right:o}):(N=e,e=a)):(N=e,e=a),e===a&&(e=q()),e}function q(){var e,n,r;return e=N,W()!==a?("not"===t.substr(N,3)?(n="not",N+=3):(n=a,0===T&&I(u)),n!==a&&W()!==a&&(r=V())!==a?(_=e,e={type:"not",expr:r}):(N=e,e=a)):(N=e,e=a),e===a&&(e=V()),e}function V(){var e,n,r,o;return e=N,40===t.charCodeAt(N)?(n="(",N++):(n=a,0===T&&I(c)),n!==a&&W()!==a&&(r=U())!==a&&W()!==a?(41===t.charCodeAt(N)?(o=")",N++):(o=a,0===T&&I(d)),o!==a?(_=e,e=n=r):(N=e,e=a)):(N=e,e=a),e===a&&(e=function(){var e,n,r,o;e=N,f.test(t.charAt(N))?(n=t.charAt(N),N++):(n=a,0===T&&I(p));if(n!==a){for(r=[],h.test(t.charAt(N))?(o=t.charAt(N),N++):(o=a,0===T&&I(v));o!==a;)r.push(o),h.test(t.charAt(N))?(o=t.charAt(N),N++):(o=a,0===T&&I(v));r!==a?(f.test(t.charAt(N))?(o=t.charAt(N),N++):(o=a,0===T&&I(p)),o!==a?(_=e,e=n=m()):(N=e,e=a)):(N=e,e=a)}else N=e,e=a;return e}(),e===a&&(e=function(){var e,n,r,o;e=N,y.test(t.charAt(N))?(n=t.charAt(N),N++):(n=a,0===T&&I(g));if(n!==a){for(r=[],b.test(t.charAt(N))?(o=t.charAt(N),N++):(o=a,0===T&&I(w));o!==a;)r.push(o),b.test(t.charAt(N))?(o=t.charAt(N),N++):(o=a,0===T&&I(w));r!==a?(y.test(t.charAt(N))?(o=t.charAt(N),N++):(o=a,0===T&&I(g)),o!==a?(_=e,e=n=m()):(N=e,e=a)):(N=e,e=a)}else N=e,e=a;return e}(),e===a&&(e=function(){var e,n,r;e=N,n=[],x.test(t.charAt(N))?(r=t.charAt(N),N++):(r=a,0===T&&I(k));for(;r!==a;)n.push(r),x.test(t.charAt(N))?(r=t.charAt(N),N++):(r=a,0===T&&I(k));n!==a&&(_=e,n={type:"keyword",value:O()});return e=n,e}()))),e}function W(){var e,n;for(T++,e=[],E.test(t.charAt(N))?(n=t.charAt(N),N++):(n=a,0===T&&I(C));n!==a;)e.push(n),E.test(t.charAt(N))?(n=t.charAt(N),N++):(n=a,0===T&&I(C));return T--,e===a&&(n=a,0===T&&I(S)),e}if((r=l())!==a&&N===t.length)return r;throw r!==a&&N<t.length&&I({type:"end"}),B(P,j<t.length?t.charAt(j):null,j<t.length?M(j,j+1):M(j,j))}}}()},694:function(e,t){var n;!function(){"use strict";var r={}.hasOwnProperty;function a(){for(var e=[],t=0;t<arguments.length;t++){var n=arguments[t];if(n){var o=typeof n;if("string"===o||"number"===o)e.push(n);else if(Array.isArray(n)){if(n.length){var l=a.apply(null,n);l&&e.push(l)}}else if("object"===o){if(n.toString!==Object.prototype.toString&&!n.toString.toString().includes("[native code]")){e.push(n.toString());continue}for(var i in n)r.call(n,i)&&n[i]&&e.push(i)}}}return e.join(" ")}e.exports?(a.default=a,e.exports=a):void 0===(n=function(){return a}.apply(t,[]))||(e.exports=n)}()},998:function(e,t,n){"use strict";var r=n(458),a={"text/plain":"Text","text/html":"Url",default:"Text"};e.exports=function(e,t){var n,o,l,i,s,u,c=!1;t||(t={}),n=t.debug||!1;try{if(l=r(),i=document.createRange(),s=document.getSelection(),(u=document.createElement("span")).textContent=e,u.ariaHidden="true",u.style.all="unset",u.style.position="fixed",u.style.top=0,u.style.clip="rect(0, 0, 0, 0)",u.style.whiteSpace="pre",u.style.webkitUserSelect="text",u.style.MozUserSelect="text",u.style.msUserSelect="text",u.style.userSelect="text",u.addEventListener("copy",(function(r){if(r.stopPropagation(),t.format)if(r.preventDefault(),"undefined"===typeof r.clipboardData){n&&console.warn("unable to use e.clipboardData"),n&&console.warn("trying IE specific stuff"),window.clipboardData.clearData();var o=a[t.format]||a.default;window.clipboardData.setData(o,e)}else r.clipboardData.clearData(),r.clipboardData.setData(t.format,e);t.onCopy&&(r.preventDefault(),t.onCopy(r.clipboardData))})),document.body.appendChild(u),i.selectNodeContents(u),s.addRange(i),!document.execCommand("copy"))throw new Error("copy command was unsuccessful");c=!0}catch(d){n&&console.error("unable to copy using execCommand: ",d),n&&console.warn("trying IE specific stuff");try{window.clipboardData.setData(t.format||"text",e),t.onCopy&&t.onCopy(window.clipboardData),c=!0}catch(d){n&&console.error("unable to copy using clipboardData: ",d),n&&console.error("falling back to prompt"),o=function(e){var t=(/mac os x/i.test(navigator.userAgent)?"\u2318":"Ctrl")+"+C";return e.replace(/#{\s*key\s*}/g,t)}("message"in t?t.message:"Copy to clipboard: #{key}, Enter"),window.prompt(o,e)}}finally{s&&("function"==typeof s.removeRange?s.removeRange(i):s.removeAllRanges()),u&&document.body.removeChild(u),l()}return c}},400:function(e,t){"use strict";var n=function(e){var t=e.method;return t&&{GET:" -X GET",POST:" -X POST",PUT:" -X PUT",PATCH:" -X PATCH",DELETE:" -X DELETE",HEAD:" -X HEAD",OPTIONS:" -X OPTIONS"}[t.toUpperCase()]||""},r=function(e){return"function"==typeof Headers&&e instanceof Headers},a=function(e,t){return' -H "'.concat(e,": ").concat(t.replace(/(\\|")/g,"\\$1"),'"')},o=function(){var e=arguments.length>0&&void 0!==arguments[0]?arguments[0]:{},t=e.headers,n=!1,o="";return r(t)?t.forEach((function(e,t){"content-length"!==t.toLocaleLowerCase()&&(o+=a(t,e)),"accept-encoding"===t.toLocaleLowerCase()&&(n=!0)})):t&&Object.keys(t).map((function(e){"content-length"!==e.toLocaleLowerCase()&&(o+=a(e,t[e])),"accept-encoding"===e.toLocaleLowerCase()&&(n=!0)})),{params:o,isEncode:n}};function l(e){return e?" --data-binary '".concat("object"==typeof e?JSON.stringify(e):e,"'"):""}function i(e){return e?" --compressed":""}var s=function(e,t){var r,a;"string"==typeof e?(r=e,a=t||{}):(r=(e||{}).url,a=e||{});var s=a.body,u=o(a);return"curl '".concat(r,"'").concat(n(a)).concat(u.params||"").concat(l(s)).concat(i(u.isEncode))};t.ZP=s},176:function(e){"use strict";e.exports=function(e,t,n,r,a,o,l,i){if(!e){var s;if(void 0===t)s=new Error("Minified exception occurred; use the non-minified dev environment for the full error message and additional helpful warnings.");else{var u=[n,r,a,o,l,i],c=0;(s=new Error(t.replace(/%s/g,(function(){return u[c++]})))).name="Invariant Violation"}throw s.framesToPop=1,s}}},888:function(e,t,n){"use strict";var r=n(47);function a(){}function o(){}o.resetWarningCache=a,e.exports=function(){function e(e,t,n,a,o,l){if(l!==r){var i=new Error("Calling PropTypes validators directly is not supported by the `prop-types` package. Use PropTypes.checkPropTypes() to call them. Read more at http://fb.me/use-check-prop-types");throw i.name="Invariant Violation",i}}function t(){return e}e.isRequired=e;var n={array:e,bigint:e,bool:e,func:e,number:e,object:e,string:e,symbol:e,any:e,arrayOf:t,element:e,elementType:e,instanceOf:t,node:e,objectOf:t,oneOf:t,oneOfType:t,shape:t,exact:t,checkPropTypes:o,resetWarningCache:a};return n.PropTypes=n,n}},7:function(e,t,n){e.exports=n(888)()},47:function(e){"use strict";e.exports="SECRET_DO_NOT_PASS_THIS_OR_YOU_WILL_BE_FIRED"},463:function(e,t,n){"use strict";var r=n(791),a=n(296);function o(e){for(var t="https://reactjs.org/docs/error-decoder.html?invariant="+e,n=1;n<arguments.length;n++)t+="&args[]="+encodeURIComponent(arguments[n]);return"Minified React error #"+e+"; visit "+t+" for the full message or use the non-minified dev environment for full errors and additional helpful warnings."}var l=new Set,i={};function s(e,t){u(e,t),u(e+"Capture",t)}function u(e,t){for(i[e]=t,e=0;e<t.length;e++)l.add(t[e])}var c=!("undefined"===typeof window||"undefined"===typeof window.document||"undefined"===typeof window.document.createElement),d=Object.prototype.hasOwnProperty,f=/^[:A-Z_a-z\u00C0-\u00D6\u00D8-\u00F6\u00F8-\u02FF\u0370-\u037D\u037F-\u1FFF\u200C-\u200D\u2070-\u218F\u2C00-\u2FEF\u3001-\uD7FF\uF900-\uFDCF\uFDF0-\uFFFD][:A-Z_a-z\u00C0-\u00D6\u00D8-\u00F6\u00F8-\u02FF\u0370-\u037D\u037F-\u1FFF\u200C-\u200D\u2070-\u218F\u2C00-\u2FEF\u3001-\uD7FF\uF900-\uFDCF\uFDF0-\uFFFD\-.0-9\u00B7\u0300-\u036F\u203F-\u2040]*$/,p={},h={};function v(e,t,n,r,a,o,l){this.acceptsBooleans=2===t||3===t||4===t,this.attributeName=r,this.attributeNamespace=a,this.mustUseProperty=n,this.propertyName=e,this.type=t,this.sanitizeURL=o,this.removeEmptyString=l}var m={};"children dangerouslySetInnerHTML defaultValue defaultChecked innerHTML suppressContentEditableWarning suppressHydrationWarning style".split(" ").forEach((function(e){m[e]=new v(e,0,!1,e,null,!1,!1)})),[["acceptCharset","accept-charset"],["className","class"],["htmlFor","for"],["httpEquiv","http-equiv"]].forEach((function(e){var t=e[0];m[t]=new v(t,1,!1,e[1],null,!1,!1)})),["contentEditable","draggable","spellCheck","value"].forEach((function(e){m[e]=new v(e,2,!1,e.toLowerCase(),null,!1,!1)})),["autoReverse","externalResourcesRequired","focusable","preserveAlpha"].forEach((function(e){m[e]=new v(e,2,!1,e,null,!1,!1)})),"allowFullScreen async autoFocus autoPlay controls default defer disabled disablePictureInPicture disableRemotePlayback formNoValidate hidden loop noModule noValidate open playsInline readOnly required reversed scoped seamless itemScope".split(" ").forEach((function(e){m[e]=new v(e,3,!1,e.toLowerCase(),null,!1,!1)})),["checked","multiple","muted","selected"].forEach((function(e){m[e]=new v(e,3,!0,e,null,!1,!1)})),["capture","download"].forEach((function(e){m[e]=new v(e,4,!1,e,null,!1,!1)})),["cols","rows","size","span"].forEach((function(e){m[e]=new v(e,6,!1,e,null,!1,!1)})),["rowSpan","start"].forEach((function(e){m[e]=new v(e,5,!1,e.toLowerCase(),null,!1,!1)}));var y=/[\-:]([a-z])/g;function g(e){return e[1].toUpperCase()}function b(e,t,n,r){var a=m.hasOwnProperty(t)?m[t]:null;(null!==a?0!==a.type:r||!(2<t.length)||"o"!==t[0]&&"O"!==t[0]||"n"!==t[1]&&"N"!==t[1])&&(function(e,t,n,r){if(null===t||"undefined"===typeof t||function(e,t,n,r){if(null!==n&&0===n.type)return!1;switch(typeof t){case"function":case"symbol":return!0;case"boolean":return!r&&(null!==n?!n.acceptsBooleans:"data-"!==(e=e.toLowerCase().slice(0,5))&&"aria-"!==e);default:return!1}}(e,t,n,r))return!0;if(r)return!1;if(null!==n)switch(n.type){case 3:return!t;case 4:return!1===t;case 5:return isNaN(t);case 6:return isNaN(t)||1>t}return!1}(t,n,a,r)&&(n=null),r||null===a?function(e){return!!d.call(h,e)||!d.call(p,e)&&(f.test(e)?h[e]=!0:(p[e]=!0,!1))}(t)&&(null===n?e.removeAttribute(t):e.setAttribute(t,""+n)):a.mustUseProperty?e[a.propertyName]=null===n?3!==a.type&&"":n:(t=a.attributeName,r=a.attributeNamespace,null===n?e.removeAttribute(t):(n=3===(a=a.type)||4===a&&!0===n?"":""+n,r?e.setAttributeNS(r,t,n):e.setAttribute(t,n))))}"accent-height alignment-baseline arabic-form baseline-shift cap-height clip-path clip-rule color-interpolation color-interpolation-filters color-profile color-rendering dominant-baseline enable-background fill-opacity fill-rule flood-color flood-opacity font-family font-size font-size-adjust font-stretch font-style font-variant font-weight glyph-name glyph-orientation-horizontal glyph-orientation-vertical horiz-adv-x horiz-origin-x image-rendering letter-spacing lighting-color marker-end marker-mid marker-start overline-position overline-thickness paint-order panose-1 pointer-events rendering-intent shape-rendering stop-color stop-opacity strikethrough-position strikethrough-thickness stroke-dasharray stroke-dashoffset stroke-linecap stroke-linejoin stroke-miterlimit stroke-opacity stroke-width text-anchor text-decoration text-rendering underline-position underline-thickness unicode-bidi unicode-range units-per-em v-alphabetic v-hanging v-ideographic v-mathematical vector-effect vert-adv-y vert-origin-x vert-origin-y word-spacing writing-mode xmlns:xlink x-height".split(" ").forEach((function(e){var t=e.replace(y,g);m[t]=new v(t,1,!1,e,null,!1,!1)})),"xlink:actuate xlink:arcrole xlink:role xlink:show xlink:title xlink:type".split(" ").forEach((function(e){var t=e.replace(y,g);m[t]=new v(t,1,!1,e,"http://www.w3.org/1999/xlink",!1,!1)})),["xml:base","xml:lang","xml:space"].forEach((function(e){var t=e.replace(y,g);m[t]=new v(t,1,!1,e,"http://www.w3.org/XML/1998/namespace",!1,!1)})),["tabIndex","crossOrigin"].forEach((function(e){m[e]=new v(e,1,!1,e.toLowerCase(),null,!1,!1)})),m.xlinkHref=new v("xlinkHref",1,!1,"xlink:href","http://www.w3.org/1999/xlink",!0,!1),["src","href","action","formAction"].forEach((function(e){m[e]=new v(e,1,!1,e.toLowerCase(),null,!0,!0)}));var w=r.__SECRET_INTERNALS_DO_NOT_USE_OR_YOU_WILL_BE_FIRED,x=Symbol.for("react.element"),k=Symbol.for("react.portal"),S=Symbol.for("react.fragment"),E=Symbol.for("react.strict_mode"),C=Symbol.for("react.profiler"),N=Symbol.for("react.provider"),_=Symbol.for("react.context"),R=Symbol.for("react.forward_ref"),j=Symbol.for("react.suspense"),P=Symbol.for("react.suspense_list"),T=Symbol.for("react.memo"),O=Symbol.for("react.lazy");Symbol.for("react.scope"),Symbol.for("react.debug_trace_mode");var L=Symbol.for("react.offscreen");Symbol.for("react.legacy_hidden"),Symbol.for("react.cache"),Symbol.for("react.tracing_marker");var z=Symbol.iterator;function D(e){return null===e||"object"!==typeof e?null:"function"===typeof(e=z&&e[z]||e["@@iterator"])?e:null}var F,M=Object.assign;function I(e){if(void 0===F)try{throw Error()}catch(n){var t=n.stack.trim().match(/\n( *(at )?)/);F=t&&t[1]||""}return"\n"+F+e}var B=!1;function A(e,t){if(!e||B)return"";B=!0;var n=Error.prepareStackTrace;Error.prepareStackTrace=void 0;try{if(t)if(t=function(){throw Error()},Object.defineProperty(t.prototype,"props",{set:function(){throw Error()}}),"object"===typeof Reflect&&Reflect.construct){try{Reflect.construct(t,[])}catch(u){var r=u}Reflect.construct(e,[],t)}else{try{t.call()}catch(u){r=u}e.call(t.prototype)}else{try{throw Error()}catch(u){r=u}e()}}catch(u){if(u&&r&&"string"===typeof u.stack){for(var a=u.stack.split("\n"),o=r.stack.split("\n"),l=a.length-1,i=o.length-1;1<=l&&0<=i&&a[l]!==o[i];)i--;for(;1<=l&&0<=i;l--,i--)if(a[l]!==o[i]){if(1!==l||1!==i)do{if(l--,0>--i||a[l]!==o[i]){var s="\n"+a[l].replace(" at new "," at ");return e.displayName&&s.includes("<anonymous>")&&(s=s.replace("<anonymous>",e.displayName)),s}}while(1<=l&&0<=i);break}}}finally{B=!1,Error.prepareStackTrace=n}return(e=e?e.displayName||e.name:"")?I(e):""}function U(e){switch(e.tag){case 5:return I(e.type);case 16:return I("Lazy");case 13:return I("Suspense");case 19:return I("SuspenseList");case 0:case 2:case 15:return e=A(e.type,!1);case 11:return e=A(e.type.render,!1);case 1:return e=A(e.type,!0);default:return""}}function H(e){if(null==e)return null;if("function"===typeof e)return e.displayName||e.name||null;if("string"===typeof e)return e;switch(e){case S:return"Fragment";case k:return"Portal";case C:return"Profiler";case E:return"StrictMode";case j:return"Suspense";case P:return"SuspenseList"}if("object"===typeof e)switch(e.$$typeof){case _:return(e.displayName||"Context")+".Consumer";case N:return(e._context.displayName||"Context")+".Provider";case R:var t=e.render;return(e=e.displayName)||(e=""!==(e=t.displayName||t.name||"")?"ForwardRef("+e+")":"ForwardRef"),e;case T:return null!==(t=e.displayName||null)?t:H(e.type)||"Memo";case O:t=e._payload,e=e._init;try{return H(e(t))}catch(n){}}return null}function q(e){var t=e.type;switch(e.tag){case 24:return"Cache";case 9:return(t.displayName||"Context")+".Consumer";case 10:return(t._context.displayName||"Context")+".Provider";case 18:return"DehydratedFragment";case 11:return e=(e=t.render).displayName||e.name||"",t.displayName||(""!==e?"ForwardRef("+e+")":"ForwardRef");case 7:return"Fragment";case 5:return t;case 4:return"Portal";case 3:return"Root";case 6:return"Text";case 16:return H(t);case 8:return t===E?"StrictMode":"Mode";case 22:return"Offscreen";case 12:return"Profiler";case 21:return"Scope";case 13:return"Suspense";case 19:return"SuspenseList";case 25:return"TracingMarker";case 1:case 0:case 17:case 2:case 14:case 15:if("function"===typeof t)return t.displayName||t.name||null;if("string"===typeof t)return t}return null}function V(e){switch(typeof e){case"boolean":case"number":case"string":case"undefined":case"object":return e;default:return""}}function W(e){var t=e.type;return(e=e.nodeName)&&"input"===e.toLowerCase()&&("checkbox"===t||"radio"===t)}function $(e){e._valueTracker||(e._valueTracker=function(e){var t=W(e)?"checked":"value",n=Object.getOwnPropertyDescriptor(e.constructor.prototype,t),r=""+e[t];if(!e.hasOwnProperty(t)&&"undefined"!==typeof n&&"function"===typeof n.get&&"function"===typeof n.set){var a=n.get,o=n.set;return Object.defineProperty(e,t,{configurable:!0,get:function(){return a.call(this)},set:function(e){r=""+e,o.call(this,e)}}),Object.defineProperty(e,t,{enumerable:n.enumerable}),{getValue:function(){return r},setValue:function(e){r=""+e},stopTracking:function(){e._valueTracker=null,delete e[t]}}}}(e))}function Q(e){if(!e)return!1;var t=e._valueTracker;if(!t)return!0;var n=t.getValue(),r="";return e&&(r=W(e)?e.checked?"true":"false":e.value),(e=r)!==n&&(t.setValue(e),!0)}function K(e){if("undefined"===typeof(e=e||("undefined"!==typeof document?document:void 0)))return null;try{return e.activeElement||e.body}catch(t){return e.body}}function Y(e,t){var n=t.checked;return M({},t,{defaultChecked:void 0,defaultValue:void 0,value:void 0,checked:null!=n?n:e._wrapperState.initialChecked})}function G(e,t){var n=null==t.defaultValue?"":t.defaultValue,r=null!=t.checked?t.checked:t.defaultChecked;n=V(null!=t.value?t.value:n),e._wrapperState={initialChecked:r,initialValue:n,controlled:"checkbox"===t.type||"radio"===t.type?null!=t.checked:null!=t.value}}function X(e,t){null!=(t=t.checked)&&b(e,"checked",t,!1)}function J(e,t){X(e,t);var n=V(t.value),r=t.type;if(null!=n)"number"===r?(0===n&&""===e.value||e.value!=n)&&(e.value=""+n):e.value!==""+n&&(e.value=""+n);else if("submit"===r||"reset"===r)return void e.removeAttribute("value");t.hasOwnProperty("value")?ee(e,t.type,n):t.hasOwnProperty("defaultValue")&&ee(e,t.type,V(t.defaultValue)),null==t.checked&&null!=t.defaultChecked&&(e.defaultChecked=!!t.defaultChecked)}function Z(e,t,n){if(t.hasOwnProperty("value")||t.hasOwnProperty("defaultValue")){var r=t.type;if(!("submit"!==r&&"reset"!==r||void 0!==t.value&&null!==t.value))return;t=""+e._wrapperState.initialValue,n||t===e.value||(e.value=t),e.defaultValue=t}""!==(n=e.name)&&(e.name=""),e.defaultChecked=!!e._wrapperState.initialChecked,""!==n&&(e.name=n)}function ee(e,t,n){"number"===t&&K(e.ownerDocument)===e||(null==n?e.defaultValue=""+e._wrapperState.initialValue:e.defaultValue!==""+n&&(e.defaultValue=""+n))}var te=Array.isArray;function ne(e,t,n,r){if(e=e.options,t){t={};for(var a=0;a<n.length;a++)t["$"+n[a]]=!0;for(n=0;n<e.length;n++)a=t.hasOwnProperty("$"+e[n].value),e[n].selected!==a&&(e[n].selected=a),a&&r&&(e[n].defaultSelected=!0)}else{for(n=""+V(n),t=null,a=0;a<e.length;a++){if(e[a].value===n)return e[a].selected=!0,void(r&&(e[a].defaultSelected=!0));null!==t||e[a].disabled||(t=e[a])}null!==t&&(t.selected=!0)}}function re(e,t){if(null!=t.dangerouslySetInnerHTML)throw Error(o(91));return M({},t,{value:void 0,defaultValue:void 0,children:""+e._wrapperState.initialValue})}function ae(e,t){var n=t.value;if(null==n){if(n=t.children,t=t.defaultValue,null!=n){if(null!=t)throw Error(o(92));if(te(n)){if(1<n.length)throw Error(o(93));n=n[0]}t=n}null==t&&(t=""),n=t}e._wrapperState={initialValue:V(n)}}function oe(e,t){var n=V(t.value),r=V(t.defaultValue);null!=n&&((n=""+n)!==e.value&&(e.value=n),null==t.defaultValue&&e.defaultValue!==n&&(e.defaultValue=n)),null!=r&&(e.defaultValue=""+r)}function le(e){var t=e.textContent;t===e._wrapperState.initialValue&&""!==t&&null!==t&&(e.value=t)}function ie(e){switch(e){case"svg":return"http://www.w3.org/2000/svg";case"math":return"http://www.w3.org/1998/Math/MathML";default:return"http://www.w3.org/1999/xhtml"}}function se(e,t){return null==e||"http://www.w3.org/1999/xhtml"===e?ie(t):"http://www.w3.org/2000/svg"===e&&"foreignObject"===t?"http://www.w3.org/1999/xhtml":e}var ue,ce,de=(ce=function(e,t){if("http://www.w3.org/2000/svg"!==e.namespaceURI||"innerHTML"in e)e.innerHTML=t;else{for((ue=ue||document.createElement("div")).innerHTML="<svg>"+t.valueOf().toString()+"</svg>",t=ue.firstChild;e.firstChild;)e.removeChild(e.firstChild);for(;t.firstChild;)e.appendChild(t.firstChild)}},"undefined"!==typeof MSApp&&MSApp.execUnsafeLocalFunction?function(e,t,n,r){MSApp.execUnsafeLocalFunction((function(){return ce(e,t)}))}:ce);function fe(e,t){if(t){var n=e.firstChild;if(n&&n===e.lastChild&&3===n.nodeType)return void(n.nodeValue=t)}e.textContent=t}var pe={animationIterationCount:!0,aspectRatio:!0,borderImageOutset:!0,borderImageSlice:!0,borderImageWidth:!0,boxFlex:!0,boxFlexGroup:!0,boxOrdinalGroup:!0,columnCount:!0,columns:!0,flex:!0,flexGrow:!0,flexPositive:!0,flexShrink:!0,flexNegative:!0,flexOrder:!0,gridArea:!0,gridRow:!0,gridRowEnd:!0,gridRowSpan:!0,gridRowStart:!0,gridColumn:!0,gridColumnEnd:!0,gridColumnSpan:!0,gridColumnStart:!0,fontWeight:!0,lineClamp:!0,lineHeight:!0,opacity:!0,order:!0,orphans:!0,tabSize:!0,widows:!0,zIndex:!0,zoom:!0,fillOpacity:!0,floodOpacity:!0,stopOpacity:!0,strokeDasharray:!0,strokeDashoffset:!0,strokeMiterlimit:!0,strokeOpacity:!0,strokeWidth:!0},he=["Webkit","ms","Moz","O"];function ve(e,t,n){return null==t||"boolean"===typeof t||""===t?"":n||"number"!==typeof t||0===t||pe.hasOwnProperty(e)&&pe[e]?(""+t).trim():t+"px"}function me(e,t){for(var n in e=e.style,t)if(t.hasOwnProperty(n)){var r=0===n.indexOf("--"),a=ve(n,t[n],r);"float"===n&&(n="cssFloat"),r?e.setProperty(n,a):e[n]=a}}Object.keys(pe).forEach((function(e){he.forEach((function(t){t=t+e.charAt(0).toUpperCase()+e.substring(1),pe[t]=pe[e]}))}));var ye=M({menuitem:!0},{area:!0,base:!0,br:!0,col:!0,embed:!0,hr:!0,img:!0,input:!0,keygen:!0,link:!0,meta:!0,param:!0,source:!0,track:!0,wbr:!0});function ge(e,t){if(t){if(ye[e]&&(null!=t.children||null!=t.dangerouslySetInnerHTML))throw Error(o(137,e));if(null!=t.dangerouslySetInnerHTML){if(null!=t.children)throw Error(o(60));if("object"!==typeof t.dangerouslySetInnerHTML||!("__html"in t.dangerouslySetInnerHTML))throw Error(o(61))}if(null!=t.style&&"object"!==typeof t.style)throw Error(o(62))}}function be(e,t){if(-1===e.indexOf("-"))return"string"===typeof t.is;switch(e){case"annotation-xml":case"color-profile":case"font-face":case"font-face-src":case"font-face-uri":case"font-face-format":case"font-face-name":case"missing-glyph":return!1;default:return!0}}var we=null;function xe(e){return(e=e.target||e.srcElement||window).correspondingUseElement&&(e=e.correspondingUseElement),3===e.nodeType?e.parentNode:e}var ke=null,Se=null,Ee=null;function Ce(e){if(e=ba(e)){if("function"!==typeof ke)throw Error(o(280));var t=e.stateNode;t&&(t=xa(t),ke(e.stateNode,e.type,t))}}function Ne(e){Se?Ee?Ee.push(e):Ee=[e]:Se=e}function _e(){if(Se){var e=Se,t=Ee;if(Ee=Se=null,Ce(e),t)for(e=0;e<t.length;e++)Ce(t[e])}}function Re(e,t){return e(t)}function je(){}var Pe=!1;function Te(e,t,n){if(Pe)return e(t,n);Pe=!0;try{return Re(e,t,n)}finally{Pe=!1,(null!==Se||null!==Ee)&&(je(),_e())}}function Oe(e,t){var n=e.stateNode;if(null===n)return null;var r=xa(n);if(null===r)return null;n=r[t];e:switch(t){case"onClick":case"onClickCapture":case"onDoubleClick":case"onDoubleClickCapture":case"onMouseDown":case"onMouseDownCapture":case"onMouseMove":case"onMouseMoveCapture":case"onMouseUp":case"onMouseUpCapture":case"onMouseEnter":(r=!r.disabled)||(r=!("button"===(e=e.type)||"input"===e||"select"===e||"textarea"===e)),e=!r;break e;default:e=!1}if(e)return null;if(n&&"function"!==typeof n)throw Error(o(231,t,typeof n));return n}var Le=!1;if(c)try{var ze={};Object.defineProperty(ze,"passive",{get:function(){Le=!0}}),window.addEventListener("test",ze,ze),window.removeEventListener("test",ze,ze)}catch(ce){Le=!1}function De(e,t,n,r,a,o,l,i,s){var u=Array.prototype.slice.call(arguments,3);try{t.apply(n,u)}catch(c){this.onError(c)}}var Fe=!1,Me=null,Ie=!1,Be=null,Ae={onError:function(e){Fe=!0,Me=e}};function Ue(e,t,n,r,a,o,l,i,s){Fe=!1,Me=null,De.apply(Ae,arguments)}function He(e){var t=e,n=e;if(e.alternate)for(;t.return;)t=t.return;else{e=t;do{0!==(4098&(t=e).flags)&&(n=t.return),e=t.return}while(e)}return 3===t.tag?n:null}function qe(e){if(13===e.tag){var t=e.memoizedState;if(null===t&&(null!==(e=e.alternate)&&(t=e.memoizedState)),null!==t)return t.dehydrated}return null}function Ve(e){if(He(e)!==e)throw Error(o(188))}function We(e){return null!==(e=function(e){var t=e.alternate;if(!t){if(null===(t=He(e)))throw Error(o(188));return t!==e?null:e}for(var n=e,r=t;;){var a=n.return;if(null===a)break;var l=a.alternate;if(null===l){if(null!==(r=a.return)){n=r;continue}break}if(a.child===l.child){for(l=a.child;l;){if(l===n)return Ve(a),e;if(l===r)return Ve(a),t;l=l.sibling}throw Error(o(188))}if(n.return!==r.return)n=a,r=l;else{for(var i=!1,s=a.child;s;){if(s===n){i=!0,n=a,r=l;break}if(s===r){i=!0,r=a,n=l;break}s=s.sibling}if(!i){for(s=l.child;s;){if(s===n){i=!0,n=l,r=a;break}if(s===r){i=!0,r=l,n=a;break}s=s.sibling}if(!i)throw Error(o(189))}}if(n.alternate!==r)throw Error(o(190))}if(3!==n.tag)throw Error(o(188));return n.stateNode.current===n?e:t}(e))?$e(e):null}function $e(e){if(5===e.tag||6===e.tag)return e;for(e=e.child;null!==e;){var t=$e(e);if(null!==t)return t;e=e.sibling}return null}var Qe=a.unstable_scheduleCallback,Ke=a.unstable_cancelCallback,Ye=a.unstable_shouldYield,Ge=a.unstable_requestPaint,Xe=a.unstable_now,Je=a.unstable_getCurrentPriorityLevel,Ze=a.unstable_ImmediatePriority,et=a.unstable_UserBlockingPriority,tt=a.unstable_NormalPriority,nt=a.unstable_LowPriority,rt=a.unstable_IdlePriority,at=null,ot=null;var lt=Math.clz32?Math.clz32:function(e){return e>>>=0,0===e?32:31-(it(e)/st|0)|0},it=Math.log,st=Math.LN2;var ut=64,ct=4194304;function dt(e){switch(e&-e){case 1:return 1;case 2:return 2;case 4:return 4;case 8:return 8;case 16:return 16;case 32:return 32;case 64:case 128:case 256:case 512:case 1024:case 2048:case 4096:case 8192:case 16384:case 32768:case 65536:case 131072:case 262144:case 524288:case 1048576:case 2097152:return 4194240&e;case 4194304:case 8388608:case 16777216:case 33554432:case 67108864:return 130023424&e;case 134217728:return 134217728;case 268435456:return 268435456;case 536870912:return 536870912;case 1073741824:return 1073741824;default:return e}}function ft(e,t){var n=e.pendingLanes;if(0===n)return 0;var r=0,a=e.suspendedLanes,o=e.pingedLanes,l=268435455&n;if(0!==l){var i=l&~a;0!==i?r=dt(i):0!==(o&=l)&&(r=dt(o))}else 0!==(l=n&~a)?r=dt(l):0!==o&&(r=dt(o));if(0===r)return 0;if(0!==t&&t!==r&&0===(t&a)&&((a=r&-r)>=(o=t&-t)||16===a&&0!==(4194240&o)))return t;if(0!==(4&r)&&(r|=16&n),0!==(t=e.entangledLanes))for(e=e.entanglements,t&=r;0<t;)a=1<<(n=31-lt(t)),r|=e[n],t&=~a;return r}function pt(e,t){switch(e){case 1:case 2:case 4:return t+250;case 8:case 16:case 32:case 64:case 128:case 256:case 512:case 1024:case 2048:case 4096:case 8192:case 16384:case 32768:case 65536:case 131072:case 262144:case 524288:case 1048576:case 2097152:return t+5e3;default:return-1}}function ht(e){return 0!==(e=-1073741825&e.pendingLanes)?e:1073741824&e?1073741824:0}function vt(){var e=ut;return 0===(4194240&(ut<<=1))&&(ut=64),e}function mt(e){for(var t=[],n=0;31>n;n++)t.push(e);return t}function yt(e,t,n){e.pendingLanes|=t,536870912!==t&&(e.suspendedLanes=0,e.pingedLanes=0),(e=e.eventTimes)[t=31-lt(t)]=n}function gt(e,t){var n=e.entangledLanes|=t;for(e=e.entanglements;n;){var r=31-lt(n),a=1<<r;a&t|e[r]&t&&(e[r]|=t),n&=~a}}var bt=0;function wt(e){return 1<(e&=-e)?4<e?0!==(268435455&e)?16:536870912:4:1}var xt,kt,St,Et,Ct,Nt=!1,_t=[],Rt=null,jt=null,Pt=null,Tt=new Map,Ot=new Map,Lt=[],zt="mousedown mouseup touchcancel touchend touchstart auxclick dblclick pointercancel pointerdown pointerup dragend dragstart drop compositionend compositionstart keydown keypress keyup input textInput copy cut paste click change contextmenu reset submit".split(" ");function Dt(e,t){switch(e){case"focusin":case"focusout":Rt=null;break;case"dragenter":case"dragleave":jt=null;break;case"mouseover":case"mouseout":Pt=null;break;case"pointerover":case"pointerout":Tt.delete(t.pointerId);break;case"gotpointercapture":case"lostpointercapture":Ot.delete(t.pointerId)}}function Ft(e,t,n,r,a,o){return null===e||e.nativeEvent!==o?(e={blockedOn:t,domEventName:n,eventSystemFlags:r,nativeEvent:o,targetContainers:[a]},null!==t&&(null!==(t=ba(t))&&kt(t)),e):(e.eventSystemFlags|=r,t=e.targetContainers,null!==a&&-1===t.indexOf(a)&&t.push(a),e)}function Mt(e){var t=ga(e.target);if(null!==t){var n=He(t);if(null!==n)if(13===(t=n.tag)){if(null!==(t=qe(n)))return e.blockedOn=t,void Ct(e.priority,(function(){St(n)}))}else if(3===t&&n.stateNode.current.memoizedState.isDehydrated)return void(e.blockedOn=3===n.tag?n.stateNode.containerInfo:null)}e.blockedOn=null}function It(e){if(null!==e.blockedOn)return!1;for(var t=e.targetContainers;0<t.length;){var n=Yt(e.domEventName,e.eventSystemFlags,t[0],e.nativeEvent);if(null!==n)return null!==(t=ba(n))&&kt(t),e.blockedOn=n,!1;var r=new(n=e.nativeEvent).constructor(n.type,n);we=r,n.target.dispatchEvent(r),we=null,t.shift()}return!0}function Bt(e,t,n){It(e)&&n.delete(t)}function At(){Nt=!1,null!==Rt&&It(Rt)&&(Rt=null),null!==jt&&It(jt)&&(jt=null),null!==Pt&&It(Pt)&&(Pt=null),Tt.forEach(Bt),Ot.forEach(Bt)}function Ut(e,t){e.blockedOn===t&&(e.blockedOn=null,Nt||(Nt=!0,a.unstable_scheduleCallback(a.unstable_NormalPriority,At)))}function Ht(e){function t(t){return Ut(t,e)}if(0<_t.length){Ut(_t[0],e);for(var n=1;n<_t.length;n++){var r=_t[n];r.blockedOn===e&&(r.blockedOn=null)}}for(null!==Rt&&Ut(Rt,e),null!==jt&&Ut(jt,e),null!==Pt&&Ut(Pt,e),Tt.forEach(t),Ot.forEach(t),n=0;n<Lt.length;n++)(r=Lt[n]).blockedOn===e&&(r.blockedOn=null);for(;0<Lt.length&&null===(n=Lt[0]).blockedOn;)Mt(n),null===n.blockedOn&&Lt.shift()}var qt=w.ReactCurrentBatchConfig,Vt=!0;function Wt(e,t,n,r){var a=bt,o=qt.transition;qt.transition=null;try{bt=1,Qt(e,t,n,r)}finally{bt=a,qt.transition=o}}function $t(e,t,n,r){var a=bt,o=qt.transition;qt.transition=null;try{bt=4,Qt(e,t,n,r)}finally{bt=a,qt.transition=o}}function Qt(e,t,n,r){if(Vt){var a=Yt(e,t,n,r);if(null===a)Vr(e,t,r,Kt,n),Dt(e,r);else if(function(e,t,n,r,a){switch(t){case"focusin":return Rt=Ft(Rt,e,t,n,r,a),!0;case"dragenter":return jt=Ft(jt,e,t,n,r,a),!0;case"mouseover":return Pt=Ft(Pt,e,t,n,r,a),!0;case"pointerover":var o=a.pointerId;return Tt.set(o,Ft(Tt.get(o)||null,e,t,n,r,a)),!0;case"gotpointercapture":return o=a.pointerId,Ot.set(o,Ft(Ot.get(o)||null,e,t,n,r,a)),!0}return!1}(a,e,t,n,r))r.stopPropagation();else if(Dt(e,r),4&t&&-1<zt.indexOf(e)){for(;null!==a;){var o=ba(a);if(null!==o&&xt(o),null===(o=Yt(e,t,n,r))&&Vr(e,t,r,Kt,n),o===a)break;a=o}null!==a&&r.stopPropagation()}else Vr(e,t,r,null,n)}}var Kt=null;function Yt(e,t,n,r){if(Kt=null,null!==(e=ga(e=xe(r))))if(null===(t=He(e)))e=null;else if(13===(n=t.tag)){if(null!==(e=qe(t)))return e;e=null}else if(3===n){if(t.stateNode.current.memoizedState.isDehydrated)return 3===t.tag?t.stateNode.containerInfo:null;e=null}else t!==e&&(e=null);return Kt=e,null}function Gt(e){switch(e){case"cancel":case"click":case"close":case"contextmenu":case"copy":case"cut":case"auxclick":case"dblclick":case"dragend":case"dragstart":case"drop":case"focusin":case"focusout":case"input":case"invalid":case"keydown":case"keypress":case"keyup":case"mousedown":case"mouseup":case"paste":case"pause":case"play":case"pointercancel":case"pointerdown":case"pointerup":case"ratechange":case"reset":case"resize":case"seeked":case"submit":case"touchcancel":case"touchend":case"touchstart":case"volumechange":case"change":case"selectionchange":case"textInput":case"compositionstart":case"compositionend":case"compositionupdate":case"beforeblur":case"afterblur":case"beforeinput":case"blur":case"fullscreenchange":case"focus":case"hashchange":case"popstate":case"select":case"selectstart":return 1;case"drag":case"dragenter":case"dragexit":case"dragleave":case"dragover":case"mousemove":case"mouseout":case"mouseover":case"pointermove":case"pointerout":case"pointerover":case"scroll":case"toggle":case"touchmove":case"wheel":case"mouseenter":case"mouseleave":case"pointerenter":case"pointerleave":return 4;case"message":switch(Je()){case Ze:return 1;case et:return 4;case tt:case nt:return 16;case rt:return 536870912;default:return 16}default:return 16}}var Xt=null,Jt=null,Zt=null;function en(){if(Zt)return Zt;var e,t,n=Jt,r=n.length,a="value"in Xt?Xt.value:Xt.textContent,o=a.length;for(e=0;e<r&&n[e]===a[e];e++);var l=r-e;for(t=1;t<=l&&n[r-t]===a[o-t];t++);return Zt=a.slice(e,1<t?1-t:void 0)}function tn(e){var t=e.keyCode;return"charCode"in e?0===(e=e.charCode)&&13===t&&(e=13):e=t,10===e&&(e=13),32<=e||13===e?e:0}function nn(){return!0}function rn(){return!1}function an(e){function t(t,n,r,a,o){for(var l in this._reactName=t,this._targetInst=r,this.type=n,this.nativeEvent=a,this.target=o,this.currentTarget=null,e)e.hasOwnProperty(l)&&(t=e[l],this[l]=t?t(a):a[l]);return this.isDefaultPrevented=(null!=a.defaultPrevented?a.defaultPrevented:!1===a.returnValue)?nn:rn,this.isPropagationStopped=rn,this}return M(t.prototype,{preventDefault:function(){this.defaultPrevented=!0;var e=this.nativeEvent;e&&(e.preventDefault?e.preventDefault():"unknown"!==typeof e.returnValue&&(e.returnValue=!1),this.isDefaultPrevented=nn)},stopPropagation:function(){var e=this.nativeEvent;e&&(e.stopPropagation?e.stopPropagation():"unknown"!==typeof e.cancelBubble&&(e.cancelBubble=!0),this.isPropagationStopped=nn)},persist:function(){},isPersistent:nn}),t}var on,ln,sn,un={eventPhase:0,bubbles:0,cancelable:0,timeStamp:function(e){return e.timeStamp||Date.now()},defaultPrevented:0,isTrusted:0},cn=an(un),dn=M({},un,{view:0,detail:0}),fn=an(dn),pn=M({},dn,{screenX:0,screenY:0,clientX:0,clientY:0,pageX:0,pageY:0,ctrlKey:0,shiftKey:0,altKey:0,metaKey:0,getModifierState:Cn,button:0,buttons:0,relatedTarget:function(e){return void 0===e.relatedTarget?e.fromElement===e.srcElement?e.toElement:e.fromElement:e.relatedTarget},movementX:function(e){return"movementX"in e?e.movementX:(e!==sn&&(sn&&"mousemove"===e.type?(on=e.screenX-sn.screenX,ln=e.screenY-sn.screenY):ln=on=0,sn=e),on)},movementY:function(e){return"movementY"in e?e.movementY:ln}}),hn=an(pn),vn=an(M({},pn,{dataTransfer:0})),mn=an(M({},dn,{relatedTarget:0})),yn=an(M({},un,{animationName:0,elapsedTime:0,pseudoElement:0})),gn=M({},un,{clipboardData:function(e){return"clipboardData"in e?e.clipboardData:window.clipboardData}}),bn=an(gn),wn=an(M({},un,{data:0})),xn={Esc:"Escape",Spacebar:" ",Left:"ArrowLeft",Up:"ArrowUp",Right:"ArrowRight",Down:"ArrowDown",Del:"Delete",Win:"OS",Menu:"ContextMenu",Apps:"ContextMenu",Scroll:"ScrollLock",MozPrintableKey:"Unidentified"},kn={8:"Backspace",9:"Tab",12:"Clear",13:"Enter",16:"Shift",17:"Control",18:"Alt",19:"Pause",20:"CapsLock",27:"Escape",32:" ",33:"PageUp",34:"PageDown",35:"End",36:"Home",37:"ArrowLeft",38:"ArrowUp",39:"ArrowRight",40:"ArrowDown",45:"Insert",46:"Delete",112:"F1",113:"F2",114:"F3",115:"F4",116:"F5",117:"F6",118:"F7",119:"F8",120:"F9",121:"F10",122:"F11",123:"F12",144:"NumLock",145:"ScrollLock",224:"Meta"},Sn={Alt:"altKey",Control:"ctrlKey",Meta:"metaKey",Shift:"shiftKey"};function En(e){var t=this.nativeEvent;return t.getModifierState?t.getModifierState(e):!!(e=Sn[e])&&!!t[e]}function Cn(){return En}var Nn=M({},dn,{key:function(e){if(e.key){var t=xn[e.key]||e.key;if("Unidentified"!==t)return t}return"keypress"===e.type?13===(e=tn(e))?"Enter":String.fromCharCode(e):"keydown"===e.type||"keyup"===e.type?kn[e.keyCode]||"Unidentified":""},code:0,location:0,ctrlKey:0,shiftKey:0,altKey:0,metaKey:0,repeat:0,locale:0,getModifierState:Cn,charCode:function(e){return"keypress"===e.type?tn(e):0},keyCode:function(e){return"keydown"===e.type||"keyup"===e.type?e.keyCode:0},which:function(e){return"keypress"===e.type?tn(e):"keydown"===e.type||"keyup"===e.type?e.keyCode:0}}),_n=an(Nn),Rn=an(M({},pn,{pointerId:0,width:0,height:0,pressure:0,tangentialPressure:0,tiltX:0,tiltY:0,twist:0,pointerType:0,isPrimary:0})),jn=an(M({},dn,{touches:0,targetTouches:0,changedTouches:0,altKey:0,metaKey:0,ctrlKey:0,shiftKey:0,getModifierState:Cn})),Pn=an(M({},un,{propertyName:0,elapsedTime:0,pseudoElement:0})),Tn=M({},pn,{deltaX:function(e){return"deltaX"in e?e.deltaX:"wheelDeltaX"in e?-e.wheelDeltaX:0},deltaY:function(e){return"deltaY"in e?e.deltaY:"wheelDeltaY"in e?-e.wheelDeltaY:"wheelDelta"in e?-e.wheelDelta:0},deltaZ:0,deltaMode:0}),On=an(Tn),Ln=[9,13,27,32],zn=c&&"CompositionEvent"in window,Dn=null;c&&"documentMode"in document&&(Dn=document.documentMode);var Fn=c&&"TextEvent"in window&&!Dn,Mn=c&&(!zn||Dn&&8<Dn&&11>=Dn),In=String.fromCharCode(32),Bn=!1;function An(e,t){switch(e){case"keyup":return-1!==Ln.indexOf(t.keyCode);case"keydown":return 229!==t.keyCode;case"keypress":case"mousedown":case"focusout":return!0;default:return!1}}function Un(e){return"object"===typeof(e=e.detail)&&"data"in e?e.data:null}var Hn=!1;var qn={color:!0,date:!0,datetime:!0,"datetime-local":!0,email:!0,month:!0,number:!0,password:!0,range:!0,search:!0,tel:!0,text:!0,time:!0,url:!0,week:!0};function Vn(e){var t=e&&e.nodeName&&e.nodeName.toLowerCase();return"input"===t?!!qn[e.type]:"textarea"===t}function Wn(e,t,n,r){Ne(r),0<(t=$r(t,"onChange")).length&&(n=new cn("onChange","change",null,n,r),e.push({event:n,listeners:t}))}var $n=null,Qn=null;function Kn(e){Ir(e,0)}function Yn(e){if(Q(wa(e)))return e}function Gn(e,t){if("change"===e)return t}var Xn=!1;if(c){var Jn;if(c){var Zn="oninput"in document;if(!Zn){var er=document.createElement("div");er.setAttribute("oninput","return;"),Zn="function"===typeof er.oninput}Jn=Zn}else Jn=!1;Xn=Jn&&(!document.documentMode||9<document.documentMode)}function tr(){$n&&($n.detachEvent("onpropertychange",nr),Qn=$n=null)}function nr(e){if("value"===e.propertyName&&Yn(Qn)){var t=[];Wn(t,Qn,e,xe(e)),Te(Kn,t)}}function rr(e,t,n){"focusin"===e?(tr(),Qn=n,($n=t).attachEvent("onpropertychange",nr)):"focusout"===e&&tr()}function ar(e){if("selectionchange"===e||"keyup"===e||"keydown"===e)return Yn(Qn)}function or(e,t){if("click"===e)return Yn(t)}function lr(e,t){if("input"===e||"change"===e)return Yn(t)}var ir="function"===typeof Object.is?Object.is:function(e,t){return e===t&&(0!==e||1/e===1/t)||e!==e&&t!==t};function sr(e,t){if(ir(e,t))return!0;if("object"!==typeof e||null===e||"object"!==typeof t||null===t)return!1;var n=Object.keys(e),r=Object.keys(t);if(n.length!==r.length)return!1;for(r=0;r<n.length;r++){var a=n[r];if(!d.call(t,a)||!ir(e[a],t[a]))return!1}return!0}function ur(e){for(;e&&e.firstChild;)e=e.firstChild;return e}function cr(e,t){var n,r=ur(e);for(e=0;r;){if(3===r.nodeType){if(n=e+r.textContent.length,e<=t&&n>=t)return{node:r,offset:t-e};e=n}e:{for(;r;){if(r.nextSibling){r=r.nextSibling;break e}r=r.parentNode}r=void 0}r=ur(r)}}function dr(e,t){return!(!e||!t)&&(e===t||(!e||3!==e.nodeType)&&(t&&3===t.nodeType?dr(e,t.parentNode):"contains"in e?e.contains(t):!!e.compareDocumentPosition&&!!(16&e.compareDocumentPosition(t))))}function fr(){for(var e=window,t=K();t instanceof e.HTMLIFrameElement;){try{var n="string"===typeof t.contentWindow.location.href}catch(r){n=!1}if(!n)break;t=K((e=t.contentWindow).document)}return t}function pr(e){var t=e&&e.nodeName&&e.nodeName.toLowerCase();return t&&("input"===t&&("text"===e.type||"search"===e.type||"tel"===e.type||"url"===e.type||"password"===e.type)||"textarea"===t||"true"===e.contentEditable)}function hr(e){var t=fr(),n=e.focusedElem,r=e.selectionRange;if(t!==n&&n&&n.ownerDocument&&dr(n.ownerDocument.documentElement,n)){if(null!==r&&pr(n))if(t=r.start,void 0===(e=r.end)&&(e=t),"selectionStart"in n)n.selectionStart=t,n.selectionEnd=Math.min(e,n.value.length);else if((e=(t=n.ownerDocument||document)&&t.defaultView||window).getSelection){e=e.getSelection();var a=n.textContent.length,o=Math.min(r.start,a);r=void 0===r.end?o:Math.min(r.end,a),!e.extend&&o>r&&(a=r,r=o,o=a),a=cr(n,o);var l=cr(n,r);a&&l&&(1!==e.rangeCount||e.anchorNode!==a.node||e.anchorOffset!==a.offset||e.focusNode!==l.node||e.focusOffset!==l.offset)&&((t=t.createRange()).setStart(a.node,a.offset),e.removeAllRanges(),o>r?(e.addRange(t),e.extend(l.node,l.offset)):(t.setEnd(l.node,l.offset),e.addRange(t)))}for(t=[],e=n;e=e.parentNode;)1===e.nodeType&&t.push({element:e,left:e.scrollLeft,top:e.scrollTop});for("function"===typeof n.focus&&n.focus(),n=0;n<t.length;n++)(e=t[n]).element.scrollLeft=e.left,e.element.scrollTop=e.top}}var vr=c&&"documentMode"in document&&11>=document.documentMode,mr=null,yr=null,gr=null,br=!1;function wr(e,t,n){var r=n.window===n?n.document:9===n.nodeType?n:n.ownerDocument;br||null==mr||mr!==K(r)||("selectionStart"in(r=mr)&&pr(r)?r={start:r.selectionStart,end:r.selectionEnd}:r={anchorNode:(r=(r.ownerDocument&&r.ownerDocument.defaultView||window).getSelection()).anchorNode,anchorOffset:r.anchorOffset,focusNode:r.focusNode,focusOffset:r.focusOffset},gr&&sr(gr,r)||(gr=r,0<(r=$r(yr,"onSelect")).length&&(t=new cn("onSelect","select",null,t,n),e.push({event:t,listeners:r}),t.target=mr)))}function xr(e,t){var n={};return n[e.toLowerCase()]=t.toLowerCase(),n["Webkit"+e]="webkit"+t,n["Moz"+e]="moz"+t,n}var kr={animationend:xr("Animation","AnimationEnd"),animationiteration:xr("Animation","AnimationIteration"),animationstart:xr("Animation","AnimationStart"),transitionend:xr("Transition","TransitionEnd")},Sr={},Er={};function Cr(e){if(Sr[e])return Sr[e];if(!kr[e])return e;var t,n=kr[e];for(t in n)if(n.hasOwnProperty(t)&&t in Er)return Sr[e]=n[t];return e}c&&(Er=document.createElement("div").style,"AnimationEvent"in window||(delete kr.animationend.animation,delete kr.animationiteration.animation,delete kr.animationstart.animation),"TransitionEvent"in window||delete kr.transitionend.transition);var Nr=Cr("animationend"),_r=Cr("animationiteration"),Rr=Cr("animationstart"),jr=Cr("transitionend"),Pr=new Map,Tr="abort auxClick cancel canPlay canPlayThrough click close contextMenu copy cut drag dragEnd dragEnter dragExit dragLeave dragOver dragStart drop durationChange emptied encrypted ended error gotPointerCapture input invalid keyDown keyPress keyUp load loadedData loadedMetadata loadStart lostPointerCapture mouseDown mouseMove mouseOut mouseOver mouseUp paste pause play playing pointerCancel pointerDown pointerMove pointerOut pointerOver pointerUp progress rateChange reset resize seeked seeking stalled submit suspend timeUpdate touchCancel touchEnd touchStart volumeChange scroll toggle touchMove waiting wheel".split(" ");function Or(e,t){Pr.set(e,t),s(t,[e])}for(var Lr=0;Lr<Tr.length;Lr++){var zr=Tr[Lr];Or(zr.toLowerCase(),"on"+(zr[0].toUpperCase()+zr.slice(1)))}Or(Nr,"onAnimationEnd"),Or(_r,"onAnimationIteration"),Or(Rr,"onAnimationStart"),Or("dblclick","onDoubleClick"),Or("focusin","onFocus"),Or("focusout","onBlur"),Or(jr,"onTransitionEnd"),u("onMouseEnter",["mouseout","mouseover"]),u("onMouseLeave",["mouseout","mouseover"]),u("onPointerEnter",["pointerout","pointerover"]),u("onPointerLeave",["pointerout","pointerover"]),s("onChange","change click focusin focusout input keydown keyup selectionchange".split(" ")),s("onSelect","focusout contextmenu dragend focusin keydown keyup mousedown mouseup selectionchange".split(" ")),s("onBeforeInput",["compositionend","keypress","textInput","paste"]),s("onCompositionEnd","compositionend focusout keydown keypress keyup mousedown".split(" ")),s("onCompositionStart","compositionstart focusout keydown keypress keyup mousedown".split(" ")),s("onCompositionUpdate","compositionupdate focusout keydown keypress keyup mousedown".split(" "));var Dr="abort canplay canplaythrough durationchange emptied encrypted ended error loadeddata loadedmetadata loadstart pause play playing progress ratechange resize seeked seeking stalled suspend timeupdate volumechange waiting".split(" "),Fr=new Set("cancel close invalid load scroll toggle".split(" ").concat(Dr));function Mr(e,t,n){var r=e.type||"unknown-event";e.currentTarget=n,function(e,t,n,r,a,l,i,s,u){if(Ue.apply(this,arguments),Fe){if(!Fe)throw Error(o(198));var c=Me;Fe=!1,Me=null,Ie||(Ie=!0,Be=c)}}(r,t,void 0,e),e.currentTarget=null}function Ir(e,t){t=0!==(4&t);for(var n=0;n<e.length;n++){var r=e[n],a=r.event;r=r.listeners;e:{var o=void 0;if(t)for(var l=r.length-1;0<=l;l--){var i=r[l],s=i.instance,u=i.currentTarget;if(i=i.listener,s!==o&&a.isPropagationStopped())break e;Mr(a,i,u),o=s}else for(l=0;l<r.length;l++){if(s=(i=r[l]).instance,u=i.currentTarget,i=i.listener,s!==o&&a.isPropagationStopped())break e;Mr(a,i,u),o=s}}}if(Ie)throw e=Be,Ie=!1,Be=null,e}function Br(e,t){var n=t[va];void 0===n&&(n=t[va]=new Set);var r=e+"__bubble";n.has(r)||(qr(t,e,2,!1),n.add(r))}function Ar(e,t,n){var r=0;t&&(r|=4),qr(n,e,r,t)}var Ur="_reactListening"+Math.random().toString(36).slice(2);function Hr(e){if(!e[Ur]){e[Ur]=!0,l.forEach((function(t){"selectionchange"!==t&&(Fr.has(t)||Ar(t,!1,e),Ar(t,!0,e))}));var t=9===e.nodeType?e:e.ownerDocument;null===t||t[Ur]||(t[Ur]=!0,Ar("selectionchange",!1,t))}}function qr(e,t,n,r){switch(Gt(t)){case 1:var a=Wt;break;case 4:a=$t;break;default:a=Qt}n=a.bind(null,t,n,e),a=void 0,!Le||"touchstart"!==t&&"touchmove"!==t&&"wheel"!==t||(a=!0),r?void 0!==a?e.addEventListener(t,n,{capture:!0,passive:a}):e.addEventListener(t,n,!0):void 0!==a?e.addEventListener(t,n,{passive:a}):e.addEventListener(t,n,!1)}function Vr(e,t,n,r,a){var o=r;if(0===(1&t)&&0===(2&t)&&null!==r)e:for(;;){if(null===r)return;var l=r.tag;if(3===l||4===l){var i=r.stateNode.containerInfo;if(i===a||8===i.nodeType&&i.parentNode===a)break;if(4===l)for(l=r.return;null!==l;){var s=l.tag;if((3===s||4===s)&&((s=l.stateNode.containerInfo)===a||8===s.nodeType&&s.parentNode===a))return;l=l.return}for(;null!==i;){if(null===(l=ga(i)))return;if(5===(s=l.tag)||6===s){r=o=l;continue e}i=i.parentNode}}r=r.return}Te((function(){var r=o,a=xe(n),l=[];e:{var i=Pr.get(e);if(void 0!==i){var s=cn,u=e;switch(e){case"keypress":if(0===tn(n))break e;case"keydown":case"keyup":s=_n;break;case"focusin":u="focus",s=mn;break;case"focusout":u="blur",s=mn;break;case"beforeblur":case"afterblur":s=mn;break;case"click":if(2===n.button)break e;case"auxclick":case"dblclick":case"mousedown":case"mousemove":case"mouseup":case"mouseout":case"mouseover":case"contextmenu":s=hn;break;case"drag":case"dragend":case"dragenter":case"dragexit":case"dragleave":case"dragover":case"dragstart":case"drop":s=vn;break;case"touchcancel":case"touchend":case"touchmove":case"touchstart":s=jn;break;case Nr:case _r:case Rr:s=yn;break;case jr:s=Pn;break;case"scroll":s=fn;break;case"wheel":s=On;break;case"copy":case"cut":case"paste":s=bn;break;case"gotpointercapture":case"lostpointercapture":case"pointercancel":case"pointerdown":case"pointermove":case"pointerout":case"pointerover":case"pointerup":s=Rn}var c=0!==(4&t),d=!c&&"scroll"===e,f=c?null!==i?i+"Capture":null:i;c=[];for(var p,h=r;null!==h;){var v=(p=h).stateNode;if(5===p.tag&&null!==v&&(p=v,null!==f&&(null!=(v=Oe(h,f))&&c.push(Wr(h,v,p)))),d)break;h=h.return}0<c.length&&(i=new s(i,u,null,n,a),l.push({event:i,listeners:c}))}}if(0===(7&t)){if(s="mouseout"===e||"pointerout"===e,(!(i="mouseover"===e||"pointerover"===e)||n===we||!(u=n.relatedTarget||n.fromElement)||!ga(u)&&!u[ha])&&(s||i)&&(i=a.window===a?a:(i=a.ownerDocument)?i.defaultView||i.parentWindow:window,s?(s=r,null!==(u=(u=n.relatedTarget||n.toElement)?ga(u):null)&&(u!==(d=He(u))||5!==u.tag&&6!==u.tag)&&(u=null)):(s=null,u=r),s!==u)){if(c=hn,v="onMouseLeave",f="onMouseEnter",h="mouse","pointerout"!==e&&"pointerover"!==e||(c=Rn,v="onPointerLeave",f="onPointerEnter",h="pointer"),d=null==s?i:wa(s),p=null==u?i:wa(u),(i=new c(v,h+"leave",s,n,a)).target=d,i.relatedTarget=p,v=null,ga(a)===r&&((c=new c(f,h+"enter",u,n,a)).target=p,c.relatedTarget=d,v=c),d=v,s&&u)e:{for(f=u,h=0,p=c=s;p;p=Qr(p))h++;for(p=0,v=f;v;v=Qr(v))p++;for(;0<h-p;)c=Qr(c),h--;for(;0<p-h;)f=Qr(f),p--;for(;h--;){if(c===f||null!==f&&c===f.alternate)break e;c=Qr(c),f=Qr(f)}c=null}else c=null;null!==s&&Kr(l,i,s,c,!1),null!==u&&null!==d&&Kr(l,d,u,c,!0)}if("select"===(s=(i=r?wa(r):window).nodeName&&i.nodeName.toLowerCase())||"input"===s&&"file"===i.type)var m=Gn;else if(Vn(i))if(Xn)m=lr;else{m=ar;var y=rr}else(s=i.nodeName)&&"input"===s.toLowerCase()&&("checkbox"===i.type||"radio"===i.type)&&(m=or);switch(m&&(m=m(e,r))?Wn(l,m,n,a):(y&&y(e,i,r),"focusout"===e&&(y=i._wrapperState)&&y.controlled&&"number"===i.type&&ee(i,"number",i.value)),y=r?wa(r):window,e){case"focusin":(Vn(y)||"true"===y.contentEditable)&&(mr=y,yr=r,gr=null);break;case"focusout":gr=yr=mr=null;break;case"mousedown":br=!0;break;case"contextmenu":case"mouseup":case"dragend":br=!1,wr(l,n,a);break;case"selectionchange":if(vr)break;case"keydown":case"keyup":wr(l,n,a)}var g;if(zn)e:{switch(e){case"compositionstart":var b="onCompositionStart";break e;case"compositionend":b="onCompositionEnd";break e;case"compositionupdate":b="onCompositionUpdate";break e}b=void 0}else Hn?An(e,n)&&(b="onCompositionEnd"):"keydown"===e&&229===n.keyCode&&(b="onCompositionStart");b&&(Mn&&"ko"!==n.locale&&(Hn||"onCompositionStart"!==b?"onCompositionEnd"===b&&Hn&&(g=en()):(Jt="value"in(Xt=a)?Xt.value:Xt.textContent,Hn=!0)),0<(y=$r(r,b)).length&&(b=new wn(b,e,null,n,a),l.push({event:b,listeners:y}),g?b.data=g:null!==(g=Un(n))&&(b.data=g))),(g=Fn?function(e,t){switch(e){case"compositionend":return Un(t);case"keypress":return 32!==t.which?null:(Bn=!0,In);case"textInput":return(e=t.data)===In&&Bn?null:e;default:return null}}(e,n):function(e,t){if(Hn)return"compositionend"===e||!zn&&An(e,t)?(e=en(),Zt=Jt=Xt=null,Hn=!1,e):null;switch(e){case"paste":default:return null;case"keypress":if(!(t.ctrlKey||t.altKey||t.metaKey)||t.ctrlKey&&t.altKey){if(t.char&&1<t.char.length)return t.char;if(t.which)return String.fromCharCode(t.which)}return null;case"compositionend":return Mn&&"ko"!==t.locale?null:t.data}}(e,n))&&(0<(r=$r(r,"onBeforeInput")).length&&(a=new wn("onBeforeInput","beforeinput",null,n,a),l.push({event:a,listeners:r}),a.data=g))}Ir(l,t)}))}function Wr(e,t,n){return{instance:e,listener:t,currentTarget:n}}function $r(e,t){for(var n=t+"Capture",r=[];null!==e;){var a=e,o=a.stateNode;5===a.tag&&null!==o&&(a=o,null!=(o=Oe(e,n))&&r.unshift(Wr(e,o,a)),null!=(o=Oe(e,t))&&r.push(Wr(e,o,a))),e=e.return}return r}function Qr(e){if(null===e)return null;do{e=e.return}while(e&&5!==e.tag);return e||null}function Kr(e,t,n,r,a){for(var o=t._reactName,l=[];null!==n&&n!==r;){var i=n,s=i.alternate,u=i.stateNode;if(null!==s&&s===r)break;5===i.tag&&null!==u&&(i=u,a?null!=(s=Oe(n,o))&&l.unshift(Wr(n,s,i)):a||null!=(s=Oe(n,o))&&l.push(Wr(n,s,i))),n=n.return}0!==l.length&&e.push({event:t,listeners:l})}var Yr=/\r\n?/g,Gr=/\u0000|\uFFFD/g;function Xr(e){return("string"===typeof e?e:""+e).replace(Yr,"\n").replace(Gr,"")}function Jr(e,t,n){if(t=Xr(t),Xr(e)!==t&&n)throw Error(o(425))}function Zr(){}var ea=null,ta=null;function na(e,t){return"textarea"===e||"noscript"===e||"string"===typeof t.children||"number"===typeof t.children||"object"===typeof t.dangerouslySetInnerHTML&&null!==t.dangerouslySetInnerHTML&&null!=t.dangerouslySetInnerHTML.__html}var ra="function"===typeof setTimeout?setTimeout:void 0,aa="function"===typeof clearTimeout?clearTimeout:void 0,oa="function"===typeof Promise?Promise:void 0,la="function"===typeof queueMicrotask?queueMicrotask:"undefined"!==typeof oa?function(e){return oa.resolve(null).then(e).catch(ia)}:ra;function ia(e){setTimeout((function(){throw e}))}function sa(e,t){var n=t,r=0;do{var a=n.nextSibling;if(e.removeChild(n),a&&8===a.nodeType)if("/$"===(n=a.data)){if(0===r)return e.removeChild(a),void Ht(t);r--}else"$"!==n&&"$?"!==n&&"$!"!==n||r++;n=a}while(n);Ht(t)}function ua(e){for(;null!=e;e=e.nextSibling){var t=e.nodeType;if(1===t||3===t)break;if(8===t){if("$"===(t=e.data)||"$!"===t||"$?"===t)break;if("/$"===t)return null}}return e}function ca(e){e=e.previousSibling;for(var t=0;e;){if(8===e.nodeType){var n=e.data;if("$"===n||"$!"===n||"$?"===n){if(0===t)return e;t--}else"/$"===n&&t++}e=e.previousSibling}return null}var da=Math.random().toString(36).slice(2),fa="__reactFiber$"+da,pa="__reactProps$"+da,ha="__reactContainer$"+da,va="__reactEvents$"+da,ma="__reactListeners$"+da,ya="__reactHandles$"+da;function ga(e){var t=e[fa];if(t)return t;for(var n=e.parentNode;n;){if(t=n[ha]||n[fa]){if(n=t.alternate,null!==t.child||null!==n&&null!==n.child)for(e=ca(e);null!==e;){if(n=e[fa])return n;e=ca(e)}return t}n=(e=n).parentNode}return null}function ba(e){return!(e=e[fa]||e[ha])||5!==e.tag&&6!==e.tag&&13!==e.tag&&3!==e.tag?null:e}function wa(e){if(5===e.tag||6===e.tag)return e.stateNode;throw Error(o(33))}function xa(e){return e[pa]||null}var ka=[],Sa=-1;function Ea(e){return{current:e}}function Ca(e){0>Sa||(e.current=ka[Sa],ka[Sa]=null,Sa--)}function Na(e,t){Sa++,ka[Sa]=e.current,e.current=t}var _a={},Ra=Ea(_a),ja=Ea(!1),Pa=_a;function Ta(e,t){var n=e.type.contextTypes;if(!n)return _a;var r=e.stateNode;if(r&&r.__reactInternalMemoizedUnmaskedChildContext===t)return r.__reactInternalMemoizedMaskedChildContext;var a,o={};for(a in n)o[a]=t[a];return r&&((e=e.stateNode).__reactInternalMemoizedUnmaskedChildContext=t,e.__reactInternalMemoizedMaskedChildContext=o),o}function Oa(e){return null!==(e=e.childContextTypes)&&void 0!==e}function La(){Ca(ja),Ca(Ra)}function za(e,t,n){if(Ra.current!==_a)throw Error(o(168));Na(Ra,t),Na(ja,n)}function Da(e,t,n){var r=e.stateNode;if(t=t.childContextTypes,"function"!==typeof r.getChildContext)return n;for(var a in r=r.getChildContext())if(!(a in t))throw Error(o(108,q(e)||"Unknown",a));return M({},n,r)}function Fa(e){return e=(e=e.stateNode)&&e.__reactInternalMemoizedMergedChildContext||_a,Pa=Ra.current,Na(Ra,e),Na(ja,ja.current),!0}function Ma(e,t,n){var r=e.stateNode;if(!r)throw Error(o(169));n?(e=Da(e,t,Pa),r.__reactInternalMemoizedMergedChildContext=e,Ca(ja),Ca(Ra),Na(Ra,e)):Ca(ja),Na(ja,n)}var Ia=null,Ba=!1,Aa=!1;function Ua(e){null===Ia?Ia=[e]:Ia.push(e)}function Ha(){if(!Aa&&null!==Ia){Aa=!0;var e=0,t=bt;try{var n=Ia;for(bt=1;e<n.length;e++){var r=n[e];do{r=r(!0)}while(null!==r)}Ia=null,Ba=!1}catch(a){throw null!==Ia&&(Ia=Ia.slice(e+1)),Qe(Ze,Ha),a}finally{bt=t,Aa=!1}}return null}var qa=[],Va=0,Wa=null,$a=0,Qa=[],Ka=0,Ya=null,Ga=1,Xa="";function Ja(e,t){qa[Va++]=$a,qa[Va++]=Wa,Wa=e,$a=t}function Za(e,t,n){Qa[Ka++]=Ga,Qa[Ka++]=Xa,Qa[Ka++]=Ya,Ya=e;var r=Ga;e=Xa;var a=32-lt(r)-1;r&=~(1<<a),n+=1;var o=32-lt(t)+a;if(30<o){var l=a-a%5;o=(r&(1<<l)-1).toString(32),r>>=l,a-=l,Ga=1<<32-lt(t)+a|n<<a|r,Xa=o+e}else Ga=1<<o|n<<a|r,Xa=e}function eo(e){null!==e.return&&(Ja(e,1),Za(e,1,0))}function to(e){for(;e===Wa;)Wa=qa[--Va],qa[Va]=null,$a=qa[--Va],qa[Va]=null;for(;e===Ya;)Ya=Qa[--Ka],Qa[Ka]=null,Xa=Qa[--Ka],Qa[Ka]=null,Ga=Qa[--Ka],Qa[Ka]=null}var no=null,ro=null,ao=!1,oo=null;function lo(e,t){var n=Tu(5,null,null,0);n.elementType="DELETED",n.stateNode=t,n.return=e,null===(t=e.deletions)?(e.deletions=[n],e.flags|=16):t.push(n)}function io(e,t){switch(e.tag){case 5:var n=e.type;return null!==(t=1!==t.nodeType||n.toLowerCase()!==t.nodeName.toLowerCase()?null:t)&&(e.stateNode=t,no=e,ro=ua(t.firstChild),!0);case 6:return null!==(t=""===e.pendingProps||3!==t.nodeType?null:t)&&(e.stateNode=t,no=e,ro=null,!0);case 13:return null!==(t=8!==t.nodeType?null:t)&&(n=null!==Ya?{id:Ga,overflow:Xa}:null,e.memoizedState={dehydrated:t,treeContext:n,retryLane:1073741824},(n=Tu(18,null,null,0)).stateNode=t,n.return=e,e.child=n,no=e,ro=null,!0);default:return!1}}function so(e){return 0!==(1&e.mode)&&0===(128&e.flags)}function uo(e){if(ao){var t=ro;if(t){var n=t;if(!io(e,t)){if(so(e))throw Error(o(418));t=ua(n.nextSibling);var r=no;t&&io(e,t)?lo(r,n):(e.flags=-4097&e.flags|2,ao=!1,no=e)}}else{if(so(e))throw Error(o(418));e.flags=-4097&e.flags|2,ao=!1,no=e}}}function co(e){for(e=e.return;null!==e&&5!==e.tag&&3!==e.tag&&13!==e.tag;)e=e.return;no=e}function fo(e){if(e!==no)return!1;if(!ao)return co(e),ao=!0,!1;var t;if((t=3!==e.tag)&&!(t=5!==e.tag)&&(t="head"!==(t=e.type)&&"body"!==t&&!na(e.type,e.memoizedProps)),t&&(t=ro)){if(so(e))throw po(),Error(o(418));for(;t;)lo(e,t),t=ua(t.nextSibling)}if(co(e),13===e.tag){if(!(e=null!==(e=e.memoizedState)?e.dehydrated:null))throw Error(o(317));e:{for(e=e.nextSibling,t=0;e;){if(8===e.nodeType){var n=e.data;if("/$"===n){if(0===t){ro=ua(e.nextSibling);break e}t--}else"$"!==n&&"$!"!==n&&"$?"!==n||t++}e=e.nextSibling}ro=null}}else ro=no?ua(e.stateNode.nextSibling):null;return!0}function po(){for(var e=ro;e;)e=ua(e.nextSibling)}function ho(){ro=no=null,ao=!1}function vo(e){null===oo?oo=[e]:oo.push(e)}var mo=w.ReactCurrentBatchConfig;function yo(e,t){if(e&&e.defaultProps){for(var n in t=M({},t),e=e.defaultProps)void 0===t[n]&&(t[n]=e[n]);return t}return t}var go=Ea(null),bo=null,wo=null,xo=null;function ko(){xo=wo=bo=null}function So(e){var t=go.current;Ca(go),e._currentValue=t}function Eo(e,t,n){for(;null!==e;){var r=e.alternate;if((e.childLanes&t)!==t?(e.childLanes|=t,null!==r&&(r.childLanes|=t)):null!==r&&(r.childLanes&t)!==t&&(r.childLanes|=t),e===n)break;e=e.return}}function Co(e,t){bo=e,xo=wo=null,null!==(e=e.dependencies)&&null!==e.firstContext&&(0!==(e.lanes&t)&&(wi=!0),e.firstContext=null)}function No(e){var t=e._currentValue;if(xo!==e)if(e={context:e,memoizedValue:t,next:null},null===wo){if(null===bo)throw Error(o(308));wo=e,bo.dependencies={lanes:0,firstContext:e}}else wo=wo.next=e;return t}var _o=null;function Ro(e){null===_o?_o=[e]:_o.push(e)}function jo(e,t,n,r){var a=t.interleaved;return null===a?(n.next=n,Ro(t)):(n.next=a.next,a.next=n),t.interleaved=n,Po(e,r)}function Po(e,t){e.lanes|=t;var n=e.alternate;for(null!==n&&(n.lanes|=t),n=e,e=e.return;null!==e;)e.childLanes|=t,null!==(n=e.alternate)&&(n.childLanes|=t),n=e,e=e.return;return 3===n.tag?n.stateNode:null}var To=!1;function Oo(e){e.updateQueue={baseState:e.memoizedState,firstBaseUpdate:null,lastBaseUpdate:null,shared:{pending:null,interleaved:null,lanes:0},effects:null}}function Lo(e,t){e=e.updateQueue,t.updateQueue===e&&(t.updateQueue={baseState:e.baseState,firstBaseUpdate:e.firstBaseUpdate,lastBaseUpdate:e.lastBaseUpdate,shared:e.shared,effects:e.effects})}function zo(e,t){return{eventTime:e,lane:t,tag:0,payload:null,callback:null,next:null}}function Do(e,t,n){var r=e.updateQueue;if(null===r)return null;if(r=r.shared,0!==(2&Rs)){var a=r.pending;return null===a?t.next=t:(t.next=a.next,a.next=t),r.pending=t,Po(e,n)}return null===(a=r.interleaved)?(t.next=t,Ro(r)):(t.next=a.next,a.next=t),r.interleaved=t,Po(e,n)}function Fo(e,t,n){if(null!==(t=t.updateQueue)&&(t=t.shared,0!==(4194240&n))){var r=t.lanes;n|=r&=e.pendingLanes,t.lanes=n,gt(e,n)}}function Mo(e,t){var n=e.updateQueue,r=e.alternate;if(null!==r&&n===(r=r.updateQueue)){var a=null,o=null;if(null!==(n=n.firstBaseUpdate)){do{var l={eventTime:n.eventTime,lane:n.lane,tag:n.tag,payload:n.payload,callback:n.callback,next:null};null===o?a=o=l:o=o.next=l,n=n.next}while(null!==n);null===o?a=o=t:o=o.next=t}else a=o=t;return n={baseState:r.baseState,firstBaseUpdate:a,lastBaseUpdate:o,shared:r.shared,effects:r.effects},void(e.updateQueue=n)}null===(e=n.lastBaseUpdate)?n.firstBaseUpdate=t:e.next=t,n.lastBaseUpdate=t}function Io(e,t,n,r){var a=e.updateQueue;To=!1;var o=a.firstBaseUpdate,l=a.lastBaseUpdate,i=a.shared.pending;if(null!==i){a.shared.pending=null;var s=i,u=s.next;s.next=null,null===l?o=u:l.next=u,l=s;var c=e.alternate;null!==c&&((i=(c=c.updateQueue).lastBaseUpdate)!==l&&(null===i?c.firstBaseUpdate=u:i.next=u,c.lastBaseUpdate=s))}if(null!==o){var d=a.baseState;for(l=0,c=u=s=null,i=o;;){var f=i.lane,p=i.eventTime;if((r&f)===f){null!==c&&(c=c.next={eventTime:p,lane:0,tag:i.tag,payload:i.payload,callback:i.callback,next:null});e:{var h=e,v=i;switch(f=t,p=n,v.tag){case 1:if("function"===typeof(h=v.payload)){d=h.call(p,d,f);break e}d=h;break e;case 3:h.flags=-65537&h.flags|128;case 0:if(null===(f="function"===typeof(h=v.payload)?h.call(p,d,f):h)||void 0===f)break e;d=M({},d,f);break e;case 2:To=!0}}null!==i.callback&&0!==i.lane&&(e.flags|=64,null===(f=a.effects)?a.effects=[i]:f.push(i))}else p={eventTime:p,lane:f,tag:i.tag,payload:i.payload,callback:i.callback,next:null},null===c?(u=c=p,s=d):c=c.next=p,l|=f;if(null===(i=i.next)){if(null===(i=a.shared.pending))break;i=(f=i).next,f.next=null,a.lastBaseUpdate=f,a.shared.pending=null}}if(null===c&&(s=d),a.baseState=s,a.firstBaseUpdate=u,a.lastBaseUpdate=c,null!==(t=a.shared.interleaved)){a=t;do{l|=a.lane,a=a.next}while(a!==t)}else null===o&&(a.shared.lanes=0);Fs|=l,e.lanes=l,e.memoizedState=d}}function Bo(e,t,n){if(e=t.effects,t.effects=null,null!==e)for(t=0;t<e.length;t++){var r=e[t],a=r.callback;if(null!==a){if(r.callback=null,r=n,"function"!==typeof a)throw Error(o(191,a));a.call(r)}}}var Ao=(new r.Component).refs;function Uo(e,t,n,r){n=null===(n=n(r,t=e.memoizedState))||void 0===n?t:M({},t,n),e.memoizedState=n,0===e.lanes&&(e.updateQueue.baseState=n)}var Ho={isMounted:function(e){return!!(e=e._reactInternals)&&He(e)===e},enqueueSetState:function(e,t,n){e=e._reactInternals;var r=eu(),a=tu(e),o=zo(r,a);o.payload=t,void 0!==n&&null!==n&&(o.callback=n),null!==(t=Do(e,o,a))&&(nu(t,e,a,r),Fo(t,e,a))},enqueueReplaceState:function(e,t,n){e=e._reactInternals;var r=eu(),a=tu(e),o=zo(r,a);o.tag=1,o.payload=t,void 0!==n&&null!==n&&(o.callback=n),null!==(t=Do(e,o,a))&&(nu(t,e,a,r),Fo(t,e,a))},enqueueForceUpdate:function(e,t){e=e._reactInternals;var n=eu(),r=tu(e),a=zo(n,r);a.tag=2,void 0!==t&&null!==t&&(a.callback=t),null!==(t=Do(e,a,r))&&(nu(t,e,r,n),Fo(t,e,r))}};function qo(e,t,n,r,a,o,l){return"function"===typeof(e=e.stateNode).shouldComponentUpdate?e.shouldComponentUpdate(r,o,l):!t.prototype||!t.prototype.isPureReactComponent||(!sr(n,r)||!sr(a,o))}function Vo(e,t,n){var r=!1,a=_a,o=t.contextType;return"object"===typeof o&&null!==o?o=No(o):(a=Oa(t)?Pa:Ra.current,o=(r=null!==(r=t.contextTypes)&&void 0!==r)?Ta(e,a):_a),t=new t(n,o),e.memoizedState=null!==t.state&&void 0!==t.state?t.state:null,t.updater=Ho,e.stateNode=t,t._reactInternals=e,r&&((e=e.stateNode).__reactInternalMemoizedUnmaskedChildContext=a,e.__reactInternalMemoizedMaskedChildContext=o),t}function Wo(e,t,n,r){e=t.state,"function"===typeof t.componentWillReceiveProps&&t.componentWillReceiveProps(n,r),"function"===typeof t.UNSAFE_componentWillReceiveProps&&t.UNSAFE_componentWillReceiveProps(n,r),t.state!==e&&Ho.enqueueReplaceState(t,t.state,null)}function $o(e,t,n,r){var a=e.stateNode;a.props=n,a.state=e.memoizedState,a.refs=Ao,Oo(e);var o=t.contextType;"object"===typeof o&&null!==o?a.context=No(o):(o=Oa(t)?Pa:Ra.current,a.context=Ta(e,o)),a.state=e.memoizedState,"function"===typeof(o=t.getDerivedStateFromProps)&&(Uo(e,t,o,n),a.state=e.memoizedState),"function"===typeof t.getDerivedStateFromProps||"function"===typeof a.getSnapshotBeforeUpdate||"function"!==typeof a.UNSAFE_componentWillMount&&"function"!==typeof a.componentWillMount||(t=a.state,"function"===typeof a.componentWillMount&&a.componentWillMount(),"function"===typeof a.UNSAFE_componentWillMount&&a.UNSAFE_componentWillMount(),t!==a.state&&Ho.enqueueReplaceState(a,a.state,null),Io(e,n,a,r),a.state=e.memoizedState),"function"===typeof a.componentDidMount&&(e.flags|=4194308)}function Qo(e,t,n){if(null!==(e=n.ref)&&"function"!==typeof e&&"object"!==typeof e){if(n._owner){if(n=n._owner){if(1!==n.tag)throw Error(o(309));var r=n.stateNode}if(!r)throw Error(o(147,e));var a=r,l=""+e;return null!==t&&null!==t.ref&&"function"===typeof t.ref&&t.ref._stringRef===l?t.ref:(t=function(e){var t=a.refs;t===Ao&&(t=a.refs={}),null===e?delete t[l]:t[l]=e},t._stringRef=l,t)}if("string"!==typeof e)throw Error(o(284));if(!n._owner)throw Error(o(290,e))}return e}function Ko(e,t){throw e=Object.prototype.toString.call(t),Error(o(31,"[object Object]"===e?"object with keys {"+Object.keys(t).join(", ")+"}":e))}function Yo(e){return(0,e._init)(e._payload)}function Go(e){function t(t,n){if(e){var r=t.deletions;null===r?(t.deletions=[n],t.flags|=16):r.push(n)}}function n(n,r){if(!e)return null;for(;null!==r;)t(n,r),r=r.sibling;return null}function r(e,t){for(e=new Map;null!==t;)null!==t.key?e.set(t.key,t):e.set(t.index,t),t=t.sibling;return e}function a(e,t){return(e=Lu(e,t)).index=0,e.sibling=null,e}function l(t,n,r){return t.index=r,e?null!==(r=t.alternate)?(r=r.index)<n?(t.flags|=2,n):r:(t.flags|=2,n):(t.flags|=1048576,n)}function i(t){return e&&null===t.alternate&&(t.flags|=2),t}function s(e,t,n,r){return null===t||6!==t.tag?((t=Mu(n,e.mode,r)).return=e,t):((t=a(t,n)).return=e,t)}function u(e,t,n,r){var o=n.type;return o===S?d(e,t,n.props.children,r,n.key):null!==t&&(t.elementType===o||"object"===typeof o&&null!==o&&o.$$typeof===O&&Yo(o)===t.type)?((r=a(t,n.props)).ref=Qo(e,t,n),r.return=e,r):((r=zu(n.type,n.key,n.props,null,e.mode,r)).ref=Qo(e,t,n),r.return=e,r)}function c(e,t,n,r){return null===t||4!==t.tag||t.stateNode.containerInfo!==n.containerInfo||t.stateNode.implementation!==n.implementation?((t=Iu(n,e.mode,r)).return=e,t):((t=a(t,n.children||[])).return=e,t)}function d(e,t,n,r,o){return null===t||7!==t.tag?((t=Du(n,e.mode,r,o)).return=e,t):((t=a(t,n)).return=e,t)}function f(e,t,n){if("string"===typeof t&&""!==t||"number"===typeof t)return(t=Mu(""+t,e.mode,n)).return=e,t;if("object"===typeof t&&null!==t){switch(t.$$typeof){case x:return(n=zu(t.type,t.key,t.props,null,e.mode,n)).ref=Qo(e,null,t),n.return=e,n;case k:return(t=Iu(t,e.mode,n)).return=e,t;case O:return f(e,(0,t._init)(t._payload),n)}if(te(t)||D(t))return(t=Du(t,e.mode,n,null)).return=e,t;Ko(e,t)}return null}function p(e,t,n,r){var a=null!==t?t.key:null;if("string"===typeof n&&""!==n||"number"===typeof n)return null!==a?null:s(e,t,""+n,r);if("object"===typeof n&&null!==n){switch(n.$$typeof){case x:return n.key===a?u(e,t,n,r):null;case k:return n.key===a?c(e,t,n,r):null;case O:return p(e,t,(a=n._init)(n._payload),r)}if(te(n)||D(n))return null!==a?null:d(e,t,n,r,null);Ko(e,n)}return null}function h(e,t,n,r,a){if("string"===typeof r&&""!==r||"number"===typeof r)return s(t,e=e.get(n)||null,""+r,a);if("object"===typeof r&&null!==r){switch(r.$$typeof){case x:return u(t,e=e.get(null===r.key?n:r.key)||null,r,a);case k:return c(t,e=e.get(null===r.key?n:r.key)||null,r,a);case O:return h(e,t,n,(0,r._init)(r._payload),a)}if(te(r)||D(r))return d(t,e=e.get(n)||null,r,a,null);Ko(t,r)}return null}function v(a,o,i,s){for(var u=null,c=null,d=o,v=o=0,m=null;null!==d&&v<i.length;v++){d.index>v?(m=d,d=null):m=d.sibling;var y=p(a,d,i[v],s);if(null===y){null===d&&(d=m);break}e&&d&&null===y.alternate&&t(a,d),o=l(y,o,v),null===c?u=y:c.sibling=y,c=y,d=m}if(v===i.length)return n(a,d),ao&&Ja(a,v),u;if(null===d){for(;v<i.length;v++)null!==(d=f(a,i[v],s))&&(o=l(d,o,v),null===c?u=d:c.sibling=d,c=d);return ao&&Ja(a,v),u}for(d=r(a,d);v<i.length;v++)null!==(m=h(d,a,v,i[v],s))&&(e&&null!==m.alternate&&d.delete(null===m.key?v:m.key),o=l(m,o,v),null===c?u=m:c.sibling=m,c=m);return e&&d.forEach((function(e){return t(a,e)})),ao&&Ja(a,v),u}function m(a,i,s,u){var c=D(s);if("function"!==typeof c)throw Error(o(150));if(null==(s=c.call(s)))throw Error(o(151));for(var d=c=null,v=i,m=i=0,y=null,g=s.next();null!==v&&!g.done;m++,g=s.next()){v.index>m?(y=v,v=null):y=v.sibling;var b=p(a,v,g.value,u);if(null===b){null===v&&(v=y);break}e&&v&&null===b.alternate&&t(a,v),i=l(b,i,m),null===d?c=b:d.sibling=b,d=b,v=y}if(g.done)return n(a,v),ao&&Ja(a,m),c;if(null===v){for(;!g.done;m++,g=s.next())null!==(g=f(a,g.value,u))&&(i=l(g,i,m),null===d?c=g:d.sibling=g,d=g);return ao&&Ja(a,m),c}for(v=r(a,v);!g.done;m++,g=s.next())null!==(g=h(v,a,m,g.value,u))&&(e&&null!==g.alternate&&v.delete(null===g.key?m:g.key),i=l(g,i,m),null===d?c=g:d.sibling=g,d=g);return e&&v.forEach((function(e){return t(a,e)})),ao&&Ja(a,m),c}return function e(r,o,l,s){if("object"===typeof l&&null!==l&&l.type===S&&null===l.key&&(l=l.props.children),"object"===typeof l&&null!==l){switch(l.$$typeof){case x:e:{for(var u=l.key,c=o;null!==c;){if(c.key===u){if((u=l.type)===S){if(7===c.tag){n(r,c.sibling),(o=a(c,l.props.children)).return=r,r=o;break e}}else if(c.elementType===u||"object"===typeof u&&null!==u&&u.$$typeof===O&&Yo(u)===c.type){n(r,c.sibling),(o=a(c,l.props)).ref=Qo(r,c,l),o.return=r,r=o;break e}n(r,c);break}t(r,c),c=c.sibling}l.type===S?((o=Du(l.props.children,r.mode,s,l.key)).return=r,r=o):((s=zu(l.type,l.key,l.props,null,r.mode,s)).ref=Qo(r,o,l),s.return=r,r=s)}return i(r);case k:e:{for(c=l.key;null!==o;){if(o.key===c){if(4===o.tag&&o.stateNode.containerInfo===l.containerInfo&&o.stateNode.implementation===l.implementation){n(r,o.sibling),(o=a(o,l.children||[])).return=r,r=o;break e}n(r,o);break}t(r,o),o=o.sibling}(o=Iu(l,r.mode,s)).return=r,r=o}return i(r);case O:return e(r,o,(c=l._init)(l._payload),s)}if(te(l))return v(r,o,l,s);if(D(l))return m(r,o,l,s);Ko(r,l)}return"string"===typeof l&&""!==l||"number"===typeof l?(l=""+l,null!==o&&6===o.tag?(n(r,o.sibling),(o=a(o,l)).return=r,r=o):(n(r,o),(o=Mu(l,r.mode,s)).return=r,r=o),i(r)):n(r,o)}}var Xo=Go(!0),Jo=Go(!1),Zo={},el=Ea(Zo),tl=Ea(Zo),nl=Ea(Zo);function rl(e){if(e===Zo)throw Error(o(174));return e}function al(e,t){switch(Na(nl,t),Na(tl,e),Na(el,Zo),e=t.nodeType){case 9:case 11:t=(t=t.documentElement)?t.namespaceURI:se(null,"");break;default:t=se(t=(e=8===e?t.parentNode:t).namespaceURI||null,e=e.tagName)}Ca(el),Na(el,t)}function ol(){Ca(el),Ca(tl),Ca(nl)}function ll(e){rl(nl.current);var t=rl(el.current),n=se(t,e.type);t!==n&&(Na(tl,e),Na(el,n))}function il(e){tl.current===e&&(Ca(el),Ca(tl))}var sl=Ea(0);function ul(e){for(var t=e;null!==t;){if(13===t.tag){var n=t.memoizedState;if(null!==n&&(null===(n=n.dehydrated)||"$?"===n.data||"$!"===n.data))return t}else if(19===t.tag&&void 0!==t.memoizedProps.revealOrder){if(0!==(128&t.flags))return t}else if(null!==t.child){t.child.return=t,t=t.child;continue}if(t===e)break;for(;null===t.sibling;){if(null===t.return||t.return===e)return null;t=t.return}t.sibling.return=t.return,t=t.sibling}return null}var cl=[];function dl(){for(var e=0;e<cl.length;e++)cl[e]._workInProgressVersionPrimary=null;cl.length=0}var fl=w.ReactCurrentDispatcher,pl=w.ReactCurrentBatchConfig,hl=0,vl=null,ml=null,yl=null,gl=!1,bl=!1,wl=0,xl=0;function kl(){throw Error(o(321))}function Sl(e,t){if(null===t)return!1;for(var n=0;n<t.length&&n<e.length;n++)if(!ir(e[n],t[n]))return!1;return!0}function El(e,t,n,r,a,l){if(hl=l,vl=t,t.memoizedState=null,t.updateQueue=null,t.lanes=0,fl.current=null===e||null===e.memoizedState?ii:si,e=n(r,a),bl){l=0;do{if(bl=!1,wl=0,25<=l)throw Error(o(301));l+=1,yl=ml=null,t.updateQueue=null,fl.current=ui,e=n(r,a)}while(bl)}if(fl.current=li,t=null!==ml&&null!==ml.next,hl=0,yl=ml=vl=null,gl=!1,t)throw Error(o(300));return e}function Cl(){var e=0!==wl;return wl=0,e}function Nl(){var e={memoizedState:null,baseState:null,baseQueue:null,queue:null,next:null};return null===yl?vl.memoizedState=yl=e:yl=yl.next=e,yl}function _l(){if(null===ml){var e=vl.alternate;e=null!==e?e.memoizedState:null}else e=ml.next;var t=null===yl?vl.memoizedState:yl.next;if(null!==t)yl=t,ml=e;else{if(null===e)throw Error(o(310));e={memoizedState:(ml=e).memoizedState,baseState:ml.baseState,baseQueue:ml.baseQueue,queue:ml.queue,next:null},null===yl?vl.memoizedState=yl=e:yl=yl.next=e}return yl}function Rl(e,t){return"function"===typeof t?t(e):t}function jl(e){var t=_l(),n=t.queue;if(null===n)throw Error(o(311));n.lastRenderedReducer=e;var r=ml,a=r.baseQueue,l=n.pending;if(null!==l){if(null!==a){var i=a.next;a.next=l.next,l.next=i}r.baseQueue=a=l,n.pending=null}if(null!==a){l=a.next,r=r.baseState;var s=i=null,u=null,c=l;do{var d=c.lane;if((hl&d)===d)null!==u&&(u=u.next={lane:0,action:c.action,hasEagerState:c.hasEagerState,eagerState:c.eagerState,next:null}),r=c.hasEagerState?c.eagerState:e(r,c.action);else{var f={lane:d,action:c.action,hasEagerState:c.hasEagerState,eagerState:c.eagerState,next:null};null===u?(s=u=f,i=r):u=u.next=f,vl.lanes|=d,Fs|=d}c=c.next}while(null!==c&&c!==l);null===u?i=r:u.next=s,ir(r,t.memoizedState)||(wi=!0),t.memoizedState=r,t.baseState=i,t.baseQueue=u,n.lastRenderedState=r}if(null!==(e=n.interleaved)){a=e;do{l=a.lane,vl.lanes|=l,Fs|=l,a=a.next}while(a!==e)}else null===a&&(n.lanes=0);return[t.memoizedState,n.dispatch]}function Pl(e){var t=_l(),n=t.queue;if(null===n)throw Error(o(311));n.lastRenderedReducer=e;var r=n.dispatch,a=n.pending,l=t.memoizedState;if(null!==a){n.pending=null;var i=a=a.next;do{l=e(l,i.action),i=i.next}while(i!==a);ir(l,t.memoizedState)||(wi=!0),t.memoizedState=l,null===t.baseQueue&&(t.baseState=l),n.lastRenderedState=l}return[l,r]}function Tl(){}function Ol(e,t){var n=vl,r=_l(),a=t(),l=!ir(r.memoizedState,a);if(l&&(r.memoizedState=a,wi=!0),r=r.queue,Vl(Dl.bind(null,n,r,e),[e]),r.getSnapshot!==t||l||null!==yl&&1&yl.memoizedState.tag){if(n.flags|=2048,Bl(9,zl.bind(null,n,r,a,t),void 0,null),null===js)throw Error(o(349));0!==(30&hl)||Ll(n,t,a)}return a}function Ll(e,t,n){e.flags|=16384,e={getSnapshot:t,value:n},null===(t=vl.updateQueue)?(t={lastEffect:null,stores:null},vl.updateQueue=t,t.stores=[e]):null===(n=t.stores)?t.stores=[e]:n.push(e)}function zl(e,t,n,r){t.value=n,t.getSnapshot=r,Fl(t)&&Ml(e)}function Dl(e,t,n){return n((function(){Fl(t)&&Ml(e)}))}function Fl(e){var t=e.getSnapshot;e=e.value;try{var n=t();return!ir(e,n)}catch(r){return!0}}function Ml(e){var t=Po(e,1);null!==t&&nu(t,e,1,-1)}function Il(e){var t=Nl();return"function"===typeof e&&(e=e()),t.memoizedState=t.baseState=e,e={pending:null,interleaved:null,lanes:0,dispatch:null,lastRenderedReducer:Rl,lastRenderedState:e},t.queue=e,e=e.dispatch=ni.bind(null,vl,e),[t.memoizedState,e]}function Bl(e,t,n,r){return e={tag:e,create:t,destroy:n,deps:r,next:null},null===(t=vl.updateQueue)?(t={lastEffect:null,stores:null},vl.updateQueue=t,t.lastEffect=e.next=e):null===(n=t.lastEffect)?t.lastEffect=e.next=e:(r=n.next,n.next=e,e.next=r,t.lastEffect=e),e}function Al(){return _l().memoizedState}function Ul(e,t,n,r){var a=Nl();vl.flags|=e,a.memoizedState=Bl(1|t,n,void 0,void 0===r?null:r)}function Hl(e,t,n,r){var a=_l();r=void 0===r?null:r;var o=void 0;if(null!==ml){var l=ml.memoizedState;if(o=l.destroy,null!==r&&Sl(r,l.deps))return void(a.memoizedState=Bl(t,n,o,r))}vl.flags|=e,a.memoizedState=Bl(1|t,n,o,r)}function ql(e,t){return Ul(8390656,8,e,t)}function Vl(e,t){return Hl(2048,8,e,t)}function Wl(e,t){return Hl(4,2,e,t)}function $l(e,t){return Hl(4,4,e,t)}function Ql(e,t){return"function"===typeof t?(e=e(),t(e),function(){t(null)}):null!==t&&void 0!==t?(e=e(),t.current=e,function(){t.current=null}):void 0}function Kl(e,t,n){return n=null!==n&&void 0!==n?n.concat([e]):null,Hl(4,4,Ql.bind(null,t,e),n)}function Yl(){}function Gl(e,t){var n=_l();t=void 0===t?null:t;var r=n.memoizedState;return null!==r&&null!==t&&Sl(t,r[1])?r[0]:(n.memoizedState=[e,t],e)}function Xl(e,t){var n=_l();t=void 0===t?null:t;var r=n.memoizedState;return null!==r&&null!==t&&Sl(t,r[1])?r[0]:(e=e(),n.memoizedState=[e,t],e)}function Jl(e,t,n){return 0===(21&hl)?(e.baseState&&(e.baseState=!1,wi=!0),e.memoizedState=n):(ir(n,t)||(n=vt(),vl.lanes|=n,Fs|=n,e.baseState=!0),t)}function Zl(e,t){var n=bt;bt=0!==n&&4>n?n:4,e(!0);var r=pl.transition;pl.transition={};try{e(!1),t()}finally{bt=n,pl.transition=r}}function ei(){return _l().memoizedState}function ti(e,t,n){var r=tu(e);if(n={lane:r,action:n,hasEagerState:!1,eagerState:null,next:null},ri(e))ai(t,n);else if(null!==(n=jo(e,t,n,r))){nu(n,e,r,eu()),oi(n,t,r)}}function ni(e,t,n){var r=tu(e),a={lane:r,action:n,hasEagerState:!1,eagerState:null,next:null};if(ri(e))ai(t,a);else{var o=e.alternate;if(0===e.lanes&&(null===o||0===o.lanes)&&null!==(o=t.lastRenderedReducer))try{var l=t.lastRenderedState,i=o(l,n);if(a.hasEagerState=!0,a.eagerState=i,ir(i,l)){var s=t.interleaved;return null===s?(a.next=a,Ro(t)):(a.next=s.next,s.next=a),void(t.interleaved=a)}}catch(u){}null!==(n=jo(e,t,a,r))&&(nu(n,e,r,a=eu()),oi(n,t,r))}}function ri(e){var t=e.alternate;return e===vl||null!==t&&t===vl}function ai(e,t){bl=gl=!0;var n=e.pending;null===n?t.next=t:(t.next=n.next,n.next=t),e.pending=t}function oi(e,t,n){if(0!==(4194240&n)){var r=t.lanes;n|=r&=e.pendingLanes,t.lanes=n,gt(e,n)}}var li={readContext:No,useCallback:kl,useContext:kl,useEffect:kl,useImperativeHandle:kl,useInsertionEffect:kl,useLayoutEffect:kl,useMemo:kl,useReducer:kl,useRef:kl,useState:kl,useDebugValue:kl,useDeferredValue:kl,useTransition:kl,useMutableSource:kl,useSyncExternalStore:kl,useId:kl,unstable_isNewReconciler:!1},ii={readContext:No,useCallback:function(e,t){return Nl().memoizedState=[e,void 0===t?null:t],e},useContext:No,useEffect:ql,useImperativeHandle:function(e,t,n){return n=null!==n&&void 0!==n?n.concat([e]):null,Ul(4194308,4,Ql.bind(null,t,e),n)},useLayoutEffect:function(e,t){return Ul(4194308,4,e,t)},useInsertionEffect:function(e,t){return Ul(4,2,e,t)},useMemo:function(e,t){var n=Nl();return t=void 0===t?null:t,e=e(),n.memoizedState=[e,t],e},useReducer:function(e,t,n){var r=Nl();return t=void 0!==n?n(t):t,r.memoizedState=r.baseState=t,e={pending:null,interleaved:null,lanes:0,dispatch:null,lastRenderedReducer:e,lastRenderedState:t},r.queue=e,e=e.dispatch=ti.bind(null,vl,e),[r.memoizedState,e]},useRef:function(e){return e={current:e},Nl().memoizedState=e},useState:Il,useDebugValue:Yl,useDeferredValue:function(e){return Nl().memoizedState=e},useTransition:function(){var e=Il(!1),t=e[0];return e=Zl.bind(null,e[1]),Nl().memoizedState=e,[t,e]},useMutableSource:function(){},useSyncExternalStore:function(e,t,n){var r=vl,a=Nl();if(ao){if(void 0===n)throw Error(o(407));n=n()}else{if(n=t(),null===js)throw Error(o(349));0!==(30&hl)||Ll(r,t,n)}a.memoizedState=n;var l={value:n,getSnapshot:t};return a.queue=l,ql(Dl.bind(null,r,l,e),[e]),r.flags|=2048,Bl(9,zl.bind(null,r,l,n,t),void 0,null),n},useId:function(){var e=Nl(),t=js.identifierPrefix;if(ao){var n=Xa;t=":"+t+"R"+(n=(Ga&~(1<<32-lt(Ga)-1)).toString(32)+n),0<(n=wl++)&&(t+="H"+n.toString(32)),t+=":"}else t=":"+t+"r"+(n=xl++).toString(32)+":";return e.memoizedState=t},unstable_isNewReconciler:!1},si={readContext:No,useCallback:Gl,useContext:No,useEffect:Vl,useImperativeHandle:Kl,useInsertionEffect:Wl,useLayoutEffect:$l,useMemo:Xl,useReducer:jl,useRef:Al,useState:function(){return jl(Rl)},useDebugValue:Yl,useDeferredValue:function(e){return Jl(_l(),ml.memoizedState,e)},useTransition:function(){return[jl(Rl)[0],_l().memoizedState]},useMutableSource:Tl,useSyncExternalStore:Ol,useId:ei,unstable_isNewReconciler:!1},ui={readContext:No,useCallback:Gl,useContext:No,useEffect:Vl,useImperativeHandle:Kl,useInsertionEffect:Wl,useLayoutEffect:$l,useMemo:Xl,useReducer:Pl,useRef:Al,useState:function(){return Pl(Rl)},useDebugValue:Yl,useDeferredValue:function(e){var t=_l();return null===ml?t.memoizedState=e:Jl(t,ml.memoizedState,e)},useTransition:function(){return[Pl(Rl)[0],_l().memoizedState]},useMutableSource:Tl,useSyncExternalStore:Ol,useId:ei,unstable_isNewReconciler:!1};function ci(e,t){try{var n="",r=t;do{n+=U(r),r=r.return}while(r);var a=n}catch(o){a="\nError generating stack: "+o.message+"\n"+o.stack}return{value:e,source:t,stack:a,digest:null}}function di(e,t,n){return{value:e,source:null,stack:null!=n?n:null,digest:null!=t?t:null}}function fi(e,t){try{console.error(t.value)}catch(n){setTimeout((function(){throw n}))}}var pi="function"===typeof WeakMap?WeakMap:Map;function hi(e,t,n){(n=zo(-1,n)).tag=3,n.payload={element:null};var r=t.value;return n.callback=function(){Vs||(Vs=!0,Ws=r),fi(0,t)},n}function vi(e,t,n){(n=zo(-1,n)).tag=3;var r=e.type.getDerivedStateFromError;if("function"===typeof r){var a=t.value;n.payload=function(){return r(a)},n.callback=function(){fi(0,t)}}var o=e.stateNode;return null!==o&&"function"===typeof o.componentDidCatch&&(n.callback=function(){fi(0,t),"function"!==typeof r&&(null===$s?$s=new Set([this]):$s.add(this));var e=t.stack;this.componentDidCatch(t.value,{componentStack:null!==e?e:""})}),n}function mi(e,t,n){var r=e.pingCache;if(null===r){r=e.pingCache=new pi;var a=new Set;r.set(t,a)}else void 0===(a=r.get(t))&&(a=new Set,r.set(t,a));a.has(n)||(a.add(n),e=Cu.bind(null,e,t,n),t.then(e,e))}function yi(e){do{var t;if((t=13===e.tag)&&(t=null===(t=e.memoizedState)||null!==t.dehydrated),t)return e;e=e.return}while(null!==e);return null}function gi(e,t,n,r,a){return 0===(1&e.mode)?(e===t?e.flags|=65536:(e.flags|=128,n.flags|=131072,n.flags&=-52805,1===n.tag&&(null===n.alternate?n.tag=17:((t=zo(-1,1)).tag=2,Do(n,t,1))),n.lanes|=1),e):(e.flags|=65536,e.lanes=a,e)}var bi=w.ReactCurrentOwner,wi=!1;function xi(e,t,n,r){t.child=null===e?Jo(t,null,n,r):Xo(t,e.child,n,r)}function ki(e,t,n,r,a){n=n.render;var o=t.ref;return Co(t,a),r=El(e,t,n,r,o,a),n=Cl(),null===e||wi?(ao&&n&&eo(t),t.flags|=1,xi(e,t,r,a),t.child):(t.updateQueue=e.updateQueue,t.flags&=-2053,e.lanes&=~a,Vi(e,t,a))}function Si(e,t,n,r,a){if(null===e){var o=n.type;return"function"!==typeof o||Ou(o)||void 0!==o.defaultProps||null!==n.compare||void 0!==n.defaultProps?((e=zu(n.type,null,r,t,t.mode,a)).ref=t.ref,e.return=t,t.child=e):(t.tag=15,t.type=o,Ei(e,t,o,r,a))}if(o=e.child,0===(e.lanes&a)){var l=o.memoizedProps;if((n=null!==(n=n.compare)?n:sr)(l,r)&&e.ref===t.ref)return Vi(e,t,a)}return t.flags|=1,(e=Lu(o,r)).ref=t.ref,e.return=t,t.child=e}function Ei(e,t,n,r,a){if(null!==e){var o=e.memoizedProps;if(sr(o,r)&&e.ref===t.ref){if(wi=!1,t.pendingProps=r=o,0===(e.lanes&a))return t.lanes=e.lanes,Vi(e,t,a);0!==(131072&e.flags)&&(wi=!0)}}return _i(e,t,n,r,a)}function Ci(e,t,n){var r=t.pendingProps,a=r.children,o=null!==e?e.memoizedState:null;if("hidden"===r.mode)if(0===(1&t.mode))t.memoizedState={baseLanes:0,cachePool:null,transitions:null},Na(Ls,Os),Os|=n;else{if(0===(1073741824&n))return e=null!==o?o.baseLanes|n:n,t.lanes=t.childLanes=1073741824,t.memoizedState={baseLanes:e,cachePool:null,transitions:null},t.updateQueue=null,Na(Ls,Os),Os|=e,null;t.memoizedState={baseLanes:0,cachePool:null,transitions:null},r=null!==o?o.baseLanes:n,Na(Ls,Os),Os|=r}else null!==o?(r=o.baseLanes|n,t.memoizedState=null):r=n,Na(Ls,Os),Os|=r;return xi(e,t,a,n),t.child}function Ni(e,t){var n=t.ref;(null===e&&null!==n||null!==e&&e.ref!==n)&&(t.flags|=512,t.flags|=2097152)}function _i(e,t,n,r,a){var o=Oa(n)?Pa:Ra.current;return o=Ta(t,o),Co(t,a),n=El(e,t,n,r,o,a),r=Cl(),null===e||wi?(ao&&r&&eo(t),t.flags|=1,xi(e,t,n,a),t.child):(t.updateQueue=e.updateQueue,t.flags&=-2053,e.lanes&=~a,Vi(e,t,a))}function Ri(e,t,n,r,a){if(Oa(n)){var o=!0;Fa(t)}else o=!1;if(Co(t,a),null===t.stateNode)qi(e,t),Vo(t,n,r),$o(t,n,r,a),r=!0;else if(null===e){var l=t.stateNode,i=t.memoizedProps;l.props=i;var s=l.context,u=n.contextType;"object"===typeof u&&null!==u?u=No(u):u=Ta(t,u=Oa(n)?Pa:Ra.current);var c=n.getDerivedStateFromProps,d="function"===typeof c||"function"===typeof l.getSnapshotBeforeUpdate;d||"function"!==typeof l.UNSAFE_componentWillReceiveProps&&"function"!==typeof l.componentWillReceiveProps||(i!==r||s!==u)&&Wo(t,l,r,u),To=!1;var f=t.memoizedState;l.state=f,Io(t,r,l,a),s=t.memoizedState,i!==r||f!==s||ja.current||To?("function"===typeof c&&(Uo(t,n,c,r),s=t.memoizedState),(i=To||qo(t,n,i,r,f,s,u))?(d||"function"!==typeof l.UNSAFE_componentWillMount&&"function"!==typeof l.componentWillMount||("function"===typeof l.componentWillMount&&l.componentWillMount(),"function"===typeof l.UNSAFE_componentWillMount&&l.UNSAFE_componentWillMount()),"function"===typeof l.componentDidMount&&(t.flags|=4194308)):("function"===typeof l.componentDidMount&&(t.flags|=4194308),t.memoizedProps=r,t.memoizedState=s),l.props=r,l.state=s,l.context=u,r=i):("function"===typeof l.componentDidMount&&(t.flags|=4194308),r=!1)}else{l=t.stateNode,Lo(e,t),i=t.memoizedProps,u=t.type===t.elementType?i:yo(t.type,i),l.props=u,d=t.pendingProps,f=l.context,"object"===typeof(s=n.contextType)&&null!==s?s=No(s):s=Ta(t,s=Oa(n)?Pa:Ra.current);var p=n.getDerivedStateFromProps;(c="function"===typeof p||"function"===typeof l.getSnapshotBeforeUpdate)||"function"!==typeof l.UNSAFE_componentWillReceiveProps&&"function"!==typeof l.componentWillReceiveProps||(i!==d||f!==s)&&Wo(t,l,r,s),To=!1,f=t.memoizedState,l.state=f,Io(t,r,l,a);var h=t.memoizedState;i!==d||f!==h||ja.current||To?("function"===typeof p&&(Uo(t,n,p,r),h=t.memoizedState),(u=To||qo(t,n,u,r,f,h,s)||!1)?(c||"function"!==typeof l.UNSAFE_componentWillUpdate&&"function"!==typeof l.componentWillUpdate||("function"===typeof l.componentWillUpdate&&l.componentWillUpdate(r,h,s),"function"===typeof l.UNSAFE_componentWillUpdate&&l.UNSAFE_componentWillUpdate(r,h,s)),"function"===typeof l.componentDidUpdate&&(t.flags|=4),"function"===typeof l.getSnapshotBeforeUpdate&&(t.flags|=1024)):("function"!==typeof l.componentDidUpdate||i===e.memoizedProps&&f===e.memoizedState||(t.flags|=4),"function"!==typeof l.getSnapshotBeforeUpdate||i===e.memoizedProps&&f===e.memoizedState||(t.flags|=1024),t.memoizedProps=r,t.memoizedState=h),l.props=r,l.state=h,l.context=s,r=u):("function"!==typeof l.componentDidUpdate||i===e.memoizedProps&&f===e.memoizedState||(t.flags|=4),"function"!==typeof l.getSnapshotBeforeUpdate||i===e.memoizedProps&&f===e.memoizedState||(t.flags|=1024),r=!1)}return ji(e,t,n,r,o,a)}function ji(e,t,n,r,a,o){Ni(e,t);var l=0!==(128&t.flags);if(!r&&!l)return a&&Ma(t,n,!1),Vi(e,t,o);r=t.stateNode,bi.current=t;var i=l&&"function"!==typeof n.getDerivedStateFromError?null:r.render();return t.flags|=1,null!==e&&l?(t.child=Xo(t,e.child,null,o),t.child=Xo(t,null,i,o)):xi(e,t,i,o),t.memoizedState=r.state,a&&Ma(t,n,!0),t.child}function Pi(e){var t=e.stateNode;t.pendingContext?za(0,t.pendingContext,t.pendingContext!==t.context):t.context&&za(0,t.context,!1),al(e,t.containerInfo)}function Ti(e,t,n,r,a){return ho(),vo(a),t.flags|=256,xi(e,t,n,r),t.child}var Oi,Li,zi,Di={dehydrated:null,treeContext:null,retryLane:0};function Fi(e){return{baseLanes:e,cachePool:null,transitions:null}}function Mi(e,t,n){var r,a=t.pendingProps,l=sl.current,i=!1,s=0!==(128&t.flags);if((r=s)||(r=(null===e||null!==e.memoizedState)&&0!==(2&l)),r?(i=!0,t.flags&=-129):null!==e&&null===e.memoizedState||(l|=1),Na(sl,1&l),null===e)return uo(t),null!==(e=t.memoizedState)&&null!==(e=e.dehydrated)?(0===(1&t.mode)?t.lanes=1:"$!"===e.data?t.lanes=8:t.lanes=1073741824,null):(s=a.children,e=a.fallback,i?(a=t.mode,i=t.child,s={mode:"hidden",children:s},0===(1&a)&&null!==i?(i.childLanes=0,i.pendingProps=s):i=Fu(s,a,0,null),e=Du(e,a,n,null),i.return=t,e.return=t,i.sibling=e,t.child=i,t.child.memoizedState=Fi(n),t.memoizedState=Di,e):Ii(t,s));if(null!==(l=e.memoizedState)&&null!==(r=l.dehydrated))return function(e,t,n,r,a,l,i){if(n)return 256&t.flags?(t.flags&=-257,Bi(e,t,i,r=di(Error(o(422))))):null!==t.memoizedState?(t.child=e.child,t.flags|=128,null):(l=r.fallback,a=t.mode,r=Fu({mode:"visible",children:r.children},a,0,null),(l=Du(l,a,i,null)).flags|=2,r.return=t,l.return=t,r.sibling=l,t.child=r,0!==(1&t.mode)&&Xo(t,e.child,null,i),t.child.memoizedState=Fi(i),t.memoizedState=Di,l);if(0===(1&t.mode))return Bi(e,t,i,null);if("$!"===a.data){if(r=a.nextSibling&&a.nextSibling.dataset)var s=r.dgst;return r=s,Bi(e,t,i,r=di(l=Error(o(419)),r,void 0))}if(s=0!==(i&e.childLanes),wi||s){if(null!==(r=js)){switch(i&-i){case 4:a=2;break;case 16:a=8;break;case 64:case 128:case 256:case 512:case 1024:case 2048:case 4096:case 8192:case 16384:case 32768:case 65536:case 131072:case 262144:case 524288:case 1048576:case 2097152:case 4194304:case 8388608:case 16777216:case 33554432:case 67108864:a=32;break;case 536870912:a=268435456;break;default:a=0}0!==(a=0!==(a&(r.suspendedLanes|i))?0:a)&&a!==l.retryLane&&(l.retryLane=a,Po(e,a),nu(r,e,a,-1))}return vu(),Bi(e,t,i,r=di(Error(o(421))))}return"$?"===a.data?(t.flags|=128,t.child=e.child,t=_u.bind(null,e),a._reactRetry=t,null):(e=l.treeContext,ro=ua(a.nextSibling),no=t,ao=!0,oo=null,null!==e&&(Qa[Ka++]=Ga,Qa[Ka++]=Xa,Qa[Ka++]=Ya,Ga=e.id,Xa=e.overflow,Ya=t),t=Ii(t,r.children),t.flags|=4096,t)}(e,t,s,a,r,l,n);if(i){i=a.fallback,s=t.mode,r=(l=e.child).sibling;var u={mode:"hidden",children:a.children};return 0===(1&s)&&t.child!==l?((a=t.child).childLanes=0,a.pendingProps=u,t.deletions=null):(a=Lu(l,u)).subtreeFlags=14680064&l.subtreeFlags,null!==r?i=Lu(r,i):(i=Du(i,s,n,null)).flags|=2,i.return=t,a.return=t,a.sibling=i,t.child=a,a=i,i=t.child,s=null===(s=e.child.memoizedState)?Fi(n):{baseLanes:s.baseLanes|n,cachePool:null,transitions:s.transitions},i.memoizedState=s,i.childLanes=e.childLanes&~n,t.memoizedState=Di,a}return e=(i=e.child).sibling,a=Lu(i,{mode:"visible",children:a.children}),0===(1&t.mode)&&(a.lanes=n),a.return=t,a.sibling=null,null!==e&&(null===(n=t.deletions)?(t.deletions=[e],t.flags|=16):n.push(e)),t.child=a,t.memoizedState=null,a}function Ii(e,t){return(t=Fu({mode:"visible",children:t},e.mode,0,null)).return=e,e.child=t}function Bi(e,t,n,r){return null!==r&&vo(r),Xo(t,e.child,null,n),(e=Ii(t,t.pendingProps.children)).flags|=2,t.memoizedState=null,e}function Ai(e,t,n){e.lanes|=t;var r=e.alternate;null!==r&&(r.lanes|=t),Eo(e.return,t,n)}function Ui(e,t,n,r,a){var o=e.memoizedState;null===o?e.memoizedState={isBackwards:t,rendering:null,renderingStartTime:0,last:r,tail:n,tailMode:a}:(o.isBackwards=t,o.rendering=null,o.renderingStartTime=0,o.last=r,o.tail=n,o.tailMode=a)}function Hi(e,t,n){var r=t.pendingProps,a=r.revealOrder,o=r.tail;if(xi(e,t,r.children,n),0!==(2&(r=sl.current)))r=1&r|2,t.flags|=128;else{if(null!==e&&0!==(128&e.flags))e:for(e=t.child;null!==e;){if(13===e.tag)null!==e.memoizedState&&Ai(e,n,t);else if(19===e.tag)Ai(e,n,t);else if(null!==e.child){e.child.return=e,e=e.child;continue}if(e===t)break e;for(;null===e.sibling;){if(null===e.return||e.return===t)break e;e=e.return}e.sibling.return=e.return,e=e.sibling}r&=1}if(Na(sl,r),0===(1&t.mode))t.memoizedState=null;else switch(a){case"forwards":for(n=t.child,a=null;null!==n;)null!==(e=n.alternate)&&null===ul(e)&&(a=n),n=n.sibling;null===(n=a)?(a=t.child,t.child=null):(a=n.sibling,n.sibling=null),Ui(t,!1,a,n,o);break;case"backwards":for(n=null,a=t.child,t.child=null;null!==a;){if(null!==(e=a.alternate)&&null===ul(e)){t.child=a;break}e=a.sibling,a.sibling=n,n=a,a=e}Ui(t,!0,n,null,o);break;case"together":Ui(t,!1,null,null,void 0);break;default:t.memoizedState=null}return t.child}function qi(e,t){0===(1&t.mode)&&null!==e&&(e.alternate=null,t.alternate=null,t.flags|=2)}function Vi(e,t,n){if(null!==e&&(t.dependencies=e.dependencies),Fs|=t.lanes,0===(n&t.childLanes))return null;if(null!==e&&t.child!==e.child)throw Error(o(153));if(null!==t.child){for(n=Lu(e=t.child,e.pendingProps),t.child=n,n.return=t;null!==e.sibling;)e=e.sibling,(n=n.sibling=Lu(e,e.pendingProps)).return=t;n.sibling=null}return t.child}function Wi(e,t){if(!ao)switch(e.tailMode){case"hidden":t=e.tail;for(var n=null;null!==t;)null!==t.alternate&&(n=t),t=t.sibling;null===n?e.tail=null:n.sibling=null;break;case"collapsed":n=e.tail;for(var r=null;null!==n;)null!==n.alternate&&(r=n),n=n.sibling;null===r?t||null===e.tail?e.tail=null:e.tail.sibling=null:r.sibling=null}}function $i(e){var t=null!==e.alternate&&e.alternate.child===e.child,n=0,r=0;if(t)for(var a=e.child;null!==a;)n|=a.lanes|a.childLanes,r|=14680064&a.subtreeFlags,r|=14680064&a.flags,a.return=e,a=a.sibling;else for(a=e.child;null!==a;)n|=a.lanes|a.childLanes,r|=a.subtreeFlags,r|=a.flags,a.return=e,a=a.sibling;return e.subtreeFlags|=r,e.childLanes=n,t}function Qi(e,t,n){var r=t.pendingProps;switch(to(t),t.tag){case 2:case 16:case 15:case 0:case 11:case 7:case 8:case 12:case 9:case 14:return $i(t),null;case 1:case 17:return Oa(t.type)&&La(),$i(t),null;case 3:return r=t.stateNode,ol(),Ca(ja),Ca(Ra),dl(),r.pendingContext&&(r.context=r.pendingContext,r.pendingContext=null),null!==e&&null!==e.child||(fo(t)?t.flags|=4:null===e||e.memoizedState.isDehydrated&&0===(256&t.flags)||(t.flags|=1024,null!==oo&&(lu(oo),oo=null))),$i(t),null;case 5:il(t);var a=rl(nl.current);if(n=t.type,null!==e&&null!=t.stateNode)Li(e,t,n,r),e.ref!==t.ref&&(t.flags|=512,t.flags|=2097152);else{if(!r){if(null===t.stateNode)throw Error(o(166));return $i(t),null}if(e=rl(el.current),fo(t)){r=t.stateNode,n=t.type;var l=t.memoizedProps;switch(r[fa]=t,r[pa]=l,e=0!==(1&t.mode),n){case"dialog":Br("cancel",r),Br("close",r);break;case"iframe":case"object":case"embed":Br("load",r);break;case"video":case"audio":for(a=0;a<Dr.length;a++)Br(Dr[a],r);break;case"source":Br("error",r);break;case"img":case"image":case"link":Br("error",r),Br("load",r);break;case"details":Br("toggle",r);break;case"input":G(r,l),Br("invalid",r);break;case"select":r._wrapperState={wasMultiple:!!l.multiple},Br("invalid",r);break;case"textarea":ae(r,l),Br("invalid",r)}for(var s in ge(n,l),a=null,l)if(l.hasOwnProperty(s)){var u=l[s];"children"===s?"string"===typeof u?r.textContent!==u&&(!0!==l.suppressHydrationWarning&&Jr(r.textContent,u,e),a=["children",u]):"number"===typeof u&&r.textContent!==""+u&&(!0!==l.suppressHydrationWarning&&Jr(r.textContent,u,e),a=["children",""+u]):i.hasOwnProperty(s)&&null!=u&&"onScroll"===s&&Br("scroll",r)}switch(n){case"input":$(r),Z(r,l,!0);break;case"textarea":$(r),le(r);break;case"select":case"option":break;default:"function"===typeof l.onClick&&(r.onclick=Zr)}r=a,t.updateQueue=r,null!==r&&(t.flags|=4)}else{s=9===a.nodeType?a:a.ownerDocument,"http://www.w3.org/1999/xhtml"===e&&(e=ie(n)),"http://www.w3.org/1999/xhtml"===e?"script"===n?((e=s.createElement("div")).innerHTML="<script><\/script>",e=e.removeChild(e.firstChild)):"string"===typeof r.is?e=s.createElement(n,{is:r.is}):(e=s.createElement(n),"select"===n&&(s=e,r.multiple?s.multiple=!0:r.size&&(s.size=r.size))):e=s.createElementNS(e,n),e[fa]=t,e[pa]=r,Oi(e,t),t.stateNode=e;e:{switch(s=be(n,r),n){case"dialog":Br("cancel",e),Br("close",e),a=r;break;case"iframe":case"object":case"embed":Br("load",e),a=r;break;case"video":case"audio":for(a=0;a<Dr.length;a++)Br(Dr[a],e);a=r;break;case"source":Br("error",e),a=r;break;case"img":case"image":case"link":Br("error",e),Br("load",e),a=r;break;case"details":Br("toggle",e),a=r;break;case"input":G(e,r),a=Y(e,r),Br("invalid",e);break;case"option":default:a=r;break;case"select":e._wrapperState={wasMultiple:!!r.multiple},a=M({},r,{value:void 0}),Br("invalid",e);break;case"textarea":ae(e,r),a=re(e,r),Br("invalid",e)}for(l in ge(n,a),u=a)if(u.hasOwnProperty(l)){var c=u[l];"style"===l?me(e,c):"dangerouslySetInnerHTML"===l?null!=(c=c?c.__html:void 0)&&de(e,c):"children"===l?"string"===typeof c?("textarea"!==n||""!==c)&&fe(e,c):"number"===typeof c&&fe(e,""+c):"suppressContentEditableWarning"!==l&&"suppressHydrationWarning"!==l&&"autoFocus"!==l&&(i.hasOwnProperty(l)?null!=c&&"onScroll"===l&&Br("scroll",e):null!=c&&b(e,l,c,s))}switch(n){case"input":$(e),Z(e,r,!1);break;case"textarea":$(e),le(e);break;case"option":null!=r.value&&e.setAttribute("value",""+V(r.value));break;case"select":e.multiple=!!r.multiple,null!=(l=r.value)?ne(e,!!r.multiple,l,!1):null!=r.defaultValue&&ne(e,!!r.multiple,r.defaultValue,!0);break;default:"function"===typeof a.onClick&&(e.onclick=Zr)}switch(n){case"button":case"input":case"select":case"textarea":r=!!r.autoFocus;break e;case"img":r=!0;break e;default:r=!1}}r&&(t.flags|=4)}null!==t.ref&&(t.flags|=512,t.flags|=2097152)}return $i(t),null;case 6:if(e&&null!=t.stateNode)zi(0,t,e.memoizedProps,r);else{if("string"!==typeof r&&null===t.stateNode)throw Error(o(166));if(n=rl(nl.current),rl(el.current),fo(t)){if(r=t.stateNode,n=t.memoizedProps,r[fa]=t,(l=r.nodeValue!==n)&&null!==(e=no))switch(e.tag){case 3:Jr(r.nodeValue,n,0!==(1&e.mode));break;case 5:!0!==e.memoizedProps.suppressHydrationWarning&&Jr(r.nodeValue,n,0!==(1&e.mode))}l&&(t.flags|=4)}else(r=(9===n.nodeType?n:n.ownerDocument).createTextNode(r))[fa]=t,t.stateNode=r}return $i(t),null;case 13:if(Ca(sl),r=t.memoizedState,null===e||null!==e.memoizedState&&null!==e.memoizedState.dehydrated){if(ao&&null!==ro&&0!==(1&t.mode)&&0===(128&t.flags))po(),ho(),t.flags|=98560,l=!1;else if(l=fo(t),null!==r&&null!==r.dehydrated){if(null===e){if(!l)throw Error(o(318));if(!(l=null!==(l=t.memoizedState)?l.dehydrated:null))throw Error(o(317));l[fa]=t}else ho(),0===(128&t.flags)&&(t.memoizedState=null),t.flags|=4;$i(t),l=!1}else null!==oo&&(lu(oo),oo=null),l=!0;if(!l)return 65536&t.flags?t:null}return 0!==(128&t.flags)?(t.lanes=n,t):((r=null!==r)!==(null!==e&&null!==e.memoizedState)&&r&&(t.child.flags|=8192,0!==(1&t.mode)&&(null===e||0!==(1&sl.current)?0===zs&&(zs=3):vu())),null!==t.updateQueue&&(t.flags|=4),$i(t),null);case 4:return ol(),null===e&&Hr(t.stateNode.containerInfo),$i(t),null;case 10:return So(t.type._context),$i(t),null;case 19:if(Ca(sl),null===(l=t.memoizedState))return $i(t),null;if(r=0!==(128&t.flags),null===(s=l.rendering))if(r)Wi(l,!1);else{if(0!==zs||null!==e&&0!==(128&e.flags))for(e=t.child;null!==e;){if(null!==(s=ul(e))){for(t.flags|=128,Wi(l,!1),null!==(r=s.updateQueue)&&(t.updateQueue=r,t.flags|=4),t.subtreeFlags=0,r=n,n=t.child;null!==n;)e=r,(l=n).flags&=14680066,null===(s=l.alternate)?(l.childLanes=0,l.lanes=e,l.child=null,l.subtreeFlags=0,l.memoizedProps=null,l.memoizedState=null,l.updateQueue=null,l.dependencies=null,l.stateNode=null):(l.childLanes=s.childLanes,l.lanes=s.lanes,l.child=s.child,l.subtreeFlags=0,l.deletions=null,l.memoizedProps=s.memoizedProps,l.memoizedState=s.memoizedState,l.updateQueue=s.updateQueue,l.type=s.type,e=s.dependencies,l.dependencies=null===e?null:{lanes:e.lanes,firstContext:e.firstContext}),n=n.sibling;return Na(sl,1&sl.current|2),t.child}e=e.sibling}null!==l.tail&&Xe()>Hs&&(t.flags|=128,r=!0,Wi(l,!1),t.lanes=4194304)}else{if(!r)if(null!==(e=ul(s))){if(t.flags|=128,r=!0,null!==(n=e.updateQueue)&&(t.updateQueue=n,t.flags|=4),Wi(l,!0),null===l.tail&&"hidden"===l.tailMode&&!s.alternate&&!ao)return $i(t),null}else 2*Xe()-l.renderingStartTime>Hs&&1073741824!==n&&(t.flags|=128,r=!0,Wi(l,!1),t.lanes=4194304);l.isBackwards?(s.sibling=t.child,t.child=s):(null!==(n=l.last)?n.sibling=s:t.child=s,l.last=s)}return null!==l.tail?(t=l.tail,l.rendering=t,l.tail=t.sibling,l.renderingStartTime=Xe(),t.sibling=null,n=sl.current,Na(sl,r?1&n|2:1&n),t):($i(t),null);case 22:case 23:return du(),r=null!==t.memoizedState,null!==e&&null!==e.memoizedState!==r&&(t.flags|=8192),r&&0!==(1&t.mode)?0!==(1073741824&Os)&&($i(t),6&t.subtreeFlags&&(t.flags|=8192)):$i(t),null;case 24:case 25:return null}throw Error(o(156,t.tag))}function Ki(e,t){switch(to(t),t.tag){case 1:return Oa(t.type)&&La(),65536&(e=t.flags)?(t.flags=-65537&e|128,t):null;case 3:return ol(),Ca(ja),Ca(Ra),dl(),0!==(65536&(e=t.flags))&&0===(128&e)?(t.flags=-65537&e|128,t):null;case 5:return il(t),null;case 13:if(Ca(sl),null!==(e=t.memoizedState)&&null!==e.dehydrated){if(null===t.alternate)throw Error(o(340));ho()}return 65536&(e=t.flags)?(t.flags=-65537&e|128,t):null;case 19:return Ca(sl),null;case 4:return ol(),null;case 10:return So(t.type._context),null;case 22:case 23:return du(),null;default:return null}}Oi=function(e,t){for(var n=t.child;null!==n;){if(5===n.tag||6===n.tag)e.appendChild(n.stateNode);else if(4!==n.tag&&null!==n.child){n.child.return=n,n=n.child;continue}if(n===t)break;for(;null===n.sibling;){if(null===n.return||n.return===t)return;n=n.return}n.sibling.return=n.return,n=n.sibling}},Li=function(e,t,n,r){var a=e.memoizedProps;if(a!==r){e=t.stateNode,rl(el.current);var o,l=null;switch(n){case"input":a=Y(e,a),r=Y(e,r),l=[];break;case"select":a=M({},a,{value:void 0}),r=M({},r,{value:void 0}),l=[];break;case"textarea":a=re(e,a),r=re(e,r),l=[];break;default:"function"!==typeof a.onClick&&"function"===typeof r.onClick&&(e.onclick=Zr)}for(c in ge(n,r),n=null,a)if(!r.hasOwnProperty(c)&&a.hasOwnProperty(c)&&null!=a[c])if("style"===c){var s=a[c];for(o in s)s.hasOwnProperty(o)&&(n||(n={}),n[o]="")}else"dangerouslySetInnerHTML"!==c&&"children"!==c&&"suppressContentEditableWarning"!==c&&"suppressHydrationWarning"!==c&&"autoFocus"!==c&&(i.hasOwnProperty(c)?l||(l=[]):(l=l||[]).push(c,null));for(c in r){var u=r[c];if(s=null!=a?a[c]:void 0,r.hasOwnProperty(c)&&u!==s&&(null!=u||null!=s))if("style"===c)if(s){for(o in s)!s.hasOwnProperty(o)||u&&u.hasOwnProperty(o)||(n||(n={}),n[o]="");for(o in u)u.hasOwnProperty(o)&&s[o]!==u[o]&&(n||(n={}),n[o]=u[o])}else n||(l||(l=[]),l.push(c,n)),n=u;else"dangerouslySetInnerHTML"===c?(u=u?u.__html:void 0,s=s?s.__html:void 0,null!=u&&s!==u&&(l=l||[]).push(c,u)):"children"===c?"string"!==typeof u&&"number"!==typeof u||(l=l||[]).push(c,""+u):"suppressContentEditableWarning"!==c&&"suppressHydrationWarning"!==c&&(i.hasOwnProperty(c)?(null!=u&&"onScroll"===c&&Br("scroll",e),l||s===u||(l=[])):(l=l||[]).push(c,u))}n&&(l=l||[]).push("style",n);var c=l;(t.updateQueue=c)&&(t.flags|=4)}},zi=function(e,t,n,r){n!==r&&(t.flags|=4)};var Yi=!1,Gi=!1,Xi="function"===typeof WeakSet?WeakSet:Set,Ji=null;function Zi(e,t){var n=e.ref;if(null!==n)if("function"===typeof n)try{n(null)}catch(r){Eu(e,t,r)}else n.current=null}function es(e,t,n){try{n()}catch(r){Eu(e,t,r)}}var ts=!1;function ns(e,t,n){var r=t.updateQueue;if(null!==(r=null!==r?r.lastEffect:null)){var a=r=r.next;do{if((a.tag&e)===e){var o=a.destroy;a.destroy=void 0,void 0!==o&&es(t,n,o)}a=a.next}while(a!==r)}}function rs(e,t){if(null!==(t=null!==(t=t.updateQueue)?t.lastEffect:null)){var n=t=t.next;do{if((n.tag&e)===e){var r=n.create;n.destroy=r()}n=n.next}while(n!==t)}}function as(e){var t=e.ref;if(null!==t){var n=e.stateNode;e.tag,e=n,"function"===typeof t?t(e):t.current=e}}function os(e){var t=e.alternate;null!==t&&(e.alternate=null,os(t)),e.child=null,e.deletions=null,e.sibling=null,5===e.tag&&(null!==(t=e.stateNode)&&(delete t[fa],delete t[pa],delete t[va],delete t[ma],delete t[ya])),e.stateNode=null,e.return=null,e.dependencies=null,e.memoizedProps=null,e.memoizedState=null,e.pendingProps=null,e.stateNode=null,e.updateQueue=null}function ls(e){return 5===e.tag||3===e.tag||4===e.tag}function is(e){e:for(;;){for(;null===e.sibling;){if(null===e.return||ls(e.return))return null;e=e.return}for(e.sibling.return=e.return,e=e.sibling;5!==e.tag&&6!==e.tag&&18!==e.tag;){if(2&e.flags)continue e;if(null===e.child||4===e.tag)continue e;e.child.return=e,e=e.child}if(!(2&e.flags))return e.stateNode}}function ss(e,t,n){var r=e.tag;if(5===r||6===r)e=e.stateNode,t?8===n.nodeType?n.parentNode.insertBefore(e,t):n.insertBefore(e,t):(8===n.nodeType?(t=n.parentNode).insertBefore(e,n):(t=n).appendChild(e),null!==(n=n._reactRootContainer)&&void 0!==n||null!==t.onclick||(t.onclick=Zr));else if(4!==r&&null!==(e=e.child))for(ss(e,t,n),e=e.sibling;null!==e;)ss(e,t,n),e=e.sibling}function us(e,t,n){var r=e.tag;if(5===r||6===r)e=e.stateNode,t?n.insertBefore(e,t):n.appendChild(e);else if(4!==r&&null!==(e=e.child))for(us(e,t,n),e=e.sibling;null!==e;)us(e,t,n),e=e.sibling}var cs=null,ds=!1;function fs(e,t,n){for(n=n.child;null!==n;)ps(e,t,n),n=n.sibling}function ps(e,t,n){if(ot&&"function"===typeof ot.onCommitFiberUnmount)try{ot.onCommitFiberUnmount(at,n)}catch(i){}switch(n.tag){case 5:Gi||Zi(n,t);case 6:var r=cs,a=ds;cs=null,fs(e,t,n),ds=a,null!==(cs=r)&&(ds?(e=cs,n=n.stateNode,8===e.nodeType?e.parentNode.removeChild(n):e.removeChild(n)):cs.removeChild(n.stateNode));break;case 18:null!==cs&&(ds?(e=cs,n=n.stateNode,8===e.nodeType?sa(e.parentNode,n):1===e.nodeType&&sa(e,n),Ht(e)):sa(cs,n.stateNode));break;case 4:r=cs,a=ds,cs=n.stateNode.containerInfo,ds=!0,fs(e,t,n),cs=r,ds=a;break;case 0:case 11:case 14:case 15:if(!Gi&&(null!==(r=n.updateQueue)&&null!==(r=r.lastEffect))){a=r=r.next;do{var o=a,l=o.destroy;o=o.tag,void 0!==l&&(0!==(2&o)||0!==(4&o))&&es(n,t,l),a=a.next}while(a!==r)}fs(e,t,n);break;case 1:if(!Gi&&(Zi(n,t),"function"===typeof(r=n.stateNode).componentWillUnmount))try{r.props=n.memoizedProps,r.state=n.memoizedState,r.componentWillUnmount()}catch(i){Eu(n,t,i)}fs(e,t,n);break;case 21:fs(e,t,n);break;case 22:1&n.mode?(Gi=(r=Gi)||null!==n.memoizedState,fs(e,t,n),Gi=r):fs(e,t,n);break;default:fs(e,t,n)}}function hs(e){var t=e.updateQueue;if(null!==t){e.updateQueue=null;var n=e.stateNode;null===n&&(n=e.stateNode=new Xi),t.forEach((function(t){var r=Ru.bind(null,e,t);n.has(t)||(n.add(t),t.then(r,r))}))}}function vs(e,t){var n=t.deletions;if(null!==n)for(var r=0;r<n.length;r++){var a=n[r];try{var l=e,i=t,s=i;e:for(;null!==s;){switch(s.tag){case 5:cs=s.stateNode,ds=!1;break e;case 3:case 4:cs=s.stateNode.containerInfo,ds=!0;break e}s=s.return}if(null===cs)throw Error(o(160));ps(l,i,a),cs=null,ds=!1;var u=a.alternate;null!==u&&(u.return=null),a.return=null}catch(c){Eu(a,t,c)}}if(12854&t.subtreeFlags)for(t=t.child;null!==t;)ms(t,e),t=t.sibling}function ms(e,t){var n=e.alternate,r=e.flags;switch(e.tag){case 0:case 11:case 14:case 15:if(vs(t,e),ys(e),4&r){try{ns(3,e,e.return),rs(3,e)}catch(m){Eu(e,e.return,m)}try{ns(5,e,e.return)}catch(m){Eu(e,e.return,m)}}break;case 1:vs(t,e),ys(e),512&r&&null!==n&&Zi(n,n.return);break;case 5:if(vs(t,e),ys(e),512&r&&null!==n&&Zi(n,n.return),32&e.flags){var a=e.stateNode;try{fe(a,"")}catch(m){Eu(e,e.return,m)}}if(4&r&&null!=(a=e.stateNode)){var l=e.memoizedProps,i=null!==n?n.memoizedProps:l,s=e.type,u=e.updateQueue;if(e.updateQueue=null,null!==u)try{"input"===s&&"radio"===l.type&&null!=l.name&&X(a,l),be(s,i);var c=be(s,l);for(i=0;i<u.length;i+=2){var d=u[i],f=u[i+1];"style"===d?me(a,f):"dangerouslySetInnerHTML"===d?de(a,f):"children"===d?fe(a,f):b(a,d,f,c)}switch(s){case"input":J(a,l);break;case"textarea":oe(a,l);break;case"select":var p=a._wrapperState.wasMultiple;a._wrapperState.wasMultiple=!!l.multiple;var h=l.value;null!=h?ne(a,!!l.multiple,h,!1):p!==!!l.multiple&&(null!=l.defaultValue?ne(a,!!l.multiple,l.defaultValue,!0):ne(a,!!l.multiple,l.multiple?[]:"",!1))}a[pa]=l}catch(m){Eu(e,e.return,m)}}break;case 6:if(vs(t,e),ys(e),4&r){if(null===e.stateNode)throw Error(o(162));a=e.stateNode,l=e.memoizedProps;try{a.nodeValue=l}catch(m){Eu(e,e.return,m)}}break;case 3:if(vs(t,e),ys(e),4&r&&null!==n&&n.memoizedState.isDehydrated)try{Ht(t.containerInfo)}catch(m){Eu(e,e.return,m)}break;case 4:default:vs(t,e),ys(e);break;case 13:vs(t,e),ys(e),8192&(a=e.child).flags&&(l=null!==a.memoizedState,a.stateNode.isHidden=l,!l||null!==a.alternate&&null!==a.alternate.memoizedState||(Us=Xe())),4&r&&hs(e);break;case 22:if(d=null!==n&&null!==n.memoizedState,1&e.mode?(Gi=(c=Gi)||d,vs(t,e),Gi=c):vs(t,e),ys(e),8192&r){if(c=null!==e.memoizedState,(e.stateNode.isHidden=c)&&!d&&0!==(1&e.mode))for(Ji=e,d=e.child;null!==d;){for(f=Ji=d;null!==Ji;){switch(h=(p=Ji).child,p.tag){case 0:case 11:case 14:case 15:ns(4,p,p.return);break;case 1:Zi(p,p.return);var v=p.stateNode;if("function"===typeof v.componentWillUnmount){r=p,n=p.return;try{t=r,v.props=t.memoizedProps,v.state=t.memoizedState,v.componentWillUnmount()}catch(m){Eu(r,n,m)}}break;case 5:Zi(p,p.return);break;case 22:if(null!==p.memoizedState){xs(f);continue}}null!==h?(h.return=p,Ji=h):xs(f)}d=d.sibling}e:for(d=null,f=e;;){if(5===f.tag){if(null===d){d=f;try{a=f.stateNode,c?"function"===typeof(l=a.style).setProperty?l.setProperty("display","none","important"):l.display="none":(s=f.stateNode,i=void 0!==(u=f.memoizedProps.style)&&null!==u&&u.hasOwnProperty("display")?u.display:null,s.style.display=ve("display",i))}catch(m){Eu(e,e.return,m)}}}else if(6===f.tag){if(null===d)try{f.stateNode.nodeValue=c?"":f.memoizedProps}catch(m){Eu(e,e.return,m)}}else if((22!==f.tag&&23!==f.tag||null===f.memoizedState||f===e)&&null!==f.child){f.child.return=f,f=f.child;continue}if(f===e)break e;for(;null===f.sibling;){if(null===f.return||f.return===e)break e;d===f&&(d=null),f=f.return}d===f&&(d=null),f.sibling.return=f.return,f=f.sibling}}break;case 19:vs(t,e),ys(e),4&r&&hs(e);case 21:}}function ys(e){var t=e.flags;if(2&t){try{e:{for(var n=e.return;null!==n;){if(ls(n)){var r=n;break e}n=n.return}throw Error(o(160))}switch(r.tag){case 5:var a=r.stateNode;32&r.flags&&(fe(a,""),r.flags&=-33),us(e,is(e),a);break;case 3:case 4:var l=r.stateNode.containerInfo;ss(e,is(e),l);break;default:throw Error(o(161))}}catch(i){Eu(e,e.return,i)}e.flags&=-3}4096&t&&(e.flags&=-4097)}function gs(e,t,n){Ji=e,bs(e,t,n)}function bs(e,t,n){for(var r=0!==(1&e.mode);null!==Ji;){var a=Ji,o=a.child;if(22===a.tag&&r){var l=null!==a.memoizedState||Yi;if(!l){var i=a.alternate,s=null!==i&&null!==i.memoizedState||Gi;i=Yi;var u=Gi;if(Yi=l,(Gi=s)&&!u)for(Ji=a;null!==Ji;)s=(l=Ji).child,22===l.tag&&null!==l.memoizedState?ks(a):null!==s?(s.return=l,Ji=s):ks(a);for(;null!==o;)Ji=o,bs(o,t,n),o=o.sibling;Ji=a,Yi=i,Gi=u}ws(e)}else 0!==(8772&a.subtreeFlags)&&null!==o?(o.return=a,Ji=o):ws(e)}}function ws(e){for(;null!==Ji;){var t=Ji;if(0!==(8772&t.flags)){var n=t.alternate;try{if(0!==(8772&t.flags))switch(t.tag){case 0:case 11:case 15:Gi||rs(5,t);break;case 1:var r=t.stateNode;if(4&t.flags&&!Gi)if(null===n)r.componentDidMount();else{var a=t.elementType===t.type?n.memoizedProps:yo(t.type,n.memoizedProps);r.componentDidUpdate(a,n.memoizedState,r.__reactInternalSnapshotBeforeUpdate)}var l=t.updateQueue;null!==l&&Bo(t,l,r);break;case 3:var i=t.updateQueue;if(null!==i){if(n=null,null!==t.child)switch(t.child.tag){case 5:case 1:n=t.child.stateNode}Bo(t,i,n)}break;case 5:var s=t.stateNode;if(null===n&&4&t.flags){n=s;var u=t.memoizedProps;switch(t.type){case"button":case"input":case"select":case"textarea":u.autoFocus&&n.focus();break;case"img":u.src&&(n.src=u.src)}}break;case 6:case 4:case 12:case 19:case 17:case 21:case 22:case 23:case 25:break;case 13:if(null===t.memoizedState){var c=t.alternate;if(null!==c){var d=c.memoizedState;if(null!==d){var f=d.dehydrated;null!==f&&Ht(f)}}}break;default:throw Error(o(163))}Gi||512&t.flags&&as(t)}catch(p){Eu(t,t.return,p)}}if(t===e){Ji=null;break}if(null!==(n=t.sibling)){n.return=t.return,Ji=n;break}Ji=t.return}}function xs(e){for(;null!==Ji;){var t=Ji;if(t===e){Ji=null;break}var n=t.sibling;if(null!==n){n.return=t.return,Ji=n;break}Ji=t.return}}function ks(e){for(;null!==Ji;){var t=Ji;try{switch(t.tag){case 0:case 11:case 15:var n=t.return;try{rs(4,t)}catch(s){Eu(t,n,s)}break;case 1:var r=t.stateNode;if("function"===typeof r.componentDidMount){var a=t.return;try{r.componentDidMount()}catch(s){Eu(t,a,s)}}var o=t.return;try{as(t)}catch(s){Eu(t,o,s)}break;case 5:var l=t.return;try{as(t)}catch(s){Eu(t,l,s)}}}catch(s){Eu(t,t.return,s)}if(t===e){Ji=null;break}var i=t.sibling;if(null!==i){i.return=t.return,Ji=i;break}Ji=t.return}}var Ss,Es=Math.ceil,Cs=w.ReactCurrentDispatcher,Ns=w.ReactCurrentOwner,_s=w.ReactCurrentBatchConfig,Rs=0,js=null,Ps=null,Ts=0,Os=0,Ls=Ea(0),zs=0,Ds=null,Fs=0,Ms=0,Is=0,Bs=null,As=null,Us=0,Hs=1/0,qs=null,Vs=!1,Ws=null,$s=null,Qs=!1,Ks=null,Ys=0,Gs=0,Xs=null,Js=-1,Zs=0;function eu(){return 0!==(6&Rs)?Xe():-1!==Js?Js:Js=Xe()}function tu(e){return 0===(1&e.mode)?1:0!==(2&Rs)&&0!==Ts?Ts&-Ts:null!==mo.transition?(0===Zs&&(Zs=vt()),Zs):0!==(e=bt)?e:e=void 0===(e=window.event)?16:Gt(e.type)}function nu(e,t,n,r){if(50<Gs)throw Gs=0,Xs=null,Error(o(185));yt(e,n,r),0!==(2&Rs)&&e===js||(e===js&&(0===(2&Rs)&&(Ms|=n),4===zs&&iu(e,Ts)),ru(e,r),1===n&&0===Rs&&0===(1&t.mode)&&(Hs=Xe()+500,Ba&&Ha()))}function ru(e,t){var n=e.callbackNode;!function(e,t){for(var n=e.suspendedLanes,r=e.pingedLanes,a=e.expirationTimes,o=e.pendingLanes;0<o;){var l=31-lt(o),i=1<<l,s=a[l];-1===s?0!==(i&n)&&0===(i&r)||(a[l]=pt(i,t)):s<=t&&(e.expiredLanes|=i),o&=~i}}(e,t);var r=ft(e,e===js?Ts:0);if(0===r)null!==n&&Ke(n),e.callbackNode=null,e.callbackPriority=0;else if(t=r&-r,e.callbackPriority!==t){if(null!=n&&Ke(n),1===t)0===e.tag?function(e){Ba=!0,Ua(e)}(su.bind(null,e)):Ua(su.bind(null,e)),la((function(){0===(6&Rs)&&Ha()})),n=null;else{switch(wt(r)){case 1:n=Ze;break;case 4:n=et;break;case 16:default:n=tt;break;case 536870912:n=rt}n=ju(n,au.bind(null,e))}e.callbackPriority=t,e.callbackNode=n}}function au(e,t){if(Js=-1,Zs=0,0!==(6&Rs))throw Error(o(327));var n=e.callbackNode;if(ku()&&e.callbackNode!==n)return null;var r=ft(e,e===js?Ts:0);if(0===r)return null;if(0!==(30&r)||0!==(r&e.expiredLanes)||t)t=mu(e,r);else{t=r;var a=Rs;Rs|=2;var l=hu();for(js===e&&Ts===t||(qs=null,Hs=Xe()+500,fu(e,t));;)try{gu();break}catch(s){pu(e,s)}ko(),Cs.current=l,Rs=a,null!==Ps?t=0:(js=null,Ts=0,t=zs)}if(0!==t){if(2===t&&(0!==(a=ht(e))&&(r=a,t=ou(e,a))),1===t)throw n=Ds,fu(e,0),iu(e,r),ru(e,Xe()),n;if(6===t)iu(e,r);else{if(a=e.current.alternate,0===(30&r)&&!function(e){for(var t=e;;){if(16384&t.flags){var n=t.updateQueue;if(null!==n&&null!==(n=n.stores))for(var r=0;r<n.length;r++){var a=n[r],o=a.getSnapshot;a=a.value;try{if(!ir(o(),a))return!1}catch(i){return!1}}}if(n=t.child,16384&t.subtreeFlags&&null!==n)n.return=t,t=n;else{if(t===e)break;for(;null===t.sibling;){if(null===t.return||t.return===e)return!0;t=t.return}t.sibling.return=t.return,t=t.sibling}}return!0}(a)&&(2===(t=mu(e,r))&&(0!==(l=ht(e))&&(r=l,t=ou(e,l))),1===t))throw n=Ds,fu(e,0),iu(e,r),ru(e,Xe()),n;switch(e.finishedWork=a,e.finishedLanes=r,t){case 0:case 1:throw Error(o(345));case 2:case 5:xu(e,As,qs);break;case 3:if(iu(e,r),(130023424&r)===r&&10<(t=Us+500-Xe())){if(0!==ft(e,0))break;if(((a=e.suspendedLanes)&r)!==r){eu(),e.pingedLanes|=e.suspendedLanes&a;break}e.timeoutHandle=ra(xu.bind(null,e,As,qs),t);break}xu(e,As,qs);break;case 4:if(iu(e,r),(4194240&r)===r)break;for(t=e.eventTimes,a=-1;0<r;){var i=31-lt(r);l=1<<i,(i=t[i])>a&&(a=i),r&=~l}if(r=a,10<(r=(120>(r=Xe()-r)?120:480>r?480:1080>r?1080:1920>r?1920:3e3>r?3e3:4320>r?4320:1960*Es(r/1960))-r)){e.timeoutHandle=ra(xu.bind(null,e,As,qs),r);break}xu(e,As,qs);break;default:throw Error(o(329))}}}return ru(e,Xe()),e.callbackNode===n?au.bind(null,e):null}function ou(e,t){var n=Bs;return e.current.memoizedState.isDehydrated&&(fu(e,t).flags|=256),2!==(e=mu(e,t))&&(t=As,As=n,null!==t&&lu(t)),e}function lu(e){null===As?As=e:As.push.apply(As,e)}function iu(e,t){for(t&=~Is,t&=~Ms,e.suspendedLanes|=t,e.pingedLanes&=~t,e=e.expirationTimes;0<t;){var n=31-lt(t),r=1<<n;e[n]=-1,t&=~r}}function su(e){if(0!==(6&Rs))throw Error(o(327));ku();var t=ft(e,0);if(0===(1&t))return ru(e,Xe()),null;var n=mu(e,t);if(0!==e.tag&&2===n){var r=ht(e);0!==r&&(t=r,n=ou(e,r))}if(1===n)throw n=Ds,fu(e,0),iu(e,t),ru(e,Xe()),n;if(6===n)throw Error(o(345));return e.finishedWork=e.current.alternate,e.finishedLanes=t,xu(e,As,qs),ru(e,Xe()),null}function uu(e,t){var n=Rs;Rs|=1;try{return e(t)}finally{0===(Rs=n)&&(Hs=Xe()+500,Ba&&Ha())}}function cu(e){null!==Ks&&0===Ks.tag&&0===(6&Rs)&&ku();var t=Rs;Rs|=1;var n=_s.transition,r=bt;try{if(_s.transition=null,bt=1,e)return e()}finally{bt=r,_s.transition=n,0===(6&(Rs=t))&&Ha()}}function du(){Os=Ls.current,Ca(Ls)}function fu(e,t){e.finishedWork=null,e.finishedLanes=0;var n=e.timeoutHandle;if(-1!==n&&(e.timeoutHandle=-1,aa(n)),null!==Ps)for(n=Ps.return;null!==n;){var r=n;switch(to(r),r.tag){case 1:null!==(r=r.type.childContextTypes)&&void 0!==r&&La();break;case 3:ol(),Ca(ja),Ca(Ra),dl();break;case 5:il(r);break;case 4:ol();break;case 13:case 19:Ca(sl);break;case 10:So(r.type._context);break;case 22:case 23:du()}n=n.return}if(js=e,Ps=e=Lu(e.current,null),Ts=Os=t,zs=0,Ds=null,Is=Ms=Fs=0,As=Bs=null,null!==_o){for(t=0;t<_o.length;t++)if(null!==(r=(n=_o[t]).interleaved)){n.interleaved=null;var a=r.next,o=n.pending;if(null!==o){var l=o.next;o.next=a,r.next=l}n.pending=r}_o=null}return e}function pu(e,t){for(;;){var n=Ps;try{if(ko(),fl.current=li,gl){for(var r=vl.memoizedState;null!==r;){var a=r.queue;null!==a&&(a.pending=null),r=r.next}gl=!1}if(hl=0,yl=ml=vl=null,bl=!1,wl=0,Ns.current=null,null===n||null===n.return){zs=1,Ds=t,Ps=null;break}e:{var l=e,i=n.return,s=n,u=t;if(t=Ts,s.flags|=32768,null!==u&&"object"===typeof u&&"function"===typeof u.then){var c=u,d=s,f=d.tag;if(0===(1&d.mode)&&(0===f||11===f||15===f)){var p=d.alternate;p?(d.updateQueue=p.updateQueue,d.memoizedState=p.memoizedState,d.lanes=p.lanes):(d.updateQueue=null,d.memoizedState=null)}var h=yi(i);if(null!==h){h.flags&=-257,gi(h,i,s,0,t),1&h.mode&&mi(l,c,t),u=c;var v=(t=h).updateQueue;if(null===v){var m=new Set;m.add(u),t.updateQueue=m}else v.add(u);break e}if(0===(1&t)){mi(l,c,t),vu();break e}u=Error(o(426))}else if(ao&&1&s.mode){var y=yi(i);if(null!==y){0===(65536&y.flags)&&(y.flags|=256),gi(y,i,s,0,t),vo(ci(u,s));break e}}l=u=ci(u,s),4!==zs&&(zs=2),null===Bs?Bs=[l]:Bs.push(l),l=i;do{switch(l.tag){case 3:l.flags|=65536,t&=-t,l.lanes|=t,Mo(l,hi(0,u,t));break e;case 1:s=u;var g=l.type,b=l.stateNode;if(0===(128&l.flags)&&("function"===typeof g.getDerivedStateFromError||null!==b&&"function"===typeof b.componentDidCatch&&(null===$s||!$s.has(b)))){l.flags|=65536,t&=-t,l.lanes|=t,Mo(l,vi(l,s,t));break e}}l=l.return}while(null!==l)}wu(n)}catch(w){t=w,Ps===n&&null!==n&&(Ps=n=n.return);continue}break}}function hu(){var e=Cs.current;return Cs.current=li,null===e?li:e}function vu(){0!==zs&&3!==zs&&2!==zs||(zs=4),null===js||0===(268435455&Fs)&&0===(268435455&Ms)||iu(js,Ts)}function mu(e,t){var n=Rs;Rs|=2;var r=hu();for(js===e&&Ts===t||(qs=null,fu(e,t));;)try{yu();break}catch(a){pu(e,a)}if(ko(),Rs=n,Cs.current=r,null!==Ps)throw Error(o(261));return js=null,Ts=0,zs}function yu(){for(;null!==Ps;)bu(Ps)}function gu(){for(;null!==Ps&&!Ye();)bu(Ps)}function bu(e){var t=Ss(e.alternate,e,Os);e.memoizedProps=e.pendingProps,null===t?wu(e):Ps=t,Ns.current=null}function wu(e){var t=e;do{var n=t.alternate;if(e=t.return,0===(32768&t.flags)){if(null!==(n=Qi(n,t,Os)))return void(Ps=n)}else{if(null!==(n=Ki(n,t)))return n.flags&=32767,void(Ps=n);if(null===e)return zs=6,void(Ps=null);e.flags|=32768,e.subtreeFlags=0,e.deletions=null}if(null!==(t=t.sibling))return void(Ps=t);Ps=t=e}while(null!==t);0===zs&&(zs=5)}function xu(e,t,n){var r=bt,a=_s.transition;try{_s.transition=null,bt=1,function(e,t,n,r){do{ku()}while(null!==Ks);if(0!==(6&Rs))throw Error(o(327));n=e.finishedWork;var a=e.finishedLanes;if(null===n)return null;if(e.finishedWork=null,e.finishedLanes=0,n===e.current)throw Error(o(177));e.callbackNode=null,e.callbackPriority=0;var l=n.lanes|n.childLanes;if(function(e,t){var n=e.pendingLanes&~t;e.pendingLanes=t,e.suspendedLanes=0,e.pingedLanes=0,e.expiredLanes&=t,e.mutableReadLanes&=t,e.entangledLanes&=t,t=e.entanglements;var r=e.eventTimes;for(e=e.expirationTimes;0<n;){var a=31-lt(n),o=1<<a;t[a]=0,r[a]=-1,e[a]=-1,n&=~o}}(e,l),e===js&&(Ps=js=null,Ts=0),0===(2064&n.subtreeFlags)&&0===(2064&n.flags)||Qs||(Qs=!0,ju(tt,(function(){return ku(),null}))),l=0!==(15990&n.flags),0!==(15990&n.subtreeFlags)||l){l=_s.transition,_s.transition=null;var i=bt;bt=1;var s=Rs;Rs|=4,Ns.current=null,function(e,t){if(ea=Vt,pr(e=fr())){if("selectionStart"in e)var n={start:e.selectionStart,end:e.selectionEnd};else e:{var r=(n=(n=e.ownerDocument)&&n.defaultView||window).getSelection&&n.getSelection();if(r&&0!==r.rangeCount){n=r.anchorNode;var a=r.anchorOffset,l=r.focusNode;r=r.focusOffset;try{n.nodeType,l.nodeType}catch(x){n=null;break e}var i=0,s=-1,u=-1,c=0,d=0,f=e,p=null;t:for(;;){for(var h;f!==n||0!==a&&3!==f.nodeType||(s=i+a),f!==l||0!==r&&3!==f.nodeType||(u=i+r),3===f.nodeType&&(i+=f.nodeValue.length),null!==(h=f.firstChild);)p=f,f=h;for(;;){if(f===e)break t;if(p===n&&++c===a&&(s=i),p===l&&++d===r&&(u=i),null!==(h=f.nextSibling))break;p=(f=p).parentNode}f=h}n=-1===s||-1===u?null:{start:s,end:u}}else n=null}n=n||{start:0,end:0}}else n=null;for(ta={focusedElem:e,selectionRange:n},Vt=!1,Ji=t;null!==Ji;)if(e=(t=Ji).child,0!==(1028&t.subtreeFlags)&&null!==e)e.return=t,Ji=e;else for(;null!==Ji;){t=Ji;try{var v=t.alternate;if(0!==(1024&t.flags))switch(t.tag){case 0:case 11:case 15:case 5:case 6:case 4:case 17:break;case 1:if(null!==v){var m=v.memoizedProps,y=v.memoizedState,g=t.stateNode,b=g.getSnapshotBeforeUpdate(t.elementType===t.type?m:yo(t.type,m),y);g.__reactInternalSnapshotBeforeUpdate=b}break;case 3:var w=t.stateNode.containerInfo;1===w.nodeType?w.textContent="":9===w.nodeType&&w.documentElement&&w.removeChild(w.documentElement);break;default:throw Error(o(163))}}catch(x){Eu(t,t.return,x)}if(null!==(e=t.sibling)){e.return=t.return,Ji=e;break}Ji=t.return}v=ts,ts=!1}(e,n),ms(n,e),hr(ta),Vt=!!ea,ta=ea=null,e.current=n,gs(n,e,a),Ge(),Rs=s,bt=i,_s.transition=l}else e.current=n;if(Qs&&(Qs=!1,Ks=e,Ys=a),l=e.pendingLanes,0===l&&($s=null),function(e){if(ot&&"function"===typeof ot.onCommitFiberRoot)try{ot.onCommitFiberRoot(at,e,void 0,128===(128&e.current.flags))}catch(t){}}(n.stateNode),ru(e,Xe()),null!==t)for(r=e.onRecoverableError,n=0;n<t.length;n++)r((a=t[n]).value,{componentStack:a.stack,digest:a.digest});if(Vs)throw Vs=!1,e=Ws,Ws=null,e;0!==(1&Ys)&&0!==e.tag&&ku(),l=e.pendingLanes,0!==(1&l)?e===Xs?Gs++:(Gs=0,Xs=e):Gs=0,Ha()}(e,t,n,r)}finally{_s.transition=a,bt=r}return null}function ku(){if(null!==Ks){var e=wt(Ys),t=_s.transition,n=bt;try{if(_s.transition=null,bt=16>e?16:e,null===Ks)var r=!1;else{if(e=Ks,Ks=null,Ys=0,0!==(6&Rs))throw Error(o(331));var a=Rs;for(Rs|=4,Ji=e.current;null!==Ji;){var l=Ji,i=l.child;if(0!==(16&Ji.flags)){var s=l.deletions;if(null!==s){for(var u=0;u<s.length;u++){var c=s[u];for(Ji=c;null!==Ji;){var d=Ji;switch(d.tag){case 0:case 11:case 15:ns(8,d,l)}var f=d.child;if(null!==f)f.return=d,Ji=f;else for(;null!==Ji;){var p=(d=Ji).sibling,h=d.return;if(os(d),d===c){Ji=null;break}if(null!==p){p.return=h,Ji=p;break}Ji=h}}}var v=l.alternate;if(null!==v){var m=v.child;if(null!==m){v.child=null;do{var y=m.sibling;m.sibling=null,m=y}while(null!==m)}}Ji=l}}if(0!==(2064&l.subtreeFlags)&&null!==i)i.return=l,Ji=i;else e:for(;null!==Ji;){if(0!==(2048&(l=Ji).flags))switch(l.tag){case 0:case 11:case 15:ns(9,l,l.return)}var g=l.sibling;if(null!==g){g.return=l.return,Ji=g;break e}Ji=l.return}}var b=e.current;for(Ji=b;null!==Ji;){var w=(i=Ji).child;if(0!==(2064&i.subtreeFlags)&&null!==w)w.return=i,Ji=w;else e:for(i=b;null!==Ji;){if(0!==(2048&(s=Ji).flags))try{switch(s.tag){case 0:case 11:case 15:rs(9,s)}}catch(k){Eu(s,s.return,k)}if(s===i){Ji=null;break e}var x=s.sibling;if(null!==x){x.return=s.return,Ji=x;break e}Ji=s.return}}if(Rs=a,Ha(),ot&&"function"===typeof ot.onPostCommitFiberRoot)try{ot.onPostCommitFiberRoot(at,e)}catch(k){}r=!0}return r}finally{bt=n,_s.transition=t}}return!1}function Su(e,t,n){e=Do(e,t=hi(0,t=ci(n,t),1),1),t=eu(),null!==e&&(yt(e,1,t),ru(e,t))}function Eu(e,t,n){if(3===e.tag)Su(e,e,n);else for(;null!==t;){if(3===t.tag){Su(t,e,n);break}if(1===t.tag){var r=t.stateNode;if("function"===typeof t.type.getDerivedStateFromError||"function"===typeof r.componentDidCatch&&(null===$s||!$s.has(r))){t=Do(t,e=vi(t,e=ci(n,e),1),1),e=eu(),null!==t&&(yt(t,1,e),ru(t,e));break}}t=t.return}}function Cu(e,t,n){var r=e.pingCache;null!==r&&r.delete(t),t=eu(),e.pingedLanes|=e.suspendedLanes&n,js===e&&(Ts&n)===n&&(4===zs||3===zs&&(130023424&Ts)===Ts&&500>Xe()-Us?fu(e,0):Is|=n),ru(e,t)}function Nu(e,t){0===t&&(0===(1&e.mode)?t=1:(t=ct,0===(130023424&(ct<<=1))&&(ct=4194304)));var n=eu();null!==(e=Po(e,t))&&(yt(e,t,n),ru(e,n))}function _u(e){var t=e.memoizedState,n=0;null!==t&&(n=t.retryLane),Nu(e,n)}function Ru(e,t){var n=0;switch(e.tag){case 13:var r=e.stateNode,a=e.memoizedState;null!==a&&(n=a.retryLane);break;case 19:r=e.stateNode;break;default:throw Error(o(314))}null!==r&&r.delete(t),Nu(e,n)}function ju(e,t){return Qe(e,t)}function Pu(e,t,n,r){this.tag=e,this.key=n,this.sibling=this.child=this.return=this.stateNode=this.type=this.elementType=null,this.index=0,this.ref=null,this.pendingProps=t,this.dependencies=this.memoizedState=this.updateQueue=this.memoizedProps=null,this.mode=r,this.subtreeFlags=this.flags=0,this.deletions=null,this.childLanes=this.lanes=0,this.alternate=null}function Tu(e,t,n,r){return new Pu(e,t,n,r)}function Ou(e){return!(!(e=e.prototype)||!e.isReactComponent)}function Lu(e,t){var n=e.alternate;return null===n?((n=Tu(e.tag,t,e.key,e.mode)).elementType=e.elementType,n.type=e.type,n.stateNode=e.stateNode,n.alternate=e,e.alternate=n):(n.pendingProps=t,n.type=e.type,n.flags=0,n.subtreeFlags=0,n.deletions=null),n.flags=14680064&e.flags,n.childLanes=e.childLanes,n.lanes=e.lanes,n.child=e.child,n.memoizedProps=e.memoizedProps,n.memoizedState=e.memoizedState,n.updateQueue=e.updateQueue,t=e.dependencies,n.dependencies=null===t?null:{lanes:t.lanes,firstContext:t.firstContext},n.sibling=e.sibling,n.index=e.index,n.ref=e.ref,n}function zu(e,t,n,r,a,l){var i=2;if(r=e,"function"===typeof e)Ou(e)&&(i=1);else if("string"===typeof e)i=5;else e:switch(e){case S:return Du(n.children,a,l,t);case E:i=8,a|=8;break;case C:return(e=Tu(12,n,t,2|a)).elementType=C,e.lanes=l,e;case j:return(e=Tu(13,n,t,a)).elementType=j,e.lanes=l,e;case P:return(e=Tu(19,n,t,a)).elementType=P,e.lanes=l,e;case L:return Fu(n,a,l,t);default:if("object"===typeof e&&null!==e)switch(e.$$typeof){case N:i=10;break e;case _:i=9;break e;case R:i=11;break e;case T:i=14;break e;case O:i=16,r=null;break e}throw Error(o(130,null==e?e:typeof e,""))}return(t=Tu(i,n,t,a)).elementType=e,t.type=r,t.lanes=l,t}function Du(e,t,n,r){return(e=Tu(7,e,r,t)).lanes=n,e}function Fu(e,t,n,r){return(e=Tu(22,e,r,t)).elementType=L,e.lanes=n,e.stateNode={isHidden:!1},e}function Mu(e,t,n){return(e=Tu(6,e,null,t)).lanes=n,e}function Iu(e,t,n){return(t=Tu(4,null!==e.children?e.children:[],e.key,t)).lanes=n,t.stateNode={containerInfo:e.containerInfo,pendingChildren:null,implementation:e.implementation},t}function Bu(e,t,n,r,a){this.tag=t,this.containerInfo=e,this.finishedWork=this.pingCache=this.current=this.pendingChildren=null,this.timeoutHandle=-1,this.callbackNode=this.pendingContext=this.context=null,this.callbackPriority=0,this.eventTimes=mt(0),this.expirationTimes=mt(-1),this.entangledLanes=this.finishedLanes=this.mutableReadLanes=this.expiredLanes=this.pingedLanes=this.suspendedLanes=this.pendingLanes=0,this.entanglements=mt(0),this.identifierPrefix=r,this.onRecoverableError=a,this.mutableSourceEagerHydrationData=null}function Au(e,t,n,r,a,o,l,i,s){return e=new Bu(e,t,n,i,s),1===t?(t=1,!0===o&&(t|=8)):t=0,o=Tu(3,null,null,t),e.current=o,o.stateNode=e,o.memoizedState={element:r,isDehydrated:n,cache:null,transitions:null,pendingSuspenseBoundaries:null},Oo(o),e}function Uu(e,t,n){var r=3<arguments.length&&void 0!==arguments[3]?arguments[3]:null;return{$$typeof:k,key:null==r?null:""+r,children:e,containerInfo:t,implementation:n}}function Hu(e){if(!e)return _a;e:{if(He(e=e._reactInternals)!==e||1!==e.tag)throw Error(o(170));var t=e;do{switch(t.tag){case 3:t=t.stateNode.context;break e;case 1:if(Oa(t.type)){t=t.stateNode.__reactInternalMemoizedMergedChildContext;break e}}t=t.return}while(null!==t);throw Error(o(171))}if(1===e.tag){var n=e.type;if(Oa(n))return Da(e,n,t)}return t}function qu(e,t,n,r,a,o,l,i,s){return(e=Au(n,r,!0,e,0,o,0,i,s)).context=Hu(null),n=e.current,(o=zo(r=eu(),a=tu(n))).callback=void 0!==t&&null!==t?t:null,Do(n,o,a),e.current.lanes=a,yt(e,a,r),ru(e,r),e}function Vu(e,t,n,r){var a=t.current,o=eu(),l=tu(a);return n=Hu(n),null===t.context?t.context=n:t.pendingContext=n,(t=zo(o,l)).payload={element:e},null!==(r=void 0===r?null:r)&&(t.callback=r),null!==(e=Do(a,t,l))&&(nu(e,a,l,o),Fo(e,a,l)),l}function Wu(e){return(e=e.current).child?(e.child.tag,e.child.stateNode):null}function $u(e,t){if(null!==(e=e.memoizedState)&&null!==e.dehydrated){var n=e.retryLane;e.retryLane=0!==n&&n<t?n:t}}function Qu(e,t){$u(e,t),(e=e.alternate)&&$u(e,t)}Ss=function(e,t,n){if(null!==e)if(e.memoizedProps!==t.pendingProps||ja.current)wi=!0;else{if(0===(e.lanes&n)&&0===(128&t.flags))return wi=!1,function(e,t,n){switch(t.tag){case 3:Pi(t),ho();break;case 5:ll(t);break;case 1:Oa(t.type)&&Fa(t);break;case 4:al(t,t.stateNode.containerInfo);break;case 10:var r=t.type._context,a=t.memoizedProps.value;Na(go,r._currentValue),r._currentValue=a;break;case 13:if(null!==(r=t.memoizedState))return null!==r.dehydrated?(Na(sl,1&sl.current),t.flags|=128,null):0!==(n&t.child.childLanes)?Mi(e,t,n):(Na(sl,1&sl.current),null!==(e=Vi(e,t,n))?e.sibling:null);Na(sl,1&sl.current);break;case 19:if(r=0!==(n&t.childLanes),0!==(128&e.flags)){if(r)return Hi(e,t,n);t.flags|=128}if(null!==(a=t.memoizedState)&&(a.rendering=null,a.tail=null,a.lastEffect=null),Na(sl,sl.current),r)break;return null;case 22:case 23:return t.lanes=0,Ci(e,t,n)}return Vi(e,t,n)}(e,t,n);wi=0!==(131072&e.flags)}else wi=!1,ao&&0!==(1048576&t.flags)&&Za(t,$a,t.index);switch(t.lanes=0,t.tag){case 2:var r=t.type;qi(e,t),e=t.pendingProps;var a=Ta(t,Ra.current);Co(t,n),a=El(null,t,r,e,a,n);var l=Cl();return t.flags|=1,"object"===typeof a&&null!==a&&"function"===typeof a.render&&void 0===a.$$typeof?(t.tag=1,t.memoizedState=null,t.updateQueue=null,Oa(r)?(l=!0,Fa(t)):l=!1,t.memoizedState=null!==a.state&&void 0!==a.state?a.state:null,Oo(t),a.updater=Ho,t.stateNode=a,a._reactInternals=t,$o(t,r,e,n),t=ji(null,t,r,!0,l,n)):(t.tag=0,ao&&l&&eo(t),xi(null,t,a,n),t=t.child),t;case 16:r=t.elementType;e:{switch(qi(e,t),e=t.pendingProps,r=(a=r._init)(r._payload),t.type=r,a=t.tag=function(e){if("function"===typeof e)return Ou(e)?1:0;if(void 0!==e&&null!==e){if((e=e.$$typeof)===R)return 11;if(e===T)return 14}return 2}(r),e=yo(r,e),a){case 0:t=_i(null,t,r,e,n);break e;case 1:t=Ri(null,t,r,e,n);break e;case 11:t=ki(null,t,r,e,n);break e;case 14:t=Si(null,t,r,yo(r.type,e),n);break e}throw Error(o(306,r,""))}return t;case 0:return r=t.type,a=t.pendingProps,_i(e,t,r,a=t.elementType===r?a:yo(r,a),n);case 1:return r=t.type,a=t.pendingProps,Ri(e,t,r,a=t.elementType===r?a:yo(r,a),n);case 3:e:{if(Pi(t),null===e)throw Error(o(387));r=t.pendingProps,a=(l=t.memoizedState).element,Lo(e,t),Io(t,r,null,n);var i=t.memoizedState;if(r=i.element,l.isDehydrated){if(l={element:r,isDehydrated:!1,cache:i.cache,pendingSuspenseBoundaries:i.pendingSuspenseBoundaries,transitions:i.transitions},t.updateQueue.baseState=l,t.memoizedState=l,256&t.flags){t=Ti(e,t,r,n,a=ci(Error(o(423)),t));break e}if(r!==a){t=Ti(e,t,r,n,a=ci(Error(o(424)),t));break e}for(ro=ua(t.stateNode.containerInfo.firstChild),no=t,ao=!0,oo=null,n=Jo(t,null,r,n),t.child=n;n;)n.flags=-3&n.flags|4096,n=n.sibling}else{if(ho(),r===a){t=Vi(e,t,n);break e}xi(e,t,r,n)}t=t.child}return t;case 5:return ll(t),null===e&&uo(t),r=t.type,a=t.pendingProps,l=null!==e?e.memoizedProps:null,i=a.children,na(r,a)?i=null:null!==l&&na(r,l)&&(t.flags|=32),Ni(e,t),xi(e,t,i,n),t.child;case 6:return null===e&&uo(t),null;case 13:return Mi(e,t,n);case 4:return al(t,t.stateNode.containerInfo),r=t.pendingProps,null===e?t.child=Xo(t,null,r,n):xi(e,t,r,n),t.child;case 11:return r=t.type,a=t.pendingProps,ki(e,t,r,a=t.elementType===r?a:yo(r,a),n);case 7:return xi(e,t,t.pendingProps,n),t.child;case 8:case 12:return xi(e,t,t.pendingProps.children,n),t.child;case 10:e:{if(r=t.type._context,a=t.pendingProps,l=t.memoizedProps,i=a.value,Na(go,r._currentValue),r._currentValue=i,null!==l)if(ir(l.value,i)){if(l.children===a.children&&!ja.current){t=Vi(e,t,n);break e}}else for(null!==(l=t.child)&&(l.return=t);null!==l;){var s=l.dependencies;if(null!==s){i=l.child;for(var u=s.firstContext;null!==u;){if(u.context===r){if(1===l.tag){(u=zo(-1,n&-n)).tag=2;var c=l.updateQueue;if(null!==c){var d=(c=c.shared).pending;null===d?u.next=u:(u.next=d.next,d.next=u),c.pending=u}}l.lanes|=n,null!==(u=l.alternate)&&(u.lanes|=n),Eo(l.return,n,t),s.lanes|=n;break}u=u.next}}else if(10===l.tag)i=l.type===t.type?null:l.child;else if(18===l.tag){if(null===(i=l.return))throw Error(o(341));i.lanes|=n,null!==(s=i.alternate)&&(s.lanes|=n),Eo(i,n,t),i=l.sibling}else i=l.child;if(null!==i)i.return=l;else for(i=l;null!==i;){if(i===t){i=null;break}if(null!==(l=i.sibling)){l.return=i.return,i=l;break}i=i.return}l=i}xi(e,t,a.children,n),t=t.child}return t;case 9:return a=t.type,r=t.pendingProps.children,Co(t,n),r=r(a=No(a)),t.flags|=1,xi(e,t,r,n),t.child;case 14:return a=yo(r=t.type,t.pendingProps),Si(e,t,r,a=yo(r.type,a),n);case 15:return Ei(e,t,t.type,t.pendingProps,n);case 17:return r=t.type,a=t.pendingProps,a=t.elementType===r?a:yo(r,a),qi(e,t),t.tag=1,Oa(r)?(e=!0,Fa(t)):e=!1,Co(t,n),Vo(t,r,a),$o(t,r,a,n),ji(null,t,r,!0,e,n);case 19:return Hi(e,t,n);case 22:return Ci(e,t,n)}throw Error(o(156,t.tag))};var Ku="function"===typeof reportError?reportError:function(e){console.error(e)};function Yu(e){this._internalRoot=e}function Gu(e){this._internalRoot=e}function Xu(e){return!(!e||1!==e.nodeType&&9!==e.nodeType&&11!==e.nodeType)}function Ju(e){return!(!e||1!==e.nodeType&&9!==e.nodeType&&11!==e.nodeType&&(8!==e.nodeType||" react-mount-point-unstable "!==e.nodeValue))}function Zu(){}function ec(e,t,n,r,a){var o=n._reactRootContainer;if(o){var l=o;if("function"===typeof a){var i=a;a=function(){var e=Wu(l);i.call(e)}}Vu(t,l,e,a)}else l=function(e,t,n,r,a){if(a){if("function"===typeof r){var o=r;r=function(){var e=Wu(l);o.call(e)}}var l=qu(t,r,e,0,null,!1,0,"",Zu);return e._reactRootContainer=l,e[ha]=l.current,Hr(8===e.nodeType?e.parentNode:e),cu(),l}for(;a=e.lastChild;)e.removeChild(a);if("function"===typeof r){var i=r;r=function(){var e=Wu(s);i.call(e)}}var s=Au(e,0,!1,null,0,!1,0,"",Zu);return e._reactRootContainer=s,e[ha]=s.current,Hr(8===e.nodeType?e.parentNode:e),cu((function(){Vu(t,s,n,r)})),s}(n,t,e,a,r);return Wu(l)}Gu.prototype.render=Yu.prototype.render=function(e){var t=this._internalRoot;if(null===t)throw Error(o(409));Vu(e,t,null,null)},Gu.prototype.unmount=Yu.prototype.unmount=function(){var e=this._internalRoot;if(null!==e){this._internalRoot=null;var t=e.containerInfo;cu((function(){Vu(null,e,null,null)})),t[ha]=null}},Gu.prototype.unstable_scheduleHydration=function(e){if(e){var t=Et();e={blockedOn:null,target:e,priority:t};for(var n=0;n<Lt.length&&0!==t&&t<Lt[n].priority;n++);Lt.splice(n,0,e),0===n&&Mt(e)}},xt=function(e){switch(e.tag){case 3:var t=e.stateNode;if(t.current.memoizedState.isDehydrated){var n=dt(t.pendingLanes);0!==n&&(gt(t,1|n),ru(t,Xe()),0===(6&Rs)&&(Hs=Xe()+500,Ha()))}break;case 13:cu((function(){var t=Po(e,1);if(null!==t){var n=eu();nu(t,e,1,n)}})),Qu(e,1)}},kt=function(e){if(13===e.tag){var t=Po(e,134217728);if(null!==t)nu(t,e,134217728,eu());Qu(e,134217728)}},St=function(e){if(13===e.tag){var t=tu(e),n=Po(e,t);if(null!==n)nu(n,e,t,eu());Qu(e,t)}},Et=function(){return bt},Ct=function(e,t){var n=bt;try{return bt=e,t()}finally{bt=n}},ke=function(e,t,n){switch(t){case"input":if(J(e,n),t=n.name,"radio"===n.type&&null!=t){for(n=e;n.parentNode;)n=n.parentNode;for(n=n.querySelectorAll("input[name="+JSON.stringify(""+t)+'][type="radio"]'),t=0;t<n.length;t++){var r=n[t];if(r!==e&&r.form===e.form){var a=xa(r);if(!a)throw Error(o(90));Q(r),J(r,a)}}}break;case"textarea":oe(e,n);break;case"select":null!=(t=n.value)&&ne(e,!!n.multiple,t,!1)}},Re=uu,je=cu;var tc={usingClientEntryPoint:!1,Events:[ba,wa,xa,Ne,_e,uu]},nc={findFiberByHostInstance:ga,bundleType:0,version:"18.2.0",rendererPackageName:"react-dom"},rc={bundleType:nc.bundleType,version:nc.version,rendererPackageName:nc.rendererPackageName,rendererConfig:nc.rendererConfig,overrideHookState:null,overrideHookStateDeletePath:null,overrideHookStateRenamePath:null,overrideProps:null,overridePropsDeletePath:null,overridePropsRenamePath:null,setErrorHandler:null,setSuspenseHandler:null,scheduleUpdate:null,currentDispatcherRef:w.ReactCurrentDispatcher,findHostInstanceByFiber:function(e){return null===(e=We(e))?null:e.stateNode},findFiberByHostInstance:nc.findFiberByHostInstance||function(){return null},findHostInstancesForRefresh:null,scheduleRefresh:null,scheduleRoot:null,setRefreshHandler:null,getCurrentFiber:null,reconcilerVersion:"18.2.0-next-9e3b772b8-20220608"};if("undefined"!==typeof __REACT_DEVTOOLS_GLOBAL_HOOK__){var ac=__REACT_DEVTOOLS_GLOBAL_HOOK__;if(!ac.isDisabled&&ac.supportsFiber)try{at=ac.inject(rc),ot=ac}catch(ce){}}t.__SECRET_INTERNALS_DO_NOT_USE_OR_YOU_WILL_BE_FIRED=tc,t.createPortal=function(e,t){var n=2<arguments.length&&void 0!==arguments[2]?arguments[2]:null;if(!Xu(t))throw Error(o(200));return Uu(e,t,null,n)},t.createRoot=function(e,t){if(!Xu(e))throw Error(o(299));var n=!1,r="",a=Ku;return null!==t&&void 0!==t&&(!0===t.unstable_strictMode&&(n=!0),void 0!==t.identifierPrefix&&(r=t.identifierPrefix),void 0!==t.onRecoverableError&&(a=t.onRecoverableError)),t=Au(e,1,!1,null,0,n,0,r,a),e[ha]=t.current,Hr(8===e.nodeType?e.parentNode:e),new Yu(t)},t.findDOMNode=function(e){if(null==e)return null;if(1===e.nodeType)return e;var t=e._reactInternals;if(void 0===t){if("function"===typeof e.render)throw Error(o(188));throw e=Object.keys(e).join(","),Error(o(268,e))}return e=null===(e=We(t))?null:e.stateNode},t.flushSync=function(e){return cu(e)},t.hydrate=function(e,t,n){if(!Ju(t))throw Error(o(200));return ec(null,e,t,!0,n)},t.hydrateRoot=function(e,t,n){if(!Xu(e))throw Error(o(405));var r=null!=n&&n.hydratedSources||null,a=!1,l="",i=Ku;if(null!==n&&void 0!==n&&(!0===n.unstable_strictMode&&(a=!0),void 0!==n.identifierPrefix&&(l=n.identifierPrefix),void 0!==n.onRecoverableError&&(i=n.onRecoverableError)),t=qu(t,null,e,1,null!=n?n:null,a,0,l,i),e[ha]=t.current,Hr(e),r)for(e=0;e<r.length;e++)a=(a=(n=r[e])._getVersion)(n._source),null==t.mutableSourceEagerHydrationData?t.mutableSourceEagerHydrationData=[n,a]:t.mutableSourceEagerHydrationData.push(n,a);return new Gu(t)},t.render=function(e,t,n){if(!Ju(t))throw Error(o(200));return ec(null,e,t,!1,n)},t.unmountComponentAtNode=function(e){if(!Ju(e))throw Error(o(40));return!!e._reactRootContainer&&(cu((function(){ec(null,null,e,!1,(function(){e._reactRootContainer=null,e[ha]=null}))})),!0)},t.unstable_batchedUpdates=uu,t.unstable_renderSubtreeIntoContainer=function(e,t,n,r){if(!Ju(n))throw Error(o(200));if(null==e||void 0===e._reactInternals)throw Error(o(38));return ec(e,t,n,!1,r)},t.version="18.2.0-next-9e3b772b8-20220608"},250:function(e,t,n){"use strict";var r=n(164);t.s=r.createRoot,r.hydrateRoot},164:function(e,t,n){"use strict";!function e(){if("undefined"!==typeof __REACT_DEVTOOLS_GLOBAL_HOOK__&&"function"===typeof __REACT_DEVTOOLS_GLOBAL_HOOK__.checkDCE)try{__REACT_DEVTOOLS_GLOBAL_HOOK__.checkDCE(e)}catch(t){console.error(t)}}(),e.exports=n(463)},779:function(e,t,n){"use strict";var r=this&&this.__extends||function(){var e=function(t,n){return e=Object.setPrototypeOf||{__proto__:[]}instanceof Array&&function(e,t){e.__proto__=t}||function(e,t){for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n])},e(t,n)};return function(t,n){function r(){this.constructor=t}e(t,n),t.prototype=null===n?Object.create(n):(r.prototype=n.prototype,new r)}}(),a=this&&this.__assign||function(){return a=Object.assign||function(e){for(var t,n=1,r=arguments.length;n<r;n++)for(var a in t=arguments[n])Object.prototype.hasOwnProperty.call(t,a)&&(e[a]=t[a]);return e},a.apply(this,arguments)},o=this&&this.__rest||function(e,t){var n={};for(var r in e)Object.prototype.hasOwnProperty.call(e,r)&&t.indexOf(r)<0&&(n[r]=e[r]);if(null!=e&&"function"===typeof Object.getOwnPropertySymbols){var a=0;for(r=Object.getOwnPropertySymbols(e);a<r.length;a++)t.indexOf(r[a])<0&&(n[r[a]]=e[r[a]])}return n},l=this&&this.__importStar||function(e){if(e&&e.__esModule)return e;var t={};if(null!=e)for(var n in e)Object.hasOwnProperty.call(e,n)&&(t[n]=e[n]);return t.default=e,t},i=l(n(7)),s=l(n(791));function u(e,t,n){var r=function(e,t,n){var r=n[e+"Style"]||"",a=t&&t[e]||"";return r?r+";"+a:a}(e,t,n);return r?' style="'+r+'"':""}var c={'"':"&quot;","'":"&apos;","&":"&amp;",">":"&gt;","<":"&lt"};var d=function(e){function t(){return null!==e&&e.apply(this,arguments)||this}return r(t,e),t.prototype.render=function(){var e,t=this.props,n=t.json,r=t.data,l=t.replacer,i=t.space,d=t.themeClassName,f=t.theme,p=t.onJSONPrettyError,h=t.onError,v=t.silent,m=t.mainStyle,y=t.keyStyle,g=t.valueStyle,b=t.stringStyle,w=t.booleanStyle,x=t.errorStyle,k=o(t,["json","data","replacer","space","themeClassName","theme","onJSONPrettyError","onError","silent","mainStyle","keyStyle","valueStyle","stringStyle","booleanStyle","errorStyle"]),S={mainStyle:m,keyStyle:y,valueStyle:g,stringStyle:b,booleanStyle:w,errorStyle:x},E=r||n;if("string"===typeof E)try{E=JSON.parse(E)}catch(C){return v||console.warn("[react-json-pretty]: "+C.message),p&&p(C),!p&&h&&(h(C),console.warn("JSONPretty#onError is deprecated, please use JSONPretty#onJSONPrettyError instead")),s.createElement("div",a({},k,{dangerouslySetInnerHTML:{__html:'<pre class="__json-pretty-error__"'+u("error",f,S)+">"+(e=E,(e?e.replace(/<|>|&|"|'/g,(function(e){return c[e]})):e)+"</pre>")}}))}return s.createElement("div",a({},k,{dangerouslySetInnerHTML:{__html:'<pre class="'+d+'"'+u("main",f,S)+">"+this._pretty(f,E,l,+i,S)+"</pre>"}}))},t.prototype._pretty=function(e,t,n,r,a){var o=JSON.stringify(t,"function"===typeof n?n:null,isNaN(r)?2:r);return o?o.replace(/&/g,"&amp;").replace(/\\"([^,])/g,"\\&quot;$1").replace(/</g,"&lt;").replace(/>/g,"&gt;").replace(/^( *)("[^"]+": )?("[^"]*"|[\w.+-]*)?([,[{]|\[\s*\],?|\{\s*\},?)?$/gm,this._replace.bind(null,e,a)):o},t.prototype._replace=function(e,t,n,r,a,o,l){var i="</span>",s='<span class="__json-key__"'+u("key",e,t)+">",c='<span class="__json-value__"'+u("value",e,t)+">",d='<span class="__json-string__"'+u("string",e,t)+">",f='<span class="__json-boolean__"'+u("boolean",e,t)+">",p=r||"";return a&&(p=p+'"'+s+a.replace(/^"|":\s$/g,"")+i+'": '),o&&(p="true"===o||"false"===o?p+f+o+i:p+('"'===o[0]?d:c)+o+i),p+(l||"")},t.propTypes={data:i.any,json:i.any,replacer:i.func,silent:i.bool,space:i.oneOfType([i.number,i.string]),theme:i.object,themeClassName:i.string,onJSONPrettyError:i.func},t.defaultProps={data:"",json:"",silent:!0,space:2,themeClassName:"__json-pretty__"},t}(s.Component);e.exports=d},374:function(e,t,n){"use strict";var r=n(791),a=Symbol.for("react.element"),o=Symbol.for("react.fragment"),l=Object.prototype.hasOwnProperty,i=r.__SECRET_INTERNALS_DO_NOT_USE_OR_YOU_WILL_BE_FIRED.ReactCurrentOwner,s={key:!0,ref:!0,__self:!0,__source:!0};function u(e,t,n){var r,o={},u=null,c=null;for(r in void 0!==n&&(u=""+n),void 0!==t.key&&(u=""+t.key),void 0!==t.ref&&(c=t.ref),t)l.call(t,r)&&!s.hasOwnProperty(r)&&(o[r]=t[r]);if(e&&e.defaultProps)for(r in t=e.defaultProps)void 0===o[r]&&(o[r]=t[r]);return{$$typeof:a,type:e,key:u,ref:c,props:o,_owner:i.current}}t.Fragment=o,t.jsx=u,t.jsxs=u},117:function(e,t){"use strict";var n=Symbol.for("react.element"),r=Symbol.for("react.portal"),a=Symbol.for("react.fragment"),o=Symbol.for("react.strict_mode"),l=Symbol.for("react.profiler"),i=Symbol.for("react.provider"),s=Symbol.for("react.context"),u=Symbol.for("react.forward_ref"),c=Symbol.for("react.suspense"),d=Symbol.for("react.memo"),f=Symbol.for("react.lazy"),p=Symbol.iterator;var h={isMounted:function(){return!1},enqueueForceUpdate:function(){},enqueueReplaceState:function(){},enqueueSetState:function(){}},v=Object.assign,m={};function y(e,t,n){this.props=e,this.context=t,this.refs=m,this.updater=n||h}function g(){}function b(e,t,n){this.props=e,this.context=t,this.refs=m,this.updater=n||h}y.prototype.isReactComponent={},y.prototype.setState=function(e,t){if("object"!==typeof e&&"function"!==typeof e&&null!=e)throw Error("setState(...): takes an object of state variables to update or a function which returns an object of state variables.");this.updater.enqueueSetState(this,e,t,"setState")},y.prototype.forceUpdate=function(e){this.updater.enqueueForceUpdate(this,e,"forceUpdate")},g.prototype=y.prototype;var w=b.prototype=new g;w.constructor=b,v(w,y.prototype),w.isPureReactComponent=!0;var x=Array.isArray,k=Object.prototype.hasOwnProperty,S={current:null},E={key:!0,ref:!0,__self:!0,__source:!0};function C(e,t,r){var a,o={},l=null,i=null;if(null!=t)for(a in void 0!==t.ref&&(i=t.ref),void 0!==t.key&&(l=""+t.key),t)k.call(t,a)&&!E.hasOwnProperty(a)&&(o[a]=t[a]);var s=arguments.length-2;if(1===s)o.children=r;else if(1<s){for(var u=Array(s),c=0;c<s;c++)u[c]=arguments[c+2];o.children=u}if(e&&e.defaultProps)for(a in s=e.defaultProps)void 0===o[a]&&(o[a]=s[a]);return{$$typeof:n,type:e,key:l,ref:i,props:o,_owner:S.current}}function N(e){return"object"===typeof e&&null!==e&&e.$$typeof===n}var _=/\/+/g;function R(e,t){return"object"===typeof e&&null!==e&&null!=e.key?function(e){var t={"=":"=0",":":"=2"};return"$"+e.replace(/[=:]/g,(function(e){return t[e]}))}(""+e.key):t.toString(36)}function j(e,t,a,o,l){var i=typeof e;"undefined"!==i&&"boolean"!==i||(e=null);var s=!1;if(null===e)s=!0;else switch(i){case"string":case"number":s=!0;break;case"object":switch(e.$$typeof){case n:case r:s=!0}}if(s)return l=l(s=e),e=""===o?"."+R(s,0):o,x(l)?(a="",null!=e&&(a=e.replace(_,"$&/")+"/"),j(l,t,a,"",(function(e){return e}))):null!=l&&(N(l)&&(l=function(e,t){return{$$typeof:n,type:e.type,key:t,ref:e.ref,props:e.props,_owner:e._owner}}(l,a+(!l.key||s&&s.key===l.key?"":(""+l.key).replace(_,"$&/")+"/")+e)),t.push(l)),1;if(s=0,o=""===o?".":o+":",x(e))for(var u=0;u<e.length;u++){var c=o+R(i=e[u],u);s+=j(i,t,a,c,l)}else if(c=function(e){return null===e||"object"!==typeof e?null:"function"===typeof(e=p&&e[p]||e["@@iterator"])?e:null}(e),"function"===typeof c)for(e=c.call(e),u=0;!(i=e.next()).done;)s+=j(i=i.value,t,a,c=o+R(i,u++),l);else if("object"===i)throw t=String(e),Error("Objects are not valid as a React child (found: "+("[object Object]"===t?"object with keys {"+Object.keys(e).join(", ")+"}":t)+"). If you meant to render a collection of children, use an array instead.");return s}function P(e,t,n){if(null==e)return e;var r=[],a=0;return j(e,r,"","",(function(e){return t.call(n,e,a++)})),r}function T(e){if(-1===e._status){var t=e._result;(t=t()).then((function(t){0!==e._status&&-1!==e._status||(e._status=1,e._result=t)}),(function(t){0!==e._status&&-1!==e._status||(e._status=2,e._result=t)})),-1===e._status&&(e._status=0,e._result=t)}if(1===e._status)return e._result.default;throw e._result}var O={current:null},L={transition:null},z={ReactCurrentDispatcher:O,ReactCurrentBatchConfig:L,ReactCurrentOwner:S};t.Children={map:P,forEach:function(e,t,n){P(e,(function(){t.apply(this,arguments)}),n)},count:function(e){var t=0;return P(e,(function(){t++})),t},toArray:function(e){return P(e,(function(e){return e}))||[]},only:function(e){if(!N(e))throw Error("React.Children.only expected to receive a single React element child.");return e}},t.Component=y,t.Fragment=a,t.Profiler=l,t.PureComponent=b,t.StrictMode=o,t.Suspense=c,t.__SECRET_INTERNALS_DO_NOT_USE_OR_YOU_WILL_BE_FIRED=z,t.cloneElement=function(e,t,r){if(null===e||void 0===e)throw Error("React.cloneElement(...): The argument must be a React element, but you passed "+e+".");var a=v({},e.props),o=e.key,l=e.ref,i=e._owner;if(null!=t){if(void 0!==t.ref&&(l=t.ref,i=S.current),void 0!==t.key&&(o=""+t.key),e.type&&e.type.defaultProps)var s=e.type.defaultProps;for(u in t)k.call(t,u)&&!E.hasOwnProperty(u)&&(a[u]=void 0===t[u]&&void 0!==s?s[u]:t[u])}var u=arguments.length-2;if(1===u)a.children=r;else if(1<u){s=Array(u);for(var c=0;c<u;c++)s[c]=arguments[c+2];a.children=s}return{$$typeof:n,type:e.type,key:o,ref:l,props:a,_owner:i}},t.createContext=function(e){return(e={$$typeof:s,_currentValue:e,_currentValue2:e,_threadCount:0,Provider:null,Consumer:null,_defaultValue:null,_globalName:null}).Provider={$$typeof:i,_context:e},e.Consumer=e},t.createElement=C,t.createFactory=function(e){var t=C.bind(null,e);return t.type=e,t},t.createRef=function(){return{current:null}},t.forwardRef=function(e){return{$$typeof:u,render:e}},t.isValidElement=N,t.lazy=function(e){return{$$typeof:f,_payload:{_status:-1,_result:e},_init:T}},t.memo=function(e,t){return{$$typeof:d,type:e,compare:void 0===t?null:t}},t.startTransition=function(e){var t=L.transition;L.transition={};try{e()}finally{L.transition=t}},t.unstable_act=function(){throw Error("act(...) is not supported in production builds of React.")},t.useCallback=function(e,t){return O.current.useCallback(e,t)},t.useContext=function(e){return O.current.useContext(e)},t.useDebugValue=function(){},t.useDeferredValue=function(e){return O.current.useDeferredValue(e)},t.useEffect=function(e,t){return O.current.useEffect(e,t)},t.useId=function(){return O.current.useId()},t.useImperativeHandle=function(e,t,n){return O.current.useImperativeHandle(e,t,n)},t.useInsertionEffect=function(e,t){return O.current.useInsertionEffect(e,t)},t.useLayoutEffect=function(e,t){return O.current.useLayoutEffect(e,t)},t.useMemo=function(e,t){return O.current.useMemo(e,t)},t.useReducer=function(e,t,n){return O.current.useReducer(e,t,n)},t.useRef=function(e){return O.current.useRef(e)},t.useState=function(e){return O.current.useState(e)},t.useSyncExternalStore=function(e,t,n){return O.current.useSyncExternalStore(e,t,n)},t.useTransition=function(){return O.current.useTransition()},t.version="18.2.0"},791:function(e,t,n){"use strict";e.exports=n(117)},184:function(e,t,n){"use strict";e.exports=n(374)},813:function(e,t){"use strict";function n(e,t){var n=e.length;e.push(t);e:for(;0<n;){var r=n-1>>>1,a=e[r];if(!(0<o(a,t)))break e;e[r]=t,e[n]=a,n=r}}function r(e){return 0===e.length?null:e[0]}function a(e){if(0===e.length)return null;var t=e[0],n=e.pop();if(n!==t){e[0]=n;e:for(var r=0,a=e.length,l=a>>>1;r<l;){var i=2*(r+1)-1,s=e[i],u=i+1,c=e[u];if(0>o(s,n))u<a&&0>o(c,s)?(e[r]=c,e[u]=n,r=u):(e[r]=s,e[i]=n,r=i);else{if(!(u<a&&0>o(c,n)))break e;e[r]=c,e[u]=n,r=u}}}return t}function o(e,t){var n=e.sortIndex-t.sortIndex;return 0!==n?n:e.id-t.id}if("object"===typeof performance&&"function"===typeof performance.now){var l=performance;t.unstable_now=function(){return l.now()}}else{var i=Date,s=i.now();t.unstable_now=function(){return i.now()-s}}var u=[],c=[],d=1,f=null,p=3,h=!1,v=!1,m=!1,y="function"===typeof setTimeout?setTimeout:null,g="function"===typeof clearTimeout?clearTimeout:null,b="undefined"!==typeof setImmediate?setImmediate:null;function w(e){for(var t=r(c);null!==t;){if(null===t.callback)a(c);else{if(!(t.startTime<=e))break;a(c),t.sortIndex=t.expirationTime,n(u,t)}t=r(c)}}function x(e){if(m=!1,w(e),!v)if(null!==r(u))v=!0,L(k);else{var t=r(c);null!==t&&z(x,t.startTime-e)}}function k(e,n){v=!1,m&&(m=!1,g(N),N=-1),h=!0;var o=p;try{for(w(n),f=r(u);null!==f&&(!(f.expirationTime>n)||e&&!j());){var l=f.callback;if("function"===typeof l){f.callback=null,p=f.priorityLevel;var i=l(f.expirationTime<=n);n=t.unstable_now(),"function"===typeof i?f.callback=i:f===r(u)&&a(u),w(n)}else a(u);f=r(u)}if(null!==f)var s=!0;else{var d=r(c);null!==d&&z(x,d.startTime-n),s=!1}return s}finally{f=null,p=o,h=!1}}"undefined"!==typeof navigator&&void 0!==navigator.scheduling&&void 0!==navigator.scheduling.isInputPending&&navigator.scheduling.isInputPending.bind(navigator.scheduling);var S,E=!1,C=null,N=-1,_=5,R=-1;function j(){return!(t.unstable_now()-R<_)}function P(){if(null!==C){var e=t.unstable_now();R=e;var n=!0;try{n=C(!0,e)}finally{n?S():(E=!1,C=null)}}else E=!1}if("function"===typeof b)S=function(){b(P)};else if("undefined"!==typeof MessageChannel){var T=new MessageChannel,O=T.port2;T.port1.onmessage=P,S=function(){O.postMessage(null)}}else S=function(){y(P,0)};function L(e){C=e,E||(E=!0,S())}function z(e,n){N=y((function(){e(t.unstable_now())}),n)}t.unstable_IdlePriority=5,t.unstable_ImmediatePriority=1,t.unstable_LowPriority=4,t.unstable_NormalPriority=3,t.unstable_Profiling=null,t.unstable_UserBlockingPriority=2,t.unstable_cancelCallback=function(e){e.callback=null},t.unstable_continueExecution=function(){v||h||(v=!0,L(k))},t.unstable_forceFrameRate=function(e){0>e||125<e?console.error("forceFrameRate takes a positive int between 0 and 125, forcing frame rates higher than 125 fps is not supported"):_=0<e?Math.floor(1e3/e):5},t.unstable_getCurrentPriorityLevel=function(){return p},t.unstable_getFirstCallbackNode=function(){return r(u)},t.unstable_next=function(e){switch(p){case 1:case 2:case 3:var t=3;break;default:t=p}var n=p;p=t;try{return e()}finally{p=n}},t.unstable_pauseExecution=function(){},t.unstable_requestPaint=function(){},t.unstable_runWithPriority=function(e,t){switch(e){case 1:case 2:case 3:case 4:case 5:break;default:e=3}var n=p;p=e;try{return t()}finally{p=n}},t.unstable_scheduleCallback=function(e,a,o){var l=t.unstable_now();switch("object"===typeof o&&null!==o?o="number"===typeof(o=o.delay)&&0<o?l+o:l:o=l,e){case 1:var i=-1;break;case 2:i=250;break;case 5:i=1073741823;break;case 4:i=1e4;break;default:i=5e3}return e={id:d++,callback:a,priorityLevel:e,startTime:o,expirationTime:i=o+i,sortIndex:-1},o>l?(e.sortIndex=o,n(c,e),null===r(u)&&e===r(c)&&(m?(g(N),N=-1):m=!0,z(x,o-l))):(e.sortIndex=i,n(u,e),v||h||(v=!0,L(k))),e},t.unstable_shouldYield=j,t.unstable_wrapCallback=function(e){var t=p;return function(){var n=p;p=t;try{return e.apply(this,arguments)}finally{p=n}}}},296:function(e,t,n){"use strict";e.exports=n(813)},458:function(e){e.exports=function(){var e=document.getSelection();if(!e.rangeCount)return function(){};for(var t=document.activeElement,n=[],r=0;r<e.rangeCount;r++)n.push(e.getRangeAt(r));switch(t.tagName.toUpperCase()){case"INPUT":case"TEXTAREA":t.blur();break;default:t=null}return e.removeAllRanges(),function(){"Caret"===e.type&&e.removeAllRanges(),e.rangeCount||n.forEach((function(t){e.addRange(t)})),t&&t.focus()}}},391:function(e){"use strict";var t=function(){};e.exports=t}},t={};function n(r){var a=t[r];if(void 0!==a)return a.exports;var o=t[r]={exports:{}};return e[r].call(o.exports,o,o.exports,n),o.exports}n.m=e,n.n=function(e){var t=e&&e.__esModule?function(){return e.default}:function(){return e};return n.d(t,{a:t}),t},n.d=function(e,t){for(var r in t)n.o(t,r)&&!n.o(e,r)&&Object.defineProperty(e,r,{enumerable:!0,get:t[r]})},n.f={},n.e=function(e){return Promise.all(Object.keys(n.f).reduce((function(t,r){return n.f[r](e,t),t}),[]))},n.u=function(e){return"static/js/"+e+".aae294ae.chunk.js"},n.miniCssF=function(e){},n.g=function(){if("object"===typeof globalThis)return globalThis;try{return this||new Function("return this")()}catch(e){if("object"===typeof window)return window}}(),n.o=function(e,t){return Object.prototype.hasOwnProperty.call(e,t)},function(){var e={},t="mitmproxy-client:";n.l=function(r,a,o,l){if(e[r])e[r].push(a);else{var i,s;if(void 0!==o)for(var u=document.getElementsByTagName("script"),c=0;c<u.length;c++){var d=u[c];if(d.getAttribute("src")==r||d.getAttribute("data-webpack")==t+o){i=d;break}}i||(s=!0,(i=document.createElement("script")).charset="utf-8",i.timeout=120,n.nc&&i.setAttribute("nonce",n.nc),i.setAttribute("data-webpack",t+o),i.src=r),e[r]=[a];var f=function(t,n){i.onerror=i.onload=null,clearTimeout(p);var a=e[r];if(delete e[r],i.parentNode&&i.parentNode.removeChild(i),a&&a.forEach((function(e){return e(n)})),t)return t(n)},p=setTimeout(f.bind(null,void 0,{type:"timeout",target:i}),12e4);i.onerror=f.bind(null,i.onerror),i.onload=f.bind(null,i.onload),s&&document.head.appendChild(i)}}}(),n.r=function(e){"undefined"!==typeof Symbol&&Symbol.toStringTag&&Object.defineProperty(e,Symbol.toStringTag,{value:"Module"}),Object.defineProperty(e,"__esModule",{value:!0})},n.p="/",function(){var e={179:0};n.f.j=function(t,r){var a=n.o(e,t)?e[t]:void 0;if(0!==a)if(a)r.push(a[2]);else{var o=new Promise((function(n,r){a=e[t]=[n,r]}));r.push(a[2]=o);var l=n.p+n.u(t),i=new Error;n.l(l,(function(r){if(n.o(e,t)&&(0!==(a=e[t])&&(e[t]=void 0),a)){var o=r&&("load"===r.type?"missing":r.type),l=r&&r.target&&r.target.src;i.message="Loading chunk "+t+" failed.\n("+o+": "+l+")",i.name="ChunkLoadError",i.type=o,i.request=l,a[1](i)}}),"chunk-"+t,t)}};var t=function(t,r){var a,o,l=r[0],i=r[1],s=r[2],u=0;if(l.some((function(t){return 0!==e[t]}))){for(a in i)n.o(i,a)&&(n.m[a]=i[a]);if(s)s(n)}for(t&&t(r);u<l.length;u++)o=l[u],n.o(e,o)&&e[o]&&e[o][0](),e[o]=0},r=self.webpackChunkmitmproxy_client=self.webpackChunkmitmproxy_client||[];r.forEach(t.bind(null,0)),r.push=t.bind(null,r.push.bind(r))}(),function(){"use strict";var e=n(791),t=n(250);function r(e,t){if(!(e instanceof t))throw new TypeError("Cannot call a class as a function")}function a(e){return a="function"==typeof Symbol&&"symbol"==typeof Symbol.iterator?function(e){return typeof e}:function(e){return e&&"function"==typeof Symbol&&e.constructor===Symbol&&e!==Symbol.prototype?"symbol":typeof e},a(e)}function o(e){var t=function(e,t){if("object"!==a(e)||null===e)return e;var n=e[Symbol.toPrimitive];if(void 0!==n){var r=n.call(e,t||"default");if("object"!==a(r))return r;throw new TypeError("@@toPrimitive must return a primitive value.")}return("string"===t?String:Number)(e)}(e,"string");return"symbol"===a(t)?t:String(t)}function l(e,t){for(var n=0;n<t.length;n++){var r=t[n];r.enumerable=r.enumerable||!1,r.configurable=!0,"value"in r&&(r.writable=!0),Object.defineProperty(e,o(r.key),r)}}function i(e,t,n){return t&&l(e.prototype,t),n&&l(e,n),Object.defineProperty(e,"prototype",{writable:!1}),e}function s(e,t){return s=Object.setPrototypeOf?Object.setPrototypeOf.bind():function(e,t){return e.__proto__=t,e},s(e,t)}function u(e,t){if("function"!==typeof t&&null!==t)throw new TypeError("Super expression must either be null or a function");e.prototype=Object.create(t&&t.prototype,{constructor:{value:e,writable:!0,configurable:!0}}),Object.defineProperty(e,"prototype",{writable:!1}),t&&s(e,t)}function c(e){return c=Object.setPrototypeOf?Object.getPrototypeOf.bind():function(e){return e.__proto__||Object.getPrototypeOf(e)},c(e)}function d(e){if(void 0===e)throw new ReferenceError("this hasn't been initialised - super() hasn't been called");return e}function f(e,t){if(t&&("object"===a(t)||"function"===typeof t))return t;if(void 0!==t)throw new TypeError("Derived constructors may only return object or undefined");return d(e)}function p(e){var t=function(){if("undefined"===typeof Reflect||!Reflect.construct)return!1;if(Reflect.construct.sham)return!1;if("function"===typeof Proxy)return!0;try{return Boolean.prototype.valueOf.call(Reflect.construct(Boolean,[],(function(){}))),!0}catch(e){return!1}}();return function(){var n,r=c(e);if(t){var a=c(this).constructor;n=Reflect.construct(r,arguments,a)}else n=r.apply(this,arguments);return f(this,n)}}function h(e,t,n){return(t=o(t))in e?Object.defineProperty(e,t,{value:n,enumerable:!0,configurable:!0,writable:!0}):e[t]=n,e}function v(e,t){var n=Object.keys(e);if(Object.getOwnPropertySymbols){var r=Object.getOwnPropertySymbols(e);t&&(r=r.filter((function(t){return Object.getOwnPropertyDescriptor(e,t).enumerable}))),n.push.apply(n,r)}return n}function m(e){for(var t=1;t<arguments.length;t++){var n=null!=arguments[t]?arguments[t]:{};t%2?v(Object(n),!0).forEach((function(t){h(e,t,n[t])})):Object.getOwnPropertyDescriptors?Object.defineProperties(e,Object.getOwnPropertyDescriptors(n)):v(Object(n)).forEach((function(t){Object.defineProperty(e,t,Object.getOwnPropertyDescriptor(n,t))}))}return e}function y(e,t){if(null==e)return{};var n,r,a={},o=Object.keys(e);for(r=0;r<o.length;r++)n=o[r],t.indexOf(n)>=0||(a[n]=e[n]);return a}function g(e,t){if(null==e)return{};var n,r,a=y(e,t);if(Object.getOwnPropertySymbols){var o=Object.getOwnPropertySymbols(e);for(r=0;r<o.length;r++)n=o[r],t.indexOf(n)>=0||Object.prototype.propertyIsEnumerable.call(e,n)&&(a[n]=e[n])}return a}var b=n(694),w=n.n(b),x=n(184),k=["xxl","xl","lg","md","sm","xs"],S=e.createContext({prefixes:{},breakpoints:k,minBreakpoint:"xs"});S.Consumer,S.Provider;function E(t,n){var r=(0,e.useContext)(S).prefixes;return t||r[n]||n}function C(){return(0,e.useContext)(S).breakpoints}function N(){return(0,e.useContext)(S).minBreakpoint}var _=["bsPrefix","className","striped","bordered","borderless","hover","size","variant","responsive"],R=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.className,a=e.striped,o=e.bordered,l=e.borderless,i=e.hover,s=e.size,u=e.variant,c=e.responsive,d=g(e,_),f=E(n,"table"),p=w()(r,f,u&&"".concat(f,"-").concat(u),s&&"".concat(f,"-").concat(s),a&&"".concat(f,"-").concat("string"===typeof a?"striped-".concat(a):"striped"),o&&"".concat(f,"-bordered"),l&&"".concat(f,"-borderless"),i&&"".concat(f,"-hover")),h=(0,x.jsx)("table",m(m({},d),{},{className:p,ref:t}));if(c){var v="".concat(f,"-responsive");return"string"===typeof c&&(v="".concat(v,"-").concat(c)),(0,x.jsx)("div",{className:v,children:h})}return h})),j=R,P=n(7),T=n.n(P),O=["as","className","type","tooltip"],L={type:T().string,tooltip:T().bool,as:T().elementType},z=e.forwardRef((function(e,t){var n=e.as,r=void 0===n?"div":n,a=e.className,o=e.type,l=void 0===o?"valid":o,i=e.tooltip,s=void 0!==i&&i,u=g(e,O);return(0,x.jsx)(r,m(m({},u),{},{ref:t,className:w()(a,"".concat(l,"-").concat(s?"tooltip":"feedback"))}))}));z.displayName="Feedback",z.propTypes=L;var D=z,F=e.createContext({}),M=["id","bsPrefix","className","type","isValid","isInvalid","as"],I=e.forwardRef((function(t,n){var r=t.id,a=t.bsPrefix,o=t.className,l=t.type,i=void 0===l?"checkbox":l,s=t.isValid,u=void 0!==s&&s,c=t.isInvalid,d=void 0!==c&&c,f=t.as,p=void 0===f?"input":f,h=g(t,M),v=(0,e.useContext)(F).controlId;return a=E(a,"form-check-input"),(0,x.jsx)(p,m(m({},h),{},{ref:n,type:i,id:r||v,className:w()(o,a,u&&"is-valid",d&&"is-invalid")}))}));I.displayName="FormCheckInput";var B=I,A=["bsPrefix","className","htmlFor"],U=e.forwardRef((function(t,n){var r=t.bsPrefix,a=t.className,o=t.htmlFor,l=g(t,A),i=(0,e.useContext)(F).controlId;return r=E(r,"form-check-label"),(0,x.jsx)("label",m(m({},l),{},{ref:n,htmlFor:o||i,className:w()(a,r)}))}));U.displayName="FormCheckLabel";var H=U;var q=["id","bsPrefix","bsSwitchPrefix","inline","reverse","disabled","isValid","isInvalid","feedbackTooltip","feedback","feedbackType","className","style","title","type","label","children","as"],V=e.forwardRef((function(t,n){var r=t.id,a=t.bsPrefix,o=t.bsSwitchPrefix,l=t.inline,i=void 0!==l&&l,s=t.reverse,u=void 0!==s&&s,c=t.disabled,d=void 0!==c&&c,f=t.isValid,p=void 0!==f&&f,h=t.isInvalid,v=void 0!==h&&h,y=t.feedbackTooltip,b=void 0!==y&&y,k=t.feedback,S=t.feedbackType,C=t.className,N=t.style,_=t.title,R=void 0===_?"":_,j=t.type,P=void 0===j?"checkbox":j,T=t.label,O=t.children,L=t.as,z=void 0===L?"input":L,M=g(t,q);a=E(a,"form-check"),o=E(o,"form-switch");var I=(0,e.useContext)(F).controlId,A=(0,e.useMemo)((function(){return{controlId:r||I}}),[I,r]),U=!O&&null!=T&&!1!==T||function(t,n){return e.Children.toArray(t).some((function(t){return e.isValidElement(t)&&t.type===n}))}(O,H),V=(0,x.jsx)(B,m(m({},M),{},{type:"switch"===P?"checkbox":P,ref:n,isValid:p,isInvalid:v,disabled:d,as:z}));return(0,x.jsx)(F.Provider,{value:A,children:(0,x.jsx)("div",{style:N,className:w()(C,U&&a,i&&"".concat(a,"-inline"),u&&"".concat(a,"-reverse"),"switch"===P&&o),children:O||(0,x.jsxs)(x.Fragment,{children:[V,U&&(0,x.jsx)(H,{title:R,children:T}),k&&(0,x.jsx)(D,{type:S,tooltip:b,children:k})]})})})}));V.displayName="FormCheck";var W=Object.assign(V,{Input:B,Label:H}),$=(n(391),["bsPrefix","type","size","htmlSize","id","className","isValid","isInvalid","plaintext","readOnly","as"]),Q=e.forwardRef((function(t,n){var r,a,o=t.bsPrefix,l=t.type,i=t.size,s=t.htmlSize,u=t.id,c=t.className,d=t.isValid,f=void 0!==d&&d,p=t.isInvalid,v=void 0!==p&&p,y=t.plaintext,b=t.readOnly,k=t.as,S=void 0===k?"input":k,C=g(t,$),N=(0,e.useContext)(F).controlId;(o=E(o,"form-control"),y)?r=h({},"".concat(o,"-plaintext"),!0):(h(a={},o,!0),h(a,"".concat(o,"-").concat(i),i),r=a);return(0,x.jsx)(S,m(m({},C),{},{type:l,size:s,ref:n,readOnly:b,id:u||N,className:w()(c,r,f&&"is-valid",v&&"is-invalid","color"===l&&"".concat(o,"-color"))}))}));Q.displayName="FormControl";var K=Object.assign(Q,{Feedback:D}),Y=/-(.)/g;var G=["className","bsPrefix","as"],X=function(e){return e[0].toUpperCase()+(t=e,t.replace(Y,(function(e,t){return t.toUpperCase()}))).slice(1);var t};function J(t){var n=arguments.length>1&&void 0!==arguments[1]?arguments[1]:{},r=n.displayName,a=void 0===r?X(t):r,o=n.Component,l=n.defaultProps,i=e.forwardRef((function(e,n){var r=e.className,a=e.bsPrefix,l=e.as,i=void 0===l?o||"div":l,s=g(e,G),u=E(a,t);return(0,x.jsx)(i,m({ref:n,className:w()(r,u)},s))}));return i.defaultProps=l,i.displayName=a,i}var Z=J("form-floating"),ee=["controlId","as"],te=e.forwardRef((function(t,n){var r=t.controlId,a=t.as,o=void 0===a?"div":a,l=g(t,ee),i=(0,e.useMemo)((function(){return{controlId:r}}),[r]);return(0,x.jsx)(F.Provider,{value:i,children:(0,x.jsx)(o,m(m({},l),{},{ref:n}))})}));te.displayName="FormGroup";var ne=te;function re(e,t){(null==t||t>e.length)&&(t=e.length);for(var n=0,r=new Array(t);n<t;n++)r[n]=e[n];return r}function ae(e,t){if(e){if("string"===typeof e)return re(e,t);var n=Object.prototype.toString.call(e).slice(8,-1);return"Object"===n&&e.constructor&&(n=e.constructor.name),"Map"===n||"Set"===n?Array.from(e):"Arguments"===n||/^(?:Ui|I)nt(?:8|16|32)(?:Clamped)?Array$/.test(n)?re(e,t):void 0}}function oe(e,t){return function(e){if(Array.isArray(e))return e}(e)||function(e,t){var n=null==e?null:"undefined"!=typeof Symbol&&e[Symbol.iterator]||e["@@iterator"];if(null!=n){var r,a,o,l,i=[],s=!0,u=!1;try{if(o=(n=n.call(e)).next,0===t){if(Object(n)!==n)return;s=!1}else for(;!(s=(r=o.call(n)).done)&&(i.push(r.value),i.length!==t);s=!0);}catch(c){u=!0,a=c}finally{try{if(!s&&null!=n.return&&(l=n.return(),Object(l)!==l))return}finally{if(u)throw a}}return i}}(e,t)||ae(e,t)||function(){throw new TypeError("Invalid attempt to destructure non-iterable instance.\nIn order to be iterable, non-array objects must have a [Symbol.iterator]() method.")}()}var le=["as","bsPrefix","className"],ie=["className"];var se=e.forwardRef((function(e,t){var n=function(e){var t=e.as,n=e.bsPrefix,r=e.className,a=g(e,le);n=E(n,"col");var o=C(),l=N(),i=[],s=[];return o.forEach((function(e){var t,r,o,u=a[e];delete a[e],"object"===typeof u&&null!=u?(t=u.span,r=u.offset,o=u.order):t=u;var c=e!==l?"-".concat(e):"";t&&i.push(!0===t?"".concat(n).concat(c):"".concat(n).concat(c,"-").concat(t)),null!=o&&s.push("order".concat(c,"-").concat(o)),null!=r&&s.push("offset".concat(c,"-").concat(r))})),[m(m({},a),{},{className:w().apply(void 0,[r].concat(i,s))}),{as:t,bsPrefix:n,spans:i}]}(e),r=oe(n,2),a=r[0],o=a.className,l=g(a,ie),i=r[1],s=i.as,u=void 0===s?"div":s,c=i.bsPrefix,d=i.spans;return(0,x.jsx)(u,m(m({},l),{},{ref:t,className:w()(o,!d.length&&c)}))}));se.displayName="Col";var ue=se,ce=["as","bsPrefix","column","visuallyHidden","className","htmlFor"],de=e.forwardRef((function(t,n){var r=t.as,a=void 0===r?"label":r,o=t.bsPrefix,l=t.column,i=t.visuallyHidden,s=t.className,u=t.htmlFor,c=g(t,ce),d=(0,e.useContext)(F).controlId;o=E(o,"form-label");var f="col-form-label";"string"===typeof l&&(f="".concat(f," ").concat(f,"-").concat(l));var p=w()(s,o,i&&"visually-hidden",l&&f);return u=u||d,l?(0,x.jsx)(ue,m({ref:n,as:"label",className:p,htmlFor:u},c)):(0,x.jsx)(a,m({ref:n,className:p,htmlFor:u},c))}));de.displayName="FormLabel",de.defaultProps={column:!1,visuallyHidden:!1};var fe=de,pe=["bsPrefix","className","id"],he=e.forwardRef((function(t,n){var r=t.bsPrefix,a=t.className,o=t.id,l=g(t,pe),i=(0,e.useContext)(F).controlId;return r=E(r,"form-range"),(0,x.jsx)("input",m(m({},l),{},{type:"range",ref:n,className:w()(a,r),id:o||i}))}));he.displayName="FormRange";var ve=he,me=["bsPrefix","size","htmlSize","className","isValid","isInvalid","id"],ye=e.forwardRef((function(t,n){var r=t.bsPrefix,a=t.size,o=t.htmlSize,l=t.className,i=t.isValid,s=void 0!==i&&i,u=t.isInvalid,c=void 0!==u&&u,d=t.id,f=g(t,me),p=(0,e.useContext)(F).controlId;return r=E(r,"form-select"),(0,x.jsx)("select",m(m({},f),{},{size:o,ref:n,className:w()(l,r,a&&"".concat(r,"-").concat(a),s&&"is-valid",c&&"is-invalid"),id:d||p}))}));ye.displayName="FormSelect";var ge=ye,be=["bsPrefix","className","as","muted"],we=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.className,a=e.as,o=void 0===a?"small":a,l=e.muted,i=g(e,be);return n=E(n,"form-text"),(0,x.jsx)(o,m(m({},i),{},{ref:t,className:w()(r,n,l&&"text-muted")}))}));we.displayName="FormText";var xe=we,ke=e.forwardRef((function(e,t){return(0,x.jsx)(W,m(m({},e),{},{ref:t,type:"switch"}))}));ke.displayName="Switch";var Se=Object.assign(ke,{Input:W.Input,Label:W.Label}),Ee=["bsPrefix","className","children","controlId","label"],Ce=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.className,a=e.children,o=e.controlId,l=e.label,i=g(e,Ee);return n=E(n,"form-floating"),(0,x.jsxs)(ne,m(m({ref:t,className:w()(r,n),controlId:o},i),{},{children:[a,(0,x.jsx)("label",{htmlFor:o,children:l})]}))}));Ce.displayName="FloatingLabel";var Ne=Ce,_e=["className","validated","as"],Re={_ref:T().any,validated:T().bool,as:T().elementType},je=e.forwardRef((function(e,t){var n=e.className,r=e.validated,a=e.as,o=void 0===a?"form":a,l=g(e,_e);return(0,x.jsx)(o,m(m({},l),{},{ref:t,className:w()(n,r&&"was-validated")}))}));je.displayName="Form",je.propTypes=Re;var Pe=Object.assign(je,{Group:ne,Control:K,Floating:Z,Check:W,Switch:Se,Label:fe,Text:xe,Range:ve,Select:ge,FloatingLabel:Ne}),Te=["as","disabled"];function Oe(e){var t=e.tagName,n=e.disabled,r=e.href,a=e.target,o=e.rel,l=e.role,i=e.onClick,s=e.tabIndex,u=void 0===s?0:s,c=e.type;t||(t=null!=r||null!=a||null!=o?"a":"button");var d={tagName:t};if("button"===t)return[{type:c||"button",disabled:n},d];var f=function(e){(n||"a"===t&&function(e){return!e||"#"===e.trim()}(r))&&e.preventDefault(),n?e.stopPropagation():null==i||i(e)};return"a"===t&&(r||(r="#"),n&&(r=void 0)),[{role:null!=l?l:"button",disabled:void 0,tabIndex:n?void 0:u,href:r,target:"a"===t?a:void 0,"aria-disabled":n||void 0,rel:"a"===t?o:void 0,onClick:f,onKeyDown:function(e){" "===e.key&&(e.preventDefault(),f(e))}},d]}var Le=e.forwardRef((function(e,t){var n=e.as,r=e.disabled,a=function(e,t){if(null==e)return{};var n,r,a={},o=Object.keys(e);for(r=0;r<o.length;r++)n=o[r],t.indexOf(n)>=0||(a[n]=e[n]);return a}(e,Te),o=oe(Oe(Object.assign({tagName:n,disabled:r},a)),2),l=o[0],i=o[1].tagName;return(0,x.jsx)(i,Object.assign({},a,l,{ref:t}))}));Le.displayName="Button";var ze=["as","bsPrefix","variant","size","active","className"],De=e.forwardRef((function(e,t){var n=e.as,r=e.bsPrefix,a=e.variant,o=e.size,l=e.active,i=e.className,s=g(e,ze),u=E(r,"btn"),c=oe(Oe(m({tagName:n},s)),2),d=c[0],f=c[1].tagName;return(0,x.jsx)(f,m(m(m({},d),s),{},{ref:t,className:w()(i,u,l&&"active",a&&"".concat(u,"-").concat(a),o&&"".concat(u,"-").concat(o),s.href&&s.disabled&&"disabled")}))}));De.displayName="Button",De.defaultProps={variant:"primary",active:!1,disabled:!1};var Fe=De;var Me=n.p+"static/media/github-mark.6fa18895f6e6c7772cab7049f7e05f59.svg",Ie=["bsPrefix","bg","pill","text","className","as"],Be=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.bg,a=e.pill,o=e.text,l=e.className,i=e.as,s=void 0===i?"span":i,u=g(e,Ie),c=E(n,"badge");return(0,x.jsx)(s,m(m({ref:t},u),{},{className:w()(l,c,a&&"rounded-pill",o&&"text-".concat(o),r&&"bg-".concat(r))}))}));Be.displayName="Badge",Be.defaultProps={bg:"primary",pill:!1};var Ae=Be,Ue=!("undefined"===typeof window||!window.document||!window.document.createElement),He=!1,qe=!1;try{var Ve={get passive(){return He=!0},get once(){return qe=He=!0}};Ue&&(window.addEventListener("test",Ve,Ve),window.removeEventListener("test",Ve,!0))}catch(_r){}var We=function(e,t,n,r){if(r&&"boolean"!==typeof r&&!qe){var a=r.once,o=r.capture,l=n;!qe&&a&&(l=n.__once||function e(r){this.removeEventListener(t,e,o),n.call(this,r)},n.__once=l),e.addEventListener(t,l,He?r:o)}e.addEventListener(t,n,r)};function $e(e){return e&&e.ownerDocument||document}var Qe,Ke=function(e,t,n,r){var a=r&&"boolean"!==typeof r?r.capture:r;e.removeEventListener(t,n,a),n.__once&&e.removeEventListener(t,n.__once,a)};function Ye(e){if((!Qe&&0!==Qe||e)&&Ue){var t=document.createElement("div");t.style.position="absolute",t.style.top="-9999px",t.style.width="50px",t.style.height="50px",t.style.overflow="scroll",document.body.appendChild(t),Qe=t.offsetWidth-t.clientWidth,document.body.removeChild(t)}return Qe}var Ge=function(t){var n=(0,e.useRef)(t);return(0,e.useEffect)((function(){n.current=t}),[t]),n};function Xe(t){var n=Ge(t);return(0,e.useCallback)((function(){return n.current&&n.current.apply(n,arguments)}),[n])}var Je=function(e){return e&&"function"!==typeof e?function(t){e.current=t}:e};var Ze=function(t,n){return(0,e.useMemo)((function(){return function(e,t){var n=Je(e),r=Je(t);return function(e){n&&n(e),r&&r(e)}}(t,n)}),[t,n])};function et(t){var n=function(t){var n=(0,e.useRef)(t);return n.current=t,n}(t);(0,e.useEffect)((function(){return function(){return n.current()}}),[])}function tt(e,t){return function(e){var t=$e(e);return t&&t.defaultView||window}(e).getComputedStyle(e,t)}var nt=/([A-Z])/g;var rt=/^ms-/;function at(e){return function(e){return e.replace(nt,"-$1").toLowerCase()}(e).replace(rt,"-ms-")}var ot=/^((translate|rotate|scale)(X|Y|Z|3d)?|matrix(3d)?|perspective|skew(X|Y)?)$/i;var lt=function(e,t){var n="",r="";if("string"===typeof t)return e.style.getPropertyValue(at(t))||tt(e).getPropertyValue(at(t));Object.keys(t).forEach((function(a){var o=t[a];o||0===o?!function(e){return!(!e||!ot.test(e))}(a)?n+=at(a)+": "+o+";":r+=a+"("+o+") ":e.style.removeProperty(at(a))})),r&&(n+="transform: "+r+";"),e.style.cssText+=";"+n};var it=function(e,t,n,r){return We(e,t,n,r),function(){Ke(e,t,n,r)}};function st(e,t,n){void 0===n&&(n=5);var r=!1,a=setTimeout((function(){r||function(e,t,n,r){if(void 0===n&&(n=!1),void 0===r&&(r=!0),e){var a=document.createEvent("HTMLEvents");a.initEvent(t,n,r),e.dispatchEvent(a)}}(e,"transitionend",!0)}),t+n),o=it(e,"transitionend",(function(){r=!0}),{once:!0});return function(){clearTimeout(a),o()}}function ut(e,t,n,r){null==n&&(n=function(e){var t=lt(e,"transitionDuration")||"",n=-1===t.indexOf("ms")?1e3:1;return parseFloat(t)*n}(e)||0);var a=st(e,n,r),o=it(e,"transitionend",t);return function(){a(),o()}}function ct(e){void 0===e&&(e=$e());try{var t=e.activeElement;return t&&t.nodeName?t:null}catch(_r){return e.body}}function dt(e,t){return e.contains?e.contains(t):e.compareDocumentPosition?e===t||!!(16&e.compareDocumentPosition(t)):void 0}var ft=n(164);function pt(e){return function(e){if(Array.isArray(e))return re(e)}(e)||function(e){if("undefined"!==typeof Symbol&&null!=e[Symbol.iterator]||null!=e["@@iterator"])return Array.from(e)}(e)||ae(e)||function(){throw new TypeError("Invalid attempt to spread non-iterable instance.\nIn order to be iterable, non-array objects must have a [Symbol.iterator]() method.")}()}var ht,vt=(ht="modal-open","".concat("data-rr-ui-").concat(ht)),mt=function(){function e(){var t=arguments.length>0&&void 0!==arguments[0]?arguments[0]:{},n=t.ownerDocument,a=t.handleContainerOverflow,o=void 0===a||a,l=t.isRTL,i=void 0!==l&&l;r(this,e),this.handleContainerOverflow=o,this.isRTL=i,this.modals=[],this.ownerDocument=n}return i(e,[{key:"getScrollbarWidth",value:function(){return function(){var e=arguments.length>0&&void 0!==arguments[0]?arguments[0]:document,t=e.defaultView;return Math.abs(t.innerWidth-e.documentElement.clientWidth)}(this.ownerDocument)}},{key:"getElement",value:function(){return(this.ownerDocument||document).body}},{key:"setModalAttributes",value:function(e){}},{key:"removeModalAttributes",value:function(e){}},{key:"setContainerStyle",value:function(e){var t={overflow:"hidden"},n=this.isRTL?"paddingLeft":"paddingRight",r=this.getElement();e.style=h({overflow:r.style.overflow},n,r.style[n]),e.scrollBarWidth&&(t[n]="".concat(parseInt(lt(r,n)||"0",10)+e.scrollBarWidth,"px")),r.setAttribute(vt,""),lt(r,t)}},{key:"reset",value:function(){var e=this;pt(this.modals).forEach((function(t){return e.remove(t)}))}},{key:"removeContainerStyle",value:function(e){var t=this.getElement();t.removeAttribute(vt),Object.assign(t.style,e.style)}},{key:"add",value:function(e){var t=this.modals.indexOf(e);return-1!==t?t:(t=this.modals.length,this.modals.push(e),this.setModalAttributes(e),0!==t||(this.state={scrollBarWidth:this.getScrollbarWidth(),style:{}},this.handleContainerOverflow&&this.setContainerStyle(this.state)),t)}},{key:"remove",value:function(e){var t=this.modals.indexOf(e);-1!==t&&(this.modals.splice(t,1),!this.modals.length&&this.handleContainerOverflow&&this.removeContainerStyle(this.state),this.removeModalAttributes(e))}},{key:"isTopModal",value:function(e){return!!this.modals.length&&this.modals[this.modals.length-1]===e}}]),e}(),yt=mt,gt=(0,e.createContext)(Ue?window:void 0);gt.Provider;function bt(){return(0,e.useContext)(gt)}var wt=function(e,t){return Ue?null==e?(t||$e()).body:("function"===typeof e&&(e=e()),e&&"current"in e&&(e=e.current),e&&("nodeType"in e||e.getBoundingClientRect)?e:null):null};var xt,kt=["show","role","className","style","children","backdrop","keyboard","onBackdropClick","onEscapeKeyDown","transition","backdropTransition","autoFocus","enforceFocus","restoreFocus","restoreFocusOptions","renderDialog","renderBackdrop","manager","container","onShow","onHide","onExit","onExited","onExiting","onEnter","onEntering","onEntered"];function St(t){var n=bt(),r=t||function(e){return xt||(xt=new yt({ownerDocument:null==e?void 0:e.document})),xt}(n),a=(0,e.useRef)({dialog:null,backdrop:null});return Object.assign(a.current,{add:function(){return r.add(a.current)},remove:function(){return r.remove(a.current)},isTopModal:function(){return r.isTopModal(a.current)},setDialogRef:(0,e.useCallback)((function(e){a.current.dialog=e}),[]),setBackdropRef:(0,e.useCallback)((function(e){a.current.backdrop=e}),[])})}var Et=(0,e.forwardRef)((function(t,n){var r=t.show,a=void 0!==r&&r,o=t.role,l=void 0===o?"dialog":o,i=t.className,s=t.style,u=t.children,c=t.backdrop,d=void 0===c||c,f=t.keyboard,p=void 0===f||f,h=t.onBackdropClick,v=t.onEscapeKeyDown,m=t.transition,y=t.backdropTransition,g=t.autoFocus,b=void 0===g||g,w=t.enforceFocus,k=void 0===w||w,S=t.restoreFocus,E=void 0===S||S,C=t.restoreFocusOptions,N=t.renderDialog,_=t.renderBackdrop,R=void 0===_?function(e){return(0,x.jsx)("div",Object.assign({},e))}:_,j=t.manager,P=t.container,T=t.onShow,O=t.onHide,L=void 0===O?function(){}:O,z=t.onExit,D=t.onExited,F=t.onExiting,M=t.onEnter,I=t.onEntering,B=t.onEntered,A=function(e,t){if(null==e)return{};var n,r,a={},o=Object.keys(e);for(r=0;r<o.length;r++)n=o[r],t.indexOf(n)>=0||(a[n]=e[n]);return a}(t,kt),U=function(t,n){var r=bt(),a=oe((0,e.useState)((function(){return wt(t,null==r?void 0:r.document)})),2),o=a[0],l=a[1];if(!o){var i=wt(t);i&&l(i)}return(0,e.useEffect)((function(){n&&o&&n(o)}),[n,o]),(0,e.useEffect)((function(){var e=wt(t);e!==o&&l(e)}),[t,o]),o}(P),H=St(j),q=function(){var t=(0,e.useRef)(!0),n=(0,e.useRef)((function(){return t.current}));return(0,e.useEffect)((function(){return t.current=!0,function(){t.current=!1}}),[]),n.current}(),V=function(t){var n=(0,e.useRef)(null);return(0,e.useEffect)((function(){n.current=t})),n.current}(a),W=oe((0,e.useState)(!a),2),$=W[0],Q=W[1],K=(0,e.useRef)(null);(0,e.useImperativeHandle)(n,(function(){return H}),[H]),Ue&&!V&&a&&(K.current=ct()),m||a||$?a&&$&&Q(!1):Q(!0);var Y=Xe((function(){if(H.add(),te.current=it(document,"keydown",Z),ee.current=it(document,"focus",(function(){return setTimeout(X)}),!0),T&&T(),b){var e=ct(document);H.dialog&&e&&!dt(H.dialog,e)&&(K.current=e,H.dialog.focus())}})),G=Xe((function(){var e;(H.remove(),null==te.current||te.current(),null==ee.current||ee.current(),E)&&(null==(e=K.current)||null==e.focus||e.focus(C),K.current=null)}));(0,e.useEffect)((function(){a&&U&&Y()}),[a,U,Y]),(0,e.useEffect)((function(){$&&G()}),[$,G]),et((function(){G()}));var X=Xe((function(){if(k&&q()&&H.isTopModal()){var e=ct();H.dialog&&e&&!dt(H.dialog,e)&&H.dialog.focus()}})),J=Xe((function(e){e.target===e.currentTarget&&(null==h||h(e),!0===d&&L())})),Z=Xe((function(e){p&&27===e.keyCode&&H.isTopModal()&&(null==v||v(e),e.defaultPrevented||L())})),ee=(0,e.useRef)(),te=(0,e.useRef)(),ne=m;if(!U||!(a||ne&&!$))return null;var re=Object.assign({role:l,ref:H.setDialogRef,"aria-modal":"dialog"===l||void 0},A,{style:s,className:i,tabIndex:-1}),ae=N?N(re):(0,x.jsx)("div",Object.assign({},re,{children:e.cloneElement(u,{role:"document"})}));ne&&(ae=(0,x.jsx)(ne,{appear:!0,unmountOnExit:!0,in:!!a,onExit:z,onExiting:F,onExited:function(){Q(!0),null==D||D.apply(void 0,arguments)},onEnter:M,onEntering:I,onEntered:B,children:ae}));var le=null;if(d){var ie=y;le=R({ref:H.setBackdropRef,onClick:J}),ie&&(le=(0,x.jsx)(ie,{appear:!0,in:!!a,children:le}))}return(0,x.jsx)(x.Fragment,{children:ft.createPortal((0,x.jsxs)(x.Fragment,{children:[le,ae]}),U)})}));Et.displayName="Modal";var Ct=Object.assign(Et,{Manager:yt});function Nt(e,t){for(;!Object.prototype.hasOwnProperty.call(e,t)&&null!==(e=c(e)););return e}function _t(){return _t="undefined"!==typeof Reflect&&Reflect.get?Reflect.get.bind():function(e,t,n){var r=Nt(e,t);if(r){var a=Object.getOwnPropertyDescriptor(r,t);return a.get?a.get.call(arguments.length<3?e:n):a.value}},_t.apply(this,arguments)}var Rt=Function.prototype.bind.call(Function.prototype.call,[].slice);function jt(e,t){return Rt(e.querySelectorAll(t))}function Pt(e,t){return e.replace(new RegExp("(^|\\s)"+t+"(?:\\s|$)","g"),"$1").replace(/\s+/g," ").replace(/^\s*|\s*$/g,"")}var Tt,Ot=".fixed-top, .fixed-bottom, .is-fixed, .sticky-top",Lt=".sticky-top",zt=".navbar-toggler",Dt=function(e){u(n,e);var t=p(n);function n(){return r(this,n),t.apply(this,arguments)}return i(n,[{key:"adjustAndStore",value:function(e,t,n){var r=t.style[e];t.dataset[e]=r,lt(t,h({},e,"".concat(parseFloat(lt(t,e))+n,"px")))}},{key:"restore",value:function(e,t){var n=t.dataset[e];void 0!==n&&(delete t.dataset[e],lt(t,h({},e,n)))}},{key:"setContainerStyle",value:function(e){var t=this;_t(c(n.prototype),"setContainerStyle",this).call(this,e);var r,a,o=this.getElement();if(a="modal-open",(r=o).classList?r.classList.add(a):function(e,t){return e.classList?!!t&&e.classList.contains(t):-1!==(" "+(e.className.baseVal||e.className)+" ").indexOf(" "+t+" ")}(r,a)||("string"===typeof r.className?r.className=r.className+" "+a:r.setAttribute("class",(r.className&&r.className.baseVal||"")+" "+a)),e.scrollBarWidth){var l=this.isRTL?"paddingLeft":"paddingRight",i=this.isRTL?"marginLeft":"marginRight";jt(o,Ot).forEach((function(n){return t.adjustAndStore(l,n,e.scrollBarWidth)})),jt(o,Lt).forEach((function(n){return t.adjustAndStore(i,n,-e.scrollBarWidth)})),jt(o,zt).forEach((function(n){return t.adjustAndStore(i,n,e.scrollBarWidth)}))}}},{key:"removeContainerStyle",value:function(e){var t=this;_t(c(n.prototype),"removeContainerStyle",this).call(this,e);var r,a,o=this.getElement();a="modal-open",(r=o).classList?r.classList.remove(a):"string"===typeof r.className?r.className=Pt(r.className,a):r.setAttribute("class",Pt(r.className&&r.className.baseVal||"",a));var l=this.isRTL?"paddingLeft":"paddingRight",i=this.isRTL?"marginLeft":"marginRight";jt(o,Ot).forEach((function(e){return t.restore(l,e)})),jt(o,Lt).forEach((function(e){return t.restore(i,e)})),jt(o,zt).forEach((function(e){return t.restore(i,e)}))}}]),n}(yt);var Ft=!1,Mt=e.createContext(null),It="unmounted",Bt="exited",At="entering",Ut="entered",Ht="exiting",qt=function(t){var n,r;function a(e,n){var r;r=t.call(this,e,n)||this;var a,o=n&&!n.isMounting?e.enter:e.appear;return r.appearStatus=null,e.in?o?(a=Bt,r.appearStatus=At):a=Ut:a=e.unmountOnExit||e.mountOnEnter?It:Bt,r.state={status:a},r.nextCallback=null,r}r=t,(n=a).prototype=Object.create(r.prototype),n.prototype.constructor=n,s(n,r),a.getDerivedStateFromProps=function(e,t){return e.in&&t.status===It?{status:Bt}:null};var o=a.prototype;return o.componentDidMount=function(){this.updateStatus(!0,this.appearStatus)},o.componentDidUpdate=function(e){var t=null;if(e!==this.props){var n=this.state.status;this.props.in?n!==At&&n!==Ut&&(t=At):n!==At&&n!==Ut||(t=Ht)}this.updateStatus(!1,t)},o.componentWillUnmount=function(){this.cancelNextCallback()},o.getTimeouts=function(){var e,t,n,r=this.props.timeout;return e=t=n=r,null!=r&&"number"!==typeof r&&(e=r.exit,t=r.enter,n=void 0!==r.appear?r.appear:t),{exit:e,enter:t,appear:n}},o.updateStatus=function(e,t){if(void 0===e&&(e=!1),null!==t)if(this.cancelNextCallback(),t===At){if(this.props.unmountOnExit||this.props.mountOnEnter){var n=this.props.nodeRef?this.props.nodeRef.current:ft.findDOMNode(this);n&&function(e){e.scrollTop}(n)}this.performEnter(e)}else this.performExit();else this.props.unmountOnExit&&this.state.status===Bt&&this.setState({status:It})},o.performEnter=function(e){var t=this,n=this.props.enter,r=this.context?this.context.isMounting:e,a=this.props.nodeRef?[r]:[ft.findDOMNode(this),r],o=a[0],l=a[1],i=this.getTimeouts(),s=r?i.appear:i.enter;!e&&!n||Ft?this.safeSetState({status:Ut},(function(){t.props.onEntered(o)})):(this.props.onEnter(o,l),this.safeSetState({status:At},(function(){t.props.onEntering(o,l),t.onTransitionEnd(s,(function(){t.safeSetState({status:Ut},(function(){t.props.onEntered(o,l)}))}))})))},o.performExit=function(){var e=this,t=this.props.exit,n=this.getTimeouts(),r=this.props.nodeRef?void 0:ft.findDOMNode(this);t&&!Ft?(this.props.onExit(r),this.safeSetState({status:Ht},(function(){e.props.onExiting(r),e.onTransitionEnd(n.exit,(function(){e.safeSetState({status:Bt},(function(){e.props.onExited(r)}))}))}))):this.safeSetState({status:Bt},(function(){e.props.onExited(r)}))},o.cancelNextCallback=function(){null!==this.nextCallback&&(this.nextCallback.cancel(),this.nextCallback=null)},o.safeSetState=function(e,t){t=this.setNextCallback(t),this.setState(e,t)},o.setNextCallback=function(e){var t=this,n=!0;return this.nextCallback=function(r){n&&(n=!1,t.nextCallback=null,e(r))},this.nextCallback.cancel=function(){n=!1},this.nextCallback},o.onTransitionEnd=function(e,t){this.setNextCallback(t);var n=this.props.nodeRef?this.props.nodeRef.current:ft.findDOMNode(this),r=null==e&&!this.props.addEndListener;if(n&&!r){if(this.props.addEndListener){var a=this.props.nodeRef?[this.nextCallback]:[n,this.nextCallback],o=a[0],l=a[1];this.props.addEndListener(o,l)}null!=e&&setTimeout(this.nextCallback,e)}else setTimeout(this.nextCallback,0)},o.render=function(){var t=this.state.status;if(t===It)return null;var n=this.props,r=n.children,a=(n.in,n.mountOnEnter,n.unmountOnExit,n.appear,n.enter,n.exit,n.timeout,n.addEndListener,n.onEnter,n.onEntering,n.onEntered,n.onExit,n.onExiting,n.onExited,n.nodeRef,y(n,["children","in","mountOnEnter","unmountOnExit","appear","enter","exit","timeout","addEndListener","onEnter","onEntering","onEntered","onExit","onExiting","onExited","nodeRef"]));return e.createElement(Mt.Provider,{value:null},"function"===typeof r?r(t,a):e.cloneElement(e.Children.only(r),a))},a}(e.Component);function Vt(){}qt.contextType=Mt,qt.propTypes={},qt.defaultProps={in:!1,mountOnEnter:!1,unmountOnExit:!1,appear:!1,enter:!0,exit:!0,onEnter:Vt,onEntering:Vt,onEntered:Vt,onExit:Vt,onExiting:Vt,onExited:Vt},qt.UNMOUNTED=It,qt.EXITED=Bt,qt.ENTERING=At,qt.ENTERED=Ut,qt.EXITING=Ht;var Wt=qt;function $t(e,t){var n=lt(e,t)||"",r=-1===n.indexOf("ms")?1e3:1;return parseFloat(n)*r}function Qt(e,t){var n=$t(e,"transitionDuration"),r=$t(e,"transitionDelay"),a=ut(e,(function(n){n.target===e&&(a(),t(n))}),n+r)}var Kt,Yt=["onEnter","onEntering","onEntered","onExit","onExiting","onExited","addEndListener","children","childRef"],Gt=e.forwardRef((function(t,n){var r=t.onEnter,a=t.onEntering,o=t.onEntered,l=t.onExit,i=t.onExiting,s=t.onExited,u=t.addEndListener,c=t.children,d=t.childRef,f=g(t,Yt),p=(0,e.useRef)(null),h=Ze(p,d),v=function(e){var t;h((t=e)&&"setState"in t?ft.findDOMNode(t):null!=t?t:null)},y=function(e){return function(t){e&&p.current&&e(p.current,t)}},b=(0,e.useCallback)(y(r),[r]),w=(0,e.useCallback)(y(a),[a]),k=(0,e.useCallback)(y(o),[o]),S=(0,e.useCallback)(y(l),[l]),E=(0,e.useCallback)(y(i),[i]),C=(0,e.useCallback)(y(s),[s]),N=(0,e.useCallback)(y(u),[u]);return(0,x.jsx)(Wt,m(m({ref:n},f),{},{onEnter:b,onEntered:k,onEntering:w,onExit:S,onExited:C,onExiting:E,addEndListener:N,nodeRef:p,children:"function"===typeof c?function(e,t){return c(e,m(m({},t),{},{ref:v}))}:e.cloneElement(c,{ref:v})}))})),Xt=["className","children","transitionClasses"],Jt=(h(Kt={},At,"show"),h(Kt,Ut,"show"),Kt),Zt=e.forwardRef((function(t,n){var r=t.className,a=t.children,o=t.transitionClasses,l=void 0===o?{}:o,i=g(t,Xt),s=(0,e.useCallback)((function(e,t){!function(e){e.offsetHeight}(e),null==i.onEnter||i.onEnter(e,t)}),[i]);return(0,x.jsx)(Gt,m(m({ref:n,addEndListener:Qt},i),{},{onEnter:s,childRef:a.ref,children:function(t,n){return e.cloneElement(a,m(m({},n),{},{className:w()("fade",r,a.props.className,Jt[t],l[t])}))}}))}));Zt.defaultProps={in:!1,timeout:300,mountOnEnter:!1,unmountOnExit:!1,appear:!1},Zt.displayName="Fade";var en=Zt,tn=J("modal-body"),nn=e.createContext({onHide:function(){}}),rn=["bsPrefix","className","contentClassName","centered","size","fullscreen","children","scrollable"],an=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.className,a=e.contentClassName,o=e.centered,l=e.size,i=e.fullscreen,s=e.children,u=e.scrollable,c=g(e,rn);n=E(n,"modal");var d="".concat(n,"-dialog"),f="string"===typeof i?"".concat(n,"-fullscreen-").concat(i):"".concat(n,"-fullscreen");return(0,x.jsx)("div",m(m({},c),{},{ref:t,className:w()(d,r,l&&"".concat(n,"-").concat(l),o&&"".concat(d,"-centered"),u&&"".concat(d,"-scrollable"),i&&f),children:(0,x.jsx)("div",{className:w()("".concat(n,"-content"),a),children:s})}))}));an.displayName="ModalDialog";var on=an,ln=J("modal-footer"),sn=["className","variant"],un={"aria-label":T().string,onClick:T().func,variant:T().oneOf(["white"])},cn=e.forwardRef((function(e,t){var n=e.className,r=e.variant,a=g(e,sn);return(0,x.jsx)("button",m({ref:t,type:"button",className:w()("btn-close",r&&"btn-close-".concat(r),n)},a))}));cn.displayName="CloseButton",cn.propTypes=un,cn.defaultProps={"aria-label":"Close"};var dn=cn,fn=["closeLabel","closeVariant","closeButton","onHide","children"],pn=e.forwardRef((function(t,n){var r=t.closeLabel,a=t.closeVariant,o=t.closeButton,l=t.onHide,i=t.children,s=g(t,fn),u=(0,e.useContext)(nn),c=Xe((function(){null==u||u.onHide(),null==l||l()}));return(0,x.jsxs)("div",m(m({ref:n},s),{},{children:[i,o&&(0,x.jsx)(dn,{"aria-label":r,variant:a,onClick:c})]}))}));pn.defaultProps={closeLabel:"Close",closeButton:!1};var hn=pn,vn=["bsPrefix","className"],mn=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.className,a=g(e,vn);return n=E(n,"modal-header"),(0,x.jsx)(hn,m(m({ref:t},a),{},{className:w()(r,n)}))}));mn.displayName="ModalHeader",mn.defaultProps={closeLabel:"Close",closeButton:!1};var yn=mn,gn=function(t){return e.forwardRef((function(e,n){return(0,x.jsx)("div",m(m({},e),{},{ref:n,className:w()(e.className,t)}))}))},bn=J("modal-title",{Component:gn("h4")}),wn=["bsPrefix","className","style","dialogClassName","contentClassName","children","dialogAs","aria-labelledby","aria-describedby","aria-label","show","animation","backdrop","keyboard","onEscapeKeyDown","onShow","onHide","container","autoFocus","enforceFocus","restoreFocus","restoreFocusOptions","onEntered","onExit","onExiting","onEnter","onEntering","onExited","backdropClassName","manager"],xn={show:!1,backdrop:!0,keyboard:!0,autoFocus:!0,enforceFocus:!0,restoreFocus:!0,animation:!0,dialogAs:on};function kn(e){return(0,x.jsx)(en,m(m({},e),{},{timeout:null}))}function Sn(e){return(0,x.jsx)(en,m(m({},e),{},{timeout:null}))}var En=e.forwardRef((function(t,n){var r=t.bsPrefix,a=t.className,o=t.style,l=t.dialogClassName,i=t.contentClassName,s=t.children,u=t.dialogAs,c=t["aria-labelledby"],d=t["aria-describedby"],f=t["aria-label"],p=t.show,h=t.animation,v=t.backdrop,y=t.keyboard,b=t.onEscapeKeyDown,k=t.onShow,C=t.onHide,N=t.container,_=t.autoFocus,R=t.enforceFocus,j=t.restoreFocus,P=t.restoreFocusOptions,T=t.onEntered,O=t.onExit,L=t.onExiting,z=t.onEnter,D=t.onEntering,F=t.onExited,M=t.backdropClassName,I=t.manager,B=g(t,wn),A=oe((0,e.useState)({}),2),U=A[0],H=A[1],q=oe((0,e.useState)(!1),2),V=q[0],W=q[1],$=(0,e.useRef)(!1),Q=(0,e.useRef)(!1),K=(0,e.useRef)(null),Y=oe((0,e.useState)(null),2),G=Y[0],X=Y[1],J=Ze(n,X),Z=Xe(C),ee="rtl"===(0,e.useContext)(S).dir;r=E(r,"modal");var te=(0,e.useMemo)((function(){return{onHide:Z}}),[Z]);function ne(){return I||function(e){return Tt||(Tt=new Dt(e)),Tt}({isRTL:ee})}function re(e){if(Ue){var t=ne().getScrollbarWidth()>0,n=e.scrollHeight>$e(e).documentElement.clientHeight;H({paddingRight:t&&!n?Ye():void 0,paddingLeft:!t&&n?Ye():void 0})}}var ae=Xe((function(){G&&re(G.dialog)}));et((function(){Ke(window,"resize",ae),null==K.current||K.current()}));var le=function(){$.current=!0},ie=function(e){$.current&&G&&e.target===G.dialog&&(Q.current=!0),$.current=!1},se=function(){W(!0),K.current=ut(G.dialog,(function(){W(!1)}))},ue=function(e){"static"!==v?Q.current||e.target!==e.currentTarget?Q.current=!1:null==C||C():function(e){e.target===e.currentTarget&&se()}(e)},ce=(0,e.useCallback)((function(e){return(0,x.jsx)("div",m(m({},e),{},{className:w()("".concat(r,"-backdrop"),M,!h&&"show")}))}),[h,M,r]),de=m(m({},o),U);de.display="block";return(0,x.jsx)(nn.Provider,{value:te,children:(0,x.jsx)(Ct,{show:p,ref:J,backdrop:v,container:N,keyboard:!0,autoFocus:_,enforceFocus:R,restoreFocus:j,restoreFocusOptions:P,onEscapeKeyDown:function(e){y?null==b||b(e):(e.preventDefault(),"static"===v&&se())},onShow:k,onHide:C,onEnter:function(e,t){e&&re(e),null==z||z(e,t)},onEntering:function(e,t){null==D||D(e,t),We(window,"resize",ae)},onEntered:T,onExit:function(e){null==K.current||K.current(),null==O||O(e)},onExiting:L,onExited:function(e){e&&(e.style.display=""),null==F||F(e),Ke(window,"resize",ae)},manager:ne(),transition:h?kn:void 0,backdropTransition:h?Sn:void 0,renderBackdrop:ce,renderDialog:function(e){return(0,x.jsx)("div",m(m({role:"dialog"},e),{},{style:de,className:w()(a,r,V&&"".concat(r,"-static"),!h&&"show"),onClick:v?ue:void 0,onMouseUp:ie,"aria-label":f,"aria-labelledby":c,"aria-describedby":d,children:(0,x.jsx)(u,m(m({},B),{},{onMouseDown:le,className:l,contentClassName:i,children:s}))}))}})})}));En.displayName="Modal",En.defaultProps=xn;var Cn=Object.assign(En,{Body:tn,Header:yn,Title:bn,Footer:ln,Dialog:on,TRANSITION_DURATION:300,BACKDROP_TRANSITION_DURATION:150}),Nn=["bsPrefix","className","as"],_n=e.forwardRef((function(e,t){var n=e.bsPrefix,r=e.className,a=e.as,o=void 0===a?"div":a,l=g(e,Nn),i=E(n,"row"),s=C(),u=N(),c="".concat(i,"-cols"),d=[];return s.forEach((function(e){var t,n=l[e];delete l[e],t=null!=n&&"object"===typeof n?n.cols:n;var r=e!==u?"-".concat(e):"";null!=t&&d.push("".concat(c).concat(r,"-").concat(t))})),(0,x.jsx)(o,m(m({ref:t},l),{},{className:w().apply(void 0,[r,i].concat(d))}))}));_n.displayName="Row";var Rn=_n,jn=function(e){u(n,e);var t=p(n);function n(e){var a;return r(this,n),(a=t.call(this,e)).state={show:!1,rule:{method:"ALL",url:"",action:1},haveRules:!1},a.handleClose=a.handleClose.bind(d(a)),a.handleShow=a.handleShow.bind(d(a)),a.handleSave=a.handleSave.bind(d(a)),a}return i(n,[{key:"handleClose",value:function(){this.setState({show:!1})}},{key:"handleShow",value:function(){this.setState({show:!0})}},{key:"handleSave",value:function(){var e=this.state.rule,t=[];e.url&&t.push({method:"ALL"===e.method?"":e.method,url:e.url,action:e.action}),this.props.onSave(t),this.handleClose(),this.setState({haveRules:!!t.length})}},{key:"render",value:function(){var e=this,t=this.state,n=t.rule,r=t.haveRules?"success":"primary";return(0,x.jsxs)("div",{children:[(0,x.jsx)(Fe,{variant:r,size:"sm",onClick:this.handleShow,children:"BreakPoint"}),(0,x.jsxs)(Cn,{show:this.state.show,onHide:this.handleClose,children:[(0,x.jsx)(Cn.Header,{closeButton:!0,children:(0,x.jsx)(Cn.Title,{children:"Set BreakPoint"})}),(0,x.jsxs)(Cn.Body,{children:[(0,x.jsxs)(Pe.Group,{as:Rn,children:[(0,x.jsx)(Pe.Label,{column:!0,sm:2,children:"Method"}),(0,x.jsx)(ue,{sm:10,children:(0,x.jsxs)(Pe.Control,{as:"select",value:n.method,onChange:function(t){e.setState({rule:m(m({},n),{},{method:t.target.value})})},children:[(0,x.jsx)("option",{children:"ALL"}),(0,x.jsx)("option",{children:"GET"}),(0,x.jsx)("option",{children:"POST"}),(0,x.jsx)("option",{children:"PUT"}),(0,x.jsx)("option",{children:"DELETE"})]})})]}),(0,x.jsxs)(Pe.Group,{as:Rn,children:[(0,x.jsx)(Pe.Label,{column:!0,sm:2,children:"URL"}),(0,x.jsx)(ue,{sm:10,children:(0,x.jsx)(Pe.Control,{value:n.url,onChange:function(t){e.setState({rule:m(m({},n),{},{url:t.target.value})})}})})]}),(0,x.jsxs)(Pe.Group,{as:Rn,children:[(0,x.jsx)(Pe.Label,{column:!0,sm:2,children:"Action"}),(0,x.jsx)(ue,{sm:10,children:(0,x.jsxs)(Pe.Control,{as:"select",value:n.action,onChange:function(t){e.setState({rule:m(m({},n),{},{action:parseInt(t.target.value)})})},children:[(0,x.jsx)("option",{value:"1",children:"Request"}),(0,x.jsx)("option",{value:"2",children:"Response"}),(0,x.jsx)("option",{value:"3",children:"Both"})]})})]})]}),(0,x.jsxs)(Cn.Footer,{children:[(0,x.jsx)(Fe,{variant:"secondary",onClick:this.handleClose,children:"Close"}),(0,x.jsx)(Fe,{variant:"primary",onClick:this.handleSave,children:"Save"})]})]})]})}}]),n}(e.Component),Pn=function(e){return!!e&&(!!e.header&&(!!e.header["Content-Type"]&&/text|javascript|json|x-www-form-urlencoded|xml|form-data/.test(e.header["Content-Type"].join(""))))},Tn=function(e){return e?isNaN(e)||e<=0?"0":e<1024?"".concat(e," B"):e<1048576?"".concat((e/1024).toFixed(2)," KB"):"".concat((e/1048576).toFixed(2)," MB"):"0"},On=function(e){for(var t="",n=new Uint8Array(e),r=n.byteLength,a=0;a<r;a++)t+=String.fromCharCode(n[a]);return btoa(t)},Ln=function(e){var t="",n=new Uint8Array(e),r=n.byteLength,a="";t+="00000000:  ";for(var o=0;o<r;o++)t+=n[o].toString(16).padStart(2,"0")+" ",n[o]>=32&&n[o]<=126?a+=String.fromCharCode(n[o]):a+=".",(o+1)%16===0?(t+="   "+a,a="",t+="\n".concat((o+1).toString(16).padStart(8,"0"),":  ")):(o+1)%8===0&&(t+="  ");if(a.length>0){for(var l=a.length;l<16;l++)t+="   ",(l+1)%8===0&&(t+="  ");t+=" "+a}return t};var zn=function(e){u(n,e);var t=p(n);function n(){return r(this,n),t.apply(this,arguments)}return i(n,[{key:"shouldComponentUpdate",value:function(e){return e.isSelected!==this.props.isSelected||!function(e,t){if(e===t)return!0;var n=Object.keys(e),r=Object.keys(t);if(n.length!==r.length)return!1;for(var a=0;a<n.length;a++){var o=n[a];if(void 0===t[o]||e[o]!==t[o])return!1}return!0}(e.flow,this.props.flow)}},{key:"render",value:function(){var e=this,t=this.props.flow,n=[];return this.props.isSelected?n.push("tr-selected"):t.waitIntercept?n.push("tr-wait-intercept"):t.warn&&n.push("tr-wait-warn"),(0,x.jsxs)("tr",{className:n.length?n.join(" "):void 0,onClick:function(){e.props.onShowDetail()},children:[(0,x.jsx)("td",{children:t.no}),(0,x.jsx)("td",{children:t.method}),(0,x.jsx)("td",{children:t.host}),(0,x.jsx)("td",{children:t.path}),(0,x.jsx)("td",{children:t.contentType}),(0,x.jsx)("td",{children:t.statusCode}),(0,x.jsx)("td",{children:t.size}),(0,x.jsx)("td",{children:t.costTime})]})}}]),n}(e.Component),Dn=zn,Fn=n(400),Mn=n(998),In=n.n(Mn),Bn=n(779),An=n.n(Bn);function Un(e,t){var n="undefined"!==typeof Symbol&&e[Symbol.iterator]||e["@@iterator"];if(!n){if(Array.isArray(e)||(n=ae(e))||t&&e&&"number"===typeof e.length){n&&(e=n);var r=0,a=function(){};return{s:a,n:function(){return r>=e.length?{done:!0}:{done:!1,value:e[r++]}},e:function(e){throw e},f:a}}throw new TypeError("Invalid attempt to iterate non-iterable instance.\nIn order to be iterable, non-array objects must have a [Symbol.iterator]() method.")}var o,l=!0,i=!1;return{s:function(){n=n.call(e)},n:function(){var e=n.next();return l=e.done,e},e:function(e){i=!0,o=e},f:function(){try{l||null==n.return||n.return()}finally{if(i)throw o}}}}function Hn(){return Hn=Object.assign?Object.assign.bind():function(e){for(var t=1;t<arguments.length;t++){var n=arguments[t];for(var r in n)Object.prototype.hasOwnProperty.call(n,r)&&(e[r]=n[r])}return e},Hn.apply(this,arguments)}n(176);function qn(e){return"default"+e.charAt(0).toUpperCase()+e.substr(1)}function Vn(e){var t=function(e,t){if("object"!==typeof e||null===e)return e;var n=e[Symbol.toPrimitive];if(void 0!==n){var r=n.call(e,t||"default");if("object"!==typeof r)return r;throw new TypeError("@@toPrimitive must return a primitive value.")}return("string"===t?String:Number)(e)}(e,"string");return"symbol"===typeof t?t:String(t)}function Wn(t,n){return Object.keys(n).reduce((function(r,a){var o,l=r,i=l[qn(a)],s=l[a],u=y(l,[qn(a),a].map(Vn)),c=n[a],d=function(t,n,r){var a=(0,e.useRef)(void 0!==t),o=(0,e.useState)(n),l=o[0],i=o[1],s=void 0!==t,u=a.current;return a.current=s,!s&&u&&l!==n&&i(n),[s?t:l,(0,e.useCallback)((function(e){for(var t=arguments.length,n=new Array(t>1?t-1:0),a=1;a<t;a++)n[a-1]=arguments[a];r&&r.apply(void 0,[e].concat(n)),i(e)}),[r])]}(s,i,t[c]),f=d[0],p=d[1];return Hn({},u,((o={})[a]=f,o[c]=p,o))}),t)}function $n(){var e=this.constructor.getDerivedStateFromProps(this.props,this.state);null!==e&&void 0!==e&&this.setState(e)}function Qn(e){this.setState(function(t){var n=this.constructor.getDerivedStateFromProps(e,t);return null!==n&&void 0!==n?n:null}.bind(this))}function Kn(e,t){try{var n=this.props,r=this.state;this.props=e,this.state=t,this.__reactInternalSnapshotFlag=!0,this.__reactInternalSnapshot=this.getSnapshotBeforeUpdate(n,r)}finally{this.props=n,this.state=r}}$n.__suppressDeprecationWarning=!0,Qn.__suppressDeprecationWarning=!0,Kn.__suppressDeprecationWarning=!0;var Yn="undefined"!==typeof n.g&&n.g.navigator&&"ReactNative"===n.g.navigator.product;"undefined"!==typeof document||Yn?e.useLayoutEffect:e.useEffect,new WeakMap;var Gn=["onKeyDown"];var Xn=e.forwardRef((function(e,t){var n,r=e.onKeyDown,a=function(e,t){if(null==e)return{};var n,r,a={},o=Object.keys(e);for(r=0;r<o.length;r++)n=o[r],t.indexOf(n)>=0||(a[n]=e[n]);return a}(e,Gn),o=oe(Oe(Object.assign({tagName:"a"},a)),1)[0],l=Xe((function(e){o.onKeyDown(e),null==r||r(e)}));return(n=a.href)&&"#"!==n.trim()&&"button"!==a.role?(0,x.jsx)("a",Object.assign({ref:t},a,{onKeyDown:r})):(0,x.jsx)("a",Object.assign({ref:t},a,o,{onKeyDown:l}))}));Xn.displayName="Anchor";var Jn=Xn,Zn=["bsPrefix","show","closeLabel","closeVariant","className","children","variant","onClose","dismissible","transition"],er=gn("h4");er.displayName="DivStyledAsH4";var tr=J("alert-heading",{Component:er}),nr=J("alert-link",{Component:Jn}),rr={variant:"primary",show:!0,transition:en,closeLabel:"Close alert"},ar=e.forwardRef((function(e,t){var n=Wn(e,{show:"onClose"}),r=n.bsPrefix,a=n.show,o=n.closeLabel,l=n.closeVariant,i=n.className,s=n.children,u=n.variant,c=n.onClose,d=n.dismissible,f=n.transition,p=g(n,Zn),h=E(r,"alert"),v=Xe((function(e){c&&c(!1,e)})),y=!0===f?en:f,b=(0,x.jsxs)("div",m(m({role:"alert"},y?void 0:p),{},{ref:t,className:w()(i,h,u&&"".concat(h,"-").concat(u),d&&"".concat(h,"-dismissible")),children:[d&&(0,x.jsx)(dn,{onClick:v,"aria-label":o,variant:l}),s]}));return y?(0,x.jsx)(y,m(m({unmountOnExit:!0},p),{},{ref:void 0,in:a,children:b})):a?b:null}));ar.displayName="Alert",ar.defaultProps=rr;var or,lr=Object.assign(ar,{Link:nr,Heading:tr}),ir=["body"],sr=["body"];!function(e){e[e.CONN=0]="CONN",e[e.CONN_CLOSE=5]="CONN_CLOSE",e[e.REQUEST=1]="REQUEST",e[e.REQUEST_BODY=2]="REQUEST_BODY",e[e.RESPONSE=3]="RESPONSE",e[e.RESPONSE_BODY=4]="RESPONSE_BODY"}(or||(or={}));var ur,cr=[or.CONN,or.CONN_CLOSE,or.REQUEST,or.REQUEST_BODY,or.RESPONSE,or.RESPONSE_BODY];!function(e){e[e.CHANGE_REQUEST=11]="CHANGE_REQUEST",e[e.CHANGE_RESPONSE=12]="CHANGE_RESPONSE",e[e.DROP_REQUEST=13]="DROP_REQUEST",e[e.DROP_RESPONSE=14]="DROP_RESPONSE",e[e.CHANGE_BREAK_POINT_RULES=21]="CHANGE_BREAK_POINT_RULES"}(ur||(ur={}));var dr=function(e,t){var h=(new TextEncoder).encode(t.id),m=4+h.byteLength;if(e===ur.DROP_REQUEST||e===ur.DROP_RESPONSE){var n=new Uint8Array(m);return n[0]=3,n[1]=e,new DataView(n.buffer).setUint16(2,h.byteLength),n.set(h,4),n}var r,a;if(e===ur.CHANGE_REQUEST){var o=t.request;a=o.body,r=g(o,ir)}else{if(e!==ur.CHANGE_RESPONSE)throw new Error("invalid message type");var l=t.response;a=l.body,r=g(l,sr)}a instanceof ArrayBuffer&&(a=new Uint8Array(a));var i=a&&a.byteLength?a.byteLength:0;"Content-Encoding"in r.header&&delete r.header["Content-Encoding"],"Transfer-Encoding"in r.header&&delete r.header["Transfer-Encoding"],r.header["Content-Length"]=[String(i)];var s=(new TextEncoder).encode(JSON.stringify(r)),u=m+4+s.byteLength+4+i,c=new ArrayBuffer(u),d=new Uint8Array(c);d[0]=3,d[1]=e,d.set(h,4),d.set(s,m+4),i&&d.set(a,m+4+s.byteLength+4);var f=new DataView(c);return f.setUint16(2,h.byteLength),f.setUint32(m,s.byteLength),f.setUint32(m+4+s.byteLength,i),d},fr=function(e){u(n,e);var t=p(n);function n(e){var a;return r(this,n),(a=t.call(this,e)).state={show:!1,alertMsg:"",content:""},a.handleClose=a.handleClose.bind(d(a)),a.handleShow=a.handleShow.bind(d(a)),a.handleSave=a.handleSave.bind(d(a)),a}return i(n,[{key:"showAlert",value:function(e){this.setState({alertMsg:e})}},{key:"handleClose",value:function(){this.setState({show:!1})}},{key:"handleShow",value:function(){var e=this.props.flow,t="";t="request"===(e.response?"response":"request")?function(e){var t="".concat(e.method," ").concat(e.url),n=Object.keys(e.header).map((function(t){var n=e.header[t].join(" \t ");return"".concat(t,": ").concat(n)})).join("\n"),r="";return e.body&&Pn(e)&&(r=(new TextDecoder).decode(e.body)),"".concat(t,"\n\n").concat(n,"\n\n").concat(r)}(e.request):function(e){var t="".concat(e.statusCode),n=Object.keys(e.header).map((function(t){var n=e.header[t].join(" \t ");return"".concat(t,": ").concat(n)})).join("\n"),r="";return e.body&&Pn(e)&&(r=(new TextDecoder).decode(e.body)),"".concat(t,"\n\n").concat(n,"\n\n").concat(r)}(e.response),this.setState({show:!0,alertMsg:"",content:t})}},{key:"handleSave",value:function(){var e=this.props.flow.response?"response":"request",t=this.state.content;if("request"===e){var n=function(e){var t=e.indexOf("\n\n");if(!(t<=0)){var n=oe(e.slice(0,t).split(" "),2),r=n[0],a=n[1];if(r&&a){var o=e.indexOf("\n\n",t+2);if(!(o<=0)){var l,i={},s=Un(e.slice(t+2,o).split("\n"));try{for(s.s();!(l=s.n()).done;){var u=oe(l.value.split(": "),2),c=u[0],d=u[1];if(!c||!d)return;i[c]=d.split(" \t ")}}catch(h){s.e(h)}finally{s.f()}var f,p=e.slice(o+2);return p&&(f=(new TextEncoder).encode(p)),{method:r,url:a,proto:"",header:i,body:f}}}}}(t);if(!n)return void this.showAlert("parse error");this.props.onChangeRequest(n),this.handleClose()}else{var r=function(e){var t=e.indexOf("\n\n");if(!(t<=0)){var n=e.slice(0,t),r=parseInt(n);if(!isNaN(r)){var a=e.indexOf("\n\n",t+2);if(!(a<=0)){var o,l={},i=Un(e.slice(t+2,a).split("\n"));try{for(i.s();!(o=i.n()).done;){var s=oe(o.value.split(": "),2),u=s[0],c=s[1];if(!u||!c)return;l[u]=c.split(" \t ")}}catch(p){i.e(p)}finally{i.f()}var d,f=e.slice(a+2);return f&&(d=(new TextEncoder).encode(f)),{statusCode:r,header:l,body:d}}}}}(t);if(!r)return void this.showAlert("parse error");this.props.onChangeResponse(r),this.handleClose()}}},{key:"render",value:function(){var e=this,t=this.props.flow;if(!t.waitIntercept)return null;var n=this.state.alertMsg,r=t.response?"response":"request";return(0,x.jsxs)("div",{className:"flow-wait-area",children:[(0,x.jsx)(Fe,{size:"sm",onClick:this.handleShow,children:"Edit"}),(0,x.jsx)(Fe,{size:"sm",onClick:function(){var n="response"===r?ur.CHANGE_RESPONSE:ur.CHANGE_REQUEST,a=dr(n,t);e.props.onMessage(a)},children:"Continue"}),(0,x.jsx)(Fe,{size:"sm",onClick:function(){var n="response"===r?ur.DROP_RESPONSE:ur.DROP_REQUEST,a=dr(n,t);e.props.onMessage(a)},children:"Drop"}),(0,x.jsxs)(Cn,{size:"lg",show:this.state.show,onHide:this.handleClose,children:[(0,x.jsx)(Cn.Header,{closeButton:!0,children:(0,x.jsxs)(Cn.Title,{children:["Edit ","request"===r?"Request":"Response"]})}),(0,x.jsxs)(Cn.Body,{children:[(0,x.jsx)(Pe.Group,{children:(0,x.jsx)(Pe.Control,{as:"textarea",rows:10,value:this.state.content,onChange:function(t){e.setState({content:t.target.value})}})}),n?(0,x.jsx)(lr,{variant:"danger",children:n}):null]}),(0,x.jsxs)(Cn.Footer,{children:[(0,x.jsx)(Fe,{variant:"secondary",onClick:this.handleClose,children:"Close"}),(0,x.jsx)(Fe,{variant:"primary",onClick:this.handleSave,children:"Save"})]})]})]})}}]),n}(e.Component),pr=function(e){u(n,e);var t=p(n);function n(e){var a;return r(this,n),(a=t.call(this,e)).state={flowTab:"Detail",copied:!1,requestBodyViewTab:"Raw",responseBodyLineBreak:!1},a}return i(n,[{key:"preview",value:function(){var e=this.props.flow;if(!e)return null;var t=e.response;if(!t)return null;if(!t.body||!t.body.byteLength)return(0,x.jsx)("div",{style:{color:"gray"},children:"No response"});var n=e.previewResponseBody();return n?"image"===n.type?(0,x.jsx)("img",{src:"data:image/png;base64,".concat(n.data)}):"json"===n.type?(0,x.jsx)("div",{children:(0,x.jsx)(An(),{data:n.data,keyStyle:"color: rgb(130,40,144);",stringStyle:"color: rgb(153,68,60);",valueStyle:"color: rgb(25,1,199);",booleanStyle:"color: rgb(94,105,192);"})}):(0,x.jsx)("div",{style:{color:"gray"},children:"Not support preview"}):(0,x.jsx)("div",{style:{color:"gray"},children:"Not support preview"})}},{key:"requestBodyPreview",value:function(){var e=this.props.flow;if(!e)return null;var t=e.previewRequestBody();return t?"json"===t.type?(0,x.jsx)("div",{children:(0,x.jsx)(An(),{data:t.data,keyStyle:"color: rgb(130,40,144);",stringStyle:"color: rgb(153,68,60);",valueStyle:"color: rgb(25,1,199);",booleanStyle:"color: rgb(94,105,192);"})}):"binary"===t.type?(0,x.jsx)("div",{children:(0,x.jsx)("pre",{children:t.data})}):(0,x.jsx)("div",{style:{color:"gray"},children:"Not support preview"}):(0,x.jsx)("div",{style:{color:"gray"},children:"Not support preview"})}},{key:"hexview",value:function(){var e=this.props.flow;if(!e)return null;var t=e.response;return t?t.body&&t.body.byteLength?(0,x.jsx)("pre",{children:e.hexviewResponseBody()}):(0,x.jsx)("div",{style:{color:"gray"},children:"No response"}):null}},{key:"detail",value:function(){var e=this.props.flow;if(!e)return null;var t=e.getConn();return(0,x.jsxs)("div",{children:[(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Flow Info"}),(0,x.jsx)("div",{className:"header-block-content",children:(0,x.jsxs)("p",{children:["Id: ",e.id]})})]}),t?(0,x.jsxs)(x.Fragment,{children:[t.serverConn?(0,x.jsx)(x.Fragment,{children:(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Server Connection"}),(0,x.jsxs)("div",{className:"header-block-content",children:[(0,x.jsxs)("p",{children:["Address: ",t.serverConn.address]}),(0,x.jsxs)("p",{children:["Resolved Address: ",t.serverConn.peername]})]})]})}):null,(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Client Connection"}),(0,x.jsx)("div",{className:"header-block-content",children:(0,x.jsxs)("p",{children:["Address: ",t.clientConn.address]})})]}),(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Connection Info"}),(0,x.jsxs)("div",{className:"header-block-content",children:[(0,x.jsxs)("p",{children:["Id: ",t.clientConn.id]}),(0,x.jsxs)("p",{children:["Intercept: ",t.intercept?"true":"false"]}),null==t.opening?null:(0,x.jsxs)("p",{children:["Opening: ",t.opening?"true":"false"]}),null==t.flowCount?null:(0,x.jsxs)("p",{children:["Flow Count: ",t.flowCount]})]})]})]}):null]})}},{key:"copyAsCurl",value:function(){var e=this,t=this.props.flow;return t?(0,x.jsx)(Fe,{size:"sm",variant:this.state.copied?"success":"primary",disabled:this.state.copied,onClick:function(){var n=(0,Fn.ZP)({url:t.request.url,method:t.request.method,headers:Object.keys(t.request.header).reduce((function(e,n){return e[n]=t.request.header[n][0],e}),{}),body:t.requestBody()});In()(n),e.setState({copied:!0},(function(){setTimeout((function(){e.setState({copied:!1})}),1e3)}))},children:this.state.copied?"Copied":"Copy as cURL"}):null}},{key:"render",value:function(){var e=this;if(!this.props.flow)return null;var t=this.props.flow,n=this.state.flowTab,r=t.request,a=t.response||{},o=[];return t.url&&t.url.search&&t.url.searchParams.forEach((function(e,t){o.push({key:t,value:e})})),(0,x.jsxs)("div",{className:"flow-detail",children:[(0,x.jsxs)("div",{className:"header-tabs",children:[(0,x.jsx)("span",{style:{position:"absolute",top:"2px",left:"0px",cursor:"pointer"},onClick:function(){e.props.onClose()},children:"x"}),(0,x.jsx)(fr,{flow:t,onChangeRequest:function(n){t.request.method=n.method,t.request.url=n.url,t.request.header=n.header,Pn(t.request)&&(t.request.body=n.body),e.props.onReRenderFlows()},onChangeResponse:function(n){t.response||(t.response={}),t.response.statusCode=n.statusCode,t.response.header=n.header,Pn(t.response)&&(t.response.body=n.body),e.props.onReRenderFlows()},onMessage:function(n){e.props.onMessage(n),t.waitIntercept=!1,e.props.onReRenderFlows()}}),(0,x.jsx)("div",{children:this.copyAsCurl()}),(0,x.jsxs)("div",{children:[(0,x.jsx)("span",{className:"Detail"===n?"selected":void 0,onClick:function(){e.setState({flowTab:"Detail"})},children:"Detail"}),(0,x.jsx)("span",{className:"Headers"===n?"selected":void 0,onClick:function(){e.setState({flowTab:"Headers"})},children:"Headers"}),(0,x.jsx)("span",{className:"Preview"===n?"selected":void 0,onClick:function(){e.setState({flowTab:"Preview"})},children:"Preview"}),(0,x.jsx)("span",{className:"Response"===n?"selected":void 0,onClick:function(){e.setState({flowTab:"Response"})},children:"Response"}),(0,x.jsx)("span",{className:"Hexview"===n?"selected":void 0,onClick:function(){e.setState({flowTab:"Hexview"})},children:"Hexview"})]})]}),(0,x.jsxs)("div",{style:{padding:"20px 25px"},children:["Headers"!==n?null:(0,x.jsxs)("div",{children:[(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"General"}),(0,x.jsxs)("div",{className:"header-block-content",children:[(0,x.jsxs)("p",{children:["Request URL: ",r.url]}),(0,x.jsxs)("p",{children:["Request Method: ",r.method]}),(0,x.jsxs)("p",{children:["Status Code: ","".concat(a.statusCode||"(pending)")]})]})]}),a.header?(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Response Headers"}),(0,x.jsx)("div",{className:"header-block-content",children:Object.keys(a.header).map((function(e){return(0,x.jsxs)("p",{children:[e,": ",a.header[e].join(" ")]},e)}))})]}):null,(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Request Headers"}),(0,x.jsx)("div",{className:"header-block-content",children:r.header?Object.keys(r.header).map((function(e){return(0,x.jsxs)("p",{children:[e,": ",r.header[e].join(" ")]},e)})):null})]}),o.length?(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Query String Parameters"}),(0,x.jsx)("div",{className:"header-block-content",children:o.map((function(e,t){var n=e.key,r=e.value;return(0,x.jsxs)("p",{children:[n,": ",r]},"".concat(n,"-").concat(t))}))})]}):null,r.body&&r.body.byteLength?(0,x.jsxs)("div",{className:"header-block",children:[(0,x.jsx)("p",{children:"Request Body"}),(0,x.jsx)("div",{className:"header-block-content",children:(0,x.jsxs)("div",{children:[(0,x.jsxs)("div",{className:"request-body-detail",style:{marginBottom:"15px"},children:[(0,x.jsx)("span",{className:"Raw"===this.state.requestBodyViewTab?"selected":void 0,onClick:function(){e.setState({requestBodyViewTab:"Raw"})},children:"Raw"}),(0,x.jsx)("span",{className:"Preview"===this.state.requestBodyViewTab?"selected":void 0,onClick:function(){e.setState({requestBodyViewTab:"Preview"})},children:"Preview"})]}),"Raw"!==this.state.requestBodyViewTab?null:(0,x.jsx)("div",{children:t.isTextRequest()?t.requestBody():(0,x.jsx)("span",{style:{color:"gray"},children:"Not text Request"})}),"Preview"!==this.state.requestBodyViewTab?null:(0,x.jsx)("div",{children:this.requestBodyPreview()})]})})]}):null]}),"Response"!==n?null:a.body&&a.body.byteLength?t.isTextResponse()?(0,x.jsxs)("div",{children:[(0,x.jsx)("div",{style:{marginBottom:"20px"},children:(0,x.jsx)(W,{inline:!0,type:"checkbox",checked:this.state.responseBodyLineBreak,onChange:function(t){e.setState({responseBodyLineBreak:t.target.checked})},label:"\u81ea\u52a8\u6362\u884c"})}),(0,x.jsx)("div",{style:{whiteSpace:this.state.responseBodyLineBreak?"pre-wrap":"pre"},children:t.responseBody()})]}):(0,x.jsx)("div",{style:{color:"gray"},children:"Not text response"}):(0,x.jsx)("div",{style:{color:"gray"},children:"No response"}),"Preview"!==n?null:(0,x.jsx)("div",{children:this.preview()}),"Hexview"!==n?null:(0,x.jsx)("div",{children:this.hexview()}),"Detail"!==n?null:(0,x.jsx)("div",{children:this.detail()})]})]})}}]),n}(e.Component),hr=function(e){u(n,e);var t=p(n);function n(e){var a;return r(this,n),(a=t.call(this,e)).x=0,a.w=0,a.state={width:e.width},a.mouseMoveHandler=a.mouseMoveHandler.bind(d(a)),a.mouseUpHandler=a.mouseUpHandler.bind(d(a)),a}return i(n,[{key:"mouseMoveHandler",value:function(e){var t=e.clientX-this.x;this.setState({width:this.w+t})}},{key:"mouseUpHandler",value:function(){document.removeEventListener("mousemove",this.mouseMoveHandler),document.removeEventListener("mouseup",this.mouseUpHandler)}},{key:"render",value:function(){var e=this;return(0,x.jsxs)("th",{style:{width:"".concat(this.state.width,"px"),position:"relative"},children:[this.props.children,(0,x.jsx)("div",{style:{position:"absolute",top:0,right:0,width:"5px",height:"100%",cursor:"col-resize",userSelect:"none"},onMouseDown:function(t){e.x=t.clientX,e.w=e.state.width,document.addEventListener("mousemove",e.mouseMoveHandler),document.addEventListener("mouseup",e.mouseUpHandler)}})]})}}]),n}(e.Component),vr=n(816),mr=n.n(vr),yr=["url","method","code","header","reqheader","resheader","body","reqbody","resbody","all"],gr=function(){function e(t){r(this,e),this.rule=void 0,(t=t.trim())&&(this.rule=mr().parse(t))}return i(e,[{key:"match",value:function(e){return!this.rule||this._match(e,this.rule)}},{key:"_match",value:function(e,t){if("keyword"===t.type)return this.getFlowFilterKeyword(t).match(e);if("not"===t.type)return!this._match(e,t.expr);if("and"===t.type)return this._match(e,t.left)&&this._match(e,t.right);if("or"===t.type)return this._match(e,t.left)||this._match(e,t.right);throw new Error("invalid rule type ".concat(t.type))}},{key:"getFlowFilterKeyword",value:function(e){return e.filter||(e.filter=new br(e.value)),e.filter}}]),e}(),br=function(){function e(t){if(r(this,e),this.keyword=void 0,this.scope="url",t=t.trim()){var n,a=Un(yr);try{for(a.s();!(n=a.n()).done;){var o=n.value;if(t.startsWith("".concat(o,":"))){this.scope=o,t=t.replace("".concat(o,":"),"").trim();break}}}catch(i){a.e(i)}finally{a.f()}if(t)if(t.startsWith("/")&&(t.endsWith("/")||t.endsWith("/i"))){var l;if(t.endsWith("i")&&(l="i",t=t.slice(0,-1)),!(t=t.slice(1,-1).trim()))return;this.keyword=new RegExp(t,l)}else this.keyword=t}}return i(e,[{key:"match",value:function(e){switch(this.scope){case"url":return this.matchUrl(e);case"method":return this.matchMethod(e);case"code":return this.matchCode(e);case"reqheader":return this.matchReqHeader(e);case"resheader":return this.matchResHeader(e);case"header":return this.matchHeader(e);case"reqbody":return this.matchReqBody(e);case"resbody":return this.matchResBody(e);case"body":return this.matchBody(e);case"all":return this.matchAll(e);default:throw new Error("invalid scope ".concat(this.scope))}}},{key:"matchUrl",value:function(e){return this.matchKeyword(e.request.url)}},{key:"matchMethod",value:function(e){return this.matchKeyword(e.request.method)||this.matchKeyword(e.request.method.toLowerCase())}},{key:"matchCode",value:function(e){return!!e.response&&this.matchKeyword(e.response.statusCode.toString())}},{key:"_matchHeader",value:function(e){var t=this;return Object.entries(e).some((function(e){var n=oe(e,2),r=n[0],a=n[1];return[r].concat(a).some((function(e){return t.matchKeyword(e)}))}))}},{key:"matchReqHeader",value:function(e){return this._matchHeader(e.request.header)}},{key:"matchResHeader",value:function(e){return!!e.response&&this._matchHeader(e.response.header)}},{key:"matchHeader",value:function(e){return this.matchReqHeader(e)||this.matchResHeader(e)}},{key:"matchReqBody",value:function(e){var t=e.requestBody();return!!t&&this.matchKeyword(t)}},{key:"matchResBody",value:function(e){var t=e.responseBody();return!!t&&this.matchKeyword(t)}},{key:"matchBody",value:function(e){return this.matchReqBody(e)||this.matchResBody(e)}},{key:"matchAll",value:function(e){return this.matchUrl(e)||this.matchMethod(e)||this.matchHeader(e)||this.matchBody(e)}},{key:"matchKeyword",value:function(e){return!this.keyword||!!e&&(this.keyword instanceof RegExp?this.keyword.test(e):e.includes(this.keyword))}}]),e}(),wr=function(){function e(t,n){r(this,e),this.no=void 0,this.id=void 0,this.connId=void 0,this.waitIntercept=void 0,this.request=void 0,this.response=null,this.url=void 0,this.path=void 0,this._size=0,this.size="0",this.headerContentLengthExist=!1,this.contentType="",this.startTime=Date.now(),this.endTime=0,this.costTime="(pending)",this.status=or.REQUEST,this._isTextRequest=void 0,this._isTextResponse=void 0,this._requestBody=void 0,this._hexviewRequestBody=null,this._responseBody=void 0,this._previewResponseBody=null,this._previewRequestBody=null,this._hexviewResponseBody=null,this.connMgr=void 0,this.conn=void 0,this.no=++e.curNo,this.id=t.id,this.waitIntercept=t.waitIntercept;var a=t.content;this.connId=a.connId,this.request=a.request;var o=this.request.url;o.startsWith("//")&&(o="http:"+o),this.url=new URL(o),this.path=this.url.pathname+this.url.search,this._isTextRequest=null,this._isTextResponse=null,this._requestBody=null,this._responseBody=null,this.connMgr=n}return i(e,[{key:"addRequestBody",value:function(e){return this.status=or.REQUEST_BODY,this.waitIntercept=e.waitIntercept,this.request.body=e.content,this}},{key:"addResponse",value:function(e){return this.status=or.RESPONSE,this.waitIntercept=e.waitIntercept,this.response=e.content,this.response&&this.response.header&&(null!=this.response.header["Content-Type"]&&(this.contentType=this.response.header["Content-Type"][0].split(";")[0],this.contentType.includes("javascript")&&(this.contentType="javascript")),null!=this.response.header["Content-Length"]&&(this.headerContentLengthExist=!0,this._size=parseInt(this.response.header["Content-Length"][0]),this.size=Tn(this._size))),this}},{key:"addResponseBody",value:function(e){return this.status=or.RESPONSE_BODY,this.waitIntercept=e.waitIntercept,this.response&&(this.response.body=e.content),this.endTime=Date.now(),this.costTime=String(this.endTime-this.startTime)+" ms",!this.headerContentLengthExist&&this.response&&this.response.body&&(this._size=this.response.body.byteLength,this.size=Tn(this._size)),this}},{key:"preview",value:function(){var e;return{no:this.no,id:this.id,waitIntercept:this.waitIntercept,host:this.url.host,path:this.path,method:this.request.method,statusCode:this.response?String(this.response.statusCode):"(pending)",size:this.size,costTime:this.costTime,contentType:this.contentType,warn:0===(null===(e=this.getConn())||void 0===e?void 0:e.flowCount)}}},{key:"isTextRequest",value:function(){return null!==this._isTextRequest||(this._isTextRequest=Pn(this.request)),this._isTextRequest}},{key:"requestBody",value:function(){return null!==this._requestBody?this._requestBody:this.isTextRequest()?this.status<or.REQUEST_BODY?"":(this._requestBody=(new TextDecoder).decode(this.request.body),this._requestBody):(this._requestBody="",this._requestBody)}},{key:"hexviewRequestBody",value:function(){var e,t;return null!==this._hexviewRequestBody?this._hexviewRequestBody:this.status<or.REQUEST_BODY?null:null!==(e=this.request)&&void 0!==e&&null!==(t=e.body)&&void 0!==t&&t.byteLength?(this._hexviewRequestBody=Ln(this.request.body),this._hexviewRequestBody):null}},{key:"isTextResponse",value:function(){return this.status<or.RESPONSE?null:(null!==this._isTextResponse||(this._isTextResponse=Pn(this.response)),this._isTextResponse)}},{key:"responseBody",value:function(){var e;return null!==this._responseBody?this._responseBody:this.status<or.RESPONSE?"":this.isTextResponse()?this.status<or.RESPONSE_BODY?"":(this._responseBody=(new TextDecoder).decode(null===(e=this.response)||void 0===e?void 0:e.body),this._responseBody):(this._responseBody="",this._responseBody)}},{key:"previewResponseBody",value:function(){var e,t,n;return this._previewResponseBody?this._previewResponseBody:this.status<or.RESPONSE_BODY?null:null!==(e=this.response)&&void 0!==e&&null!==(t=e.body)&&void 0!==t&&t.byteLength?(this.response.header["Content-Type"]&&(n=this.response.header["Content-Type"][0]),n?(n.startsWith("image/")?this._previewResponseBody={type:"image",data:On(this.response.body)}:n.includes("application/json")&&(this._previewResponseBody={type:"json",data:this.responseBody()}),this._previewResponseBody):null):null}},{key:"previewRequestBody",value:function(){var e;return this._previewRequestBody?this._previewRequestBody:this.status<or.REQUEST_BODY?null:null!==(e=this.request.body)&&void 0!==e&&e.byteLength?(this.isTextRequest()?/json/.test(this.request.header["Content-Type"].join(""))&&(this._previewRequestBody={type:"json",data:this.requestBody()}):this._previewRequestBody={type:"binary",data:this.hexviewRequestBody()},this._previewRequestBody):null}},{key:"hexviewResponseBody",value:function(){var e,t;return null!==this._hexviewResponseBody?this._hexviewResponseBody:this.status<or.RESPONSE_BODY?null:null!==(e=this.response)&&void 0!==e&&null!==(t=e.body)&&void 0!==t&&t.byteLength?(this._hexviewResponseBody=Ln(this.response.body),this._hexviewResponseBody):null}},{key:"getConn",value:function(){return this.conn||(this.conn=this.connMgr.get(this.connId)),this.conn}}]),e}();wr.curNo=0;var xr=function(){function e(){r(this,e),this.items=void 0,this._map=void 0,this.flowFilter=void 0,this.filterTimer=void 0,this.num=void 0,this.max=void 0,this.items=[],this._map=new Map,this.filterTimer=null,this.num=0,this.max=1e3}return i(e,[{key:"showList",value:function(){var e=this;return this.flowFilter?this.items.filter((function(t){return e.flowFilter.match(t)})):this.items}},{key:"add",value:function(e){if(e.no=++this.num,this.items.push(e),this._map.set(e.id,e),this.items.length>this.max){var t=this.items.shift();t&&this._map.delete(t.id)}}},{key:"get",value:function(e){return this._map.get(e)}},{key:"changeFilterLazy",value:function(e,t){var n=this;this.filterTimer&&(clearTimeout(this.filterTimer),this.filterTimer=null),this.filterTimer=setTimeout((function(){try{n.flowFilter=new gr(e),t(null)}catch(r){n.flowFilter=void 0,t(r)}}),300)}},{key:"clear",value:function(){this.items=[],this._map=new Map}}]),e}(),kr=function(){function e(){r(this,e),this._map=void 0,this._map=new Map}return i(e,[{key:"get",value:function(e){return this._map.get(e)}},{key:"add",value:function(e,t){this._map.set(e,t)}},{key:"delete",value:function(e){this._map.delete(e)}}]),e}(),Sr=[1,1,2,2,4,4,8,8,16,16,32,32],Er=function(t){u(a,t);var n=p(a);function a(t){var o;return r(this,a),(o=n.call(this,t)).connMgr=void 0,o.flowMgr=void 0,o.ws=void 0,o.wsUnmountClose=void 0,o.tableBottomRef=void 0,o.wsReconnCount=-1,o.connMgr=new kr,o.flowMgr=new xr,o.state={flows:o.flowMgr.showList(),flow:null,wsStatus:"close",filterInvalid:!1},o.ws=null,o.wsUnmountClose=!1,o.tableBottomRef=e.createRef(),o}return i(a,[{key:"componentDidMount",value:function(){this.initWs()}},{key:"componentWillUnmount",value:function(){this.ws&&(this.wsUnmountClose=!0,this.ws.close(),this.ws=null)}},{key:"initWs",value:function(){var e,t=this;this.ws||(this.setState({wsStatus:"connecting"}),e=new URL(document.URL).host,this.ws=new WebSocket("ws://".concat(e,"/echo")),this.ws.binaryType="arraybuffer",this.ws.onopen=function(){t.wsReconnCount=-1,t.setState({wsStatus:"open"})},this.ws.onerror=function(e){var n;console.error("ERROR:",e),null===(n=t.ws)||void 0===n||n.close()},this.ws.onclose=function(){if(t.setState({wsStatus:"close"}),!t.wsUnmountClose){t.wsReconnCount++,t.ws=null;var e=Sr[t.wsReconnCount]||Sr[Sr.length-1];console.info("will reconnect after ".concat(e," seconds")),setTimeout((function(){t.initWs()}),1e3*e)}},this.ws.onmessage=function(e){var n=function(e){if(e.byteLength<4)return null;var t=new DataView(e);if(3!==t.getUint8(0))return null;var n=t.getUint8(1);if(!cr.includes(n))return null;var s=4+t.getUint16(2,!1),c=s+1;if(e.byteLength<c)return null;var r={type:n,id:(new TextDecoder).decode(e.slice(4,s)),waitIntercept:1===t.getUint8(s)};if(c===e.byteLength)return r;if(n===or.REQUEST_BODY||n===or.RESPONSE_BODY)return r.content=e.slice(c),r;if(n===or.CONN_CLOSE){var a=new DataView(e.slice(c));return r.content=a.getUint32(0,!1),r}var o,l=(new TextDecoder).decode(e.slice(c));try{o=JSON.parse(l)}catch(i){return null}return r.content=o,r}(e.data);if(n){if(n.type===or.CONN){var r=n.content;r.intercept&&(r.opening=!0),t.connMgr.add(n.id,r),t.setState({flows:t.state.flows})}else if(n.type===or.CONN_CLOSE){var a=t.connMgr.get(n.id);if(!a)return;a.opening=!1,a.flowCount=n.content,t.setState({flows:t.state.flows}),t.connMgr.delete(n.id)}else if(n.type===or.REQUEST){var o,l=new wr(n,t.connMgr);l.getConn(),t.flowMgr.add(l);var i=!1;null!==(o=t.tableBottomRef)&&void 0!==o&&o.current&&function(e){var t=window.innerWidth||document.documentElement.clientWidth,n=window.innerHeight||document.documentElement.clientHeight,r=e.getBoundingClientRect(),a=r.top,o=r.right,l=r.bottom,i=r.left;return a>=0&&i>=0&&o<=t&&l<=n}(t.tableBottomRef.current)&&(i=!0),t.setState({flows:t.flowMgr.showList()},(function(){var e,n;i&&(null===(e=t.tableBottomRef)||void 0===e||null===(n=e.current)||void 0===n||n.scrollIntoView({behavior:"auto"}))}))}else if(n.type===or.REQUEST_BODY){var s=t.flowMgr.get(n.id);if(!s)return;s.addRequestBody(n),t.setState({flows:t.state.flows})}else if(n.type===or.RESPONSE){var u=t.flowMgr.get(n.id);if(!u)return;u.getConn(),u.addResponse(n),t.setState({flows:t.state.flows})}else if(n.type===or.RESPONSE_BODY){var c=t.flowMgr.get(n.id);if(!c||!c.response)return;c.addResponseBody(n),t.setState({flows:t.state.flows})}}else console.error("parse error:",e.data)})}},{key:"render",value:function(){var e=this,t=this.state.flows;return(0,x.jsxs)("div",{className:"main-table-wrap",children:[(0,x.jsxs)("div",{className:"top-control",children:[(0,x.jsxs)("div",{style:{display:"flex",alignItems:"center"},children:[(0,x.jsx)("div",{style:{marginRight:"10px"},children:(0,x.jsx)(Fe,{size:"sm",onClick:function(){e.flowMgr.clear(),e.setState({flows:e.flowMgr.showList(),flow:null})},children:"Clear"})}),(0,x.jsx)("div",{style:{marginRight:"10px"},children:(0,x.jsx)(Pe.Control,{size:"sm",placeholder:"Filter",style:{width:"350px"},isInvalid:this.state.filterInvalid,onChange:function(t){var n=t.target.value;e.flowMgr.changeFilterLazy(n,(function(t){t&&console.log("changeFilterLazy error",t),e.setState({filterInvalid:!!t,flows:e.flowMgr.showList()})}))}})}),(0,x.jsx)("div",{style:{marginRight:"10px"},children:(0,x.jsx)(jn,{onSave:function(t){var n=function(e,t){if(e!==ur.CHANGE_BREAK_POINT_RULES)throw new Error("invalid message type");var n=(new TextEncoder).encode(JSON.stringify(t)),r=new Uint8Array(2+n.byteLength);return r[0]=3,r[1]=e,r.set(n,2),r}(ur.CHANGE_BREAK_POINT_RULES,t);e.ws&&e.ws.send(n)}})})]}),(0,x.jsxs)("div",{style:{display:"flex",alignItems:"center"},children:[(0,x.jsx)("div",{style:{marginRight:"10px"},children:"open"===this.state.wsStatus?(0,x.jsx)(Ae,{pill:!0,bg:"success",children:"on"}):(0,x.jsx)(Ae,{pill:!0,bg:"danger",children:"off"})}),(0,x.jsx)("a",{href:"https://github.com/lqqyt2423/go-mitmproxy",target:"_blank",rel:"noreferrer",children:(0,x.jsx)("img",{style:{height:"30px"},src:Me,alt:"GitHub Logo"})})]})]}),(0,x.jsxs)("div",{className:"table-wrap-div",children:[(0,x.jsxs)(j,{striped:!0,bordered:!0,size:"sm",style:{tableLayout:"fixed"},children:[(0,x.jsx)("thead",{children:(0,x.jsxs)("tr",{children:[(0,x.jsx)(hr,{width:50,children:"No"}),(0,x.jsx)(hr,{width:80,children:"Method"}),(0,x.jsx)(hr,{width:250,children:"Host"}),(0,x.jsx)(hr,{width:500,children:"Path"}),(0,x.jsx)(hr,{width:150,children:"Type"}),(0,x.jsx)(hr,{width:80,children:"Status"}),(0,x.jsx)(hr,{width:90,children:"Size"}),(0,x.jsx)(hr,{width:90,children:"Time"})]})}),(0,x.jsx)("tbody",{children:t.map((function(t){var n=t.preview();return(0,x.jsx)(Dn,{flow:n,isSelected:!(!e.state.flow||e.state.flow.id!==n.id),onShowDetail:function(){e.setState({flow:t})}},n.id)}))})]}),(0,x.jsx)("div",{ref:this.tableBottomRef,id:"hidden-bottom",style:{height:"0px",visibility:"hidden",marginBottom:"1px"}})]}),(0,x.jsx)(pr,{flow:this.state.flow,onClose:function(){e.setState({flow:null})},onReRenderFlows:function(){e.setState({flows:e.state.flows})},onMessage:function(t){e.ws&&e.ws.send(t)}})]})}}]),a}(e.Component),Cr=Er,Nr=function(e){e&&e instanceof Function&&n.e(496).then(n.bind(n,496)).then((function(t){var n=t.getCLS,r=t.getFID,a=t.getFCP,o=t.getLCP,l=t.getTTFB;n(e),r(e),a(e),o(e),l(e)}))};(0,t.s)(document.getElementById("root")).render((0,x.jsx)(Cr,{})),Nr()}()}();
+//# sourceMappingURL=main.62392d36.js.map
\ No newline at end of file
@@ -1,7 +1,7 @@
 import type { IConnection } from './connection'
 import type { Flow, IFlowRequest, IRequest, IResponse } from './flow'
 
-const MESSAGE_VERSION = 2
+const MESSAGE_VERSION = 3
 
 export enum MessageType {
   CONN = 0,
@@ -28,36 +28,41 @@ export interface IMessage {
   content?: ArrayBuffer | IFlowRequest | IResponse | IConnection | number
 }
 
+// the id is prefixed with its length, its format depends on the IDGenerator of proxy
+
 // type: 0/1/2/3/4
 // messageFlow
-// version 1 byte + type 1 byte + id 36 byte + waitIntercept 1 byte + content left bytes
+// version 1 byte + type 1 byte + id len 2 byte + id bytes + waitIntercept 1 byte + content left bytes
 export const parseMessage = (data: ArrayBuffer): IMessage | null => {
-  if (data.byteLength < 39) return null
-  const meta = new Int8Array(data.slice(0, 39))
-  const version = meta[0]
+  if (data.byteLength < 4) return null
+  const meta = new DataView(data)
+  const version = meta.getUint8(0)
   if (version !== MESSAGE_VERSION) return null
-  const type = meta[1] as MessageType
+  const type = meta.getUint8(1) as MessageType
   if (!allMessageBytes.includes(type)) return null
-  const id = new TextDecoder().decode(data.slice(2, 38))
-  const waitIntercept = meta[38] === 1
+  const idEnd = 4 + meta.getUint16(2, false)
+  const contentStart = idEnd + 1
+  if (data.byteLength < contentStart) return null
+  const id = new TextDecoder().decode(data.slice(4, idEnd))
+  const waitIntercept = meta.getUint8(idEnd) === 1
 
   const resp: IMessage = {
     type,
     id,
     waitIntercept,
   }
-  if (data.byteLength === 39) return resp
+  if (data.byteLength === contentStart) return resp
   if (type === MessageType.REQUEST_BODY || type === MessageType.RESPONSE_BODY) {
-    resp.content = data.slice(39)
+    resp.content = data.slice(contentStart)
     return resp
   }
   if (type === MessageType.CONN_CLOSE) {
-    const view = new DataView(data.slice(39))
+    const view = new DataView(data.slice(contentStart))
     resp.content = view.getUint32(0, false)
     return resp
   }
 
-  const contentStr = new TextDecoder().decode(data.slice(39))
+  const contentStr = new TextDecoder().decode(data.slice(contentStart))
   let content: any
   try {
     content = JSON.parse(contentStr)
@@ -79,13 +84,16 @@ export enum SendMessageType {
 
 // type: 11/12/13/14
 // messageEdit
-// version 1 byte + type 1 byte + id 36 byte + header len 4 byte + header content bytes + body len 4 byte + [body content bytes]
+// version 1 byte + type 1 byte + id len 2 byte + id bytes + header len 4 byte + header content bytes + body len 4 byte + [body content bytes]
 export const buildMessageEdit = (messageType: SendMessageType, flow: Flow) => {
+  const idBytes = new TextEncoder().encode(flow.id)
+  const idEnd = 4 + idBytes.byteLength
   if (messageType === SendMessageType.DROP_REQUEST || messageType === SendMessageType.DROP_RESPONSE) {
-    const view = new Uint8Array(38)
+    const view = new Uint8Array(idEnd)
     view[0] = MESSAGE_VERSION
     view[1] = messageType
-    view.set(new TextEncoder().encode(flow.id), 2)
+    new DataView(view.buffer).setUint16(2, idBytes.byteLength)
+    view.set(idBytes, 4)
     return view
   }
 
@@ -108,18 +116,19 @@ export const buildMessageEdit = (messageType: SendMessageType, flow: Flow) => {
   header.header['Content-Length'] = [String(bodyLen)]
 
   const headerBytes = new TextEncoder().encode(JSON.stringify(header))
-  const len = 2 + 36 + 4 + headerBytes.byteLength + 4 + bodyLen
+  const len = idEnd + 4 + headerBytes.byteLength + 4 + bodyLen
   const data = new ArrayBuffer(len)
   const view = new Uint8Array(data)
   view[0] = MESSAGE_VERSION
   view[1] = messageType
-  view.set(new TextEncoder().encode(flow.id), 2)
-  view.set(headerBytes, 2 + 36 + 4)
-  if (bodyLen) view.set(body as Uint8Array, 2 + 36 + 4 + headerBytes.byteLength + 4)
+  view.set(idBytes, 4)
+  view.set(headerBytes, idEnd + 4)
+  if (bodyLen) view.set(body as Uint8Array, idEnd + 4 + headerBytes.byteLength + 4)
 
   const view2 = new DataView(data)
-  view2.setUint32(2 + 36, headerBytes.byteLength)
-  view2.setUint32(2 + 36 + 4 + headerBytes.byteLength, bodyLen)
+  view2.setUint16(2, idBytes.byteLength)
+  view2.setUint32(idEnd, headerBytes.byteLength)
+  view2.setUint32(idEnd + 4 + headerBytes.byteLength, bodyLen)
 
   return view
 }
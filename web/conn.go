@@ -39,7 +39,7 @@ func (c *concurrentConn) trySendConnMessage(f *proxy.Flow) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	key := f.ConnContext.Id().String()
+	key := f.ConnContext.Id()
 	if send := c.sendConnMessageMap[key]; send {
 		return
 	}
@@ -56,7 +56,7 @@ func (c *concurrentConn) whenConnClose(connCtx *proxy.ConnContext) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	delete(c.sendConnMessageMap, connCtx.Id().String())
+	delete(c.sendConnMessageMap, connCtx.Id())
 
 	msg := newMessageConnClose(connCtx)
 	err := c.conn.WriteMessage(websocket.BinaryMessage, msg.bytes())
@@ -104,7 +104,7 @@ func (c *concurrentConn) readloop() {
 		}
 
 		if msgEdit, ok := msg.(*messageEdit); ok {
-			ch := c.initWaitChan(msgEdit.id.String())
+			ch := c.initWaitChan(msgEdit.id)
 			go func(m *messageEdit, ch chan<- interface{}) {
 				ch <- m
 			}(msgEdit, ch)
@@ -165,7 +165,7 @@ func (c *concurrentConn) isIntercpt(f *proxy.Flow, after *messageFlow) bool {
 
 // 拦截
 func (c *concurrentConn) waitIntercept(f *proxy.Flow, after *messageFlow) {
-	ch := c.initWaitChan(f.Id.String())
+	ch := c.initWaitChan(f.Id)
 	msg := (<-ch).(*messageEdit)
 
 	// drop
@@ -5,27 +5,29 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"errors"
+	"math"
 
 	"github.com/lqqyt2423/go-mitmproxy/proxy"
-	uuid "github.com/satori/go.uuid"
 	log "github.com/sirupsen/logrus"
 )
 
 // message:
 
+// the id is prefixed with its length, its format depends on proxy.Options.IDGenerator
+
 // type: 0/1/2/3/4/5
 // messageFlow
-// version 1 byte + type 1 byte + id 36 byte + waitIntercept 1 byte + content left bytes
+// version 1 byte + type 1 byte + id len 2 byte + id bytes + waitIntercept 1 byte + content left bytes
 
 // type: 11/12/13/14
 // messageEdit
-// version 1 byte + type 1 byte + id 36 byte + header len 4 byte + header content bytes + body len 4 byte + [body content bytes]
+// version 1 byte + type 1 byte + id len 2 byte + id bytes + header len 4 byte + header content bytes + body len 4 byte + [body content bytes]
 
 // type: 21
 // messageMeta
 // version 1 byte + type 1 byte + content left bytes
 
-const messageVersion = 2
+const messageVersion = 3
 
 type messageType byte
 
@@ -74,7 +76,7 @@ type message interface {
 
 type messageFlow struct {
 	mType         messageType
-	id            uuid.UUID
+	id            string
 	waitIntercept byte
 	content       []byte
 }
@@ -88,7 +90,7 @@ func newMessageFlow(mType messageType, f *proxy.Flow) *messageFlow {
 	} else if mType == messageTypeRequest {
 		m := make(map[string]interface{})
 		m["request"] = f.Request
-		m["connId"] = f.ConnContext.Id().String()
+		m["connId"] = f.ConnContext.Id()
 		content, err = json.Marshal(m)
 	} else if mType == messageTypeRequestBody {
 		content = f.Request.Body
@@ -130,7 +132,7 @@ func (m *messageFlow) bytes() []byte {
 	buf := bytes.NewBuffer(make([]byte, 0))
 	buf.WriteByte(byte(messageVersion))
 	buf.WriteByte(byte(m.mType))
-	buf.WriteString(m.id.String()) // len: 36
+	writeMessageId(buf, m.id)
 	buf.WriteByte(m.waitIntercept)
 	buf.Write(m.content)
 	return buf.Bytes()
@@ -138,23 +140,35 @@ func (m *messageFlow) bytes() []byte {
 
 type messageEdit struct {
 	mType    messageType
-	id       uuid.UUID
+	id       string
 	request  *proxy.Request
 	response *proxy.Response
 }
 
+// write the id with its length of 2 bytes
+func writeMessageId(buf *bytes.Buffer, id string) {
+	if len(id) > math.MaxUint16 {
+		log.Warnf("message id longer than %v bytes is truncated", math.MaxUint16)
+		id = id[:math.MaxUint16]
+	}
+	binary.Write(buf, binary.BigEndian, uint16(len(id)))
+	buf.WriteString(id)
+}
+
 func parseMessageEdit(data []byte) *messageEdit {
-	// 2 + 36
-	if len(data) < 38 {
+	// 2 + 2
+	if len(data) < 4 {
 		return nil
 	}
 
 	mType := (messageType)(data[1])
 
-	id, err := uuid.FromString(string(data[2:38]))
-	if err != nil {
+	il := (int)(binary.BigEndian.Uint16(data[2:4]))
+	if 4+il > len(data) {
 		return nil
 	}
+	id := string(data[4 : 4+il])
+	data = data[4+il:]
 
 	msg := &messageEdit{
 		mType: mType,
@@ -165,22 +179,22 @@ func parseMessageEdit(data []byte) *messageEdit {
 		return msg
 	}
 
-	// 2 + 36 + 4 + 4
-	if len(data) < 46 {
+	// the left after id: 4 + 4
+	if len(data) < 8 {
 		return nil
 	}
 
-	hl := (int)(binary.BigEndian.Uint32(data[38:42]))
-	if 42+hl+4 > len(data) {
+	hl := (int)(binary.BigEndian.Uint32(data[0:4]))
+	if 4+hl+4 > len(data) {
 		return nil
 	}
-	headerContent := data[42 : 42+hl]
+	headerContent := data[4 : 4+hl]
 
-	bl := (int)(binary.BigEndian.Uint32(data[42+hl : 42+hl+4]))
-	if 42+hl+4+bl != len(data) {
+	bl := (int)(binary.BigEndian.Uint32(data[4+hl : 4+hl+4]))
+	if 4+hl+4+bl != len(data) {
 		return nil
 	}
-	bodyContent := data[42+hl+4:]
+	bodyContent := data[4+hl+4:]
 
 	if mType == messageTypeChangeRequest {
 		req := new(proxy.Request)
@@ -209,7 +223,7 @@ func (m *messageEdit) bytes() []byte {
 	buf := bytes.NewBuffer(make([]byte, 0))
 	buf.WriteByte(byte(messageVersion))
 	buf.WriteByte(byte(m.mType))
-	buf.WriteString(m.id.String()) // len: 36
+	writeMessageId(buf, m.id)
 
 	if m.mType == messageTypeChangeRequest {
 		headerContent, err := json.Marshal(m.request)